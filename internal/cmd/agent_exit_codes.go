@@ -6,7 +6,7 @@ import (
 	"sort"
 	"strconv"
 
-	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type AgentExitCodesCmd struct{}
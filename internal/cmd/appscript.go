@@ -9,8 +9,8 @@ import (
 	scriptapi "google.golang.org/api/script/v1"
 
 	"github.com/steipete/gogcli/internal/googleapi"
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 var newAppScriptService = googleapi.NewAppScript
@@ -6,8 +6,8 @@ import (
 	"io"
 	"testing"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 func TestAuthServices_JSON(t *testing.T) {
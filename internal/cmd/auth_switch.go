@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/input"
+	"github.com/steipete/gogcli/internal/secrets"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+var (
+	authSwitchStdin      io.Reader = os.Stdin
+	authSwitchIsTerminal           = func() bool {
+		return term.IsTerminal(int(os.Stdin.Fd())) //nolint:gosec // os file descriptor fits int on supported targets
+	}
+)
+
+// AuthSwitchCmd sets the default account for the OAuth client. The default
+// only applies when neither --account nor GOG_ACCOUNT is set.
+type AuthSwitchCmd struct {
+	Query string `arg:"" name:"query" optional:"" help:"Account email, alias, or fuzzy filter (prompts when several accounts match)"`
+}
+
+type authSwitchCandidate struct {
+	Email   string
+	Aliases []string
+}
+
+func (c authSwitchCandidate) label() string {
+	if len(c.Aliases) == 0 {
+		return c.Email
+	}
+	return fmt.Sprintf("%s (%s)", c.Email, strings.Join(c.Aliases, ", "))
+}
+
+func (c *AuthSwitchCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	client, err := config.NormalizeClientNameOrDefault(flags.Client)
+	if err != nil {
+		return err
+	}
+	store, err := openSecretsStore()
+	if err != nil {
+		return err
+	}
+	candidates, err := authSwitchCandidates(store, client)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		return usagef("no stored accounts for client %s (run `gog auth add <email>`)", client)
+	}
+	previous, _ := store.GetDefaultAccount(client)
+	previous = normalizeEmail(previous)
+
+	email, err := c.pick(ctx, flags, candidates, previous)
+	if err != nil {
+		return err
+	}
+	if err := store.SetDefaultAccount(client, email); err != nil {
+		return err
+	}
+
+	if !outfmt.IsJSON(ctx) && (strings.TrimSpace(flags.Account) != "" || strings.TrimSpace(os.Getenv("GOG_ACCOUNT")) != "") {
+		u.Err().Println("Note: --account/GOG_ACCOUNT still override the default account")
+	}
+	return writeResult(ctx, u,
+		kv("account", email),
+		kv("client", client),
+		kv("previous", previous),
+	)
+}
+
+func (c *AuthSwitchCmd) pick(ctx context.Context, flags *RootFlags, candidates []authSwitchCandidate, current string) (string, error) {
+	query := strings.TrimSpace(c.Query)
+	if query != "" {
+		if resolved, ok, err := resolveAccountAlias(query); err != nil {
+			return "", err
+		} else if ok {
+			query = resolved
+		}
+		for _, cand := range candidates {
+			if cand.Email == normalizeEmail(query) {
+				return cand.Email, nil
+			}
+		}
+	}
+	matches := filterAuthSwitchCandidates(candidates, query)
+	if query != "" && len(matches) == 1 {
+		return matches[0].Email, nil
+	}
+
+	if flags.NoInput || !authSwitchIsTerminal() {
+		if len(matches) == 0 {
+			return "", usagef("no stored account matches %q", query)
+		}
+		return "", usagef("%d accounts match %q; pass an email, alias, or a more specific filter", len(matches), query)
+	}
+
+	u := ui.FromContext(ctx)
+	in := bufio.NewReader(authSwitchStdin)
+	for {
+		if len(matches) == 0 {
+			u.Err().Printf("No account matches %q", query)
+			matches = candidates
+		}
+		for i, cand := range matches {
+			marker := " "
+			if cand.Email == current {
+				marker = "*"
+			}
+			u.Err().Printf("%s %2d) %s", marker, i+1, cand.label())
+		}
+		line, err := input.PromptLineFrom(ctx, "Select account (number, or type to filter; empty to cancel): ", in)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("read selection: %w", err)
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return "", &ExitError{Code: 1, Err: errors.New("cancelled")}
+		}
+		if n, convErr := strconv.Atoi(line); convErr == nil {
+			if n >= 1 && n <= len(matches) {
+				return matches[n-1].Email, nil
+			}
+			u.Err().Printf("Pick a number between 1 and %d", len(matches))
+			continue
+		}
+		query = line
+		matches = filterAuthSwitchCandidates(candidates, query)
+		if len(matches) == 1 {
+			return matches[0].Email, nil
+		}
+	}
+}
+
+// authSwitchCandidates lists the accounts with a stored token for client,
+// with any aliases pointing at them.
+func authSwitchCandidates(store secrets.Store, client string) ([]authSwitchCandidate, error) {
+	tokens, err := store.ListTokens()
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := config.ListAccountAliases()
+	if err != nil {
+		return nil, err
+	}
+	byEmail := map[string]*authSwitchCandidate{}
+	for _, tok := range tokens {
+		email := normalizeEmail(tok.Email)
+		if email == "" || (tok.Client != "" && tok.Client != client) {
+			continue
+		}
+		if _, ok := byEmail[email]; !ok {
+			byEmail[email] = &authSwitchCandidate{Email: email}
+		}
+	}
+	for alias, email := range aliases {
+		if cand, ok := byEmail[normalizeEmail(email)]; ok {
+			cand.Aliases = append(cand.Aliases, alias)
+		}
+	}
+	out := make([]authSwitchCandidate, 0, len(byEmail))
+	for _, cand := range byEmail {
+		sort.Strings(cand.Aliases)
+		out = append(out, *cand)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Email < out[j].Email })
+	return out, nil
+}
+
+// filterAuthSwitchCandidates keeps candidates whose email or alias contains
+// the query's characters in order (case-insensitive), best matches first:
+// prefix, then substring, then scattered subsequence.
+func filterAuthSwitchCandidates(candidates []authSwitchCandidate, query string) []authSwitchCandidate {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return candidates
+	}
+	type scored struct {
+		cand  authSwitchCandidate
+		score int
+	}
+	var hits []scored
+	for _, cand := range candidates {
+		best := -1
+		for _, text := range append([]string{cand.Email}, cand.Aliases...) {
+			best = max(best, fuzzyMatchScore(strings.ToLower(text), query))
+		}
+		if best >= 0 {
+			hits = append(hits, scored{cand, best})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+	out := make([]authSwitchCandidate, 0, len(hits))
+	for _, h := range hits {
+		out = append(out, h.cand)
+	}
+	return out
+}
+
+func fuzzyMatchScore(text, query string) int {
+	switch {
+	case strings.HasPrefix(text, query):
+		return 3
+	case strings.Contains(text, query):
+		return 2
+	}
+	rest := text
+	for _, r := range query {
+		i := strings.IndexRune(rest, r)
+		if i < 0 {
+			return -1
+		}
+		rest = rest[i+len(string(r)):]
+	}
+	return 1
+}
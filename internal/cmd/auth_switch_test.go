@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/secrets"
+)
+
+func setupAuthSwitchTest(t *testing.T, stdin string, tty bool) *memSecretsStore {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+	t.Setenv("GOG_ACCOUNT", "")
+
+	store := newMemSecretsStore()
+	for _, email := range []string{"me@work.com", "me@gmail.com", "ops@client.io"} {
+		if err := store.SetToken("", email, secrets.Token{RefreshToken: "rt"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.SetToken("other", "elsewhere@x.com", secrets.Token{RefreshToken: "rt"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SetAccountAlias("client", "ops@client.io"); err != nil {
+		t.Fatal(err)
+	}
+
+	origOpen, origStdin, origTTY := openSecretsStore, authSwitchStdin, authSwitchIsTerminal
+	t.Cleanup(func() {
+		openSecretsStore, authSwitchStdin, authSwitchIsTerminal = origOpen, origStdin, origTTY
+	})
+	openSecretsStore = func() (secrets.Store, error) { return store, nil }
+	authSwitchStdin = strings.NewReader(stdin)
+	authSwitchIsTerminal = func() bool { return tty }
+	return store
+}
+
+func TestAuthSwitchCmd_Query(t *testing.T) {
+	store := setupAuthSwitchTest(t, "", false)
+	flags := &RootFlags{}
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &AuthSwitchCmd{}, []string{"gmail"}, newDocsJSONContext(t), flags); err != nil {
+			t.Fatalf("switch gmail: %v", err)
+		}
+	})
+	if store.defaults[config.DefaultClientName] != "me@gmail.com" {
+		t.Fatalf("default = %q", store.defaults[config.DefaultClientName])
+	}
+
+	// Aliases resolve exactly; fuzzy filters also match alias names.
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &AuthSwitchCmd{}, []string{"client"}, newDocsJSONContext(t), flags); err != nil {
+			t.Fatalf("switch alias: %v", err)
+		}
+	})
+	if store.defaults[config.DefaultClientName] != "ops@client.io" {
+		t.Fatalf("default = %q", store.defaults[config.DefaultClientName])
+	}
+
+	// Ambiguous without a terminal: refuse instead of prompting.
+	err := runKong(t, &AuthSwitchCmd{}, []string{"me@"}, newDocsJSONContext(t), flags)
+	if err == nil || !strings.Contains(err.Error(), "2 accounts match") {
+		t.Fatalf("expected ambiguity error, got %v", err)
+	}
+	if err := runKong(t, &AuthSwitchCmd{}, []string{"elsewhere"}, newDocsJSONContext(t), flags); err == nil {
+		t.Fatal("expected accounts of other clients to be excluded")
+	}
+}
+
+func TestAuthSwitchCmd_Interactive(t *testing.T) {
+	store := setupAuthSwitchTest(t, "mw\n", true)
+	out := captureStdout(t, func() {
+		if err := runKong(t, &AuthSwitchCmd{}, nil, newDocsJSONContext(t), &RootFlags{}); err != nil {
+			t.Fatalf("switch: %v", err)
+		}
+	})
+	if store.defaults[config.DefaultClientName] != "me@work.com" || !strings.Contains(out, `"account": "me@work.com"`) {
+		t.Fatalf("default = %q, out %s", store.defaults[config.DefaultClientName], out)
+	}
+
+	store = setupAuthSwitchTest(t, "me\n2\n", true)
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &AuthSwitchCmd{}, nil, newDocsJSONContext(t), &RootFlags{}); err != nil {
+			t.Fatalf("switch: %v", err)
+		}
+	})
+	// "me" matches both me@ addresses (sorted by email); 2 picks the second.
+	if store.defaults[config.DefaultClientName] != "me@work.com" {
+		t.Fatalf("default = %q", store.defaults[config.DefaultClientName])
+	}
+
+	setupAuthSwitchTest(t, "\n", true)
+	if err := runKong(t, &AuthSwitchCmd{}, nil, newDocsJSONContext(t), &RootFlags{}); err == nil || !strings.Contains(err.Error(), "cancelled") {
+		t.Fatalf("expected cancel, got %v", err)
+	}
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/input"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// Undo kinds recorded in the bulk journal; `gog journal undo` knows how to
+// reverse each of them.
+const (
+	bulkUndoGmailUntrash = "gmail.untrash"
+	bulkUndoDriveUntrash = "drive.untrash"
+)
+
+// bulkPreviewSample is how many affected items the preview lists before
+// summarizing the rest.
+const bulkPreviewSample = 5
+
+// bulkDestructiveOp is a trash or delete across many items. Commands describe
+// the run with one of these so that the preview, the confirmation, and the
+// journal look the same for Gmail, Drive, and Calendar.
+type bulkDestructiveOp struct {
+	Op     string // dry-run and journal op, e.g. "gmail.trash"
+	Action string // verb for prompts, e.g. "trash" or "permanently delete"
+	Noun   string // singular item noun, e.g. "message"
+	Items  []bulkItem
+	// Undo names how the journal entry can be reversed; empty when it cannot.
+	Undo string
+	// Confirm asks y/N below the threshold too. Commands that never prompted
+	// before leave it off and only confirm large runs.
+	Confirm bool
+}
+
+type bulkItem struct {
+	ID    string `json:"id"`
+	Label string `json:"label,omitempty"`
+}
+
+func bulkItemsFromIDs(ids []string) []bulkItem {
+	items := make([]bulkItem, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, bulkItem{ID: id})
+	}
+	return items
+}
+
+func (op *bulkDestructiveOp) summary() string {
+	n := len(op.Items)
+	return fmt.Sprintf("%s %d %s%s", op.Action, n, op.Noun, pluralS(n))
+}
+
+// confirmBulkDestructive handles --dry-run (adding the count and a sample to
+// request) and then confirms the run. Up to bulk_confirm_threshold items a
+// plain y/N is enough; larger runs must have their item count typed back.
+// --force skips both.
+func confirmBulkDestructive(ctx context.Context, flags *RootFlags, op *bulkDestructiveOp, request map[string]any) error {
+	n := len(op.Items)
+	if request == nil {
+		request = map[string]any{}
+	}
+	request["count"] = n
+	request["sample"] = op.Items[:min(n, bulkPreviewSample)]
+	if err := dryRunExit(ctx, flags, op.Op, request); err != nil {
+		return err
+	}
+	if n == 0 || flags == nil || flags.Force {
+		return nil
+	}
+
+	threshold := bulkConfirmThreshold()
+	if n < threshold && !op.Confirm {
+		return nil
+	}
+	if !canPrompt(flags) {
+		if n >= threshold {
+			return usagef("refusing to %s without --force (non-interactive; bulk_confirm_threshold is %d)", op.summary(), threshold)
+		}
+		return usagef("refusing to %s without --force (non-interactive)", op.summary())
+	}
+
+	printBulkPreview(ctx, op)
+	if n < threshold {
+		return confirmDestructiveChecked(ctx, flagsWithoutDryRun(flags), op.summary())
+	}
+	line, readErr := input.PromptLine(ctx, fmt.Sprintf("Type %d to %s: ", n, op.summary()))
+	if readErr != nil && !errors.Is(readErr, os.ErrClosed) {
+		if errors.Is(readErr, io.EOF) {
+			return &ExitError{Code: 1, Err: errors.New("cancelled")}
+		}
+		return fmt.Errorf("read confirmation: %w", readErr)
+	}
+	if strings.TrimSpace(line) != strconv.Itoa(n) {
+		return &ExitError{Code: 1, Err: errors.New("cancelled")}
+	}
+	return nil
+}
+
+// bulkPacer spaces the API calls of a guarded run to bulk_max_per_second, so
+// a large trash or delete does not hit rate limits halfway through. A nil
+// pacer does not wait.
+type bulkPacer struct {
+	interval time.Duration
+	next     time.Time
+}
+
+func newBulkPacer() *bulkPacer {
+	rate := config.DefaultBulkMaxPerSecond
+	if cfg, ok := readConfigOptional(); ok && cfg.BulkMaxPerSecond > 0 {
+		rate = cfg.BulkMaxPerSecond
+	}
+	return &bulkPacer{interval: time.Second / time.Duration(rate)}
+}
+
+// wait blocks until the next call may start; the first call never waits.
+func (p *bulkPacer) wait(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+	now := time.Now()
+	if delay := p.next.Sub(now); delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		now = p.next
+	}
+	p.next = now.Add(p.interval)
+	return nil
+}
+
+func bulkConfirmThreshold() int {
+	if cfg, ok := readConfigOptional(); ok && cfg.BulkConfirmThreshold > 0 {
+		return cfg.BulkConfirmThreshold
+	}
+	return config.DefaultBulkConfirmThreshold
+}
+
+func printBulkPreview(ctx context.Context, op *bulkDestructiveOp) {
+	u := ui.FromContext(ctx)
+	if u == nil || len(op.Items) < 2 {
+		return
+	}
+	u.Err().Printf("About to %s:", op.summary())
+	for _, item := range op.Items[:min(len(op.Items), bulkPreviewSample)] {
+		if item.Label != "" {
+			u.Err().Printf("  %s\t%s", item.ID, item.Label)
+		} else {
+			u.Err().Printf("  %s", item.ID)
+		}
+	}
+	if rest := len(op.Items) - bulkPreviewSample; rest > 0 {
+		u.Err().Printf("  … and %d more", rest)
+	}
+}
+
+// bulkJournalEntry is one line of the bulk journal. Undo entries point back
+// at the run they reversed through UndoOf.
+type bulkJournalEntry struct {
+	ID      string     `json:"id"`
+	Time    string     `json:"time"`
+	Account string     `json:"account,omitempty"`
+	Op      string     `json:"op"`
+	Action  string     `json:"action"`
+	Count   int        `json:"count"`
+	Items   []bulkItem `json:"items,omitempty"`
+	Undo    string     `json:"undo,omitempty"`
+	UndoOf  string     `json:"undoOf,omitempty"`
+}
+
+// journalBulkDestructive records the items a run actually changed. The
+// operation already happened, so a journal that cannot be written is only
+// warned about.
+func journalBulkDestructive(ctx context.Context, account string, op *bulkDestructiveOp, done []bulkItem) {
+	if len(done) == 0 {
+		return
+	}
+	err := appendBulkJournal(bulkJournalEntry{
+		Account: account,
+		Op:      op.Op,
+		Action:  op.Action,
+		Count:   len(done),
+		Items:   done,
+		Undo:    op.Undo,
+	})
+	if err != nil {
+		if u := ui.FromContext(ctx); u != nil {
+			u.Err().Printf("warning: %v", err)
+		}
+	}
+}
+
+func appendBulkJournal(entry bulkJournalEntry) error {
+	path, err := config.BulkJournalPath()
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	if entry.ID == "" {
+		entry.ID = strconv.FormatInt(now.UnixNano(), 36)
+	}
+	if entry.Time == "" {
+		entry.Time = now.Format(time.RFC3339)
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode journal entry: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("ensure journal dir: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600) //nolint:gosec // path derived from config dir
+	if err != nil {
+		return fmt.Errorf("open journal: %w", err)
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write journal: %w", err)
+	}
+	return f.Close()
+}
+
+// readBulkJournal loads every entry, oldest first; a missing journal means
+// no entries and torn lines are skipped.
+func readBulkJournal() ([]bulkJournalEntry, error) {
+	path, err := config.BulkJournalPath()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(path) //nolint:gosec // path derived from config dir
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []bulkJournalEntry
+	for _, line := range strings.Split(string(raw), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var e bulkJournalEntry
+		if json.Unmarshal([]byte(line), &e) == nil && e.ID != "" {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
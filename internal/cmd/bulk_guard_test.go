@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+func bulkTestItems(n int) []bulkItem {
+	items := make([]bulkItem, 0, n)
+	for i := 0; i < n; i++ {
+		items = append(items, bulkItem{ID: fmt.Sprintf("m%d", i)})
+	}
+	return items
+}
+
+func TestConfirmBulkDestructive(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	ctx := context.Background()
+	noInput := &RootFlags{NoInput: true}
+
+	small := &bulkDestructiveOp{Op: "gmail.trash", Action: "trash", Noun: "message", Items: bulkTestItems(3)}
+	if err := confirmBulkDestructive(ctx, noInput, small, nil); err != nil {
+		t.Fatalf("small run without Confirm should not prompt: %v", err)
+	}
+	small.Confirm = true
+	if err := confirmBulkDestructive(ctx, noInput, small, nil); err == nil || !strings.Contains(err.Error(), "refusing to trash 3 messages without --force") {
+		t.Fatalf("expected y/N refusal, got %v", err)
+	}
+
+	large := &bulkDestructiveOp{Op: "gmail.trash", Action: "trash", Noun: "message", Items: bulkTestItems(50)}
+	err := confirmBulkDestructive(ctx, noInput, large, nil)
+	if err == nil || !strings.Contains(err.Error(), "bulk_confirm_threshold is 50") || ExitCode(stableExitCode(err)) != 2 {
+		t.Fatalf("expected threshold refusal, got %v", err)
+	}
+	if err := confirmBulkDestructive(ctx, &RootFlags{NoInput: true, Force: true}, large, nil); err != nil {
+		t.Fatalf("--force should skip confirmation: %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		err := confirmBulkDestructive(newDocsJSONContext(t), &RootFlags{DryRun: true, NoInput: true}, large, map[string]any{"query": "older_than:1y"})
+		var exitErr *ExitError
+		if !errors.As(err, &exitErr) || exitErr.Code != 0 {
+			t.Fatalf("expected dry-run exit, got %v", err)
+		}
+	})
+	var payload struct {
+		Request struct {
+			Count  int        `json:"count"`
+			Sample []bulkItem `json:"sample"`
+			Query  string     `json:"query"`
+		} `json:"request"`
+	}
+	if err := json.Unmarshal([]byte(out), &payload); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if payload.Request.Count != 50 || len(payload.Request.Sample) != bulkPreviewSample || payload.Request.Query != "older_than:1y" {
+		t.Fatalf("unexpected dry-run request: %s", out)
+	}
+}
+
+func TestJournalUndoDriveTrash(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var restored []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || !strings.HasPrefix(r.URL.Path, "/files/") {
+			http.NotFound(w, r)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"trashed":false`) {
+			t.Errorf("expected trashed=false, got %s", body)
+		}
+		restored = append(restored, strings.TrimPrefix(r.URL.Path, "/files/"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "x", "trashed": false})
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	ctx := newDocsJSONContext(t)
+
+	dedupe := &bulkDestructiveOp{Op: "drive.dedupe", Action: "trash", Noun: "duplicate file", Undo: bulkUndoDriveUntrash}
+	journalBulkDestructive(ctx, "a@b.com", dedupe, []bulkItem{{ID: "f1"}, {ID: "f2"}})
+	journalBulkDestructive(ctx, "a@b.com", &bulkDestructiveOp{Op: "calendar.delete", Action: "delete"}, []bulkItem{{ID: "ev1"}})
+
+	entries, err := readBulkJournal()
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected 2 journal entries, got %v (%v)", entries, err)
+	}
+	if err := runKong(t, &JournalUndoCmd{}, []string{entries[1].ID}, ctx, &RootFlags{}); err == nil || !strings.Contains(err.Error(), "cannot be undone") {
+		t.Fatalf("expected calendar delete to be irreversible, got %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &JournalUndoCmd{}, []string{}, ctx, &RootFlags{}); err != nil {
+			t.Fatalf("undo: %v", err)
+		}
+	})
+	if !strings.Contains(out, `"restored": 2`) || strings.Join(restored, ",") != "f1,f2" {
+		t.Fatalf("unexpected undo: out=%s restored=%v", out, restored)
+	}
+	if err := runKong(t, &JournalUndoCmd{}, []string{}, ctx, &RootFlags{}); err == nil || !strings.Contains(err.Error(), "no journal entry can be undone") {
+		t.Fatalf("expected nothing left to undo, got %v", err)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runKong(t, &JournalListCmd{}, []string{}, ctx, &RootFlags{}); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	var listed struct {
+		Entries []journalListEntry `json:"entries"`
+	}
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(listed.Entries) != 3 || listed.Entries[0].UndoOf != entries[0].ID || !listed.Entries[2].Undone {
+		t.Fatalf("unexpected journal list: %s", out)
+	}
+}
+
+func TestBulkPacer(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := config.WriteConfig(config.File{BulkMaxPerSecond: 20}); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	pacer := newBulkPacer()
+	if pacer.interval != 50*time.Millisecond {
+		t.Fatalf("interval = %v", pacer.interval)
+	}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := pacer.wait(context.Background()); err != nil {
+			t.Fatalf("wait: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("expected three calls to take two intervals, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := pacer.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+	if err := (*bulkPacer)(nil).wait(ctx); err != nil {
+		t.Fatalf("nil pacer should not wait: %v", err)
+	}
+}
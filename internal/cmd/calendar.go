@@ -12,14 +12,21 @@ type CalendarCmd struct {
 	Delete          CalendarDeleteCmd          `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete an event"`
 	FreeBusy        CalendarFreeBusyCmd        `cmd:"" name:"freebusy" help:"Get free/busy"`
 	Respond         CalendarRespondCmd         `cmd:"" name:"respond" aliases:"rsvp,reply" help:"Respond to an event invitation"`
+	RSVPReport      CalendarRSVPReportCmd      `cmd:"" name:"rsvp-report" aliases:"rsvps" help:"Summarize attendee responses and optionally email those who have not replied"`
+	Invites         CalendarInvitesCmd         `cmd:"" name:"invites" aliases:"invitations" help:"Invitations across your calendars: list pending ones and answer them by rule"`
 	ProposeTime     CalendarProposeTimeCmd     `cmd:"" name:"propose-time" help:"Generate URL to propose a new meeting time (browser-only feature)"`
 	Colors          CalendarColorsCmd          `cmd:"" name:"colors" help:"Show calendar colors"`
 	Conflicts       CalendarConflictsCmd       `cmd:"" name:"conflicts" help:"Find conflicts"`
 	Search          CalendarSearchCmd          `cmd:"" name:"search" aliases:"find,query" help:"Search events"`
+	ExportTable     CalendarExportTableCmd     `cmd:"" name:"export-table" help:"Export events as CSV/TSV/JSON rows, one per occurrence"`
+	Stats           CalendarStatsCmd           `cmd:"" name:"stats" help:"Weekly meeting load: meeting hours, average attendees, and focus time share"`
+	FromSheet       CalendarFromSheetCmd       `cmd:"" name:"from-sheet" help:"Create or update events from spreadsheet rows and write event IDs back"`
 	Time            CalendarTimeCmd            `cmd:"" name:"time" help:"Show server time"`
 	Users           CalendarUsersCmd           `cmd:"" name:"users" help:"List workspace users (use their email as calendar ID)"`
+	Rooms           CalendarRoomsCmd           `cmd:"" name:"rooms" aliases:"resources" help:"List bookable rooms (use their email with create --room)"`
 	Team            CalendarTeamCmd            `cmd:"" name:"team" help:"Show events for all members of a Google Group"`
 	FocusTime       CalendarFocusTimeCmd       `cmd:"" name:"focus-time" aliases:"focus" help:"Create a Focus Time block"`
 	OOO             CalendarOOOCmd             `cmd:"" name:"out-of-office" aliases:"ooo" help:"Create an Out of Office event"`
 	WorkingLocation CalendarWorkingLocationCmd `cmd:"" name:"working-location" aliases:"wl" help:"Set working location (home/office/custom)"`
+	WorkingHours    CalendarWorkingHoursCmd    `cmd:"" name:"working-hours" aliases:"wh" help:"Someone's local time, working location, and availability window for cross-timezone scheduling"`
 }
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/api/calendar/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/googleapi"
+)
+
+var newCalendarServiceWithSA = googleapi.NewCalendarWithServiceAccount
+
+// CalendarActAsFlag is embedded by event mutations to act on a calendar
+// other than the account's own.
+type CalendarActAsFlag struct {
+	As string `name:"as" help:"Act on this shared/team calendar ID, or as this user via a domain-wide delegation service account (calendarId may then be 'primary')"`
+}
+
+// calendarLabel names the calendar a mutation targets, for prompts.
+func (f CalendarActAsFlag) calendarLabel(calendarID string) string {
+	if as := strings.TrimSpace(f.As); as != "" && strings.EqualFold(calendarID, primaryCalendarID) {
+		return as
+	}
+	return calendarID
+}
+
+const calendarActAsHint = "ask its owner to share it with \"Make changes to events\", or configure a domain-wide delegation service account with 'gog auth service-account set'"
+
+// resolveCalendarActAs picks the client and calendar for --as. The account's
+// own access is used when it can write to the calendar; otherwise, for a
+// user email and an account with a stored service account key, the service
+// account impersonates that user. calendarID must be 'primary' or name the
+// same calendar.
+func resolveCalendarActAs(ctx context.Context, account string, svc *calendar.Service, calendarID, actAs string) (*calendar.Service, string, error) {
+	prepared, err := prepareCalendarID(actAs, false)
+	if err != nil {
+		return nil, "", err
+	}
+	target, err := resolveCalendarID(ctx, svc, prepared)
+	if err != nil {
+		return nil, "", err
+	}
+	if !strings.EqualFold(calendarID, primaryCalendarID) && !strings.EqualFold(calendarID, target) {
+		resolved, resolveErr := resolveCalendarID(ctx, svc, calendarID)
+		if resolveErr != nil {
+			return nil, "", resolveErr
+		}
+		if !strings.EqualFold(resolved, target) {
+			return nil, "", usagef("calendarId %q conflicts with --as %q (pass 'primary' or the same calendar)", calendarID, actAs)
+		}
+	}
+
+	entry, err := svc.CalendarList.Get(target).Fields("id", "accessRole").Context(ctx).Do()
+	if err == nil && (entry.AccessRole == "owner" || entry.AccessRole == "writer") {
+		return svc, target, nil
+	}
+	if err != nil && !isNotFoundAPIError(err) {
+		return nil, "", err
+	}
+
+	if isCalendarUserID(target) {
+		saPath, pathErr := config.ServiceAccountPath(account)
+		if pathErr != nil {
+			return nil, "", pathErr
+		}
+		if _, statErr := os.Stat(saPath); statErr == nil {
+			delegated, svcErr := newCalendarServiceWithSA(ctx, saPath, target)
+			if svcErr != nil {
+				return nil, "", svcErr
+			}
+			return delegated, primaryCalendarID, nil
+		}
+	}
+
+	if err != nil {
+		return nil, "", fmt.Errorf("calendar %s is not in %s's calendar list; %s", target, account, calendarActAsHint)
+	}
+	return nil, "", fmt.Errorf("%s has only %s access to calendar %s; %s", account, entry.AccessRole, target, calendarActAsHint)
+}
+
+// isCalendarUserID reports whether id looks like a person's primary
+// calendar rather than a group or resource calendar.
+func isCalendarUserID(id string) bool {
+	return strings.Contains(id, "@") && !strings.HasSuffix(strings.ToLower(id), ".calendar.google.com")
+}
+
+// explainCalendarActAsError adds the calendar and identity to permission and
+// not-found errors from mutations made with --as.
+func explainCalendarActAsError(err error, calendarID, actAs string) error {
+	var apiErr *gapi.Error
+	if actAs == "" || !errors.As(err, &apiErr) {
+		return err
+	}
+	switch apiErr.Code {
+	case http.StatusForbidden:
+		return fmt.Errorf("permission denied on calendar %s (--as %s); %s: %w", calendarID, actAs, calendarActAsHint, err)
+	case http.StatusNotFound:
+		return fmt.Errorf("event or calendar not visible on %s (--as %s): %w", calendarID, actAs, err)
+	}
+	return err
+}
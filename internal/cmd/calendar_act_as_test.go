@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+// newCalendarActAsTestService serves CalendarList.Get from roles (missing IDs
+// return 404) and records event deletes by calendar.
+func newCalendarActAsTestService(t *testing.T, roles map[string]string, deleted *[]string) *calendar.Service {
+	t.Helper()
+	svc, cleanup := newCalendarServiceForTest(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/calendar/v3")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "/users/me/calendarList/"):
+			id := strings.TrimPrefix(path, "/users/me/calendarList/")
+			role, ok := roles[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 404, "message": "Not Found"}})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "accessRole": role})
+		case r.Method == http.MethodDelete && strings.HasPrefix(path, "/calendars/"):
+			*deleted = append(*deleted, strings.Split(strings.TrimPrefix(path, "/calendars/"), "/")[0])
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "/calendars/"):
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 403, "message": "Forbidden"}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(cleanup)
+	return svc
+}
+
+func TestCalendarDelete_AsSharedCalendar(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	var deleted []string
+	svc := newCalendarActAsTestService(t, map[string]string{
+		"team@group.calendar.google.com": "writer",
+		"ro@group.calendar.google.com":   "reader",
+	}, &deleted)
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+	flags := &RootFlags{Account: "a@b.com", Force: true}
+
+	if err := runKong(t, &CalendarDeleteCmd{}, []string{"primary", "ev1", "--as", "team@group.calendar.google.com"}, newCalendarJSONContext(t), flags); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "team@group.calendar.google.com" {
+		t.Fatalf("expected delete on team calendar, got %v", deleted)
+	}
+
+	err := runKong(t, &CalendarDeleteCmd{}, []string{"primary", "ev1", "--as", "ro@group.calendar.google.com"}, newCalendarJSONContext(t), flags)
+	if err == nil || !strings.Contains(err.Error(), "only reader access") || !strings.Contains(err.Error(), "Make changes to events") {
+		t.Fatalf("expected read-only error, got %v", err)
+	}
+
+	err = runKong(t, &CalendarDeleteCmd{}, []string{"other@group.calendar.google.com", "ev1", "--as", "team@group.calendar.google.com"}, newCalendarJSONContext(t), flags)
+	if err == nil || !strings.Contains(err.Error(), "conflicts with --as") {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+}
+
+func TestCalendarDelete_AsDelegatedUser(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+
+	origNew, origSA := newCalendarService, newCalendarServiceWithSA
+	t.Cleanup(func() { newCalendarService, newCalendarServiceWithSA = origNew, origSA })
+
+	var ownDeleted, delegatedDeleted []string
+	own := newCalendarActAsTestService(t, map[string]string{}, &ownDeleted)
+	delegated := newCalendarActAsTestService(t, map[string]string{}, &delegatedDeleted)
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return own, nil }
+	var subject string
+	newCalendarServiceWithSA = func(_ context.Context, _ string, impersonate string) (*calendar.Service, error) {
+		subject = impersonate
+		return delegated, nil
+	}
+	flags := &RootFlags{Account: "admin@example.com", Force: true}
+
+	err := runKong(t, &CalendarDeleteCmd{}, []string{"primary", "ev1", "--as", "bob@example.com"}, newCalendarJSONContext(t), flags)
+	if err == nil || !strings.Contains(err.Error(), "not in admin@example.com's calendar list") {
+		t.Fatalf("expected not-shared error without a service account, got %v", err)
+	}
+
+	saPath, err := config.ServiceAccountPath("admin@example.com")
+	if err != nil {
+		t.Fatalf("ServiceAccountPath: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(saPath), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(saPath, []byte("{}"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	if err := runKong(t, &CalendarDeleteCmd{}, []string{"primary", "ev1", "--as", "bob@example.com"}, newCalendarJSONContext(t), flags); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if subject != "bob@example.com" || len(delegatedDeleted) != 1 || delegatedDeleted[0] != "primary" || len(ownDeleted) != 0 {
+		t.Fatalf("expected delegated delete on bob's primary, got subject=%q delegated=%v own=%v", subject, delegatedDeleted, ownDeleted)
+	}
+
+	err = runKong(t, &CalendarRespondCmd{}, []string{"primary", "ev1", "--status", "accepted", "--as", "bob@example.com"}, newCalendarJSONContext(t), flags)
+	if err == nil || !strings.Contains(err.Error(), "permission denied on calendar primary (--as bob@example.com)") {
+		t.Fatalf("expected explained permission error, got %v", err)
+	}
+}
+
+func TestCalendarProposeTime_DeclineAsSharedCalendar(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	var patched []string
+	svc, cleanup := newCalendarServiceForTest(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/calendar/v3")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && path == "/users/me/calendarList/team@group.calendar.google.com":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "team@group.calendar.google.com", "accessRole": "writer"})
+		case strings.HasPrefix(path, "/calendars/"):
+			calID := strings.Split(strings.TrimPrefix(path, "/calendars/"), "/")[0]
+			if r.Method == http.MethodPatch {
+				patched = append(patched, calID)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":        "ev1",
+				"summary":   "Sync",
+				"attendees": []map[string]any{{"email": "me@example.com", "self": true}, {"email": "boss@example.com", "organizer": true}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(cleanup)
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &CalendarProposeTimeCmd{}, []string{"primary", "ev1", "--decline", "--as", "team@group.calendar.google.com"}, newCalendarJSONContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("propose-time: %v", err)
+		}
+	})
+	if len(patched) != 1 || patched[0] != "team@group.calendar.google.com" {
+		t.Fatalf("expected decline patched on the team calendar, got %v", patched)
+	}
+}
@@ -21,6 +21,24 @@ func buildAttendees(csv string) []*calendar.EventAttendee {
 	return out
 }
 
+// buildRoomEmails trims and de-duplicates --room values. Each entry may
+// itself be a comma-separated list.
+func buildRoomEmails(values []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, v := range values {
+		for _, email := range splitCSV(v) {
+			key := strings.ToLower(email)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, email)
+		}
+	}
+	return out
+}
+
 // mergeAttendees preserves existing attendees (with all their metadata like responseStatus)
 // and adds new attendees from the CSV string. Duplicates (by email) are skipped.
 func mergeAttendees(existing []*calendar.EventAttendee, addCSV string) []*calendar.EventAttendee {
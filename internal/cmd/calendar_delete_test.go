@@ -313,3 +313,53 @@ func TestCalendarDeleteCmd_ScopeFuture_InstanceEventID(t *testing.T) {
 		t.Fatalf("unexpected output: %#v", payload)
 	}
 }
+
+func TestCalendarDeleteCmd_ManyEventsUseBulkGuard(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	var deleted []string
+	svc, closeSvc := newCalendarServiceForTest(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/calendar/v3")
+		if r.Method == http.MethodDelete && strings.HasPrefix(path, "/calendars/cal@example.com/events/") {
+			deleted = append(deleted, strings.TrimPrefix(path, "/calendars/cal@example.com/events/"))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer closeSvc()
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	ctx := newCalendarJSONContext(t)
+	args := []string{"cal@example.com", "ev1", "ev2", "ev3", "ev2"}
+
+	err := runKong(t, &CalendarDeleteCmd{}, args, ctx, &RootFlags{Account: "a@b.com", NoInput: true})
+	if err == nil || !strings.Contains(err.Error(), "refusing to delete 3 events without --force") || len(deleted) != 0 {
+		t.Fatalf("expected bulk guard refusal, got %v (deleted %v)", err, deleted)
+	}
+	if err := runKong(t, &CalendarDeleteCmd{}, []string{"cal@example.com", "ev1", "ev2", "--scope", scopeSingle, "--original-start", "2025-01-02T10:00:00Z"}, ctx, &RootFlags{Account: "a@b.com", Force: true}); err == nil || !strings.Contains(err.Error(), "one event at a time") {
+		t.Fatalf("expected scope error, got %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &CalendarDeleteCmd{}, args, ctx, &RootFlags{Account: "a@b.com", Force: true}); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+	})
+	if strings.Join(deleted, ",") != "ev1,ev2,ev3" {
+		t.Fatalf("unexpected deletes: %v", deleted)
+	}
+	var payload struct {
+		Deleted  int      `json:"deleted"`
+		EventIDs []string `json:"eventIds"`
+	}
+	if err := json.Unmarshal([]byte(out), &payload); err != nil || payload.Deleted != 3 || len(payload.EventIDs) != 3 {
+		t.Fatalf("unexpected output %q: %v", out, err)
+	}
+	entries, err := readBulkJournal()
+	if err != nil || len(entries) != 1 || entries[0].Op != "calendar.delete" || entries[0].Count != 3 {
+		t.Fatalf("expected one journal entry for 3 events, got %#v (%v)", entries, err)
+	}
+}
@@ -12,41 +12,44 @@ import (
 )
 
 type CalendarCreateCmd struct {
-	CalendarID            string   `arg:"" name:"calendarId" help:"Calendar ID"`
-	Summary               string   `name:"summary" help:"Event summary/title"`
-	From                  string   `name:"from" help:"Start time (RFC3339)"`
-	To                    string   `name:"to" help:"End time (RFC3339)"`
-	Description           string   `name:"description" help:"Description"`
-	Location              string   `name:"location" help:"Location"`
-	Attendees             string   `name:"attendees" help:"Comma-separated attendee emails"`
-	AllDay                bool     `name:"all-day" help:"All-day event (use date-only in --from/--to)"`
-	Recurrence            []string `name:"rrule" help:"Recurrence rules (e.g., 'RRULE:FREQ=MONTHLY;BYMONTHDAY=11'). Can be repeated." sep:"none"`
-	Reminders             []string `name:"reminder" help:"Custom reminders as method:duration (e.g., popup:30m, email:1d). Can be repeated (max 5)."`
-	ColorId               string   `name:"event-color" help:"Event color ID (1-11). Use 'gog calendar colors' to see available colors."`
-	Visibility            string   `name:"visibility" help:"Event visibility: default, public, private, confidential"`
-	Transparency          string   `name:"transparency" help:"Show as busy (opaque) or free (transparent). Aliases: busy, free"`
-	SendUpdates           string   `name:"send-updates" help:"Notification mode: all, externalOnly, none (default: none)"`
-	GuestsCanInviteOthers *bool    `name:"guests-can-invite" help:"Allow guests to invite others"`
-	GuestsCanModify       *bool    `name:"guests-can-modify" help:"Allow guests to modify event"`
-	GuestsCanSeeOthers    *bool    `name:"guests-can-see-others" help:"Allow guests to see other guests"`
-	WithMeet              bool     `name:"with-meet" help:"Create a Google Meet video conference for this event"`
-	SourceUrl             string   `name:"source-url" help:"URL where event was created/imported from"`
-	SourceTitle           string   `name:"source-title" help:"Title of the source"`
-	Attachments           []string `name:"attachment" help:"File attachment URL (can be repeated)"`
-	PrivateProps          []string `name:"private-prop" help:"Private extended property (key=value, can be repeated)"`
-	SharedProps           []string `name:"shared-prop" help:"Shared extended property (key=value, can be repeated)"`
-	EventType             string   `name:"event-type" help:"Event type: default, focus-time, out-of-office, working-location"`
-	FocusAutoDecline      string   `name:"focus-auto-decline" help:"Focus Time auto-decline mode: none, all, new"`
-	FocusDeclineMessage   string   `name:"focus-decline-message" help:"Focus Time decline message"`
-	FocusChatStatus       string   `name:"focus-chat-status" help:"Focus Time chat status: available, doNotDisturb"`
-	OOOAutoDecline        string   `name:"ooo-auto-decline" help:"Out of Office auto-decline mode: none, all, new"`
-	OOODeclineMessage     string   `name:"ooo-decline-message" help:"Out of Office decline message"`
-	WorkingLocationType   string   `name:"working-location-type" help:"Working location type: home, office, custom"`
-	WorkingOfficeLabel    string   `name:"working-office-label" help:"Working location office name/label"`
-	WorkingBuildingId     string   `name:"working-building-id" help:"Working location building ID"`
-	WorkingFloorId        string   `name:"working-floor-id" help:"Working location floor ID"`
-	WorkingDeskId         string   `name:"working-desk-id" help:"Working location desk ID"`
-	WorkingCustomLabel    string   `name:"working-custom-label" help:"Working location custom label"`
+	CalendarID            string            `arg:"" name:"calendarId" help:"Calendar ID"`
+	Summary               string            `name:"summary" help:"Event summary/title"`
+	From                  string            `name:"from" help:"Start time (RFC3339)"`
+	To                    string            `name:"to" help:"End time (RFC3339)"`
+	Description           string            `name:"description" help:"Description"`
+	Location              string            `name:"location" help:"Location"`
+	Attendees             string            `name:"attendees" help:"Comma-separated attendee emails"`
+	Rooms                 []string          `name:"room" help:"Book a room or resource by its email (see calendar rooms list). Can be repeated."`
+	AllowBusyRoom         bool              `name:"allow-busy-room" help:"Book --room even when free/busy shows it taken"`
+	AllDay                bool              `name:"all-day" help:"All-day event (use date-only in --from/--to)"`
+	Recurrence            []string          `name:"rrule" help:"Recurrence rules (e.g., 'RRULE:FREQ=MONTHLY;BYMONTHDAY=11'). Can be repeated." sep:"none"`
+	Reminders             []string          `name:"reminder" help:"Custom reminders as method:duration (e.g., popup:30m, email:1d). Can be repeated (max 5)."`
+	ColorId               string            `name:"event-color" help:"Event color ID (1-11). Use 'gog calendar colors' to see available colors."`
+	Visibility            string            `name:"visibility" help:"Event visibility: default, public, private, confidential"`
+	Transparency          string            `name:"transparency" help:"Show as busy (opaque) or free (transparent). Aliases: busy, free"`
+	SendUpdates           string            `name:"send-updates" help:"Notification mode: all, externalOnly, none (default: none)"`
+	GuestsCanInviteOthers *bool             `name:"guests-can-invite" help:"Allow guests to invite others"`
+	GuestsCanModify       *bool             `name:"guests-can-modify" help:"Allow guests to modify event"`
+	GuestsCanSeeOthers    *bool             `name:"guests-can-see-others" help:"Allow guests to see other guests"`
+	WithMeet              bool              `name:"with-meet" help:"Create a Google Meet video conference for this event"`
+	SourceUrl             string            `name:"source-url" help:"URL where event was created/imported from"`
+	SourceTitle           string            `name:"source-title" help:"Title of the source"`
+	Attachments           []string          `name:"attachment" help:"File attachment URL (can be repeated)"`
+	PrivateProps          []string          `name:"private-prop" help:"Private extended property (key=value, can be repeated)"`
+	SharedProps           []string          `name:"shared-prop" help:"Shared extended property (key=value, can be repeated)"`
+	EventType             string            `name:"event-type" help:"Event type: default, focus-time, out-of-office, working-location"`
+	FocusAutoDecline      string            `name:"focus-auto-decline" help:"Focus Time auto-decline mode: none, all, new"`
+	FocusDeclineMessage   string            `name:"focus-decline-message" help:"Focus Time decline message"`
+	FocusChatStatus       string            `name:"focus-chat-status" help:"Focus Time chat status: available, doNotDisturb"`
+	OOOAutoDecline        string            `name:"ooo-auto-decline" help:"Out of Office auto-decline mode: none, all, new"`
+	OOODeclineMessage     string            `name:"ooo-decline-message" help:"Out of Office decline message"`
+	WorkingLocationType   string            `name:"working-location-type" help:"Working location type: home, office, custom"`
+	WorkingOfficeLabel    string            `name:"working-office-label" help:"Working location office name/label"`
+	WorkingBuildingId     string            `name:"working-building-id" help:"Working location building ID"`
+	WorkingFloorId        string            `name:"working-floor-id" help:"Working location floor ID"`
+	WorkingDeskId         string            `name:"working-desk-id" help:"Working location desk ID"`
+	WorkingCustomLabel    string            `name:"working-custom-label" help:"Working location custom label"`
+	ActAs                 CalendarActAsFlag `embed:""`
 }
 
 func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -70,10 +73,15 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return dryRunErr
 	}
 
-	mutation, err := newCalendarMutationContext(ctx, flags, calendarID)
+	mutation, err := newCalendarMutationContext(ctx, flags, calendarID, c.ActAs.As)
 	if err != nil {
 		return err
 	}
+	if len(plan.Rooms) > 0 && !c.AllowBusyRoom {
+		if err := checkCalendarRoomsFree(ctx, mutation.svc, plan.Event, plan.Rooms); err != nil {
+			return err
+		}
+	}
 
 	created, err := mutation.insertEvent(ctx, plan.Event, calendarInsertOptions{
 		sendUpdates:         plan.SendUpdates,
@@ -199,41 +207,42 @@ func (c *CalendarCreateCmd) buildOutOfOfficeProperties() (*calendar.EventOutOfOf
 }
 
 type CalendarUpdateCmd struct {
-	CalendarID            string   `arg:"" name:"calendarId" help:"Calendar ID"`
-	EventID               string   `arg:"" name:"eventId" help:"Event ID"`
-	Summary               string   `name:"summary" help:"New summary/title (set empty to clear)"`
-	From                  string   `name:"from" help:"New start time (RFC3339; set empty to clear)"`
-	To                    string   `name:"to" help:"New end time (RFC3339; set empty to clear)"`
-	Description           string   `name:"description" help:"New description (set empty to clear)"`
-	Location              string   `name:"location" help:"New location (set empty to clear)"`
-	Attendees             string   `name:"attendees" help:"Comma-separated attendee emails (replaces all; set empty to clear)"`
-	AddAttendee           string   `name:"add-attendee" help:"Comma-separated attendee emails to add (preserves existing attendees)"`
-	AllDay                bool     `name:"all-day" help:"All-day event (use date-only in --from/--to)"`
-	Recurrence            []string `name:"rrule" help:"Recurrence rules (e.g., 'RRULE:FREQ=MONTHLY;BYMONTHDAY=11'). Can be repeated. Set empty to clear." sep:"none"`
-	Reminders             []string `name:"reminder" help:"Custom reminders as method:duration (e.g., popup:30m, email:1d). Can be repeated (max 5). Set empty to clear."`
-	ColorId               string   `name:"event-color" help:"Event color ID (1-11, or empty to clear)"`
-	Visibility            string   `name:"visibility" help:"Event visibility: default, public, private, confidential"`
-	Transparency          string   `name:"transparency" help:"Show as busy (opaque) or free (transparent). Aliases: busy, free"`
-	GuestsCanInviteOthers *bool    `name:"guests-can-invite" help:"Allow guests to invite others"`
-	GuestsCanModify       *bool    `name:"guests-can-modify" help:"Allow guests to modify event"`
-	GuestsCanSeeOthers    *bool    `name:"guests-can-see-others" help:"Allow guests to see other guests"`
-	Scope                 string   `name:"scope" help:"For recurring events: single, future, all" default:"all"`
-	OriginalStartTime     string   `name:"original-start" help:"Original start time of instance (required for scope=single,future)"`
-	PrivateProps          []string `name:"private-prop" help:"Private extended property (key=value, can be repeated)"`
-	SharedProps           []string `name:"shared-prop" help:"Shared extended property (key=value, can be repeated)"`
-	EventType             string   `name:"event-type" help:"Event type: default, focus-time, out-of-office, working-location"`
-	FocusAutoDecline      string   `name:"focus-auto-decline" help:"Focus Time auto-decline mode: none, all, new"`
-	FocusDeclineMessage   string   `name:"focus-decline-message" help:"Focus Time decline message (set empty to clear)"`
-	FocusChatStatus       string   `name:"focus-chat-status" help:"Focus Time chat status: available, doNotDisturb"`
-	OOOAutoDecline        string   `name:"ooo-auto-decline" help:"Out of Office auto-decline mode: none, all, new"`
-	OOODeclineMessage     string   `name:"ooo-decline-message" help:"Out of Office decline message (set empty to clear)"`
-	WorkingLocationType   string   `name:"working-location-type" help:"Working location type: home, office, custom"`
-	WorkingOfficeLabel    string   `name:"working-office-label" help:"Working location office name/label"`
-	WorkingBuildingId     string   `name:"working-building-id" help:"Working location building ID"`
-	WorkingFloorId        string   `name:"working-floor-id" help:"Working location floor ID"`
-	WorkingDeskId         string   `name:"working-desk-id" help:"Working location desk ID"`
-	WorkingCustomLabel    string   `name:"working-custom-label" help:"Working location custom label"`
-	SendUpdates           string   `name:"send-updates" help:"Notification mode: all, externalOnly, none (default: none)"`
+	CalendarID            string            `arg:"" name:"calendarId" help:"Calendar ID"`
+	EventID               string            `arg:"" name:"eventId" help:"Event ID"`
+	Summary               string            `name:"summary" help:"New summary/title (set empty to clear)"`
+	From                  string            `name:"from" help:"New start time (RFC3339; set empty to clear)"`
+	To                    string            `name:"to" help:"New end time (RFC3339; set empty to clear)"`
+	Description           string            `name:"description" help:"New description (set empty to clear)"`
+	Location              string            `name:"location" help:"New location (set empty to clear)"`
+	Attendees             string            `name:"attendees" help:"Comma-separated attendee emails (replaces all; set empty to clear)"`
+	AddAttendee           string            `name:"add-attendee" help:"Comma-separated attendee emails to add (preserves existing attendees)"`
+	AllDay                bool              `name:"all-day" help:"All-day event (use date-only in --from/--to)"`
+	Recurrence            []string          `name:"rrule" help:"Recurrence rules (e.g., 'RRULE:FREQ=MONTHLY;BYMONTHDAY=11'). Can be repeated. Set empty to clear." sep:"none"`
+	Reminders             []string          `name:"reminder" help:"Custom reminders as method:duration (e.g., popup:30m, email:1d). Can be repeated (max 5). Set empty to clear."`
+	ColorId               string            `name:"event-color" help:"Event color ID (1-11, or empty to clear)"`
+	Visibility            string            `name:"visibility" help:"Event visibility: default, public, private, confidential"`
+	Transparency          string            `name:"transparency" help:"Show as busy (opaque) or free (transparent). Aliases: busy, free"`
+	GuestsCanInviteOthers *bool             `name:"guests-can-invite" help:"Allow guests to invite others"`
+	GuestsCanModify       *bool             `name:"guests-can-modify" help:"Allow guests to modify event"`
+	GuestsCanSeeOthers    *bool             `name:"guests-can-see-others" help:"Allow guests to see other guests"`
+	Scope                 string            `name:"scope" help:"For recurring events: single, future, all" default:"all"`
+	OriginalStartTime     string            `name:"original-start" help:"Original start time of instance (required for scope=single,future)"`
+	PrivateProps          []string          `name:"private-prop" help:"Private extended property (key=value, can be repeated)"`
+	SharedProps           []string          `name:"shared-prop" help:"Shared extended property (key=value, can be repeated)"`
+	EventType             string            `name:"event-type" help:"Event type: default, focus-time, out-of-office, working-location"`
+	FocusAutoDecline      string            `name:"focus-auto-decline" help:"Focus Time auto-decline mode: none, all, new"`
+	FocusDeclineMessage   string            `name:"focus-decline-message" help:"Focus Time decline message (set empty to clear)"`
+	FocusChatStatus       string            `name:"focus-chat-status" help:"Focus Time chat status: available, doNotDisturb"`
+	OOOAutoDecline        string            `name:"ooo-auto-decline" help:"Out of Office auto-decline mode: none, all, new"`
+	OOODeclineMessage     string            `name:"ooo-decline-message" help:"Out of Office decline message (set empty to clear)"`
+	WorkingLocationType   string            `name:"working-location-type" help:"Working location type: home, office, custom"`
+	WorkingOfficeLabel    string            `name:"working-office-label" help:"Working location office name/label"`
+	WorkingBuildingId     string            `name:"working-building-id" help:"Working location building ID"`
+	WorkingFloorId        string            `name:"working-floor-id" help:"Working location floor ID"`
+	WorkingDeskId         string            `name:"working-desk-id" help:"Working location desk ID"`
+	WorkingCustomLabel    string            `name:"working-custom-label" help:"Working location custom label"`
+	SendUpdates           string            `name:"send-updates" help:"Notification mode: all, externalOnly, none (default: none)"`
+	ActAs                 CalendarActAsFlag `embed:""`
 }
 
 func (c *CalendarUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
@@ -297,7 +306,7 @@ func (c *CalendarUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *
 		return dryRunErr
 	}
 
-	mutation, err := newCalendarMutationContext(ctx, flags, calendarID)
+	mutation, err := newCalendarMutationContext(ctx, flags, calendarID, c.ActAs.As)
 	if err != nil {
 		return err
 	}
@@ -776,11 +785,13 @@ func resolveRecurringScope(scopeValue, originalStartTime string) (string, error)
 }
 
 type CalendarDeleteCmd struct {
-	CalendarID        string `arg:"" name:"calendarId" help:"Calendar ID"`
-	EventID           string `arg:"" name:"eventId" help:"Event ID"`
-	Scope             string `name:"scope" help:"For recurring events: single, future, all" default:"all"`
-	OriginalStartTime string `name:"original-start" help:"Original start time of instance (required for scope=single,future)"`
-	SendUpdates       string `name:"send-updates" help:"Notification mode: all, externalOnly, none (default: none)"`
+	CalendarID        string            `arg:"" name:"calendarId" help:"Calendar ID"`
+	EventID           string            `arg:"" name:"eventId" help:"Event ID"`
+	MoreEventIDs      []string          `arg:"" optional:"" name:"moreEventIds" help:"More event IDs to delete in one guarded run (--scope all only)"`
+	Scope             string            `name:"scope" help:"For recurring events: single, future, all" default:"all"`
+	OriginalStartTime string            `name:"original-start" help:"Original start time of instance (required for scope=single,future)"`
+	SendUpdates       string            `name:"send-updates" help:"Notification mode: all, externalOnly, none (default: none)"`
+	ActAs             CalendarActAsFlag `embed:""`
 }
 
 func (c *CalendarDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -804,12 +815,20 @@ func (c *CalendarDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	confirmMessage := fmt.Sprintf("delete event %s from calendar %s", eventID, calendarID)
+	if len(c.MoreEventIDs) > 0 {
+		if scope != scopeAll {
+			return usage("--scope single and future delete one event at a time")
+		}
+		return c.runMany(ctx, flags, calendarID, eventID, sendUpdates)
+	}
+
+	target := c.ActAs.calendarLabel(calendarID)
+	confirmMessage := fmt.Sprintf("delete event %s from calendar %s", eventID, target)
 	if scope == scopeSingle {
-		confirmMessage = fmt.Sprintf("delete event %s (instance start %s) from calendar %s", eventID, c.OriginalStartTime, calendarID)
+		confirmMessage = fmt.Sprintf("delete event %s (instance start %s) from calendar %s", eventID, c.OriginalStartTime, target)
 	}
 	if scope == scopeFuture {
-		confirmMessage = fmt.Sprintf("delete event %s (instance start %s) and all following from calendar %s", eventID, c.OriginalStartTime, calendarID)
+		confirmMessage = fmt.Sprintf("delete event %s (instance start %s) and all following from calendar %s", eventID, c.OriginalStartTime, target)
 	}
 	if confirmErr := dryRunAndConfirmDestructive(ctx, flags, "calendar.delete", map[string]any{
 		"calendar_id":    calendarID,
@@ -821,7 +840,7 @@ func (c *CalendarDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return confirmErr
 	}
 
-	mutation, err := newCalendarMutationContext(ctx, flags, calendarID)
+	mutation, err := newCalendarMutationContext(ctx, flags, calendarID, c.ActAs.As)
 	if err != nil {
 		return err
 	}
@@ -844,9 +863,67 @@ func (c *CalendarDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 			return patchErr
 		}
 	}
+	journalBulkDestructive(ctx, mutation.account, &bulkDestructiveOp{Op: "calendar.delete", Action: "delete"},
+		[]bulkItem{{ID: resolution.TargetEventID, Label: mutation.calendarID}})
 	return writeResult(ctx, u,
 		kv("deleted", true),
 		kv("calendarId", mutation.calendarID),
 		kv("eventId", resolution.TargetEventID),
 	)
 }
+
+// runMany deletes several events through the bulk guard: a sample preview,
+// the typed count at bulk_confirm_threshold, pacing, and the journal.
+func (c *CalendarDeleteCmd) runMany(ctx context.Context, flags *RootFlags, calendarID, firstEventID, sendUpdates string) error {
+	u := ui.FromContext(ctx)
+	ids := []string{firstEventID}
+	seen := map[string]bool{firstEventID: true}
+	for _, raw := range c.MoreEventIDs {
+		id := normalizeCalendarEventID(raw)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	guard := &bulkDestructiveOp{Op: "calendar.delete", Action: "delete", Noun: "event", Confirm: true}
+	for _, id := range ids {
+		guard.Items = append(guard.Items, bulkItem{ID: id, Label: c.ActAs.calendarLabel(calendarID)})
+	}
+	if err := confirmBulkDestructive(ctx, flags, guard, map[string]any{
+		"calendar_id":  calendarID,
+		"event_ids":    ids,
+		"send_updates": sendUpdates,
+	}); err != nil {
+		return err
+	}
+
+	mutation, err := newCalendarMutationContext(ctx, flags, calendarID, c.ActAs.As)
+	if err != nil {
+		return err
+	}
+	for i := range guard.Items {
+		guard.Items[i].Label = mutation.calendarID
+	}
+
+	deleted := 0
+	pacer := newBulkPacer()
+	for _, id := range ids {
+		err := pacer.wait(ctx)
+		if err == nil {
+			err = mutation.deleteEvent(ctx, id, sendUpdates)
+		}
+		if err != nil {
+			journalBulkDestructive(ctx, mutation.account, guard, guard.Items[:deleted])
+			return fmt.Errorf("delete event %s (%d of %d deleted): %w", id, deleted, len(ids), err)
+		}
+		deleted++
+	}
+	journalBulkDestructive(ctx, mutation.account, guard, guard.Items)
+	return writeResult(ctx, u,
+		kv("deleted", deleted),
+		kv("calendarId", mutation.calendarID),
+		kv("eventIds", ids),
+	)
+}
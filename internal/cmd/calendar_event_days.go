@@ -9,12 +9,16 @@ import (
 
 type eventWithDays struct {
 	*calendar.Event
-	StartDayOfWeek string `json:"startDayOfWeek,omitempty"`
-	EndDayOfWeek   string `json:"endDayOfWeek,omitempty"`
-	Timezone       string `json:"timezone,omitempty"`
-	EventTimezone  string `json:"eventTimezone,omitempty"`
-	StartLocal     string `json:"startLocal,omitempty"`
-	EndLocal       string `json:"endLocal,omitempty"`
+	StartDayOfWeek string          `json:"startDayOfWeek,omitempty"`
+	EndDayOfWeek   string          `json:"endDayOfWeek,omitempty"`
+	Timezone       string          `json:"timezone,omitempty"`
+	EventTimezone  string          `json:"eventTimezone,omitempty"`
+	StartLocal     string          `json:"startLocal,omitempty"`
+	EndLocal       string          `json:"endLocal,omitempty"`
+	AlsoTimes      []eventZoneTime `json:"alsoTimezones,omitempty"`
+	// zoned marks events rendered for --tz/--also-tz; only those carry the
+	// computed fields above in JSON.
+	zoned bool
 }
 
 func wrapEventsWithDays(events []*calendar.Event) []*eventWithDays {
@@ -22,6 +22,7 @@ type calendarCreatePlan struct {
 	CalendarID  string
 	SendUpdates string
 	WithMeet    bool
+	Rooms       []string
 	Event       *calendar.Event
 }
 
@@ -96,6 +97,11 @@ func buildCalendarCreatePlan(c *CalendarCreateCmd) (*calendarCreatePlan, error)
 		}
 	}
 
+	rooms := buildRoomEmails(c.Rooms)
+	for _, room := range rooms {
+		event.Attendees = append(event.Attendees, &calendar.EventAttendee{Email: room, Resource: true})
+	}
+
 	if err := c.applyCreateEventType(event, eventType); err != nil {
 		return nil, err
 	}
@@ -104,6 +110,7 @@ func buildCalendarCreatePlan(c *CalendarCreateCmd) (*calendarCreatePlan, error)
 		CalendarID:  strings.TrimSpace(c.CalendarID),
 		SendUpdates: sendUpdates,
 		WithMeet:    c.WithMeet,
+		Rooms:       rooms,
 		Event:       event,
 	}, nil
 }
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// CalendarExportTableCmd flattens events into one row per occurrence for
+// spreadsheets, time tracking, and BI tools.
+type CalendarExportTableCmd struct {
+	CalendarID string   `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
+	Cal        []string `name:"cal" help:"Calendar ID or name (can be repeated; overrides calendarId)"`
+	TimeRangeFlags
+	Format   string `name:"format" help:"Output format: csv|tsv|json" enum:"csv,tsv,json" default:"csv"`
+	Out      string `name:"out" short:"o" help:"Write to this file (defaults to stdout)"`
+	Query    string `name:"query" help:"Free text search"`
+	TZ       string `name:"tz" help:"Timezone for start/end columns (IANA name or 'local'; default: your calendar timezone)"`
+	Declined bool   `name:"include-declined" help:"Include events you declined"`
+}
+
+// calendarExportRow is one expanded occurrence. Field order is column order.
+type calendarExportRow struct {
+	Calendar         string `json:"calendar"`
+	EventID          string `json:"eventId"`
+	RecurringEventID string `json:"recurringEventId,omitempty"`
+	Summary          string `json:"summary"`
+	Start            string `json:"start"`
+	End              string `json:"end"`
+	AllDay           bool   `json:"allDay"`
+	DurationMinutes  int    `json:"durationMinutes"`
+	Status           string `json:"status,omitempty"`
+	Organizer        string `json:"organizer,omitempty"`
+	Location         string `json:"location,omitempty"`
+	Attendees        string `json:"attendees,omitempty"`
+	AttendeeCount    int    `json:"attendeeCount"`
+	ResponseStatus   string `json:"responseStatus,omitempty"`
+	Link             string `json:"link,omitempty"`
+}
+
+var calendarExportColumns = []string{
+	"calendar", "event_id", "recurring_event_id", "summary", "start", "end", "all_day",
+	"duration_minutes", "status", "organizer", "location", "attendees", "attendee_count",
+	"response_status", "link",
+}
+
+func (r calendarExportRow) cells() []string {
+	return []string{
+		r.Calendar, r.EventID, r.RecurringEventID, r.Summary, r.Start, r.End, strconv.FormatBool(r.AllDay),
+		strconv.Itoa(r.DurationMinutes), r.Status, r.Organizer, r.Location, r.Attendees, strconv.Itoa(r.AttendeeCount),
+		r.ResponseStatus, r.Link,
+	}
+}
+
+func (c *CalendarExportTableCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	format := strings.ToLower(strings.TrimSpace(c.Format))
+	if outfmt.IsJSON(ctx) {
+		format = "json"
+	}
+	zone, _, err := parseTimezoneValue("--tz", c.TZ, true)
+	if err != nil {
+		return usage(err.Error())
+	}
+
+	_, svc, err := requireCalendarService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	var calendarIDs []string
+	if len(c.Cal) > 0 {
+		if calendarIDs, err = resolveCalendarIDs(ctx, svc, c.Cal); err != nil {
+			return err
+		}
+	} else {
+		id, resolveErr := resolveCalendarSelector(ctx, svc, c.CalendarID, true)
+		if resolveErr != nil {
+			return resolveErr
+		}
+		calendarIDs = []string{id}
+	}
+
+	timeRange, err := ResolveTimeRangeWithDefaults(ctx, svc, c.TimeRangeFlags, TimeRangeDefaults{
+		FromOffset:   -30 * 24 * time.Hour,
+		ToOffset:     0,
+		ToFromOffset: 30 * 24 * time.Hour,
+	})
+	if err != nil {
+		return err
+	}
+	if zone == nil {
+		zone = timeRange.Location
+	}
+	from, to := timeRange.FormatRFC3339()
+
+	var rows []calendarExportRow
+	for _, calID := range calendarIDs {
+		// SingleEvents expands recurring series into their occurrences.
+		events, listErr := collectAllPages("", func(pageToken string) ([]*calendar.Event, string, error) {
+			resp, err := calendarEventsListCall(ctx, svc, calID, from, to, 2500, c.Query, "", "", "", pageToken).Do()
+			if err != nil {
+				return nil, "", err
+			}
+			return resp.Items, resp.NextPageToken, nil
+		})
+		if listErr != nil {
+			return fmt.Errorf("calendar %s: %w", calID, listErr)
+		}
+		for _, e := range events {
+			if e == nil || e.Status == "cancelled" {
+				continue
+			}
+			row := newCalendarExportRow(calID, e, zone)
+			if row.ResponseStatus == "declined" && !c.Declined {
+				continue
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	outPath := strings.TrimSpace(c.Out)
+	var w io.Writer = os.Stdout
+	if outPath != "" {
+		f, resolved, createErr := createUserOutputFile(outPath)
+		if createErr != nil {
+			return createErr
+		}
+		defer func() { _ = f.Close() }()
+		w, outPath = f, resolved
+	}
+	if err := writeCalendarExportRows(w, format, rows); err != nil {
+		return err
+	}
+	if outPath != "" {
+		u.Err().Printf("Exported %d event%s to %s", len(rows), pluralS(len(rows)), outPath)
+	}
+	return nil
+}
+
+func writeCalendarExportRows(w io.Writer, format string, rows []calendarExportRow) error {
+	bw := bufio.NewWriter(w)
+	switch format {
+	case "json":
+		if rows == nil {
+			rows = []calendarExportRow{}
+		}
+		enc := json.NewEncoder(bw)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(map[string]any{"events": rows, "count": len(rows)}); err != nil {
+			return err
+		}
+	default:
+		cw := csv.NewWriter(bw)
+		if format == "tsv" {
+			cw.Comma = '\t'
+		}
+		if err := cw.Write(calendarExportColumns); err != nil {
+			return err
+		}
+		for _, r := range rows {
+			if err := cw.Write(r.cells()); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// newCalendarExportRow normalizes an event: timed events become RFC3339 in
+// loc, all-day events keep their dates (end exclusive, as in the API).
+func newCalendarExportRow(calendarID string, e *calendar.Event, loc *time.Location) calendarExportRow {
+	row := calendarExportRow{
+		Calendar:         calendarID,
+		EventID:          e.Id,
+		RecurringEventID: e.RecurringEventId,
+		Summary:          e.Summary,
+		Status:           e.Status,
+		Location:         e.Location,
+		Link:             e.HtmlLink,
+		AttendeeCount:    len(e.Attendees),
+	}
+	if e.Organizer != nil {
+		row.Organizer = e.Organizer.Email
+	}
+
+	if e.Start != nil && e.Start.DateTime == "" && e.Start.Date != "" {
+		row.AllDay = true
+		row.Start = e.Start.Date
+		row.End = eventEnd(e)
+		start, okStart := parseEventDate(e.Start.Date, "")
+		end, okEnd := parseEventDate(row.End, "")
+		if okStart && okEnd {
+			row.DurationMinutes = int(end.Sub(start).Minutes())
+		}
+	} else {
+		start, okStart := parseEventTime(eventStart(e), eventTimezone(e))
+		end, okEnd := parseEventTime(eventEnd(e), eventTimezone(e))
+		if okStart {
+			row.Start = start.In(loc).Format(time.RFC3339)
+		}
+		if okEnd {
+			row.End = end.In(loc).Format(time.RFC3339)
+		}
+		if okStart && okEnd {
+			row.DurationMinutes = int(end.Sub(start).Minutes())
+		}
+	}
+
+	attendees := make([]string, 0, len(e.Attendees))
+	for _, a := range e.Attendees {
+		if a == nil {
+			continue
+		}
+		attendees = append(attendees, a.Email+":"+a.ResponseStatus)
+		if a.Self {
+			row.ResponseStatus = a.ResponseStatus
+		}
+	}
+	row.Attendees = strings.Join(attendees, ";")
+	if row.ResponseStatus == "" && e.Organizer != nil && e.Organizer.Self {
+		row.ResponseStatus = "accepted"
+	}
+	return row
+}
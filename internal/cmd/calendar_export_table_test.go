@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func TestNewCalendarExportRow(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata: %v", err)
+	}
+	row := newCalendarExportRow("primary", &calendar.Event{
+		Id:               "ev_20250303",
+		RecurringEventId: "ev",
+		Summary:          "Standup",
+		Start:            &calendar.EventDateTime{DateTime: "2025-03-03T09:00:00Z"},
+		End:              &calendar.EventDateTime{DateTime: "2025-03-03T09:15:00Z"},
+		Organizer:        &calendar.EventOrganizer{Email: "lead@example.com"},
+		Attendees: []*calendar.EventAttendee{
+			{Email: "lead@example.com", ResponseStatus: "accepted"},
+			{Email: "me@example.com", ResponseStatus: "tentative", Self: true},
+		},
+	}, berlin)
+	if row.Start != "2025-03-03T10:00:00+01:00" || row.End != "2025-03-03T10:15:00+01:00" || row.DurationMinutes != 15 {
+		t.Fatalf("unexpected times: %+v", row)
+	}
+	if row.Attendees != "lead@example.com:accepted;me@example.com:tentative" || row.AttendeeCount != 2 || row.ResponseStatus != "tentative" {
+		t.Fatalf("unexpected attendees: %+v", row)
+	}
+
+	allDay := newCalendarExportRow("primary", &calendar.Event{
+		Id:        "off",
+		Start:     &calendar.EventDateTime{Date: "2025-03-10"},
+		End:       &calendar.EventDateTime{Date: "2025-03-12"},
+		Organizer: &calendar.EventOrganizer{Email: "me@example.com", Self: true},
+	}, berlin)
+	if !allDay.AllDay || allDay.Start != "2025-03-10" || allDay.DurationMinutes != 2*24*60 || allDay.ResponseStatus != "accepted" {
+		t.Fatalf("unexpected all-day row: %+v", allDay)
+	}
+}
+
+func TestCalendarExportTableCmd(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	var eventQueries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/calendarList/primary"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "primary", "timeZone": "UTC"})
+		case strings.HasSuffix(r.URL.Path, "/calendars/primary/events"):
+			eventQueries = append(eventQueries, r.URL.RawQuery)
+			if r.URL.Query().Get("pageToken") == "" {
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"items": []map[string]any{
+						{"id": "a_1", "recurringEventId": "a", "summary": "Sync, weekly", "start": map[string]any{"dateTime": "2025-03-03T09:00:00Z"}, "end": map[string]any{"dateTime": "2025-03-03T10:00:00Z"}},
+						{"id": "gone", "status": "cancelled"},
+					},
+					"nextPageToken": "p2",
+				})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{
+				{"id": "a_2", "recurringEventId": "a", "summary": "Sync, weekly", "start": map[string]any{"dateTime": "2025-03-10T09:00:00Z"}, "end": map[string]any{"dateTime": "2025-03-10T10:00:00Z"}},
+				{"id": "no", "summary": "Declined", "start": map[string]any{"dateTime": "2025-03-11T09:00:00Z"}, "end": map[string]any{"dateTime": "2025-03-11T10:00:00Z"},
+					"attendees": []map[string]any{{"email": "a@b.com", "self": true, "responseStatus": "declined"}}},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "calendar", "export-table", "--from", "2025-03-01", "--to", "2025-03-31"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if !strings.Contains(eventQueries[0], "singleEvents=true") {
+		t.Fatalf("expected recurrence expansion, got %s", eventQueries[0])
+	}
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("csv: %v\n%s", err, out)
+	}
+	if len(records) != 3 || records[0][0] != "calendar" || records[1][1] != "a_1" || records[2][1] != "a_2" {
+		t.Fatalf("unexpected rows: %q", records)
+	}
+	if records[1][3] != "Sync, weekly" || records[1][4] != "2025-03-03T09:00:00Z" || records[1][7] != "60" {
+		t.Fatalf("unexpected first row: %q", records[1])
+	}
+
+	out = captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "calendar", "export-table", "--from", "2025-03-01", "--to", "2025-03-31", "--format", "json", "--include-declined"}); err != nil {
+			t.Fatalf("Execute json: %v", err)
+		}
+	})
+	var parsed struct {
+		Events []calendarExportRow `json:"events"`
+		Count  int                 `json:"count"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if parsed.Count != 3 || parsed.Events[2].ResponseStatus != "declined" {
+		t.Fatalf("unexpected json: %s", out)
+	}
+}
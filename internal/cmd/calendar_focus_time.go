@@ -9,14 +9,15 @@ import (
 )
 
 type CalendarFocusTimeCmd struct {
-	CalendarID     string   `arg:"" name:"calendarId" help:"Calendar ID (default: primary)" default:"primary"`
-	Summary        string   `name:"summary" help:"Focus time title" default:"Focus Time"`
-	From           string   `name:"from" required:"" help:"Start time (RFC3339)"`
-	To             string   `name:"to" required:"" help:"End time (RFC3339)"`
-	AutoDecline    string   `name:"auto-decline" help:"Auto-decline mode: none, all, new" default:"all"`
-	DeclineMessage string   `name:"decline-message" help:"Message for declined invitations"`
-	ChatStatus     string   `name:"chat-status" help:"Chat status: available, doNotDisturb" default:"doNotDisturb"`
-	Recurrence     []string `name:"rrule" help:"Recurrence rules. Can be repeated." sep:"none"`
+	CalendarID     string            `arg:"" name:"calendarId" help:"Calendar ID (default: primary)" default:"primary"`
+	Summary        string            `name:"summary" help:"Focus time title" default:"Focus Time"`
+	From           string            `name:"from" required:"" help:"Start time (RFC3339)"`
+	To             string            `name:"to" required:"" help:"End time (RFC3339)"`
+	AutoDecline    string            `name:"auto-decline" help:"Auto-decline mode: none, all, new" default:"all"`
+	DeclineMessage string            `name:"decline-message" help:"Message for declined invitations"`
+	ChatStatus     string            `name:"chat-status" help:"Chat status: available, doNotDisturb" default:"doNotDisturb"`
+	Recurrence     []string          `name:"rrule" help:"Recurrence rules. Can be repeated." sep:"none"`
+	ActAs          CalendarActAsFlag `embed:""`
 }
 
 func (c *CalendarFocusTimeCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -55,7 +56,7 @@ func (c *CalendarFocusTimeCmd) Run(ctx context.Context, flags *RootFlags) error
 		return dryRunErr
 	}
 
-	mutation, err := newCalendarMutationContext(ctx, flags, calendarID)
+	mutation, err := newCalendarMutationContext(ctx, flags, calendarID, c.ActAs.As)
 	if err != nil {
 		return err
 	}
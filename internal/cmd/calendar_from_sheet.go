@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/timeparse"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// CalendarFromSheetCmd creates or updates one event per sheet row and writes
+// the event IDs back, so re-running the command updates instead of
+// duplicating.
+type CalendarFromSheetCmd struct {
+	Source      string            `arg:"" name:"spreadsheetId!range" help:"Spreadsheet ID and sheet or range, e.g. <id>!Plan or <id>!Plan!A1:F50"`
+	Map         string            `name:"map" required:"" help:"Column mapping, e.g. 'title=A,start=B,end=C,attendees=D' (fields: title, start, end, attendees, description, location, id)"`
+	CalendarID  string            `name:"cal" help:"Calendar ID or name" default:"primary"`
+	HeaderRows  int               `name:"header-rows" help:"Rows to skip at the top of the range" default:"1"`
+	Duration    string            `name:"duration" help:"Event length when a row has no end (e.g. 30m, 1h)" default:"1h"`
+	TZ          string            `name:"tz" help:"Timezone for start/end cells without an offset (IANA name or 'local'; default: the calendar's timezone)"`
+	SendUpdates string            `name:"send-updates" help:"Notification mode: all, externalOnly, none (default: none)"`
+	ActAs       CalendarActAsFlag `embed:""`
+}
+
+var calendarFromSheetFields = map[string]string{
+	"title":       "title",
+	"summary":     "title",
+	"start":       "start",
+	"end":         "end",
+	"attendees":   "attendees",
+	"description": "description",
+	"location":    "location",
+	"id":          "id",
+	"event_id":    "id",
+}
+
+type calendarFromSheetRow struct {
+	Row     int             `json:"row"`
+	Action  string          `json:"action"`
+	EventID string          `json:"eventId,omitempty"`
+	Summary string          `json:"summary"`
+	Start   string          `json:"start"`
+	End     string          `json:"end"`
+	event   *calendar.Event `json:"-"`
+}
+
+func (c *CalendarFromSheetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	spreadsheetID, rangeSpec, ok := strings.Cut(cleanRange(strings.TrimSpace(c.Source)), "!")
+	spreadsheetID = normalizeGoogleID(strings.TrimSpace(spreadsheetID))
+	rangeSpec = strings.TrimSpace(rangeSpec)
+	if !ok || spreadsheetID == "" || rangeSpec == "" {
+		return usage("expected <spreadsheetId>!<sheet or range>, e.g. 1AbC...!Plan")
+	}
+	columns, err := parseCalendarFromSheetMap(c.Map)
+	if err != nil {
+		return err
+	}
+	if c.HeaderRows < 0 {
+		return usage("--header-rows must be >= 0")
+	}
+	length, err := time.ParseDuration(strings.TrimSpace(c.Duration))
+	if err != nil || length <= 0 {
+		return usagef("invalid --duration %q (e.g. 30m, 1h)", c.Duration)
+	}
+	sendUpdates, err := validateSendUpdates(c.SendUpdates)
+	if err != nil {
+		return err
+	}
+
+	_, sheetsSvc, err := requireSheetsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	m, err := newCalendarMutationContext(ctx, flags, c.CalendarID, c.ActAs.As)
+	if err != nil {
+		return err
+	}
+	loc, err := c.location(ctx, m)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sheetsSvc.Spreadsheets.Values.Get(spreadsheetID, rangeSpec).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	read, err := parseA1Range(resp.Range)
+	if err != nil {
+		return fmt.Errorf("unexpected range %q from Sheets: %w", resp.Range, err)
+	}
+	firstRow, firstCol := max(read.StartRow, 1), max(read.StartCol, 1)
+
+	rows, err := planCalendarFromSheetRows(resp.Values, columns, firstRow, firstCol, c.HeaderRows, length, loc)
+	if err != nil {
+		return err
+	}
+	idColLetters, _ := colIndexToLetters(columns["id"])
+
+	if err := dryRunExit(ctx, flags, "calendar.from_sheet", map[string]any{
+		"spreadsheet_id": spreadsheetID,
+		"range":          resp.Range,
+		"calendar_id":    m.calendarID,
+		"id_column":      idColLetters,
+		"rows":           rows,
+	}); err != nil {
+		return err
+	}
+
+	// Write back created IDs even when a later row fails, so a re-run
+	// updates those events instead of creating duplicates.
+	var writeBack []*sheets.ValueRange
+	flushWriteBack := func() error {
+		if len(writeBack) == 0 {
+			return nil
+		}
+		_, err := sheetsSvc.Spreadsheets.Values.BatchUpdate(spreadsheetID, &sheets.BatchUpdateValuesRequest{
+			ValueInputOption: "RAW",
+			Data:             writeBack,
+		}).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("writing event IDs to column %s: %w", idColLetters, err)
+		}
+		return nil
+	}
+
+	created, updated := 0, 0
+	for _, row := range rows {
+		var applyErr error
+		switch row.Action {
+		case "create":
+			var ev *calendar.Event
+			ev, applyErr = m.insertEvent(ctx, row.event, calendarInsertOptions{sendUpdates: sendUpdates})
+			if applyErr == nil {
+				row.Action, row.EventID = "created", ev.Id
+				created++
+				writeBack = append(writeBack, &sheets.ValueRange{
+					Range:  fmt.Sprintf("%s%s%d", formatSheetPrefix(read.SheetName), idColLetters, row.Row),
+					Values: [][]interface{}{{ev.Id}},
+				})
+			}
+		case "update":
+			_, applyErr = m.patchEvent(ctx, row.EventID, row.event, sendUpdates)
+			if applyErr == nil {
+				row.Action = "updated"
+				updated++
+			}
+		}
+		if applyErr != nil {
+			if err := flushWriteBack(); err != nil {
+				u.Err().Printf("warning: %v", err)
+			}
+			return fmt.Errorf("row %d: %w", row.Row, applyErr)
+		}
+	}
+	if err := flushWriteBack(); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"calendarId": m.calendarID,
+			"created":    created,
+			"updated":    updated,
+			"rows":       rows,
+		})
+	}
+	if len(rows) == 0 {
+		u.Err().Println("No rows with a title and start")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ROW\tACTION\tEVENT_ID\tSTART\tSUMMARY")
+	for _, row := range rows {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", row.Row, row.Action, row.EventID, row.Start, row.Summary)
+	}
+	return nil
+}
+
+func (c *CalendarFromSheetCmd) location(ctx context.Context, m *calendarMutationContext) (*time.Location, error) {
+	if loc, ok, err := parseTimezoneValue("--tz", c.TZ, true); ok {
+		return loc, err
+	}
+	_, loc, err := getCalendarLocation(ctx, m.svc, m.calendarID)
+	if err != nil || loc == nil {
+		return time.Local, nil //nolint:nilerr // fall back to local time when the calendar timezone is unavailable
+	}
+	return loc, nil
+}
+
+// parseCalendarFromSheetMap turns "title=A,start=B" into field -> 1-based
+// column index. Without an id mapping, IDs go to the column after the last
+// mapped one.
+func parseCalendarFromSheetMap(spec string) (map[string]int, error) {
+	columns := map[string]int{}
+	used := map[int]string{}
+	last := 0
+	for _, pair := range splitCSV(spec) {
+		key, col, ok := strings.Cut(pair, "=")
+		field := calendarFromSheetFields[strings.ToLower(strings.TrimSpace(key))]
+		if !ok || field == "" {
+			return nil, usagef("invalid --map entry %q (fields: title, start, end, attendees, description, location, id)", pair)
+		}
+		idx, err := colLettersToIndex(col)
+		if err != nil {
+			return nil, usagef("invalid --map column for %s: %v", field, err)
+		}
+		if prev, dup := used[idx]; dup && prev != field {
+			return nil, usagef("--map uses column %s for both %s and %s", strings.ToUpper(strings.TrimSpace(col)), prev, field)
+		}
+		columns[field], used[idx] = idx, field
+		last = max(last, idx)
+	}
+	if columns["title"] == 0 || columns["start"] == 0 {
+		return nil, usage("--map needs at least title= and start=")
+	}
+	if columns["id"] == 0 {
+		columns["id"] = last + 1
+	}
+	return columns, nil
+}
+
+func planCalendarFromSheetRows(values [][]interface{}, columns map[string]int, firstRow, firstCol, headerRows int, defaultLength time.Duration, loc *time.Location) ([]*calendarFromSheetRow, error) {
+	rows := []*calendarFromSheetRow{}
+	for i, values := range values {
+		if i < headerRows {
+			continue
+		}
+		rowNum := firstRow + i
+		cell := func(field string) string {
+			idx := columns[field] - firstCol
+			if columns[field] == 0 || idx < 0 || idx >= len(values) {
+				return ""
+			}
+			return strings.TrimSpace(fmt.Sprint(values[idx]))
+		}
+		title, startRaw := cell("title"), cell("start")
+		if title == "" && startRaw == "" {
+			continue
+		}
+		if title == "" || startRaw == "" {
+			return nil, usagef("row %d: title and start are both required", rowNum)
+		}
+		start, end, err := calendarFromSheetTimes(startRaw, cell("end"), defaultLength, loc)
+		if err != nil {
+			return nil, usagef("row %d: %v", rowNum, err)
+		}
+		event := &calendar.Event{
+			Summary:     title,
+			Description: cell("description"),
+			Location:    cell("location"),
+			Start:       start,
+			End:         end,
+			Attendees:   buildAttendees(strings.ReplaceAll(cell("attendees"), "\n", ",")),
+		}
+		row := &calendarFromSheetRow{Row: rowNum, Action: "create", Summary: title, event: event}
+		row.Start, row.End = start.DateTime+start.Date, end.DateTime+end.Date
+		if id := cell("id"); id != "" {
+			row.Action, row.EventID = "update", normalizeCalendarEventID(id)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// calendarFromSheetTimes reads start/end cells. Date-only cells make an
+// all-day event whose end date is inclusive, the way people write them in
+// a plan; the API's exclusive end is the day after.
+func calendarFromSheetTimes(startRaw, endRaw string, defaultLength time.Duration, loc *time.Location) (*calendar.EventDateTime, *calendar.EventDateTime, error) {
+	start, err := timeparse.ParseDateTimeOrDate(startRaw, loc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("start: %w", err)
+	}
+	var end timeparse.ParsedDateTime
+	if endRaw != "" {
+		if end, err = timeparse.ParseDateTimeOrDate(endRaw, loc); err != nil {
+			return nil, nil, fmt.Errorf("end: %w", err)
+		}
+	}
+
+	if !start.HasTime {
+		if endRaw != "" && end.HasTime {
+			return nil, nil, fmt.Errorf("start %q is a date but end %q has a time", startRaw, endRaw)
+		}
+		last := start.Time
+		if endRaw != "" {
+			last = end.Time
+		}
+		if last.Before(start.Time) {
+			return nil, nil, fmt.Errorf("end %q is before start %q", endRaw, startRaw)
+		}
+		return &calendar.EventDateTime{Date: start.Time.Format("2006-01-02")},
+			&calendar.EventDateTime{Date: last.AddDate(0, 0, 1).Format("2006-01-02")}, nil
+	}
+
+	endTime := start.Time.Add(defaultLength)
+	if endRaw != "" {
+		if !end.HasTime {
+			return nil, nil, fmt.Errorf("start %q has a time but end %q is a date", startRaw, endRaw)
+		}
+		endTime = end.Time
+	}
+	if !endTime.After(start.Time) {
+		return nil, nil, fmt.Errorf("end %q is not after start %q", endRaw, startRaw)
+	}
+	tz := ""
+	if loc != time.Local {
+		tz = loc.String()
+	}
+	return &calendar.EventDateTime{DateTime: start.Time.Format(time.RFC3339), TimeZone: tz},
+		&calendar.EventDateTime{DateTime: endTime.Format(time.RFC3339), TimeZone: tz}, nil
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestParseCalendarFromSheetMap(t *testing.T) {
+	cols, err := parseCalendarFromSheetMap("title=A, start=B, end=C, attendees=E")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if cols["title"] != 1 || cols["end"] != 3 || cols["id"] != 6 {
+		t.Fatalf("unexpected columns: %v", cols)
+	}
+	for _, bad := range []string{"title=A", "title=A,start=A", "title=A,start=B,when=C", "title=A,start=1"} {
+		if _, err := parseCalendarFromSheetMap(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestCalendarFromSheetTimes(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+	start, end, err := calendarFromSheetTimes("2025-03-10", "2025-03-11", time.Hour, loc)
+	if err != nil || start.Date != "2025-03-10" || end.Date != "2025-03-12" {
+		t.Fatalf("all-day: %+v %+v %v", start, end, err)
+	}
+	start, end, err = calendarFromSheetTimes("2025-03-10 09:30", "", 30*time.Minute, loc)
+	if err != nil || start.DateTime != "2025-03-10T09:30:00-04:00" || end.DateTime != "2025-03-10T10:00:00-04:00" || start.TimeZone != "America/New_York" {
+		t.Fatalf("timed: %+v %+v %v", start, end, err)
+	}
+	if _, _, err := calendarFromSheetTimes("2025-03-10 09:30", "2025-03-10", time.Hour, loc); err == nil {
+		t.Fatal("expected mixed date/time error")
+	}
+}
+
+func TestCalendarFromSheetCmd(t *testing.T) {
+	origSheets, origCal := newSheetsService, newCalendarService
+	t.Cleanup(func() { newSheetsService, newCalendarService = origSheets, origCal })
+
+	var writes []sheets.ValueRange
+	sheetSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/values/"):
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"range": "Plan!A1:E4",
+				"values": [][]any{
+					{"Title", "Start", "End", "Attendees", "Event ID"},
+					{"Kickoff", "2025-03-10 09:00", "2025-03-10 10:00", "a@example.com, b@example.com"},
+					{},
+					{"Review", "2025-03-12", "", "", "existing1"},
+				},
+			})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/values:batchUpdate"):
+			var req sheets.BatchUpdateValuesRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			for _, d := range req.Data {
+				writes = append(writes, *d)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer sheetSrv.Close()
+
+	var inserted []calendar.Event
+	var patched []string
+	calSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/calendars/primary/events":
+			var ev calendar.Event
+			_ = json.NewDecoder(r.Body).Decode(&ev)
+			inserted = append(inserted, ev)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "new1"})
+		case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/calendars/primary/events/"):
+			_, _ = io.Copy(io.Discard, r.Body)
+			patched = append(patched, strings.TrimPrefix(r.URL.Path, "/calendars/primary/events/"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "existing1"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer calSrv.Close()
+
+	sheetsSvc, err := sheets.NewService(context.Background(), option.WithoutAuthentication(), option.WithHTTPClient(sheetSrv.Client()), option.WithEndpoint(sheetSrv.URL+"/"))
+	if err != nil {
+		t.Fatalf("sheets.NewService: %v", err)
+	}
+	calSvc, err := calendar.NewService(context.Background(), option.WithoutAuthentication(), option.WithHTTPClient(calSrv.Client()), option.WithEndpoint(calSrv.URL+"/"))
+	if err != nil {
+		t.Fatalf("calendar.NewService: %v", err)
+	}
+	newSheetsService = func(context.Context, string) (*sheets.Service, error) { return sheetsSvc, nil }
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return calSvc, nil }
+
+	out := captureStdout(t, func() {
+		err := runKong(t, &CalendarFromSheetCmd{}, []string{"sheet1!Plan", "--map", "title=A,start=B,end=C,attendees=D,id=E", "--tz", "UTC"}, newCalendarJSONContext(t), &RootFlags{Account: "a@b.com"})
+		if err != nil {
+			t.Fatalf("from-sheet: %v", err)
+		}
+	})
+	var result struct {
+		Created int                    `json:"created"`
+		Updated int                    `json:"updated"`
+		Rows    []calendarFromSheetRow `json:"rows"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if result.Created != 1 || result.Updated != 1 || len(result.Rows) != 2 || result.Rows[1].Row != 4 {
+		t.Fatalf("unexpected result: %s", out)
+	}
+	if len(inserted) != 1 || inserted[0].Summary != "Kickoff" || len(inserted[0].Attendees) != 2 || inserted[0].Start.DateTime != "2025-03-10T09:00:00Z" {
+		t.Fatalf("unexpected insert: %+v", inserted)
+	}
+	if len(patched) != 1 || patched[0] != "existing1" {
+		t.Fatalf("unexpected patches: %v", patched)
+	}
+	if len(writes) != 1 || writes[0].Range != "Plan!E2" || writes[0].Values[0][0] != "new1" {
+		t.Fatalf("unexpected write-back: %+v", writes)
+	}
+}
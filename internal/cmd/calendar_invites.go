@@ -0,0 +1,367 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type CalendarInvitesCmd struct {
+	List CalendarInvitesListCmd `cmd:"" name:"list" aliases:"ls" default:"withargs" help:"List invitations across calendars and optionally answer them by rule"`
+}
+
+// CalendarInvitesListCmd lists events where you are an attendee but not the
+// organizer. Rules answer pending invites only; an invite you already
+// answered is never changed. Rules are tried in the order accept, tentative,
+// decline, and the first match wins. A rule is one or more conditions joined
+// by "and":
+//
+//	organizer in (team@, @example.com, boss@example.org)
+//	summary contains standup
+//	calendar = work@example.com
+//
+// Email values ending in "@" match the local part, values starting with "@"
+// match the domain.
+type CalendarInvitesListCmd struct {
+	Cal       []string `name:"cal" help:"Calendar ID or name (can be repeated; default: calendars you own)"`
+	Calendars string   `name:"calendars" help:"Comma-separated calendar IDs, names, or indices from 'calendar calendars'"`
+	TimeRangeFlags
+	Pending bool `name:"pending" help:"Only invites still awaiting your response"`
+
+	AcceptIf    []string `name:"accept-if" help:"Accept pending invites matching this rule (repeatable)" sep:"none"`
+	TentativeIf []string `name:"tentative-if" help:"Answer tentative to pending invites matching this rule (repeatable)" sep:"none"`
+	DeclineIf   []string `name:"decline-if" help:"Decline pending invites matching this rule (repeatable)" sep:"none"`
+	Comment     string   `name:"comment" help:"Comment to include with rule-based responses"`
+}
+
+type calendarInvite struct {
+	CalendarID string `json:"calendarId"`
+	EventID    string `json:"eventId"`
+	Summary    string `json:"summary"`
+	Start      string `json:"start"`
+	Organizer  string `json:"organizer,omitempty"`
+	Status     string `json:"status"`
+	Link       string `json:"link,omitempty"`
+	Response   string `json:"response,omitempty"`
+	Rule       string `json:"rule,omitempty"`
+	Error      string `json:"error,omitempty"`
+
+	event *calendar.Event
+}
+
+type calendarInviteRule struct {
+	status string
+	expr   string
+	conds  []calendarInviteCond
+}
+
+type calendarInviteCond struct {
+	field  string
+	op     string
+	values []string
+}
+
+var (
+	calendarInviteAndRe  = regexp.MustCompile(`(?i)\s+and\s+`)
+	calendarInviteCondRe = regexp.MustCompile(`(?i)^(organizer|summary|calendar)\s+(in|=|contains)\s+(.+)$`)
+)
+
+func (c *CalendarInvitesListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	var rules []calendarInviteRule
+	for _, set := range []struct {
+		status string
+		exprs  []string
+	}{{"accepted", c.AcceptIf}, {"tentative", c.TentativeIf}, {"declined", c.DeclineIf}} {
+		for _, expr := range set.exprs {
+			rule, err := parseCalendarInviteRule(set.status, expr)
+			if err != nil {
+				return err
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	_, svc, err := requireCalendarService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	calendarIDs, err := calendarInviteCalendarIDs(ctx, svc, c.Cal, c.Calendars)
+	if err != nil {
+		return err
+	}
+	timeRange, err := ResolveTimeRangeWithDefaults(ctx, svc, c.TimeRangeFlags, TimeRangeDefaults{
+		FromOffset: 0,
+		ToOffset:   30 * 24 * time.Hour,
+	})
+	if err != nil {
+		return err
+	}
+	from, to := timeRange.FormatRFC3339()
+
+	invites, err := listCalendarInvites(ctx, svc, calendarIDs, from, to, c.Pending)
+	if err != nil {
+		return err
+	}
+	planned := 0
+	for i := range invites {
+		inv := &invites[i]
+		if inv.Status != rsvpNeedsAction {
+			continue
+		}
+		for _, rule := range rules {
+			if rule.matches(inv) {
+				inv.Response, inv.Rule = rule.status, rule.expr
+				planned++
+				break
+			}
+		}
+	}
+
+	if planned > 0 {
+		var actions []map[string]string
+		for _, inv := range invites {
+			if inv.Response != "" {
+				actions = append(actions, map[string]string{"calendar_id": inv.CalendarID, "event_id": inv.EventID, "summary": inv.Summary, "status": inv.Response, "rule": inv.Rule})
+			}
+		}
+		if err := dryRunExit(ctx, flags, "calendar.invites.respond", map[string]any{
+			"responses": actions,
+			"comment":   strings.TrimSpace(c.Comment),
+		}); err != nil {
+			return err
+		}
+	}
+
+	failed := 0
+	for i := range invites {
+		inv := &invites[i]
+		if inv.Response == "" {
+			continue
+		}
+		if err := respondToCalendarInvite(ctx, svc, inv, strings.TrimSpace(c.Comment)); err != nil {
+			inv.Error = err.Error()
+			failed++
+			continue
+		}
+		inv.Status = inv.Response
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"invites":   invites,
+			"responded": planned - failed,
+		}); err != nil {
+			return err
+		}
+	} else if len(invites) == 0 {
+		u.Err().Println("No invitations")
+	} else {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "CALENDAR\tEVENT\tSTART\tSUMMARY\tORGANIZER\tSTATUS\tRULE")
+		for _, inv := range invites {
+			rule := inv.Rule
+			if inv.Error != "" {
+				rule = "failed: " + inv.Error
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", inv.CalendarID, inv.EventID, inv.Start, oneLineTSV(orEmpty(inv.Summary, "(no title)")), inv.Organizer, rsvpStatusLabel(inv.Status), oneLineTSV(rule))
+		}
+		flush()
+		if planned > 0 {
+			u.Err().Printf("Responded to %d invitation%s", planned-failed, pluralS(planned-failed))
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d invitation responses failed", failed, planned)
+	}
+	return nil
+}
+
+// calendarInviteCalendarIDs defaults to calendars you own: on calendars
+// shared with you, the "self" attendee is the calendar's owner, not you.
+func calendarInviteCalendarIDs(ctx context.Context, svc *calendar.Service, cal []string, calendars string) ([]string, error) {
+	if len(collectCalendarInputs(cal, calendars)) > 0 {
+		return resolveSelectedCalendarIDs(ctx, svc, cal, calendars, false, false)
+	}
+	entries, err := listCalendarList(ctx, svc)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, e := range entries {
+		if e != nil && e.AccessRole == "owner" && strings.TrimSpace(e.Id) != "" {
+			ids = append(ids, e.Id)
+		}
+	}
+	if len(ids) == 0 {
+		ids = []string{primaryCalendarID}
+	}
+	return ids, nil
+}
+
+// listCalendarInvites returns events in [from, to) where the calendar's
+// owner is a non-organizer attendee, soonest first. An event on several of
+// the calendars is listed once.
+func listCalendarInvites(ctx context.Context, svc *calendar.Service, calendarIDs []string, from, to string, pendingOnly bool) ([]calendarInvite, error) {
+	seen := map[string]bool{}
+	invites := []calendarInvite{}
+	for _, calID := range calendarIDs {
+		events, err := collectAllPages("", func(pageToken string) ([]*calendar.Event, string, error) {
+			call := svc.Events.List(calID).
+				TimeMin(from).
+				TimeMax(to).
+				SingleEvents(true).
+				OrderBy("startTime").
+				MaxResults(250).
+				Context(ctx)
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+			resp, err := call.Do()
+			if err != nil {
+				return nil, "", err
+			}
+			return resp.Items, resp.NextPageToken, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("calendar %s: %w", calID, err)
+		}
+		for _, e := range events {
+			self := calendarSelfAttendee(e)
+			if self == nil || self.Organizer || e.Status == "cancelled" {
+				continue
+			}
+			status := rsvpStatusOf(self)
+			if pendingOnly && status != rsvpNeedsAction {
+				continue
+			}
+			key := e.ICalUID + "|" + eventStart(e)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			inv := calendarInvite{
+				CalendarID: calID,
+				EventID:    e.Id,
+				Summary:    e.Summary,
+				Start:      eventStart(e),
+				Status:     status,
+				Link:       e.HtmlLink,
+				event:      e,
+			}
+			if e.Organizer != nil {
+				inv.Organizer = orEmpty(e.Organizer.Email, e.Organizer.DisplayName)
+			}
+			invites = append(invites, inv)
+		}
+	}
+	sort.SliceStable(invites, func(i, j int) bool { return invites[i].Start < invites[j].Start })
+	return invites, nil
+}
+
+func calendarSelfAttendee(e *calendar.Event) *calendar.EventAttendee {
+	for _, a := range e.Attendees {
+		if a != nil && a.Self {
+			return a
+		}
+	}
+	return nil
+}
+
+// respondToCalendarInvite sets the self attendee's response. Only the
+// attendee list is patched, as in calendar respond.
+func respondToCalendarInvite(ctx context.Context, svc *calendar.Service, inv *calendarInvite, comment string) error {
+	self := calendarSelfAttendee(inv.event)
+	if self == nil {
+		return fmt.Errorf("not an attendee of %s", inv.EventID)
+	}
+	self.ResponseStatus = inv.Response
+	if comment != "" {
+		self.Comment = comment
+	}
+	_, err := svc.Events.Patch(inv.CalendarID, inv.EventID, &calendar.Event{Attendees: inv.event.Attendees}).
+		Context(ctx).
+		Do()
+	return err
+}
+
+func parseCalendarInviteRule(status, expr string) (calendarInviteRule, error) {
+	rule := calendarInviteRule{status: status, expr: strings.TrimSpace(expr)}
+	for _, part := range calendarInviteAndRe.Split(rule.expr, -1) {
+		m := calendarInviteCondRe.FindStringSubmatch(strings.TrimSpace(part))
+		if m == nil {
+			return calendarInviteRule{}, usagef("invalid rule %q (use e.g. 'organizer in (team@, @example.com)' or 'summary contains standup')", expr)
+		}
+		cond := calendarInviteCond{field: strings.ToLower(m[1]), op: strings.ToLower(m[2])}
+		raw := strings.TrimSpace(m[3])
+		if cond.op == "in" {
+			if !strings.HasPrefix(raw, "(") || !strings.HasSuffix(raw, ")") {
+				return calendarInviteRule{}, usagef("invalid rule %q (in needs a list like (a, b))", expr)
+			}
+			raw = strings.TrimSuffix(strings.TrimPrefix(raw, "("), ")")
+			for _, v := range strings.Split(raw, ",") {
+				if v = strings.Trim(strings.TrimSpace(v), `'"`); v != "" {
+					cond.values = append(cond.values, v)
+				}
+			}
+		} else if v := strings.Trim(raw, `'"`); v != "" {
+			cond.values = []string{v}
+		}
+		if len(cond.values) == 0 {
+			return calendarInviteRule{}, usagef("invalid rule %q (no values)", expr)
+		}
+		rule.conds = append(rule.conds, cond)
+	}
+	return rule, nil
+}
+
+func (r calendarInviteRule) matches(inv *calendarInvite) bool {
+	for _, cond := range r.conds {
+		if !cond.matches(inv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c calendarInviteCond) matches(inv *calendarInvite) bool {
+	var got string
+	switch c.field {
+	case "organizer":
+		got = inv.Organizer
+	case "summary":
+		got = inv.Summary
+	case "calendar":
+		got = inv.CalendarID
+	}
+	got = strings.ToLower(strings.TrimSpace(got))
+	for _, v := range c.values {
+		v = strings.ToLower(v)
+		switch {
+		case c.op == "contains":
+			if strings.Contains(got, v) {
+				return true
+			}
+		case c.field != "summary" && strings.HasSuffix(v, "@"):
+			if strings.HasPrefix(got, v) {
+				return true
+			}
+		case c.field != "summary" && strings.HasPrefix(v, "@"):
+			if strings.HasSuffix(got, v) {
+				return true
+			}
+		case got == v:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func TestCalendarInviteRules(t *testing.T) {
+	inv := &calendarInvite{CalendarID: "me@example.com", Summary: "Weekly Standup", Organizer: "team@example.com"}
+	for expr, want := range map[string]bool{
+		"organizer in (team@)":                                  true,
+		"organizer in (boss@example.com, '@example.com')":       true,
+		"organizer = team@other.com":                            false,
+		"summary contains standup":                              true,
+		"summary contains standup and organizer in (@corp.com)": false,
+		"calendar = ME@example.com":                             true,
+	} {
+		rule, err := parseCalendarInviteRule("accepted", expr)
+		if err != nil {
+			t.Fatalf("%q: %v", expr, err)
+		}
+		if got := rule.matches(inv); got != want {
+			t.Fatalf("%q: got %v want %v", expr, got, want)
+		}
+	}
+
+	for _, bad := range []string{"organizer team@", "location = here", "organizer in team@", "organizer in ()"} {
+		if _, err := parseCalendarInviteRule("accepted", bad); ExitCode(stableExitCode(err)) != 2 {
+			t.Fatalf("%q: expected usage error, got %v", bad, err)
+		}
+	}
+}
+
+func TestCalendarInvitesList_AcceptIf(t *testing.T) {
+	orig := newCalendarService
+	t.Cleanup(func() { newCalendarService = orig })
+
+	var patched map[string]calendar.Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		attendees := func(status string) []any {
+			return []any{
+				map[string]any{"email": "org@example.com", "organizer": true, "responseStatus": "accepted"},
+				map[string]any{"email": "me@example.com", "self": true, "responseStatus": status},
+			}
+		}
+		switch {
+		case strings.Contains(r.URL.Path, "/settings/"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"value": "UTC"})
+		case strings.HasSuffix(r.URL.Path, "/users/me/calendarList"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []any{
+				map[string]any{"id": "me@example.com", "accessRole": "owner"},
+				map[string]any{"id": "holidays", "accessRole": "reader"},
+			}})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/calendars/me@example.com/events"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []any{
+				map[string]any{"id": "e1", "iCalUID": "u1", "summary": "Standup", "start": map[string]any{"dateTime": "2026-01-05T09:00:00Z"},
+					"organizer": map[string]any{"email": "team@example.com"}, "attendees": attendees("needsAction")},
+				map[string]any{"id": "e2", "iCalUID": "u2", "summary": "Vendor pitch", "start": map[string]any{"dateTime": "2026-01-05T10:00:00Z"},
+					"organizer": map[string]any{"email": "sales@vendor.com"}, "attendees": attendees("needsAction")},
+				map[string]any{"id": "e3", "iCalUID": "u3", "summary": "Answered", "start": map[string]any{"dateTime": "2026-01-05T11:00:00Z"},
+					"organizer": map[string]any{"email": "team@example.com"}, "attendees": attendees("accepted")},
+				map[string]any{"id": "e4", "iCalUID": "u4", "summary": "Mine", "start": map[string]any{"dateTime": "2026-01-05T12:00:00Z"},
+					"attendees": []any{map[string]any{"email": "me@example.com", "self": true, "organizer": true, "responseStatus": "accepted"}}},
+			}})
+		case r.Method == http.MethodPatch:
+			var e calendar.Event
+			_ = json.NewDecoder(r.Body).Decode(&e)
+			if patched == nil {
+				patched = map[string]calendar.Event{}
+			}
+			patched[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]] = e
+			_ = json.NewEncoder(w).Encode(e)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "me@example.com", "calendar", "invites", "list", "--pending",
+			"--from", "2026-01-05", "--to", "2026-01-06", "--accept-if", "organizer in (team@)", "--comment", "auto"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if len(patched) != 1 {
+		t.Fatalf("expected one response, got %v", patched)
+	}
+	e1, ok := patched["e1"]
+	if !ok || e1.Attendees[1].ResponseStatus != "accepted" || e1.Attendees[1].Comment != "auto" || e1.Attendees[0].ResponseStatus != "accepted" {
+		t.Fatalf("unexpected patch %+v", patched)
+	}
+	var parsed struct {
+		Invites   []calendarInvite `json:"invites"`
+		Responded int              `json:"responded"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("decode %q: %v", out, err)
+	}
+	if parsed.Responded != 1 || len(parsed.Invites) != 2 || parsed.Invites[0].Status != "accepted" || parsed.Invites[1].Status != rsvpNeedsAction {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
@@ -9,8 +9,9 @@ import (
 	"google.golang.org/api/calendar/v3"
 	gapi "google.golang.org/api/googleapi"
 
-	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/locale"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 func calendarEventsListCall(ctx context.Context, svc *calendar.Service, calendarID, from, to string, maxResults int64, query, privatePropFilter, sharedPropFilter, fields, pageToken string) *calendar.EventsListCall {
@@ -40,7 +41,7 @@ func calendarEventsListCall(ctx context.Context, svc *calendar.Service, calendar
 	return call
 }
 
-func listCalendarEvents(ctx context.Context, svc *calendar.Service, calendarID, from, to string, maxResults int64, page string, allPages bool, failEmpty bool, query, privatePropFilter, sharedPropFilter, fields string, showWeekday bool) error {
+func listCalendarEvents(ctx context.Context, svc *calendar.Service, calendarID, from, to string, maxResults int64, page string, allPages bool, failEmpty bool, query, privatePropFilter, sharedPropFilter, fields string, showWeekday bool, zones calendarDisplayZones) error {
 	fetch := func(pageToken string) ([]*calendar.Event, string, error) {
 		resp, err := calendarEventsListCall(ctx, svc, calendarID, from, to, maxResults, query, privatePropFilter, sharedPropFilter, fields, pageToken).Do()
 		if err != nil {
@@ -66,7 +67,7 @@ func listCalendarEvents(ctx context.Context, svc *calendar.Service, calendarID,
 	}
 	if outfmt.IsJSON(ctx) {
 		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
-			"events":        wrapEventsWithDays(items),
+			"events":        wrapEventsWithZones(items, zones),
 			"nextPageToken": nextPageToken,
 		}); err != nil {
 			return err
@@ -80,20 +81,22 @@ func listCalendarEvents(ctx context.Context, svc *calendar.Service, calendarID,
 	for _, item := range items {
 		events = append(events, &eventWithCalendar{Event: item})
 	}
-	return renderCalendarEventsTable(ctx, events, nextPageToken, false, showWeekday, failEmpty, true)
+	return renderCalendarEventsTable(ctx, events, nextPageToken, false, showWeekday, failEmpty, true, zones)
 }
 
 type eventWithCalendar struct {
 	*calendar.Event
 	CalendarID     string
-	StartDayOfWeek string `json:"startDayOfWeek,omitempty"`
-	EndDayOfWeek   string `json:"endDayOfWeek,omitempty"`
-	Timezone       string `json:"timezone,omitempty"`
-	StartLocal     string `json:"startLocal,omitempty"`
-	EndLocal       string `json:"endLocal,omitempty"`
+	StartDayOfWeek string          `json:"startDayOfWeek,omitempty"`
+	EndDayOfWeek   string          `json:"endDayOfWeek,omitempty"`
+	Timezone       string          `json:"timezone,omitempty"`
+	StartLocal     string          `json:"startLocal,omitempty"`
+	EndLocal       string          `json:"endLocal,omitempty"`
+	AlsoTimes      []eventZoneTime `json:"alsoTimezones,omitempty"`
+	zoned          bool
 }
 
-func listAllCalendarsEvents(ctx context.Context, svc *calendar.Service, from, to string, maxResults int64, page string, allPages bool, failEmpty bool, query, privatePropFilter, sharedPropFilter, fields string, showWeekday bool) error {
+func listAllCalendarsEvents(ctx context.Context, svc *calendar.Service, from, to string, maxResults int64, page string, allPages bool, failEmpty bool, query, privatePropFilter, sharedPropFilter, fields string, showWeekday bool, zones calendarDisplayZones) error {
 	u := ui.FromContext(ctx)
 
 	calendars, err := listCalendarList(ctx, svc)
@@ -117,14 +120,14 @@ func listAllCalendarsEvents(ctx context.Context, svc *calendar.Service, from, to
 		u.Err().Println("No calendars")
 		return nil
 	}
-	return listCalendarIDsEvents(ctx, svc, ids, from, to, maxResults, page, allPages, failEmpty, query, privatePropFilter, sharedPropFilter, fields, showWeekday)
+	return listCalendarIDsEvents(ctx, svc, ids, from, to, maxResults, page, allPages, failEmpty, query, privatePropFilter, sharedPropFilter, fields, showWeekday, zones)
 }
 
-func listSelectedCalendarsEvents(ctx context.Context, svc *calendar.Service, calendarIDs []string, from, to string, maxResults int64, page string, allPages bool, failEmpty bool, query, privatePropFilter, sharedPropFilter, fields string, showWeekday bool) error {
-	return listCalendarIDsEvents(ctx, svc, calendarIDs, from, to, maxResults, page, allPages, failEmpty, query, privatePropFilter, sharedPropFilter, fields, showWeekday)
+func listSelectedCalendarsEvents(ctx context.Context, svc *calendar.Service, calendarIDs []string, from, to string, maxResults int64, page string, allPages bool, failEmpty bool, query, privatePropFilter, sharedPropFilter, fields string, showWeekday bool, zones calendarDisplayZones) error {
+	return listCalendarIDsEvents(ctx, svc, calendarIDs, from, to, maxResults, page, allPages, failEmpty, query, privatePropFilter, sharedPropFilter, fields, showWeekday, zones)
 }
 
-func listCalendarIDsEvents(ctx context.Context, svc *calendar.Service, calendarIDs []string, from, to string, maxResults int64, page string, allPages bool, failEmpty bool, query, privatePropFilter, sharedPropFilter, fields string, showWeekday bool) error {
+func listCalendarIDsEvents(ctx context.Context, svc *calendar.Service, calendarIDs []string, from, to string, maxResults int64, page string, allPages bool, failEmpty bool, query, privatePropFilter, sharedPropFilter, fields string, showWeekday bool, zones calendarDisplayZones) error {
 	u := ui.FromContext(ctx)
 	all := []*eventWithCalendar{}
 	for _, calID := range calendarIDs {
@@ -158,10 +161,13 @@ func listCalendarIDsEvents(ctx context.Context, svc *calendar.Service, calendarI
 		}
 
 		for _, e := range events {
-			startDay, endDay := eventDaysOfWeek(e)
+			startDay, endDay := zones.daysOfWeek(e)
 			evTimezone := eventTimezone(e)
-			startLocal := formatEventLocal(e.Start, nil)
-			endLocal := formatEventLocal(e.End, nil)
+			if zones.Primary != nil {
+				evTimezone = zones.Primary.String()
+			}
+			startLocal := formatEventLocal(e.Start, zones.Primary)
+			endLocal := formatEventLocal(e.End, zones.Primary)
 			all = append(all, &eventWithCalendar{
 				Event:          e,
 				CalendarID:     calID,
@@ -170,6 +176,8 @@ func listCalendarIDsEvents(ctx context.Context, svc *calendar.Service, calendarI
 				Timezone:       evTimezone,
 				StartLocal:     startLocal,
 				EndLocal:       endLocal,
+				AlsoTimes:      zones.alsoTimes(e),
+				zoned:          zones.active(),
 			})
 		}
 	}
@@ -183,10 +191,10 @@ func listCalendarIDsEvents(ctx context.Context, svc *calendar.Service, calendarI
 		}
 		return nil
 	}
-	return renderCalendarEventsTable(ctx, all, "", true, showWeekday, failEmpty, false)
+	return renderCalendarEventsTable(ctx, all, "", true, showWeekday, failEmpty, false, zones)
 }
 
-func renderCalendarEventsTable(ctx context.Context, events []*eventWithCalendar, nextPageToken string, includeCalendar, showWeekday, failEmpty bool, printPageHint bool) error {
+func renderCalendarEventsTable(ctx context.Context, events []*eventWithCalendar, nextPageToken string, includeCalendar, showWeekday, failEmpty bool, printPageHint bool, zones calendarDisplayZones) error {
 	u := ui.FromContext(ctx)
 	if len(events) == 0 {
 		u.Err().Println("No events")
@@ -196,29 +204,33 @@ func renderCalendarEventsTable(ctx context.Context, events []*eventWithCalendar,
 	w, flush := tableWriter(ctx)
 	defer flush()
 
+	also := zones.alsoHeaders()
+	l := locale.FromContext(ctx)
+	start := func(e *calendar.Event) string { return humanEventTime(ctx, zones.start(e)) }
+	end := func(e *calendar.Event) string { return humanEventTime(ctx, zones.end(e)) }
 	if showWeekday {
 		if includeCalendar {
-			fmt.Fprintln(w, "CALENDAR\tID\tSTART\tSTART_DOW\tEND\tEND_DOW\tSUMMARY")
+			fmt.Fprintln(w, "CALENDAR\tID\tSTART\tSTART_DOW\tEND\tEND_DOW"+also+"\tSUMMARY")
 			for _, e := range events {
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.CalendarID, e.Id, eventStart(e.Event), e.StartDayOfWeek, eventEnd(e.Event), e.EndDayOfWeek, e.Summary)
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s%s\t%s\n", e.CalendarID, e.Id, start(e.Event), humanWeekday(ctx, e.StartDayOfWeek), end(e.Event), humanWeekday(ctx, e.EndDayOfWeek), zones.alsoCells(e.Event, l), e.Summary)
 			}
 		} else {
-			fmt.Fprintln(w, "ID\tSTART\tSTART_DOW\tEND\tEND_DOW\tSUMMARY")
+			fmt.Fprintln(w, "ID\tSTART\tSTART_DOW\tEND\tEND_DOW"+also+"\tSUMMARY")
 			for _, e := range events {
-				startDay, endDay := eventDaysOfWeek(e.Event)
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", e.Id, eventStart(e.Event), startDay, eventEnd(e.Event), endDay, e.Summary)
+				startDay, endDay := zones.daysOfWeek(e.Event)
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s%s\t%s\n", e.Id, start(e.Event), humanWeekday(ctx, startDay), end(e.Event), humanWeekday(ctx, endDay), zones.alsoCells(e.Event, l), e.Summary)
 			}
 		}
 	} else {
 		if includeCalendar {
-			fmt.Fprintln(w, "CALENDAR\tID\tSTART\tEND\tSUMMARY")
+			fmt.Fprintln(w, "CALENDAR\tID\tSTART\tEND"+also+"\tSUMMARY")
 			for _, e := range events {
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.CalendarID, e.Id, eventStart(e.Event), eventEnd(e.Event), e.Summary)
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s%s\t%s\n", e.CalendarID, e.Id, start(e.Event), end(e.Event), zones.alsoCells(e.Event, l), e.Summary)
 			}
 		} else {
-			fmt.Fprintln(w, "ID\tSTART\tEND\tSUMMARY")
+			fmt.Fprintln(w, "ID\tSTART\tEND"+also+"\tSUMMARY")
 			for _, e := range events {
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", e.Id, eventStart(e.Event), eventEnd(e.Event), e.Summary)
+				fmt.Fprintf(w, "%s\t%s\t%s%s\t%s\n", e.Id, start(e.Event), end(e.Event), zones.alsoCells(e.Event, l), e.Summary)
 			}
 		}
 	}
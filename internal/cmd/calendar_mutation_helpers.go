@@ -3,17 +3,20 @@ package cmd
 import (
 	"context"
 	"os"
+	"strings"
 
 	"google.golang.org/api/calendar/v3"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type calendarMutationContext struct {
 	u          *ui.UI
 	svc        *calendar.Service
+	account    string
 	calendarID string
+	actAs      string
 }
 
 type calendarInsertOptions struct {
@@ -22,11 +25,18 @@ type calendarInsertOptions struct {
 	supportsAttachments bool
 }
 
-func newCalendarMutationContext(ctx context.Context, flags *RootFlags, calendarID string) (*calendarMutationContext, error) {
-	_, svc, err := requireCalendarService(ctx, flags)
+func newCalendarMutationContext(ctx context.Context, flags *RootFlags, calendarID, actAs string) (*calendarMutationContext, error) {
+	account, svc, err := requireCalendarService(ctx, flags)
 	if err != nil {
 		return nil, err
 	}
+	actAs = strings.TrimSpace(actAs)
+	if actAs != "" {
+		svc, calendarID, err = resolveCalendarActAs(ctx, account, svc, calendarID, actAs)
+		if err != nil {
+			return nil, err
+		}
+	}
 	resolvedCalendarID, err := resolveCalendarID(ctx, svc, calendarID)
 	if err != nil {
 		return nil, err
@@ -34,7 +44,9 @@ func newCalendarMutationContext(ctx context.Context, flags *RootFlags, calendarI
 	return &calendarMutationContext{
 		u:          ui.FromContext(ctx),
 		svc:        svc,
+		account:    account,
 		calendarID: resolvedCalendarID,
+		actAs:      actAs,
 	}, nil
 }
 
@@ -49,7 +61,8 @@ func (m *calendarMutationContext) insertEvent(ctx context.Context, event *calend
 	if opts.supportsAttachments {
 		call = call.SupportsAttachments(true)
 	}
-	return call.Do()
+	created, err := call.Do()
+	return created, m.explain(err)
 }
 
 func (m *calendarMutationContext) patchEvent(ctx context.Context, eventID string, patch *calendar.Event, sendUpdates string) (*calendar.Event, error) {
@@ -57,7 +70,8 @@ func (m *calendarMutationContext) patchEvent(ctx context.Context, eventID string
 	if sendUpdates != "" {
 		call = call.SendUpdates(sendUpdates)
 	}
-	return call.Do()
+	updated, err := call.Do()
+	return updated, m.explain(err)
 }
 
 func (m *calendarMutationContext) deleteEvent(ctx context.Context, eventID, sendUpdates string) error {
@@ -65,7 +79,14 @@ func (m *calendarMutationContext) deleteEvent(ctx context.Context, eventID, send
 	if sendUpdates != "" {
 		call = call.SendUpdates(sendUpdates)
 	}
-	return call.Do()
+	return m.explain(call.Do())
+}
+
+func (m *calendarMutationContext) explain(err error) error {
+	if err == nil {
+		return nil
+	}
+	return explainCalendarActAsError(err, m.calendarID, m.actAs)
 }
 
 func (m *calendarMutationContext) writeEvent(ctx context.Context, event *calendar.Event) error {
@@ -8,13 +8,14 @@ import (
 )
 
 type CalendarOOOCmd struct {
-	CalendarID     string `arg:"" name:"calendarId" help:"Calendar ID (default: primary)" default:"primary"`
-	Summary        string `name:"summary" help:"Out of office title" default:"Out of office"`
-	From           string `name:"from" required:"" help:"Start date or datetime (RFC3339 or YYYY-MM-DD)"`
-	To             string `name:"to" required:"" help:"End date or datetime (RFC3339 or YYYY-MM-DD)"`
-	AutoDecline    string `name:"auto-decline" help:"Auto-decline mode: none, all, new" default:"all"`
-	DeclineMessage string `name:"decline-message" help:"Message for declined invitations" default:"I am out of office and will respond when I return."`
-	AllDay         bool   `name:"all-day" help:"Create as all-day event"`
+	CalendarID     string            `arg:"" name:"calendarId" help:"Calendar ID (default: primary)" default:"primary"`
+	Summary        string            `name:"summary" help:"Out of office title" default:"Out of office"`
+	From           string            `name:"from" required:"" help:"Start date or datetime (RFC3339 or YYYY-MM-DD)"`
+	To             string            `name:"to" required:"" help:"End date or datetime (RFC3339 or YYYY-MM-DD)"`
+	AutoDecline    string            `name:"auto-decline" help:"Auto-decline mode: none, all, new" default:"all"`
+	DeclineMessage string            `name:"decline-message" help:"Message for declined invitations" default:"I am out of office and will respond when I return."`
+	AllDay         bool              `name:"all-day" help:"Create as all-day event"`
+	ActAs          CalendarActAsFlag `embed:""`
 }
 
 func (c *CalendarOOOCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -46,7 +47,7 @@ func (c *CalendarOOOCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return dryRunErr
 	}
 
-	mutation, err := newCalendarMutationContext(ctx, flags, calendarID)
+	mutation, err := newCalendarMutationContext(ctx, flags, calendarID, c.ActAs.As)
 	if err != nil {
 		return err
 	}
@@ -11,8 +11,8 @@ import (
 
 	"google.golang.org/api/calendar/v3"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 const (
@@ -24,11 +24,12 @@ const (
 // CalendarProposeTimeCmd generates a browser URL for proposing a new meeting time.
 // This is a workaround for a Google Calendar API limitation (since 2018).
 type CalendarProposeTimeCmd struct {
-	CalendarID string `arg:"" name:"calendarId" help:"Calendar ID"`
-	EventID    string `arg:"" name:"eventId" help:"Event ID"`
-	Open       bool   `name:"open" help:"Open the URL in browser automatically"`
-	Decline    bool   `name:"decline" help:"Also decline the event (notifies organizer)"`
-	Comment    string `name:"comment" help:"Comment to include with decline (implies --decline)"`
+	CalendarID string            `arg:"" name:"calendarId" help:"Calendar ID"`
+	EventID    string            `arg:"" name:"eventId" help:"Event ID"`
+	Open       bool              `name:"open" help:"Open the URL in browser automatically"`
+	Decline    bool              `name:"decline" help:"Also decline the event (notifies organizer)"`
+	Comment    string            `name:"comment" help:"Comment to include with decline (implies --decline)"`
+	ActAs      CalendarActAsFlag `embed:""`
 }
 
 func (c *CalendarProposeTimeCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -64,20 +65,11 @@ func (c *CalendarProposeTimeCmd) Run(ctx context.Context, flags *RootFlags) erro
 		return dryRunErr
 	}
 
-	account, err := requireAccount(flags)
-	if err != nil {
-		return err
-	}
-
-	svc, err := newCalendarService(ctx, account)
-	if err != nil {
-		return err
-	}
-
-	calendarID, err = resolveCalendarID(ctx, svc, calendarID)
+	mutation, err := newCalendarMutationContext(ctx, flags, calendarID, c.ActAs.As)
 	if err != nil {
 		return err
 	}
+	calendarID = mutation.calendarID
 
 	// Recompute URL in case the user provided a calendar name instead of an ID.
 	payload = eventID + " " + calendarID
@@ -85,9 +77,9 @@ func (c *CalendarProposeTimeCmd) Run(ctx context.Context, flags *RootFlags) erro
 	proposeURL = "https://calendar.google.com/calendar/u/0/r/proposetime/" + encoded
 
 	// Fetch event to display info and verify it exists
-	event, err := svc.Events.Get(calendarID, eventID).Do()
+	event, err := mutation.svc.Events.Get(calendarID, eventID).Context(ctx).Do()
 	if err != nil {
-		return fmt.Errorf("failed to get event: %w", err)
+		return fmt.Errorf("failed to get event: %w", mutation.explain(err))
 	}
 
 	// If declining, update the event response
@@ -120,7 +112,7 @@ func (c *CalendarProposeTimeCmd) Run(ctx context.Context, flags *RootFlags) erro
 			Attendees: event.Attendees,
 		}
 
-		if _, err := svc.Events.Patch(calendarID, eventID, patchEvent).SendUpdates("all").Do(); err != nil {
+		if _, err := mutation.patchEvent(ctx, eventID, patchEvent, "all"); err != nil {
 			return fmt.Errorf("failed to decline event: %w", err)
 		}
 	}
@@ -8,15 +8,16 @@ import (
 
 	"google.golang.org/api/calendar/v3"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type CalendarRespondCmd struct {
-	CalendarID string `arg:"" name:"calendarId" help:"Calendar ID"`
-	EventID    string `arg:"" name:"eventId" help:"Event ID"`
-	Status     string `name:"status" help:"Response status (accepted, declined, tentative, needsAction)"`
-	Comment    string `name:"comment" help:"Optional comment/note to include with response"`
+	CalendarID string            `arg:"" name:"calendarId" help:"Calendar ID"`
+	EventID    string            `arg:"" name:"eventId" help:"Event ID"`
+	Status     string            `name:"status" help:"Response status (accepted, declined, tentative, needsAction)"`
+	Comment    string            `name:"comment" help:"Optional comment/note to include with response"`
+	ActAs      CalendarActAsFlag `embed:""`
 }
 
 func (c *CalendarRespondCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -55,14 +56,14 @@ func (c *CalendarRespondCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return dryRunErr
 	}
 
-	mutation, err := newCalendarMutationContext(ctx, flags, calendarID)
+	mutation, err := newCalendarMutationContext(ctx, flags, calendarID, c.ActAs.As)
 	if err != nil {
 		return err
 	}
 
 	event, err := mutation.svc.Events.Get(mutation.calendarID, eventID).Do()
 	if err != nil {
-		return err
+		return mutation.explain(err)
 	}
 
 	if len(event.Attendees) == 0 {
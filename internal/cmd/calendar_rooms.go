@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+var newAdminResourcesService = googleapi.NewAdminDirectoryResources
+
+type CalendarRoomsCmd struct {
+	List CalendarRoomsListCmd `cmd:"" name:"list" aliases:"ls" default:"withargs" help:"List bookable rooms and resources"`
+}
+
+// CalendarRoomsListCmd lists Workspace calendar resources. Book one by
+// passing its email to calendar create --room.
+type CalendarRoomsListCmd struct {
+	Building  string `name:"building" help:"Only rooms in this building (ID or name)"`
+	Capacity  int64  `name:"capacity" help:"Only rooms that seat at least this many people" default:"0"`
+	Category  string `name:"category" help:"Resource category: CONFERENCE_ROOM, OTHER, or all" default:"CONFERENCE_ROOM"`
+	Max       int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
+	Page      string `name:"page" aliases:"cursor" help:"Page token"`
+	All       bool   `name:"all" aliases:"all-pages,allpages" help:"Fetch all pages"`
+	FailEmpty bool   `name:"fail-empty" aliases:"non-empty,require-results" help:"Exit with code 3 if no results"`
+}
+
+type calendarRoom struct {
+	Email      string `json:"email"`
+	Name       string `json:"name"`
+	ResourceID string `json:"resourceId,omitempty"`
+	Building   string `json:"buildingId,omitempty"`
+	Floor      string `json:"floor,omitempty"`
+	Capacity   int64  `json:"capacity,omitempty"`
+	Category   string `json:"category,omitempty"`
+	Type       string `json:"type,omitempty"`
+	FullName   string `json:"generatedName,omitempty"`
+}
+
+func (c *CalendarRoomsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Capacity < 0 {
+		return usage("--capacity must be >= 0")
+	}
+	category := strings.ToUpper(strings.TrimSpace(c.Category))
+	if category != "" && category != "ALL" && category != "CONFERENCE_ROOM" && category != "OTHER" {
+		return usage("--category must be CONFERENCE_ROOM, OTHER, or all")
+	}
+
+	account, err := requireAdminAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newAdminResourcesService(ctx, account)
+	if err != nil {
+		return wrapAdminResourcesError(err, account)
+	}
+
+	var conditions []string
+	if building := strings.TrimSpace(c.Building); building != "" {
+		buildingID, resolveErr := resolveCalendarBuildingID(ctx, svc, building)
+		if resolveErr != nil {
+			return wrapAdminResourcesError(resolveErr, account)
+		}
+		conditions = append(conditions, "buildingId="+buildingID)
+	}
+	if c.Capacity > 0 {
+		conditions = append(conditions, fmt.Sprintf("capacity>=%d", c.Capacity))
+	}
+	if category != "" && category != "ALL" {
+		conditions = append(conditions, "resourceCategory="+category)
+	}
+	query := strings.Join(conditions, " AND ")
+
+	fetch := func(pageToken string) ([]*admin.CalendarResource, string, error) {
+		call := svc.Resources.Calendars.List("my_customer").
+			MaxResults(c.Max).
+			OrderBy("buildingId, floorName, resourceName").
+			Context(ctx)
+		if query != "" {
+			call = call.Query(query)
+		}
+		if strings.TrimSpace(pageToken) != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, fetchErr := call.Do()
+		if fetchErr != nil {
+			return nil, "", wrapAdminResourcesError(fetchErr, account)
+		}
+		return resp.Items, resp.NextPageToken, nil
+	}
+
+	var resources []*admin.CalendarResource
+	nextPageToken := ""
+	if c.All {
+		resources, err = collectAllPages(c.Page, fetch)
+	} else {
+		resources, nextPageToken, err = fetch(c.Page)
+	}
+	if err != nil {
+		return err
+	}
+
+	rooms := make([]calendarRoom, 0, len(resources))
+	for _, r := range resources {
+		if r == nil || r.ResourceEmail == "" {
+			continue
+		}
+		rooms = append(rooms, calendarRoom{
+			Email:      r.ResourceEmail,
+			Name:       r.ResourceName,
+			ResourceID: r.ResourceId,
+			Building:   r.BuildingId,
+			Floor:      r.FloorName,
+			Capacity:   r.Capacity,
+			Category:   r.ResourceCategory,
+			Type:       r.ResourceType,
+			FullName:   r.GeneratedResourceName,
+		})
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"rooms":         rooms,
+			"nextPageToken": nextPageToken,
+		}); err != nil {
+			return err
+		}
+		if len(rooms) == 0 {
+			return failEmptyExit(c.FailEmpty)
+		}
+		return nil
+	}
+
+	if len(rooms) == 0 {
+		u.Err().Println("No rooms found")
+		return failEmptyExit(c.FailEmpty)
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "EMAIL\tNAME\tBUILDING\tFLOOR\tCAPACITY")
+	for _, r := range rooms {
+		capacity := ""
+		if r.Capacity > 0 {
+			capacity = fmt.Sprintf("%d", r.Capacity)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Email, sanitizeTab(r.Name), sanitizeTab(r.Building), sanitizeTab(r.Floor), capacity)
+	}
+	printNextPageHint(u, nextPageToken)
+	return nil
+}
+
+// resolveCalendarBuildingID maps a building name to its ID. Values that match
+// no building are passed through, so IDs work without the lookup matching.
+func resolveCalendarBuildingID(ctx context.Context, svc *admin.Service, building string) (string, error) {
+	var id string
+	err := svc.Resources.Buildings.List("my_customer").Pages(ctx, func(resp *admin.Buildings) error {
+		for _, b := range resp.Buildings {
+			if b == nil || id != "" {
+				continue
+			}
+			if b.BuildingId == building || strings.EqualFold(b.BuildingName, building) {
+				id = b.BuildingId
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if id == "" {
+		return building, nil
+	}
+	return id, nil
+}
+
+func wrapAdminResourcesError(err error, account string) error {
+	errStr := err.Error()
+	if strings.Contains(errStr, "insufficientPermissions") ||
+		strings.Contains(errStr, "insufficient authentication scopes") ||
+		strings.Contains(errStr, "Not Authorized") {
+		return fmt.Errorf("listing rooms needs the admin.directory.resource.calendar.readonly scope (domain-wide delegation) and an account allowed to read calendar resources: %w", err)
+	}
+	return wrapAdminDirectoryError(err, account)
+}
+
+// checkCalendarRoomsFree returns an error if any room has a busy block
+// overlapping the event's first occurrence.
+func checkCalendarRoomsFree(ctx context.Context, svc *calendar.Service, event *calendar.Event, rooms []string) error {
+	timeMin, err := calendarRoomCheckTime(event.Start)
+	if err != nil {
+		return err
+	}
+	timeMax, err := calendarRoomCheckTime(event.End)
+	if err != nil {
+		return err
+	}
+	req := &calendar.FreeBusyRequest{TimeMin: timeMin, TimeMax: timeMax}
+	for _, room := range rooms {
+		req.Items = append(req.Items, &calendar.FreeBusyRequestItem{Id: room})
+	}
+	resp, err := svc.Freebusy.Query(req).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("check room availability: %w", err)
+	}
+	for _, room := range rooms {
+		fb, ok := resp.Calendars[room]
+		if !ok {
+			return fmt.Errorf("cannot check availability of room %s (use --allow-busy-room to book anyway)", room)
+		}
+		if len(fb.Errors) > 0 && fb.Errors[0] != nil {
+			return fmt.Errorf("cannot check availability of room %s: %s (use --allow-busy-room to book anyway)", room, fb.Errors[0].Reason)
+		}
+		if len(fb.Busy) > 0 && fb.Busy[0] != nil {
+			return fmt.Errorf("room %s is busy %s - %s (use --allow-busy-room to book anyway)", room, fb.Busy[0].Start, fb.Busy[0].End)
+		}
+	}
+	return nil
+}
+
+// calendarRoomCheckTime converts an event boundary to RFC3339. All-day
+// dates are taken as local midnight.
+func calendarRoomCheckTime(edt *calendar.EventDateTime) (string, error) {
+	if edt == nil {
+		return "", usage("event has no start or end time")
+	}
+	if edt.DateTime != "" {
+		return edt.DateTime, nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", edt.Date, time.Local)
+	if err != nil {
+		return "", usagef("invalid date %q", edt.Date)
+	}
+	return day.Format(time.RFC3339), nil
+}
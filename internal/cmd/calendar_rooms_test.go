@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func TestCalendarRoomsListCmd_JSON(t *testing.T) {
+	origNew := newAdminResourcesService
+	t.Cleanup(func() { newAdminResourcesService = origNew })
+
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/customer/my_customer/resources/buildings"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"buildings": []any{
+				map[string]any{"buildingId": "hq-1", "buildingName": "HQ"},
+			}})
+		case strings.HasSuffix(r.URL.Path, "/customer/my_customer/resources/calendars"):
+			gotQuery = r.URL.Query().Get("query")
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []any{
+				map[string]any{"resourceEmail": "room1@resource.calendar.google.com", "resourceName": "Everest", "buildingId": "hq-1", "floorName": "3", "capacity": 8},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc, err := admin.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newAdminResourcesService = func(context.Context, string) (*admin.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &CalendarRoomsListCmd{}, []string{"--building", "hq", "--capacity", "6"}, newCalendarJSONContext(t), &RootFlags{Account: "a@example.com"}); err != nil {
+			t.Fatalf("runKong: %v", err)
+		}
+	})
+	if gotQuery != "buildingId=hq-1 AND capacity>=6 AND resourceCategory=CONFERENCE_ROOM" {
+		t.Fatalf("unexpected query: %q", gotQuery)
+	}
+	var got struct {
+		Rooms []calendarRoom `json:"rooms"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if len(got.Rooms) != 1 || got.Rooms[0].Name != "Everest" || got.Rooms[0].Capacity != 8 {
+		t.Fatalf("unexpected rooms: %#v", got.Rooms)
+	}
+}
+
+func TestCalendarCreateCmd_RoomAvailability(t *testing.T) {
+	origNew := newCalendarService
+	t.Cleanup(func() { newCalendarService = origNew })
+
+	const room = "room1@resource.calendar.google.com"
+	busy := true
+	var inserted *calendar.Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/calendar/v3")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && path == "/freeBusy":
+			var busyBlocks []any
+			if busy {
+				busyBlocks = []any{map[string]any{"start": "2025-01-02T10:30:00Z", "end": "2025-01-02T11:30:00Z"}}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"calendars": map[string]any{room: map[string]any{"busy": busyBlocks}}})
+		case r.Method == http.MethodPost && path == "/calendars/cal@example.com/events":
+			inserted = &calendar.Event{}
+			_ = json.NewDecoder(r.Body).Decode(inserted)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "ev1"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc := newCalendarServiceFromServer(t, srv)
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	args := []string{
+		"cal@example.com",
+		"--summary", "Planning",
+		"--from", "2025-01-02T10:00:00Z",
+		"--to", "2025-01-02T11:00:00Z",
+		"--room", room,
+	}
+	ctx := newCalendarJSONContext(t)
+	flags := &RootFlags{Account: "a@b.com"}
+
+	err := runKong(t, &CalendarCreateCmd{}, args, ctx, flags)
+	if err == nil || !strings.Contains(err.Error(), "is busy") || inserted != nil {
+		t.Fatalf("expected busy room to block creation, got err=%v inserted=%v", err, inserted)
+	}
+
+	busy = false
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &CalendarCreateCmd{}, args, ctx, flags); err != nil {
+			t.Fatalf("runKong: %v", err)
+		}
+	})
+	if inserted == nil || len(inserted.Attendees) != 1 || !inserted.Attendees[0].Resource || inserted.Attendees[0].Email != room {
+		t.Fatalf("expected room attendee, got %#v", inserted)
+	}
+}
@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+const rsvpNeedsAction = "needsAction"
+
+// rsvpStatuses is the report order; needsAction is shown as "no response".
+var rsvpStatuses = []string{"accepted", "declined", "tentative", rsvpNeedsAction}
+
+type CalendarRSVPReportCmd struct {
+	EventID    string `arg:"" name:"eventId" optional:"" help:"Event ID (or use --query)"`
+	CalendarID string `name:"calendar" help:"Calendar ID" default:"primary"`
+	Query      string `name:"query" short:"q" help:"Report on every event matching this search instead of a single event"`
+	TimeRangeFlags
+	Max int64 `name:"max" aliases:"limit" help:"Max events for --query" default:"10"`
+
+	FollowUp       bool   `name:"follow-up" help:"Email attendees who have not responded (see --follow-up-status)"`
+	FollowUpStatus string `name:"follow-up-status" help:"Comma-separated statuses to follow up with: needsAction|tentative|declined" default:"needsAction"`
+	Subject        string `name:"subject" help:"Follow-up subject (default: Please RSVP: <event>)"`
+	Body           string `name:"body" help:"Follow-up body (default: short reminder with the event time and link)"`
+	FollowUpFrom   string `name:"follow-up-from" help:"Send follow-ups from this email address (must be a verified send-as alias)"`
+}
+
+type rsvpReport struct {
+	EventID   string              `json:"eventId"`
+	Summary   string              `json:"summary"`
+	Start     string              `json:"start"`
+	Link      string              `json:"link,omitempty"`
+	Counts    map[string]int      `json:"counts"`
+	Attendees map[string][]string `json:"attendees"`
+}
+
+type rsvpFollowUp struct {
+	EventID   string `json:"eventId"`
+	To        string `json:"to"`
+	MessageID string `json:"messageId,omitempty"`
+}
+
+func (c *CalendarRSVPReportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	eventID := normalizeCalendarEventID(c.EventID)
+	query := strings.TrimSpace(c.Query)
+	if (eventID == "") == (query == "") {
+		return usage("provide an eventId or --query (not both)")
+	}
+	followStatuses, err := parseRSVPStatuses(c.FollowUpStatus)
+	if err != nil {
+		return err
+	}
+
+	_, svc, err := requireCalendarService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	calendarID, err := resolveCalendarSelector(ctx, svc, c.CalendarID, true)
+	if err != nil {
+		return err
+	}
+
+	var events []*calendar.Event
+	if eventID != "" {
+		event, getErr := svc.Events.Get(calendarID, eventID).Context(ctx).Do()
+		if getErr != nil {
+			return getErr
+		}
+		events = append(events, event)
+	} else {
+		timeRange, rangeErr := ResolveTimeRangeWithDefaults(ctx, svc, c.TimeRangeFlags, TimeRangeDefaults{
+			FromOffset: 0,
+			ToOffset:   30 * 24 * time.Hour,
+		})
+		if rangeErr != nil {
+			return rangeErr
+		}
+		from, to := timeRange.FormatRFC3339()
+		resp, listErr := svc.Events.List(calendarID).
+			Q(query).
+			TimeMin(from).
+			TimeMax(to).
+			MaxResults(c.Max).
+			SingleEvents(true).
+			OrderBy("startTime").
+			Context(ctx).
+			Do()
+		if listErr != nil {
+			return listErr
+		}
+		for _, e := range resp.Items {
+			if len(e.Attendees) > 0 {
+				events = append(events, e)
+			}
+		}
+	}
+
+	reports := make([]rsvpReport, 0, len(events))
+	for _, e := range events {
+		reports = append(reports, buildRSVPReport(e))
+	}
+
+	var followUps []rsvpFollowUp
+	if c.FollowUp {
+		followUps, err = c.sendFollowUps(ctx, flags, events, followStatuses)
+		if err != nil {
+			return err
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		out := map[string]any{"events": reports}
+		if c.FollowUp {
+			out["followUps"] = followUps
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, out)
+	}
+
+	if len(reports) == 0 {
+		u.Err().Println("No events with attendees found")
+		return nil
+	}
+	for i, r := range reports {
+		if i > 0 {
+			u.Out().Println("")
+		}
+		u.Out().Printf("id\t%s", r.EventID)
+		u.Out().Printf("summary\t%s", orEmpty(r.Summary, "(no title)"))
+		u.Out().Printf("start\t%s", r.Start)
+		for _, status := range rsvpStatuses {
+			line := fmt.Sprintf("%s\t%d", rsvpStatusLabel(status), r.Counts[status])
+			if len(r.Attendees[status]) > 0 {
+				line += "\t" + strings.Join(r.Attendees[status], ", ")
+			}
+			u.Out().Println(line)
+		}
+	}
+	if c.FollowUp {
+		u.Err().Printf("Sent %d follow-up email(s)", len(followUps))
+	}
+	return nil
+}
+
+func (c *CalendarRSVPReportCmd) sendFollowUps(ctx context.Context, flags *RootFlags, events []*calendar.Event, statuses []string) ([]rsvpFollowUp, error) {
+	type pending struct {
+		event      *calendar.Event
+		recipients []string
+	}
+	var plan []pending
+	var preview []rsvpFollowUp
+	for _, e := range events {
+		var recipients []string
+		for _, a := range e.Attendees {
+			if a == nil || a.Resource || a.Self || a.Organizer || strings.TrimSpace(a.Email) == "" {
+				continue
+			}
+			for _, s := range statuses {
+				if rsvpStatusOf(a) == s {
+					recipients = append(recipients, a.Email)
+					preview = append(preview, rsvpFollowUp{EventID: e.Id, To: a.Email})
+					break
+				}
+			}
+		}
+		if len(recipients) > 0 {
+			plan = append(plan, pending{event: e, recipients: recipients})
+		}
+	}
+
+	if err := dryRunExit(ctx, flags, "calendar.rsvp-report.follow-up", map[string]any{
+		"follow_ups": preview,
+		"statuses":   statuses,
+	}); err != nil {
+		return nil, err
+	}
+	if len(plan) == 0 {
+		return []rsvpFollowUp{}, nil
+	}
+
+	account, gsvc, err := requireGmailService(ctx, flags)
+	if err != nil {
+		return nil, err
+	}
+	sendAsList, sendAsListErr := listSendAs(ctx, gsvc)
+	from, err := resolveComposeFrom(ctx, gsvc, account, c.FollowUpFrom, sendAsList, sendAsListErr)
+	if err != nil {
+		return nil, err
+	}
+
+	sent := make([]rsvpFollowUp, 0, len(preview))
+	for _, p := range plan {
+		batches := make([]sendBatch, 0, len(p.recipients))
+		for _, r := range p.recipients {
+			batches = append(batches, sendBatch{To: []string{r}})
+		}
+		subject, body := c.followUpMessage(p.event)
+		results, sendErr := sendGmailBatches(ctx, gsvc, sendMessageOptions{
+			FromAddr: from.header,
+			Subject:  subject,
+			Body:     body,
+		}, batches)
+		for _, res := range results {
+			sent = append(sent, rsvpFollowUp{EventID: p.event.Id, To: res.To, MessageID: res.MessageID})
+		}
+		if sendErr != nil {
+			return sent, sendErr
+		}
+	}
+	return sent, nil
+}
+
+func (c *CalendarRSVPReportCmd) followUpMessage(e *calendar.Event) (string, string) {
+	summary := orEmpty(e.Summary, "(no title)")
+	subject := strings.TrimSpace(c.Subject)
+	if subject == "" {
+		subject = "Please RSVP: " + summary
+	}
+	body := c.Body
+	if strings.TrimSpace(body) == "" {
+		var b strings.Builder
+		fmt.Fprintf(&b, "Hi,\n\nCould you let me know whether you can make %q on %s?\n", summary, eventStart(e))
+		if e.HtmlLink != "" {
+			fmt.Fprintf(&b, "\nYou can respond here: %s\n", e.HtmlLink)
+		}
+		b.WriteString("\nThanks!\n")
+		body = b.String()
+	}
+	return subject, body
+}
+
+func buildRSVPReport(e *calendar.Event) rsvpReport {
+	r := rsvpReport{
+		EventID:   e.Id,
+		Summary:   e.Summary,
+		Start:     eventStart(e),
+		Link:      e.HtmlLink,
+		Counts:    make(map[string]int, len(rsvpStatuses)),
+		Attendees: make(map[string][]string, len(rsvpStatuses)),
+	}
+	for _, s := range rsvpStatuses {
+		r.Counts[s] = 0
+		r.Attendees[s] = []string{}
+	}
+	for _, a := range e.Attendees {
+		if a == nil || a.Resource {
+			continue
+		}
+		status := rsvpStatusOf(a)
+		name := strings.TrimSpace(a.Email)
+		if name == "" {
+			name = strings.TrimSpace(a.DisplayName)
+		}
+		r.Counts[status]++
+		r.Attendees[status] = append(r.Attendees[status], name)
+	}
+	return r
+}
+
+func rsvpStatusOf(a *calendar.EventAttendee) string {
+	switch a.ResponseStatus {
+	case "accepted", "declined", "tentative":
+		return a.ResponseStatus
+	default:
+		return rsvpNeedsAction
+	}
+}
+
+func rsvpStatusLabel(status string) string {
+	if status == rsvpNeedsAction {
+		return "no_response"
+	}
+	return status
+}
+
+func parseRSVPStatuses(s string) ([]string, error) {
+	var out []string
+	for _, part := range splitCSV(s) {
+		switch strings.ToLower(part) {
+		case "needsaction", "no-response", "no_response", "pending":
+			out = append(out, rsvpNeedsAction)
+		case "tentative", "declined", "accepted":
+			out = append(out, strings.ToLower(part))
+		default:
+			return nil, usagef("invalid --follow-up-status %q (use needsAction, tentative, declined, accepted)", part)
+		}
+	}
+	if len(out) == 0 {
+		return nil, usage("empty --follow-up-status")
+	}
+	return out, nil
+}
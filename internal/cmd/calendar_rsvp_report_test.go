@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestBuildRSVPReport(t *testing.T) {
+	r := buildRSVPReport(&calendar.Event{
+		Id:    "e1",
+		Start: &calendar.EventDateTime{DateTime: "2026-01-02T10:00:00Z"},
+		Attendees: []*calendar.EventAttendee{
+			{Email: "org@example.com", Organizer: true, ResponseStatus: "accepted"},
+			{Email: "a@example.com", ResponseStatus: "declined"},
+			{Email: "b@example.com", ResponseStatus: "needsAction"},
+			{Email: "c@example.com"},
+			{Email: "room@resource.calendar.google.com", Resource: true},
+		},
+	})
+	if r.Counts["accepted"] != 1 || r.Counts["declined"] != 1 || r.Counts["tentative"] != 0 || r.Counts[rsvpNeedsAction] != 2 {
+		t.Fatalf("unexpected counts: %#v", r.Counts)
+	}
+	if strings.Join(r.Attendees[rsvpNeedsAction], ",") != "b@example.com,c@example.com" {
+		t.Fatalf("unexpected pending attendees: %#v", r.Attendees)
+	}
+}
+
+func TestCalendarRSVPReportCmd_FollowUp(t *testing.T) {
+	origCal := newCalendarService
+	origGmail := newGmailService
+	t.Cleanup(func() {
+		newCalendarService = origCal
+		newGmailService = origGmail
+	})
+
+	calSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/calendars/primary/events/e1") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":       "e1",
+			"summary":  "Planning",
+			"htmlLink": "https://calendar.google.com/event?eid=e1",
+			"start":    map[string]any{"dateTime": "2026-01-02T10:00:00Z"},
+			"attendees": []any{
+				map[string]any{"email": "me@example.com", "self": true, "organizer": true, "responseStatus": "accepted"},
+				map[string]any{"email": "a@example.com", "responseStatus": "tentative"},
+				map[string]any{"email": "b@example.com", "responseStatus": "needsAction"},
+			},
+		})
+	}))
+	defer calSrv.Close()
+	calSvc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(calSrv.Client()),
+		option.WithEndpoint(calSrv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return calSvc, nil }
+
+	var sent []string
+	gmailSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/settings/sendAs"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"sendAs": []any{}})
+		case strings.HasSuffix(r.URL.Path, "/messages/send"):
+			var msg gmail.Message
+			_ = json.NewDecoder(r.Body).Decode(&msg)
+			raw, _ := base64.RawURLEncoding.DecodeString(msg.Raw)
+			sent = append(sent, string(raw))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "m1", "threadId": "t1"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer gmailSrv.Close()
+	gmailSvc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(gmailSrv.Client()),
+		option.WithEndpoint(gmailSrv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return gmailSvc, nil }
+
+	flags := &RootFlags{Account: "me@example.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+	out := captureStdout(t, func() {
+		if err := runKong(t, &CalendarRSVPReportCmd{}, []string{"e1", "--follow-up"}, ctx, flags); err != nil {
+			t.Fatalf("rsvp-report: %v", err)
+		}
+	})
+
+	var result struct {
+		Events    []rsvpReport   `json:"events"`
+		FollowUps []rsvpFollowUp `json:"followUps"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if len(result.Events) != 1 || result.Events[0].Counts["tentative"] != 1 || result.Events[0].Counts[rsvpNeedsAction] != 1 {
+		t.Fatalf("unexpected report: %#v", result.Events)
+	}
+	if len(result.FollowUps) != 1 || result.FollowUps[0].To != "b@example.com" || result.FollowUps[0].MessageID != "m1" {
+		t.Fatalf("unexpected follow-ups: %#v", result.FollowUps)
+	}
+	if len(sent) != 1 || !strings.Contains(sent[0], "To: b@example.com") || !strings.Contains(sent[0], "Subject: Please RSVP: Planning") {
+		t.Fatalf("unexpected follow-up message: %q", sent)
+	}
+
+	err = runKong(t, &CalendarRSVPReportCmd{}, []string{"e1", "--query", "x"}, ctx, flags)
+	if err == nil || !strings.Contains(err.Error(), "not both") {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
@@ -0,0 +1,332 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+var calendarStatsNow = time.Now
+
+// CalendarStatsCmd reports weekly meeting load. A meeting is a timed,
+// regular event with at least one other attendee that you have not
+// declined; focus time comes from Focus Time events.
+type CalendarStatsCmd struct {
+	CalendarID string `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
+	Weeks      int    `name:"weeks" help:"Number of weeks to report, ending with the current week" default:"12"`
+	GroupBy    string `name:"group-by" help:"Break meetings down by organizer|recurring|size" enum:",organizer,recurring,size" default:""`
+	WeekStart  string `name:"week-start" help:"Week start day (sun, mon, ...)" default:""`
+	Format     string `name:"format" help:"Output format: table|csv|json" enum:"table,csv,json" default:"table"`
+	Out        string `name:"out" short:"o" help:"Write csv/json to this file (defaults to stdout)"`
+}
+
+type calendarStatsBucket struct {
+	Meetings     int     `json:"meetings"`
+	MeetingHours float64 `json:"meetingHours"`
+	AvgAttendees float64 `json:"avgAttendees"`
+
+	attendees int
+}
+
+type calendarStatsGroup struct {
+	Key string `json:"key"`
+	calendarStatsBucket
+}
+
+type calendarStatsWeek struct {
+	WeekStart string `json:"weekStart"`
+	calendarStatsBucket
+	FocusHours float64 `json:"focusHours"`
+	// FocusFraction is focus time over focus plus meeting time.
+	FocusFraction float64              `json:"focusFraction"`
+	Groups        []calendarStatsGroup `json:"groups,omitempty"`
+
+	groups map[string]*calendarStatsBucket
+}
+
+type calendarStats struct {
+	CalendarID string              `json:"calendarId"`
+	GroupBy    string              `json:"groupBy,omitempty"`
+	From       string              `json:"from"`
+	To         string              `json:"to"`
+	Weeks      []calendarStatsWeek `json:"weeks"`
+	Total      calendarStatsWeek   `json:"total"`
+}
+
+func (c *CalendarStatsCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Weeks < 1 || c.Weeks > 104 {
+		return usage("--weeks must be between 1 and 104")
+	}
+	weekStart, err := resolveWeekStart(c.WeekStart)
+	if err != nil {
+		return usage(err.Error())
+	}
+	format := strings.ToLower(strings.TrimSpace(c.Format))
+	if outfmt.IsJSON(ctx) {
+		format = "json"
+	}
+
+	_, svc, err := requireCalendarService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	calID, err := resolveCalendarSelector(ctx, svc, c.CalendarID, true)
+	if err != nil {
+		return err
+	}
+	_, loc, err := getCalendarLocation(ctx, svc, calID)
+	if err != nil {
+		return err
+	}
+	from := startOfWeek(calendarStatsNow().In(loc), weekStart).AddDate(0, 0, -7*(c.Weeks-1))
+	to := from.AddDate(0, 0, 7*c.Weeks)
+
+	events, err := collectAllPages("", func(pageToken string) ([]*calendar.Event, string, error) {
+		resp, err := calendarEventsListCall(ctx, svc, calID, from.Format(time.RFC3339), to.Format(time.RFC3339), 2500, "", "", "", "", pageToken).Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return err
+	}
+	stats := buildCalendarStats(events, from, c.Weeks, c.GroupBy)
+	stats.CalendarID = calID
+	stats.From, stats.To = from.Format(time.RFC3339), to.Format(time.RFC3339)
+
+	if format == "table" {
+		return writeCalendarStatsTable(ctx, stats)
+	}
+	outPath := strings.TrimSpace(c.Out)
+	var w io.Writer = os.Stdout
+	if outPath != "" {
+		f, resolved, createErr := createUserOutputFile(outPath)
+		if createErr != nil {
+			return createErr
+		}
+		defer func() { _ = f.Close() }()
+		w, outPath = f, resolved
+	}
+	if format == "json" {
+		err = outfmt.WriteJSON(ctx, w, stats)
+	} else {
+		err = writeCalendarStatsCSV(w, stats)
+	}
+	if err != nil {
+		return err
+	}
+	if outPath != "" {
+		u.Err().Printf("Wrote %d week%s of stats to %s", len(stats.Weeks), pluralS(len(stats.Weeks)), outPath)
+	}
+	return nil
+}
+
+// buildCalendarStats assigns each event to the week it starts in.
+func buildCalendarStats(events []*calendar.Event, from time.Time, weeks int, groupBy string) calendarStats {
+	stats := calendarStats{GroupBy: groupBy, Weeks: make([]calendarStatsWeek, weeks)}
+	for i := range stats.Weeks {
+		stats.Weeks[i] = calendarStatsWeek{WeekStart: from.AddDate(0, 0, 7*i).Format("2006-01-02"), groups: map[string]*calendarStatsBucket{}}
+	}
+	stats.Total = calendarStatsWeek{WeekStart: stats.Weeks[0].WeekStart, groups: map[string]*calendarStatsBucket{}}
+
+	for _, e := range events {
+		if e == nil || e.Status == "cancelled" || e.Start == nil || e.Start.DateTime == "" {
+			continue
+		}
+		start, okStart := parseEventTime(eventStart(e), eventTimezone(e))
+		end, okEnd := parseEventTime(eventEnd(e), eventTimezone(e))
+		if !okStart || !okEnd || !end.After(start) {
+			continue
+		}
+		if start.Before(from) {
+			continue
+		}
+		// Step by calendar weeks rather than dividing durations so DST
+		// changes do not shift events across the boundary.
+		idx := 0
+		for idx < weeks && !start.Before(from.AddDate(0, 0, 7*(idx+1))) {
+			idx++
+		}
+		if idx >= weeks {
+			continue
+		}
+		hours := end.Sub(start).Hours()
+		week := &stats.Weeks[idx]
+
+		if e.EventType == eventTypeFocusTime {
+			week.FocusHours += hours
+			stats.Total.FocusHours += hours
+			continue
+		}
+		if !isCalendarStatsMeeting(e) {
+			continue
+		}
+		key := calendarStatsGroupKey(e, groupBy)
+		for _, w := range []*calendarStatsWeek{week, &stats.Total} {
+			w.add(hours, len(e.Attendees))
+			if key == "" {
+				continue
+			}
+			g, ok := w.groups[key]
+			if !ok {
+				g = &calendarStatsBucket{}
+				w.groups[key] = g
+			}
+			g.add(hours, len(e.Attendees))
+		}
+	}
+
+	for i := range stats.Weeks {
+		stats.Weeks[i].finish()
+	}
+	stats.Total.finish()
+	return stats
+}
+
+func isCalendarStatsMeeting(e *calendar.Event) bool {
+	if e.EventType != "" && e.EventType != eventTypeDefault {
+		return false
+	}
+	others := 0
+	for _, a := range e.Attendees {
+		if a == nil || a.Resource {
+			continue
+		}
+		if a.Self {
+			if a.ResponseStatus == "declined" {
+				return false
+			}
+			continue
+		}
+		others++
+	}
+	return others > 0
+}
+
+func calendarStatsGroupKey(e *calendar.Event, groupBy string) string {
+	switch groupBy {
+	case "organizer":
+		if e.Organizer != nil && e.Organizer.Email != "" {
+			return strings.ToLower(e.Organizer.Email)
+		}
+		return "(none)"
+	case "recurring":
+		if e.RecurringEventId != "" {
+			return "recurring"
+		}
+		return "one-off"
+	case "size":
+		switch n := len(e.Attendees); {
+		case n <= 2:
+			return "1:1"
+		case n <= 5:
+			return "3-5"
+		case n <= 10:
+			return "6-10"
+		default:
+			return "11+"
+		}
+	}
+	return ""
+}
+
+func (b *calendarStatsBucket) add(hours float64, attendees int) {
+	b.Meetings++
+	b.MeetingHours += hours
+	b.attendees += attendees
+}
+
+func (b *calendarStatsBucket) finish() {
+	if b.Meetings > 0 {
+		b.AvgAttendees = roundStat(float64(b.attendees) / float64(b.Meetings))
+	}
+	b.MeetingHours = roundStat(b.MeetingHours)
+}
+
+func (w *calendarStatsWeek) finish() {
+	if scheduled := w.FocusHours + w.MeetingHours; scheduled > 0 {
+		w.FocusFraction = roundStat(w.FocusHours / scheduled)
+	}
+	w.calendarStatsBucket.finish()
+	w.FocusHours = roundStat(w.FocusHours)
+	for key, g := range w.groups {
+		g.finish()
+		w.Groups = append(w.Groups, calendarStatsGroup{Key: key, calendarStatsBucket: *g})
+	}
+	sort.Slice(w.Groups, func(i, j int) bool {
+		if w.Groups[i].MeetingHours != w.Groups[j].MeetingHours {
+			return w.Groups[i].MeetingHours > w.Groups[j].MeetingHours
+		}
+		return w.Groups[i].Key < w.Groups[j].Key
+	})
+}
+
+func roundStat(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func writeCalendarStatsTable(ctx context.Context, stats calendarStats) error {
+	u := ui.FromContext(ctx)
+	w, flush := tableWriter(ctx)
+	fmt.Fprintln(w, "WEEK\tMEETINGS\tHOURS\tAVG_ATTENDEES\tFOCUS_HOURS\tFOCUS")
+	row := func(label string, wk calendarStatsWeek) {
+		fmt.Fprintf(w, "%s\t%d\t%.1f\t%.1f\t%.1f\t%.0f%%\n", label, wk.Meetings, wk.MeetingHours, wk.AvgAttendees, wk.FocusHours, wk.FocusFraction*100)
+	}
+	for _, wk := range stats.Weeks {
+		row(wk.WeekStart, wk)
+	}
+	row("total", stats.Total)
+	flush()
+	if len(stats.Total.Groups) == 0 {
+		return nil
+	}
+	u.Out().Println("")
+	w, flush = tableWriter(ctx)
+	defer flush()
+	fmt.Fprintf(w, "%s\tMEETINGS\tHOURS\tAVG_ATTENDEES\n", strings.ToUpper(stats.GroupBy))
+	for _, g := range stats.Total.Groups {
+		fmt.Fprintf(w, "%s\t%d\t%.1f\t%.1f\n", g.Key, g.Meetings, g.MeetingHours, g.AvgAttendees)
+	}
+	return nil
+}
+
+// writeCalendarStatsCSV emits long-format rows: one "all" row per week with
+// focus columns, then one row per group.
+func writeCalendarStatsCSV(w io.Writer, stats calendarStats) error {
+	bw := bufio.NewWriter(w)
+	cw := csv.NewWriter(bw)
+	if err := cw.Write([]string{"week_start", "group", "meetings", "meeting_hours", "avg_attendees", "focus_hours", "focus_fraction"}); err != nil {
+		return err
+	}
+	num := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	for _, wk := range stats.Weeks {
+		if err := cw.Write([]string{wk.WeekStart, "all", strconv.Itoa(wk.Meetings), num(wk.MeetingHours), num(wk.AvgAttendees), num(wk.FocusHours), num(wk.FocusFraction)}); err != nil {
+			return err
+		}
+		for _, g := range wk.Groups {
+			if err := cw.Write([]string{wk.WeekStart, g.Key, strconv.Itoa(g.Meetings), num(g.MeetingHours), num(g.AvgAttendees), "", ""}); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func TestCalendarStatsCmd(t *testing.T) {
+	origNew, origNow := newCalendarService, calendarStatsNow
+	t.Cleanup(func() { newCalendarService, calendarStatsNow = origNew, origNow })
+	calendarStatsNow = func() time.Time { return time.Date(2025, 3, 12, 12, 0, 0, 0, time.UTC) } // Wednesday
+
+	me := map[string]any{"email": "me@example.com", "self": true, "responseStatus": "accepted"}
+	peer := func(email string) map[string]any { return map[string]any{"email": email} }
+	var timeMin string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/calendars/primary"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "primary", "timeZone": "UTC"})
+		case strings.HasSuffix(r.URL.Path, "/calendars/primary/events"):
+			timeMin = r.URL.Query().Get("timeMin")
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{
+				// Week of Mar 3: a recurring 1:1 (1h) and a 4-person one-off (2h).
+				{"id": "s_1", "recurringEventId": "s", "organizer": map[string]any{"email": "Boss@example.com"}, "start": map[string]any{"dateTime": "2025-03-03T09:00:00Z"}, "end": map[string]any{"dateTime": "2025-03-03T10:00:00Z"}, "attendees": []map[string]any{me, peer("boss@example.com")}},
+				{"id": "p", "organizer": map[string]any{"email": "me@example.com"}, "start": map[string]any{"dateTime": "2025-03-04T13:00:00Z"}, "end": map[string]any{"dateTime": "2025-03-04T15:00:00Z"}, "attendees": []map[string]any{me, peer("a@example.com"), peer("b@example.com"), peer("c@example.com")}},
+				{"id": "f", "eventType": "focusTime", "start": map[string]any{"dateTime": "2025-03-05T09:00:00Z"}, "end": map[string]any{"dateTime": "2025-03-05T12:00:00Z"}},
+				// Week of Mar 10: a solo block, a declined meeting, and the recurring 1:1.
+				{"id": "solo", "start": map[string]any{"dateTime": "2025-03-10T08:00:00Z"}, "end": map[string]any{"dateTime": "2025-03-10T09:00:00Z"}},
+				{"id": "no", "start": map[string]any{"dateTime": "2025-03-11T08:00:00Z"}, "end": map[string]any{"dateTime": "2025-03-11T09:00:00Z"}, "attendees": []map[string]any{{"email": "me@example.com", "self": true, "responseStatus": "declined"}, peer("x@example.com")}},
+				{"id": "s_2", "recurringEventId": "s", "organizer": map[string]any{"email": "boss@example.com"}, "start": map[string]any{"dateTime": "2025-03-10T09:00:00Z"}, "end": map[string]any{"dateTime": "2025-03-10T09:30:00Z"}, "attendees": []map[string]any{me, peer("boss@example.com")}},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "stats", "--weeks", "2", "--group-by", "organizer"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if timeMin != "2025-03-03T00:00:00Z" {
+		t.Fatalf("unexpected timeMin %q", timeMin)
+	}
+	var stats calendarStats
+	if err := json.Unmarshal([]byte(out), &stats); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(stats.Weeks) != 2 || stats.Weeks[0].WeekStart != "2025-03-03" || stats.Weeks[1].WeekStart != "2025-03-10" {
+		t.Fatalf("unexpected weeks: %s", out)
+	}
+	w0, w1 := stats.Weeks[0], stats.Weeks[1]
+	if w0.Meetings != 2 || w0.MeetingHours != 3 || w0.AvgAttendees != 3 || w0.FocusHours != 3 || w0.FocusFraction != 0.5 {
+		t.Fatalf("unexpected first week: %+v", w0)
+	}
+	if w1.Meetings != 1 || w1.MeetingHours != 0.5 || w1.FocusFraction != 0 {
+		t.Fatalf("unexpected second week: %+v", w1)
+	}
+	if len(stats.Total.Groups) != 2 || stats.Total.Groups[0].Key != "me@example.com" || stats.Total.Groups[1].Key != "boss@example.com" || stats.Total.Groups[1].Meetings != 2 {
+		t.Fatalf("unexpected groups: %+v", stats.Total.Groups)
+	}
+
+	out = captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "calendar", "stats", "--weeks", "2", "--group-by", "size", "--format", "csv"}); err != nil {
+			t.Fatalf("Execute csv: %v", err)
+		}
+	})
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("csv: %v\n%s", err, out)
+	}
+	want := [][]string{
+		{"week_start", "group", "meetings", "meeting_hours", "avg_attendees", "focus_hours", "focus_fraction"},
+		{"2025-03-03", "all", "2", "3", "3", "3", "0.5"},
+		{"2025-03-03", "3-5", "1", "2", "4", "", ""},
+		{"2025-03-03", "1:1", "1", "1", "2", "", ""},
+		{"2025-03-10", "all", "1", "0.5", "2", "0", "0"},
+		{"2025-03-10", "1:1", "1", "0.5", "2", "", ""},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("unexpected csv rows: %q", records)
+	}
+	for i := range want {
+		if strings.Join(records[i], ",") != strings.Join(want[i], ",") {
+			t.Fatalf("row %d = %q, want %q", i, records[i], want[i])
+		}
+	}
+}
@@ -5,8 +5,8 @@ import (
 	"os"
 	"time"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type CalendarTimeCmd struct {
@@ -0,0 +1,377 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+var calendarWorkingHoursNow = time.Now
+
+// CalendarWorkingHoursCmd answers "is it a good time to reach them?" for
+// someone in another timezone. The Calendar API does not expose a person's
+// configured working hours, so the window comes from --hours and is labelled
+// as assumed; their timezone, working location, out-of-office days, and
+// free/busy come from their calendar where it is shared with you.
+type CalendarWorkingHoursCmd struct {
+	Email    string `arg:"" name:"email" help:"Person's email (their primary calendar)"`
+	Hours    string `name:"hours" help:"Working hours in their timezone, HH:MM-HH:MM (Calendar does not expose the configured hours)" default:"09:00-17:00"`
+	Days     int    `name:"days" help:"Days of working location and out-of-office to show, starting today" default:"5"`
+	Timezone string `name:"timezone" help:"Their IANA timezone, for when their calendar is not shared with you"`
+}
+
+type workingHoursDay struct {
+	Date        string `json:"date"`
+	Weekday     string `json:"weekday"`
+	Location    string `json:"location,omitempty"`
+	OutOfOffice bool   `json:"outOfOffice,omitempty"`
+}
+
+type workingHoursSpan struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type workingHoursEvent struct {
+	start, end time.Time
+	ooo        bool
+	location   string
+}
+
+func (c *CalendarWorkingHoursCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	email := strings.TrimSpace(c.Email)
+	if email == "" {
+		return usage("empty email")
+	}
+	startMin, endMin, err := parseWorkingHoursRange(c.Hours)
+	if err != nil {
+		return usage(err.Error())
+	}
+	if c.Days < 1 || c.Days > 31 {
+		return usage("--days must be between 1 and 31")
+	}
+	theirLoc, ok, err := parseTimezoneValue("--timezone", c.Timezone, false)
+	if err != nil {
+		return usage(err.Error())
+	}
+	myLoc, err := resolveOutputLocation("", false)
+	if err != nil {
+		return err
+	}
+
+	_, svc, err := requireCalendarService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	tzSource := "flag"
+	if !ok {
+		_, theirLoc, err = getCalendarLocation(ctx, svc, email)
+		if err != nil {
+			return fmt.Errorf("%w (pass --timezone if their calendar is not shared with you)", err)
+		}
+		tzSource = "calendar"
+	}
+
+	now := calendarWorkingHoursNow().In(theirLoc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, theirLoc)
+	var notes []string
+	events, err := listWorkingHoursEvents(ctx, svc, email, today, today.AddDate(0, 0, max(c.Days, 14)), theirLoc)
+	if err != nil {
+		notes = append(notes, fmt.Sprintf("working location and out-of-office not visible: %v", err))
+	}
+
+	status := "outside working hours"
+	todayStart, todayEnd := workingHoursOn(today, startMin, endMin)
+	switch {
+	case workingHoursOOOAt(events, now):
+		status = "out of office"
+	case isWorkingHoursWeekday(today) && !now.Before(todayStart) && now.Before(todayEnd):
+		status = "working"
+	}
+	winStart, winEnd := nextWorkingHoursWindow(now, startMin, endMin, events)
+
+	var free []workingHoursSpan
+	fbStart := winStart
+	if now.After(fbStart) {
+		fbStart = now
+	}
+	if busy, fbErr := queryWorkingHoursBusy(ctx, svc, email, fbStart, winEnd); fbErr != nil {
+		notes = append(notes, fmt.Sprintf("free/busy not visible: %v", fbErr))
+	} else {
+		for _, s := range freeWorkingHoursSlots(fbStart, winEnd, busy) {
+			free = append(free, workingHoursSpan{Start: s[0].Format(time.RFC3339), End: s[1].Format(time.RFC3339)})
+		}
+	}
+
+	days := make([]workingHoursDay, 0, c.Days)
+	for i := 0; i < c.Days; i++ {
+		day := today.AddDate(0, 0, i)
+		d := workingHoursDay{Date: day.Format("2006-01-02"), Weekday: day.Weekday().String()}
+		for _, e := range events {
+			if !e.start.Before(day.AddDate(0, 0, 1)) || !e.end.After(day) {
+				continue
+			}
+			if e.ooo {
+				d.OutOfOffice = true
+			} else if d.Location == "" {
+				d.Location = e.location
+			}
+		}
+		days = append(days, d)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"email":          email,
+			"timezone":       theirLoc.String(),
+			"timezoneSource": tzSource,
+			"localTime":      now.Format(time.RFC3339),
+			"workingHours":   map[string]any{"start": formatWorkingHoursClock(startMin), "end": formatWorkingHoursClock(endMin), "assumed": true},
+			"status":         status,
+			"window":         workingHoursSpan{Start: winStart.Format(time.RFC3339), End: winEnd.Format(time.RFC3339)},
+			"windowLocal":    workingHoursSpan{Start: winStart.In(myLoc).Format(time.RFC3339), End: winEnd.In(myLoc).Format(time.RFC3339)},
+			"free":           free,
+			"days":           days,
+			"notes":          notes,
+		})
+	}
+
+	u.Out().Printf("email\t%s", email)
+	u.Out().Printf("timezone\t%s (%s)", theirLoc, now.Format("-07:00"))
+	u.Out().Printf("local_time\t%s", now.Format("Mon 2006-01-02 15:04"))
+	u.Out().Printf("working_hours\t%s-%s (assumed)", formatWorkingHoursClock(startMin), formatWorkingHoursClock(endMin))
+	u.Out().Printf("status\t%s", status)
+	u.Out().Printf("window\t%s - %s %s", winStart.Format("Mon 2006-01-02 15:04"), winEnd.Format("15:04"), theirLoc)
+	u.Out().Printf("window_local\t%s - %s %s", winStart.In(myLoc).Format("Mon 2006-01-02 15:04"), winEnd.In(myLoc).Format("Mon 15:04"), myLoc)
+	if free != nil {
+		slots := make([]string, 0, len(free))
+		for _, s := range free {
+			start, _ := time.Parse(time.RFC3339, s.Start)
+			end, _ := time.Parse(time.RFC3339, s.End)
+			slots = append(slots, start.In(theirLoc).Format("15:04")+"-"+end.In(theirLoc).Format("15:04"))
+		}
+		u.Out().Printf("free\t%s", orEmpty(strings.Join(slots, ", "), "none"))
+	}
+	for _, note := range notes {
+		u.Err().Printf("Note: %s", note)
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "DATE\tDAY\tLOCATION\tOOO")
+	for _, d := range days {
+		ooo := ""
+		if d.OutOfOffice {
+			ooo = "yes"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", d.Date, d.Weekday[:3], orEmpty(d.Location, "-"), ooo)
+	}
+	return nil
+}
+
+// parseWorkingHoursRange parses "HH:MM-HH:MM" into minutes after midnight.
+func parseWorkingHoursRange(value string) (int, int, error) {
+	from, to, ok := strings.Cut(strings.TrimSpace(value), "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid --hours %q (want HH:MM-HH:MM)", value)
+	}
+	start, err := time.Parse("15:04", strings.TrimSpace(from))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid --hours start %q (want HH:MM)", from)
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(to))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid --hours end %q (want HH:MM)", to)
+	}
+	startMin, endMin := start.Hour()*60+start.Minute(), end.Hour()*60+end.Minute()
+	if endMin <= startMin {
+		return 0, 0, fmt.Errorf("--hours must end after it starts, got %q", value)
+	}
+	return startMin, endMin, nil
+}
+
+func formatWorkingHoursClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func workingHoursOn(day time.Time, startMin, endMin int) (time.Time, time.Time) {
+	base := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	return base.Add(time.Duration(startMin) * time.Minute), base.Add(time.Duration(endMin) * time.Minute)
+}
+
+func isWorkingHoursWeekday(day time.Time) bool {
+	return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+}
+
+// nextWorkingHoursWindow returns the current or next weekday window that has
+// not ended yet, skipping days an out-of-office event covers entirely.
+func nextWorkingHoursWindow(now time.Time, startMin, endMin int, events []workingHoursEvent) (time.Time, time.Time) {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for i := 0; i < 21; i++ {
+		d := day.AddDate(0, 0, i)
+		start, end := workingHoursOn(d, startMin, endMin)
+		if !isWorkingHoursWeekday(d) || !end.After(now) || workingHoursOOOCovers(events, start, end) {
+			continue
+		}
+		return start, end
+	}
+	return workingHoursOn(day.AddDate(0, 0, 21), startMin, endMin)
+}
+
+func workingHoursOOOAt(events []workingHoursEvent, t time.Time) bool {
+	for _, e := range events {
+		if e.ooo && !t.Before(e.start) && t.Before(e.end) {
+			return true
+		}
+	}
+	return false
+}
+
+func workingHoursOOOCovers(events []workingHoursEvent, start, end time.Time) bool {
+	for _, e := range events {
+		if e.ooo && !e.start.After(start) && !e.end.Before(end) {
+			return true
+		}
+	}
+	return false
+}
+
+func listWorkingHoursEvents(ctx context.Context, svc *calendar.Service, calendarID string, from, to time.Time, loc *time.Location) ([]workingHoursEvent, error) {
+	items, err := collectAllPages("", func(pageToken string) ([]*calendar.Event, string, error) {
+		call := svc.Events.List(calendarID).
+			EventTypes("workingLocation", "outOfOffice").
+			SingleEvents(true).
+			OrderBy("startTime").
+			TimeMin(from.Format(time.RFC3339)).
+			TimeMax(to.Format(time.RFC3339)).
+			Fields("nextPageToken,items(eventType,start,end,summary,workingLocationProperties)").
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]workingHoursEvent, 0, len(items))
+	for _, item := range items {
+		start, end, ok := workingHoursEventSpan(item, loc)
+		if !ok {
+			continue
+		}
+		e := workingHoursEvent{start: start, end: end, ooo: item.EventType == "outOfOffice"}
+		if !e.ooo {
+			e.location = workingLocationLabel(item)
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// workingHoursEventSpan reads an event's bounds; all-day events (which
+// working locations usually are) span whole days in the person's timezone.
+func workingHoursEventSpan(e *calendar.Event, loc *time.Location) (time.Time, time.Time, bool) {
+	if e == nil || e.Start == nil || e.End == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	if e.Start.DateTime != "" {
+		start, ok1 := parseEventTime(e.Start.DateTime, e.Start.TimeZone)
+		end, ok2 := parseEventTime(e.End.DateTime, e.End.TimeZone)
+		return start, end, ok1 && ok2
+	}
+	start, err1 := time.ParseInLocation("2006-01-02", e.Start.Date, loc)
+	end, err2 := time.ParseInLocation("2006-01-02", e.End.Date, loc)
+	return start, end, err1 == nil && err2 == nil
+}
+
+func workingLocationLabel(e *calendar.Event) string {
+	p := e.WorkingLocationProperties
+	if p == nil {
+		return strings.TrimSpace(e.Summary)
+	}
+	switch p.Type {
+	case "homeOffice":
+		return "home"
+	case "officeLocation":
+		if p.OfficeLocation != nil && strings.TrimSpace(p.OfficeLocation.Label) != "" {
+			return "office: " + strings.TrimSpace(p.OfficeLocation.Label)
+		}
+		return "office"
+	case "customLocation":
+		if p.CustomLocation != nil && strings.TrimSpace(p.CustomLocation.Label) != "" {
+			return strings.TrimSpace(p.CustomLocation.Label)
+		}
+	}
+	return orEmpty(strings.TrimSpace(e.Summary), p.Type)
+}
+
+func queryWorkingHoursBusy(ctx context.Context, svc *calendar.Service, calendarID string, from, to time.Time) ([][2]time.Time, error) {
+	resp, err := svc.Freebusy.Query(&calendar.FreeBusyRequest{
+		TimeMin: from.Format(time.RFC3339),
+		TimeMax: to.Format(time.RFC3339),
+		Items:   []*calendar.FreeBusyRequestItem{{Id: calendarID}},
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	data, ok := resp.Calendars[calendarID]
+	if !ok {
+		return nil, fmt.Errorf("no free/busy data for %s", calendarID)
+	}
+	if len(data.Errors) > 0 {
+		return nil, fmt.Errorf("%s", data.Errors[0].Reason)
+	}
+	busy := make([][2]time.Time, 0, len(data.Busy))
+	for _, b := range data.Busy {
+		start, err1 := time.Parse(time.RFC3339, b.Start)
+		end, err2 := time.Parse(time.RFC3339, b.End)
+		if err1 == nil && err2 == nil {
+			busy = append(busy, [2]time.Time{start, end})
+		}
+	}
+	return busy, nil
+}
+
+// freeWorkingHoursSlots returns the gaps between busy blocks within
+// [from, to), in from's location.
+func freeWorkingHoursSlots(from, to time.Time, busy [][2]time.Time) [][2]time.Time {
+	sort.Slice(busy, func(i, j int) bool { return busy[i][0].Before(busy[j][0]) })
+	var free [][2]time.Time
+	cursor := from
+	for _, b := range busy {
+		if b[0].After(cursor) {
+			free = append(free, [2]time.Time{cursor, minTime(b[0], to).In(from.Location())})
+		}
+		if b[1].After(cursor) {
+			cursor = b[1].In(from.Location())
+		}
+		if !cursor.Before(to) {
+			return free
+		}
+	}
+	if cursor.Before(to) {
+		free = append(free, [2]time.Time{cursor, to})
+	}
+	return free
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
+)
+
+func TestCalendarWorkingHoursCmd(t *testing.T) {
+	origNew, origNow := newCalendarService, calendarWorkingHoursNow
+	t.Cleanup(func() { newCalendarService, calendarWorkingHoursNow = origNew, origNow })
+	// Friday 20:00 UTC is Saturday 05:00 in Tokyo.
+	calendarWorkingHoursNow = func() time.Time { return time.Date(2025, 3, 14, 20, 0, 0, 0, time.UTC) }
+	t.Setenv("GOG_TIMEZONE", "UTC")
+
+	var fbReq calendar.FreeBusyRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/calendars/bob@example.com"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "bob@example.com", "timeZone": "Asia/Tokyo"})
+		case strings.HasSuffix(r.URL.Path, "/calendars/bob@example.com/events"):
+			if got := r.URL.Query()["eventTypes"]; strings.Join(got, ",") != "workingLocation,outOfOffice" {
+				t.Errorf("unexpected eventTypes %q", got)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{
+				{"eventType": "outOfOffice", "start": map[string]any{"date": "2025-03-17"}, "end": map[string]any{"date": "2025-03-18"}},
+				{"eventType": "workingLocation", "start": map[string]any{"date": "2025-03-18"}, "end": map[string]any{"date": "2025-03-19"}, "workingLocationProperties": map[string]any{"type": "homeOffice"}},
+				{"eventType": "workingLocation", "start": map[string]any{"date": "2025-03-19"}, "end": map[string]any{"date": "2025-03-20"}, "workingLocationProperties": map[string]any{"type": "officeLocation", "officeLocation": map[string]any{"label": "Shibuya"}}},
+			}})
+		case strings.HasSuffix(r.URL.Path, "/freeBusy"):
+			_ = json.NewDecoder(r.Body).Decode(&fbReq)
+			_ = json.NewEncoder(w).Encode(map[string]any{"calendars": map[string]any{
+				"bob@example.com": map[string]any{"busy": []map[string]any{{"start": "2025-03-18T01:00:00Z", "end": "2025-03-18T02:00:00Z"}}},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "working-hours", "bob@example.com"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	var got struct {
+		Timezone    string             `json:"timezone"`
+		LocalTime   string             `json:"localTime"`
+		Status      string             `json:"status"`
+		Window      workingHoursSpan   `json:"window"`
+		WindowLocal workingHoursSpan   `json:"windowLocal"`
+		Free        []workingHoursSpan `json:"free"`
+		Days        []workingHoursDay  `json:"days"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if got.Timezone != "Asia/Tokyo" || got.LocalTime != "2025-03-15T05:00:00+09:00" || got.Status != "outside working hours" {
+		t.Fatalf("unexpected header: %s", out)
+	}
+	// Monday is out of office, so the next window is Tuesday.
+	if got.Window.Start != "2025-03-18T09:00:00+09:00" || got.Window.End != "2025-03-18T17:00:00+09:00" {
+		t.Fatalf("unexpected window: %+v", got.Window)
+	}
+	if got.WindowLocal.Start != "2025-03-18T00:00:00Z" || got.WindowLocal.End != "2025-03-18T08:00:00Z" {
+		t.Fatalf("unexpected local window: %+v", got.WindowLocal)
+	}
+	if fbReq.TimeMin != "2025-03-18T09:00:00+09:00" || len(fbReq.Items) != 1 || fbReq.Items[0].Id != "bob@example.com" {
+		t.Fatalf("unexpected freebusy request: %+v", fbReq)
+	}
+	if len(got.Free) != 2 || got.Free[0].End != "2025-03-18T10:00:00+09:00" || got.Free[1].Start != "2025-03-18T11:00:00+09:00" {
+		t.Fatalf("unexpected free slots: %+v", got.Free)
+	}
+	if len(got.Days) != 5 || got.Days[0].Weekday != "Saturday" || !got.Days[2].OutOfOffice || got.Days[3].Location != "home" || got.Days[4].Location != "office: Shibuya" {
+		t.Fatalf("unexpected days: %+v", got.Days)
+	}
+}
+
+func TestCalendarWorkingHoursCmd_InvalidHours(t *testing.T) {
+	for _, hours := range []string{"9-5", "17:00-09:00", "09:00"} {
+		err := Execute([]string{"--account", "a@b.com", "calendar", "working-hours", "bob@example.com", "--hours", hours})
+		if err == nil || ExitCode(stableExitCode(err)) != 2 {
+			t.Fatalf("--hours %q: expected usage error, got %v", hours, err)
+		}
+	}
+}
+
+func TestFreeWorkingHoursSlots(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2025, 3, 18, h, 0, 0, 0, time.UTC) }
+	free := freeWorkingHoursSlots(at(9), at(17), [][2]time.Time{{at(12), at(18)}, {at(8), at(10)}, {at(11), at(13)}})
+	if len(free) != 1 || !free[0][0].Equal(at(10)) || !free[0][1].Equal(at(11)) {
+		t.Fatalf("unexpected free slots: %v", free)
+	}
+}
@@ -9,15 +9,16 @@ import (
 )
 
 type CalendarWorkingLocationCmd struct {
-	CalendarID  string `arg:"" name:"calendarId" help:"Calendar ID (default: primary)" default:"primary"`
-	From        string `name:"from" required:"" help:"Start date (YYYY-MM-DD)"`
-	To          string `name:"to" required:"" help:"End date (YYYY-MM-DD)"`
-	Type        string `name:"type" required:"" help:"Location type: home, office, custom"`
-	OfficeLabel string `name:"office-label" help:"Office name/label"`
-	BuildingId  string `name:"building-id" help:"Building ID"`
-	FloorId     string `name:"floor-id" help:"Floor ID"`
-	DeskId      string `name:"desk-id" help:"Desk ID"`
-	CustomLabel string `name:"custom-label" help:"Custom location label"`
+	CalendarID  string            `arg:"" name:"calendarId" help:"Calendar ID (default: primary)" default:"primary"`
+	From        string            `name:"from" required:"" help:"Start date (YYYY-MM-DD)"`
+	To          string            `name:"to" required:"" help:"End date (YYYY-MM-DD)"`
+	Type        string            `name:"type" required:"" help:"Location type: home, office, custom"`
+	OfficeLabel string            `name:"office-label" help:"Office name/label"`
+	BuildingId  string            `name:"building-id" help:"Building ID"`
+	FloorId     string            `name:"floor-id" help:"Floor ID"`
+	DeskId      string            `name:"desk-id" help:"Desk ID"`
+	CustomLabel string            `name:"custom-label" help:"Custom location label"`
+	ActAs       CalendarActAsFlag `embed:""`
 }
 
 func (c *CalendarWorkingLocationCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -49,7 +50,7 @@ func (c *CalendarWorkingLocationCmd) Run(ctx context.Context, flags *RootFlags)
 		return dryRunErr
 	}
 
-	mutation, err := newCalendarMutationContext(ctx, flags, calendarID)
+	mutation, err := newCalendarMutationContext(ctx, flags, calendarID, c.ActAs.As)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/locale"
+)
+
+// calendarDisplayZones controls which timezones event times are rendered in.
+// A nil Primary keeps the offsets returned by the API; Also adds one extra
+// column (or JSON entry) per zone so distributed teams can compare times.
+type calendarDisplayZones struct {
+	Primary *time.Location
+	Also    []*time.Location
+}
+
+type eventZoneTime struct {
+	Timezone string `json:"timezone"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+}
+
+func parseCalendarDisplayZones(tz string, also []string) (calendarDisplayZones, error) {
+	var zones calendarDisplayZones
+
+	loc, _, err := parseTimezoneValue("--tz", tz, true)
+	if err != nil {
+		return zones, usage(err.Error())
+	}
+	zones.Primary = loc
+
+	seen := map[string]bool{}
+	for _, raw := range also {
+		for _, name := range splitCSV(raw) {
+			extra, ok, err := parseTimezoneValue("--also-tz", name, true)
+			if err != nil {
+				return zones, usage(err.Error())
+			}
+			if !ok || seen[extra.String()] {
+				continue
+			}
+			seen[extra.String()] = true
+			zones.Also = append(zones.Also, extra)
+		}
+	}
+	return zones, nil
+}
+
+// active reports whether --tz or --also-tz was given.
+func (z calendarDisplayZones) active() bool {
+	return z.Primary != nil || len(z.Also) > 0
+}
+
+func (z calendarDisplayZones) start(e *calendar.Event) string {
+	if z.Primary == nil || e == nil {
+		return eventStart(e)
+	}
+	return formatEventLocal(e.Start, z.Primary)
+}
+
+func (z calendarDisplayZones) end(e *calendar.Event) string {
+	if z.Primary == nil || e == nil {
+		return eventEnd(e)
+	}
+	return formatEventLocal(e.End, z.Primary)
+}
+
+func (z calendarDisplayZones) daysOfWeek(e *calendar.Event) (string, string) {
+	if z.Primary == nil || e == nil {
+		return eventDaysOfWeek(e)
+	}
+	return dayOfWeekIn(e.Start, z.Primary), dayOfWeekIn(e.End, z.Primary)
+}
+
+// alsoTimes returns the event's start/end in every --also-tz zone.
+func (z calendarDisplayZones) alsoTimes(e *calendar.Event) []eventZoneTime {
+	if len(z.Also) == 0 || e == nil {
+		return nil
+	}
+	out := make([]eventZoneTime, 0, len(z.Also))
+	for _, loc := range z.Also {
+		out = append(out, eventZoneTime{
+			Timezone: loc.String(),
+			Start:    formatEventLocal(e.Start, loc),
+			End:      formatEventLocal(e.End, loc),
+		})
+	}
+	return out
+}
+
+func (z calendarDisplayZones) alsoHeaders() string {
+	var b strings.Builder
+	for _, loc := range z.Also {
+		b.WriteString("\t")
+		b.WriteString(strings.ToUpper(loc.String()))
+	}
+	return b.String()
+}
+
+func (z calendarDisplayZones) alsoCells(e *calendar.Event, l *locale.Locale) string {
+	var b strings.Builder
+	for _, loc := range z.Also {
+		b.WriteString("\t")
+		b.WriteString(formatEventSpanIn(e, loc, l))
+	}
+	return b.String()
+}
+
+// formatEventSpanIn renders a compact "2006-01-02 15:04–15:04" span in loc,
+// or in l's date and time style when set. The end date is repeated only when
+// the event crosses midnight in loc; all-day events keep their dates since
+// they have no wall-clock time.
+func formatEventSpanIn(e *calendar.Event, loc *time.Location, l *locale.Locale) string {
+	if e == nil || e.Start == nil {
+		return ""
+	}
+	if e.Start.DateTime == "" {
+		if t, err := time.Parse("2006-01-02", e.Start.Date); err == nil {
+			return l.Date(t)
+		}
+		return e.Start.Date
+	}
+	start, ok := parseEventTime(e.Start.DateTime, e.Start.TimeZone)
+	if !ok {
+		return e.Start.DateTime
+	}
+	start = start.In(loc)
+	out := l.DateTime(start)
+	if e.End == nil || e.End.DateTime == "" {
+		return out
+	}
+	end, ok := parseEventTime(e.End.DateTime, e.End.TimeZone)
+	if !ok {
+		return out
+	}
+	end = end.In(loc)
+	if end.Format("2006-01-02") != start.Format("2006-01-02") {
+		return out + "–" + l.DateTime(end)
+	}
+	return out + "–" + l.Time(end)
+}
+
+func dayOfWeekIn(dt *calendar.EventDateTime, loc *time.Location) string {
+	if dt == nil {
+		return ""
+	}
+	if dt.DateTime != "" {
+		if t, ok := parseEventTime(dt.DateTime, dt.TimeZone); ok {
+			return t.In(loc).Weekday().String()
+		}
+	}
+	return dayOfWeekFromEventDateTime(dt)
+}
+
+// wrapEventsWithZones is wrapEventsWithDays rendered in the requested zones.
+func wrapEventsWithZones(events []*calendar.Event, zones calendarDisplayZones) []*eventWithDays {
+	if !zones.active() {
+		return wrapEventsWithDays(events)
+	}
+	out := make([]*eventWithDays, 0, len(events))
+	for _, ev := range events {
+		var wrapped *eventWithDays
+		if zones.Primary != nil {
+			wrapped = wrapEventWithDaysWithTimezone(ev, zones.Primary.String(), zones.Primary)
+			if wrapped != nil {
+				wrapped.StartDayOfWeek, wrapped.EndDayOfWeek = zones.daysOfWeek(ev)
+			}
+		} else {
+			wrapped = wrapEventWithDaysWithTimezone(ev, "", nil)
+		}
+		if wrapped == nil {
+			continue
+		}
+		wrapped.AlsoTimes = zones.alsoTimes(ev)
+		wrapped.zoned = true
+		out = append(out, wrapped)
+	}
+	return out
+}
+
+// calendar.Event implements json.Marshaler, and embedding it promotes that
+// method, which drops the wrapper fields. Without --tz/--also-tz the JSON
+// stays the plain API event; with them, the computed fields are merged in.
+func (e eventWithDays) MarshalJSON() ([]byte, error) {
+	if !e.zoned {
+		return json.Marshal(e.Event)
+	}
+	return mergeEventJSON(e.Event, map[string]any{
+		"startDayOfWeek": e.StartDayOfWeek,
+		"endDayOfWeek":   e.EndDayOfWeek,
+		"timezone":       e.Timezone,
+		"eventTimezone":  e.EventTimezone,
+		"startLocal":     e.StartLocal,
+		"endLocal":       e.EndLocal,
+		"alsoTimezones":  e.AlsoTimes,
+	})
+}
+
+func (e eventWithCalendar) MarshalJSON() ([]byte, error) {
+	if !e.zoned {
+		return json.Marshal(e.Event)
+	}
+	return mergeEventJSON(e.Event, map[string]any{
+		"calendarId":     e.CalendarID,
+		"startDayOfWeek": e.StartDayOfWeek,
+		"endDayOfWeek":   e.EndDayOfWeek,
+		"timezone":       e.Timezone,
+		"startLocal":     e.StartLocal,
+		"endLocal":       e.EndLocal,
+		"alsoTimezones":  e.AlsoTimes,
+	})
+}
+
+func mergeEventJSON(event *calendar.Event, extras map[string]any) ([]byte, error) {
+	out := map[string]any{}
+	if event != nil {
+		b, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &out); err != nil {
+			return nil, err
+		}
+	}
+	for k, v := range extras {
+		switch val := v.(type) {
+		case string:
+			if val == "" {
+				continue
+			}
+		case []eventZoneTime:
+			if len(val) == 0 {
+				continue
+			}
+		}
+		out[k] = v
+	}
+	return json.Marshal(out)
+}
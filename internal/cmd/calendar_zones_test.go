@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestParseCalendarDisplayZones(t *testing.T) {
+	zones, err := parseCalendarDisplayZones("UTC", []string{"Europe/Berlin,America/New_York", "Europe/Berlin"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if zones.Primary == nil || zones.Primary.String() != "UTC" {
+		t.Fatalf("unexpected primary: %v", zones.Primary)
+	}
+	if len(zones.Also) != 2 || zones.Also[0].String() != "Europe/Berlin" || zones.Also[1].String() != "America/New_York" {
+		t.Fatalf("unexpected also zones: %v", zones.Also)
+	}
+
+	if _, err := parseCalendarDisplayZones("", []string{"Mars/Olympus"}); err == nil {
+		t.Fatalf("expected invalid zone error")
+	}
+}
+
+func TestFormatEventSpanIn(t *testing.T) {
+	zones, err := parseCalendarDisplayZones("", []string{"Asia/Tokyo"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	ev := &calendar.Event{
+		Start: &calendar.EventDateTime{DateTime: "2025-01-01T14:00:00Z"},
+		End:   &calendar.EventDateTime{DateTime: "2025-01-01T15:30:00Z"},
+	}
+	if got := formatEventSpanIn(ev, zones.Also[0], nil); got != "2025-01-01 23:00–2025-01-02 00:30" {
+		t.Fatalf("unexpected cross-midnight span: %q", got)
+	}
+	allDay := &calendar.Event{Start: &calendar.EventDateTime{Date: "2025-01-01"}}
+	if got := formatEventSpanIn(allDay, zones.Also[0], nil); got != "2025-01-01" {
+		t.Fatalf("unexpected all-day span: %q", got)
+	}
+}
+
+func TestListCalendarEvents_AlsoTimezones(t *testing.T) {
+	svc, closeServer := newCalendarServiceForTest(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/calendars/cal1/events") && r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"items": []map[string]any{
+					{"id": "e1", "summary": "Sync", "start": map[string]any{"dateTime": "2025-01-01T15:00:00Z"}, "end": map[string]any{"dateTime": "2025-01-01T16:00:00Z"}},
+				},
+			})
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer closeServer()
+
+	zones, err := parseCalendarDisplayZones("Europe/Berlin", []string{"America/New_York"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	jsonOut := captureStdout(t, func() {
+		if err := listCalendarEvents(newCalendarJSONContext(t), svc, "cal1", "2025-01-01T00:00:00Z", "2025-01-02T00:00:00Z", 10, "", false, false, "", "", "", "", false, zones); err != nil {
+			t.Fatalf("listCalendarEvents: %v", err)
+		}
+	})
+
+	var parsed struct {
+		Events []struct {
+			Timezone   string          `json:"timezone"`
+			StartLocal string          `json:"startLocal"`
+			AlsoTimes  []eventZoneTime `json:"alsoTimezones"`
+		} `json:"events"`
+	}
+	if err := json.Unmarshal([]byte(jsonOut), &parsed); err != nil {
+		t.Fatalf("json parse: %v", err)
+	}
+	if len(parsed.Events) != 1 {
+		t.Fatalf("unexpected events: %#v", parsed.Events)
+	}
+	ev := parsed.Events[0]
+	if ev.StartLocal != "2025-01-01T16:00:00+01:00" || ev.Timezone != "Europe/Berlin" {
+		t.Fatalf("unexpected primary zone output: %q %q\n%s", ev.StartLocal, ev.Timezone, jsonOut)
+	}
+	if len(ev.AlsoTimes) != 1 || ev.AlsoTimes[0].Timezone != "America/New_York" || ev.AlsoTimes[0].Start != "2025-01-01T10:00:00-05:00" {
+		t.Fatalf("unexpected also timezones: %#v", ev.AlsoTimes)
+	}
+
+	// Without --tz/--also-tz the JSON stays the plain API event.
+	plainOut := captureStdout(t, func() {
+		if err := listCalendarEvents(newCalendarJSONContext(t), svc, "cal1", "2025-01-01T00:00:00Z", "2025-01-02T00:00:00Z", 10, "", false, false, "", "", "", "", false, calendarDisplayZones{}); err != nil {
+			t.Fatalf("listCalendarEvents: %v", err)
+		}
+	})
+	for _, field := range []string{"startLocal", "startDayOfWeek", "timezone", "alsoTimezones"} {
+		if strings.Contains(plainOut, `"`+field+`"`) {
+			t.Fatalf("default JSON should not include %s:\n%s", field, plainOut)
+		}
+	}
+	if !strings.Contains(plainOut, `"summary": "Sync"`) {
+		t.Fatalf("unexpected default JSON:\n%s", plainOut)
+	}
+}
@@ -5,8 +5,8 @@ import (
 	"io"
 	"testing"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 func newCmdOutputContext(t *testing.T, stdout, stderr io.Writer) context.Context {
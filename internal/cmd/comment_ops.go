@@ -9,8 +9,8 @@ import (
 	"google.golang.org/api/drive/v3"
 	gapi "google.golang.org/api/googleapi"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 const (
@@ -155,7 +155,7 @@ func printExpandedCommentTable(ctx context.Context, comments []*drive.Comment) {
 			oneLineTSV(author),
 			quoted,
 			truncateString(oneLineTSV(comment.Content), 50),
-			formatDateTime(comment.CreatedTime),
+			humanDateTime(ctx, comment.CreatedTime),
 			comment.Resolved,
 			"",
 		)
@@ -173,7 +173,7 @@ func printExpandedCommentTable(ctx context.Context, comments []*drive.Comment) {
 				oneLineTSV(author),
 				"",
 				truncateString(oneLineTSV(reply.Content), 50),
-				formatDateTime(reply.CreatedTime),
+				humanDateTime(ctx, reply.CreatedTime),
 				"",
 				oneLineTSV(reply.Action),
 			)
@@ -209,7 +209,7 @@ func printCompactCommentTable(ctx context.Context, comments []*drive.Comment, in
 				author,
 				quoted,
 				content,
-				formatDateTime(comment.CreatedTime),
+				humanDateTime(ctx, comment.CreatedTime),
 				comment.Resolved,
 				replyCount,
 			)
@@ -219,7 +219,7 @@ func printCompactCommentTable(ctx context.Context, comments []*drive.Comment, in
 			comment.Id,
 			author,
 			content,
-			formatDateTime(comment.CreatedTime),
+			humanDateTime(ctx, comment.CreatedTime),
 			comment.Resolved,
 			replyCount,
 		)
@@ -6,7 +6,7 @@ import (
 	"os"
 
 	"github.com/steipete/gogcli/internal/config"
-	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type ConfigCmd struct {
@@ -25,8 +25,7 @@ func confirmDestructiveChecked(ctx context.Context, flags *RootFlags, action str
 		return nil
 	}
 
-	// Never prompt in non-interactive contexts.
-	if flags.NoInput || !term.IsTerminal(int(os.Stdin.Fd())) { //nolint:gosec // os file descriptor fits int on supported targets
+	if !canPrompt(flags) {
 		return usagef("refusing to %s without --force (non-interactive)", action)
 	}
 
@@ -45,6 +44,12 @@ func confirmDestructiveChecked(ctx context.Context, flags *RootFlags, action str
 	return &ExitError{Code: 1, Err: errors.New("cancelled")}
 }
 
+// canPrompt reports whether a confirmation may be asked for; we never prompt
+// in non-interactive contexts.
+func canPrompt(flags *RootFlags) bool {
+	return !flags.NoInput && term.IsTerminal(int(os.Stdin.Fd())) //nolint:gosec // os file descriptor fits int on supported targets
+}
+
 func flagsWithoutDryRun(flags *RootFlags) *RootFlags {
 	if flags == nil {
 		return nil
@@ -10,9 +10,9 @@ import (
 	"github.com/alecthomas/kong"
 	"google.golang.org/api/people/v1"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/timeparse"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 const (
@@ -22,8 +22,9 @@ const (
 )
 
 type ContactsListCmd struct {
-	Max  int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
-	Page string `name:"page" help:"Page token"`
+	Max   int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
+	Page  string `name:"page" help:"Page token"`
+	Group string `name:"group" help:"Only contacts in this contact group (name or contactGroups/ID)"`
 }
 
 func (c *ContactsListCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -32,19 +33,36 @@ func (c *ContactsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	group := strings.TrimSpace(c.Group)
+	if group != "" && c.Page != "" {
+		return usage("--page cannot be combined with --group")
+	}
 
 	svc, err := newPeopleContactsService(ctx, account)
 	if err != nil {
 		return err
 	}
 
-	resp, err := svc.People.Connections.List(peopleMeResource).
-		PersonFields(contactsReadMask).
-		PageSize(c.Max).
-		PageToken(c.Page).
-		Do()
-	if err != nil {
-		return err
+	var resp *people.ListConnectionsResponse
+	if group != "" {
+		resourceName, resolveErr := resolveContactGroup(ctx, svc, group)
+		if resolveErr != nil {
+			return resolveErr
+		}
+		members, listErr := listContactGroupMembers(ctx, svc, resourceName, c.Max)
+		if listErr != nil {
+			return listErr
+		}
+		resp = &people.ListConnectionsResponse{Connections: members}
+	} else {
+		resp, err = svc.People.Connections.List(peopleMeResource).
+			PersonFields(contactsReadMask).
+			PageSize(c.Max).
+			PageToken(c.Page).
+			Do()
+		if err != nil {
+			return err
+		}
 	}
 	if outfmt.IsJSON(ctx) {
 		type item struct {
@@ -0,0 +1,407 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/mail"
+	"os"
+	"regexp"
+	"strings"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/people/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// ContactsEnrichCmd proposes phone, title, and company updates for
+// correspondents, read from the signatures of their recent messages.
+type ContactsEnrichCmd struct {
+	Query string `name:"query" short:"q" required:"" help:"Gmail search for the correspondent's messages (e.g. from:person@example.com)"`
+	Max   int64  `name:"max" aliases:"limit" help:"Max messages to scan" default:"20"`
+	Apply bool   `name:"apply" help:"Update the contacts after confirmation (--force skips the prompt)"`
+}
+
+type emailSignature struct {
+	Phone   string `json:"phone,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Company string `json:"company,omitempty"`
+}
+
+type contactEnrichChange struct {
+	Field    string `json:"field"`
+	Current  string `json:"current,omitempty"`
+	Proposed string `json:"proposed"`
+}
+
+type contactEnrichment struct {
+	Email     string                `json:"email"`
+	Name      string                `json:"name,omitempty"`
+	Resource  string                `json:"resource,omitempty"`
+	Messages  int                   `json:"messages"`
+	Signature emailSignature        `json:"signature"`
+	Changes   []contactEnrichChange `json:"changes,omitempty"`
+	Note      string                `json:"note,omitempty"`
+
+	person *people.Person
+}
+
+func (c *ContactsEnrichCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	query := strings.TrimSpace(c.Query)
+	if query == "" {
+		return usage("empty --query")
+	}
+	if c.Max <= 0 {
+		return usage("--max must be > 0")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	gsvc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+	list, err := gsvc.Users.Messages.List("me").Q(query).MaxResults(c.Max).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	// Messages come newest first, so the first value seen for a field wins.
+	var results []*contactEnrichment
+	bySender := make(map[string]*contactEnrichment)
+	for _, m := range list.Messages {
+		if m == nil || m.Id == "" {
+			continue
+		}
+		msg, getErr := gsvc.Users.Messages.Get("me", m.Id).Format("full").Context(ctx).Do()
+		if getErr != nil {
+			return getErr
+		}
+		addr, parseErr := mail.ParseAddress(headerValue(msg.Payload, "From"))
+		if parseErr != nil || strings.EqualFold(addr.Address, account) {
+			continue
+		}
+		email := strings.ToLower(addr.Address)
+		r := bySender[email]
+		if r == nil {
+			r = &contactEnrichment{Email: email, Name: addr.Name}
+			bySender[email] = r
+			results = append(results, r)
+		}
+		r.Messages++
+		sig := parseEmailSignature(contactsEnrichBody(msg.Payload), addr.Name)
+		if r.Signature.Phone == "" {
+			r.Signature.Phone = sig.Phone
+		}
+		if r.Signature.Title == "" && r.Signature.Company == "" {
+			r.Signature.Title, r.Signature.Company = sig.Title, sig.Company
+		}
+	}
+
+	psvc, err := newPeopleContactsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	pending := 0
+	for _, r := range results {
+		if err := proposeContactEnrichment(ctx, psvc, r); err != nil {
+			return err
+		}
+		if r.person != nil && len(r.Changes) > 0 {
+			pending++
+		}
+	}
+
+	applied := 0
+	if c.Apply && pending > 0 {
+		if !outfmt.IsJSON(ctx) {
+			printContactEnrichments(ctx, results)
+		}
+		if err := dryRunAndConfirmDestructive(ctx, flags, "contacts.enrich", map[string]any{"contacts": results},
+			fmt.Sprintf("update %d contact%s", pending, pluralS(pending))); err != nil {
+			return err
+		}
+		for _, r := range results {
+			if r.person == nil || len(r.Changes) == 0 {
+				continue
+			}
+			if err := applyContactEnrichment(ctx, psvc, r); err != nil {
+				return fmt.Errorf("update %s: %w", r.Resource, err)
+			}
+			applied++
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"contacts": results,
+			"applied":  applied,
+		})
+	}
+	if len(results) == 0 {
+		u.Err().Println("No messages found")
+		return nil
+	}
+	if !c.Apply || pending == 0 {
+		printContactEnrichments(ctx, results)
+	}
+	switch {
+	case applied > 0:
+		u.Err().Printf("Updated %d contact%s", applied, pluralS(applied))
+	case pending > 0:
+		u.Err().Println("Run with --apply to update these contacts")
+	}
+	return nil
+}
+
+func printContactEnrichments(ctx context.Context, results []*contactEnrichment) {
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "EMAIL\tFIELD\tCURRENT\tPROPOSED")
+	for _, r := range results {
+		if len(r.Changes) == 0 {
+			note := r.Note
+			if note == "" {
+				note = "up to date"
+			}
+			fmt.Fprintf(w, "%s\t-\t-\t%s\n", r.Email, sanitizeTab(note))
+			continue
+		}
+		for _, ch := range r.Changes {
+			proposed := ch.Proposed
+			if r.Note != "" {
+				proposed += " (" + r.Note + ")"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Email, ch.Field, sanitizeTab(ch.Current), sanitizeTab(proposed))
+		}
+	}
+}
+
+// proposeContactEnrichment finds the saved contact for r.Email and lists the
+// signature values it is missing.
+func proposeContactEnrichment(ctx context.Context, svc *people.Service, r *contactEnrichment) error {
+	resp, err := svc.People.SearchContacts().Query(r.Email).ReadMask(contactsReadMask).PageSize(10).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	for _, res := range resp.Results {
+		if res == nil || res.Person == nil {
+			continue
+		}
+		for _, e := range res.Person.EmailAddresses {
+			if e != nil && strings.EqualFold(e.Value, r.Email) {
+				r.person = res.Person
+				break
+			}
+		}
+		if r.person != nil {
+			break
+		}
+	}
+
+	sig := r.Signature
+	var curOrg, curTitle string
+	if r.person != nil {
+		r.Resource = r.person.ResourceName
+		if len(r.person.Organizations) > 0 && r.person.Organizations[0] != nil {
+			curOrg, curTitle = r.person.Organizations[0].Name, r.person.Organizations[0].Title
+		}
+	}
+	if sig.Phone != "" && (r.person == nil || !contactHasPhone(r.person, sig.Phone)) {
+		r.Changes = append(r.Changes, contactEnrichChange{Field: "phone", Current: primaryPhone(r.person), Proposed: sig.Phone})
+	}
+	if sig.Title != "" && !strings.EqualFold(sig.Title, curTitle) {
+		r.Changes = append(r.Changes, contactEnrichChange{Field: "title", Current: curTitle, Proposed: sig.Title})
+	}
+	if sig.Company != "" && !strings.EqualFold(sig.Company, curOrg) {
+		r.Changes = append(r.Changes, contactEnrichChange{Field: "company", Current: curOrg, Proposed: sig.Company})
+	}
+	switch {
+	case sig == emailSignature{}:
+		r.Note = "no signature found"
+	case r.person == nil:
+		r.Note = "no saved contact; use contacts create"
+	}
+	return nil
+}
+
+func applyContactEnrichment(ctx context.Context, svc *people.Service, r *contactEnrichment) error {
+	existing, err := svc.People.Get(r.Resource).PersonFields(contactsUpdateReadMask).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	var fields []string
+	var org, title string
+	var orgSet, titleSet bool
+	for _, ch := range r.Changes {
+		switch ch.Field {
+		case "phone":
+			existing.PhoneNumbers = append(existing.PhoneNumbers, &people.PhoneNumber{Value: ch.Proposed, Type: "work"})
+			fields = append(fields, "phoneNumbers")
+		case "title":
+			title, titleSet = ch.Proposed, true
+		case "company":
+			org, orgSet = ch.Proposed, true
+		}
+	}
+	if orgSet || titleSet {
+		contactsApplyPersonOrganization(existing, orgSet, org, titleSet, title)
+		fields = append(fields, "organizations")
+	}
+	_, err = svc.People.UpdateContact(r.Resource, existing).
+		UpdatePersonFields(strings.Join(fields, ",")).
+		Context(ctx).
+		Do()
+	return err
+}
+
+func contactHasPhone(p *people.Person, phone string) bool {
+	want := phoneDigits(phone)
+	for _, n := range p.PhoneNumbers {
+		if n == nil {
+			continue
+		}
+		have := phoneDigits(n.Value)
+		// Compare national numbers so +1 415... matches (415) ....
+		if have != "" && (strings.HasSuffix(have, want) || strings.HasSuffix(want, have)) {
+			return true
+		}
+	}
+	return false
+}
+
+func phoneDigits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+var (
+	sigBlockTagRe  = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|tr|li|h[1-6])>`)
+	sigPhoneRe     = regexp.MustCompile(`\+?\(?\d[\d\s().\-]{6,}\d`)
+	sigReplyHeader = regexp.MustCompile(`^On .+ wrote:$`)
+	sigTitleSplit  = regexp.MustCompile(`\s+(?:\||–|—|-|@|at)\s+|,\s+`)
+)
+
+// contactsEnrichBody returns the plain text of a message, converting HTML
+// line breaks so signature lines survive.
+func contactsEnrichBody(p *gmail.MessagePart) string {
+	if plain := findPartBody(p, "text/plain"); plain != "" {
+		return plain
+	}
+	body := findPartBody(p, "text/html")
+	if body == "" {
+		return ""
+	}
+	body = scriptPattern.ReplaceAllString(body, "")
+	body = stylePattern.ReplaceAllString(body, "")
+	body = sigBlockTagRe.ReplaceAllString(body, "\n")
+	return html.UnescapeString(htmlTagPattern.ReplaceAllString(body, ""))
+}
+
+// parseEmailSignature pulls a phone number, job title, and company from the
+// signature at the end of body. The signature starts after a "-- " line, or
+// is taken as the last few lines before any quoted reply. Title and company
+// are only read from lines under the sender's name so that ordinary prose is
+// not mistaken for them.
+func parseEmailSignature(body, senderName string) emailSignature {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	var own []string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, ">") || sigReplyHeader.MatchString(trimmed) ||
+			strings.HasPrefix(trimmed, "-----Original Message") || strings.HasPrefix(trimmed, "From: ") {
+			break
+		}
+		own = append(own, strings.TrimRight(line, " \t"))
+	}
+
+	delimited := false
+	for i := len(own) - 1; i >= 0; i-- {
+		if own[i] == "--" || own[i] == "-- " {
+			own, delimited = own[i+1:], true
+			break
+		}
+	}
+	var block []string
+	for _, line := range own {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(strings.ToLower(trimmed), "sent from my") {
+			continue
+		}
+		block = append(block, trimmed)
+	}
+	if !delimited && len(block) > 8 {
+		block = block[len(block)-8:]
+	}
+
+	var sig emailSignature
+	for _, line := range block {
+		if m := sigPhoneRe.FindString(line); m != "" {
+			if digits := len(phoneDigits(m)); digits >= 7 && digits <= 15 {
+				sig.Phone = strings.TrimSpace(m)
+				break
+			}
+		}
+	}
+
+	nameIdx := -1
+	name := strings.ToLower(strings.TrimSpace(senderName))
+	for i, line := range block {
+		if name != "" && strings.EqualFold(strings.Trim(line, " ,.*_"), name) {
+			nameIdx = i
+			break
+		}
+	}
+	if nameIdx < 0 && delimited && len(block) > 0 && !isSignatureContactLine(block[0]) {
+		nameIdx = 0
+	}
+	if nameIdx < 0 {
+		return sig
+	}
+	var rest []string
+	for _, line := range block[nameIdx+1:] {
+		if isSignatureContactLine(line) || len(line) > 60 {
+			break
+		}
+		rest = append(rest, strings.Trim(line, " *_"))
+		if len(rest) == 2 {
+			break
+		}
+	}
+	switch len(rest) {
+	case 0:
+	case 1:
+		// A single line is usually "Title, Company" or "Title at Company".
+		if parts := sigTitleSplit.Split(rest[0], 2); len(parts) == 2 {
+			sig.Title, sig.Company = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		} else {
+			sig.Title = rest[0]
+		}
+	default:
+		sig.Title, sig.Company = rest[0], rest[1]
+	}
+	return sig
+}
+
+// isSignatureContactLine reports lines holding an address, link, or number
+// rather than a title or company.
+func isSignatureContactLine(line string) bool {
+	lower := strings.ToLower(line)
+	if strings.Contains(lower, "@") && !strings.Contains(lower, " @ ") {
+		return true
+	}
+	if strings.Contains(lower, "http") || strings.Contains(lower, "www.") {
+		return true
+	}
+	return len(phoneDigits(line)) >= 7
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+	"google.golang.org/api/people/v1"
+)
+
+func TestParseEmailSignature(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want emailSignature
+	}{
+		{
+			name: "delimited",
+			body: "Thanks, see you then.\n\n-- \nJane Doe\nVP Engineering\nAcme Corp\nM: +1 (415) 555-0134\njane@acme.com\n",
+			want: emailSignature{Phone: "+1 (415) 555-0134", Title: "VP Engineering", Company: "Acme Corp"},
+		},
+		{
+			name: "name line with quoted reply",
+			body: "Sounds good.\n\nBest,\nJane Doe\nHead of Sales | Initech\n415.555.0199\n\nOn Mon, Jan 6, 2025 at 9:00 AM Bob <bob@x.com> wrote:\n> Bob Smith\n> CTO\n> 212-555-0100\n",
+			want: emailSignature{Phone: "415.555.0199", Title: "Head of Sales", Company: "Initech"},
+		},
+		{
+			name: "prose only",
+			body: "Meeting moved to 3pm, room 12.\n",
+			want: emailSignature{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := parseEmailSignature(tc.body, "Jane Doe"); got != tc.want {
+				t.Fatalf("got %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestContactsEnrichCmd_Apply(t *testing.T) {
+	origGmail := newGmailService
+	t.Cleanup(func() { newGmailService = origGmail })
+
+	body := base64.URLEncoding.EncodeToString([]byte("Hi!\n\n--\nJane Doe\nCTO, Acme\n+1 415 555 0134\n"))
+	gsrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/users/me/messages"):
+			if q := r.URL.Query().Get("q"); q != "from:jane@acme.com" {
+				t.Errorf("unexpected query %q", q)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"messages": []any{map[string]any{"id": "m1"}}})
+		case strings.HasSuffix(r.URL.Path, "/users/me/messages/m1"):
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id": "m1",
+				"payload": map[string]any{
+					"mimeType": "text/plain",
+					"headers":  []any{map[string]any{"name": "From", "value": "Jane Doe <Jane@acme.com>"}},
+					"body":     map[string]any{"data": body},
+				},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(gsrv.Close)
+	gsvc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(gsrv.Client()),
+		option.WithEndpoint(gsrv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return gsvc, nil }
+
+	var updateFields string
+	var updated people.Person
+	psvc, closeSrv := newPeopleService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		contact := map[string]any{
+			"resourceName":   "people/c1",
+			"etag":           "e1",
+			"emailAddresses": []any{map[string]any{"value": "jane@acme.com"}},
+			"phoneNumbers":   []any{map[string]any{"value": "555-0000"}},
+			"organizations":  []any{map[string]any{"name": "Acme", "title": "VP"}},
+		}
+		switch {
+		case strings.Contains(r.URL.Path, "searchContacts"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"results": []any{map[string]any{"person": contact}}})
+		case strings.Contains(r.URL.Path, ":updateContact"):
+			updateFields = r.URL.Query().Get("updatePersonFields")
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			_ = json.NewEncoder(w).Encode(contact)
+		case strings.Contains(r.URL.Path, "people/c1"):
+			_ = json.NewEncoder(w).Encode(contact)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(closeSrv)
+	stubPeopleServices(t, psvc)
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &ContactsEnrichCmd{}, []string{"-q", "from:jane@acme.com", "--apply"}, newCalendarJSONContext(t), &RootFlags{Account: "me@example.com", Force: true}); err != nil {
+			t.Fatalf("runKong: %v", err)
+		}
+	})
+
+	var got struct {
+		Contacts []contactEnrichment `json:"contacts"`
+		Applied  int                 `json:"applied"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if got.Applied != 1 || len(got.Contacts) != 1 || len(got.Contacts[0].Changes) != 2 {
+		t.Fatalf("unexpected result: %#v", got)
+	}
+	if ch := got.Contacts[0].Changes; ch[0].Field != "phone" || ch[0].Proposed != "+1 415 555 0134" || ch[1].Field != "title" || ch[1].Proposed != "CTO" {
+		t.Fatalf("unexpected changes: %#v", ch)
+	}
+	if updateFields != "phoneNumbers,organizations" {
+		t.Fatalf("unexpected update fields: %q", updateFields)
+	}
+	if len(updated.PhoneNumbers) != 2 || len(updated.Organizations) != 1 || updated.Organizations[0].Title != "CTO" || updated.Organizations[0].Name != "Acme" {
+		t.Fatalf("unexpected update body: %#v", updated)
+	}
+}
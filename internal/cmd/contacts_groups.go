@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/people/v1"
+
+	"github.com/steipete/gogcli/internal/idcache"
+)
+
+const (
+	contactGroupResourcePrefix = "contactGroups/"
+	// peopleBatchGetMax is the most resource names people.getBatchGet accepts.
+	peopleBatchGetMax = 200
+)
+
+// resolveContactGroup turns a contact group name (case-insensitive, e.g.
+// "Family" or "myContacts") into its resource name, through the name cache.
+// Resource names (contactGroups/...) pass through without an API call.
+func resolveContactGroup(ctx context.Context, svc *people.Service, input string) (string, error) {
+	in := strings.TrimSpace(input)
+	if in == "" {
+		return "", usage("empty --group")
+	}
+	if strings.HasPrefix(in, contactGroupResourcePrefix) {
+		return in, nil
+	}
+
+	key := strings.ToLower(in)
+	names, err := cachedNameMap(ctx, idcache.KindContactGroups, "", []string{key}, func() (map[string]string, error) {
+		return fetchContactGroupNames(ctx, svc)
+	})
+	if err != nil {
+		return "", err
+	}
+	resourceName, ok := names[key]
+	if !ok {
+		return "", usagef("unknown contact group %q", in)
+	}
+	if resourceName == "" {
+		return "", usagef("ambiguous contact group %q: use its contactGroups/... resource name", in)
+	}
+	return resourceName, nil
+}
+
+// fetchContactGroupNames maps lower-cased group names (both the stored and
+// the display name, which differ for system groups) to resource names. Names
+// shared by two groups map to "" so lookups can report them as ambiguous.
+func fetchContactGroupNames(ctx context.Context, svc *people.Service) (map[string]string, error) {
+	out := map[string]string{}
+	pageToken := ""
+	for {
+		call := svc.ContactGroups.List().PageSize(1000).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, fmt.Errorf("list contact groups: %w", err)
+		}
+		for _, g := range resp.ContactGroups {
+			if g == nil || g.ResourceName == "" {
+				continue
+			}
+			for _, name := range []string{g.Name, g.FormattedName} {
+				key := strings.ToLower(strings.TrimSpace(name))
+				if key == "" {
+					continue
+				}
+				if existing, dup := out[key]; dup && existing != g.ResourceName {
+					out[key] = ""
+					continue
+				}
+				out[key] = g.ResourceName
+			}
+		}
+		if resp.NextPageToken == "" {
+			return out, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// listContactGroupMembers returns up to maxMembers contacts of a group.
+func listContactGroupMembers(ctx context.Context, svc *people.Service, resourceName string, maxMembers int64) ([]*people.Person, error) {
+	group, err := svc.ContactGroups.Get(resourceName).MaxMembers(maxMembers).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("get contact group %s: %w", resourceName, err)
+	}
+
+	members := group.MemberResourceNames
+	out := make([]*people.Person, 0, len(members))
+	for start := 0; start < len(members); start += peopleBatchGetMax {
+		chunk := members[start:min(start+peopleBatchGetMax, len(members))]
+		resp, err := svc.People.GetBatchGet().
+			ResourceNames(chunk...).
+			PersonFields(contactsReadMask).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, fmt.Errorf("get contact group members: %w", err)
+		}
+		for _, r := range resp.Responses {
+			if r != nil && r.Person != nil {
+				out = append(out, r.Person)
+			}
+		}
+	}
+	return out, nil
+}
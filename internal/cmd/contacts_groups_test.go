@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/people/v1"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestContactsListCmd_GroupResolvedThroughNameCache(t *testing.T) {
+	origContacts := newPeopleContactsService
+	t.Cleanup(func() { newPeopleContactsService = origContacts })
+
+	listCalls := 0
+	var batchNames []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/contactGroups":
+			listCalls++
+			_ = json.NewEncoder(w).Encode(map[string]any{"contactGroups": []map[string]any{
+				{"resourceName": "contactGroups/myContacts", "name": "myContacts", "formattedName": "My Contacts"},
+				{"resourceName": "contactGroups/abc", "name": "Family", "formattedName": "Family"},
+				{"resourceName": "contactGroups/d1", "name": "Team", "formattedName": "Team"},
+				{"resourceName": "contactGroups/d2", "name": "team", "formattedName": "team"},
+			}})
+		case r.URL.Path == "/v1/contactGroups/abc":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"resourceName":        "contactGroups/abc",
+				"memberResourceNames": []string{"people/p1", "people/p2"},
+			})
+		case r.URL.Path == "/v1/people:batchGet":
+			batchNames = r.URL.Query()["resourceNames"]
+			responses := []map[string]any{}
+			for _, rn := range batchNames {
+				responses = append(responses, map[string]any{"person": map[string]any{
+					"resourceName": rn,
+					"names":        []map[string]any{{"displayName": strings.TrimPrefix(rn, "people/")}},
+				}})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"responses": responses})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc, err := people.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newPeopleContactsService = func(context.Context, string) (*people.Service, error) { return svc, nil }
+
+	ctx := outfmt.WithMode(withTestNameCache(t), outfmt.Mode{JSON: true})
+	flags := &RootFlags{Account: "a@b.com"}
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &ContactsListCmd{}, []string{"--group", "family"}, ctx, flags); err != nil {
+			t.Fatalf("list --group: %v", err)
+		}
+	})
+	var parsed struct {
+		Contacts []struct {
+			Resource string `json:"resource"`
+		} `json:"contacts"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil || len(parsed.Contacts) != 2 || parsed.Contacts[0].Resource != "people/p1" {
+		t.Fatalf("unexpected output %q: %v", out, err)
+	}
+	if strings.Join(batchNames, ",") != "people/p1,people/p2" {
+		t.Fatalf("unexpected batch get: %v", batchNames)
+	}
+
+	// Further lookups, including the system group's display name, come from the cache.
+	for _, name := range []string{"Family", "my contacts"} {
+		if _, err := resolveContactGroup(ctx, svc, name); err != nil {
+			t.Fatalf("resolve %q: %v", name, err)
+		}
+	}
+	if listCalls != 1 {
+		t.Fatalf("expected one contactGroups.list call, got %d", listCalls)
+	}
+
+	if got, err := resolveContactGroup(ctx, svc, "contactGroups/xyz"); err != nil || got != "contactGroups/xyz" {
+		t.Fatalf("resource name should pass through: %q %v", got, err)
+	}
+	if _, err := resolveContactGroup(ctx, svc, "Team"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected ambiguous error, got %v", err)
+	}
+	if _, err := resolveContactGroup(ctx, svc, "Nope"); err == nil || !strings.Contains(err.Error(), "unknown contact group") {
+		t.Fatalf("expected unknown group error, got %v", err)
+	}
+	if err := runKong(t, &ContactsListCmd{}, []string{"--group", "Family", "--page", "x"}, ctx, flags); err == nil {
+		t.Fatalf("expected --page with --group to fail")
+	}
+}
@@ -11,35 +11,56 @@ import (
 	gapi "google.golang.org/api/googleapi"
 
 	"github.com/steipete/gogcli/internal/googleapi"
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 var newDocsService = googleapi.NewDocs
 
 type DocsCmd struct {
-	Export      DocsExportCmd      `cmd:"" name:"export" aliases:"download,dl" help:"Export a Google Doc (pdf|docx|txt|md)"`
+	Export      DocsExportCmd      `cmd:"" name:"export" aliases:"download,dl" help:"Export a Google Doc (pdf|docx|txt|md|html|epub)"`
+	ExportAll   DocsExportAllCmd   `cmd:"" name:"export-all" help:"Export a Google Doc in several formats at once, with a manifest"`
 	Info        DocsInfoCmd        `cmd:"" name:"info" aliases:"get,show" help:"Get Google Doc metadata"`
 	Create      DocsCreateCmd      `cmd:"" name:"create" aliases:"add,new" help:"Create a Google Doc"`
 	Copy        DocsCopyCmd        `cmd:"" name:"copy" aliases:"cp,duplicate" help:"Copy a Google Doc"`
+	Template    DocsTemplateCmd    `cmd:"" name:"template" help:"Copy a template doc and fill {{placeholders}} from --data/--set"`
 	Cat         DocsCatCmd         `cmd:"" name:"cat" aliases:"text,read" help:"Print a Google Doc as plain text"`
+	Access      DocsAccessCmd      `cmd:"" name:"access" help:"Show who can access a doc and whether each grant is direct or inherited from a folder or shared drive"`
 	Comments    DocsCommentsCmd    `cmd:"" name:"comments" help:"Manage comments on files"`
 	ListTabs    DocsListTabsCmd    `cmd:"" name:"list-tabs" help:"List all tabs in a Google Doc"`
+	Tabs        DocsTabsCmd        `cmd:"" name:"tabs" help:"Work with document tabs"`
+	Ranges      DocsRangesCmd      `cmd:"" name:"ranges" aliases:"named-ranges" help:"List, create, and delete named ranges"`
 	Write       DocsWriteCmd       `cmd:"" name:"write" help:"Write content to a Google Doc"`
 	Insert      DocsInsertCmd      `cmd:"" name:"insert" help:"Insert text at a specific position"`
+	Merge       DocsMergeCmd       `cmd:"" name:"merge" help:"Append other docs to a doc, keeping headings, lists, tables, and text styles"`
+	Header      DocsHeaderCmd      `cmd:"" name:"header" help:"Print, set, or remove the default page header"`
+	Footer      DocsFooterCmd      `cmd:"" name:"footer" help:"Print, set, or remove the default page footer"`
+	Stamp       DocsStampCmd       `cmd:"" name:"stamp" help:"Write or replace a single managed provenance line at the end of a doc"`
 	Delete      DocsDeleteCmd      `cmd:"" name:"delete" help:"Delete text range from document"`
 	FindReplace DocsFindReplaceCmd `cmd:"" name:"find-replace" help:"Find and replace text. Supports plain text or markdown with images; use --first for a single occurrence."`
+	Replace     DocsReplaceCmd     `cmd:"" name:"replace" help:"Replace all matches of --find (plain or --regex) and report replacement counts"`
+	Suggestions DocsSuggestionsCmd `cmd:"" name:"suggestions" aliases:"suggest" help:"List, accept, or reject suggested edits"`
+	Revisions   DocsRevisionsCmd   `cmd:"" name:"revisions" aliases:"history" help:"List revisions of a Google Doc (use with docs export --revision)"`
+	Diff        DocsDiffCmd        `cmd:"" name:"diff" help:"Unified diff between two docs or revisions"`
+	Watch       DocsWatchCmd       `cmd:"" name:"watch" help:"Poll a Google Doc and print a JSON line each time it changes"`
 	Update      DocsUpdateCmd      `cmd:"" name:"update" help:"Insert text at a specific index in a Google Doc"`
 	Edit        DocsEditCmd        `cmd:"" name:"edit" help:"Find and replace text in a Google Doc"`
 	Sed         DocsSedCmd         `cmd:"" name:"sed" help:"Regex find/replace (sed-style: s/pattern/replacement/g)"`
 	Clear       DocsClearCmd       `cmd:"" name:"clear" help:"Clear all content from a Google Doc"`
 	Structure   DocsStructureCmd   `cmd:"" name:"structure" aliases:"struct" help:"Show document structure with numbered paragraphs"`
+	Outline     DocsOutlineCmd     `cmd:"" name:"outline" aliases:"toc" help:"Show the heading hierarchy with start/end indexes and tab IDs"`
+	Grep        DocsGrepCmd        `cmd:"" name:"grep" help:"Search paragraphs with a regex; print matches with indexes, tab, and heading"`
+	Images      DocsImagesCmd      `cmd:"" name:"images" help:"Export images embedded in a Google Doc"`
+	Stats       DocsStatsCmd       `cmd:"" name:"stats" aliases:"wc" help:"Show word, character, paragraph, heading, and image counts with reading time"`
+	Linkcheck   DocsLinkcheckCmd   `cmd:"" name:"linkcheck" aliases:"links" help:"Check hyperlinks (HTTP status, Drive files, heading anchors) and report broken ones"`
 }
 
 type DocsExportCmd struct {
-	DocID  string         `arg:"" name:"docId" help:"Doc ID"`
-	Output OutputPathFlag `embed:""`
-	Format string         `name:"format" help:"Export format: pdf|docx|txt|md|html" default:"pdf"`
+	DocID    string                 `arg:"" name:"docId" help:"Doc ID"`
+	Output   OutputPathFlag         `embed:""`
+	Name     ExportNameTemplateFlag `embed:""`
+	Format   string                 `name:"format" help:"Export format: pdf|docx|txt|md|html|epub" default:"pdf"`
+	Revision string                 `name:"revision" help:"Export a historical revision (see docs revisions)"`
 }
 
 func (c *DocsExportCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -48,6 +69,8 @@ func (c *DocsExportCmd) Run(ctx context.Context, flags *RootFlags) error {
 		ExpectedMime:  "application/vnd.google-apps.document",
 		KindLabel:     "Google Doc",
 		DefaultFormat: "pdf",
+		Revision:      c.Revision,
+		NameTemplate:  c.Name.NameTemplate,
 	}, c.DocID, c.Output.Path, c.Format)
 }
 
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// docsAccessMaxDepth bounds the parent-folder walk.
+const docsAccessMaxDepth = 25
+
+const docsAccessPermissionFields = "nextPageToken, permissions(id, type, role, emailAddress, domain, displayName, deleted, permissionDetails(permissionType, role, inherited, inheritedFrom))"
+
+type DocsAccessCmd struct {
+	DocID string `arg:"" name:"docId" help:"Doc ID (any Drive file ID works)"`
+	User  string `name:"user" help:"Only show grants that cover this email (user, domain, or anyone with the link; group membership is not expanded)"`
+}
+
+// docsAccessSource says where a grant comes from.
+type docsAccessSource struct {
+	Kind string `json:"kind"` // direct|folder|sharedDrive
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type docsAccessEntry struct {
+	PermissionID string           `json:"permissionId"`
+	Type         string           `json:"type"`
+	Role         string           `json:"role"`
+	Who          string           `json:"who"`
+	Source       docsAccessSource `json:"source"`
+}
+
+type docsAccessFolder struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"`
+
+	perms []*drive.Permission
+}
+
+func (c *DocsAccessCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	id := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if id == "" {
+		return usage("empty docId")
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+
+	file, err := svc.Files.Get(id).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType, parents, driveId, webViewLink").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	perms, err := listAllPermissions(ctx, svc, id)
+	if err != nil {
+		return err
+	}
+
+	var driveName string
+	if file.DriveId != "" {
+		if d, driveErr := svc.Drives.Get(file.DriveId).Fields("id, name").Context(ctx).Do(); driveErr == nil {
+			driveName = d.Name
+		}
+	}
+
+	// Shared drive items report inheritance directly; for My Drive items we
+	// match grants against the permissions of each ancestor folder.
+	ancestors := docsAccessAncestors(ctx, svc, file.Parents, file.DriveId)
+	entries := buildDocsAccessEntries(perms, ancestors, file.DriveId, driveName)
+	if user := strings.TrimSpace(c.User); user != "" {
+		entries = filterDocsAccessEntries(entries, user)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			strFile:     file,
+			"access":    entries,
+			"ancestors": ancestors,
+		})
+	}
+
+	if len(entries) == 0 {
+		if strings.TrimSpace(c.User) != "" {
+			u.Err().Printf("No grant covers %s (they may have access through a group)", c.User)
+		} else {
+			u.Err().Println("No permissions")
+		}
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "WHO\tTYPE\tROLE\tSOURCE")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", e.Who, e.Type, e.Role, e.Source.label())
+	}
+	return nil
+}
+
+func (s docsAccessSource) label() string {
+	switch s.Kind {
+	case "folder":
+		return fmt.Sprintf("folder %q (%s)", s.Name, s.ID)
+	case "sharedDrive":
+		if s.Name != "" {
+			return fmt.Sprintf("shared drive %q (%s)", s.Name, s.ID)
+		}
+		return "shared drive " + s.ID
+	default:
+		return s.Kind
+	}
+}
+
+func listAllPermissions(ctx context.Context, svc *drive.Service, fileID string) ([]*drive.Permission, error) {
+	var out []*drive.Permission
+	pageToken := ""
+	for {
+		call := svc.Permissions.List(fileID).
+			SupportsAllDrives(true).
+			PageSize(100).
+			Fields(docsAccessPermissionFields).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, resp.Permissions...)
+		if resp.NextPageToken == "" {
+			return out, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// docsAccessAncestors walks up the first parent of each folder, nearest
+// first. Folders we cannot read are kept with an error so the chain is
+// still visible.
+func docsAccessAncestors(ctx context.Context, svc *drive.Service, parents []string, driveID string) []docsAccessFolder {
+	var out []docsAccessFolder
+	seen := map[string]bool{}
+	for len(parents) > 0 && len(out) < docsAccessMaxDepth {
+		folderID := parents[0]
+		if folderID == "" || seen[folderID] || folderID == driveID {
+			break
+		}
+		seen[folderID] = true
+
+		folder := docsAccessFolder{ID: folderID}
+		meta, err := svc.Files.Get(folderID).
+			SupportsAllDrives(true).
+			Fields("id, name, parents").
+			Context(ctx).
+			Do()
+		if err != nil {
+			folder.Error = err.Error()
+			out = append(out, folder)
+			break
+		}
+		folder.Name = meta.Name
+		if driveID == "" {
+			perms, permErr := listAllPermissions(ctx, svc, folderID)
+			if permErr != nil {
+				folder.Error = permErr.Error()
+			}
+			folder.perms = perms
+		}
+		out = append(out, folder)
+		parents = meta.Parents
+	}
+	return out
+}
+
+func buildDocsAccessEntries(perms []*drive.Permission, ancestors []docsAccessFolder, driveID, driveName string) []docsAccessEntry {
+	entries := make([]docsAccessEntry, 0, len(perms))
+	for _, p := range perms {
+		if p == nil || p.Deleted {
+			continue
+		}
+		e := docsAccessEntry{
+			PermissionID: p.Id,
+			Type:         p.Type,
+			Role:         p.Role,
+			Who:          docsAccessWho(p),
+			Source:       docsAccessSource{Kind: "direct"},
+		}
+		switch {
+		case len(p.PermissionDetails) > 0:
+			for _, d := range p.PermissionDetails {
+				if d == nil || !d.Inherited {
+					continue
+				}
+				if d.InheritedFrom == driveID {
+					e.Source = docsAccessSource{Kind: "sharedDrive", ID: driveID, Name: driveName}
+				} else {
+					e.Source = docsAccessSource{Kind: "folder", ID: d.InheritedFrom, Name: docsAccessFolderName(ancestors, d.InheritedFrom)}
+				}
+				break
+			}
+		case p.Role != "owner":
+			// The furthest ancestor granting the same access is the origin.
+			for i := len(ancestors) - 1; i >= 0; i-- {
+				if docsAccessHasGrant(ancestors[i].perms, p) {
+					e.Source = docsAccessSource{Kind: "folder", ID: ancestors[i].ID, Name: ancestors[i].Name}
+					break
+				}
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func docsAccessHasGrant(perms []*drive.Permission, want *drive.Permission) bool {
+	for _, p := range perms {
+		if p != nil && p.Id == want.Id && p.Role == want.Role {
+			return true
+		}
+	}
+	return false
+}
+
+func docsAccessFolderName(ancestors []docsAccessFolder, id string) string {
+	for _, a := range ancestors {
+		if a.ID == id {
+			return a.Name
+		}
+	}
+	return ""
+}
+
+func docsAccessWho(p *drive.Permission) string {
+	switch {
+	case p.Type == "anyone":
+		return "anyone with the link"
+	case p.EmailAddress != "":
+		return p.EmailAddress
+	case p.Domain != "":
+		return p.Domain
+	case p.DisplayName != "":
+		return p.DisplayName
+	default:
+		return p.Id
+	}
+}
+
+// filterDocsAccessEntries keeps grants that apply to email: exact user or
+// group matches, the user's domain, and anyone links.
+func filterDocsAccessEntries(entries []docsAccessEntry, email string) []docsAccessEntry {
+	email = strings.ToLower(email)
+	_, domain, _ := strings.Cut(email, "@")
+	var out []docsAccessEntry
+	for _, e := range entries {
+		who := strings.ToLower(e.Who)
+		switch {
+		case e.Type == "anyone",
+			who == email,
+			e.Type == "domain" && domain != "" && who == domain:
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestBuildDocsAccessEntries_SharedDrive(t *testing.T) {
+	perms := []*drive.Permission{
+		{Id: "u1", Type: "user", Role: "organizer", EmailAddress: "lead@example.com", PermissionDetails: []*drive.PermissionPermissionDetails{
+			{Inherited: true, InheritedFrom: "drive1", Role: "organizer"},
+		}},
+		{Id: "u2", Type: "user", Role: "writer", EmailAddress: "ann@example.com", PermissionDetails: []*drive.PermissionPermissionDetails{
+			{Inherited: true, InheritedFrom: "folderA", Role: "writer"},
+		}},
+		{Id: "u3", Type: "user", Role: "reader", EmailAddress: "bob@example.com", PermissionDetails: []*drive.PermissionPermissionDetails{
+			{Inherited: false, Role: "reader"},
+		}},
+	}
+	ancestors := []docsAccessFolder{{ID: "folderA", Name: "Team"}}
+	entries := buildDocsAccessEntries(perms, ancestors, "drive1", "Eng")
+
+	if got := entries[0].Source.label(); got != `shared drive "Eng" (drive1)` {
+		t.Fatalf("unexpected drive source: %q", got)
+	}
+	if got := entries[1].Source.label(); got != `folder "Team" (folderA)` {
+		t.Fatalf("unexpected folder source: %q", got)
+	}
+	if entries[2].Source.Kind != "direct" {
+		t.Fatalf("expected direct grant, got %#v", entries[2].Source)
+	}
+
+	filtered := filterDocsAccessEntries(append(entries, docsAccessEntry{Type: "domain", Who: "example.com"}), "Bob@Example.com")
+	if len(filtered) != 2 || filtered[0].Who != "bob@example.com" || filtered[1].Type != "domain" {
+		t.Fatalf("unexpected filter result: %#v", filtered)
+	}
+}
+
+func TestDocsAccessCmd_MyDriveInheritance(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	files := map[string]map[string]any{
+		"doc1":  {"id": "doc1", "name": "Plan", "mimeType": driveMimeGoogleDoc, "parents": []string{"inner"}},
+		"inner": {"id": "inner", "name": "Q3", "parents": []string{"outer"}},
+		"outer": {"id": "outer", "name": "Projects"},
+	}
+	owner := map[string]any{"id": "o1", "type": "user", "role": "owner", "emailAddress": "me@example.com"}
+	teammate := map[string]any{"id": "t1", "type": "user", "role": "writer", "emailAddress": "ann@example.com"}
+	perms := map[string][]any{
+		"doc1":  {owner, teammate, map[string]any{"id": "anyoneWithLink", "type": "anyone", "role": "reader"}},
+		"inner": {owner, teammate},
+		"outer": {owner, teammate},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/drive/v3"), "/files/")
+		w.Header().Set("Content-Type", "application/json")
+		if id, ok := strings.CutSuffix(path, "/permissions"); ok {
+			_ = json.NewEncoder(w).Encode(map[string]any{"permissions": perms[id]})
+			return
+		}
+		if f, ok := files[path]; ok {
+			_ = json.NewEncoder(w).Encode(f)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	flags := &RootFlags{Account: "me@example.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DocsAccessCmd{}, []string{"doc1"}, ctx, flags); err != nil {
+			t.Fatalf("access: %v", err)
+		}
+	})
+
+	var result struct {
+		Access    []docsAccessEntry  `json:"access"`
+		Ancestors []docsAccessFolder `json:"ancestors"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if len(result.Ancestors) != 2 || result.Ancestors[1].Name != "Projects" {
+		t.Fatalf("unexpected ancestors: %#v", result.Ancestors)
+	}
+	if len(result.Access) != 3 {
+		t.Fatalf("expected 3 grants, got %#v", result.Access)
+	}
+	if result.Access[0].Source.Kind != "direct" || result.Access[2].Source.Kind != "direct" {
+		t.Fatalf("expected owner and link grants to be direct: %#v", result.Access)
+	}
+	if src := result.Access[1].Source; src.Kind != "folder" || src.ID != "outer" {
+		t.Fatalf("expected teammate grant from the outer folder, got %#v", src)
+	}
+}
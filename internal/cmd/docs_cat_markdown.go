@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+)
+
+const docsCatFormatMarkdown = "md"
+
+// docsMarkdownBlock is one rendered paragraph, list item, or table. List items
+// are joined with a single newline so consecutive items stay one list.
+type docsMarkdownBlock struct {
+	text     string
+	listItem bool
+}
+
+// docsMarkdownRenderer converts a document body into Markdown that the
+// markdown importer (docs create --file, docs edit --format markdown) can read.
+type docsMarkdownRenderer struct {
+	lists    map[string]docs.List
+	counters map[string][]int
+	blocks   []docsMarkdownBlock
+}
+
+func docsMarkdown(doc *docs.Document, maxBytes int64) string {
+	if doc == nil {
+		return ""
+	}
+	return docsBodyMarkdown(doc.Body, doc.Lists, maxBytes)
+}
+
+func tabMarkdown(tab *docs.Tab, maxBytes int64) string {
+	if tab == nil || tab.DocumentTab == nil {
+		return ""
+	}
+	return docsBodyMarkdown(tab.DocumentTab.Body, tab.DocumentTab.Lists, maxBytes)
+}
+
+func docsBodyMarkdown(body *docs.Body, lists map[string]docs.List, maxBytes int64) string {
+	if body == nil {
+		return ""
+	}
+	r := &docsMarkdownRenderer{lists: lists, counters: map[string][]int{}}
+	for _, el := range body.Content {
+		r.element(el)
+	}
+
+	var b strings.Builder
+	for i, block := range r.blocks {
+		if i > 0 {
+			if block.listItem && r.blocks[i-1].listItem {
+				b.WriteString("\n")
+			} else {
+				b.WriteString("\n\n")
+			}
+		}
+		b.WriteString(block.text)
+	}
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+
+	out := b.String()
+	if maxBytes > 0 {
+		out, _ = truncateUTF8Bytes(out, int(maxBytes))
+	}
+	return out
+}
+
+func (r *docsMarkdownRenderer) element(el *docs.StructuralElement) {
+	if el == nil {
+		return
+	}
+	switch {
+	case el.Paragraph != nil:
+		r.paragraph(el.Paragraph)
+	case el.Table != nil:
+		if table := docsMarkdownTable(el.Table); table != "" {
+			r.blocks = append(r.blocks, docsMarkdownBlock{text: table})
+		}
+	}
+	// Tables of contents are generated from headings; re-importing them would
+	// duplicate content, so they are left out.
+}
+
+func (r *docsMarkdownRenderer) paragraph(p *docs.Paragraph) {
+	for _, pe := range p.Elements {
+		if pe != nil && pe.HorizontalRule != nil {
+			r.blocks = append(r.blocks, docsMarkdownBlock{text: "---"})
+			return
+		}
+	}
+
+	text := docsMarkdownInline(p.Elements)
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+
+	if p.Bullet != nil {
+		level := int(p.Bullet.NestingLevel)
+		marker := "-"
+		if r.ordered(p.Bullet.ListId, level) {
+			marker = strconv.Itoa(r.nextNumber(p.Bullet.ListId, level)) + "."
+		}
+		r.blocks = append(r.blocks, docsMarkdownBlock{
+			text:     strings.Repeat("  ", level) + marker + " " + text,
+			listItem: true,
+		})
+		return
+	}
+
+	if prefix := docsMarkdownHeadingPrefix(p.ParagraphStyle); prefix != "" {
+		// Heading styles already carry the emphasis; markers would be noise.
+		text = prefix + docsParagraphText(p.Elements)
+	}
+	r.blocks = append(r.blocks, docsMarkdownBlock{text: text})
+}
+
+// ordered mirrors inferBulletPreset: numeric and alphabetic glyphs render as
+// numbered items, everything else as "-" bullets.
+func (r *docsMarkdownRenderer) ordered(listID string, level int) bool {
+	list, ok := r.lists[listID]
+	if !ok || list.ListProperties == nil {
+		return false
+	}
+	levels := list.ListProperties.NestingLevels
+	if level >= len(levels) || levels[level] == nil {
+		return false
+	}
+	switch levels[level].GlyphType {
+	case "DECIMAL", "ZERO_DECIMAL", "UPPER_ALPHA", "ALPHA", "UPPER_ROMAN", "ROMAN":
+		return true
+	}
+	return false
+}
+
+// nextNumber returns the item number for a list level and resets the
+// counters of deeper levels, so nested lists restart at 1.
+func (r *docsMarkdownRenderer) nextNumber(listID string, level int) int {
+	counts := r.counters[listID]
+	if len(counts) <= level {
+		counts = append(counts, make([]int, level+1-len(counts))...)
+	}
+	counts[level]++
+	counts = counts[:level+1]
+	r.counters[listID] = counts
+	return counts[level]
+}
+
+func docsMarkdownHeadingPrefix(style *docs.ParagraphStyle) string {
+	if style == nil {
+		return ""
+	}
+	switch style.NamedStyleType {
+	case "TITLE", "HEADING_1":
+		return "# "
+	case "HEADING_2":
+		return "## "
+	case "HEADING_3":
+		return "### "
+	case "HEADING_4":
+		return "#### "
+	case "HEADING_5":
+		return "##### "
+	case "HEADING_6":
+		return "###### "
+	}
+	return ""
+}
+
+func docsParagraphText(elements []*docs.ParagraphElement) string {
+	var b strings.Builder
+	for _, pe := range elements {
+		if pe != nil && pe.TextRun != nil {
+			b.WriteString(pe.TextRun.Content)
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// docsMarkdownInline renders a paragraph's text runs with emphasis and link
+// markers. Surrounding whitespace is kept outside the markers so "**bold **"
+// style runs still parse as emphasis.
+func docsMarkdownInline(elements []*docs.ParagraphElement) string {
+	var b strings.Builder
+	for _, pe := range elements {
+		if pe == nil || pe.TextRun == nil {
+			continue
+		}
+		content := strings.ReplaceAll(pe.TextRun.Content, "\n", "")
+		// Docs uses a vertical tab for soft line breaks within a paragraph.
+		content = strings.ReplaceAll(content, "\v", "  \n")
+		if content == "" {
+			continue
+		}
+		core := strings.TrimSpace(content)
+		if core == "" {
+			b.WriteString(content)
+			continue
+		}
+		lead := content[:strings.Index(content, core)]
+		trail := content[len(lead)+len(core):]
+
+		style := pe.TextRun.TextStyle
+		if style != nil {
+			switch {
+			case style.Bold && style.Italic:
+				core = "***" + core + "***"
+			case style.Bold:
+				core = "**" + core + "**"
+			case style.Italic:
+				core = "*" + core + "*"
+			}
+			if style.Strikethrough {
+				core = "~~" + core + "~~"
+			}
+			if style.Link != nil && style.Link.Url != "" {
+				core = "[" + core + "](" + style.Link.Url + ")"
+			}
+		}
+		b.WriteString(lead)
+		b.WriteString(core)
+		b.WriteString(trail)
+	}
+	return b.String()
+}
+
+func docsMarkdownTable(t *docs.Table) string {
+	var rows [][]string
+	width := 0
+	for _, row := range t.TableRows {
+		if row == nil {
+			continue
+		}
+		var cells []string
+		for _, cell := range row.TableCells {
+			cells = append(cells, docsMarkdownTableCell(cell))
+		}
+		width = max(width, len(cells))
+		rows = append(rows, cells)
+	}
+	if len(rows) == 0 || width == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for i := 0; i < width; i++ {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			b.WriteString(" " + cell + " |")
+		}
+	}
+	// Markdown tables always have a header; the first row plays that role.
+	writeRow(rows[0])
+	b.WriteString("\n|")
+	b.WriteString(strings.Repeat(" --- |", width))
+	for _, row := range rows[1:] {
+		b.WriteString("\n")
+		writeRow(row)
+	}
+	return b.String()
+}
+
+func docsMarkdownTableCell(cell *docs.TableCell) string {
+	if cell == nil {
+		return ""
+	}
+	var parts []string
+	for _, el := range cell.Content {
+		if el == nil || el.Paragraph == nil {
+			continue
+		}
+		text := strings.TrimSpace(docsMarkdownInline(el.Paragraph.Elements))
+		if text != "" {
+			parts = append(parts, text)
+		}
+	}
+	text := strings.Join(parts, "<br>")
+	text = strings.ReplaceAll(text, "  \n", "<br>")
+	return strings.ReplaceAll(text, "|", `\|`)
+}
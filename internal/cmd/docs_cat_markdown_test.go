@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func mdRun(text string, style *docs.TextStyle) *docs.ParagraphElement {
+	return &docs.ParagraphElement{TextRun: &docs.TextRun{Content: text, TextStyle: style}}
+}
+
+func mdPara(style string, bullet *docs.Bullet, runs ...*docs.ParagraphElement) *docs.StructuralElement {
+	return &docs.StructuralElement{Paragraph: &docs.Paragraph{
+		Elements:       runs,
+		ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: style},
+		Bullet:         bullet,
+	}}
+}
+
+func TestDocsBodyMarkdown(t *testing.T) {
+	lists := map[string]docs.List{
+		"ol": {ListProperties: &docs.ListProperties{NestingLevels: []*docs.NestingLevel{{GlyphType: "DECIMAL"}, {GlyphType: "ALPHA"}}}},
+		"ul": {ListProperties: &docs.ListProperties{NestingLevels: []*docs.NestingLevel{{GlyphType: "GLYPH_TYPE_UNSPECIFIED"}}}},
+	}
+	cell := func(text string) *docs.TableCell {
+		return &docs.TableCell{Content: []*docs.StructuralElement{mdPara("NORMAL_TEXT", nil, mdRun(text+"\n", nil))}}
+	}
+	body := &docs.Body{Content: []*docs.StructuralElement{
+		{SectionBreak: &docs.SectionBreak{}},
+		mdPara("TITLE", nil, mdRun("Plan\n", nil)),
+		mdPara("NORMAL_TEXT", nil,
+			mdRun("Some ", nil),
+			mdRun("bold ", &docs.TextStyle{Bold: true}),
+			mdRun("and ", nil),
+			mdRun("italic", &docs.TextStyle{Italic: true}),
+			mdRun(", see ", nil),
+			mdRun("docs", &docs.TextStyle{Link: &docs.Link{Url: "https://example.com"}}),
+			mdRun(".\n", nil),
+		),
+		mdPara("NORMAL_TEXT", nil, mdRun("\n", nil)),
+		mdPara("HEADING_2", nil, mdRun("Steps\n", &docs.TextStyle{Bold: true})),
+		mdPara("NORMAL_TEXT", &docs.Bullet{ListId: "ol"}, mdRun("First\n", nil)),
+		mdPara("NORMAL_TEXT", &docs.Bullet{ListId: "ol", NestingLevel: 1}, mdRun("Nested\n", nil)),
+		mdPara("NORMAL_TEXT", &docs.Bullet{ListId: "ol"}, mdRun("Second\n", nil)),
+		mdPara("NORMAL_TEXT", &docs.Bullet{ListId: "ul"}, mdRun("Loose\n", &docs.TextStyle{Strikethrough: true})),
+		{Table: &docs.Table{TableRows: []*docs.TableRow{
+			{TableCells: []*docs.TableCell{cell("Name"), cell("Value")}},
+			{TableCells: []*docs.TableCell{cell("a|b"), cell("1")}},
+		}}},
+		{TableOfContents: &docs.TableOfContents{Content: []*docs.StructuralElement{mdPara("NORMAL_TEXT", nil, mdRun("Steps\n", nil))}}},
+		mdPara("NORMAL_TEXT", nil, &docs.ParagraphElement{HorizontalRule: &docs.HorizontalRule{}}, mdRun("\n", nil)),
+	}}
+
+	got := docsBodyMarkdown(body, lists, 0)
+	want := strings.Join([]string{
+		"# Plan",
+		"",
+		"Some **bold** and *italic*, see [docs](https://example.com).",
+		"",
+		"## Steps",
+		"",
+		"1. First",
+		"  1. Nested",
+		"2. Second",
+		"- ~~Loose~~",
+		"",
+		"| Name | Value |",
+		"| --- | --- |",
+		`| a\|b | 1 |`,
+		"",
+		"---",
+		"",
+	}, "\n")
+	if got != want {
+		t.Fatalf("unexpected markdown:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := docsBodyMarkdown(body, lists, 6); got != "# Plan" {
+		t.Fatalf("expected truncated output, got %q", got)
+	}
+}
+
+func TestDocsMaxBytesKeepsRunesWhole(t *testing.T) {
+	// "ü" and "ß" are two bytes each; a 5-byte limit falls inside "ß".
+	body := &docs.Body{Content: []*docs.StructuralElement{mdPara("NORMAL_TEXT", nil, mdRun("Grüße\n", nil))}}
+
+	if got := docsBodyMarkdown(body, nil, 4); got != "Grü" {
+		t.Fatalf("unexpected markdown truncation: %q", got)
+	}
+	if got := docsBodyMarkdown(body, nil, 5); got != "Grü" {
+		t.Fatalf("markdown truncation split a rune: %q", got)
+	}
+	if got := docsPlainText(&docs.Document{Body: body}, 5); got != "Grü" {
+		t.Fatalf("plain text truncation split a rune: %q", got)
+	}
+}
+
+func TestDocsCatCmd_FormatMarkdown(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	svc, closeSrv := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"documentId": "doc1",
+			"body": map[string]any{"content": []any{
+				map[string]any{"paragraph": map[string]any{
+					"paragraphStyle": map[string]any{"namedStyleType": "HEADING_1"},
+					"elements":       []any{map[string]any{"textRun": map[string]any{"content": "Title\n"}}},
+				}},
+			}},
+		})
+	})
+	defer closeSrv()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return svc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DocsCatCmd{}, []string{"doc1", "--format", "md"}, newDocsCmdContext(t), flags); err != nil {
+			t.Fatalf("cat: %v", err)
+		}
+	})
+	if out != "# Title\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	err := runKong(t, &DocsCatCmd{}, []string{"doc1", "--format", "md", "--numbered"}, newDocsCmdContext(t), flags)
+	if err == nil || !strings.Contains(err.Error(), "--numbered") {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
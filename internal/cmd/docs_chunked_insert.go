@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/pkg/docsmd"
+)
+
+// Large markdown writes are split so no single batchUpdate exceeds the Docs
+// API payload limits. Vars so tests can shrink them.
+var (
+	// docsInsertChunkUnits caps the UTF-16 length of one InsertText request.
+	docsInsertChunkUnits int64 = 50000
+	// docsStyleBatchSize caps the number of style requests per batchUpdate.
+	docsStyleBatchSize = 500
+)
+
+// needsChunkedDocsInsert reports whether text and its style requests are
+// too large for a single batchUpdate.
+func needsChunkedDocsInsert(text string, styleRequests int) bool {
+	return docsmd.UTF16Len(text) > docsInsertChunkUnits || styleRequests > docsStyleBatchSize
+}
+
+// applyChunkedDocsInsert replaces [startIdx, endIdx) with text using several
+// batchUpdates. The new text is inserted in front of the old range, styled,
+// and only then is the old range deleted, so a failure at any step can be
+// rolled back by deleting what was inserted, leaving the original content
+// in place. Each batch is pinned to the revision the previous one produced.
+func applyChunkedDocsInsert(ctx context.Context, svc *docs.Service, docID, revisionID string, startIdx, endIdx int64, text string, styles []*docs.Request) error {
+	revision := revisionID
+	apply := func(requests []*docs.Request) error {
+		resp, err := svc.Documents.BatchUpdate(docID, docsBatchAtRevision(revision, requests)).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+		if resp.WriteControl != nil && resp.WriteControl.RequiredRevisionId != "" {
+			revision = resp.WriteControl.RequiredRevisionId
+		}
+		return nil
+	}
+
+	var inserted int64
+	rollback := func(cause error) error {
+		if inserted == 0 {
+			return cause
+		}
+		undo := []*docs.Request{{DeleteContentRange: &docs.DeleteContentRangeRequest{
+			Range: &docs.Range{StartIndex: startIdx, EndIndex: startIdx + inserted},
+		}}}
+		if err := apply(undo); err != nil {
+			return fmt.Errorf("%w (rollback failed, document may contain partial content at index %d-%d: %v)", cause, startIdx, startIdx+inserted, err)
+		}
+		return fmt.Errorf("%w (changes rolled back)", cause)
+	}
+
+	for _, chunk := range splitDocsInsertText(text, docsInsertChunkUnits) {
+		if err := apply([]*docs.Request{{InsertText: &docs.InsertTextRequest{
+			Location: &docs.Location{Index: startIdx + inserted},
+			Text:     chunk,
+		}}}); err != nil {
+			return rollback(fmt.Errorf("insert text at index %d: %w", startIdx+inserted, err))
+		}
+		inserted += docsmd.UTF16Len(chunk)
+	}
+
+	for i := 0; i < len(styles); i += docsStyleBatchSize {
+		end := min(i+docsStyleBatchSize, len(styles))
+		if err := apply(styles[i:end]); err != nil {
+			return rollback(fmt.Errorf("apply formatting (requests %d-%d): %w", i+1, end, err))
+		}
+	}
+
+	if endIdx > startIdx {
+		if err := apply([]*docs.Request{{DeleteContentRange: &docs.DeleteContentRangeRequest{
+			Range: &docs.Range{StartIndex: startIdx + inserted, EndIndex: endIdx + inserted},
+		}}}); err != nil {
+			return rollback(fmt.Errorf("delete replaced range: %w", err))
+		}
+	}
+	return nil
+}
+
+// splitDocsInsertText cuts text into pieces of at most maxUnits UTF-16 code
+// units, preferring line boundaries and never splitting a surrogate pair.
+func splitDocsInsertText(text string, maxUnits int64) []string {
+	if maxUnits <= 0 || docsmd.UTF16Len(text) <= maxUnits {
+		return []string{text}
+	}
+	var chunks []string
+	var cur strings.Builder
+	var curUnits int64
+	flush := func() {
+		if cur.Len() > 0 {
+			chunks = append(chunks, cur.String())
+			cur.Reset()
+			curUnits = 0
+		}
+	}
+	for _, line := range strings.SplitAfter(text, "\n") {
+		lineUnits := docsmd.UTF16Len(line)
+		if curUnits+lineUnits > maxUnits {
+			flush()
+		}
+		if lineUnits <= maxUnits {
+			cur.WriteString(line)
+			curUnits += lineUnits
+			continue
+		}
+		// A single line longer than the cap: split between runes.
+		for _, r := range line {
+			n := int64(utf16.RuneLen(r))
+			if n < 0 {
+				n = 1
+			}
+			if curUnits+n > maxUnits {
+				flush()
+			}
+			cur.WriteRune(r)
+			curUnits += n
+		}
+	}
+	flush()
+	return chunks
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/pkg/docsmd"
+)
+
+func TestSplitDocsInsertText(t *testing.T) {
+	text := "ab\ncd\nef😀g\n"
+	chunks := splitDocsInsertText(text, 4)
+	if strings.Join(chunks, "") != text {
+		t.Fatalf("chunks do not reassemble: %q", chunks)
+	}
+	for _, c := range chunks {
+		if docsmd.UTF16Len(c) > 4 {
+			t.Fatalf("chunk %q exceeds limit", c)
+		}
+	}
+	if chunks[0] != "ab\n" || chunks[1] != "cd\n" {
+		t.Fatalf("expected line-aligned chunks, got %q", chunks)
+	}
+	if got := splitDocsInsertText("short", 10); len(got) != 1 {
+		t.Fatalf("expected a single chunk, got %q", got)
+	}
+}
+
+func TestApplyChunkedDocsInsert(t *testing.T) {
+	origUnits, origBatch := docsInsertChunkUnits, docsStyleBatchSize
+	t.Cleanup(func() { docsInsertChunkUnits, docsStyleBatchSize = origUnits, origBatch })
+	docsInsertChunkUnits, docsStyleBatchSize = 6, 1
+
+	var batches []docs.BatchUpdateDocumentRequest
+	failStyles := false
+	svc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		var req docs.BatchUpdateDocumentRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		batches = append(batches, req)
+		if failStyles && req.Requests[0].UpdateTextStyle != nil {
+			http.Error(w, `{"error":{"code":400,"message":"bad"}}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"documentId":   "doc1",
+			"writeControl": map[string]any{"requiredRevisionId": fmt.Sprintf("rev%d", len(batches))},
+		})
+	})
+	defer cleanup()
+
+	styles := []*docs.Request{
+		{UpdateTextStyle: &docs.UpdateTextStyleRequest{Range: &docs.Range{StartIndex: 5, EndIndex: 7}}},
+		{UpdateTextStyle: &docs.UpdateTextStyleRequest{Range: &docs.Range{StartIndex: 9, EndIndex: 11}}},
+	}
+	if err := applyChunkedDocsInsert(context.Background(), svc, "doc1", "rev0", 5, 8, "one\ntwo\n😀x\n", styles); err != nil {
+		t.Fatalf("applyChunkedDocsInsert: %v", err)
+	}
+	// 3 inserts + 2 style batches + 1 delete.
+	if len(batches) != 6 {
+		t.Fatalf("expected 6 batches, got %d", len(batches))
+	}
+	if loc := batches[2].Requests[0].InsertText.Location.Index; loc != 13 {
+		t.Fatalf("expected third chunk at index 13, got %d", loc)
+	}
+	for i, b := range batches {
+		if want := fmt.Sprintf("rev%d", i); b.WriteControl == nil || b.WriteControl.RequiredRevisionId != want {
+			t.Fatalf("batch %d not pinned to %s: %#v", i, want, b.WriteControl)
+		}
+	}
+	del := batches[5].Requests[0].DeleteContentRange.Range
+	if del.StartIndex != 17 || del.EndIndex != 20 {
+		t.Fatalf("unexpected delete range: [%d,%d)", del.StartIndex, del.EndIndex)
+	}
+
+	batches = nil
+	failStyles = true
+	err := applyChunkedDocsInsert(context.Background(), svc, "doc1", "", 5, 8, "one\ntwo\n", styles)
+	if err == nil || !strings.Contains(err.Error(), "rolled back") {
+		t.Fatalf("expected rolled back error, got %v", err)
+	}
+	undo := batches[len(batches)-1].Requests[0].DeleteContentRange
+	if undo == nil || undo.Range.StartIndex != 5 || undo.Range.EndIndex != 13 {
+		t.Fatalf("expected rollback of inserted range, got %#v", batches[len(batches)-1])
+	}
+}
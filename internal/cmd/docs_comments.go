@@ -16,6 +16,9 @@ type DocsCommentsCmd struct {
 	Reply   DocsCommentsReplyCmd   `cmd:"" name:"reply" aliases:"respond" help:"Reply to a comment"`
 	Resolve DocsCommentsResolveCmd `cmd:"" name:"resolve" help:"Resolve a comment (mark as done)"`
 	Delete  DocsCommentsDeleteCmd  `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete a comment"`
+	Context DocsCommentsContextCmd `cmd:"" name:"context" aliases:"anchor" help:"Locate a comment's quoted text: index range, tab, and enclosing heading"`
+
+	ReplyAndResolve DocsCommentsReplyResolveCmd `cmd:"" name:"reply-and-resolve" help:"Reply to a comment and resolve it in one call"`
 }
 
 // DocsCommentsListCmd lists comments on a Google Doc.
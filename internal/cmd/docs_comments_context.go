@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// DocsCommentsContextCmd maps a comment's quoted text back to document
+// indexes and the enclosing heading.
+type DocsCommentsContextCmd struct {
+	DocID     string `arg:"" name:"docId" help:"Google Doc ID or URL"`
+	CommentID string `arg:"" name:"commentId" help:"Comment ID"`
+}
+
+// docsCommentAnchor is one place the comment's quoted text occurs.
+type docsCommentAnchor struct {
+	TabID      string            `json:"tabId,omitempty"`
+	TabTitle   string            `json:"tabTitle,omitempty"`
+	StartIndex int64             `json:"startIndex"`
+	EndIndex   int64             `json:"endIndex"`
+	Heading    *docsOutlineEntry `json:"heading,omitempty"`
+}
+
+func (c *DocsCommentsContextCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	commentID := strings.TrimSpace(c.CommentID)
+	if docID == "" {
+		return usage("empty docId")
+	}
+	if commentID == "" {
+		return usage("empty commentId")
+	}
+
+	_, driveSvc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	comment, err := getDriveComment(ctx, driveSvc, docID, commentID)
+	if err != nil {
+		return err
+	}
+	var quoted string
+	if comment.QuotedFileContent != nil {
+		quoted = comment.QuotedFileContent.Value
+	}
+	// The Drive anchor is opaque for Docs, so the quoted text is the only
+	// link back to the body. Quotes spanning paragraphs are located by
+	// their first line.
+	find, _, _ := strings.Cut(strings.TrimSpace(quoted), "\n")
+	if find = strings.TrimSpace(find); find == "" {
+		return fmt.Errorf("comment %s has no quoted text; it is not anchored to document text", commentID)
+	}
+
+	docsSvc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	doc, err := docsSvc.Documents.Get(docID).IncludeTabsContent(true).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return fmt.Errorf("doc not found or not a Google Doc (id=%s)", docID)
+		}
+		return err
+	}
+	anchors := findDocsCommentAnchors(doc, find)
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"docId":     docID,
+			"commentId": commentID,
+			"content":   comment.Content,
+			"resolved":  comment.Resolved,
+			"quoted":    quoted,
+			"anchors":   anchors,
+		})
+	}
+
+	u.Out().Printf("commentId\t%s", commentID)
+	u.Out().Printf("content\t%s", oneLineTSV(comment.Content))
+	u.Out().Printf("quoted\t%s", oneLineTSV(quoted))
+	if len(anchors) == 0 {
+		u.Err().Println("Quoted text not found in the document (it may have been edited since the comment was made)")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "TAB\tSTART\tEND\tHEADING")
+	for _, a := range anchors {
+		heading := ""
+		if a.Heading != nil {
+			heading = a.Heading.Text
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", a.TabID, a.StartIndex, a.EndIndex, heading)
+	}
+	return nil
+}
+
+// findDocsCommentAnchors returns every case-sensitive occurrence of find in
+// the document's tabs, each with the nearest preceding heading.
+func findDocsCommentAnchors(doc *docs.Document, find string) []docsCommentAnchor {
+	anchors := []docsCommentAnchor{}
+	search := func(body *docs.Body, tabID, tabTitle string) {
+		if body == nil {
+			return
+		}
+		outline := buildDocsOutline(body, tabID, tabTitle)
+		for _, m := range findTextMatches(&docs.Document{Body: body}, find, true) {
+			anchor := docsCommentAnchor{TabID: tabID, TabTitle: tabTitle, StartIndex: m.startIndex, EndIndex: m.endIndex}
+			for i := range outline {
+				if outline[i].StartIndex > m.startIndex {
+					break
+				}
+				anchor.Heading = &outline[i]
+			}
+			anchors = append(anchors, anchor)
+		}
+	}
+
+	tabs := flattenTabs(doc.Tabs)
+	if len(tabs) == 0 {
+		search(doc.Body, "", "")
+		return anchors
+	}
+	for _, tab := range tabs {
+		if tab == nil || tab.DocumentTab == nil {
+			continue
+		}
+		var tabID, title string
+		if tab.TabProperties != nil {
+			tabID, title = tab.TabProperties.TabId, tab.TabProperties.Title
+		}
+		search(tab.DocumentTab.Body, tabID, title)
+	}
+	return anchors
+}
+
+// DocsCommentsReplyResolveCmd posts a reply and resolves the comment in a
+// single Drive call.
+type DocsCommentsReplyResolveCmd struct {
+	DocID     string `arg:"" name:"docId" help:"Google Doc ID or URL"`
+	CommentID string `arg:"" name:"commentId" help:"Comment ID"`
+	Content   string `arg:"" name:"content" help:"Reply text"`
+}
+
+func (c *DocsCommentsReplyResolveCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	commentID := strings.TrimSpace(c.CommentID)
+	content := strings.TrimSpace(c.Content)
+	if docID == "" {
+		return usage("empty docId")
+	}
+	if commentID == "" {
+		return usage("empty commentId")
+	}
+	if content == "" {
+		return usage("empty content")
+	}
+
+	if err := dryRunExit(ctx, flags, "docs.comments.reply_and_resolve", map[string]any{
+		"doc_id":     docID,
+		"comment_id": commentID,
+		"content":    content,
+	}); err != nil {
+		return err
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+
+	created, err := resolveDriveComment(ctx, svc, docID, commentID, content)
+	if err != nil {
+		return err
+	}
+	return writeDriveReplyMutation(ctx, u, created, true, "docId", docID, commentID)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/pkg/docsmd"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestDocsCommentsContextAndReplyResolve(t *testing.T) {
+	origDrive, origDocs := newDriveService, newDocsService
+	t.Cleanup(func() { newDriveService, newDocsService = origDrive, origDocs })
+
+	var gotReply drive.Reply
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/files/doc1/comments/c1" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":                "c1",
+				"content":           "Tighten this",
+				"quotedFileContent": map[string]any{"value": "brown fox"},
+			})
+		case r.URL.Path == "/files/doc1/comments/c1/replies" && r.Method == http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&gotReply)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "r9", "action": "resolve", "content": gotReply.Content})
+		case strings.HasSuffix(r.URL.Path, "/documents/doc1") && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"documentId": "doc1",
+				"tabs": []any{map[string]any{
+					"tabProperties": map[string]any{"tabId": "t.0", "title": "Main"},
+					"documentTab": map[string]any{"body": map[string]any{"content": []any{
+						docsTestParagraph(1, "Intro\n", "HEADING_1"),
+						docsTestParagraph(7, "The quick brown fox\n", "NORMAL_TEXT"),
+					}}},
+				}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	opts := []option.ClientOption{
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL + "/"),
+	}
+	driveSvc, err := drive.NewService(context.Background(), opts...)
+	if err != nil {
+		t.Fatalf("drive.NewService: %v", err)
+	}
+	docsSvc, err := docs.NewService(context.Background(), opts...)
+	if err != nil {
+		t.Fatalf("docs.NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return driveSvc, nil }
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docsSvc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DocsCommentsContextCmd{}, []string{"doc1", "c1"}, ctx, flags); err != nil {
+			t.Fatalf("context: %v", err)
+		}
+	})
+	var got struct {
+		Anchors []docsCommentAnchor `json:"anchors"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if len(got.Anchors) != 1 {
+		t.Fatalf("expected one anchor, got %#v", got.Anchors)
+	}
+	a := got.Anchors[0]
+	if a.TabID != "t.0" || a.StartIndex != 17 || a.EndIndex != 26 || a.Heading == nil || a.Heading.Text != "Intro" {
+		t.Fatalf("unexpected anchor: %#v (heading %#v)", a, a.Heading)
+	}
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DocsCommentsReplyResolveCmd{}, []string{"doc1", "c1", "Done"}, ctx, flags); err != nil {
+			t.Fatalf("reply-and-resolve: %v", err)
+		}
+	})
+	if gotReply.Action != "resolve" || gotReply.Content != "Done" {
+		t.Fatalf("unexpected reply: %#v", gotReply)
+	}
+}
+
+func docsTestParagraph(start int64, text, style string) map[string]any {
+	end := start + docsmd.UTF16Len(text)
+	return map[string]any{
+		"startIndex": start,
+		"endIndex":   end,
+		"paragraph": map[string]any{
+			"paragraphStyle": map[string]any{"namedStyleType": style},
+			"elements": []any{map[string]any{
+				"startIndex": start,
+				"endIndex":   end,
+				"textRun":    map[string]any{"content": text},
+			}},
+		},
+	}
+}
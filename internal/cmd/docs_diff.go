@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/textdiff"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type DocsDiffCmd struct {
+	DocA      string   `arg:"" name:"docIdA" help:"Doc ID (old side)"`
+	DocB      string   `arg:"" optional:"" name:"docIdB" help:"Doc ID (new side; defaults to docIdA for revision diffs)"`
+	Revisions []string `name:"revision" help:"Revision for the old side, then the new side (repeat; omitted side uses the current version)"`
+	Format    string   `name:"format" help:"Text to compare: txt|md" default:"txt" enum:"txt,md"`
+	Context   int      `name:"context" short:"U" help:"Lines of context" default:"3"`
+}
+
+// docsDiffSide is one input of a diff: a document at its head or a revision.
+type docsDiffSide struct {
+	DocID    string `json:"documentId"`
+	Revision string `json:"revision,omitempty"`
+}
+
+func (s docsDiffSide) label() string {
+	if s.Revision == "" {
+		return s.DocID
+	}
+	return s.DocID + "@" + s.Revision
+}
+
+func (c *DocsDiffCmd) Run(ctx context.Context, flags *RootFlags) error {
+	docA := normalizeGoogleID(strings.TrimSpace(c.DocA))
+	docB := normalizeGoogleID(strings.TrimSpace(c.DocB))
+	if docA == "" {
+		return usage("empty docIdA")
+	}
+	if docB == "" {
+		docB = docA
+	}
+	if len(c.Revisions) > 2 {
+		return usage("--revision may be given at most twice (old side, then new side)")
+	}
+
+	oldSide := docsDiffSide{DocID: docA}
+	newSide := docsDiffSide{DocID: docB}
+	if len(c.Revisions) > 0 {
+		oldSide.Revision = strings.TrimSpace(c.Revisions[0])
+	}
+	if len(c.Revisions) > 1 {
+		newSide.Revision = strings.TrimSpace(c.Revisions[1])
+	}
+	if oldSide == newSide {
+		return usage("nothing to compare: pass two doc IDs or --revision")
+	}
+
+	mimeType := mimeTextPlain
+	if c.Format == "md" {
+		mimeType = mimeTextMarkdown
+	}
+
+	account, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+
+	oldText, err := fetchDocsDiffText(ctx, account, svc, oldSide, mimeType)
+	if err != nil {
+		return err
+	}
+	newText, err := fetchDocsDiffText(ctx, account, svc, newSide, mimeType)
+	if err != nil {
+		return err
+	}
+
+	ops := textdiff.Diff(textdiff.Lines(oldText), textdiff.Lines(newText))
+	unified := textdiff.Unified("a/"+oldSide.label(), "b/"+newSide.label(), ops, c.Context)
+
+	if outfmt.IsJSON(ctx) {
+		changes := textdiff.Changes(ops)
+		if changes == nil {
+			changes = []textdiff.Change{}
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"old":       oldSide,
+			"new":       newSide,
+			"format":    c.Format,
+			"identical": len(changes) == 0,
+			"changes":   changes,
+			"diff":      unified,
+		})
+	}
+
+	_, err = io.WriteString(os.Stdout, unified)
+	return err
+}
+
+// fetchDocsDiffText exports one side through Drive so heads and revisions go
+// through the same converter and produce comparable text.
+func fetchDocsDiffText(ctx context.Context, account string, svc *drive.Service, side docsDiffSide, mimeType string) (string, error) {
+	var (
+		body io.ReadCloser
+		err  error
+	)
+	if side.Revision == "" {
+		resp, exportErr := driveExportDownload(ctx, svc, side.DocID, mimeType)
+		if exportErr != nil {
+			return "", fmt.Errorf("export %s: %w", side.label(), exportErr)
+		}
+		body, err = driveDiffResponseBody(resp)
+	} else {
+		rev, getErr := svc.Revisions.Get(side.DocID, side.Revision).Fields("id,exportLinks").Context(ctx).Do()
+		if getErr != nil {
+			return "", fmt.Errorf("revision %s: %w", side.label(), getErr)
+		}
+		link := rev.ExportLinks[mimeType]
+		if link == "" {
+			return "", fmt.Errorf("revision %s cannot be exported as %s", side.label(), mimeType)
+		}
+		resp, exportErr := driveRevisionExportDownload(ctx, account, link)
+		if exportErr != nil {
+			return "", fmt.Errorf("export %s: %w", side.label(), exportErr)
+		}
+		body, err = driveDiffResponseBody(resp)
+	}
+	if err != nil {
+		return "", fmt.Errorf("export %s: %w", side.label(), err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	// Drive prefixes plain-text exports with a UTF-8 BOM.
+	return strings.TrimPrefix(string(data), "\ufeff"), nil
+}
+
+func driveDiffResponseBody(resp *http.Response) (io.ReadCloser, error) {
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("download failed: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return resp.Body, nil
+}
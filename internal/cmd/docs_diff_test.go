@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/internal/textdiff"
+)
+
+func TestDocsDiff_RevisionAgainstHead(t *testing.T) {
+	origNew := newDriveService
+	origExport := driveExportDownload
+	origRevision := driveRevisionExportDownload
+	t.Cleanup(func() {
+		newDriveService = origNew
+		driveExportDownload = origExport
+		driveRevisionExportDownload = origRevision
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.TrimPrefix(r.URL.Path, "/drive/v3") == "/files/doc1/revisions/3" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":          "3",
+				"exportLinks": map[string]any{"text/plain": "https://docs.example/export?revision=3&exportFormat=txt"},
+			})
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	textResponse := func(s string) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(s))}
+	}
+	driveRevisionExportDownload = func(context.Context, string, string) (*http.Response, error) {
+		return textResponse("\ufeffTitle\nold line\nfooter\n"), nil
+	}
+	driveExportDownload = func(_ context.Context, _ *drive.Service, fileID string, mimeType string) (*http.Response, error) {
+		if fileID != "doc1" || mimeType != mimeTextPlain {
+			t.Fatalf("unexpected export %s %s", fileID, mimeType)
+		}
+		return textResponse("\ufeffTitle\nnew line\nfooter\n"), nil
+	}
+
+	text := captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "docs", "diff", "doc1", "--revision", "3"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	want := "--- a/doc1@3\n+++ b/doc1\n@@ -1,3 +1,3 @@\n Title\n-old line\n+new line\n footer\n"
+	if text != want {
+		t.Fatalf("unexpected diff:\n%s", text)
+	}
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "docs", "diff", "doc1", "--revision", "3"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	var parsed struct {
+		Identical bool              `json:"identical"`
+		Changes   []textdiff.Change `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if parsed.Identical || len(parsed.Changes) != 1 || parsed.Changes[0].OldStart != 2 || parsed.Changes[0].Added[0] != "new line" {
+		t.Fatalf("unexpected changes: %#v", parsed)
+	}
+}
+
+func TestDocsDiff_RequiresTwoSides(t *testing.T) {
+	err := Execute([]string{"--account", "a@b.com", "docs", "diff", "doc1"})
+	if err == nil || !strings.Contains(err.Error(), "nothing to compare") {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
@@ -9,8 +9,8 @@ import (
 	"github.com/alecthomas/kong"
 	"google.golang.org/api/docs/v1"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type DocsWriteCmd struct {
@@ -18,8 +18,9 @@ type DocsWriteCmd struct {
 	Text     string `name:"text" help:"Text to write"`
 	File     string `name:"file" help:"Text file path ('-' for stdin)"`
 	Append   bool   `name:"append" help:"Append instead of replacing the document body"`
+	Format   string `name:"format" help:"Content format: plain|markdown. Markdown converts headings, lists, formatting, tables, images, and [^1] footnotes." default:"plain" enum:"plain,markdown"`
 	Pageless bool   `name:"pageless" help:"Set document to pageless mode"`
-	TabID    string `name:"tab-id" help:"Target a specific tab by ID (see docs list-tabs)"`
+	TabID    string `name:"tab-id" aliases:"tab" help:"Target a specific tab by ID or title (see docs tabs list)"`
 }
 
 func (c *DocsWriteCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
@@ -28,6 +29,9 @@ func (c *DocsWriteCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootF
 	if id == "" {
 		return usage("empty docId")
 	}
+	if err := ensureDriveUnlocked(ctx, flags, id); err != nil {
+		return err
+	}
 
 	text, provided, err := resolveTextInput(c.Text, c.File, kctx, "text", "file")
 	if err != nil {
@@ -39,11 +43,22 @@ func (c *DocsWriteCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootF
 	if text == "" {
 		return usage("empty text")
 	}
+	markdown := c.Format == docsContentFormatMarkdown
+	if markdown && c.TabID != "" {
+		return usage("--tab-id is not yet supported with --format markdown")
+	}
 
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
 	svc, err := requireDocsService(ctx, flags)
 	if err != nil {
 		return err
 	}
+	if c.TabID, err = resolveDocsTabID(ctx, svc, id, c.TabID); err != nil {
+		return err
+	}
 
 	endIndex, err := docsTargetEndIndex(ctx, svc, id, c.TabID)
 	if err != nil {
@@ -71,14 +86,49 @@ func (c *DocsWriteCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootF
 			Text:     text,
 		},
 	})
+	// Markdown replaces the same span the plain delete would clear.
+	mdStart, mdEnd := insertIndex, insertIndex
+	if !c.Append {
+		mdEnd = max(endIndex-1, mdStart)
+	}
 
-	resp, err := svc.Documents.BatchUpdate(id, &docs.BatchUpdateDocumentRequest{Requests: reqs}).Context(ctx).Do()
-	if err != nil {
-		if isDocsNotFound(err) {
-			return fmt.Errorf("doc not found or not a Google Doc (id=%s)", id)
+	plan := map[string]any{
+		"documentId": id,
+		"tabId":      c.TabID,
+		"append":     c.Append,
+		"index":      insertIndex,
+		"pageless":   c.Pageless,
+	}
+	if markdown {
+		plan["format"] = c.Format
+		for k, v := range docsMarkdownPlan(text, mdStart, mdEnd) {
+			plan[k] = v
 		}
+	} else {
+		plan["requests"] = reqs
+	}
+	if err := dryRunExit(ctx, flags, "docs.write", plan); err != nil {
 		return err
 	}
+
+	resp := &docs.BatchUpdateDocumentResponse{DocumentId: id}
+	if markdown {
+		basePath := "."
+		if c.File != "" && c.File != "-" {
+			basePath = c.File
+		}
+		if err := replaceDocsMarkdownRange(ctx, svc, account, &docs.Document{DocumentId: id}, mdStart, mdEnd, text, basePath); err != nil {
+			return err
+		}
+	} else {
+		resp, err = svc.Documents.BatchUpdate(id, &docs.BatchUpdateDocumentRequest{Requests: reqs}).Context(ctx).Do()
+		if err != nil {
+			if isDocsNotFound(err) {
+				return fmt.Errorf("doc not found or not a Google Doc (id=%s)", id)
+			}
+			return err
+		}
+	}
 	if c.Pageless {
 		if err := setDocumentPageless(ctx, svc, id); err != nil {
 			return fmt.Errorf("set pageless mode: %w", err)
@@ -88,10 +138,14 @@ func (c *DocsWriteCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootF
 	if outfmt.IsJSON(ctx) {
 		payload := map[string]any{
 			"documentId": resp.DocumentId,
-			"requests":   len(reqs),
 			"append":     c.Append,
 			"index":      insertIndex,
 		}
+		if markdown {
+			payload["format"] = c.Format
+		} else {
+			payload["requests"] = len(reqs)
+		}
 		if c.TabID != "" {
 			payload["tabId"] = c.TabID
 		}
@@ -102,7 +156,11 @@ func (c *DocsWriteCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootF
 	}
 
 	u.Out().Printf("id\t%s", resp.DocumentId)
-	u.Out().Printf("requests\t%d", len(reqs))
+	if markdown {
+		u.Out().Printf("format\t%s", c.Format)
+	} else {
+		u.Out().Printf("requests\t%d", len(reqs))
+	}
 	u.Out().Printf("append\t%t", c.Append)
 	u.Out().Printf("index\t%d", insertIndex)
 	if c.TabID != "" {
@@ -120,7 +178,7 @@ type DocsUpdateCmd struct {
 	File     string `name:"file" help:"Text file path ('-' for stdin)"`
 	Index    int64  `name:"index" help:"Insert index (default: end of document)"`
 	Pageless bool   `name:"pageless" help:"Set document to pageless mode"`
-	TabID    string `name:"tab-id" help:"Target a specific tab by ID (see docs list-tabs)"`
+	TabID    string `name:"tab-id" aliases:"tab" help:"Target a specific tab by ID or title (see docs tabs list)"`
 }
 
 func (c *DocsUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
@@ -129,6 +187,9 @@ func (c *DocsUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *Root
 	if id == "" {
 		return usage("empty docId")
 	}
+	if err := ensureDriveUnlocked(ctx, flags, id); err != nil {
+		return err
+	}
 
 	text, provided, err := resolveTextInput(c.Text, c.File, kctx, "text", "file")
 	if err != nil {
@@ -148,6 +209,9 @@ func (c *DocsUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *Root
 	if err != nil {
 		return err
 	}
+	if c.TabID, err = resolveDocsTabID(ctx, svc, id, c.TabID); err != nil {
+		return err
+	}
 
 	insertIndex := c.Index
 	if insertIndex <= 0 {
@@ -164,6 +228,15 @@ func (c *DocsUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *Root
 			Text:     text,
 		},
 	}}
+	if err := dryRunExit(ctx, flags, "docs.update", map[string]any{
+		"documentId": id,
+		"tabId":      c.TabID,
+		"index":      insertIndex,
+		"pageless":   c.Pageless,
+		"requests":   reqs,
+	}); err != nil {
+		return err
+	}
 
 	resp, err := svc.Documents.BatchUpdate(id, &docs.BatchUpdateDocumentRequest{Requests: reqs}).Context(ctx).Do()
 	if err != nil {
@@ -206,19 +279,26 @@ func (c *DocsUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *Root
 }
 
 type DocsInsertCmd struct {
-	DocID   string `arg:"" name:"docId" help:"Doc ID"`
-	Content string `arg:"" optional:"" name:"content" help:"Text to insert (or use --file / stdin)"`
-	Index   int64  `name:"index" help:"Character index to insert at (1 = beginning)" default:"1"`
-	File    string `name:"file" short:"f" help:"Read content from file (use - for stdin)"`
-	TabID   string `name:"tab-id" help:"Target a specific tab by ID (see docs list-tabs)"`
+	DocID        string `arg:"" name:"docId" help:"Doc ID"`
+	Content      string `arg:"" optional:"" name:"content" help:"Text to insert (or use --file / stdin)"`
+	Index        int64  `name:"index" help:"Character index to insert at (1 = beginning)" default:"1"`
+	AfterHeading string `name:"after-heading" help:"Insert right after the first heading with this text (case-insensitive)"`
+	File         string `name:"file" short:"f" help:"Read content from file (use - for stdin)"`
+	Format       string `name:"format" help:"Content format: plain|markdown. Markdown converts headings, lists, formatting, tables, and images." default:"plain" enum:"plain,markdown"`
+	TabID        string `name:"tab-id" aliases:"tab" help:"Target a specific tab by ID or title (see docs tabs list)"`
+	Range        string `name:"range" help:"Insert at the start of this named range, by name or ID (see docs ranges list)"`
+	RangeEnd     bool   `name:"range-end" help:"With --range, insert at the end of the range instead"`
 }
 
-func (c *DocsInsertCmd) Run(ctx context.Context, flags *RootFlags) error {
+func (c *DocsInsertCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
 	u := ui.FromContext(ctx)
 	docID := strings.TrimSpace(c.DocID)
 	if docID == "" {
 		return usage("empty docId")
 	}
+	if err := ensureDriveUnlocked(ctx, flags, docID); err != nil {
+		return err
+	}
 	content, err := resolveContentInput(c.Content, c.File)
 	if err != nil {
 		return err
@@ -226,52 +306,219 @@ func (c *DocsInsertCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if content == "" {
 		return usage("no content provided (use argument, --file, or stdin)")
 	}
+	heading := strings.TrimSpace(c.AfterHeading)
+	if heading != "" && flagProvided(kctx, "index") {
+		return usage("use either --index or --after-heading, not both")
+	}
 	if c.Index < 1 {
 		return usage("--index must be >= 1 (index 0 is reserved)")
 	}
+	rangeSel := strings.TrimSpace(c.Range)
+	if rangeSel != "" && (heading != "" || flagProvided(kctx, "index") || c.TabID != "") {
+		return usage("--range cannot be combined with --index, --after-heading, or --tab-id")
+	}
+	if c.RangeEnd && rangeSel == "" {
+		return usage("--range-end requires --range")
+	}
+	markdown := c.Format == docsContentFormatMarkdown
+	if markdown && c.TabID != "" {
+		return usage("--tab-id is not yet supported with --format markdown")
+	}
 
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
 	svc, err := requireDocsService(ctx, flags)
 	if err != nil {
 		return err
 	}
+	if c.TabID, err = resolveDocsTabID(ctx, svc, docID, c.TabID); err != nil {
+		return err
+	}
 
-	result, err := svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{
-		Requests: []*docs.Request{{
-			InsertText: &docs.InsertTextRequest{
-				Text: content,
-				Location: &docs.Location{
-					Index: c.Index,
-					TabId: c.TabID,
-				},
+	doc := &docs.Document{DocumentId: docID}
+	index := c.Index
+	if rangeSel != "" {
+		index, err = c.namedRangeIndex(ctx, svc, docID, rangeSel)
+		if err != nil {
+			return err
+		}
+		if markdown && c.TabID != "" {
+			return usage("named ranges in other tabs are not yet supported with --format markdown")
+		}
+	}
+	if heading != "" {
+		index, doc, err = c.prepareAfterHeading(ctx, svc, docID, heading)
+		if err != nil {
+			return err
+		}
+		// Keep the inserted text in its own paragraph(s) rather than merging
+		// it into the paragraph that follows the heading.
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+	}
+
+	plainReqs := []*docs.Request{{
+		InsertText: &docs.InsertTextRequest{
+			Text: content,
+			Location: &docs.Location{
+				Index: index,
+				TabId: c.TabID,
 			},
-		}},
-	}).Context(ctx).Do()
-	if err != nil {
-		return fmt.Errorf("inserting text: %w", err)
+		},
+	}}
+	plan := map[string]any{"documentId": docID, "tabId": c.TabID, "index": index, "format": c.Format}
+	if markdown {
+		for k, v := range docsMarkdownPlan(content, index, index) {
+			plan[k] = v
+		}
+	} else {
+		plan["requests"] = plainReqs
+	}
+	if err := dryRunExit(ctx, flags, "docs.insert", plan); err != nil {
+		return err
+	}
+
+	if markdown {
+		basePath := "."
+		if c.File != "" && c.File != "-" {
+			basePath = c.File
+		}
+		if err := replaceDocsMarkdownRange(ctx, svc, account, doc, index, index, content, basePath); err != nil {
+			return err
+		}
+	} else {
+		_, err = svc.Documents.BatchUpdate(docID, docsBatchAtRevision(doc.RevisionId, plainReqs)).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("inserting text: %w", err)
+		}
 	}
 
 	if outfmt.IsJSON(ctx) {
-		payload := map[string]any{"documentId": result.DocumentId, "inserted": len(content), "atIndex": c.Index}
+		payload := map[string]any{"documentId": docID, "inserted": len(content), "atIndex": index}
+		if heading != "" {
+			payload["afterHeading"] = heading
+		}
+		if rangeSel != "" {
+			payload["range"] = rangeSel
+		}
 		if c.TabID != "" {
 			payload["tabId"] = c.TabID
 		}
 		return outfmt.WriteJSON(ctx, os.Stdout, payload)
 	}
 
-	u.Out().Printf("documentId\t%s", result.DocumentId)
+	u.Out().Printf("documentId\t%s", docID)
 	u.Out().Printf("inserted\t%d bytes", len(content))
-	u.Out().Printf("atIndex\t%d", c.Index)
+	u.Out().Printf("atIndex\t%d", index)
+	if heading != "" {
+		u.Out().Printf("afterHeading\t%s", heading)
+	}
+	if rangeSel != "" {
+		u.Out().Printf("range\t%s", rangeSel)
+	}
 	if c.TabID != "" {
 		u.Out().Printf("tabId\t%s", c.TabID)
 	}
 	return nil
 }
 
+// namedRangeIndex returns the insertion point for --range and adopts the
+// range's tab. Discontiguous ranges use their first span's start or their
+// last span's end.
+func (c *DocsInsertCmd) namedRangeIndex(ctx context.Context, svc *docs.Service, docID, nameOrID string) (int64, error) {
+	nr, err := resolveDocsNamedRange(ctx, svc, docID, nameOrID)
+	if err != nil {
+		return 0, err
+	}
+	var spans []*docs.Range
+	for _, r := range nr.Ranges {
+		if r != nil {
+			spans = append(spans, r)
+		}
+	}
+	if len(spans) == 0 {
+		return 0, fmt.Errorf("named range %q has no spans", nameOrID)
+	}
+	span := spans[0]
+	index := span.StartIndex
+	if c.RangeEnd {
+		span = spans[len(spans)-1]
+		index = span.EndIndex
+	}
+	c.TabID = span.TabId
+	return max(index, 1), nil
+}
+
+// prepareAfterHeading resolves the index right after a heading. When the
+// heading is the last paragraph there is nothing to insert before, so an empty
+// normal-text paragraph is added after it first.
+func (c *DocsInsertCmd) prepareAfterHeading(ctx context.Context, svc *docs.Service, docID, heading string) (int64, *docs.Document, error) {
+	loaded, err := loadDocsTargetDocument(ctx, svc, docID, c.TabID)
+	if err != nil {
+		return 0, nil, err
+	}
+	doc := loaded.target
+	end, last, ok := findDocsHeadingEnd(doc.Body, heading)
+	if !ok {
+		return 0, nil, fmt.Errorf("heading not found: %q", heading)
+	}
+	if !last {
+		return end, doc, nil
+	}
+
+	resp, err := svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{
+		WriteControl: &docs.WriteControl{RequiredRevisionId: doc.RevisionId},
+		Requests: []*docs.Request{
+			{InsertText: &docs.InsertTextRequest{
+				Location: &docs.Location{Index: end - 1, TabId: c.TabID},
+				Text:     "\n",
+			}},
+			{UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
+				Range:          &docs.Range{StartIndex: end, EndIndex: end + 1, TabId: c.TabID},
+				ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: "NORMAL_TEXT"},
+				Fields:         "namedStyleType",
+			}},
+		},
+	}).Context(ctx).Do()
+	if err != nil {
+		return 0, nil, fmt.Errorf("inserting text: %w", err)
+	}
+	next := &docs.Document{DocumentId: docID}
+	if resp.WriteControl != nil {
+		next.RevisionId = resp.WriteControl.RequiredRevisionId
+	}
+	return end, next, nil
+}
+
+// findDocsHeadingEnd returns the end index of the first heading paragraph
+// whose text matches heading, and whether it is the body's last element.
+func findDocsHeadingEnd(body *docs.Body, heading string) (int64, bool, bool) {
+	if body == nil {
+		return 0, false, false
+	}
+	for i, el := range body.Content {
+		if el == nil || el.Paragraph == nil || el.Paragraph.ParagraphStyle == nil {
+			continue
+		}
+		style := el.Paragraph.ParagraphStyle.NamedStyleType
+		if !strings.HasPrefix(style, "HEADING_") && style != "TITLE" && style != "SUBTITLE" {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(docsParagraphText(el.Paragraph.Elements)), heading) {
+			return el.EndIndex, i == len(body.Content)-1, true
+		}
+	}
+	return 0, false, false
+}
+
 type DocsDeleteCmd struct {
 	DocID string `arg:"" name:"docId" help:"Doc ID"`
 	Start int64  `name:"start" required:"" help:"Start index (>= 1)"`
 	End   int64  `name:"end" required:"" help:"End index (> start)"`
-	TabID string `name:"tab-id" help:"Target a specific tab by ID (see docs list-tabs)"`
+	TabID string `name:"tab-id" aliases:"tab" help:"Target a specific tab by ID or title (see docs tabs list)"`
 }
 
 func (c *DocsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -280,6 +527,9 @@ func (c *DocsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if docID == "" {
 		return usage("empty docId")
 	}
+	if err := ensureDriveUnlocked(ctx, flags, docID); err != nil {
+		return err
+	}
 	if c.Start < 1 {
 		return usage("--start must be >= 1")
 	}
@@ -291,6 +541,9 @@ func (c *DocsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	if c.TabID, err = resolveDocsTabID(ctx, svc, docID, c.TabID); err != nil {
+		return err
+	}
 
 	result, err := svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{
 		Requests: []*docs.Request{{
@@ -327,6 +580,7 @@ func (c *DocsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 type DocsClearCmd struct {
 	DocID string `arg:"" name:"docId" help:"Doc ID"`
+	TabID string `name:"tab-id" aliases:"tab" help:"Clear only this tab, by ID or title (see docs tabs list)"`
 }
 
 func (c *DocsClearCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -334,7 +588,49 @@ func (c *DocsClearCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if docID == "" {
 		return usage("empty docId")
 	}
-	return (&DocsSedCmd{DocID: docID, Expression: `s/^$//`}).Run(ctx, flags)
+	if err := ensureDriveUnlocked(ctx, flags, docID); err != nil {
+		return err
+	}
+	if strings.TrimSpace(c.TabID) == "" {
+		return (&DocsSedCmd{DocID: docID, Expression: `s/^$//`}).Run(ctx, flags)
+	}
+
+	if err := dryRunExit(ctx, flags, "docs.clear", map[string]any{
+		"documentId": docID,
+		"tabId":      c.TabID,
+	}); err != nil {
+		return err
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	tabID, err := resolveDocsTabID(ctx, svc, docID, c.TabID)
+	if err != nil {
+		return err
+	}
+	endIndex, err := docsTargetEndIndex(ctx, svc, docID, tabID)
+	if err != nil {
+		return err
+	}
+
+	// The tab body's final newline cannot be deleted.
+	u := ui.FromContext(ctx)
+	deleteEnd := endIndex - 1
+	if deleteEnd < 2 {
+		return sedOutputOK(ctx, u, docID, sedOutputKV{"cleared", 0}, sedOutputKV{"tabId", tabID})
+	}
+	if _, err := svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{
+		Requests: []*docs.Request{{
+			DeleteContentRange: &docs.DeleteContentRangeRequest{
+				Range: &docs.Range{StartIndex: 1, EndIndex: deleteEnd, TabId: tabID},
+			},
+		}},
+	}).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("clearing tab: %w", err)
+	}
+	return sedOutputOK(ctx, u, docID, sedOutputKV{"cleared", deleteEnd - 1}, sedOutputKV{"tabId", tabID})
 }
 
 type DocsFindReplaceCmd struct {
@@ -345,7 +641,7 @@ type DocsFindReplaceCmd struct {
 	MatchCase   bool   `name:"match-case" help:"Case-sensitive matching"`
 	Format      string `name:"format" help:"Replacement format: plain|markdown. Markdown converts formatting, tables, and inline images; local images must be under --content-file's directory (or use remote URLs)." default:"plain" enum:"plain,markdown"`
 	First       bool   `name:"first" help:"Replace only the first occurrence instead of all."`
-	TabID       string `name:"tab-id" help:"Target a specific tab by ID (see docs list-tabs)"`
+	TabID       string `name:"tab-id" aliases:"tab" help:"Target a specific tab by ID or title (see docs tabs list)"`
 }
 
 type DocsEditCmd struct {
@@ -370,6 +666,9 @@ func (c *DocsFindReplaceCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if docID == "" {
 		return usage("empty docId")
 	}
+	if err := ensureDriveUnlocked(ctx, flags, docID); err != nil {
+		return err
+	}
 	if c.Find == "" {
 		return usage("find text cannot be empty")
 	}
@@ -396,6 +695,9 @@ func (c *DocsFindReplaceCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	if c.TabID, err = resolveDocsTabID(ctx, svc, docID, c.TabID); err != nil {
+		return err
+	}
 
 	if !c.First && format == docsContentFormatPlain {
 		return c.runReplaceAll(ctx, u, svc, docID, replaceText)
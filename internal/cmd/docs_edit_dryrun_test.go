@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestDocsWriteAndInsert_DryRunShowsRequests(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	var batchCalls int
+	svc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, ":batchUpdate") {
+			batchCalls++
+			http.Error(w, "unexpected write", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"documentId": "doc1",
+			"body":       map[string]any{"content": []any{map[string]any{"startIndex": 1, "endIndex": 12}}},
+		})
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return svc, nil }
+
+	flags := &RootFlags{Account: "a@b.com", DryRun: true}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+
+	type plan struct {
+		Op      string `json:"op"`
+		Request struct {
+			Requests []*docs.Request       `json:"requests"`
+			Segments []docsMarkdownSegment `json:"segments"`
+		} `json:"request"`
+	}
+	run := func(cmd any, args []string) plan {
+		t.Helper()
+		out := captureStdout(t, func() {
+			err := runKong(t, cmd, args, ctx, flags)
+			var exitErr *ExitError
+			if err != nil && (!errors.As(err, &exitErr) || exitErr.Code != 0) {
+				t.Fatalf("dry run: %v", err)
+			}
+		})
+		var got plan
+		if err := json.Unmarshal([]byte(out), &got); err != nil {
+			t.Fatalf("unmarshal: %v (output: %q)", err, out)
+		}
+		return got
+	}
+
+	write := run(&DocsWriteCmd{}, []string{"doc1", "--text", "hello"})
+	if write.Op != "docs.write" || len(write.Request.Requests) != 2 ||
+		write.Request.Requests[0].DeleteContentRange.Range.EndIndex != 11 ||
+		write.Request.Requests[1].InsertText.Text != "hello" {
+		t.Fatalf("unexpected write plan: %#v", write)
+	}
+
+	insert := run(&DocsInsertCmd{}, []string{"doc1", "# Title\n\n- **item**", "--format", "markdown"})
+	if insert.Op != "docs.insert" || len(insert.Request.Segments) != 2 ||
+		insert.Request.Segments[0].Type != "heading1" || insert.Request.Segments[1].Type != "bullet" {
+		t.Fatalf("unexpected segments: %#v", insert.Request.Segments)
+	}
+	if len(insert.Request.Requests) < 2 || insert.Request.Requests[0].InsertText == nil {
+		t.Fatalf("unexpected insert requests: %#v", insert.Request.Requests)
+	}
+	if batchCalls != 0 {
+		t.Fatalf("dry run made %d batchUpdate calls", batchCalls)
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+const docsExportAllDefaultName = "{{.Title}}{{.Ext}}"
+
+// DocsExportAllCmd exports one Doc in several formats at once, e.g. for a
+// release pipeline. Every file is named from the same template and the run
+// is recorded in manifest.json, like drive export-all.
+type DocsExportAllCmd struct {
+	DocID   string                 `arg:"" name:"docId" help:"Doc ID"`
+	Formats string                 `name:"formats" help:"Comma-separated export formats: pdf|docx|txt|md|html|epub" default:"pdf,docx,md,txt"`
+	Out     string                 `name:"out" short:"o" required:"" help:"Directory to write exports and manifest.json into"`
+	Name    ExportNameTemplateFlag `embed:""`
+	Workers int                    `name:"workers" help:"Concurrent exports" default:"4"`
+	Retries int                    `name:"retries" help:"Retries per format on rate limits and server errors" default:"3"`
+}
+
+func (c *DocsExportAllCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if docID == "" {
+		return usage("empty docId")
+	}
+	outDir, err := config.ExpandPath(strings.TrimSpace(c.Out))
+	if err != nil {
+		return err
+	}
+	if outDir == "" {
+		return usage("empty --out")
+	}
+	if c.Workers < 1 {
+		return usage("--workers must be >= 1")
+	}
+	if c.Retries < 0 {
+		return usage("--retries must be >= 0")
+	}
+	var formats []string
+	seenFormat := map[string]bool{}
+	for _, f := range splitCSV(c.Formats) {
+		f = strings.ToLower(f)
+		if seenFormat[f] {
+			continue
+		}
+		if _, err := driveExportMimeTypeForFormat(driveMimeGoogleDoc, f); err != nil {
+			return usage(err.Error())
+		}
+		seenFormat[f] = true
+		formats = append(formats, f)
+	}
+	if len(formats) == 0 {
+		return usage("empty --formats")
+	}
+	rawTmpl := c.Name.NameTemplate
+	if strings.TrimSpace(rawTmpl) == "" {
+		rawTmpl = docsExportAllDefaultName
+	}
+	nameTmpl, err := parseExportNameTemplate(rawTmpl)
+	if err != nil {
+		return err
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	meta, err := svc.Files.Get(docID).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType, headRevisionId, version, modifiedTime").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	if meta.MimeType != driveMimeGoogleDoc {
+		return usagef("%s is not a Google Doc (mimeType=%s)", meta.Name, meta.MimeType)
+	}
+
+	// #nosec G301 -- destination directory is explicitly chosen by the caller.
+	if err := os.MkdirAll(outDir, 0o700); err != nil {
+		return err
+	}
+	files := make([]*driveExportAllFile, 0, len(formats))
+	seenPath := map[string]string{}
+	for _, format := range formats {
+		dest, err := resolveTemplatedDestPath(nameTmpl, newExportNameData(meta, "", format), outDir)
+		if err != nil {
+			return err
+		}
+		name := filepath.Base(dest)
+		if other, dup := seenPath[name]; dup {
+			return usagef("--name-template gives %s and %s the same filename %q (use .Ext or .Format)", other, format, name)
+		}
+		seenPath[name] = format
+		exportMime, _ := driveExportMimeTypeForFormat(meta.MimeType, format)
+		files = append(files, &driveExportAllFile{Path: name, ID: meta.Id, MimeType: meta.MimeType, exportMime: exportMime})
+	}
+	exportDriveFiles(ctx, svc, outDir, files, c.Workers, c.Retries)
+
+	exported := []*driveExportAllFile{}
+	failed := []*driveExportAllFile{}
+	for _, f := range files {
+		if f.Error != "" {
+			failed = append(failed, f)
+		} else {
+			exported = append(exported, f)
+		}
+	}
+	manifest := map[string]any{
+		"docId":      meta.Id,
+		"title":      meta.Name,
+		"revision":   meta.HeadRevisionId,
+		"formats":    formats,
+		"exportedAt": time.Now().UTC().Format(time.RFC3339),
+		"exported":   exported,
+		"failed":     failed,
+	}
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	manifestPath := filepath.Join(outDir, driveExportAllManifest)
+	if err := os.WriteFile(manifestPath, append(raw, '\n'), 0o600); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		manifest["manifest"] = manifestPath
+		if err := outfmt.WriteJSON(ctx, os.Stdout, manifest); err != nil {
+			return err
+		}
+	} else {
+		for _, f := range failed {
+			u.Err().Printf("Failed %s: %s", f.Path, f.Error)
+		}
+		for _, f := range exported {
+			u.Out().Printf("%s\t%s", f.Path, humanSize(ctx, f.Size))
+		}
+		u.Out().Printf("manifest\t%s", manifestPath)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d formats failed to export (see %s)", len(failed), len(files), manifestPath)
+	}
+	return nil
+}
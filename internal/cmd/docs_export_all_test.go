@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDocsExportAll(t *testing.T) {
+	origDrive, origExport := newDriveService, driveExportDownload
+	t.Cleanup(func() { newDriveService, driveExportDownload = origDrive, origExport })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.TrimPrefix(r.URL.Path, "/drive/v3") != "/files/doc1" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "doc1", "name": "Release Notes", "mimeType": driveMimeGoogleDoc, "headRevisionId": "r9"})
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	var mu sync.Mutex
+	var exported []string
+	driveExportDownload = func(_ context.Context, _ *drive.Service, fileID string, mimeType string) (*http.Response, error) {
+		mu.Lock()
+		exported = append(exported, mimeType)
+		mu.Unlock()
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(fileID + ":" + mimeType))}, nil
+	}
+
+	outDir := filepath.Join(t.TempDir(), "out")
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "docs", "export-all", "doc1", "--formats", "pdf,docx,md,txt,pdf", "-o", outDir}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if len(exported) != 4 {
+		t.Fatalf("expected 4 exports, got %v", exported)
+	}
+	for name, mime := range map[string]string{"Release Notes.pdf": mimePDF, "Release Notes.docx": mimeDocx, "Release Notes.md": mimeTextMarkdown, "Release Notes.txt": mimeTextPlain} {
+		b, err := os.ReadFile(filepath.Join(outDir, name))
+		if err != nil || string(b) != "doc1:"+mime {
+			t.Fatalf("%s: %q %v", name, b, err)
+		}
+	}
+	var manifest struct {
+		Revision string               `json:"revision"`
+		Exported []driveExportAllFile `json:"exported"`
+	}
+	raw, err := os.ReadFile(filepath.Join(outDir, driveExportAllManifest))
+	if err != nil || json.Unmarshal(raw, &manifest) != nil || manifest.Revision != "r9" || len(manifest.Exported) != 4 {
+		t.Fatalf("unexpected manifest %s: %v", raw, err)
+	}
+	if !strings.Contains(out, `"manifest":`) {
+		t.Fatalf("unexpected output %q", out)
+	}
+
+	err = Execute([]string{"--account", "a@b.com", "docs", "export-all", "doc1", "--formats", "pdf,docx", "-o", outDir, "--name-template", "{{.Title}}"})
+	if ExitCode(stableExitCode(err)) != 2 || !strings.Contains(err.Error(), "same filename") {
+		t.Fatalf("expected filename clash usage error, got %v", err)
+	}
+	if err := Execute([]string{"--account", "a@b.com", "docs", "export-all", "doc1", "--formats", "pdf,xlsx", "-o", outDir}); ExitCode(stableExitCode(err)) != 2 {
+		t.Fatalf("expected usage error for xlsx, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+)
+
+var (
+	mdFootnoteDefRe = regexp.MustCompile(`^ {0,3}\[\^([^\]\s]+)\]:[ \t]*(.*)$`)
+	mdFootnoteRefRe = regexp.MustCompile(`\[\^([^\]\s]+)\]`)
+)
+
+// markdownFootnote is a [^label] reference extracted from markdown content,
+// paired with the text of its [^label]: definition.
+type markdownFootnote struct {
+	index int
+	label string
+	text  string
+	token string
+}
+
+func (f markdownFootnote) placeholder() string {
+	return fmt.Sprintf("<<FN_%s_%d>>", f.token, f.index)
+}
+
+// extractMarkdownFootnotes removes [^label]: definitions from content and
+// replaces each [^label] reference with a unique <<FN_token_N>> placeholder.
+// Indented lines after a definition continue it. References without a
+// definition and anything inside fenced code blocks are left alone. A label
+// referenced twice yields two footnotes, since Docs has no shared notes.
+func extractMarkdownFootnotes(content string) (string, []markdownFootnote) {
+	lines := strings.Split(content, "\n")
+	defs := make(map[string]string)
+	kept := make([]string, 0, len(lines))
+	code := make([]bool, 0, len(lines))
+	inFence := false
+	lastDef := ""
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			lastDef = ""
+			kept = append(kept, line)
+			code = append(code, true)
+			continue
+		}
+		if inFence {
+			kept = append(kept, line)
+			code = append(code, true)
+			continue
+		}
+		if m := mdFootnoteDefRe.FindStringSubmatch(line); m != nil {
+			lastDef = m[1]
+			defs[lastDef] = strings.TrimSpace(m[2])
+			continue
+		}
+		if lastDef != "" && trimmed != "" && (strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")) {
+			defs[lastDef] = strings.TrimSpace(defs[lastDef] + " " + trimmed)
+			continue
+		}
+		lastDef = ""
+		kept = append(kept, line)
+		code = append(code, false)
+	}
+	if len(defs) == 0 {
+		return content, nil
+	}
+
+	token := imgPlaceholderToken()
+	var notes []markdownFootnote
+	for i, line := range kept {
+		if code[i] {
+			continue
+		}
+		kept[i] = mdFootnoteRefRe.ReplaceAllStringFunc(line, func(match string) string {
+			label := mdFootnoteRefRe.FindStringSubmatch(match)[1]
+			text, ok := defs[label]
+			if !ok {
+				return match
+			}
+			note := markdownFootnote{index: len(notes), label: label, text: text, token: token}
+			notes = append(notes, note)
+			return note.placeholder()
+		})
+	}
+
+	// Definitions usually sit at the end; drop the blank lines they leave.
+	cleaned := strings.TrimRight(strings.Join(kept, "\n"), " \t\n")
+	if strings.HasSuffix(content, "\n") {
+		cleaned += "\n"
+	}
+	return cleaned, notes
+}
+
+// insertFootnotesIntoDocs reads back docID, swaps each footnote placeholder
+// for a footnote reference, then fills in the footnote bodies. It takes two
+// batches because footnote IDs are only known after CreateFootnote runs.
+func insertFootnotesIntoDocs(ctx context.Context, svc *docs.Service, docID string, notes []markdownFootnote) error {
+	doc, err := svc.Documents.Get(docID).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("read back document: %w", err)
+	}
+	if doc.Body == nil {
+		return nil
+	}
+
+	placeholders := make([]string, len(notes))
+	for i, note := range notes {
+		placeholders[i] = note.placeholder()
+	}
+	found := make(map[string]docRange)
+	searchElements(doc.Body.Content, placeholders, found)
+
+	type entry struct {
+		note markdownFootnote
+		dr   docRange
+	}
+	var entries []entry
+	for _, note := range notes {
+		if dr, ok := found[note.placeholder()]; ok {
+			entries = append(entries, entry{note: note, dr: dr})
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	// Work from the end of the document so earlier indices stay valid.
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].dr.startIndex > entries[j].dr.startIndex
+	})
+
+	reqs := make([]*docs.Request, 0, len(entries)*2)
+	for _, e := range entries {
+		reqs = append(reqs,
+			&docs.Request{DeleteContentRange: &docs.DeleteContentRangeRequest{
+				Range: &docs.Range{StartIndex: e.dr.startIndex, EndIndex: e.dr.endIndex},
+			}},
+			&docs.Request{CreateFootnote: &docs.CreateFootnoteRequest{
+				Location: &docs.Location{Index: e.dr.startIndex},
+			}},
+		)
+	}
+	resp, err := svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{Requests: reqs}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("create footnotes: %w", err)
+	}
+
+	var textReqs []*docs.Request
+	for i, e := range entries {
+		reply := 2*i + 1
+		if reply >= len(resp.Replies) || resp.Replies[reply].CreateFootnote == nil || e.note.text == "" {
+			continue
+		}
+		textReqs = append(textReqs, &docs.Request{InsertText: &docs.InsertTextRequest{
+			EndOfSegmentLocation: &docs.EndOfSegmentLocation{SegmentId: resp.Replies[reply].CreateFootnote.FootnoteId},
+			Text:                 e.note.text,
+		}})
+	}
+	if len(textReqs) == 0 {
+		return nil
+	}
+	if _, err := svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{Requests: textReqs}).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("insert footnote text: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestExtractMarkdownFootnotes(t *testing.T) {
+	origToken := imgPlaceholderToken
+	t.Cleanup(func() { imgPlaceholderToken = origToken })
+	imgPlaceholderToken = func() string { return "t" }
+
+	in := "Claim one[^1] and two[^note].\nAgain[^1], missing[^x].\n\n```\ncode[^1]\n```\n\n[^1]: First source.\n[^note]: Second source\n    continued here.\n"
+	cleaned, notes := extractMarkdownFootnotes(in)
+
+	want := "Claim one<<FN_t_0>> and two<<FN_t_1>>.\nAgain<<FN_t_2>>, missing[^x].\n\n```\ncode[^1]\n```\n"
+	if cleaned != want {
+		t.Fatalf("unexpected cleaned content:\n%q\nwant\n%q", cleaned, want)
+	}
+	if len(notes) != 3 || notes[0].text != "First source." || notes[1].text != "Second source continued here." || notes[2].label != "1" {
+		t.Fatalf("unexpected notes: %#v", notes)
+	}
+
+	if out, none := extractMarkdownFootnotes("no notes [^1] here"); out != "no notes [^1] here" || none != nil {
+		t.Fatalf("expected content without definitions to pass through, got %q %#v", out, none)
+	}
+}
+
+func TestDocsWriteCmd_MarkdownFootnotes(t *testing.T) {
+	origDocs, origToken := newDocsService, imgPlaceholderToken
+	t.Cleanup(func() { newDocsService = origDocs; imgPlaceholderToken = origToken })
+	imgPlaceholderToken = func() string { return "t" }
+
+	written := false
+	var batches []docs.BatchUpdateDocumentRequest
+	svc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/documents/doc1"):
+			text := "Old\n"
+			if written {
+				text = "Fact<<FN_t_0>>.\n"
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"documentId": "doc1",
+				"body":       map[string]any{"content": []any{docsTestParagraph(1, text, "NORMAL_TEXT")}},
+			})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/documents/doc1:batchUpdate"):
+			var req docs.BatchUpdateDocumentRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("decode: %v", err)
+			}
+			batches = append(batches, req)
+			written = true
+			replies := make([]any, len(req.Requests))
+			for i, rq := range req.Requests {
+				replies[i] = map[string]any{}
+				if rq.CreateFootnote != nil {
+					replies[i] = map[string]any{"createFootnote": map[string]any{"footnoteId": "kix.fn1"}}
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"documentId": "doc1", "replies": replies})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return svc, nil }
+
+	ctx, out := newDocsCmdOutputContext(t)
+	args := []string{"doc1", "--format", "markdown", "--text", "Fact[^1].\n\n[^1]: Smith 2020, p. 4.\n"}
+	if err := runKong(t, &DocsWriteCmd{}, args, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	var create *docs.CreateFootnoteRequest
+	var deleted *docs.Range
+	var note *docs.InsertTextRequest
+	for _, b := range batches {
+		for _, r := range b.Requests {
+			switch {
+			case r.CreateFootnote != nil:
+				create = r.CreateFootnote
+			case r.DeleteContentRange != nil && create == nil:
+				deleted = r.DeleteContentRange.Range
+			case r.InsertText != nil && r.InsertText.EndOfSegmentLocation != nil:
+				note = r.InsertText
+			}
+		}
+	}
+	if create == nil || create.Location.Index != 5 {
+		t.Fatalf("expected footnote at the placeholder, got %#v", create)
+	}
+	if deleted == nil || deleted.StartIndex != 5 || deleted.EndIndex != 15 {
+		t.Fatalf("expected placeholder to be deleted, got %#v", deleted)
+	}
+	if note == nil || note.EndOfSegmentLocation.SegmentId != "kix.fn1" || note.Text != "Smith 2020, p. 4." {
+		t.Fatalf("unexpected footnote text request: %#v", note)
+	}
+	if !strings.Contains(out.String(), "format\tmarkdown") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
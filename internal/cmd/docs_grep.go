@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/docsmd"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type DocsGrepCmd struct {
+	DocID      string `arg:"" name:"docId" help:"Doc ID"`
+	Pattern    string `arg:"" name:"pattern" help:"Regular expression (Go RE2 syntax) matched against each paragraph"`
+	IgnoreCase bool   `name:"ignore-case" short:"i" help:"Case-insensitive match"`
+	Fixed      bool   `name:"fixed" short:"F" help:"Treat pattern as a literal string"`
+	Tab        string `name:"tab" help:"Only search this tab, by title or ID (default: every tab)"`
+	Max        int    `name:"max" aliases:"limit" help:"Stop after this many matching paragraphs (0 = no limit)" default:"0"`
+	FailEmpty  bool   `name:"fail-empty" aliases:"non-empty,require-results" help:"Exit with code 3 if no results"`
+}
+
+// docsGrepHit is one paragraph containing at least one match. Match ranges
+// are document indexes, usable with docs delete/insert and --range style
+// commands.
+type docsGrepHit struct {
+	TabID      string          `json:"tabId,omitempty"`
+	TabTitle   string          `json:"tabTitle,omitempty"`
+	Heading    string          `json:"heading,omitempty"`
+	StartIndex int64           `json:"startIndex"`
+	EndIndex   int64           `json:"endIndex"`
+	Text       string          `json:"text"`
+	Matches    []docsGrepMatch `json:"matches"`
+	InTable    bool            `json:"inTable,omitempty"`
+}
+
+type docsGrepMatch struct {
+	StartIndex int64  `json:"startIndex"`
+	EndIndex   int64  `json:"endIndex"`
+	Text       string `json:"text"`
+}
+
+func (c *DocsGrepCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	id := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if id == "" {
+		return usage("empty docId")
+	}
+	if c.Pattern == "" {
+		return usage("empty pattern")
+	}
+	if c.Max < 0 {
+		return usage("--max must be >= 0")
+	}
+	expr := c.Pattern
+	if c.Fixed {
+		expr = regexp.QuoteMeta(expr)
+	}
+	if c.IgnoreCase {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return usagef("invalid pattern: %v", err)
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	doc, err := svc.Documents.Get(id).IncludeTabsContent(true).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return fmt.Errorf("doc not found or not a Google Doc (id=%s)", id)
+		}
+		return err
+	}
+	if doc == nil {
+		return errors.New("doc not found")
+	}
+
+	var hits []docsGrepHit
+	tabs := flattenTabs(doc.Tabs)
+	switch {
+	case strings.TrimSpace(c.Tab) != "":
+		tab := findTab(tabs, c.Tab)
+		if tab == nil {
+			return fmt.Errorf("tab not found: %s", c.Tab)
+		}
+		hits = grepDocsTab(tab, re)
+	case len(tabs) == 0:
+		hits = grepDocsBody(doc.Body, re, "", "")
+	default:
+		for _, tab := range tabs {
+			hits = append(hits, grepDocsTab(tab, re)...)
+		}
+	}
+	if c.Max > 0 && len(hits) > c.Max {
+		hits = hits[:c.Max]
+	}
+	if hits == nil {
+		hits = []docsGrepHit{}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId": id,
+			"pattern":    c.Pattern,
+			"hits":       hits,
+		}); err != nil {
+			return err
+		}
+		if len(hits) == 0 {
+			return failEmptyExit(c.FailEmpty)
+		}
+		return nil
+	}
+
+	if len(hits) == 0 {
+		u.Err().Println("No matches")
+		return failEmptyExit(c.FailEmpty)
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "START\tEND\tTAB\tHEADING\tTEXT")
+	for _, h := range hits {
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\n", h.StartIndex, h.EndIndex, h.TabID, oneLineTSV(h.Heading), oneLineTSV(h.Text))
+	}
+	return nil
+}
+
+func grepDocsTab(tab *docs.Tab, re *regexp.Regexp) []docsGrepHit {
+	if tab == nil || tab.DocumentTab == nil {
+		return nil
+	}
+	var tabID, title string
+	if tab.TabProperties != nil {
+		tabID, title = tab.TabProperties.TabId, tab.TabProperties.Title
+	}
+	return grepDocsBody(tab.DocumentTab.Body, re, tabID, title)
+}
+
+// grepDocsBody matches re against every paragraph in body, including table
+// cells, and labels each hit with the nearest preceding top-level heading.
+func grepDocsBody(body *docs.Body, re *regexp.Regexp, tabID, tabTitle string) []docsGrepHit {
+	if body == nil {
+		return nil
+	}
+	var hits []docsGrepHit
+	heading := ""
+	var walk func(content []*docs.StructuralElement, inTable bool)
+	walk = func(content []*docs.StructuralElement, inTable bool) {
+		for _, el := range content {
+			if el == nil {
+				continue
+			}
+			if el.Table != nil {
+				for _, row := range el.Table.TableRows {
+					for _, cell := range row.TableCells {
+						walk(cell.Content, true)
+					}
+				}
+				continue
+			}
+			if el.Paragraph == nil {
+				continue
+			}
+			text := docsParagraphText(el.Paragraph.Elements)
+			if !inTable && el.Paragraph.ParagraphStyle != nil {
+				if _, ok := docsHeadingLevel(el.Paragraph.ParagraphStyle.NamedStyleType); ok {
+					heading = strings.TrimSpace(text)
+				}
+			}
+			if hit, ok := grepDocsParagraph(el, text, re); ok {
+				hit.TabID, hit.TabTitle, hit.Heading, hit.InTable = tabID, tabTitle, heading, inTable
+				hits = append(hits, hit)
+			}
+		}
+	}
+	walk(body.Content, false)
+	return hits
+}
+
+func grepDocsParagraph(el *docs.StructuralElement, text string, re *regexp.Regexp) (docsGrepHit, bool) {
+	locs := re.FindAllStringIndex(text, -1)
+	if len(locs) == 0 {
+		return docsGrepHit{}, false
+	}
+	start := el.StartIndex
+	for _, pe := range el.Paragraph.Elements {
+		if pe != nil && pe.TextRun != nil {
+			start = pe.StartIndex
+			break
+		}
+	}
+	hit := docsGrepHit{StartIndex: el.StartIndex, EndIndex: el.EndIndex, Text: text}
+	for _, loc := range locs {
+		if loc[0] == loc[1] {
+			continue
+		}
+		mStart := start + docsmd.UTF16Len(text[:loc[0]])
+		hit.Matches = append(hit.Matches, docsGrepMatch{
+			StartIndex: mStart,
+			EndIndex:   mStart + docsmd.UTF16Len(text[loc[0]:loc[1]]),
+			Text:       text[loc[0]:loc[1]],
+		})
+	}
+	if len(hit.Matches) == 0 {
+		return docsGrepHit{}, false
+	}
+	return hit, true
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestDocsGrepCmd(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	svc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"documentId": "doc1",
+			"tabs": []any{map[string]any{
+				"tabProperties": map[string]any{"tabId": "t.0", "title": "Main"},
+				"documentTab": map[string]any{"body": map[string]any{"content": []any{
+					docsTestParagraph(1, "Setup\n", "HEADING_1"),
+					docsTestParagraph(7, "Run the TODO list, todo later\n", "NORMAL_TEXT"),
+					map[string]any{"startIndex": 37, "endIndex": 50, "table": map[string]any{"tableRows": []any{
+						map[string]any{"tableCells": []any{map[string]any{"content": []any{
+							docsTestParagraph(39, "cell todo\n", "NORMAL_TEXT"),
+						}}}},
+					}}},
+					docsTestParagraph(50, "Nothing here\n", "NORMAL_TEXT"),
+				}}},
+			}},
+		})
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return svc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DocsGrepCmd{}, []string{"doc1", "todo", "-i"}, ctx, flags); err != nil {
+			t.Fatalf("grep: %v", err)
+		}
+	})
+	var got struct {
+		Hits []docsGrepHit `json:"hits"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if len(got.Hits) != 2 {
+		t.Fatalf("expected 2 hits, got %#v", got.Hits)
+	}
+	first := got.Hits[0]
+	if first.Heading != "Setup" || first.TabID != "t.0" || len(first.Matches) != 2 {
+		t.Fatalf("unexpected first hit: %#v", first)
+	}
+	if m := first.Matches[0]; m.StartIndex != 15 || m.EndIndex != 19 || m.Text != "TODO" {
+		t.Fatalf("unexpected match: %#v", m)
+	}
+	if !got.Hits[1].InTable || got.Hits[1].Matches[0].StartIndex != 44 {
+		t.Fatalf("unexpected table hit: %#v", got.Hits[1])
+	}
+
+	err := runKong(t, &DocsGrepCmd{}, []string{"doc1", "absent", "--fail-empty"}, ctx, flags)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != emptyResultsExitCode {
+		t.Fatalf("expected empty-results exit, got %v", err)
+	}
+}
@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+const (
+	docsSegmentHeader = "header"
+	docsSegmentFooter = "footer"
+)
+
+// DocsSegmentFlags is shared by docs header and docs footer.
+// Page-number fields are read-only in the Docs API: they show up as {page}
+// and {pages} when printed but cannot be inserted with --text.
+type DocsSegmentFlags struct {
+	DocID  string `arg:"" name:"docId" help:"Doc ID"`
+	Text   string `name:"text" help:"Replace the text (creates the segment if missing); omit to print the current text"`
+	Remove bool   `name:"remove" help:"Delete the segment from the document"`
+}
+
+type DocsHeaderCmd struct {
+	Segment DocsSegmentFlags `embed:""`
+}
+
+func (c *DocsHeaderCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
+	return runDocsHeaderFooter(ctx, flags, docsSegmentHeader, c.Segment, flagProvided(kctx, "text"))
+}
+
+type DocsFooterCmd struct {
+	Segment DocsSegmentFlags `embed:""`
+}
+
+func (c *DocsFooterCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
+	return runDocsHeaderFooter(ctx, flags, docsSegmentFooter, c.Segment, flagProvided(kctx, "text"))
+}
+
+func runDocsHeaderFooter(ctx context.Context, flags *RootFlags, kind string, c DocsSegmentFlags, setText bool) error {
+	u := ui.FromContext(ctx)
+	id := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if id == "" {
+		return usage("empty docId")
+	}
+	if setText && c.Remove {
+		return usage("use --text or --remove, not both")
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	doc, err := svc.Documents.Get(id).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return fmt.Errorf("doc not found or not a Google Doc (id=%s)", id)
+		}
+		return err
+	}
+	if doc == nil {
+		return errors.New("doc not found")
+	}
+
+	segmentID, segment := docsDefaultSegment(doc.DocumentStyle, doc.Headers, doc.Footers, kind)
+
+	switch {
+	case c.Remove:
+		if segmentID == "" {
+			return fmt.Errorf("doc has no %s", kind)
+		}
+		if err := dryRunExit(ctx, flags, "docs."+kind+".remove", map[string]any{
+			"documentId": id,
+			"segmentId":  segmentID,
+		}); err != nil {
+			return err
+		}
+		req := &docs.Request{}
+		if kind == docsSegmentHeader {
+			req.DeleteHeader = &docs.DeleteHeaderRequest{HeaderId: segmentID}
+		} else {
+			req.DeleteFooter = &docs.DeleteFooterRequest{FooterId: segmentID}
+		}
+		if _, err := svc.Documents.BatchUpdate(id, docsBatchAtRevision(doc.RevisionId, []*docs.Request{req})).Context(ctx).Do(); err != nil {
+			return fmt.Errorf("removing %s: %w", kind, err)
+		}
+		return writeResult(ctx, u,
+			kv("documentId", id),
+			kv("removed", kind),
+			kv("segmentId", segmentID),
+		)
+
+	case setText:
+		text := strings.TrimRight(c.Text, "\n")
+		if err := dryRunExit(ctx, flags, "docs."+kind+".set", map[string]any{
+			"documentId": id,
+			"segmentId":  segmentID,
+			"text":       text,
+		}); err != nil {
+			return err
+		}
+		created := false
+		revision := doc.RevisionId
+		if segmentID == "" {
+			req := &docs.Request{}
+			if kind == docsSegmentHeader {
+				req.CreateHeader = &docs.CreateHeaderRequest{Type: "DEFAULT"}
+			} else {
+				req.CreateFooter = &docs.CreateFooterRequest{Type: "DEFAULT"}
+			}
+			resp, createErr := svc.Documents.BatchUpdate(id, docsBatchAtRevision(revision, []*docs.Request{req})).Context(ctx).Do()
+			if createErr != nil {
+				return fmt.Errorf("creating %s: %w", kind, createErr)
+			}
+			segmentID = docsCreatedSegmentID(resp)
+			if segmentID == "" {
+				return fmt.Errorf("creating %s: no segment ID in response", kind)
+			}
+			created = true
+			revision = ""
+			if resp.WriteControl != nil {
+				revision = resp.WriteControl.RequiredRevisionId
+			}
+		}
+
+		requests := docsReplaceSegmentRequests(segmentID, segment, text)
+		if len(requests) > 0 {
+			if _, err := svc.Documents.BatchUpdate(id, docsBatchAtRevision(revision, requests)).Context(ctx).Do(); err != nil {
+				return fmt.Errorf("writing %s: %w", kind, err)
+			}
+		}
+		return writeResult(ctx, u,
+			kv("documentId", id),
+			kv("segmentId", segmentID),
+			kv("created", created),
+			kv("text", text),
+		)
+	}
+
+	text := docsSegmentText(segment)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId": id,
+			"segmentId":  segmentID,
+			"text":       text,
+		})
+	}
+	if segmentID == "" {
+		u.Err().Printf("Doc has no %s", kind)
+		return nil
+	}
+	u.Out().Println(strings.TrimRight(text, "\n"))
+	return nil
+}
+
+// docsDefaultSegment returns the default header or footer and its ID.
+func docsDefaultSegment(style *docs.DocumentStyle, headers map[string]docs.Header, footers map[string]docs.Footer, kind string) (string, []*docs.StructuralElement) {
+	if style == nil {
+		return "", nil
+	}
+	if kind == docsSegmentHeader {
+		if h, ok := headers[style.DefaultHeaderId]; ok && style.DefaultHeaderId != "" {
+			return style.DefaultHeaderId, h.Content
+		}
+		return "", nil
+	}
+	if f, ok := footers[style.DefaultFooterId]; ok && style.DefaultFooterId != "" {
+		return style.DefaultFooterId, f.Content
+	}
+	return "", nil
+}
+
+func docsCreatedSegmentID(resp *docs.BatchUpdateDocumentResponse) string {
+	if resp == nil {
+		return ""
+	}
+	for _, reply := range resp.Replies {
+		switch {
+		case reply == nil:
+		case reply.CreateHeader != nil:
+			return reply.CreateHeader.HeaderId
+		case reply.CreateFooter != nil:
+			return reply.CreateFooter.FooterId
+		}
+	}
+	return ""
+}
+
+// docsReplaceSegmentRequests clears a header/footer segment and inserts text.
+// The segment's final newline cannot be deleted, so the range stops before it.
+func docsReplaceSegmentRequests(segmentID string, content []*docs.StructuralElement, text string) []*docs.Request {
+	var start, end int64
+	if len(content) > 0 {
+		start = content[0].StartIndex
+		end = content[len(content)-1].EndIndex
+	}
+
+	var requests []*docs.Request
+	if end-1 > start {
+		requests = append(requests, &docs.Request{
+			DeleteContentRange: &docs.DeleteContentRangeRequest{
+				Range: &docs.Range{SegmentId: segmentID, StartIndex: start, EndIndex: end - 1},
+			},
+		})
+	}
+	if text != "" {
+		requests = append(requests, &docs.Request{
+			InsertText: &docs.InsertTextRequest{
+				Location: &docs.Location{SegmentId: segmentID, Index: start},
+				Text:     text,
+			},
+		})
+	}
+	return requests
+}
+
+// docsSegmentText renders header/footer content as plain text, showing
+// page-number fields as {page} and {pages}.
+func docsSegmentText(content []*docs.StructuralElement) string {
+	var buf bytes.Buffer
+	for _, el := range content {
+		if el == nil {
+			continue
+		}
+		if el.Paragraph == nil {
+			appendDocsElementText(&buf, 0, el)
+			continue
+		}
+		for _, p := range el.Paragraph.Elements {
+			switch {
+			case p.TextRun != nil:
+				buf.WriteString(p.TextRun.Content)
+			case p.AutoText != nil && p.AutoText.Type == "PAGE_NUMBER":
+				buf.WriteString("{page}")
+			case p.AutoText != nil && p.AutoText.Type == "PAGE_COUNT":
+				buf.WriteString("{pages}")
+			}
+		}
+	}
+	return buf.String()
+}
+
+// docsWithHeaderFooter wraps body text with the default header and footer.
+func docsWithHeaderFooter(body string, style *docs.DocumentStyle, headers map[string]docs.Header, footers map[string]docs.Footer) string {
+	var b strings.Builder
+	if _, header := docsDefaultSegment(style, headers, footers, docsSegmentHeader); header != nil {
+		b.WriteString("=== Header ===\n")
+		b.WriteString(strings.TrimRight(docsSegmentText(header), "\n"))
+		b.WriteString("\n=== Body ===\n")
+	}
+	b.WriteString(body)
+	if _, footer := docsDefaultSegment(style, headers, footers, docsSegmentFooter); footer != nil {
+		if body != "" && !strings.HasSuffix(body, "\n") {
+			b.WriteString("\n")
+		}
+		b.WriteString("=== Footer ===\n")
+		b.WriteString(strings.TrimRight(docsSegmentText(footer), "\n"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func headerDocJSON(withHeader bool) map[string]any {
+	doc := map[string]any{
+		"documentId": "doc1",
+		"revisionId": "rev1",
+		"body": map[string]any{"content": []any{
+			map[string]any{"startIndex": 1, "endIndex": 6, "paragraph": map[string]any{"elements": []any{
+				map[string]any{"textRun": map[string]any{"content": "Body\n"}},
+			}}},
+		}},
+		"footers": map[string]any{
+			"f1": map[string]any{"footerId": "f1", "content": []any{
+				map[string]any{"startIndex": 0, "endIndex": 8, "paragraph": map[string]any{"elements": []any{
+					map[string]any{"textRun": map[string]any{"content": "Page "}},
+					map[string]any{"autoText": map[string]any{"type": "PAGE_NUMBER"}},
+					map[string]any{"textRun": map[string]any{"content": "\n"}},
+				}}},
+			}},
+		},
+	}
+	style := map[string]any{"defaultFooterId": "f1"}
+	if withHeader {
+		style["defaultHeaderId"] = "h1"
+		doc["headers"] = map[string]any{
+			"h1": map[string]any{"headerId": "h1", "content": []any{
+				map[string]any{"startIndex": 0, "endIndex": 9, "paragraph": map[string]any{"elements": []any{
+					map[string]any{"textRun": map[string]any{"content": "Old head\n"}},
+				}}},
+			}},
+		}
+	}
+	doc["documentStyle"] = style
+	return doc
+}
+
+func TestDocsHeaderCmd_Set(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	withHeader := true
+	var batches []docs.BatchUpdateDocumentRequest
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && strings.Contains(r.URL.Path, ":batchUpdate") {
+			var req docs.BatchUpdateDocumentRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			batches = append(batches, req)
+			resp := map[string]any{"documentId": "doc1", "writeControl": map[string]any{"requiredRevisionId": "rev2"}}
+			if req.Requests[0].CreateHeader != nil {
+				resp["replies"] = []any{map[string]any{"createHeader": map[string]any{"headerId": "hNew"}}}
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(headerDocJSON(withHeader))
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := newDocsCmdContext(t)
+
+	if err := runKong(t, &DocsHeaderCmd{}, []string{"doc1", "--text", "Confidential"}, ctx, flags); err != nil {
+		t.Fatalf("header set: %v", err)
+	}
+	if len(batches) != 1 {
+		t.Fatalf("expected 1 batch, got %d", len(batches))
+	}
+	reqs := batches[0].Requests
+	if del := reqs[0].DeleteContentRange; del == nil || del.Range.SegmentId != "h1" || del.Range.StartIndex != 0 || del.Range.EndIndex != 8 {
+		t.Fatalf("unexpected delete: %#v", reqs[0])
+	}
+	if ins := reqs[1].InsertText; ins == nil || ins.Location.SegmentId != "h1" || ins.Text != "Confidential" {
+		t.Fatalf("unexpected insert: %#v", reqs[1])
+	}
+
+	// No header yet: create it first, then write into the new segment.
+	withHeader = false
+	batches = nil
+	if err := runKong(t, &DocsHeaderCmd{}, []string{"doc1", "--text", "Draft"}, ctx, flags); err != nil {
+		t.Fatalf("header create: %v", err)
+	}
+	if len(batches) != 2 || batches[0].Requests[0].CreateHeader == nil {
+		t.Fatalf("expected create then insert, got %#v", batches)
+	}
+	if ins := batches[1].Requests[0].InsertText; ins == nil || ins.Location.SegmentId != "hNew" || ins.Location.Index != 0 {
+		t.Fatalf("unexpected insert: %#v", batches[1].Requests[0])
+	}
+	if batches[1].WriteControl == nil || batches[1].WriteControl.RequiredRevisionId != "rev2" {
+		t.Fatalf("expected write control for rev2, got %#v", batches[1].WriteControl)
+	}
+
+	batches = nil
+	if err := runKong(t, &DocsFooterCmd{}, []string{"doc1", "--remove"}, ctx, flags); err != nil {
+		t.Fatalf("footer remove: %v", err)
+	}
+	if len(batches) != 1 || batches[0].Requests[0].DeleteFooter == nil || batches[0].Requests[0].DeleteFooter.FooterId != "f1" {
+		t.Fatalf("unexpected remove: %#v", batches)
+	}
+}
+
+func TestDocsCatCmd_Headers(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(headerDocJSON(true))
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DocsCatCmd{}, []string{"doc1", "--headers"}, newDocsCmdContext(t), flags); err != nil {
+			t.Fatalf("cat: %v", err)
+		}
+	})
+	want := "=== Header ===\nOld head\n=== Body ===\nBody\n=== Footer ===\nPage {page}\n"
+	if out != want {
+		t.Fatalf("unexpected output:\n%q\nwant\n%q", out, want)
+	}
+}
@@ -169,6 +169,40 @@ func docsTargetEndIndex(ctx context.Context, svc *docs.Service, docID, tabID str
 	return docsTabEndIndex(tab), nil
 }
 
+// resolveDocsTabID maps a --tab value (tab ID or title) to a tab ID.
+// Values shaped like tab IDs ("t.xxx") are used as-is to avoid an extra
+// fetch; anything else is looked up by ID, then case-insensitive title.
+func resolveDocsTabID(ctx context.Context, svc *docs.Service, docID, tab string) (string, error) {
+	tab = strings.TrimSpace(tab)
+	if tab == "" || looksLikeDocsTabID(tab) {
+		return tab, nil
+	}
+	doc, err := svc.Documents.Get(docID).
+		IncludeTabsContent(true).
+		Fields("tabs(tabProperties,childTabs(tabProperties,childTabs(tabProperties)))").
+		Context(ctx).
+		Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return "", fmt.Errorf("doc not found or not a Google Doc (id=%s)", docID)
+		}
+		return "", err
+	}
+	if doc == nil {
+		return "", errors.New("doc not found")
+	}
+	found := findTab(flattenTabs(doc.Tabs), tab)
+	if found == nil || found.TabProperties == nil {
+		return "", fmt.Errorf("tab not found: %s", tab)
+	}
+	return found.TabProperties.TabId, nil
+}
+
+func looksLikeDocsTabID(s string) bool {
+	rest, ok := strings.CutPrefix(s, "t.")
+	return ok && rest != "" && !strings.ContainsAny(rest, " \t")
+}
+
 func docsAppendIndex(endIndex int64) int64 {
 	if endIndex > 1 {
 		return endIndex - 1
@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+const docsImagesManifest = "manifest.json"
+
+type DocsImagesCmd struct {
+	Export DocsImagesExportCmd `cmd:"" name:"export" aliases:"download,dl" help:"Download inline and positioned images with a manifest of their positions"`
+}
+
+type DocsImagesExportCmd struct {
+	DocID string `arg:"" name:"docId" help:"Doc ID"`
+	Out   string `name:"out" short:"o" required:"" help:"Directory to write images and manifest.json into"`
+	Tab   string `name:"tab" help:"Only export images from this tab, by title or ID (default: every tab)"`
+}
+
+// docsImage is one image placement in the manifest. Index is the document
+// index of the inline object, or of the anchoring paragraph for positioned
+// objects. Width and height are the displayed size in points.
+type docsImage struct {
+	File        string  `json:"file"`
+	ObjectID    string  `json:"objectId"`
+	Kind        string  `json:"kind"`
+	TabID       string  `json:"tabId,omitempty"`
+	Index       int64   `json:"index"`
+	Heading     string  `json:"heading,omitempty"`
+	InTable     bool    `json:"inTable,omitempty"`
+	Width       float64 `json:"widthPt,omitempty"`
+	Height      float64 `json:"heightPt,omitempty"`
+	Title       string  `json:"title,omitempty"`
+	Description string  `json:"description,omitempty"`
+	SourceURI   string  `json:"sourceUri,omitempty"`
+	MimeType    string  `json:"mimeType,omitempty"`
+	Bytes       int64   `json:"bytes"`
+
+	contentURI string
+}
+
+func (c *DocsImagesExportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	id := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if id == "" {
+		return usage("empty docId")
+	}
+	outDir, err := config.ExpandPath(strings.TrimSpace(c.Out))
+	if err != nil {
+		return err
+	}
+	if outDir == "" {
+		return usage("empty --out")
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	doc, err := svc.Documents.Get(id).IncludeTabsContent(true).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return fmt.Errorf("doc not found or not a Google Doc (id=%s)", id)
+		}
+		return err
+	}
+	if doc == nil {
+		return errors.New("doc not found")
+	}
+
+	var images []docsImage
+	tabs := flattenTabs(doc.Tabs)
+	switch {
+	case strings.TrimSpace(c.Tab) != "":
+		tab := findTab(tabs, c.Tab)
+		if tab == nil {
+			return fmt.Errorf("tab not found: %s", c.Tab)
+		}
+		images = collectDocsTabImages(tab)
+	case len(tabs) == 0:
+		images = collectDocsImages(doc.Body, doc.InlineObjects, doc.PositionedObjects, "")
+	default:
+		for _, tab := range tabs {
+			images = append(images, collectDocsTabImages(tab)...)
+		}
+	}
+
+	if err := os.MkdirAll(outDir, 0o700); err != nil {
+		return err
+	}
+	for i := range images {
+		if err := downloadDocsImage(ctx, outDir, i+1, &images[i]); err != nil {
+			return fmt.Errorf("download image %s: %w", images[i].ObjectID, err)
+		}
+	}
+	if images == nil {
+		images = []docsImage{}
+	}
+
+	manifest := map[string]any{
+		"documentId": id,
+		"title":      doc.Title,
+		"images":     images,
+	}
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	manifestPath := filepath.Join(outDir, docsImagesManifest)
+	if err := os.WriteFile(manifestPath, append(raw, '\n'), 0o600); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		manifest["manifest"] = manifestPath
+		return outfmt.WriteJSON(ctx, os.Stdout, manifest)
+	}
+	if len(images) == 0 {
+		u.Err().Println("No images")
+		u.Out().Printf("manifest\t%s", manifestPath)
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	fmt.Fprintln(w, "FILE\tKIND\tTAB\tINDEX\tHEADING")
+	for _, img := range images {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", img.File, img.Kind, img.TabID, img.Index, oneLineTSV(img.Heading))
+	}
+	flush()
+	u.Err().Printf("Wrote %d image%s and %s to %s", len(images), pluralS(len(images)), docsImagesManifest, outDir)
+	return nil
+}
+
+func collectDocsTabImages(tab *docs.Tab) []docsImage {
+	if tab == nil || tab.DocumentTab == nil {
+		return nil
+	}
+	tabID := ""
+	if tab.TabProperties != nil {
+		tabID = tab.TabProperties.TabId
+	}
+	dt := tab.DocumentTab
+	return collectDocsImages(dt.Body, dt.InlineObjects, dt.PositionedObjects, tabID)
+}
+
+// collectDocsImages lists image placements in body order, labelling each
+// with the nearest preceding top-level heading.
+func collectDocsImages(body *docs.Body, inline map[string]docs.InlineObject, positioned map[string]docs.PositionedObject, tabID string) []docsImage {
+	if body == nil {
+		return nil
+	}
+	var images []docsImage
+	heading := ""
+	add := func(objectID, kind string, index int64, inTable bool, obj *docs.EmbeddedObject) {
+		if obj == nil || obj.ImageProperties == nil || obj.ImageProperties.ContentUri == "" {
+			return
+		}
+		img := docsImage{
+			ObjectID:    objectID,
+			Kind:        kind,
+			TabID:       tabID,
+			Index:       index,
+			Heading:     heading,
+			InTable:     inTable,
+			Title:       obj.Title,
+			Description: obj.Description,
+			SourceURI:   obj.ImageProperties.SourceUri,
+			contentURI:  obj.ImageProperties.ContentUri,
+		}
+		if obj.Size != nil {
+			img.Width, img.Height = docsDimensionPt(obj.Size.Width), docsDimensionPt(obj.Size.Height)
+		}
+		images = append(images, img)
+	}
+
+	var walk func(content []*docs.StructuralElement, inTable bool)
+	walk = func(content []*docs.StructuralElement, inTable bool) {
+		for _, el := range content {
+			if el == nil {
+				continue
+			}
+			if el.Table != nil {
+				for _, row := range el.Table.TableRows {
+					for _, cell := range row.TableCells {
+						walk(cell.Content, true)
+					}
+				}
+				continue
+			}
+			if el.Paragraph == nil {
+				continue
+			}
+			if !inTable && el.Paragraph.ParagraphStyle != nil {
+				if _, ok := docsHeadingLevel(el.Paragraph.ParagraphStyle.NamedStyleType); ok {
+					heading = strings.TrimSpace(docsParagraphText(el.Paragraph.Elements))
+				}
+			}
+			for _, objID := range el.Paragraph.PositionedObjectIds {
+				if obj, ok := positioned[objID]; ok && obj.PositionedObjectProperties != nil {
+					add(objID, "positioned", el.StartIndex, inTable, obj.PositionedObjectProperties.EmbeddedObject)
+				}
+			}
+			for _, pe := range el.Paragraph.Elements {
+				if pe == nil || pe.InlineObjectElement == nil {
+					continue
+				}
+				objID := pe.InlineObjectElement.InlineObjectId
+				if obj, ok := inline[objID]; ok && obj.InlineObjectProperties != nil {
+					add(objID, "inline", pe.StartIndex, inTable, obj.InlineObjectProperties.EmbeddedObject)
+				}
+			}
+		}
+	}
+	walk(body.Content, false)
+	return images
+}
+
+func docsDimensionPt(d *docs.Dimension) float64 {
+	if d == nil {
+		return 0
+	}
+	return d.Magnitude
+}
+
+// downloadDocsImage saves img as image-NNN.<ext>. Content URIs are
+// short-lived and already carry the requester's access, so no auth header is
+// sent.
+func downloadDocsImage(ctx context.Context, dir string, n int, img *docsImage) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, img.contentURI, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req) //nolint:gosec // content URI comes from the Docs API
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	img.MimeType = strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+	img.File = fmt.Sprintf("image-%03d%s", n, docsImageExtension(img.MimeType))
+	f, err := os.OpenFile(filepath.Join(dir, img.File), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) //nolint:gosec // directory chosen by the user
+	if err != nil {
+		return err
+	}
+	written, copyErr := io.Copy(f, resp.Body)
+	closeErr := f.Close()
+	if copyErr != nil {
+		return copyErr
+	}
+	img.Bytes = written
+	return closeErr
+}
+
+func docsImageExtension(mimeType string) string {
+	switch strings.ToLower(mimeType) {
+	case mimePNG:
+		return extPNG
+	case "image/jpeg", "image/jpg":
+		return ".jpg"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	case "image/svg+xml":
+		return ".svg"
+	case "image/bmp":
+		return ".bmp"
+	default:
+		return ".img"
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestDocsImagesExportCmd(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	images := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte("png-bytes"))
+		case "/b":
+			w.Header().Set("Content-Type", "image/jpeg; charset=binary")
+			_, _ = w.Write([]byte("jpg"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(images.Close)
+
+	embedded := func(uri string) map[string]any {
+		return map[string]any{
+			"title":           "Diagram",
+			"size":            map[string]any{"width": map[string]any{"magnitude": 200, "unit": "PT"}, "height": map[string]any{"magnitude": 100, "unit": "PT"}},
+			"imageProperties": map[string]any{"contentUri": uri, "sourceUri": "https://example.com/src.png"},
+		}
+	}
+	heading := docsTestParagraph(1, "Setup\n", "HEADING_1")
+	para := map[string]any{
+		"startIndex": 7,
+		"endIndex":   10,
+		"paragraph": map[string]any{
+			"positionedObjectIds": []any{"kix.pos"},
+			"elements": []any{
+				map[string]any{"startIndex": 7, "endIndex": 8, "textRun": map[string]any{"content": "x"}},
+				map[string]any{"startIndex": 8, "endIndex": 9, "inlineObjectElement": map[string]any{"inlineObjectId": "kix.inl"}},
+				map[string]any{"startIndex": 9, "endIndex": 10, "textRun": map[string]any{"content": "\n"}},
+			},
+		},
+	}
+	svc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"documentId": "doc1",
+			"title":      "Guide",
+			"tabs": []any{map[string]any{
+				"tabProperties": map[string]any{"tabId": "t.0", "title": "Tab 1"},
+				"documentTab": map[string]any{
+					"body":              map[string]any{"content": []any{heading, para}},
+					"inlineObjects":     map[string]any{"kix.inl": map[string]any{"inlineObjectProperties": map[string]any{"embeddedObject": embedded(images.URL + "/a")}}},
+					"positionedObjects": map[string]any{"kix.pos": map[string]any{"positionedObjectProperties": map[string]any{"embeddedObject": embedded(images.URL + "/b")}}},
+				},
+			}},
+		})
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return svc, nil }
+
+	dir := filepath.Join(t.TempDir(), "assets")
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DocsImagesExportCmd{}, []string{"doc1", "-o", dir}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("export: %v", err)
+		}
+	})
+	var got struct {
+		Images []docsImage `json:"images"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if len(got.Images) != 2 {
+		t.Fatalf("expected 2 images, got %#v", got.Images)
+	}
+	pos, inl := got.Images[0], got.Images[1]
+	if pos.Kind != "positioned" || pos.File != "image-001.jpg" || pos.Index != 7 || pos.Heading != "Setup" || pos.TabID != "t.0" {
+		t.Fatalf("unexpected positioned image: %#v", pos)
+	}
+	if inl.Kind != "inline" || inl.File != "image-002.png" || inl.Index != 8 || inl.Width != 200 || inl.Bytes != 9 {
+		t.Fatalf("unexpected inline image: %#v", inl)
+	}
+	if b, err := os.ReadFile(filepath.Join(dir, "image-002.png")); err != nil || string(b) != "png-bytes" {
+		t.Fatalf("unexpected image file %q: %v", b, err)
+	}
+	manifest, err := os.ReadFile(filepath.Join(dir, docsImagesManifest))
+	if err != nil || !strings.Contains(string(manifest), `"objectId": "kix.inl"`) {
+		t.Fatalf("unexpected manifest %q: %v", manifest, err)
+	}
+}
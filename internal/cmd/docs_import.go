@@ -15,6 +15,8 @@ import (
 
 	"google.golang.org/api/docs/v1"
 	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/pkg/docsmd"
 )
 
 // markdownImage holds a parsed image reference from a markdown file.
@@ -206,8 +208,8 @@ func searchParagraph(para *docs.Paragraph, placeholders []string, result map[str
 				break
 			}
 		}
-		absStart := baseAbs + utf16Len(full[baseByteOff:pos])
-		absEnd := absStart + utf16Len(ph)
+		absStart := baseAbs + docsmd.UTF16Len(full[baseByteOff:pos])
+		absEnd := absStart + docsmd.UTF16Len(ph)
 		result[ph] = docRange{
 			startIndex: absStart,
 			endIndex:   absEnd,
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/pkg/docsmd"
 )
 
 // testImages creates []markdownImage with the fixed "test" token for use in tests.
@@ -847,7 +849,7 @@ func TestFindPlaceholderIndices_NonASCIIPrefix(t *testing.T) {
 	if dr.startIndex != wantStart {
 		t.Fatalf("startIndex = %d, want %d (UTF-16 offset, not byte offset)", dr.startIndex, wantStart)
 	}
-	wantEnd := wantStart + utf16Len("<<IMG_test_0>>")
+	wantEnd := wantStart + docsmd.UTF16Len("<<IMG_test_0>>")
 	if dr.endIndex != wantEnd {
 		t.Fatalf("endIndex = %d, want %d", dr.endIndex, wantEnd)
 	}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func headingDocJSON(withTrailer bool) map[string]any {
+	para := func(start, end int64, style, text string) map[string]any {
+		return map[string]any{
+			"startIndex": start,
+			"endIndex":   end,
+			"paragraph": map[string]any{
+				"paragraphStyle": map[string]any{"namedStyleType": style},
+				"elements": []any{map[string]any{
+					"startIndex": start,
+					"endIndex":   end,
+					"textRun":    map[string]any{"content": text},
+				}},
+			},
+		}
+	}
+	content := []any{
+		map[string]any{"endIndex": 1, "sectionBreak": map[string]any{}},
+		para(1, 7, "NORMAL_TEXT", "Intro\n"),
+		para(7, 21, "HEADING_2", "Release Notes\n"),
+	}
+	if withTrailer {
+		content = append(content, para(21, 30, "NORMAL_TEXT", "Old note\n"))
+	}
+	return map[string]any{
+		"documentId": "doc1",
+		"revisionId": "rev1",
+		"body":       map[string]any{"content": content},
+	}
+}
+
+func TestDocsInsertCmd_AfterHeading(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	withTrailer := true
+	var batches []docs.BatchUpdateDocumentRequest
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && strings.Contains(r.URL.Path, ":batchUpdate") {
+			var req docs.BatchUpdateDocumentRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			batches = append(batches, req)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"documentId":   "doc1",
+				"writeControl": map[string]any{"requiredRevisionId": "rev2"},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(headingDocJSON(withTrailer))
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := newDocsCmdContext(t)
+
+	if err := runKong(t, &DocsInsertCmd{}, []string{"doc1", "New note", "--after-heading", "release notes"}, ctx, flags); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if len(batches) != 1 {
+		t.Fatalf("expected 1 batch, got %d", len(batches))
+	}
+	ins := batches[0].Requests[0].InsertText
+	if ins == nil || ins.Location.Index != 21 || ins.Text != "New note\n" {
+		t.Fatalf("unexpected insert: %#v", ins)
+	}
+	if batches[0].WriteControl == nil || batches[0].WriteControl.RequiredRevisionId != "rev1" {
+		t.Fatalf("expected write control for rev1, got %#v", batches[0].WriteControl)
+	}
+
+	// Heading at the end of the body: a normal paragraph is opened first.
+	withTrailer = false
+	batches = nil
+	if err := runKong(t, &DocsInsertCmd{}, []string{"doc1", "Tail", "--after-heading", "Release Notes"}, ctx, flags); err != nil {
+		t.Fatalf("insert at end: %v", err)
+	}
+	if len(batches) != 2 {
+		t.Fatalf("expected 2 batches, got %d", len(batches))
+	}
+	if got := batches[0].Requests[0].InsertText; got == nil || got.Location.Index != 20 || got.Text != "\n" {
+		t.Fatalf("unexpected paragraph split: %#v", got)
+	}
+	if got := batches[0].Requests[1].UpdateParagraphStyle; got == nil || got.ParagraphStyle.NamedStyleType != "NORMAL_TEXT" {
+		t.Fatalf("unexpected paragraph style: %#v", got)
+	}
+	if got := batches[1].Requests[0].InsertText; got == nil || got.Location.Index != 21 || got.Text != "Tail\n" {
+		t.Fatalf("unexpected insert: %#v", got)
+	}
+	if batches[1].WriteControl == nil || batches[1].WriteControl.RequiredRevisionId != "rev2" {
+		t.Fatalf("expected write control for rev2, got %#v", batches[1].WriteControl)
+	}
+
+	err := runKong(t, &DocsInsertCmd{}, []string{"doc1", "x", "--after-heading", "Missing"}, ctx, flags)
+	if err == nil || !strings.Contains(err.Error(), "heading not found") {
+		t.Fatalf("expected heading not found, got %v", err)
+	}
+
+	err = runKong(t, &DocsInsertCmd{}, []string{"doc1", "x", "--after-heading", "Intro", "--index", "3"}, ctx, flags)
+	if err == nil || !strings.Contains(err.Error(), "not both") {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
+func TestDocsInsertCmd_MarkdownAtIndex(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	var batches []docs.BatchUpdateDocumentRequest
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		var req docs.BatchUpdateDocumentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		batches = append(batches, req)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"documentId": "doc1"})
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	if err := runKong(t, &DocsInsertCmd{}, []string{"doc1", "## Title\n", "--index", "5", "--format", "markdown"}, newDocsCmdContext(t), flags); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	reqs := batches[0].Requests
+	if reqs[0].DeleteContentRange != nil || reqs[0].InsertText == nil || reqs[0].InsertText.Location.Index != 5 {
+		t.Fatalf("expected a plain insertion at 5, got %#v", reqs[0])
+	}
+	var heading bool
+	for _, req := range reqs {
+		if req.UpdateParagraphStyle != nil && req.UpdateParagraphStyle.ParagraphStyle.NamedStyleType == "HEADING_2" {
+			heading = true
+		}
+	}
+	if !heading {
+		t.Fatalf("expected a HEADING_2 style request")
+	}
+}
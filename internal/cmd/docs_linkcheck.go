@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/docs/v1"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type DocsLinkcheckCmd struct {
+	DocID       string        `arg:"" name:"docId" help:"Doc ID"`
+	Tab         string        `name:"tab" help:"Only check links in this tab, by title or ID (default: every tab)"`
+	Concurrency int           `name:"concurrency" aliases:"jobs" help:"Links to check at once" default:"8"`
+	Timeout     time.Duration `name:"timeout" help:"Per-link request timeout" default:"10s"`
+	All         bool          `name:"all" help:"List every link, not only broken ones"`
+}
+
+// docsLink is one hyperlink in a document. Adjacent runs with the same
+// target are merged, so Text is the whole anchor text.
+type docsLink struct {
+	URL        string `json:"url,omitempty"`
+	HeadingID  string `json:"headingId,omitempty"`
+	Text       string `json:"text"`
+	TabID      string `json:"tabId,omitempty"`
+	Heading    string `json:"heading,omitempty"`
+	StartIndex int64  `json:"startIndex"`
+	EndIndex   int64  `json:"endIndex"`
+	Kind       string `json:"kind"`
+	Status     string `json:"status"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Broken     bool   `json:"broken"`
+}
+
+type docsLinkResult struct {
+	kind   string
+	status string
+	code   int
+	broken bool
+}
+
+func (c *DocsLinkcheckCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	id := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if id == "" {
+		return usage("empty docId")
+	}
+	if c.Concurrency <= 0 {
+		return usage("--concurrency must be > 0")
+	}
+	if c.Timeout <= 0 {
+		return usage("--timeout must be > 0")
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	doc, err := svc.Documents.Get(id).IncludeTabsContent(true).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return fmt.Errorf("doc not found or not a Google Doc (id=%s)", id)
+		}
+		return err
+	}
+	if doc == nil {
+		return errors.New("doc not found")
+	}
+
+	tabs := flattenTabs(doc.Tabs)
+	headingIDs := make(map[string]bool)
+	var links []docsLink
+	switch {
+	case len(tabs) == 0:
+		links = collectDocsLinks(doc.Body, "", headingIDs)
+	default:
+		var only *docs.Tab
+		if strings.TrimSpace(c.Tab) != "" {
+			if only = findTab(tabs, c.Tab); only == nil {
+				return fmt.Errorf("tab not found: %s", c.Tab)
+			}
+		}
+		// Walk every tab so links into other tabs' headings resolve.
+		for _, tab := range tabs {
+			if tab.DocumentTab == nil {
+				continue
+			}
+			tabID := ""
+			if tab.TabProperties != nil {
+				tabID = tab.TabProperties.TabId
+			}
+			tabLinks := collectDocsLinks(tab.DocumentTab.Body, tabID, headingIDs)
+			if only == nil || tab == only {
+				links = append(links, tabLinks...)
+			}
+		}
+	}
+
+	checker := &docsLinkChecker{
+		client:     &http.Client{Timeout: c.Timeout},
+		headingIDs: headingIDs,
+		driveSvc: func() (*drive.Service, error) {
+			_, driveSvc, driveErr := requireDriveService(ctx, flags)
+			return driveSvc, driveErr
+		},
+	}
+	checker.checkAll(ctx, links, c.Concurrency)
+
+	broken := 0
+	shown := make([]docsLink, 0, len(links))
+	for _, l := range links {
+		if l.Broken {
+			broken++
+		}
+		if l.Broken || c.All {
+			shown = append(shown, l)
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId": id,
+			"checked":    len(links),
+			"broken":     broken,
+			"links":      shown,
+		})
+	}
+	if len(shown) > 0 {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "STATUS\tTARGET\tTEXT\tHEADING\tINDEX")
+		for _, l := range shown {
+			target := l.URL
+			if target == "" {
+				target = "#" + l.HeadingID
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", l.Status, target, oneLineTSV(l.Text), oneLineTSV(l.Heading), l.StartIndex)
+		}
+		flush()
+	}
+	u.Err().Printf("Checked %d link%s, %d broken", len(links), pluralS(len(links)), broken)
+	return nil
+}
+
+// collectDocsLinks lists hyperlinks in body order with their nearest
+// preceding top-level heading, and records heading IDs found along the way.
+func collectDocsLinks(body *docs.Body, tabID string, headingIDs map[string]bool) []docsLink {
+	if body == nil {
+		return nil
+	}
+	var links []docsLink
+	heading := ""
+	var walk func(content []*docs.StructuralElement, inTable bool)
+	walk = func(content []*docs.StructuralElement, inTable bool) {
+		for _, el := range content {
+			if el == nil {
+				continue
+			}
+			if el.Table != nil {
+				for _, row := range el.Table.TableRows {
+					for _, cell := range row.TableCells {
+						walk(cell.Content, true)
+					}
+				}
+				continue
+			}
+			if el.Paragraph == nil {
+				continue
+			}
+			if style := el.Paragraph.ParagraphStyle; style != nil {
+				if style.HeadingId != "" {
+					headingIDs[style.HeadingId] = true
+				}
+				if _, ok := docsHeadingLevel(style.NamedStyleType); ok && !inTable {
+					heading = strings.TrimSpace(docsParagraphText(el.Paragraph.Elements))
+				}
+			}
+			var last *docsLink
+			for _, pe := range el.Paragraph.Elements {
+				if pe == nil || pe.TextRun == nil || pe.TextRun.TextStyle == nil || pe.TextRun.TextStyle.Link == nil {
+					last = nil
+					continue
+				}
+				link := pe.TextRun.TextStyle.Link
+				target := docsLink{URL: link.Url, HeadingID: link.HeadingId}
+				if target.HeadingID == "" && link.Heading != nil {
+					target.HeadingID = link.Heading.Id
+				}
+				if target.URL == "" && target.HeadingID == "" {
+					last = nil
+					continue
+				}
+				if last != nil && last.URL == target.URL && last.HeadingID == target.HeadingID && last.EndIndex == pe.StartIndex {
+					last.Text += pe.TextRun.Content
+					last.EndIndex = pe.EndIndex
+					continue
+				}
+				target.Text = pe.TextRun.Content
+				target.TabID, target.Heading = tabID, heading
+				target.StartIndex, target.EndIndex = pe.StartIndex, pe.EndIndex
+				links = append(links, target)
+				last = &links[len(links)-1]
+			}
+		}
+	}
+	walk(body.Content, false)
+	for i := range links {
+		links[i].Text = strings.TrimRight(links[i].Text, "\n")
+	}
+	return links
+}
+
+type docsLinkChecker struct {
+	client     *http.Client
+	headingIDs map[string]bool
+	driveSvc   func() (*drive.Service, error)
+
+	driveOnce sync.Once
+	drive     *drive.Service
+	driveErr  error
+}
+
+// checkAll fills in the status of each link, checking each distinct target
+// once with at most concurrency requests in flight.
+func (lc *docsLinkChecker) checkAll(ctx context.Context, links []docsLink, concurrency int) {
+	targets := make(map[string]*docsLinkResult)
+	var order []string
+	for _, l := range links {
+		key := docsLinkKey(l)
+		if _, ok := targets[key]; !ok {
+			targets[key] = &docsLinkResult{}
+			order = append(order, key)
+		}
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, key := range order {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(key string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			*targets[key] = lc.check(ctx, key)
+		}(key)
+	}
+	wg.Wait()
+
+	for i := range links {
+		r := targets[docsLinkKey(links[i])]
+		links[i].Kind, links[i].Status, links[i].StatusCode, links[i].Broken = r.kind, r.status, r.code, r.broken
+	}
+}
+
+func docsLinkKey(l docsLink) string {
+	if l.URL != "" {
+		return l.URL
+	}
+	return "#" + l.HeadingID
+}
+
+func (lc *docsLinkChecker) check(ctx context.Context, target string) docsLinkResult {
+	if headingID, ok := strings.CutPrefix(target, "#"); ok {
+		if lc.headingIDs[headingID] {
+			return docsLinkResult{kind: "heading", status: "ok"}
+		}
+		return docsLinkResult{kind: "heading", status: "missing heading", broken: true}
+	}
+	lower := strings.ToLower(target)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return docsLinkResult{kind: "other", status: "skipped"}
+	}
+	if u := parseMaybeURL(target); u != nil {
+		host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+		if host == "docs.google.com" || host == "drive.google.com" {
+			if fileID := normalizeGoogleID(target); fileID != target {
+				return lc.checkDrive(ctx, fileID)
+			}
+		}
+	}
+	return lc.checkHTTP(ctx, target)
+}
+
+func (lc *docsLinkChecker) checkDrive(ctx context.Context, fileID string) docsLinkResult {
+	lc.driveOnce.Do(func() { lc.drive, lc.driveErr = lc.driveSvc() })
+	if lc.driveErr != nil {
+		return docsLinkResult{kind: "drive", status: "error: " + lc.driveErr.Error()}
+	}
+	f, err := lc.drive.Files.Get(fileID).SupportsAllDrives(true).Fields("id", "trashed").Context(ctx).Do()
+	switch {
+	case isNotFoundAPIError(err):
+		return docsLinkResult{kind: "drive", status: "not found", code: http.StatusNotFound, broken: true}
+	case err != nil:
+		return docsLinkResult{kind: "drive", status: "error: " + err.Error(), broken: true}
+	case f.Trashed:
+		return docsLinkResult{kind: "drive", status: "trashed", broken: true}
+	}
+	return docsLinkResult{kind: "drive", status: "ok"}
+}
+
+// checkHTTP sends HEAD, falling back to GET for servers that reject HEAD.
+func (lc *docsLinkChecker) checkHTTP(ctx context.Context, target string) docsLinkResult {
+	code, err := lc.status(ctx, http.MethodHead, target)
+	if err == nil && (code == http.StatusMethodNotAllowed || code == http.StatusNotImplemented || code == http.StatusForbidden) {
+		code, err = lc.status(ctx, http.MethodGet, target)
+	}
+	if err != nil {
+		return docsLinkResult{kind: "http", status: "error: " + err.Error(), broken: true}
+	}
+	return docsLinkResult{kind: "http", status: fmt.Sprintf("%d", code), code: code, broken: code >= 400}
+}
+
+func (lc *docsLinkChecker) status(ctx context.Context, method, target string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "gogcli-linkcheck")
+	resp, err := lc.client.Do(req) //nolint:gosec // links come from the user's document
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
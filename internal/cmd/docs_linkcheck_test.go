@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/pkg/docsmd"
+)
+
+func TestDocsLinkcheckCmd(t *testing.T) {
+	origDocs, origDrive := newDocsService, newDriveService
+	t.Cleanup(func() { newDocsService, newDriveService = origDocs, origDrive })
+
+	web := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+		case "/nohead":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(web.Close)
+
+	driveSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/files/alive") {
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "alive"})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 404, "message": "File not found"}})
+	}))
+	t.Cleanup(driveSrv.Close)
+	driveSvc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(driveSrv.Client()),
+		option.WithEndpoint(driveSrv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return driveSvc, nil }
+
+	run := func(start int64, text string, link map[string]any) map[string]any {
+		return map[string]any{
+			"startIndex": start,
+			"endIndex":   start + docsmd.UTF16Len(text),
+			"textRun":    map[string]any{"content": text, "textStyle": map[string]any{"link": link}},
+		}
+	}
+	heading := docsTestParagraph(1, "Sources\n", "HEADING_1")
+	heading["paragraph"].(map[string]any)["paragraphStyle"].(map[string]any)["headingId"] = "h.src"
+	body := map[string]any{
+		"startIndex": 9,
+		"paragraph": map[string]any{"elements": []any{
+			run(9, "good ", map[string]any{"url": web.URL + "/ok"}),
+			run(14, "link", map[string]any{"url": web.URL + "/ok"}),
+			run(18, "gone", map[string]any{"url": web.URL + "/gone"}),
+			run(22, "nohead", map[string]any{"url": web.URL + "/nohead"}),
+			run(28, "doc", map[string]any{"url": "https://docs.google.com/document/d/dead/edit"}),
+			run(31, "file", map[string]any{"url": "https://drive.google.com/file/d/alive/view"}),
+			run(35, "up", map[string]any{"headingId": "h.src"}),
+			run(37, "lost", map[string]any{"headingId": "h.nope"}),
+			run(41, "mail\n", map[string]any{"url": "mailto:a@b.com"}),
+		}},
+	}
+	svc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"documentId": "doc1",
+			"body":       map[string]any{"content": []any{heading, body}},
+		})
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DocsLinkcheckCmd{}, []string{"doc1", "--all"}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("linkcheck: %v", err)
+		}
+	})
+	var got struct {
+		Checked int        `json:"checked"`
+		Broken  int        `json:"broken"`
+		Links   []docsLink `json:"links"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if got.Checked != 8 || got.Broken != 3 {
+		t.Fatalf("unexpected counts: checked=%d broken=%d links=%#v", got.Checked, got.Broken, got.Links)
+	}
+	byText := make(map[string]docsLink)
+	for _, l := range got.Links {
+		byText[l.Text] = l
+	}
+	checks := []struct {
+		text   string
+		status string
+		broken bool
+	}{
+		{"good link", "200", false},
+		{"gone", "404", true},
+		{"nohead", "200", false},
+		{"doc", "not found", true},
+		{"file", "ok", false},
+		{"up", "ok", false},
+		{"lost", "missing heading", true},
+		{"mail", "skipped", false},
+	}
+	for _, want := range checks {
+		l, ok := byText[want.text]
+		if !ok || l.Status != want.status || l.Broken != want.broken {
+			t.Errorf("link %q: got %#v, want status %q broken=%v", want.text, l, want.status, want.broken)
+		}
+	}
+	if byText["gone"].Heading != "Sources" {
+		t.Errorf("expected containing heading, got %#v", byText["gone"])
+	}
+}
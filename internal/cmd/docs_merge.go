@@ -0,0 +1,369 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/docsmd"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// DocsMergeCmd appends the bodies of one or more source docs to the end of a
+// target doc, replaying paragraph styles, text styles, lists, and tables.
+type DocsMergeCmd struct {
+	TargetID  string   `arg:"" name:"targetDocId" help:"Doc to append to"`
+	SourceIDs []string `arg:"" name:"sourceDocId" help:"Docs to append, in order"`
+}
+
+// docsMergeSource summarizes what was copied from one source doc.
+type docsMergeSource struct {
+	DocumentID string `json:"documentId"`
+	Title      string `json:"title,omitempty"`
+	Paragraphs int    `json:"paragraphs"`
+	Tables     int    `json:"tables"`
+	// Skipped counts elements that cannot be recreated from their API
+	// representation: images, tables of contents, footnotes, smart chips.
+	Skipped int `json:"skipped"`
+}
+
+// docsMergeBulletGroup is a run of consecutive paragraphs from one source
+// list, recreated with a single createParagraphBullets so numbering carries
+// across items.
+type docsMergeBulletGroup struct {
+	key        string
+	preset     string
+	start, end int64
+}
+
+// docsMergeBuilder accumulates the text, styles, and tables for a merge
+// starting at a fixed document index.
+type docsMergeBuilder struct {
+	text    strings.Builder
+	offset  int64
+	styles  []*docs.Request
+	bullets []docsMergeBulletGroup
+	tables  []docsmd.TableData
+	// nestingTabs counts the leading tabs createParagraphBullets strips to
+	// set list nesting; table placeholders after them move back by as much.
+	nestingTabs int64
+	endsInBreak bool
+}
+
+func (c *DocsMergeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	targetID := normalizeGoogleID(strings.TrimSpace(c.TargetID))
+	if targetID == "" {
+		return usage("empty targetDocId")
+	}
+	sourceIDs := make([]string, 0, len(c.SourceIDs))
+	for _, id := range c.SourceIDs {
+		if id = normalizeGoogleID(strings.TrimSpace(id)); id != "" {
+			sourceIDs = append(sourceIDs, id)
+		}
+	}
+	if len(sourceIDs) == 0 {
+		return usage("at least one sourceDocId is required")
+	}
+
+	if err := dryRunExit(ctx, flags, "docs.merge", map[string]any{
+		"target_id":  targetID,
+		"source_ids": sourceIDs,
+	}); err != nil {
+		return err
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+
+	sources := make([]*docs.Document, 0, len(sourceIDs))
+	for _, id := range sourceIDs {
+		doc, err := getDocsMergeDocument(ctx, svc, id)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, doc)
+	}
+	target, err := getDocsMergeDocument(ctx, svc, targetID)
+	if err != nil {
+		return err
+	}
+
+	insertAt, needsBreak := docsMergeInsertionPoint(target)
+	b := &docsMergeBuilder{offset: insertAt}
+	if needsBreak {
+		b.write("\n")
+	}
+	contentStart := b.offset
+	summary := make([]docsMergeSource, 0, len(sources))
+	for _, src := range sources {
+		summary = append(summary, b.addDocument(src))
+	}
+	text, requests := b.requests(contentStart)
+	if text == "" {
+		return errors.New("source docs have no content to merge")
+	}
+
+	if needsChunkedDocsInsert(text, len(requests)) {
+		err = applyChunkedDocsInsert(ctx, svc, targetID, target.RevisionId, insertAt, insertAt, text, requests)
+	} else {
+		_, err = svc.Documents.BatchUpdate(targetID, docsBatchAtRevision(target.RevisionId,
+			docsMarkdownReplaceRequests(insertAt, insertAt, text, requests))).Context(ctx).Do()
+	}
+	if err != nil {
+		return fmt.Errorf("merge: %w", err)
+	}
+
+	tableInserter := NewTableInserter(svc, targetID)
+	tableInserter.plainHeader = true
+	if err := insertDocsNativeTables(ctx, tableInserter, b.tables); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId": targetID,
+			"insertedAt": insertAt,
+			"sources":    summary,
+		})
+	}
+	for _, s := range summary {
+		line := fmt.Sprintf("Merged %s (%d paragraphs, %d tables)", s.DocumentID, s.Paragraphs, s.Tables)
+		if s.Skipped > 0 {
+			line += fmt.Sprintf("; skipped %d unsupported element%s", s.Skipped, pluralS(s.Skipped))
+		}
+		u.Out().Println(line)
+	}
+	u.Out().Printf("link\t%s", docsWebViewLink(targetID))
+	return nil
+}
+
+func getDocsMergeDocument(ctx context.Context, svc *docs.Service, id string) (*docs.Document, error) {
+	doc, err := svc.Documents.Get(id).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return nil, fmt.Errorf("doc not found or not a Google Doc (id=%s)", id)
+		}
+		return nil, err
+	}
+	if doc == nil || doc.Body == nil {
+		return nil, fmt.Errorf("doc not found (id=%s)", id)
+	}
+	return doc, nil
+}
+
+// docsMergeInsertionPoint returns the index just before the body's final
+// newline and whether a paragraph break is needed first, which is the case
+// unless the last paragraph is empty.
+func docsMergeInsertionPoint(doc *docs.Document) (int64, bool) {
+	content := doc.Body.Content
+	if len(content) == 0 {
+		return 1, false
+	}
+	last := content[len(content)-1]
+	index := max(last.EndIndex-1, 1)
+	if last.Paragraph == nil {
+		return index, true
+	}
+	for _, pe := range last.Paragraph.Elements {
+		if pe == nil {
+			continue
+		}
+		if pe.TextRun == nil || strings.TrimRight(pe.TextRun.Content, "\n") != "" {
+			return index, true
+		}
+	}
+	return index, false
+}
+
+func (b *docsMergeBuilder) write(s string) {
+	if s == "" {
+		return
+	}
+	b.text.WriteString(s)
+	b.offset += docsmd.UTF16Len(s)
+	b.endsInBreak = strings.HasSuffix(s, "\n")
+}
+
+func (b *docsMergeBuilder) addDocument(doc *docs.Document) docsMergeSource {
+	summary := docsMergeSource{DocumentID: doc.DocumentId, Title: doc.Title}
+	for _, el := range doc.Body.Content {
+		switch {
+		case el == nil || el.SectionBreak != nil:
+		case el.Paragraph != nil:
+			summary.Skipped += b.addParagraph(doc, el.Paragraph)
+			summary.Paragraphs++
+		case el.Table != nil:
+			b.addTable(el.Table)
+			summary.Tables++
+		default:
+			summary.Skipped++
+		}
+	}
+	return summary
+}
+
+// addParagraph copies the paragraph's text runs and reports how many other
+// elements it had to drop.
+func (b *docsMergeBuilder) addParagraph(doc *docs.Document, p *docs.Paragraph) int {
+	start := b.offset
+	if p.Bullet != nil && p.Bullet.NestingLevel > 0 {
+		b.write(strings.Repeat("\t", int(p.Bullet.NestingLevel)))
+		b.nestingTabs += p.Bullet.NestingLevel
+	}
+
+	skipped := 0
+	for _, pe := range p.Elements {
+		if pe == nil {
+			continue
+		}
+		if pe.TextRun == nil {
+			skipped++
+			continue
+		}
+		if pe.TextRun.Content == "" {
+			continue
+		}
+		runStart := b.offset
+		b.write(pe.TextRun.Content)
+		b.styles = append(b.styles, &docs.Request{UpdateTextStyle: &docs.UpdateTextStyleRequest{
+			Range:     &docs.Range{StartIndex: runStart, EndIndex: b.offset},
+			TextStyle: docsMergeTextStyle(pe.TextRun.TextStyle),
+			// "*" resets anything the source run does not set, so the
+			// copy does not inherit the style at the insertion point.
+			Fields: "*",
+		}})
+	}
+	if !b.endsInBreak || b.offset == start {
+		b.write("\n")
+	}
+
+	style, fields := docsMergeParagraphStyle(p.ParagraphStyle)
+	b.styles = append(b.styles, &docs.Request{UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
+		Range:          &docs.Range{StartIndex: start, EndIndex: b.offset},
+		ParagraphStyle: style,
+		Fields:         fields,
+	}})
+
+	if p.Bullet != nil {
+		key := doc.DocumentId + "/" + p.Bullet.ListId
+		if n := len(b.bullets); n > 0 && b.bullets[n-1].key == key && b.bullets[n-1].end == start {
+			b.bullets[n-1].end = b.offset
+		} else {
+			b.bullets = append(b.bullets, docsMergeBulletGroup{
+				key:    key,
+				preset: inferBulletPreset(doc, p.Bullet.ListId),
+				start:  start,
+				end:    b.offset,
+			})
+		}
+	}
+	return skipped
+}
+
+// addTable leaves a placeholder paragraph that insertDocsNativeTables later
+// fills. Cell text is copied without its styling.
+func (b *docsMergeBuilder) addTable(t *docs.Table) {
+	cells := make([][]string, 0, len(t.TableRows))
+	for _, row := range t.TableRows {
+		if row == nil {
+			continue
+		}
+		r := make([]string, 0, len(row.TableCells))
+		for _, cell := range row.TableCells {
+			r = append(r, docsMergeCellText(cell))
+		}
+		cells = append(cells, r)
+	}
+	b.tables = append(b.tables, docsmd.TableData{StartIndex: b.offset - b.nestingTabs, Cells: cells})
+	b.write("\n")
+}
+
+// requests returns the text to insert and the requests that style it. The
+// final newline is dropped because the target's own closing newline ends
+// the last merged paragraph. Bullets go last, in reverse document order, so
+// the nesting tabs each one strips do not shift ranges still to be applied.
+func (b *docsMergeBuilder) requests(contentStart int64) (string, []*docs.Request) {
+	text := strings.TrimSuffix(b.text.String(), "\n")
+	if b.offset <= contentStart {
+		return "", nil
+	}
+	requests := make([]*docs.Request, 0, 1+len(b.styles)+len(b.bullets))
+	requests = append(requests, &docs.Request{DeleteParagraphBullets: &docs.DeleteParagraphBulletsRequest{
+		Range: &docs.Range{StartIndex: contentStart, EndIndex: b.offset},
+	}})
+	for _, r := range b.styles {
+		// Text styles may not cover the body's closing newline.
+		if r.UpdateTextStyle != nil && r.UpdateTextStyle.Range.EndIndex == b.offset {
+			if r.UpdateTextStyle.Range.EndIndex--; r.UpdateTextStyle.Range.EndIndex <= r.UpdateTextStyle.Range.StartIndex {
+				continue
+			}
+		}
+		requests = append(requests, r)
+	}
+	for i := len(b.bullets) - 1; i >= 0; i-- {
+		g := b.bullets[i]
+		requests = append(requests, &docs.Request{CreateParagraphBullets: &docs.CreateParagraphBulletsRequest{
+			Range:        &docs.Range{StartIndex: g.start, EndIndex: g.end},
+			BulletPreset: g.preset,
+		}})
+	}
+	return text, requests
+}
+
+// docsMergeTextStyle copies a run's style, dropping links into the source
+// doc's headings and bookmarks, which do not exist in the target.
+func docsMergeTextStyle(style *docs.TextStyle) *docs.TextStyle {
+	if style == nil {
+		return &docs.TextStyle{}
+	}
+	out := *style
+	if out.Link != nil && out.Link.Url == "" {
+		out.Link = nil
+	}
+	return &out
+}
+
+// docsMergeParagraphStyle copies the writable layout fields of a paragraph
+// style. Read-only fields such as headingId are left out.
+func docsMergeParagraphStyle(style *docs.ParagraphStyle) (*docs.ParagraphStyle, string) {
+	const fields = "namedStyleType,alignment,direction,lineSpacing,spaceAbove,spaceBelow,indentStart,indentEnd,indentFirstLine,keepLinesTogether,keepWithNext"
+	out := &docs.ParagraphStyle{NamedStyleType: "NORMAL_TEXT"}
+	if style == nil {
+		return out, fields
+	}
+	if style.NamedStyleType != "" {
+		out.NamedStyleType = style.NamedStyleType
+	}
+	out.Alignment = style.Alignment
+	out.Direction = style.Direction
+	out.LineSpacing = style.LineSpacing
+	out.SpaceAbove = style.SpaceAbove
+	out.SpaceBelow = style.SpaceBelow
+	out.IndentStart = style.IndentStart
+	out.IndentEnd = style.IndentEnd
+	out.IndentFirstLine = style.IndentFirstLine
+	out.KeepLinesTogether = style.KeepLinesTogether
+	out.KeepWithNext = style.KeepWithNext
+	return out, fields
+}
+
+func docsMergeCellText(cell *docs.TableCell) string {
+	if cell == nil {
+		return ""
+	}
+	parts := make([]string, 0, len(cell.Content))
+	for _, el := range cell.Content {
+		if el != nil && el.Paragraph != nil {
+			parts = append(parts, docsParagraphText(el.Paragraph.Elements))
+		}
+	}
+	return strings.TrimRight(strings.Join(parts, "\n"), "\n")
+}
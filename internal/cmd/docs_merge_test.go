@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func docsMergeTestSource() *docs.Document {
+	para := func(style string, bullet *docs.Bullet, runs ...*docs.TextRun) *docs.StructuralElement {
+		p := &docs.Paragraph{ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: style, HeadingId: "h.1"}, Bullet: bullet}
+		for _, r := range runs {
+			p.Elements = append(p.Elements, &docs.ParagraphElement{TextRun: r})
+		}
+		return &docs.StructuralElement{Paragraph: p}
+	}
+	return &docs.Document{
+		DocumentId: "src",
+		Title:      "Source",
+		Lists: map[string]docs.List{
+			"l1": {ListProperties: &docs.ListProperties{NestingLevels: []*docs.NestingLevel{{GlyphType: "DECIMAL"}}}},
+		},
+		Body: &docs.Body{Content: []*docs.StructuralElement{
+			{SectionBreak: &docs.SectionBreak{}},
+			para("HEADING_1", nil, &docs.TextRun{Content: "Title\n"}),
+			para("NORMAL_TEXT", nil,
+				&docs.TextRun{Content: "bold", TextStyle: &docs.TextStyle{Bold: true}},
+				&docs.TextRun{Content: " link\n", TextStyle: &docs.TextStyle{Link: &docs.Link{HeadingId: "h.1"}}},
+			),
+			para("NORMAL_TEXT", &docs.Bullet{ListId: "l1"}, &docs.TextRun{Content: "one\n"}),
+			para("NORMAL_TEXT", &docs.Bullet{ListId: "l1", NestingLevel: 1}, &docs.TextRun{Content: "nested\n"}),
+			{Table: &docs.Table{TableRows: []*docs.TableRow{{TableCells: []*docs.TableCell{
+				{Content: []*docs.StructuralElement{para("NORMAL_TEXT", nil, &docs.TextRun{Content: "a\n"})}},
+				{Content: []*docs.StructuralElement{para("NORMAL_TEXT", nil, &docs.TextRun{Content: "b\n"})}},
+			}}}}},
+			para("NORMAL_TEXT", nil, &docs.TextRun{Content: "end\n"}),
+		}},
+	}
+}
+
+func TestDocsMergeBuilder(t *testing.T) {
+	b := &docsMergeBuilder{offset: 10}
+	b.write("\n")
+	summary := b.addDocument(docsMergeTestSource())
+	text, requests := b.requests(11)
+
+	if summary.Paragraphs != 5 || summary.Tables != 1 || summary.Skipped != 0 {
+		t.Fatalf("unexpected summary: %#v", summary)
+	}
+	if text != "\nTitle\nbold link\none\n\tnested\n\nend" {
+		t.Fatalf("unexpected text: %q", text)
+	}
+	// The table placeholder sits at 39 before the nesting tab is stripped.
+	if len(b.tables) != 1 || b.tables[0].StartIndex != 38 || b.tables[0].Cells[0][1] != "b" {
+		t.Fatalf("unexpected tables: %#v", b.tables)
+	}
+
+	if requests[0].DeleteParagraphBullets == nil {
+		t.Fatalf("expected inherited bullets to be cleared first, got %#v", requests[0])
+	}
+	var bullets []*docs.CreateParagraphBulletsRequest
+	var heading *docs.UpdateParagraphStyleRequest
+	var last *docs.UpdateTextStyleRequest
+	for _, r := range requests {
+		switch {
+		case r.CreateParagraphBullets != nil:
+			bullets = append(bullets, r.CreateParagraphBullets)
+		case r.UpdateParagraphStyle != nil && heading == nil:
+			heading = r.UpdateParagraphStyle
+		case r.UpdateTextStyle != nil:
+			last = r.UpdateTextStyle
+			if r.UpdateTextStyle.Range.StartIndex == 21 && r.UpdateTextStyle.TextStyle.Link != nil {
+				t.Fatalf("internal link should be dropped: %#v", r.UpdateTextStyle.TextStyle)
+			}
+		}
+	}
+	if heading == nil || heading.ParagraphStyle.NamedStyleType != "HEADING_1" || heading.ParagraphStyle.HeadingId != "" || heading.Range.StartIndex != 11 || heading.Range.EndIndex != 17 {
+		t.Fatalf("unexpected heading style: %#v", heading)
+	}
+	if len(bullets) != 1 || bullets[0].BulletPreset != "NUMBERED_DECIMAL_NESTED" || bullets[0].Range.StartIndex != 27 || bullets[0].Range.EndIndex != 39 {
+		t.Fatalf("unexpected bullets: %#v", bullets)
+	}
+	if last == nil || last.Range.EndIndex != 43 {
+		t.Fatalf("last text style should stop before the closing newline: %#v", last)
+	}
+}
+
+func TestDocsMergeCmd(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	source := docsMergeTestSource()
+	source.Body.Content = source.Body.Content[:5]
+	var got docs.BatchUpdateDocumentRequest
+	svc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/documents/src"):
+			_ = json.NewEncoder(w).Encode(source)
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/documents/tgt"):
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"documentId": "tgt",
+				"revisionId": "rev1",
+				"body":       map[string]any{"content": []any{docsTestParagraph(1, "Hello\n", "NORMAL_TEXT")}},
+			})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/documents/tgt:batchUpdate"):
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Errorf("decode: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"documentId": "tgt"})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return svc, nil }
+
+	ctx, out := newDocsCmdOutputContext(t)
+	if err := runKong(t, &DocsMergeCmd{}, []string{"tgt", "src"}, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if got.WriteControl == nil || got.WriteControl.RequiredRevisionId != "rev1" {
+		t.Fatalf("expected batch pinned to the target revision: %#v", got.WriteControl)
+	}
+	insert := got.Requests[0].InsertText
+	if insert == nil || insert.Location.Index != 6 || insert.Text != "\nTitle\nbold link\none\n\tnested" {
+		t.Fatalf("unexpected insert: %#v", got.Requests[0])
+	}
+	if !strings.Contains(out.String(), "Merged src (4 paragraphs, 0 tables)") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
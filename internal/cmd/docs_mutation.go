@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/pkg/docsmd"
 )
 
 const (
@@ -104,47 +106,21 @@ func replaceDocsTextRange(ctx context.Context, svc *docs.Service, doc *docs.Docu
 }
 
 func replaceDocsMarkdownRange(ctx context.Context, svc *docs.Service, account string, doc *docs.Document, startIdx, endIdx int64, replaceText, basePath string) error {
-	cleaned, images := extractMarkdownImages(replaceText)
-	elements := ParseMarkdown(cleaned)
-	formattingRequests, textToInsert, tables := MarkdownToDocsRequests(elements, startIdx)
+	withNotes, notes := extractMarkdownFootnotes(replaceText)
+	cleaned, images := extractMarkdownImages(withNotes)
+	elements := docsmd.ParseMarkdown(cleaned)
+	formattingRequests, textToInsert, tables := docsmd.MarkdownToDocsRequests(elements, startIdx)
 
-	requests := make([]*docs.Request, 0, 2+len(formattingRequests))
-	requests = append(requests,
-		&docs.Request{
-			DeleteContentRange: &docs.DeleteContentRangeRequest{
-				Range: &docs.Range{StartIndex: startIdx, EndIndex: endIdx},
-			},
-		},
-		&docs.Request{
-			InsertText: &docs.InsertTextRequest{
-				Location: &docs.Location{Index: startIdx},
-				Text:     textToInsert,
-			},
-		},
-	)
-	requests = append(requests, formattingRequests...)
-
-	_, err := svc.Documents.BatchUpdate(doc.DocumentId, &docs.BatchUpdateDocumentRequest{
-		WriteControl: &docs.WriteControl{RequiredRevisionId: doc.RevisionId},
-		Requests:     requests,
-	}).Context(ctx).Do()
-	if err != nil {
-		return fmt.Errorf("replace (markdown): %w", err)
+	if needsChunkedDocsInsert(textToInsert, len(formattingRequests)) {
+		if err := applyChunkedDocsInsert(ctx, svc, doc.DocumentId, doc.RevisionId, startIdx, endIdx, textToInsert, formattingRequests); err != nil {
+			return fmt.Errorf("replace (markdown): %w", err)
+		}
+	} else if err := replaceDocsMarkdownSingleBatch(ctx, svc, doc, startIdx, endIdx, textToInsert, formattingRequests); err != nil {
+		return err
 	}
 
-	if len(tables) > 0 {
-		tableInserter := NewTableInserter(svc, doc.DocumentId)
-		tableOffset := int64(0)
-		for _, table := range tables {
-			tableIndex := table.StartIndex + tableOffset
-			tableEnd, tableErr := tableInserter.InsertNativeTable(ctx, tableIndex, table.Cells)
-			if tableErr != nil {
-				return fmt.Errorf("insert native table: %w", tableErr)
-			}
-			if tableEnd > tableIndex {
-				tableOffset += (tableEnd - tableIndex) - 1
-			}
-		}
+	if err := insertDocsNativeTables(ctx, NewTableInserter(svc, doc.DocumentId), tables); err != nil {
+		return err
 	}
 
 	if len(images) > 0 {
@@ -155,16 +131,130 @@ func replaceDocsMarkdownRange(ctx context.Context, svc *docs.Service, account st
 		}
 	}
 
+	if len(notes) > 0 {
+		noteErr := insertFootnotesIntoDocs(ctx, svc, doc.DocumentId, notes)
+		placeholders := make([]string, 0, len(notes))
+		for _, note := range notes {
+			placeholders = append(placeholders, note.placeholder())
+		}
+		cleanupDocsPlaceholders(ctx, svc, doc.DocumentId, placeholders)
+		if noteErr != nil {
+			return fmt.Errorf("insert footnotes: %w", noteErr)
+		}
+	}
+
+	return nil
+}
+
+// insertDocsNativeTables fills each placeholder paragraph with a native
+// table, shifting later placeholders by the size of the tables before them.
+func insertDocsNativeTables(ctx context.Context, tableInserter *TableInserter, tables []docsmd.TableData) error {
+	tableOffset := int64(0)
+	for _, table := range tables {
+		tableIndex := table.StartIndex + tableOffset
+		tableEnd, err := tableInserter.InsertNativeTable(ctx, tableIndex, table.Cells)
+		if err != nil {
+			return fmt.Errorf("insert native table: %w", err)
+		}
+		if tableEnd > tableIndex {
+			tableOffset += (tableEnd - tableIndex) - 1
+		}
+	}
+	return nil
+}
+
+// docsMarkdownSegment is a parsed markdown element as shown by --dry-run.
+type docsMarkdownSegment struct {
+	Type    string     `json:"type"`
+	Content string     `json:"content,omitempty"`
+	Cells   [][]string `json:"cells,omitempty"`
+}
+
+// docsMarkdownPlan describes what replaceDocsMarkdownRange would send for
+// replaceText at [startIdx, endIdx), without calling the API.
+func docsMarkdownPlan(replaceText string, startIdx, endIdx int64) map[string]any {
+	withNotes, notes := extractMarkdownFootnotes(replaceText)
+	cleaned, images := extractMarkdownImages(withNotes)
+	elements := docsmd.ParseMarkdown(cleaned)
+	formattingRequests, textToInsert, tables := docsmd.MarkdownToDocsRequests(elements, startIdx)
+
+	segments := make([]docsMarkdownSegment, 0, len(elements))
+	for _, el := range elements {
+		segments = append(segments, docsMarkdownSegment{Type: el.Type.String(), Content: el.Content, Cells: el.TableCells})
+	}
+	tableStarts := make([]int64, 0, len(tables))
+	for _, table := range tables {
+		tableStarts = append(tableStarts, table.StartIndex)
+	}
+	return map[string]any{
+		"segments":      segments,
+		"requests":      docsMarkdownReplaceRequests(startIdx, endIdx, textToInsert, formattingRequests),
+		"chunked":       needsChunkedDocsInsert(textToInsert, len(formattingRequests)),
+		"tables":        tableStarts,
+		"imageCount":    len(images),
+		"footnoteCount": len(notes),
+		"insertUnits":   docsmd.UTF16Len(textToInsert),
+	}
+}
+
+// replaceDocsMarkdownSingleBatch deletes the range, inserts the text, and
+// styles it in one atomic batchUpdate.
+func replaceDocsMarkdownSingleBatch(ctx context.Context, svc *docs.Service, doc *docs.Document, startIdx, endIdx int64, textToInsert string, formattingRequests []*docs.Request) error {
+	requests := docsMarkdownReplaceRequests(startIdx, endIdx, textToInsert, formattingRequests)
+	_, err := svc.Documents.BatchUpdate(doc.DocumentId, docsBatchAtRevision(doc.RevisionId, requests)).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("replace (markdown): %w", err)
+	}
 	return nil
 }
 
+// docsMarkdownReplaceRequests deletes [startIdx, endIdx), inserts the text,
+// and styles it.
+func docsMarkdownReplaceRequests(startIdx, endIdx int64, textToInsert string, formattingRequests []*docs.Request) []*docs.Request {
+	requests := make([]*docs.Request, 0, 2+len(formattingRequests))
+	// An empty range is a plain insertion.
+	if endIdx > startIdx {
+		requests = append(requests, &docs.Request{
+			DeleteContentRange: &docs.DeleteContentRangeRequest{
+				Range: &docs.Range{StartIndex: startIdx, EndIndex: endIdx},
+			},
+		})
+	}
+	requests = append(requests, &docs.Request{
+		InsertText: &docs.InsertTextRequest{
+			Location: &docs.Location{Index: startIdx},
+			Text:     textToInsert,
+		},
+	})
+	return append(requests, formattingRequests...)
+}
+
+// docsBatchAtRevision pins a batch update to revisionID when one is known.
+func docsBatchAtRevision(revisionID string, requests []*docs.Request) *docs.BatchUpdateDocumentRequest {
+	req := &docs.BatchUpdateDocumentRequest{Requests: requests}
+	if revisionID != "" {
+		req.WriteControl = &docs.WriteControl{RequiredRevisionId: revisionID}
+	}
+	return req
+}
+
 func cleanupDocsImagePlaceholders(ctx context.Context, svc *docs.Service, docID string, images []markdownImage) {
-	reqs := make([]*docs.Request, 0, len(images))
+	placeholders := make([]string, 0, len(images))
 	for _, img := range images {
+		placeholders = append(placeholders, img.placeholder())
+	}
+	cleanupDocsPlaceholders(ctx, svc, docID, placeholders)
+}
+
+// cleanupDocsPlaceholders best-effort removes placeholder text left behind
+// by a failed or partial image or footnote pass.
+func cleanupDocsPlaceholders(ctx context.Context, svc *docs.Service, docID string, placeholders []string) {
+	reqs := make([]*docs.Request, 0, len(placeholders))
+	for _, ph := range placeholders {
 		reqs = append(reqs, &docs.Request{
 			ReplaceAllText: &docs.ReplaceAllTextRequest{
 				ContainsText: &docs.SubstringMatchCriteria{
-					Text:      img.placeholder(),
+					Text:      ph,
 					MatchCase: true,
 				},
 				ReplaceText: "",
@@ -248,8 +338,8 @@ func findTextInParagraph(para *docs.Paragraph, searchText, find string, matchCas
 			break
 		}
 		absIdx := offset + idx
-		matchStart := paraStart + utf16Len(text[:absIdx])
-		matchEnd := matchStart + utf16Len(searchText)
+		matchStart := paraStart + docsmd.UTF16Len(text[:absIdx])
+		matchEnd := matchStart + docsmd.UTF16Len(searchText)
 		*matches = append(*matches, docRange{startIndex: matchStart, endIndex: matchEnd})
 		offset = absIdx + len(find)
 	}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type DocsOutlineCmd struct {
+	DocID string `arg:"" name:"docId" help:"Doc ID"`
+	Tab   string `name:"tab" help:"Only this tab, by title or ID (default: every tab)"`
+}
+
+// docsOutlineEntry is one heading. StartIndex/EndIndex cover the heading
+// paragraph; SectionEnd is where the next heading of the same or a higher
+// level starts (or the end of the tab body), so [EndIndex, SectionEnd) is the
+// section's content.
+type docsOutlineEntry struct {
+	Level      int    `json:"level"`
+	Style      string `json:"style"`
+	Text       string `json:"text"`
+	StartIndex int64  `json:"startIndex"`
+	EndIndex   int64  `json:"endIndex"`
+	SectionEnd int64  `json:"sectionEnd"`
+	HeadingID  string `json:"headingId,omitempty"`
+	TabID      string `json:"tabId,omitempty"`
+	TabTitle   string `json:"tabTitle,omitempty"`
+}
+
+func (c *DocsOutlineCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	id := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if id == "" {
+		return usage("empty docId")
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	doc, err := svc.Documents.Get(id).IncludeTabsContent(true).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return fmt.Errorf("doc not found or not a Google Doc (id=%s)", id)
+		}
+		return err
+	}
+	if doc == nil {
+		return errors.New("doc not found")
+	}
+
+	var entries []docsOutlineEntry
+	tabs := flattenTabs(doc.Tabs)
+	switch {
+	case strings.TrimSpace(c.Tab) != "":
+		tab := findTab(tabs, c.Tab)
+		if tab == nil {
+			return fmt.Errorf("tab not found: %s", c.Tab)
+		}
+		entries = docsTabOutline(tab)
+	case len(tabs) == 0:
+		entries = buildDocsOutline(doc.Body, "", "")
+	default:
+		for _, tab := range tabs {
+			entries = append(entries, docsTabOutline(tab)...)
+		}
+	}
+	if entries == nil {
+		entries = []docsOutlineEntry{}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId": id,
+			"title":      doc.Title,
+			"headings":   entries,
+		})
+	}
+
+	if len(entries) == 0 {
+		u.Err().Println("No headings")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "START\tEND\tSECTION_END\tTAB\tHEADING")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%d\t%d\t%d\t%s\t%s%s\n",
+			e.StartIndex, e.EndIndex, e.SectionEnd, e.TabID, strings.Repeat("  ", max(e.Level-1, 0)), e.Text)
+	}
+	return nil
+}
+
+func docsTabOutline(tab *docs.Tab) []docsOutlineEntry {
+	if tab == nil || tab.DocumentTab == nil {
+		return nil
+	}
+	var tabID, title string
+	if tab.TabProperties != nil {
+		tabID, title = tab.TabProperties.TabId, tab.TabProperties.Title
+	}
+	return buildDocsOutline(tab.DocumentTab.Body, tabID, title)
+}
+
+// buildDocsOutline lists the body's top-level heading paragraphs in order.
+// Headings nested in tables are not part of the outline.
+func buildDocsOutline(body *docs.Body, tabID, tabTitle string) []docsOutlineEntry {
+	if body == nil {
+		return nil
+	}
+	var entries []docsOutlineEntry
+	bodyEnd := int64(1)
+	for _, el := range body.Content {
+		if el == nil {
+			continue
+		}
+		bodyEnd = max(bodyEnd, el.EndIndex)
+		if el.Paragraph == nil || el.Paragraph.ParagraphStyle == nil {
+			continue
+		}
+		style := el.Paragraph.ParagraphStyle
+		level, ok := docsHeadingLevel(style.NamedStyleType)
+		if !ok {
+			continue
+		}
+		entries = append(entries, docsOutlineEntry{
+			Level:      level,
+			Style:      style.NamedStyleType,
+			Text:       strings.TrimSpace(docsParagraphText(el.Paragraph.Elements)),
+			StartIndex: el.StartIndex,
+			EndIndex:   el.EndIndex,
+			HeadingID:  style.HeadingId,
+			TabID:      tabID,
+			TabTitle:   tabTitle,
+		})
+	}
+
+	for i := range entries {
+		entries[i].SectionEnd = bodyEnd
+		for _, next := range entries[i+1:] {
+			if next.Level <= entries[i].Level {
+				entries[i].SectionEnd = next.StartIndex
+				break
+			}
+		}
+	}
+	return entries
+}
+
+// docsHeadingLevel maps a named style to an outline level: TITLE and
+// SUBTITLE are 0, HEADING_n is n.
+func docsHeadingLevel(style string) (int, bool) {
+	switch style {
+	case "TITLE", "SUBTITLE":
+		return 0, true
+	}
+	n, ok := strings.CutPrefix(style, "HEADING_")
+	if !ok {
+		return 0, false
+	}
+	level, err := strconv.Atoi(n)
+	if err != nil {
+		return 0, false
+	}
+	return level, true
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func outlineParagraph(start, end int64, style, text string) map[string]any {
+	return map[string]any{
+		"startIndex": start,
+		"endIndex":   end,
+		"paragraph": map[string]any{
+			"paragraphStyle": map[string]any{"namedStyleType": style},
+			"elements":       []any{map[string]any{"textRun": map[string]any{"content": text + "\n"}}},
+		},
+	}
+}
+
+func TestDocsOutlineCmd_JSON(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"documentId": "doc1",
+			"title":      "Plan",
+			"tabs": []any{
+				map[string]any{
+					"tabProperties": map[string]any{"tabId": "t.0", "title": "Main"},
+					"documentTab": map[string]any{"body": map[string]any{"content": []any{
+						outlineParagraph(1, 8, "HEADING_1", "Intro"),
+						outlineParagraph(8, 20, "NORMAL_TEXT", "Some text"),
+						outlineParagraph(20, 28, "HEADING_2", "Scope"),
+						outlineParagraph(28, 40, "NORMAL_TEXT", "More text"),
+						outlineParagraph(40, 48, "HEADING_1", "Risks"),
+						outlineParagraph(48, 60, "NORMAL_TEXT", "Last words"),
+					}}},
+				},
+				map[string]any{
+					"tabProperties": map[string]any{"tabId": "t.notes", "title": "Notes"},
+					"documentTab": map[string]any{"body": map[string]any{"content": []any{
+						outlineParagraph(1, 7, "TITLE", "Notes"),
+					}}},
+				},
+			},
+		})
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DocsOutlineCmd{}, []string{"doc1"}, ctx, flags); err != nil {
+			t.Fatalf("outline: %v", err)
+		}
+	})
+
+	var got struct {
+		Headings []docsOutlineEntry `json:"headings"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	want := []docsOutlineEntry{
+		{Level: 1, Style: "HEADING_1", Text: "Intro", StartIndex: 1, EndIndex: 8, SectionEnd: 40, TabID: "t.0", TabTitle: "Main"},
+		{Level: 2, Style: "HEADING_2", Text: "Scope", StartIndex: 20, EndIndex: 28, SectionEnd: 40, TabID: "t.0", TabTitle: "Main"},
+		{Level: 1, Style: "HEADING_1", Text: "Risks", StartIndex: 40, EndIndex: 48, SectionEnd: 60, TabID: "t.0", TabTitle: "Main"},
+		{Level: 0, Style: "TITLE", Text: "Notes", StartIndex: 1, EndIndex: 7, SectionEnd: 7, TabID: "t.notes", TabTitle: "Notes"},
+	}
+	if len(got.Headings) != len(want) {
+		t.Fatalf("unexpected headings: %#v", got.Headings)
+	}
+	for i := range want {
+		if got.Headings[i] != want[i] {
+			t.Fatalf("heading %d: got %#v, want %#v", i, got.Headings[i], want[i])
+		}
+	}
+
+	out = captureStdout(t, func() {
+		if err := runKong(t, &DocsOutlineCmd{}, []string{"doc1", "--tab", "notes"}, ctx, flags); err != nil {
+			t.Fatalf("outline tab: %v", err)
+		}
+	})
+	if err := json.Unmarshal([]byte(out), &got); err != nil || len(got.Headings) != 1 || got.Headings[0].TabID != "t.notes" {
+		t.Fatalf("unexpected tab outline: %v %#v", err, got.Headings)
+	}
+}
@@ -0,0 +1,290 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/selectorutil"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type DocsRangesCmd struct {
+	List   DocsRangesListCmd   `cmd:"" default:"withargs" aliases:"ls" help:"List named ranges"`
+	Create DocsRangesCreateCmd `cmd:"" name:"create" aliases:"add,new" help:"Create a named range over a span of the document"`
+	Delete DocsRangesDeleteCmd `cmd:"" name:"delete" aliases:"rm,remove,del" help:"Delete a named range"`
+}
+
+type docsRangeSpan struct {
+	StartIndex int64  `json:"startIndex"`
+	EndIndex   int64  `json:"endIndex"`
+	TabID      string `json:"tabId,omitempty"`
+}
+
+type docsNamedRangeItem struct {
+	Name         string          `json:"name"`
+	NamedRangeID string          `json:"namedRangeId"`
+	Ranges       []docsRangeSpan `json:"ranges"`
+}
+
+type DocsRangesListCmd struct {
+	DocID string `arg:"" name:"docId" help:"Doc ID"`
+}
+
+func (c *DocsRangesListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := strings.TrimSpace(c.DocID)
+	if docID == "" {
+		return usage("empty docId")
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	ranges, err := fetchDocsNamedRanges(ctx, svc, docID)
+	if err != nil {
+		return err
+	}
+
+	items := make([]docsNamedRangeItem, 0, len(ranges))
+	for _, nr := range ranges {
+		items = append(items, docsNamedRangeToItem(nr))
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId":  docID,
+			"namedRanges": items,
+		})
+	}
+
+	if len(items) == 0 {
+		u.Err().Println("No named ranges")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "NAME\tID\tSTART\tEND\tTAB")
+	for _, it := range items {
+		for _, span := range it.Ranges {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\n", it.Name, it.NamedRangeID, span.StartIndex, span.EndIndex, span.TabID)
+		}
+	}
+	return nil
+}
+
+type DocsRangesCreateCmd struct {
+	DocID string `arg:"" name:"docId" help:"Doc ID"`
+	Name  string `arg:"" name:"name" help:"Named range name (need not be unique)"`
+	Start int64  `name:"start" required:"" help:"Start index (inclusive, >= 1)"`
+	End   int64  `name:"end" required:"" help:"End index (exclusive)"`
+	TabID string `name:"tab-id" aliases:"tab" help:"Target a specific tab by ID or title (see docs tabs list)"`
+}
+
+func (c *DocsRangesCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := strings.TrimSpace(c.DocID)
+	name := strings.TrimSpace(c.Name)
+	if docID == "" {
+		return usage("empty docId")
+	}
+	if name == "" {
+		return usage("empty name")
+	}
+	if c.Start < 1 {
+		return usage("--start must be >= 1")
+	}
+	if c.End <= c.Start {
+		return usage("--end must be greater than --start")
+	}
+
+	if err := dryRunExit(ctx, flags, "docs.ranges.create", map[string]any{
+		"documentId": docID,
+		"name":       name,
+		"startIndex": c.Start,
+		"endIndex":   c.End,
+		"tabId":      c.TabID,
+	}); err != nil {
+		return err
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	if c.TabID, err = resolveDocsTabID(ctx, svc, docID, c.TabID); err != nil {
+		return err
+	}
+
+	resp, err := svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{
+		Requests: []*docs.Request{{
+			CreateNamedRange: &docs.CreateNamedRangeRequest{
+				Name:  name,
+				Range: &docs.Range{StartIndex: c.Start, EndIndex: c.End, TabId: c.TabID},
+			},
+		}},
+	}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("creating named range: %w", err)
+	}
+
+	var rangeID string
+	if len(resp.Replies) > 0 && resp.Replies[0] != nil && resp.Replies[0].CreateNamedRange != nil {
+		rangeID = resp.Replies[0].CreateNamedRange.NamedRangeId
+	}
+
+	return writeResult(ctx, u,
+		kv("documentId", docID),
+		kv("name", name),
+		kv("namedRangeId", rangeID),
+		kv("startIndex", c.Start),
+		kv("endIndex", c.End),
+	)
+}
+
+type DocsRangesDeleteCmd struct {
+	DocID    string `arg:"" name:"docId" help:"Doc ID"`
+	NameOrID string `arg:"" name:"nameOrId" help:"Named range name or ID"`
+}
+
+func (c *DocsRangesDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := strings.TrimSpace(c.DocID)
+	in := strings.TrimSpace(c.NameOrID)
+	if docID == "" {
+		return usage("empty docId")
+	}
+	if in == "" {
+		return usage("empty nameOrId")
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	nr, err := resolveDocsNamedRange(ctx, svc, docID, in)
+	if err != nil {
+		return err
+	}
+
+	if err := dryRunExit(ctx, flags, "docs.ranges.delete", map[string]any{
+		"documentId":   docID,
+		"name":         nr.Name,
+		"namedRangeId": nr.NamedRangeId,
+	}); err != nil {
+		return err
+	}
+
+	if _, err := svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{
+		Requests: []*docs.Request{{
+			DeleteNamedRange: &docs.DeleteNamedRangeRequest{NamedRangeId: nr.NamedRangeId},
+		}},
+	}).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("deleting named range: %w", err)
+	}
+
+	return writeResult(ctx, u,
+		kv("documentId", docID),
+		kv("deleted", true),
+		kv("name", nr.Name),
+		kv("namedRangeId", nr.NamedRangeId),
+	)
+}
+
+// fetchDocsNamedRanges returns the named ranges of every tab, ordered by name
+// then ID.
+func fetchDocsNamedRanges(ctx context.Context, svc *docs.Service, docID string) ([]*docs.NamedRange, error) {
+	doc, err := svc.Documents.Get(docID).
+		IncludeTabsContent(true).
+		Fields("namedRanges,tabs(tabProperties,documentTab/namedRanges,childTabs(tabProperties,documentTab/namedRanges,childTabs(tabProperties,documentTab/namedRanges)))").
+		Context(ctx).
+		Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return nil, fmt.Errorf("doc not found or not a Google Doc (id=%s)", docID)
+		}
+		return nil, err
+	}
+	if doc == nil {
+		return nil, errors.New("doc not found")
+	}
+
+	var out []*docs.NamedRange
+	collect := func(groups map[string]docs.NamedRanges) {
+		for _, group := range groups {
+			for _, nr := range group.NamedRanges {
+				if nr != nil {
+					out = append(out, nr)
+				}
+			}
+		}
+	}
+	tabs := flattenTabs(doc.Tabs)
+	if len(tabs) == 0 {
+		collect(doc.NamedRanges)
+	}
+	for _, tab := range tabs {
+		if tab != nil && tab.DocumentTab != nil {
+			collect(tab.DocumentTab.NamedRanges)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name == out[j].Name {
+			return out[i].NamedRangeId < out[j].NamedRangeId
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+// resolveDocsNamedRange finds a named range by ID, then by case-insensitive
+// name. Names are not unique in Docs, so a name shared by several ranges is
+// rejected in favour of an explicit ID.
+func resolveDocsNamedRange(ctx context.Context, svc *docs.Service, docID, nameOrID string) (*docs.NamedRange, error) {
+	ranges, err := fetchDocsNamedRanges(ctx, svc, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]selectorutil.Match, 0, len(ranges))
+	for _, nr := range ranges {
+		options = append(options, selectorutil.Match{ID: nr.NamedRangeId, Name: nr.Name})
+	}
+	match, found, ambiguous := selectorutil.FindByIDOrCaseFoldName(nameOrID, options)
+	if !found {
+		if len(ambiguous) > 0 {
+			ids := make([]string, 0, len(ambiguous))
+			for _, m := range ambiguous {
+				ids = append(ids, m.ID)
+			}
+			return nil, usagef("ambiguous named range %q; use one of the IDs: %s", nameOrID, strings.Join(ids, ", "))
+		}
+		return nil, usagef("unknown named range %q (see docs ranges list)", nameOrID)
+	}
+	for _, nr := range ranges {
+		if nr.NamedRangeId == match.ID {
+			return nr, nil
+		}
+	}
+	return nil, fmt.Errorf("named range match disappeared (id=%q)", match.ID)
+}
+
+func docsNamedRangeToItem(nr *docs.NamedRange) docsNamedRangeItem {
+	it := docsNamedRangeItem{Name: nr.Name, NamedRangeID: nr.NamedRangeId, Ranges: []docsRangeSpan{}}
+	for _, r := range nr.Ranges {
+		if r == nil {
+			continue
+		}
+		it.Ranges = append(it.Ranges, docsRangeSpan{StartIndex: r.StartIndex, EndIndex: r.EndIndex, TabID: r.TabId})
+	}
+	return it
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func namedRangesDocJSON() map[string]any {
+	return map[string]any{
+		"documentId": "doc1",
+		"tabs": []any{
+			map[string]any{
+				"tabProperties": map[string]any{"tabId": "t.0", "title": "Main"},
+				"documentTab": map[string]any{"namedRanges": map[string]any{
+					"summary": map[string]any{"name": "summary", "namedRanges": []any{
+						map[string]any{"namedRangeId": "kix.sum", "name": "summary", "ranges": []any{
+							map[string]any{"startIndex": 5, "endIndex": 20, "tabId": "t.0"},
+						}},
+					}},
+					"dup": map[string]any{"name": "dup", "namedRanges": []any{
+						map[string]any{"namedRangeId": "kix.d1", "name": "dup", "ranges": []any{map[string]any{"startIndex": 1, "endIndex": 2}}},
+						map[string]any{"namedRangeId": "kix.d2", "name": "dup", "ranges": []any{map[string]any{"startIndex": 3, "endIndex": 4}}},
+					}},
+				}},
+			},
+			map[string]any{
+				"tabProperties": map[string]any{"tabId": "t.notes", "title": "Notes"},
+				"documentTab": map[string]any{"namedRanges": map[string]any{
+					"footnote": map[string]any{"name": "footnote", "namedRanges": []any{
+						map[string]any{"namedRangeId": "kix.fn", "name": "footnote", "ranges": []any{
+							map[string]any{"startIndex": 2, "endIndex": 9, "tabId": "t.notes"},
+						}},
+					}},
+				}},
+			},
+		},
+	}
+}
+
+func newNamedRangesTestService(t *testing.T, batches *[]docs.BatchUpdateDocumentRequest) {
+	t.Helper()
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && strings.Contains(r.URL.Path, ":batchUpdate") {
+			var req docs.BatchUpdateDocumentRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			*batches = append(*batches, req)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"documentId": "doc1",
+				"replies":    []any{map[string]any{"createNamedRange": map[string]any{"namedRangeId": "kix.new"}}},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(namedRangesDocJSON())
+	})
+	t.Cleanup(cleanup)
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+}
+
+func TestDocsRangesListCreateDelete(t *testing.T) {
+	var batches []docs.BatchUpdateDocumentRequest
+	newNamedRangesTestService(t, &batches)
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := newDocsCmdContext(t)
+
+	jsonCtx := outfmt.WithMode(ctx, outfmt.Mode{JSON: true})
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DocsRangesCmd{}, []string{"list", "doc1"}, jsonCtx, flags); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	var listed struct {
+		NamedRanges []docsNamedRangeItem `json:"namedRanges"`
+	}
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if len(listed.NamedRanges) != 4 || listed.NamedRanges[0].NamedRangeID != "kix.d1" || listed.NamedRanges[2].Name != "footnote" {
+		t.Fatalf("unexpected ranges: %#v", listed.NamedRanges)
+	}
+	if span := listed.NamedRanges[2].Ranges[0]; span.TabID != "t.notes" || span.StartIndex != 2 || span.EndIndex != 9 {
+		t.Fatalf("unexpected span: %#v", span)
+	}
+
+	if err := runKong(t, &DocsRangesCmd{}, []string{"create", "doc1", "intro", "--start", "3", "--end", "10", "--tab", "t.notes"}, ctx, flags); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	create := batches[0].Requests[0].CreateNamedRange
+	if create == nil || create.Name != "intro" || create.Range.StartIndex != 3 || create.Range.EndIndex != 10 || create.Range.TabId != "t.notes" {
+		t.Fatalf("unexpected create: %#v", batches[0].Requests[0])
+	}
+
+	err := runKong(t, &DocsRangesCmd{}, []string{"delete", "doc1", "dup"}, ctx, flags)
+	if err == nil || !strings.Contains(err.Error(), "kix.d1, kix.d2") {
+		t.Fatalf("expected ambiguity error, got %v", err)
+	}
+	if err := runKong(t, &DocsRangesCmd{}, []string{"delete", "doc1", "kix.d2"}, ctx, flags); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if del := batches[1].Requests[0].DeleteNamedRange; del == nil || del.NamedRangeId != "kix.d2" {
+		t.Fatalf("unexpected delete: %#v", batches[1].Requests[0])
+	}
+}
+
+func TestDocsInsertReplace_NamedRange(t *testing.T) {
+	var batches []docs.BatchUpdateDocumentRequest
+	newNamedRangesTestService(t, &batches)
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := newDocsCmdContext(t)
+
+	if err := runKong(t, &DocsInsertCmd{}, []string{"doc1", "Note: ", "--range", "Footnote"}, ctx, flags); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if loc := batches[0].Requests[0].InsertText.Location; loc.Index != 2 || loc.TabId != "t.notes" {
+		t.Fatalf("unexpected insert location: %#v", loc)
+	}
+
+	if err := runKong(t, &DocsInsertCmd{}, []string{"doc1", "!", "--range", "summary", "--range-end"}, ctx, flags); err != nil {
+		t.Fatalf("insert at end: %v", err)
+	}
+	if loc := batches[1].Requests[0].InsertText.Location; loc.Index != 20 || loc.TabId != "t.0" {
+		t.Fatalf("unexpected insert location: %#v", loc)
+	}
+
+	if err := runKong(t, &DocsReplaceCmd{}, []string{"doc1", "--range", "summary", "--replace", "New summary"}, ctx, flags); err != nil {
+		t.Fatalf("replace: %v", err)
+	}
+	repl := batches[2].Requests[0].ReplaceNamedRangeContent
+	if repl == nil || repl.NamedRangeId != "kix.sum" || repl.Text != "New summary" {
+		t.Fatalf("unexpected replace: %#v", batches[2].Requests[0])
+	}
+
+	if err := runKong(t, &DocsReplaceCmd{}, []string{"doc1", "--range", "summary", "--find", "x"}, ctx, flags); err == nil {
+		t.Fatal("expected usage error for --range with --find")
+	}
+}
@@ -12,8 +12,8 @@ import (
 
 	"google.golang.org/api/docs/v1"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type DocsCatCmd struct {
@@ -23,6 +23,8 @@ type DocsCatCmd struct {
 	AllTabs  bool   `name:"all-tabs" help:"Show all tabs with headers"`
 	Raw      bool   `name:"raw" help:"Output the raw Google Docs API JSON response without modifications"`
 	Numbered bool   `name:"numbered" short:"N" help:"Prefix each paragraph with its number"`
+	Format   string `name:"format" help:"Output format: txt|md (md keeps headings, lists, emphasis, links, and tables)" default:"txt" enum:"txt,md"`
+	Headers  bool   `name:"headers" help:"Include the default header and footer text"`
 }
 
 func (c *DocsCatCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -30,6 +32,9 @@ func (c *DocsCatCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if id == "" {
 		return usage("empty docId")
 	}
+	if c.Format == docsCatFormatMarkdown && (c.Raw || c.Numbered) {
+		return usage("--format md cannot be combined with --raw or --numbered")
+	}
 
 	svc, err := requireDocsService(ctx, flags)
 	if err != nil {
@@ -81,7 +86,7 @@ func (c *DocsCatCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return c.printNumbered(ctx, doc, "")
 	}
 
-	text := docsPlainText(doc, c.MaxBytes)
+	text := c.docText(doc)
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"text": text})
 	}
@@ -110,7 +115,7 @@ func (c *DocsCatCmd) runWithTabs(ctx context.Context, svc *docs.Service, id stri
 		if c.Numbered {
 			return c.printNumbered(ctx, doc, c.Tab)
 		}
-		text := tabPlainText(tab, c.MaxBytes)
+		text := c.tabText(tab)
 		if outfmt.IsJSON(ctx) {
 			return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"tab": tabJSON(tab, text)})
 		}
@@ -121,7 +126,7 @@ func (c *DocsCatCmd) runWithTabs(ctx context.Context, svc *docs.Service, id stri
 	if outfmt.IsJSON(ctx) {
 		var out []map[string]any
 		for _, tab := range tabs {
-			text := tabPlainText(tab, c.MaxBytes)
+			text := c.tabText(tab)
 			out = append(out, tabJSON(tab, text))
 		}
 		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"tabs": out})
@@ -137,7 +142,7 @@ func (c *DocsCatCmd) runWithTabs(ctx context.Context, svc *docs.Service, id stri
 		if _, err := fmt.Fprintf(os.Stdout, "=== Tab: %s ===\n", title); err != nil {
 			return err
 		}
-		text := tabPlainText(tab, c.MaxBytes)
+		text := c.tabText(tab)
 		if _, err := io.WriteString(os.Stdout, text); err != nil {
 			return err
 		}
@@ -150,6 +155,36 @@ func (c *DocsCatCmd) runWithTabs(ctx context.Context, svc *docs.Service, id stri
 	return nil
 }
 
+func (c *DocsCatCmd) docText(doc *docs.Document) string {
+	var text string
+	if c.Format == docsCatFormatMarkdown {
+		text = docsMarkdown(doc, c.MaxBytes)
+	} else {
+		text = docsPlainText(doc, c.MaxBytes)
+	}
+	if c.Headers && doc != nil {
+		text = docsWithHeaderFooter(text, doc.DocumentStyle, doc.Headers, doc.Footers)
+	}
+	return text
+}
+
+func (c *DocsCatCmd) tabText(tab *docs.Tab) string {
+	var text string
+	if c.Format == docsCatFormatMarkdown {
+		text = tabMarkdown(tab, c.MaxBytes)
+	} else {
+		text = tabPlainText(tab, c.MaxBytes)
+	}
+	if c.Headers && tab != nil && tab.DocumentTab != nil {
+		text = docsWithHeaderFooter(text, tab.DocumentTab.DocumentStyle, tab.DocumentTab.Headers, tab.DocumentTab.Footers)
+	}
+	return text
+}
+
+type DocsTabsCmd struct {
+	List DocsListTabsCmd `cmd:"" name:"list" aliases:"ls" default:"withargs" help:"List all tabs in a Google Doc"`
+}
+
 type DocsListTabsCmd struct {
 	DocID string `arg:"" name:"docId" help:"Doc ID"`
 }
@@ -342,7 +377,8 @@ func appendLimited(buf *bytes.Buffer, maxBytes int64, s string) bool {
 		return false
 	}
 	if len(s) > remaining {
-		_, _ = buf.WriteString(s[:remaining])
+		truncated, _ := truncateUTF8Bytes(s, remaining)
+		_, _ = buf.WriteString(truncated)
 		return false
 	}
 	_, _ = buf.WriteString(s)
@@ -0,0 +1,344 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type DocsReplaceCmd struct {
+	DocID     string `arg:"" name:"docId" help:"Doc ID"`
+	Find      string `name:"find" help:"Text to find (a Go RE2 pattern with --regex)"`
+	Range     string `name:"range" help:"Replace the whole content of this named range instead, by name or ID (see docs ranges list)"`
+	Replace   string `name:"replace" help:"Replacement text (with --regex, $1 and $name expand capture groups)"`
+	Regex     bool   `name:"regex" help:"Treat --find as a regular expression"`
+	MatchCase bool   `name:"match-case" help:"Case-sensitive matching"`
+	TabID     string `name:"tab-id" aliases:"tab" help:"Target a specific tab by ID or title (see docs tabs list)"`
+}
+
+// docsReplacement reports one find/replace pair and how many occurrences it changed.
+type docsReplacement struct {
+	Find        string `json:"find"`
+	Replace     string `json:"replace"`
+	Occurrences int64  `json:"occurrences"`
+}
+
+func (c *DocsReplaceCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := strings.TrimSpace(c.DocID)
+	if docID == "" {
+		return usage("empty docId")
+	}
+	if err := ensureDriveUnlocked(ctx, flags, docID); err != nil {
+		return err
+	}
+	if rangeSel := strings.TrimSpace(c.Range); rangeSel != "" {
+		if c.Find != "" || c.Regex || c.MatchCase || c.TabID != "" {
+			return usage("--range cannot be combined with --find, --regex, --match-case, or --tab-id")
+		}
+		return c.runNamedRange(ctx, flags, docID, rangeSel)
+	}
+	if c.Find == "" {
+		return usage("--find cannot be empty (or use --range)")
+	}
+
+	var re *regexp.Regexp
+	if c.Regex {
+		pattern := c.Find
+		if !c.MatchCase {
+			pattern = "(?i)" + pattern
+		}
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return usage(fmt.Sprintf("invalid --find regex: %v", err))
+		}
+		re = compiled
+	}
+
+	if err := dryRunExit(ctx, flags, "docs.replace", map[string]any{
+		"documentId": docID,
+		"find":       c.Find,
+		"replace":    c.Replace,
+		"regex":      c.Regex,
+		"matchCase":  c.MatchCase,
+		"tabId":      c.TabID,
+	}); err != nil {
+		return err
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	if c.TabID, err = resolveDocsTabID(ctx, svc, docID, c.TabID); err != nil {
+		return err
+	}
+
+	var results []docsReplacement
+	if re == nil {
+		results, err = applyDocsReplaceAll(ctx, svc, docID, c.Find, c.Replace, c.MatchCase, c.TabID)
+	} else {
+		loaded, loadErr := loadDocsTargetDocument(ctx, svc, docID, c.TabID)
+		if loadErr != nil {
+			return loadErr
+		}
+		edits := planDocsRegexEdits(re, loaded.target, c.Replace)
+		results, err = applyDocsRegexEdits(ctx, svc, docID, edits, c.TabID, loaded.full.RevisionId)
+	}
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, r := range results {
+		total += r.Occurrences
+	}
+
+	if outfmt.IsJSON(ctx) {
+		payload := map[string]any{
+			"documentId":   docID,
+			"find":         c.Find,
+			"replace":      c.Replace,
+			"regex":        c.Regex,
+			"matchCase":    c.MatchCase,
+			"replacements": total,
+			"results":      results,
+		}
+		if c.TabID != "" {
+			payload["tabId"] = c.TabID
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, payload)
+	}
+
+	u.Out().Printf("documentId\t%s", docID)
+	u.Out().Printf("replacements\t%d", total)
+	if c.Regex {
+		for _, r := range results {
+			u.Out().Printf("match\t%q\t%q\t%d", r.Find, r.Replace, r.Occurrences)
+		}
+	}
+	if c.TabID != "" {
+		u.Out().Printf("tabId\t%s", c.TabID)
+	}
+	return nil
+}
+
+// runNamedRange swaps a named range's content for --replace. The Docs API
+// keeps the range anchored to the new text.
+func (c *DocsReplaceCmd) runNamedRange(ctx context.Context, flags *RootFlags, docID, nameOrID string) error {
+	u := ui.FromContext(ctx)
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	nr, err := resolveDocsNamedRange(ctx, svc, docID, nameOrID)
+	if err != nil {
+		return err
+	}
+
+	if err := dryRunExit(ctx, flags, "docs.replace.range", map[string]any{
+		"documentId":   docID,
+		"name":         nr.Name,
+		"namedRangeId": nr.NamedRangeId,
+		"replace":      c.Replace,
+	}); err != nil {
+		return err
+	}
+
+	if _, err := svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{
+		Requests: []*docs.Request{{
+			ReplaceNamedRangeContent: &docs.ReplaceNamedRangeContentRequest{
+				NamedRangeId: nr.NamedRangeId,
+				Text:         c.Replace,
+			},
+		}},
+	}).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("replacing named range content: %w", err)
+	}
+
+	return writeResult(ctx, u,
+		kv("documentId", docID),
+		kv("name", nr.Name),
+		kv("namedRangeId", nr.NamedRangeId),
+		kv("replace", c.Replace),
+	)
+}
+
+// docsRegexEdit rewrites one regex match in place by document index.
+type docsRegexEdit struct {
+	Start, End int64
+	Find       string
+	Replace    string
+}
+
+// docsParagraphRun maps a text run's bytes in the joined paragraph text back
+// to its document index.
+type docsParagraphRun struct {
+	offset  int
+	index   int64
+	content string
+}
+
+// planDocsRegexEdits matches re against each paragraph (including table cells)
+// and returns the matches in document order. The Docs API has no regex
+// support, and ReplaceAllText on the matched literal would also hit copies of
+// it that the pattern's context (\b, ^, neighbouring text) excluded, so every
+// match becomes its own delete+insert at the matched indexes, as in docs sed.
+// Paragraphs are matched without their trailing newline, so ^ and $ anchor to
+// paragraph boundaries and matches never span paragraphs.
+func planDocsRegexEdits(re *regexp.Regexp, doc *docs.Document, template string) []docsRegexEdit {
+	if doc == nil || doc.Body == nil {
+		return nil
+	}
+	var edits []docsRegexEdit
+	var walk func(content []*docs.StructuralElement)
+	walk = func(content []*docs.StructuralElement) {
+		for _, elem := range content {
+			if elem == nil {
+				continue
+			}
+			if elem.Paragraph != nil {
+				edits = append(edits, planDocsParagraphEdits(re, elem.Paragraph, template)...)
+			}
+			if elem.Table != nil {
+				for _, row := range elem.Table.TableRows {
+					for _, cell := range row.TableCells {
+						walk(cell.Content)
+					}
+				}
+			}
+		}
+	}
+	walk(doc.Body.Content)
+	return edits
+}
+
+func planDocsParagraphEdits(re *regexp.Regexp, para *docs.Paragraph, template string) []docsRegexEdit {
+	var (
+		b    strings.Builder
+		runs []docsParagraphRun
+	)
+	for _, pe := range para.Elements {
+		if pe == nil || pe.TextRun == nil || pe.TextRun.Content == "" {
+			continue
+		}
+		runs = append(runs, docsParagraphRun{offset: b.Len(), index: pe.StartIndex, content: pe.TextRun.Content})
+		b.WriteString(pe.TextRun.Content)
+	}
+	text := strings.TrimSuffix(b.String(), "\n")
+
+	var edits []docsRegexEdit
+	for _, loc := range re.FindAllStringSubmatchIndex(text, -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+		literal := text[loc[0]:loc[1]]
+		replacement := string(re.ExpandString(nil, template, text, loc))
+		if replacement == literal {
+			continue
+		}
+		edits = append(edits, docsRegexEdit{
+			Start:   docsParagraphIndex(runs, loc[0]),
+			End:     docsParagraphIndex(runs, loc[1]),
+			Find:    literal,
+			Replace: replacement,
+		})
+	}
+	return edits
+}
+
+// docsParagraphIndex converts a byte offset in the joined paragraph text to a
+// document index, which counts UTF-16 code units.
+func docsParagraphIndex(runs []docsParagraphRun, offset int) int64 {
+	for i := len(runs) - 1; i >= 0; i-- {
+		r := runs[i]
+		if offset >= r.offset {
+			return r.index + int64(len(utf16.Encode([]rune(r.content[:offset-r.offset]))))
+		}
+	}
+	return 0
+}
+
+// applyDocsRegexEdits deletes and re-inserts every match back to front so
+// earlier indexes stay valid, in one revision-guarded batch. Results group the
+// edits by matched text for reporting.
+func applyDocsRegexEdits(ctx context.Context, svc *docs.Service, docID string, edits []docsRegexEdit, tabID, revision string) ([]docsReplacement, error) {
+	if len(edits) == 0 {
+		return []docsReplacement{}, nil
+	}
+
+	reqs := make([]*docs.Request, 0, 2*len(edits))
+	for i := len(edits) - 1; i >= 0; i-- {
+		e := edits[i]
+		reqs = append(reqs, &docs.Request{DeleteContentRange: &docs.DeleteContentRangeRequest{
+			Range: &docs.Range{StartIndex: e.Start, EndIndex: e.End, TabId: tabID},
+		}})
+		if e.Replace != "" {
+			reqs = append(reqs, &docs.Request{InsertText: &docs.InsertTextRequest{
+				Location: &docs.Location{Index: e.Start, TabId: tabID},
+				Text:     e.Replace,
+			}})
+		}
+	}
+
+	batch := &docs.BatchUpdateDocumentRequest{Requests: reqs}
+	if revision != "" {
+		batch.WriteControl = &docs.WriteControl{RequiredRevisionId: revision}
+	}
+	if _, err := svc.Documents.BatchUpdate(docID, batch).Context(ctx).Do(); err != nil {
+		if isDocsNotFound(err) {
+			return nil, fmt.Errorf("doc not found or not a Google Doc (id=%s)", docID)
+		}
+		return nil, fmt.Errorf("replace: %w", err)
+	}
+
+	results := []docsReplacement{}
+	pos := map[[2]string]int{}
+	for _, e := range edits {
+		key := [2]string{e.Find, e.Replace}
+		i, ok := pos[key]
+		if !ok {
+			i = len(results)
+			pos[key] = i
+			results = append(results, docsReplacement{Find: e.Find, Replace: e.Replace})
+		}
+		results[i].Occurrences++
+	}
+	return results, nil
+}
+
+// applyDocsReplaceAll replaces every occurrence of a literal with a single
+// ReplaceAllText request.
+func applyDocsReplaceAll(ctx context.Context, svc *docs.Service, docID, find, replace string, matchCase bool, tabID string) ([]docsReplacement, error) {
+	req := &docs.ReplaceAllTextRequest{
+		ContainsText: &docs.SubstringMatchCriteria{Text: find, MatchCase: matchCase},
+		ReplaceText:  replace,
+	}
+	if tabID != "" {
+		req.TabsCriteria = &docs.TabsCriteria{TabIds: []string{tabID}}
+	}
+
+	resp, err := svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{
+		Requests: []*docs.Request{{ReplaceAllText: req}},
+	}).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return nil, fmt.Errorf("doc not found or not a Google Doc (id=%s)", docID)
+		}
+		return nil, fmt.Errorf("replace: %w", err)
+	}
+
+	result := docsReplacement{Find: find, Replace: replace}
+	if len(resp.Replies) > 0 && resp.Replies[0] != nil && resp.Replies[0].ReplaceAllText != nil {
+		result.Occurrences = resp.Replies[0].ReplaceAllText.OccurrencesChanged
+	}
+	return []docsReplacement{result}, nil
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestDocsReplace_PlainReportsCounts(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	var got docs.BatchUpdateDocumentRequest
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Fatalf("decode batchUpdate: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"documentId": "doc1",
+				"replies":    []any{map[string]any{"replaceAllText": map[string]any{"occurrencesChanged": 3}}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DocsReplaceCmd{}, []string{"doc1", "--find", "{{name}}", "--replace", "Ada", "--match-case"}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("docs replace: %v", err)
+		}
+	})
+
+	if len(got.Requests) != 1 || got.Requests[0].ReplaceAllText == nil {
+		t.Fatalf("expected one ReplaceAllText request, got %#v", got.Requests)
+	}
+	req := got.Requests[0].ReplaceAllText
+	if req.ContainsText.Text != "{{name}}" || !req.ContainsText.MatchCase || req.ReplaceText != "Ada" {
+		t.Fatalf("unexpected request: %#v", req)
+	}
+
+	var payload struct {
+		Replacements int64             `json:"replacements"`
+		Results      []docsReplacement `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(out), &payload); err != nil {
+		t.Fatalf("decode output %q: %v", out, err)
+	}
+	if payload.Replacements != 3 || len(payload.Results) != 1 || payload.Results[0].Occurrences != 3 {
+		t.Fatalf("unexpected report: %#v", payload)
+	}
+}
+
+func TestDocsReplace_RegexExpandsLiterals(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	var got docs.BatchUpdateDocumentRequest
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/documents/"):
+			body := docBodyWithText("v1.2 and v10.0 and v1.2 again")
+			body["revisionId"] = "rev-1"
+			_ = json.NewEncoder(w).Encode(body)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Fatalf("decode batchUpdate: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"documentId": "doc1"})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	if err := runKong(t, &DocsReplaceCmd{}, []string{"doc1", "--find", `v(\d+)\.(\d+)`, "--replace", "version $1-$2", "--regex"}, newDocsCmdContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+		t.Fatalf("docs replace --regex: %v", err)
+	}
+
+	if got.WriteControl == nil || got.WriteControl.RequiredRevisionId != "rev-1" {
+		t.Fatalf("expected revision write control, got %#v", got.WriteControl)
+	}
+	// Three matches, applied back to front as delete+insert pairs.
+	if len(got.Requests) != 6 {
+		t.Fatalf("expected 6 requests, got %d", len(got.Requests))
+	}
+	for i, want := range []struct {
+		start, end int64
+		text       string
+	}{{20, 24, "version 1-2"}, {10, 15, "version 10-0"}, {1, 5, "version 1-2"}} {
+		del, ins := got.Requests[2*i].DeleteContentRange, got.Requests[2*i+1].InsertText
+		if del == nil || del.Range.StartIndex != want.start || del.Range.EndIndex != want.end {
+			t.Fatalf("request %d: unexpected delete %#v", 2*i, got.Requests[2*i])
+		}
+		if ins == nil || ins.Location.Index != want.start || ins.Text != want.text {
+			t.Fatalf("request %d: unexpected insert %#v", 2*i+1, got.Requests[2*i+1])
+		}
+	}
+}
+
+func TestPlanDocsRegexEdits_KeepsContextAndDoesNotChain(t *testing.T) {
+	doc := &docs.Document{Body: &docs.Body{Content: []*docs.StructuralElement{
+		{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+			{StartIndex: 1, TextRun: &docs.TextRun{Content: "v1 xv1 "}},
+			{StartIndex: 8, TextRun: &docs.TextRun{Content: "v2 é v1\n"}},
+		}}},
+		{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+			{StartIndex: 17, TextRun: &docs.TextRun{Content: "A\n"}},
+		}}},
+	}}}
+
+	// \b excludes "xv1"; v1->v2 must not feed into v2->v3; indexes after
+	// "é" count UTF-16 units, not bytes.
+	edits := planDocsRegexEdits(regexp.MustCompile(`\bv([12])\b`), doc, "v$1+")
+	var got []string
+	for _, e := range edits {
+		got = append(got, fmt.Sprintf("%d-%d %s>%s", e.Start, e.End, e.Find, e.Replace))
+	}
+	if want := "1-3 v1>v1+, 8-10 v2>v2+, 13-15 v1>v1+"; strings.Join(got, ", ") != want {
+		t.Fatalf("got %s\nwant %s", strings.Join(got, ", "), want)
+	}
+
+	// $ anchors to the paragraph end, and matches never cross paragraphs.
+	edits = planDocsRegexEdits(regexp.MustCompile(`(?i)v1$|1\s*a`), doc, "X")
+	if len(edits) != 1 || edits[0].Start != 13 || edits[0].End != 15 {
+		t.Fatalf("unexpected anchored edits: %#v", edits)
+	}
+}
+
+func TestDocsReplace_InvalidRegex(t *testing.T) {
+	err := runKong(t, &DocsReplaceCmd{}, []string{"doc1", "--find", "(", "--regex"}, newDocsCmdContext(t), &RootFlags{Account: "a@b.com"})
+	if err == nil || !strings.Contains(err.Error(), "invalid --find regex") {
+		t.Fatalf("expected regex error, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+var newDriveHTTPClient = googleapi.NewDriveHTTPClient
+
+// driveRevisionExportDownload fetches a revision export link. Google Workspace
+// revisions are only downloadable through these links, which live outside the
+// Drive API surface and need an authenticated client of their own.
+var driveRevisionExportDownload = func(ctx context.Context, account string, link string) (*http.Response, error) {
+	client, err := newDriveHTTPClient(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+const driveRevisionFields = "id,modifiedTime,lastModifyingUser(displayName,emailAddress),keepForever,size"
+
+type DocsRevisionsCmd struct {
+	DocID string `arg:"" name:"docId" help:"Doc ID"`
+	Max   int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
+	Page  string `name:"page" aliases:"cursor" help:"Page token"`
+}
+
+func (c *DocsRevisionsCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if docID == "" {
+		return usage("empty docId")
+	}
+	if c.Max <= 0 {
+		return usage("--max must be > 0")
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+
+	call := svc.Revisions.List(docID).
+		PageSize(c.Max).
+		Fields("nextPageToken", "revisions("+driveRevisionFields+")").
+		Context(ctx)
+	if strings.TrimSpace(c.Page) != "" {
+		call = call.PageToken(c.Page)
+	}
+	resp, err := call.Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		revisions := resp.Revisions
+		if revisions == nil {
+			revisions = []*drive.Revision{}
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId":    docID,
+			"revisions":     revisions,
+			"nextPageToken": resp.NextPageToken,
+		})
+	}
+
+	if len(resp.Revisions) == 0 {
+		u.Err().Println("No revisions")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tMODIFIED\tAUTHOR\tKEEP")
+	for _, r := range resp.Revisions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", r.Id, humanDateTime(ctx, r.ModifiedTime), driveRevisionAuthor(r), r.KeepForever)
+	}
+	printNextPageHint(u, resp.NextPageToken)
+	return nil
+}
+
+// downloadDriveRevision is downloadDriveFile for a historical revision.
+// Workspace files are exported via the revision's exportLinks; binary files
+// use the revision media endpoint.
+func downloadDriveRevision(ctx context.Context, account string, svc *drive.Service, meta *drive.File, revisionID, destPath, format string) (string, int64, error) {
+	if !strings.HasPrefix(meta.MimeType, "application/vnd.google-apps.") {
+		resp, err := svc.Revisions.Get(meta.Id, revisionID).Context(ctx).Download()
+		if err != nil {
+			return "", 0, err
+		}
+		return writeDriveDownloadResponse(resp, destPath)
+	}
+
+	if err := validateDriveDownloadFormatForFile(meta, format); err != nil {
+		return "", 0, err
+	}
+	normalizedFormat := strings.ToLower(strings.TrimSpace(format))
+	exportMimeType := driveExportMimeType(meta.MimeType)
+	if normalizedFormat != "" && normalizedFormat != formatAuto {
+		var err error
+		exportMimeType, err = driveExportMimeTypeForFormat(meta.MimeType, normalizedFormat)
+		if err != nil {
+			return "", 0, err
+		}
+	}
+
+	rev, err := svc.Revisions.Get(meta.Id, revisionID).Fields("id,exportLinks").Context(ctx).Do()
+	if err != nil {
+		return "", 0, err
+	}
+	link := rev.ExportLinks[exportMimeType]
+	if link == "" {
+		available := make([]string, 0, len(rev.ExportLinks))
+		for mime := range rev.ExportLinks {
+			available = append(available, mime)
+		}
+		sort.Strings(available)
+		return "", 0, fmt.Errorf("revision %s cannot be exported as %s (available: %s)", revisionID, exportMimeType, strings.Join(available, ", "))
+	}
+
+	resp, err := driveRevisionExportDownload(ctx, account, link)
+	if err != nil {
+		return "", 0, err
+	}
+	return writeDriveDownloadResponse(resp, replaceExt(destPath, driveExportExtension(exportMimeType)))
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func newDocsRevisionsDriveService(t *testing.T) {
+	t.Helper()
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		drivePath := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case drivePath == "/files/doc1/revisions" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"revisions": []map[string]any{
+					{"id": "1", "modifiedTime": "2026-01-01T10:00:00Z", "lastModifyingUser": map[string]any{"emailAddress": "a@b.com"}},
+					{"id": "7", "modifiedTime": "2026-01-02T10:00:00Z", "keepForever": true},
+				},
+			})
+		case drivePath == "/files/doc1/revisions/7" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id": "7",
+				"exportLinks": map[string]any{
+					"application/pdf": "https://docs.example/export?id=doc1&revision=7&exportFormat=pdf",
+				},
+			})
+		case drivePath == "/files/doc1" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":       "doc1",
+				"name":     "Doc",
+				"mimeType": "application/vnd.google-apps.document",
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+}
+
+func TestDocsRevisions_JSON(t *testing.T) {
+	newDocsRevisionsDriveService(t)
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "docs", "revisions", "doc1"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+
+	var parsed struct {
+		Revisions []drive.Revision `json:"revisions"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if len(parsed.Revisions) != 2 || parsed.Revisions[1].Id != "7" || !parsed.Revisions[1].KeepForever {
+		t.Fatalf("unexpected revisions: %#v", parsed.Revisions)
+	}
+}
+
+func TestDocsExport_Revision(t *testing.T) {
+	newDocsRevisionsDriveService(t)
+
+	origDownload := driveRevisionExportDownload
+	t.Cleanup(func() { driveRevisionExportDownload = origDownload })
+	var gotLink string
+	driveRevisionExportDownload = func(_ context.Context, _ string, link string) (*http.Response, error) {
+		gotLink = link
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("%PDF-old"))}, nil
+	}
+
+	outDir := t.TempDir()
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "docs", "export", "doc1", "--revision", "7", "--out", outDir}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+
+	if !strings.Contains(gotLink, "revision=7") {
+		t.Fatalf("unexpected export link: %q", gotLink)
+	}
+	var parsed struct {
+		Path string `json:"path"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if filepath.Base(parsed.Path) != "doc1_Doc_rev7.pdf" {
+		t.Fatalf("unexpected path: %q", parsed.Path)
+	}
+	if data, err := os.ReadFile(parsed.Path); err != nil || string(data) != "%PDF-old" {
+		t.Fatalf("unexpected file contents: %q %v", data, err)
+	}
+
+	if err := Execute([]string{"--json", "--account", "a@b.com", "docs", "export", "doc1", "--revision", "7", "--format", "docx", "--out", outDir}); err == nil || !strings.Contains(err.Error(), "available: application/pdf") {
+		t.Fatalf("expected missing export link error, got %v", err)
+	}
+}
@@ -119,6 +119,9 @@ func (c *DocsSedCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if id == "" {
 		return usage("empty docId")
 	}
+	if err := ensureDriveUnlocked(ctx, flags, id); err != nil {
+		return err
+	}
 
 	// Collect all expressions
 	rawExprs, err := c.collectExpressions()
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/pkg/docsmd"
 )
 
 // buildBraceTextStyleRequests converts a braceExpr to UpdateTextStyle requests.
@@ -65,7 +67,7 @@ func buildBraceTextStyleRequests(be *braceExpr, start, end int64) []*docs.Reques
 	// Code flag: monospace font + grey background
 	if be.Code != nil && *be.Code {
 		style.WeightedFontFamily = &docs.WeightedFontFamily{FontFamily: "Courier New"}
-		style.BackgroundColor = greyColor(codeBackgroundGrey)
+		style.BackgroundColor = docsmd.GreyColor(codeBackgroundGrey)
 		fields = append(fields, "weightedFontFamily", "backgroundColor")
 	}
 
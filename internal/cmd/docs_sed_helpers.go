@@ -10,8 +10,9 @@ import (
 
 	"google.golang.org/api/docs/v1"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/docsmd"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 // compilePattern compiles the sedExpr's pattern into a regexp.
@@ -50,24 +51,12 @@ func getDoc(ctx context.Context, docsSvc *docs.Service, docID string) (*docs.Doc
 // codeBackgroundGrey is the RGB value for inline code background shading.
 const codeBackgroundGrey = 0.95
 
-// borderGrey is the grey intensity for borders (blockquotes, horizontal rules).
-const borderGrey = 0.8
-
 // indentPointsPerLevel is the number of points per indent level in Google Docs.
 const indentPointsPerLevel = 36.0
 
 // hrulePaddingPt is the padding in points below a horizontal rule border.
 const hrulePaddingPt = 6.0
 
-// blockquoteBorderWidthPt is the border width in points for blockquotes.
-const blockquoteBorderWidthPt = 3.0
-
-// blockquoteIndentPt is the left indent in points for blockquotes.
-const blockquoteIndentPt = 36.0
-
-// blockquotePaddingPt is the left padding in points for blockquotes.
-const blockquotePaddingPt = 12.0
-
 // bulletPresetDisc is the default unordered bullet preset.
 const bulletPresetDisc = "BULLET_DISC_CIRCLE_SQUARE"
 
@@ -169,9 +158,9 @@ func buildTextStyleRequests(formats []string, start, end int64) []*docs.Request
 		case "strikethrough":
 			textStyle.Strikethrough = true
 			textFields = append(textFields, "strikethrough")
-		case inlineTypeCode:
+		case "code":
 			textStyle.WeightedFontFamily = &docs.WeightedFontFamily{FontFamily: "Courier New"}
-			textStyle.BackgroundColor = greyColor(codeBackgroundGrey)
+			textStyle.BackgroundColor = docsmd.GreyColor(codeBackgroundGrey)
 			textFields = append(textFields, "weightedFontFamily", "backgroundColor")
 		case "underline":
 			textStyle.Underline = true
@@ -298,21 +287,7 @@ func buildParagraphStyleRequests(formats []string, start, end int64) []*docs.Req
 	}
 
 	if isBlockquote {
-		requests = append(requests, &docs.Request{
-			UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
-				Range: &docs.Range{StartIndex: start, EndIndex: end},
-				ParagraphStyle: &docs.ParagraphStyle{
-					IndentStart: &docs.Dimension{Magnitude: blockquoteIndentPt, Unit: "PT"},
-					BorderLeft: &docs.ParagraphBorder{
-						Color:     greyColor(borderGrey),
-						Width:     &docs.Dimension{Magnitude: blockquoteBorderWidthPt, Unit: "PT"},
-						DashStyle: "SOLID",
-						Padding:   &docs.Dimension{Magnitude: blockquotePaddingPt, Unit: "PT"},
-					},
-				},
-				Fields: "indentStart,borderLeft",
-			},
-		})
+		requests = append(requests, docsmd.BlockquoteStyleRequest(start, end))
 	}
 
 	return requests
@@ -326,7 +301,7 @@ func buildHruleBorderRequest(start, end int64) *docs.Request {
 			Range: &docs.Range{StartIndex: start, EndIndex: end},
 			ParagraphStyle: &docs.ParagraphStyle{
 				BorderBottom: &docs.ParagraphBorder{
-					Color:     greyColor(borderGrey),
+					Color:     docsmd.GreyColor(docsmd.BorderGrey),
 					Width:     &docs.Dimension{Magnitude: 1, Unit: "PT"},
 					DashStyle: "SOLID",
 					Padding:   &docs.Dimension{Magnitude: hrulePaddingPt, Unit: "PT"},
@@ -337,11 +312,6 @@ func buildHruleBorderRequest(start, end int64) *docs.Request {
 	}
 }
 
-// greyColor returns an OptionalColor with the given greyscale intensity (0.0=black, 1.0=white).
-func greyColor(intensity float64) *docs.OptionalColor {
-	return &docs.OptionalColor{Color: &docs.Color{RgbColor: &docs.RgbColor{Red: intensity, Green: intensity, Blue: intensity}}}
-}
-
 // containsFormat returns true if the format slice contains the given format string.
 func containsFormat(formats []string, f string) bool {
 	for _, v := range formats {
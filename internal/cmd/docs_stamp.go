@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/docsmd"
+)
+
+// docsStampRangeName marks the managed stamp paragraph. Docs keeps named
+// ranges attached to their text as the document is edited, so the previous
+// stamp can be found and replaced even after content was added around it.
+const docsStampRangeName = "gog:stamp"
+
+// DocsStampCmd maintains a single provenance line at the end of a doc.
+type DocsStampCmd struct {
+	DocID  string `arg:"" name:"docId" help:"Doc ID"`
+	Footer string `name:"footer" help:"Stamp template: {{.Tool}}, {{.Version}}, {{.Time}}, {{.Date}}, {{.GitSHA}}, {{.Account}}, {{.DocID}}" default:"Generated by {{.Tool}} at {{.Time}}"`
+	GitSHA string `name:"git-sha" help:"Commit for {{.GitSHA}} (default: $GITHUB_SHA, $CI_COMMIT_SHA, or git rev-parse HEAD)"`
+	Remove bool   `name:"remove" help:"Remove the managed stamp instead of writing one"`
+}
+
+// docsStampData is the template data for --footer.
+type docsStampData struct {
+	Tool    string
+	Version string
+	Time    string
+	Date    string
+	GitSHA  string
+	Account string
+	DocID   string
+}
+
+// docsStampGitSHA resolves the current commit; swapped in tests.
+var docsStampGitSHA = func(ctx context.Context) string {
+	for _, key := range []string{"GITHUB_SHA", "CI_COMMIT_SHA"} {
+		if sha := strings.TrimSpace(os.Getenv(key)); sha != "" {
+			return shortSHA(sha)
+		}
+	}
+	out, err := exec.CommandContext(ctx, "git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return shortSHA(strings.TrimSpace(string(out)))
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
+
+func (c *DocsStampCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	id := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if id == "" {
+		return usage("empty docId")
+	}
+
+	var text string
+	if !c.Remove {
+		account, _ := requireAccount(flags)
+		sha := strings.TrimSpace(c.GitSHA)
+		if sha == "" && strings.Contains(c.Footer, ".GitSHA") {
+			sha = docsStampGitSHA(ctx)
+		}
+		now := time.Now().UTC()
+		ver := strings.TrimSpace(version)
+		if ver == "" {
+			ver = "dev"
+		}
+		rendered, err := renderDocsStamp(c.Footer, docsStampData{
+			Tool:    "gog",
+			Version: ver,
+			Time:    now.Format(time.RFC3339),
+			Date:    now.Format("2006-01-02"),
+			GitSHA:  sha,
+			Account: account,
+			DocID:   id,
+		})
+		if err != nil {
+			return err
+		}
+		text = rendered
+	}
+
+	op := "docs.stamp"
+	if c.Remove {
+		op = "docs.stamp.remove"
+	}
+	if err := dryRunExit(ctx, flags, op, map[string]any{
+		"documentId": id,
+		"text":       text,
+	}); err != nil {
+		return err
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	doc, err := svc.Documents.Get(id).
+		Fields("documentId,revisionId,body(content(startIndex,endIndex)),namedRanges").
+		Context(ctx).
+		Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return fmt.Errorf("doc not found or not a Google Doc (id=%s)", id)
+		}
+		return err
+	}
+	if doc == nil {
+		return errors.New("doc not found")
+	}
+
+	requests, replaced := docsStampRequests(doc, text, c.Remove)
+	if c.Remove && !replaced {
+		u.Err().Println("No stamp to remove")
+		return nil
+	}
+	if _, err := svc.Documents.BatchUpdate(id, docsBatchAtRevision(doc.RevisionId, requests)).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("writing stamp: %w", err)
+	}
+
+	if c.Remove {
+		return writeResult(ctx, u,
+			kv("documentId", id),
+			kv("removed", true),
+		)
+	}
+	return writeResult(ctx, u,
+		kv("documentId", id),
+		kv("replaced", replaced),
+		kv("text", text),
+	)
+}
+
+// renderDocsStamp executes the --footer template. Stamps are a single
+// paragraph, so newlines are folded into spaces.
+func renderDocsStamp(tmpl string, data docsStampData) (string, error) {
+	t, err := template.New("stamp").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", usagef("invalid --footer template: %v", err)
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return "", usagef("invalid --footer template: %v", err)
+	}
+	text := strings.Join(strings.Fields(strings.ReplaceAll(b.String(), "\n", " ")), " ")
+	if text == "" {
+		return "", usage("--footer rendered to empty text")
+	}
+	return text, nil
+}
+
+// docsStampRequests deletes any previous stamp (every range named
+// docsStampRangeName) and, unless removing, appends text as a new last
+// paragraph covered by a fresh named range. The stamp range includes the
+// paragraph break before the text, so deleting it restores the doc exactly.
+func docsStampRequests(doc *docs.Document, text string, remove bool) ([]*docs.Request, bool) {
+	var old []*docs.Range
+	if group, ok := doc.NamedRanges[docsStampRangeName]; ok {
+		for _, nr := range group.NamedRanges {
+			if nr == nil {
+				continue
+			}
+			for _, r := range nr.Ranges {
+				if r != nil && r.EndIndex > r.StartIndex {
+					old = append(old, r)
+				}
+			}
+		}
+	}
+	// Delete from the end so earlier indexes stay valid.
+	sort.Slice(old, func(i, j int) bool { return old[i].StartIndex > old[j].StartIndex })
+
+	var requests []*docs.Request
+	if len(old) > 0 {
+		requests = append(requests, &docs.Request{DeleteNamedRange: &docs.DeleteNamedRangeRequest{Name: docsStampRangeName}})
+	}
+	end := docsDocumentEndIndex(doc)
+	for _, r := range old {
+		requests = append(requests, &docs.Request{DeleteContentRange: &docs.DeleteContentRangeRequest{
+			Range: &docs.Range{StartIndex: r.StartIndex, EndIndex: r.EndIndex},
+		}})
+		end -= r.EndIndex - r.StartIndex
+	}
+	if remove {
+		return requests, len(old) > 0
+	}
+
+	// Insert before the body's final newline; an empty doc gets the stamp
+	// as its only paragraph.
+	at := max(end-1, 1)
+	insert := text
+	if at > 1 {
+		insert = "\n" + text
+	}
+	requests = append(requests,
+		&docs.Request{InsertText: &docs.InsertTextRequest{
+			Location: &docs.Location{Index: at},
+			Text:     insert,
+		}},
+		&docs.Request{CreateNamedRange: &docs.CreateNamedRangeRequest{
+			Name:  docsStampRangeName,
+			Range: &docs.Range{StartIndex: at, EndIndex: at + docsmd.UTF16Len(insert)},
+		}},
+	)
+	return requests, len(old) > 0
+}
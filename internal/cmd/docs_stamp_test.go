@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestRenderDocsStamp(t *testing.T) {
+	got, err := renderDocsStamp("Generated by {{.Tool}}\nat {{.Time}} from {{.GitSHA}}", docsStampData{Tool: "gog", Time: "2025-03-07T10:00:00Z", GitSHA: "abc123"})
+	if err != nil || got != "Generated by gog at 2025-03-07T10:00:00Z from abc123" {
+		t.Fatalf("renderDocsStamp = %q, %v", got, err)
+	}
+	if _, err := renderDocsStamp("{{.Nope}}", docsStampData{}); err == nil || !strings.Contains(err.Error(), "invalid --footer template") {
+		t.Fatalf("expected template error, got %v", err)
+	}
+}
+
+func TestDocsStampRequests(t *testing.T) {
+	doc := &docs.Document{Body: &docs.Body{Content: []*docs.StructuralElement{
+		{StartIndex: 1, EndIndex: 7},
+		{StartIndex: 7, EndIndex: 20},
+	}}}
+	reqs, replaced := docsStampRequests(doc, "Stamp é", false)
+	if replaced || len(reqs) != 2 {
+		t.Fatalf("unexpected first stamp requests: %d replaced=%v", len(reqs), replaced)
+	}
+	if ins := reqs[0].InsertText; ins.Location.Index != 19 || ins.Text != "\nStamp é" {
+		t.Fatalf("unexpected insert: %+v", ins)
+	}
+	if nr := reqs[1].CreateNamedRange; nr.Name != docsStampRangeName || nr.Range.StartIndex != 19 || nr.Range.EndIndex != 27 {
+		t.Fatalf("unexpected named range: %+v", nr.Range)
+	}
+
+	// A second run deletes the old stamp before appending the new one.
+	doc.Body.Content = append(doc.Body.Content, &docs.StructuralElement{StartIndex: 20, EndIndex: 28})
+	doc.NamedRanges = map[string]docs.NamedRanges{docsStampRangeName: {NamedRanges: []*docs.NamedRange{{
+		Name:   docsStampRangeName,
+		Ranges: []*docs.Range{{StartIndex: 19, EndIndex: 27}},
+	}}}}
+	reqs, replaced = docsStampRequests(doc, "New", false)
+	if !replaced || len(reqs) != 4 || reqs[0].DeleteNamedRange == nil {
+		t.Fatalf("unexpected replace requests: %+v", reqs)
+	}
+	if del := reqs[1].DeleteContentRange.Range; del.StartIndex != 19 || del.EndIndex != 27 {
+		t.Fatalf("unexpected delete: %+v", del)
+	}
+	if ins := reqs[2].InsertText; ins.Location.Index != 19 || ins.Text != "\nNew" {
+		t.Fatalf("unexpected re-insert: %+v", ins)
+	}
+
+	empty := &docs.Document{Body: &docs.Body{Content: []*docs.StructuralElement{{StartIndex: 1, EndIndex: 2}}}}
+	reqs, _ = docsStampRequests(empty, "Only", false)
+	if ins := reqs[0].InsertText; ins.Location.Index != 1 || ins.Text != "Only" {
+		t.Fatalf("unexpected insert into empty doc: %+v", ins)
+	}
+}
+
+func TestDocsStampCmd(t *testing.T) {
+	origDocs, origSHA := newDocsService, docsStampGitSHA
+	t.Cleanup(func() { newDocsService, docsStampGitSHA = origDocs, origSHA })
+	docsStampGitSHA = func(context.Context) string { return "deadbeef" }
+
+	var batches []docs.BatchUpdateDocumentRequest
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && strings.Contains(r.URL.Path, ":batchUpdate") {
+			var req docs.BatchUpdateDocumentRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			batches = append(batches, req)
+			_ = json.NewEncoder(w).Encode(map[string]any{"documentId": "doc1"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"documentId": "doc1",
+			"revisionId": "rev1",
+			"body":       map[string]any{"content": []any{map[string]any{"startIndex": 1, "endIndex": 6}}},
+		})
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := newDocsCmdContext(t)
+	if err := runKong(t, &DocsStampCmd{}, []string{"doc1", "--footer", "Built from {{.GitSHA}} for {{.DocID}}"}, ctx, flags); err != nil {
+		t.Fatalf("stamp: %v", err)
+	}
+	if len(batches) != 1 || batches[0].WriteControl == nil || batches[0].WriteControl.RequiredRevisionId != "rev1" {
+		t.Fatalf("expected one revision-checked batch, got %+v", batches)
+	}
+	if ins := batches[0].Requests[0].InsertText; ins == nil || ins.Text != "\nBuilt from deadbeef for doc1" {
+		t.Fatalf("unexpected insert: %+v", ins)
+	}
+
+	if err := runKong(t, &DocsStampCmd{}, []string{"doc1", "--remove"}, ctx, flags); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if len(batches) != 1 {
+		t.Fatalf("expected no batch when there is no stamp, got %d", len(batches))
+	}
+}
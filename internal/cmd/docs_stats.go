@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type DocsStatsCmd struct {
+	DocID string `arg:"" name:"docId" help:"Doc ID"`
+	Tab   string `name:"tab" help:"Only count this tab, by title or ID (default: every tab)"`
+	WPM   int    `name:"wpm" help:"Reading speed in words per minute for the reading-time estimate" default:"238"`
+}
+
+// docsStats counts a document's text. Characters exclude paragraph breaks;
+// paragraphs are non-empty ones, table cells included.
+type docsStats struct {
+	Words              int `json:"words"`
+	Characters         int `json:"characters"`
+	CharactersNoSpaces int `json:"charactersNoSpaces"`
+	Paragraphs         int `json:"paragraphs"`
+	Headings           int `json:"headings"`
+	Tables             int `json:"tables"`
+	Images             int `json:"images"`
+	ReadingMinutes     int `json:"readingMinutes"`
+}
+
+func (c *DocsStatsCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	id := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if id == "" {
+		return usage("empty docId")
+	}
+	if c.WPM <= 0 {
+		return usage("--wpm must be > 0")
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	doc, err := svc.Documents.Get(id).IncludeTabsContent(true).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return fmt.Errorf("doc not found or not a Google Doc (id=%s)", id)
+		}
+		return err
+	}
+	if doc == nil {
+		return errors.New("doc not found")
+	}
+
+	var stats docsStats
+	tabs := flattenTabs(doc.Tabs)
+	switch {
+	case strings.TrimSpace(c.Tab) != "":
+		tab := findTab(tabs, c.Tab)
+		if tab == nil {
+			return fmt.Errorf("tab not found: %s", c.Tab)
+		}
+		stats.addTab(tab)
+	case len(tabs) == 0:
+		stats.addBody(doc.Body)
+		stats.Images += len(doc.InlineObjects) + len(doc.PositionedObjects)
+	default:
+		for _, tab := range tabs {
+			stats.addTab(tab)
+		}
+	}
+	if stats.Words > 0 {
+		stats.ReadingMinutes = (stats.Words + c.WPM - 1) / c.WPM
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId": id,
+			"title":      doc.Title,
+			"stats":      stats,
+		})
+	}
+	u.Out().Printf("title\t%s", doc.Title)
+	u.Out().Printf("words\t%d", stats.Words)
+	u.Out().Printf("characters\t%d", stats.Characters)
+	u.Out().Printf("charactersNoSpaces\t%d", stats.CharactersNoSpaces)
+	u.Out().Printf("paragraphs\t%d", stats.Paragraphs)
+	u.Out().Printf("headings\t%d", stats.Headings)
+	u.Out().Printf("tables\t%d", stats.Tables)
+	u.Out().Printf("images\t%d", stats.Images)
+	u.Out().Printf("readingTime\t%d min", stats.ReadingMinutes)
+	return nil
+}
+
+func (s *docsStats) addTab(tab *docs.Tab) {
+	if tab == nil || tab.DocumentTab == nil {
+		return
+	}
+	s.addBody(tab.DocumentTab.Body)
+	s.Images += len(tab.DocumentTab.InlineObjects) + len(tab.DocumentTab.PositionedObjects)
+}
+
+func (s *docsStats) addBody(body *docs.Body) {
+	if body == nil {
+		return
+	}
+	var walk func(content []*docs.StructuralElement)
+	walk = func(content []*docs.StructuralElement) {
+		for _, el := range content {
+			switch {
+			case el == nil:
+			case el.Table != nil:
+				s.Tables++
+				for _, row := range el.Table.TableRows {
+					for _, cell := range row.TableCells {
+						walk(cell.Content)
+					}
+				}
+			case el.Paragraph != nil:
+				s.addParagraph(el.Paragraph)
+			}
+		}
+	}
+	walk(body.Content)
+}
+
+func (s *docsStats) addParagraph(p *docs.Paragraph) {
+	text := docsParagraphText(p.Elements)
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	s.Paragraphs++
+	if p.ParagraphStyle != nil {
+		if _, ok := docsHeadingLevel(p.ParagraphStyle.NamedStyleType); ok {
+			s.Headings++
+		}
+	}
+	s.Words += len(strings.Fields(text))
+	s.Characters += utf8.RuneCountInString(text)
+	for _, r := range text {
+		if !unicode.IsSpace(r) {
+			s.CharactersNoSpaces++
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestDocsStatsCmd(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	svc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"documentId": "doc1",
+			"title":      "Essay",
+			"body": map[string]any{"content": []any{
+				docsTestParagraph(1, "Intro\n", "HEADING_1"),
+				docsTestParagraph(7, "Hello  wide world.\n", "NORMAL_TEXT"),
+				docsTestParagraph(26, "\n", "NORMAL_TEXT"),
+				map[string]any{"startIndex": 27, "endIndex": 40, "table": map[string]any{"tableRows": []any{
+					map[string]any{"tableCells": []any{map[string]any{"content": []any{docsTestParagraph(29, "cell text\n", "NORMAL_TEXT")}}}},
+				}}},
+			}},
+			"inlineObjects": map[string]any{"kix.1": map[string]any{}},
+		})
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DocsStatsCmd{}, []string{"doc1", "--wpm", "3"}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("stats: %v", err)
+		}
+	})
+	var got struct {
+		Stats docsStats `json:"stats"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	want := docsStats{Words: 6, Characters: 32, CharactersNoSpaces: 28, Paragraphs: 3, Headings: 1, Tables: 1, Images: 1, ReadingMinutes: 2}
+	if got.Stats != want {
+		t.Fatalf("got %#v, want %#v", got.Stats, want)
+	}
+
+	ctx, text := newDocsCmdOutputContext(t)
+	if err := runKong(t, &DocsStatsCmd{}, []string{"doc1"}, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if !strings.Contains(text.String(), "words\t6") || !strings.Contains(text.String(), "readingTime\t1 min") {
+		t.Fatalf("unexpected text output: %q", text)
+	}
+}
@@ -0,0 +1,456 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+const (
+	docsSuggestionsInline = "SUGGESTIONS_INLINE"
+
+	docsSuggestionInsertion = "insertion"
+	docsSuggestionDeletion  = "deletion"
+	docsSuggestionStyle     = "style"
+)
+
+// DocsSuggestionsCmd is the parent command for tracked changes (suggested edits).
+type DocsSuggestionsCmd struct {
+	List   DocsSuggestionsListCmd   `cmd:"" name:"list" aliases:"ls" help:"List suggested edits (SUGGESTIONS_INLINE view)"`
+	Accept DocsSuggestionsAcceptCmd `cmd:"" name:"accept" help:"Accept suggested insertions/deletions"`
+	Reject DocsSuggestionsRejectCmd `cmd:"" name:"reject" help:"Reject suggested insertions/deletions"`
+}
+
+type DocsSuggestionsListCmd struct {
+	DocID string `arg:"" name:"docId" help:"Doc ID"`
+	TabID string `name:"tab-id" aliases:"tab" help:"Target a specific tab by ID or title (see docs tabs list)"`
+}
+
+type DocsSuggestionsAcceptCmd struct {
+	DocID         string   `arg:"" name:"docId" help:"Doc ID"`
+	SuggestionIDs []string `arg:"" optional:"" name:"suggestionId" help:"Suggestion IDs (see docs suggestions list)"`
+	All           bool     `name:"all" help:"Apply to every text suggestion in the document"`
+	TabID         string   `name:"tab-id" aliases:"tab" help:"Target a specific tab by ID or title (see docs tabs list)"`
+}
+
+type DocsSuggestionsRejectCmd struct {
+	DocID         string   `arg:"" name:"docId" help:"Doc ID"`
+	SuggestionIDs []string `arg:"" optional:"" name:"suggestionId" help:"Suggestion IDs (see docs suggestions list)"`
+	All           bool     `name:"all" help:"Apply to every text suggestion in the document"`
+	TabID         string   `name:"tab-id" aliases:"tab" help:"Target a specific tab by ID or title (see docs tabs list)"`
+}
+
+// docsSuggestion aggregates every text run tagged with one suggestion ID.
+type docsSuggestion struct {
+	ID     string     `json:"id"`
+	Kind   string     `json:"kind"`
+	Text   string     `json:"text"`
+	Ranges []docRange `json:"-"`
+	Start  int64      `json:"startIndex"`
+	End    int64      `json:"endIndex"`
+}
+
+func (c *DocsSuggestionsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	docID := strings.TrimSpace(c.DocID)
+	if docID == "" {
+		return usage("empty docId")
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	if c.TabID, err = resolveDocsTabID(ctx, svc, docID, c.TabID); err != nil {
+		return err
+	}
+
+	_, body, err := loadDocsSuggestionsBody(ctx, svc, docID, c.TabID)
+	if err != nil {
+		return err
+	}
+	suggestions := collectDocsSuggestions(body)
+
+	if outfmt.IsJSON(ctx) {
+		payload := map[string]any{
+			"documentId":  docID,
+			"suggestions": suggestions,
+		}
+		if c.TabID != "" {
+			payload["tabId"] = c.TabID
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, payload)
+	}
+
+	if len(suggestions) == 0 {
+		u.Err().Println("No suggestions")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tKIND\tRANGE\tTEXT")
+	for _, s := range suggestions {
+		fmt.Fprintf(w, "%s\t%s\t%d-%d\t%s\n", s.ID, s.Kind, s.Start, s.End, docsSuggestionPreview(s.Text))
+	}
+	return nil
+}
+
+func (c *DocsSuggestionsAcceptCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return runDocsSuggestionsResolve(ctx, flags, c.DocID, c.SuggestionIDs, c.All, c.TabID, true)
+}
+
+func (c *DocsSuggestionsRejectCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return runDocsSuggestionsResolve(ctx, flags, c.DocID, c.SuggestionIDs, c.All, c.TabID, false)
+}
+
+func runDocsSuggestionsResolve(ctx context.Context, flags *RootFlags, rawDocID string, ids []string, all bool, tabID string, accept bool) error {
+	u := ui.FromContext(ctx)
+	docID := strings.TrimSpace(rawDocID)
+	if docID == "" {
+		return usage("empty docId")
+	}
+	if all == (len(ids) > 0) {
+		return usage("pass suggestion IDs or --all (not both)")
+	}
+
+	op := "reject"
+	if accept {
+		op = "accept"
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	if tabID, err = resolveDocsTabID(ctx, svc, docID, tabID); err != nil {
+		return err
+	}
+
+	doc, body, err := loadDocsSuggestionsBody(ctx, svc, docID, tabID)
+	if err != nil {
+		return err
+	}
+
+	selected, skipped, err := selectDocsSuggestions(collectDocsSuggestions(body), ids, all)
+	if err != nil {
+		return err
+	}
+	reqs := buildDocsSuggestionRequests(selected, accept, tabID, docsBodyEndIndex(body))
+
+	if dryRunErr := dryRunExit(ctx, flags, "docs.suggestions."+op, map[string]any{
+		"documentId":  docID,
+		"suggestions": selected,
+		"requests":    reqs,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	if len(reqs) > 0 {
+		_, err = svc.Documents.BatchUpdate(docID, &docs.BatchUpdateDocumentRequest{
+			Requests:     reqs,
+			WriteControl: &docs.WriteControl{RequiredRevisionId: doc.RevisionId},
+		}).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("%s suggestions: %w", op, err)
+		}
+	}
+
+	applied := make([]string, 0, len(selected))
+	for _, s := range selected {
+		applied = append(applied, s.ID)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId": docID,
+			"action":     op,
+			"applied":    applied,
+			"skipped":    skipped,
+			"requests":   len(reqs),
+		})
+	}
+
+	u.Out().Printf("documentId\t%s", docID)
+	u.Out().Printf("action\t%s", op)
+	u.Out().Printf("applied\t%d", len(applied))
+	for _, s := range skipped {
+		u.Err().Printf("skipped %s: style suggestions cannot be resolved via the Docs API", s)
+	}
+	return nil
+}
+
+func loadDocsSuggestionsBody(ctx context.Context, svc *docs.Service, docID, tabID string) (*docs.Document, *docs.Body, error) {
+	call := svc.Documents.Get(docID).SuggestionsViewMode(docsSuggestionsInline).Context(ctx)
+	if tabID != "" {
+		call = call.IncludeTabsContent(true)
+	}
+	doc, err := call.Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return nil, nil, fmt.Errorf("doc not found or not a Google Doc (id=%s)", docID)
+		}
+		return nil, nil, err
+	}
+	if doc == nil {
+		return nil, nil, errors.New("doc not found")
+	}
+	if tabID == "" {
+		return doc, doc.Body, nil
+	}
+
+	tab := findTabByID(flattenTabs(doc.Tabs), tabID)
+	if tab == nil {
+		return nil, nil, fmt.Errorf("tab not found: %s", tabID)
+	}
+	if tab.DocumentTab == nil || tab.DocumentTab.Body == nil {
+		return nil, nil, fmt.Errorf("tab has no document body: %s", tabID)
+	}
+	return doc, tab.DocumentTab.Body, nil
+}
+
+// collectDocsSuggestions walks the body (including table cells) and groups
+// suggested text runs by suggestion ID, ordered by first appearance.
+func collectDocsSuggestions(body *docs.Body) []*docsSuggestion {
+	byID := map[string]*docsSuggestion{}
+	var order []string
+
+	add := func(id, kind string, run *docs.ParagraphElement) {
+		s, ok := byID[id]
+		if !ok {
+			s = &docsSuggestion{ID: id, Kind: kind, Start: run.StartIndex}
+			byID[id] = s
+			order = append(order, id)
+		}
+		s.Text += run.TextRun.Content
+		s.End = run.EndIndex
+		if n := len(s.Ranges); n > 0 && s.Ranges[n-1].endIndex == run.StartIndex {
+			s.Ranges[n-1].endIndex = run.EndIndex
+		} else {
+			s.Ranges = append(s.Ranges, docRange{startIndex: run.StartIndex, endIndex: run.EndIndex})
+		}
+	}
+
+	var walk func([]*docs.StructuralElement)
+	walk = func(elements []*docs.StructuralElement) {
+		for _, el := range elements {
+			if el == nil {
+				continue
+			}
+			switch {
+			case el.Paragraph != nil:
+				for _, pe := range el.Paragraph.Elements {
+					if pe == nil || pe.TextRun == nil {
+						continue
+					}
+					for _, id := range pe.TextRun.SuggestedInsertionIds {
+						add(id, docsSuggestionInsertion, pe)
+					}
+					for _, id := range pe.TextRun.SuggestedDeletionIds {
+						add(id, docsSuggestionDeletion, pe)
+					}
+					for id := range pe.TextRun.SuggestedTextStyleChanges {
+						add(id, docsSuggestionStyle, pe)
+					}
+				}
+			case el.Table != nil:
+				for _, row := range el.Table.TableRows {
+					for _, cell := range row.TableCells {
+						walk(cell.Content)
+					}
+				}
+			}
+		}
+	}
+	if body != nil {
+		walk(body.Content)
+	}
+
+	out := make([]*docsSuggestion, 0, len(order))
+	for _, id := range order {
+		out = append(out, byID[id])
+	}
+	return out
+}
+
+func selectDocsSuggestions(all []*docsSuggestion, ids []string, selectAll bool) ([]*docsSuggestion, []string, error) {
+	byID := make(map[string]*docsSuggestion, len(all))
+	for _, s := range all {
+		byID[s.ID] = s
+	}
+
+	var candidates []*docsSuggestion
+	if selectAll {
+		candidates = all
+	} else {
+		var missing []string
+		for _, raw := range ids {
+			id := strings.TrimSpace(raw)
+			if s, ok := byID[id]; ok {
+				candidates = append(candidates, s)
+			} else if id != "" {
+				missing = append(missing, id)
+			}
+		}
+		if len(missing) > 0 {
+			return nil, nil, usagef("unknown suggestion id(s): %s", strings.Join(missing, ", "))
+		}
+	}
+
+	var selected []*docsSuggestion
+	skipped := []string{}
+	for _, s := range candidates {
+		if s.Kind == docsSuggestionStyle {
+			skipped = append(skipped, s.ID)
+			continue
+		}
+		selected = append(selected, s)
+	}
+	return selected, skipped, nil
+}
+
+// buildDocsSuggestionRequests translates accept/reject into plain edits.
+// The Docs API has no native accept/reject, so:
+//   - accept deletion / reject insertion: delete the suggested range
+//   - accept insertion / reject deletion: delete the range and re-insert its
+//     text as a normal (non-suggested) edit; character styling is not kept
+//
+// A run can carry several suggestions (e.g. an insertion that another
+// suggestion deletes), so overlapping ranges are merged into one delete, and
+// a character is re-inserted only when none of the suggestions covering it
+// removes it. Ranges are applied from the end of the document backwards so
+// earlier indexes stay valid within the single batch. The segment's final
+// newline can never be deleted, so ranges reaching bodyEnd are clipped
+// before it.
+func buildDocsSuggestionRequests(selected []*docsSuggestion, accept bool, tabID string, bodyEnd int64) []*docs.Request {
+	var edits []docsSuggestionEdit
+	for _, s := range selected {
+		keepText := (s.Kind == docsSuggestionInsertion) == accept
+		for _, r := range s.Ranges {
+			e := docsSuggestionEdit{r: r, keep: keepText, units: utf16.Encode([]rune(docsSuggestionRangeText(s, r)))}
+			if bodyEnd > 0 && e.r.endIndex >= bodyEnd {
+				e.r.endIndex = bodyEnd - 1
+			}
+			if e.r.endIndex <= e.r.startIndex {
+				continue
+			}
+			edits = append(edits, e)
+		}
+	}
+
+	merged := mergeDocsSuggestionEdits(edits)
+	reqs := make([]*docs.Request, 0, len(merged)*2)
+	for i := len(merged) - 1; i >= 0; i-- {
+		m := merged[i]
+		reqs = append(reqs, &docs.Request{DeleteContentRange: &docs.DeleteContentRangeRequest{
+			Range: &docs.Range{StartIndex: m.r.startIndex, EndIndex: m.r.endIndex, TabId: tabID},
+		}})
+		if m.reinsert != "" {
+			reqs = append(reqs, &docs.Request{InsertText: &docs.InsertTextRequest{
+				Location: &docs.Location{Index: m.r.startIndex, TabId: tabID},
+				Text:     m.reinsert,
+			}})
+		}
+	}
+	return reqs
+}
+
+// docsSuggestionEdit is one suggested range with its UTF-16 text and
+// whether resolving the suggestion keeps that text.
+type docsSuggestionEdit struct {
+	r     docRange
+	units []uint16
+	keep  bool
+}
+
+type docsMergedSuggestionEdit struct {
+	r        docRange
+	reinsert string
+}
+
+// mergeDocsSuggestionEdits groups overlapping edits, in document order, into
+// one range each along with the text to re-insert there.
+func mergeDocsSuggestionEdits(edits []docsSuggestionEdit) []docsMergedSuggestionEdit {
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].r.startIndex < edits[j].r.startIndex })
+
+	var out []docsMergedSuggestionEdit
+	for i := 0; i < len(edits); {
+		group := docRange{startIndex: edits[i].r.startIndex, endIndex: edits[i].r.endIndex}
+		j := i + 1
+		for ; j < len(edits) && edits[j].r.startIndex < group.endIndex; j++ {
+			group.endIndex = max(group.endIndex, edits[j].r.endIndex)
+		}
+
+		size := group.endIndex - group.startIndex
+		units := make([]uint16, size)
+		known := make([]bool, size)
+		drop := make([]bool, size)
+		for _, e := range edits[i:j] {
+			offset := e.r.startIndex - group.startIndex
+			for k := int64(0); k < e.r.endIndex-e.r.startIndex; k++ {
+				if k < int64(len(e.units)) {
+					units[offset+k], known[offset+k] = e.units[k], true
+				}
+				if !e.keep {
+					drop[offset+k] = true
+				}
+			}
+		}
+		kept := make([]uint16, 0, size)
+		for k := range units {
+			if known[k] && !drop[k] {
+				kept = append(kept, units[k])
+			}
+		}
+		out = append(out, docsMergedSuggestionEdit{r: group, reinsert: string(utf16.Decode(kept))})
+		i = j
+	}
+	return out
+}
+
+// docsSuggestionRangeText returns the slice of s.Text covered by r, using
+// UTF-16 offsets like the Docs API indexes.
+func docsSuggestionRangeText(s *docsSuggestion, r docRange) string {
+	var offset int64
+	for _, prev := range s.Ranges {
+		if prev == r {
+			break
+		}
+		offset += prev.endIndex - prev.startIndex
+	}
+	units := utf16.Encode([]rune(s.Text))
+	end := offset + (r.endIndex - r.startIndex)
+	if offset < 0 || end > int64(len(units)) {
+		return ""
+	}
+	return string(utf16.Decode(units[offset:end]))
+}
+
+func docsBodyEndIndex(body *docs.Body) int64 {
+	if body == nil || len(body.Content) == 0 {
+		return 0
+	}
+	last := body.Content[len(body.Content)-1]
+	if last == nil {
+		return 0
+	}
+	return last.EndIndex
+}
+
+func docsSuggestionPreview(text string) string {
+	text = strings.ReplaceAll(strings.TrimRight(text, "\n"), "\n", " ⏎ ")
+	const limit = 60
+	if r := []rune(text); len(r) > limit {
+		return string(r[:limit]) + "…"
+	}
+	return text
+}
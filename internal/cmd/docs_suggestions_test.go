@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+// suggestionsDocBody is "Hello brave new world\n" where "brave " is a
+// suggested insertion (ins1) and "new " a suggested deletion (del1).
+func suggestionsDocBody() map[string]any {
+	run := func(start, end int, text string, extra map[string]any) map[string]any {
+		tr := map[string]any{"content": text}
+		for k, v := range extra {
+			tr[k] = v
+		}
+		return map[string]any{"startIndex": start, "endIndex": end, "textRun": tr}
+	}
+	return map[string]any{
+		"documentId": "doc1",
+		"revisionId": "rev-1",
+		"body": map[string]any{
+			"content": []any{
+				map[string]any{"startIndex": 0, "endIndex": 1, "sectionBreak": map[string]any{}},
+				map[string]any{
+					"startIndex": 1,
+					"endIndex":   23,
+					"paragraph": map[string]any{
+						"elements": []any{
+							run(1, 7, "Hello ", nil),
+							run(7, 13, "brave ", map[string]any{"suggestedInsertionIds": []string{"ins1"}}),
+							run(13, 17, "new ", map[string]any{"suggestedDeletionIds": []string{"del1"}}),
+							run(17, 23, "world\n", nil),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newSuggestionsDocsService(t *testing.T, got *docs.BatchUpdateDocumentRequest) {
+	t.Helper()
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/documents/"):
+			if mode := r.URL.Query().Get("suggestionsViewMode"); mode != docsSuggestionsInline {
+				t.Fatalf("expected inline suggestions view, got %q", mode)
+			}
+			_ = json.NewEncoder(w).Encode(suggestionsDocBody())
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+				t.Fatalf("decode batchUpdate: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"documentId": "doc1"})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	t.Cleanup(cleanup)
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+}
+
+func TestDocsSuggestionsList_JSON(t *testing.T) {
+	var got docs.BatchUpdateDocumentRequest
+	newSuggestionsDocsService(t, &got)
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DocsSuggestionsListCmd{}, []string{"doc1"}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+
+	var payload struct {
+		Suggestions []docsSuggestion `json:"suggestions"`
+	}
+	if err := json.Unmarshal([]byte(out), &payload); err != nil {
+		t.Fatalf("decode %q: %v", out, err)
+	}
+	if len(payload.Suggestions) != 2 {
+		t.Fatalf("expected 2 suggestions, got %#v", payload.Suggestions)
+	}
+	ins, del := payload.Suggestions[0], payload.Suggestions[1]
+	if ins.ID != "ins1" || ins.Kind != docsSuggestionInsertion || ins.Text != "brave " || ins.Start != 7 || ins.End != 13 {
+		t.Fatalf("unexpected insertion: %#v", ins)
+	}
+	if del.ID != "del1" || del.Kind != docsSuggestionDeletion || del.Text != "new " {
+		t.Fatalf("unexpected deletion: %#v", del)
+	}
+}
+
+func TestDocsSuggestionsAccept_All(t *testing.T) {
+	var got docs.BatchUpdateDocumentRequest
+	newSuggestionsDocsService(t, &got)
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DocsSuggestionsAcceptCmd{}, []string{"doc1", "--all"}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("accept: %v", err)
+		}
+	})
+
+	if got.WriteControl == nil || got.WriteControl.RequiredRevisionId != "rev-1" {
+		t.Fatalf("expected revision guard, got %#v", got.WriteControl)
+	}
+	// Deletion (13-17) is applied first, then insertion (7-13) is rewritten as plain text.
+	if len(got.Requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(got.Requests))
+	}
+	if r := got.Requests[0].DeleteContentRange; r == nil || r.Range.StartIndex != 13 || r.Range.EndIndex != 17 {
+		t.Fatalf("unexpected first request: %#v", got.Requests[0])
+	}
+	if r := got.Requests[1].DeleteContentRange; r == nil || r.Range.StartIndex != 7 || r.Range.EndIndex != 13 {
+		t.Fatalf("unexpected second request: %#v", got.Requests[1])
+	}
+	if r := got.Requests[2].InsertText; r == nil || r.Location.Index != 7 || r.Text != "brave " {
+		t.Fatalf("unexpected third request: %#v", got.Requests[2])
+	}
+}
+
+func TestDocsSuggestionsReject_ByID(t *testing.T) {
+	var got docs.BatchUpdateDocumentRequest
+	newSuggestionsDocsService(t, &got)
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DocsSuggestionsRejectCmd{}, []string{"doc1", "ins1"}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("reject: %v", err)
+		}
+	})
+
+	if len(got.Requests) != 1 {
+		t.Fatalf("expected 1 request, got %#v", got.Requests)
+	}
+	if r := got.Requests[0].DeleteContentRange; r == nil || r.Range.StartIndex != 7 || r.Range.EndIndex != 13 {
+		t.Fatalf("unexpected request: %#v", got.Requests[0])
+	}
+}
+
+func TestDocsSuggestionsReject_Validation(t *testing.T) {
+	var got docs.BatchUpdateDocumentRequest
+	newSuggestionsDocsService(t, &got)
+
+	if err := runKong(t, &DocsSuggestionsRejectCmd{}, []string{"doc1"}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"}); err == nil {
+		t.Fatalf("expected error without IDs or --all")
+	}
+	if err := runKong(t, &DocsSuggestionsRejectCmd{}, []string{"doc1", "nope"}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"}); err == nil || !strings.Contains(err.Error(), "nope") {
+		t.Fatalf("expected unknown id error, got %v", err)
+	}
+}
+
+func TestBuildDocsSuggestionRequests_KeepsFinalNewline(t *testing.T) {
+	s := &docsSuggestion{ID: "x", Kind: docsSuggestionInsertion, Text: "tail\n", Ranges: []docRange{{startIndex: 10, endIndex: 15}}}
+	reqs := buildDocsSuggestionRequests([]*docsSuggestion{s}, true, "", 15)
+	if len(reqs) != 2 {
+		t.Fatalf("expected delete+insert, got %#v", reqs)
+	}
+	if reqs[0].DeleteContentRange.Range.EndIndex != 14 || reqs[1].InsertText.Text != "tail" {
+		t.Fatalf("expected final newline preserved, got %#v %#v", reqs[0].DeleteContentRange.Range, reqs[1].InsertText)
+	}
+}
+
+func TestBuildDocsSuggestionRequests_MergesOverlappingSuggestions(t *testing.T) {
+	// "world" was suggested as an insertion by a and then suggested for
+	// deletion by b; c and d are insertions that share one character.
+	a := &docsSuggestion{ID: "a", Kind: docsSuggestionInsertion, Text: "hello world", Ranges: []docRange{{startIndex: 10, endIndex: 21}}}
+	b := &docsSuggestion{ID: "b", Kind: docsSuggestionDeletion, Text: "world", Ranges: []docRange{{startIndex: 16, endIndex: 21}}}
+	c := &docsSuggestion{ID: "c", Kind: docsSuggestionInsertion, Text: "abc", Ranges: []docRange{{startIndex: 2, endIndex: 5}}}
+	d := &docsSuggestion{ID: "d", Kind: docsSuggestionInsertion, Text: "cde", Ranges: []docRange{{startIndex: 4, endIndex: 7}}}
+
+	reqs := buildDocsSuggestionRequests([]*docsSuggestion{a, b, c, d}, true, "", 100)
+	if len(reqs) != 4 {
+		t.Fatalf("expected one delete+insert per merged range, got %d requests", len(reqs))
+	}
+	if r := reqs[0].DeleteContentRange.Range; r.StartIndex != 10 || r.EndIndex != 21 || reqs[1].InsertText.Text != "hello " {
+		t.Fatalf("unexpected first edit: %#v %#v", r, reqs[1].InsertText)
+	}
+	if r := reqs[2].DeleteContentRange.Range; r.StartIndex != 2 || r.EndIndex != 7 || reqs[3].InsertText.Text != "abcde" {
+		t.Fatalf("unexpected second edit: %#v %#v", r, reqs[3].InsertText)
+	}
+
+	// Rejecting a removes its text even though rejecting b would keep it.
+	reqs = buildDocsSuggestionRequests([]*docsSuggestion{a, b}, false, "", 100)
+	if len(reqs) != 1 || reqs[0].DeleteContentRange.Range.StartIndex != 10 || reqs[0].DeleteContentRange.Range.EndIndex != 21 {
+		t.Fatalf("expected a single delete of the merged range, got %#v", reqs)
+	}
+}
@@ -191,3 +191,47 @@ func TestDocsEditingCommands_WithTabID(t *testing.T) {
 		t.Fatalf("unexpected tabs criteria: %#v", req)
 	}
 }
+
+func TestDocsTabTitleAddressing(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	var batchRequests [][]*docs.Request
+	docSvc, cleanup := newDocsServiceForTest(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && strings.Contains(r.URL.Path, ":batchUpdate") {
+			var req docs.BatchUpdateDocumentRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			batchRequests = append(batchRequests, req.Requests)
+			_ = json.NewEncoder(w).Encode(map[string]any{"documentId": "doc1"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(tabsDocWithEndIndex())
+	}))
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := newDocsCmdContext(t)
+
+	if err := runKong(t, &DocsInsertCmd{}, []string{"doc1", "hi", "--index", "3", "--tab", "second"}, ctx, flags); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if got := batchRequests[0][0].InsertText.Location; got.TabId != "t.second" || got.Index != 3 {
+		t.Fatalf("unexpected insert location: %#v", got)
+	}
+
+	if err := runKong(t, &DocsClearCmd{}, []string{"doc1", "--tab", "Second"}, ctx, flags); err != nil {
+		t.Fatalf("clear: %v", err)
+	}
+	if got := batchRequests[1][0].DeleteContentRange.Range; got.TabId != "t.second" || got.StartIndex != 1 || got.EndIndex != 19 {
+		t.Fatalf("unexpected clear range: %#v", got)
+	}
+
+	err := runKong(t, &DocsWriteCmd{}, []string{"doc1", "--text", "x", "--tab", "Notes"}, ctx, flags)
+	if err == nil || !strings.Contains(err.Error(), "tab not found: Notes") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -5,12 +5,17 @@ import (
 	"fmt"
 
 	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/pkg/docsmd"
 )
 
 // TableInserter handles multi-step table insertion for native Google Docs tables
 type TableInserter struct {
 	svc   *docs.Service
 	docID string
+	// plainHeader leaves the first row unbolded, for tables copied from a
+	// document that already has its own header formatting.
+	plainHeader bool
 }
 
 func NewTableInserter(svc *docs.Service, docID string) *TableInserter {
@@ -85,12 +90,12 @@ func (ti *TableInserter) InsertNativeTable(ctx context.Context, tableIndex int64
 
 			// Make text bold if it's a header row
 			var boldReq *docs.Request
-			if rowIdx == 0 {
+			if rowIdx == 0 && !ti.plainHeader {
 				boldReq = &docs.Request{
 					UpdateTextStyle: &docs.UpdateTextStyleRequest{
 						Range: &docs.Range{
 							StartIndex: cellIdx,
-							EndIndex:   cellIdx + utf16Len(cellContent),
+							EndIndex:   cellIdx + docsmd.UTF16Len(cellContent),
 						},
 						TextStyle: &docs.TextStyle{
 							Bold: true,
@@ -113,7 +118,7 @@ func (ti *TableInserter) InsertNativeTable(ctx context.Context, tableIndex int64
 			}
 
 			// Update indices for subsequent cells (they shift by the content length)
-			ti.updateIndicesAfter(cellIdx, utf16Len(cellContent), cellIndices, &tableEndIndex)
+			ti.updateIndicesAfter(cellIdx, docsmd.UTF16Len(cellContent), cellIndices, &tableEndIndex)
 		}
 	}
 
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type DocsTemplateCmd struct {
+	TemplateID string   `arg:"" name:"templateDocId" help:"Template Google Doc ID"`
+	Title      string   `name:"title" required:"" help:"Title for the new doc"`
+	Data       string   `name:"data" help:"JSON file with merge field values, e.g. {\"name\": \"Jane\"}" type:"existingfile"`
+	Set        []string `name:"set" help:"Merge field in format 'key=value' (repeatable, overrides --data)"`
+	Parent     string   `name:"parent" help:"Destination folder ID"`
+	Exact      bool     `name:"exact" help:"Use exact string matching instead of {{key}} placeholders"`
+}
+
+func (c *DocsTemplateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	templateID := normalizeGoogleID(strings.TrimSpace(c.TemplateID))
+	if templateID == "" {
+		return usage("empty templateDocId")
+	}
+	title := strings.TrimSpace(c.Title)
+	if title == "" {
+		return usage("empty --title")
+	}
+
+	fields, err := parseTemplateReplacements(c.Data, c.Set)
+	if err != nil {
+		return err
+	}
+	if len(fields) == 0 {
+		return usage("no merge fields specified (use --data or --set)")
+	}
+
+	parent := normalizeGoogleID(strings.TrimSpace(c.Parent))
+	if err := dryRunExit(ctx, flags, "docs.template", map[string]any{
+		"template_id": templateID,
+		"title":       title,
+		"parent":      parent,
+		"exact":       c.Exact,
+		"fields":      fields,
+	}); err != nil {
+		return err
+	}
+
+	_, driveSvc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	docsSvc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+
+	f := &drive.File{Name: title}
+	if parent != "" {
+		f.Parents = []string{parent}
+	}
+	created, err := driveSvc.Files.Copy(templateID, f).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType, webViewLink").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return fmt.Errorf("failed to copy template: %w", err)
+	}
+	if created == nil {
+		return errors.New("template copy failed")
+	}
+	if created.MimeType != driveMimeGoogleDoc {
+		return fmt.Errorf("template is not a Google Doc (got %s)", created.MimeType)
+	}
+
+	keys, requests := buildDocsTemplateRequests(fields, c.Exact)
+	resp, err := docsSvc.Documents.BatchUpdate(created.Id, &docs.BatchUpdateDocumentRequest{
+		Requests: requests,
+	}).Context(ctx).Do()
+	if err != nil {
+		u.Err().Printf("Warning: doc created but merge failed: %v", err)
+		u.Err().Printf("Doc ID: %s", created.Id)
+		return fmt.Errorf("merge failed: %w", err)
+	}
+
+	counts := make(map[string]int64, len(keys))
+	for i, key := range keys {
+		counts[key] = 0
+		if i < len(resp.Replies) && resp.Replies[i] != nil && resp.Replies[i].ReplaceAllText != nil {
+			counts[key] = resp.Replies[i].ReplaceAllText.OccurrencesChanged
+		}
+	}
+
+	link := created.WebViewLink
+	if link == "" {
+		link = docsWebViewLink(created.Id)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"documentId":   created.Id,
+			"name":         created.Name,
+			"link":         link,
+			"replacements": counts,
+		})
+	}
+
+	u.Out().Printf("id\t%s", created.Id)
+	u.Out().Printf("name\t%s", created.Name)
+	if link != "" {
+		u.Out().Printf("link\t%s", link)
+	}
+	for _, key := range keys {
+		if counts[key] == 0 {
+			u.Err().Printf("Warning: placeholder %s not found", templateReplacementSearchText(key, c.Exact))
+		}
+	}
+	return nil
+}
+
+// buildDocsTemplateRequests returns one ReplaceAllText request per merge
+// field, in sorted key order so replies can be matched back to keys.
+func buildDocsTemplateRequests(fields map[string]string, exact bool) ([]string, []*docs.Request) {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	requests := make([]*docs.Request, 0, len(keys))
+	for _, key := range keys {
+		requests = append(requests, &docs.Request{
+			ReplaceAllText: &docs.ReplaceAllTextRequest{
+				ContainsText: &docs.SubstringMatchCriteria{
+					Text:      templateReplacementSearchText(key, exact),
+					MatchCase: true,
+				},
+				ReplaceText: fields[key],
+			},
+		})
+	}
+	return keys, requests
+}
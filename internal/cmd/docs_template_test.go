@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestDocsTemplateCmd_JSON(t *testing.T) {
+	origDrive := newDriveService
+	origDocs := newDocsService
+	t.Cleanup(func() {
+		newDriveService = origDrive
+		newDocsService = origDocs
+	})
+
+	var copied drive.File
+	driveSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/files/tmpl1/copy") {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&copied)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":       "doc2",
+			"name":     copied.Name,
+			"mimeType": driveMimeGoogleDoc,
+		})
+	}))
+	defer driveSrv.Close()
+	driveSvc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(driveSrv.Client()),
+		option.WithEndpoint(driveSrv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return driveSvc, nil }
+
+	var batch docs.BatchUpdateDocumentRequest
+	var batchPath string
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		batchPath = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"documentId": "doc2",
+			"replies": []any{
+				map[string]any{"replaceAllText": map[string]any{"occurrencesChanged": 2}},
+				map[string]any{"replaceAllText": map[string]any{}},
+				map[string]any{"replaceAllText": map[string]any{"occurrencesChanged": 1}},
+			},
+		})
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	data := filepath.Join(t.TempDir(), "data.json")
+	if err := os.WriteFile(data, []byte(`{"name":"Jane","salary":95000,"start":"May 1"}`), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+	out := captureStdout(t, func() {
+		args := []string{"tmpl1", "--title", "Offer – Jane", "--data", data, "--set", "start=June 1", "--parent", "folder1"}
+		if err := runKong(t, &DocsTemplateCmd{}, args, ctx, flags); err != nil {
+			t.Fatalf("template: %v", err)
+		}
+	})
+
+	if copied.Name != "Offer – Jane" || len(copied.Parents) != 1 || copied.Parents[0] != "folder1" {
+		t.Fatalf("unexpected copy request: %#v", copied)
+	}
+	if !strings.Contains(batchPath, "doc2:batchUpdate") {
+		t.Fatalf("unexpected batch path %q", batchPath)
+	}
+	if len(batch.Requests) != 3 {
+		t.Fatalf("expected 3 requests in one batch, got %d", len(batch.Requests))
+	}
+	want := [][2]string{{"{{name}}", "Jane"}, {"{{salary}}", "95000"}, {"{{start}}", "June 1"}}
+	for i, w := range want {
+		got := batch.Requests[i].ReplaceAllText
+		if got == nil || got.ContainsText.Text != w[0] || got.ReplaceText != w[1] || !got.ContainsText.MatchCase {
+			t.Fatalf("request %d = %#v, want %q", i, got, w)
+		}
+	}
+
+	var result struct {
+		DocumentID   string           `json:"documentId"`
+		Replacements map[string]int64 `json:"replacements"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if result.DocumentID != "doc2" || result.Replacements["name"] != 2 || result.Replacements["salary"] != 0 {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+}
+
+func TestDocsTemplateCmd_RequiresFields(t *testing.T) {
+	flags := &RootFlags{Account: "a@b.com"}
+	err := runKong(t, &DocsTemplateCmd{}, []string{"tmpl1", "--title", "X"}, newDocsCmdContext(t), flags)
+	if err == nil || !strings.Contains(err.Error(), "no merge fields") {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type DocsWatchCmd struct {
+	DocID     string        `arg:"" name:"docId" help:"Doc ID"`
+	Interval  time.Duration `name:"interval" help:"Polling interval" default:"30s"`
+	MaxEvents int           `name:"max-events" help:"Exit after this many change events (0 = run until interrupted)"`
+	Initial   bool          `name:"initial" help:"Emit an event for the current revision before watching"`
+}
+
+// docsWatchEvent is one JSON line printed by docs watch.
+type docsWatchEvent struct {
+	Type               string `json:"type"`
+	DocumentID         string `json:"documentId"`
+	Title              string `json:"title"`
+	RevisionID         string `json:"revisionId"`
+	PreviousRevisionID string `json:"previousRevisionId,omitempty"`
+	Time               string `json:"time"`
+}
+
+func (c *DocsWatchCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	id := normalizeGoogleID(strings.TrimSpace(c.DocID))
+	if id == "" {
+		return usage("empty docId")
+	}
+	if c.Interval <= 0 {
+		return usage("--interval must be > 0")
+	}
+	if c.MaxEvents < 0 {
+		return usage("--max-events must be >= 0")
+	}
+
+	svc, err := requireDocsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+
+	// Events are JSON lines regardless of --json so they can be piped
+	// straight into other tools.
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetEscapeHTML(false)
+
+	doc, err := fetchDocsWatchState(ctx, svc, id)
+	if err != nil {
+		return err
+	}
+	if c.Initial {
+		if err := enc.Encode(newDocsWatchEvent("initial", doc, "")); err != nil {
+			return err
+		}
+	}
+
+	events := 0
+	timer := time.NewTimer(c.Interval)
+	defer timer.Stop()
+	for c.MaxEvents == 0 || events < c.MaxEvents {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+		timer.Reset(c.Interval)
+
+		next, pollErr := fetchDocsWatchState(ctx, svc, id)
+		if pollErr != nil {
+			// Keep watching through transient failures; the next poll retries.
+			u.Err().Printf("poll failed: %v", pollErr)
+			continue
+		}
+		if next.RevisionId == doc.RevisionId {
+			continue
+		}
+		if err := enc.Encode(newDocsWatchEvent("change", next, doc.RevisionId)); err != nil {
+			return err
+		}
+		doc = next
+		events++
+	}
+	return nil
+}
+
+func fetchDocsWatchState(ctx context.Context, svc *docs.Service, id string) (*docs.Document, error) {
+	doc, err := svc.Documents.Get(id).Fields("documentId,title,revisionId").Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return nil, fmt.Errorf("doc not found or not a Google Doc (id=%s)", id)
+		}
+		return nil, err
+	}
+	return doc, nil
+}
+
+func newDocsWatchEvent(kind string, doc *docs.Document, previous string) docsWatchEvent {
+	return docsWatchEvent{
+		Type:               kind,
+		DocumentID:         doc.DocumentId,
+		Title:              doc.Title,
+		RevisionID:         doc.RevisionId,
+		PreviousRevisionID: previous,
+		Time:               time.Now().UTC().Format(time.RFC3339),
+	}
+}
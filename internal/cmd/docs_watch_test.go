@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestDocsWatchCmd(t *testing.T) {
+	origDocs := newDocsService
+	t.Cleanup(func() { newDocsService = origDocs })
+
+	revisions := []string{"r1", "r1", "r2", "r2", "r3"}
+	polls := 0
+	docSvc, cleanup := newDocsServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		rev := revisions[min(polls, len(revisions)-1)]
+		polls++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"documentId": "doc1", "title": "Plan", "revisionId": rev})
+	})
+	defer cleanup()
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	out := captureStdout(t, func() {
+		args := []string{"doc1", "--interval", "1ms", "--max-events", "2", "--initial"}
+		if err := runKong(t, &DocsWatchCmd{}, args, newDocsCmdContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("watch: %v", err)
+		}
+	})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 events, got %d: %q", len(lines), out)
+	}
+	var events []docsWatchEvent
+	for _, line := range lines {
+		var ev docsWatchEvent
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			t.Fatalf("unmarshal %q: %v", line, err)
+		}
+		events = append(events, ev)
+	}
+	if events[0].Type != "initial" || events[0].RevisionID != "r1" {
+		t.Fatalf("unexpected initial event: %#v", events[0])
+	}
+	if events[1].Type != "change" || events[1].RevisionID != "r2" || events[1].PreviousRevisionID != "r1" {
+		t.Fatalf("unexpected first change: %#v", events[1])
+	}
+	if events[2].RevisionID != "r3" || events[2].PreviousRevisionID != "r2" || events[2].Title != "Plan" {
+		t.Fatalf("unexpected second change: %#v", events[2])
+	}
+}
@@ -9,13 +9,16 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"google.golang.org/api/drive/v3"
 
 	"github.com/steipete/gogcli/internal/googleapi"
-	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/locale"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 var newDriveService = googleapi.NewDrive
@@ -28,6 +31,7 @@ var (
 	// Only treat as "already constrained" when the query contains a real trashed predicate,
 	// not just the word inside a quoted literal (e.g. "name contains 'trashed'").
 	driveTrashedPredicatePattern = regexp.MustCompile(`(?i)\btrashed\b\s*(?:=|!=)\s*(?:true|false)\b`)
+	driveExpiresDurationPattern  = regexp.MustCompile(`^(\d+)([hdw])$`)
 )
 
 const (
@@ -35,6 +39,8 @@ const (
 	driveMimeGoogleSheet   = "application/vnd.google-apps.spreadsheet"
 	driveMimeGoogleSlides  = "application/vnd.google-apps.presentation"
 	driveMimeGoogleDrawing = "application/vnd.google-apps.drawing"
+	driveMimeFolder        = "application/vnd.google-apps.folder"
+	driveMimeShortcut      = "application/vnd.google-apps.shortcut"
 	mimePDF                = "application/pdf"
 	mimeCSV                = "text/csv"
 	mimeDocx               = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
@@ -44,6 +50,7 @@ const (
 	mimeTextPlain          = "text/plain"
 	mimeTextMarkdown       = "text/markdown"
 	mimeHTML               = "text/html"
+	mimeEPUB               = "application/epub+zip"
 	extPDF                 = ".pdf"
 	extCSV                 = ".csv"
 	extXlsx                = ".xlsx"
@@ -53,53 +60,90 @@ const (
 	extTXT                 = ".txt"
 	extMD                  = ".md"
 	extHTML                = ".html"
+	extEPUB                = ".epub"
 	formatAuto             = "auto"
 	driveShareToAnyone     = "anyone"
 	driveShareToUser       = "user"
 	driveShareToDomain     = "domain"
-
-	drivePermRoleReader = "reader"
-	drivePermRoleWriter = "writer"
+	driveShareToGroup      = "group"
+
+	drivePermRoleReader        = "reader"
+	drivePermRoleCommenter     = "commenter"
+	drivePermRoleWriter        = "writer"
+	drivePermRoleFileOrganizer = "fileOrganizer"
+	drivePermRoleOrganizer     = "organizer"
+	drivePermRoleOwner         = "owner"
 )
 
 type DriveCmd struct {
-	Ls          DriveLsCmd          `cmd:"" name:"ls" help:"List files in a folder (default: root)"`
-	Search      DriveSearchCmd      `cmd:"" name:"search" help:"Full-text search across Drive"`
-	Get         DriveGetCmd         `cmd:"" name:"get" help:"Get file metadata"`
-	Download    DriveDownloadCmd    `cmd:"" name:"download" help:"Download a file (exports Google Docs formats)"`
-	Copy        DriveCopyCmd        `cmd:"" name:"copy" help:"Copy a file"`
-	Upload      DriveUploadCmd      `cmd:"" name:"upload" help:"Upload a file"`
-	Mkdir       DriveMkdirCmd       `cmd:"" name:"mkdir" help:"Create a folder"`
-	Delete      DriveDeleteCmd      `cmd:"" name:"delete" help:"Move a file to trash (use --permanent to delete forever)" aliases:"rm,del"`
-	Move        DriveMoveCmd        `cmd:"" name:"move" help:"Move a file to a different folder"`
-	Rename      DriveRenameCmd      `cmd:"" name:"rename" help:"Rename a file or folder"`
-	Share       DriveShareCmd       `cmd:"" name:"share" help:"Share a file or folder"`
-	Unshare     DriveUnshareCmd     `cmd:"" name:"unshare" help:"Remove a permission from a file"`
-	Permissions DrivePermissionsCmd `cmd:"" name:"permissions" help:"List permissions on a file"`
-	URL         DriveURLCmd         `cmd:"" name:"url" help:"Print web URLs for files"`
-	Comments    DriveCommentsCmd    `cmd:"" name:"comments" help:"Manage comments on files"`
-	Drives      DriveDrivesCmd      `cmd:"" name:"drives" help:"List shared drives (Team Drives)"`
+	Ls                DriveLsCmd                `cmd:"" name:"ls" help:"List files in a folder (default: root)"`
+	Search            DriveSearchCmd            `cmd:"" name:"search" help:"Full-text search across Drive"`
+	Get               DriveGetCmd               `cmd:"" name:"get" help:"Get file metadata"`
+	Download          DriveDownloadCmd          `cmd:"" name:"download" help:"Download a file (exports Google Docs formats)"`
+	Thumbnail         DriveThumbnailCmd         `cmd:"" name:"thumbnail" aliases:"thumb" help:"Download a file's thumbnail image (no full download)"`
+	ExportAll         DriveExportAllCmd         `cmd:"" name:"export-all" help:"Export every Doc, Sheet, and Slides file under a folder, with a manifest of results"`
+	Copy              DriveCopyCmd              `cmd:"" name:"copy" help:"Copy a file"`
+	Convert           DriveConvertCmd           `cmd:"" name:"convert" help:"Convert a file to another format as a new Drive file (export, import, or both)"`
+	Upload            DriveUploadCmd            `cmd:"" name:"upload" help:"Upload one or more files"`
+	Sync              DriveSyncCmd              `cmd:"" name:"sync" help:"Sync a local directory with a Drive folder (push, pull, or two-way)"`
+	Verify            DriveVerifyCmd            `cmd:"" name:"verify" help:"Check a local copy of a folder against Drive: missing, modified, and extra files"`
+	Watch             DriveWatchCmd             `cmd:"" name:"watch" help:"Receive push notifications for a file or folder and print them as NDJSON or run a hook"`
+	GenerateIDs       DriveGenerateIDsCmd       `cmd:"" name:"generate-ids" help:"Pre-allocate file IDs for drive upload --id (idempotent retries, references before upload)"`
+	Mkdir             DriveMkdirCmd             `cmd:"" name:"mkdir" help:"Create a folder"`
+	Delete            DriveDeleteCmd            `cmd:"" name:"delete" help:"Move a file to trash (use --permanent to delete forever)" aliases:"rm,del"`
+	Trash             DriveTrashCmd             `cmd:"" name:"trash" help:"List, restore, or empty trashed files"`
+	Dedupe            DriveDedupeCmd            `cmd:"" name:"dedupe" help:"Find duplicate files by checksum and optionally trash all but the newest copy"`
+	Retention         DriveRetentionCmd         `cmd:"" name:"retention" help:"Retention policies: trash old files and prune revisions per rules in a YAML file"`
+	CleanupEmpty      DriveCleanupEmptyCmd      `cmd:"" name:"cleanup-empty" help:"Find folders with no files at any depth and optionally trash them"`
+	Move              DriveMoveCmd              `cmd:"" name:"move" help:"Move a file to a different folder"`
+	Rename            DriveRenameCmd            `cmd:"" name:"rename" help:"Rename a file or folder"`
+	Star              DriveStarCmd              `cmd:"" name:"star" help:"Star files"`
+	Unstar            DriveUnstarCmd            `cmd:"" name:"unstar" help:"Remove the star from files"`
+	Share             DriveShareCmd             `cmd:"" name:"share" help:"Share a file or folder"`
+	Unshare           DriveUnshareCmd           `cmd:"" name:"unshare" help:"Remove a permission from a file"`
+	Permissions       DrivePermissionsCmd       `cmd:"" name:"permissions" help:"List and manage permissions on a file"`
+	TransferOwnership DriveTransferOwnershipCmd `cmd:"" name:"transfer-ownership" aliases:"chown" help:"Transfer ownership of a file or folder tree to another user (direct within a Workspace domain, pending acceptance otherwise)"`
+	Revisions         DriveRevisionsCmd         `cmd:"" name:"revisions" aliases:"history" help:"Version history: list, download, or keep revisions"`
+	Changes           DriveChangesCmd           `cmd:"" name:"changes" help:"Incremental change feed as NDJSON (resumes from a stored page token)"`
+	Transcripts       DriveTranscriptsCmd       `cmd:"" name:"transcripts" help:"Meet recordings and transcripts in a folder: export to text or WebVTT and match calendar events"`
+	Artifacts         DriveArtifactsCmd         `cmd:"" name:"artifacts" help:"Content-addressed artifact store: push and pull files by SHA-256"`
+	Swm               DriveSwmCmd               `cmd:"" name:"swm" aliases:"shared-with-me" help:"Review items shared with me: filter, shortcut into my Drive, or leave shares"`
+	URL               DriveURLCmd               `cmd:"" name:"url" help:"Print web URLs for files"`
+	Comments          DriveCommentsCmd          `cmd:"" name:"comments" help:"Manage comments on files"`
+	Labels            DriveLabelsCmd            `cmd:"" name:"labels" aliases:"label" help:"Drive Labels: list available labels, read a file's labels, apply or remove them"`
+	Props             DrivePropsCmd             `cmd:"" name:"props" aliases:"properties" help:"Get, set, or delete custom file properties and appProperties"`
+	Drives            DriveDrivesCmd            `cmd:"" name:"drives" help:"List shared drives (Team Drives)"`
+	About             DriveAboutCmd             `cmd:"" name:"about" aliases:"quota" help:"Show storage quota usage and the largest files you own"`
 }
 
 type DriveLsCmd struct {
-	Max       int64  `name:"max" aliases:"limit" help:"Max results" default:"20"`
+	Folder    string `arg:"" name:"folderId" optional:"" help:"Folder ID to walk with --tree (same as --parent)"`
+	Max       int64  `name:"max" aliases:"limit" help:"Max results (fetches further pages when larger than --page-size)" default:"20"`
+	PageSize  int64  `name:"page-size" help:"Results per API request (default: --max; capped at 1000)"`
 	Page      string `name:"page" aliases:"cursor" help:"Page token"`
+	OrderBy   string `name:"order-by" aliases:"sort" help:"Sort by: name|modifiedTime|size (default: modifiedTime, newest first). size orders by storage quota used."`
+	Desc      bool   `name:"desc" help:"Sort descending (with --order-by)"`
 	Query     string `name:"query" help:"Drive query filter"`
 	Parent    string `name:"parent" help:"Folder ID to list (default: root)"`
 	All       bool   `name:"all" aliases:"global" help:"List all accessible files (mutually exclusive with --parent)"`
 	AllDrives bool   `name:"all-drives" help:"Include shared drives (default: true; use --no-all-drives for My Drive only)" default:"true" negatable:"_"`
+	Starred   bool   `name:"starred" help:"Only starred files (across Drive unless --parent is set)"`
+	Tree      bool   `name:"tree" help:"Walk subfolders and print an indented hierarchy"`
+	Depth     int    `name:"depth" help:"With --tree, levels of subfolders to descend (0 = unlimited)" default:"0"`
 }
 
 type DriveSearchCmd struct {
-	Query     []string `arg:"" name:"query" help:"Search query"`
-	RawQuery  bool     `name:"raw-query" aliases:"raw" help:"Treat query as Drive query language (pass through; may error if invalid)"`
-	Max       int64    `name:"max" aliases:"limit" help:"Max results" default:"20"`
-	Page      string   `name:"page" aliases:"cursor" help:"Page token"`
-	AllDrives bool     `name:"all-drives" help:"Include shared drives (default: true; use --no-all-drives for My Drive only)" default:"true" negatable:"_"`
+	Query     []string           `arg:"" name:"query" optional:"" help:"Search query (optional with filter flags)"`
+	RawQuery  bool               `name:"raw-query" aliases:"raw" help:"Treat query as Drive query language (pass through; may error if invalid)"`
+	Filters   driveSearchFilters `embed:""`
+	Max       int64              `name:"max" aliases:"limit" help:"Max results" default:"20"`
+	Page      string             `name:"page" aliases:"cursor" help:"Page token"`
+	AllDrives bool               `name:"all-drives" help:"Include shared drives (default: true; use --no-all-drives for My Drive only)" default:"true" negatable:"_"`
 }
 
 type DriveGetCmd struct {
-	FileID string `arg:"" name:"fileId" help:"File ID"`
+	FileID string `arg:"" name:"fileId" optional:"" help:"File ID or /path"`
+	Path   string `name:"path" help:"Drive path instead of a file ID (e.g. \"/My Drive/Projects/Q3/report.docx\" or \"/Shared drives/Team/plan.pdf\")"`
 }
 
 func (c *DriveGetCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -108,15 +152,19 @@ func (c *DriveGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
-	fileID := strings.TrimSpace(c.FileID)
-	if fileID == "" {
-		return usage("empty fileId")
+	ref, err := driveFileRef(c.FileID, c.Path)
+	if err != nil {
+		return err
 	}
 
 	svc, err := newDriveService(ctx, account)
 	if err != nil {
 		return err
 	}
+	fileID, err := resolveDriveID(ctx, svc, ref)
+	if err != nil {
+		return err
+	}
 
 	f, err := svc.Files.Get(fileID).
 		SupportsAllDrives(true).
@@ -134,7 +182,7 @@ func (c *DriveGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u.Out().Printf("id\t%s", f.Id)
 	u.Out().Printf("name\t%s", f.Name)
 	u.Out().Printf("type\t%s", f.MimeType)
-	u.Out().Printf("size\t%s", formatDriveSize(f.Size))
+	u.Out().Printf("size\t%s", humanSize(ctx, f.Size))
 	u.Out().Printf("created\t%s", f.CreatedTime)
 	u.Out().Printf("modified\t%s", f.ModifiedTime)
 	if f.Description != "" {
@@ -148,9 +196,17 @@ func (c *DriveGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveDownloadCmd struct {
-	FileID string         `arg:"" name:"fileId" help:"File ID"`
-	Output OutputPathFlag `embed:""`
-	Format string         `name:"format" help:"Export format for Google Docs files: pdf|csv|xlsx|pptx|txt|png|docx|md (default: inferred)"`
+	FileID   string                 `arg:"" name:"fileId" optional:"" help:"File ID or /path"`
+	Path     string                 `name:"path" help:"Drive path instead of a file ID (e.g. \"/My Drive/Projects/Q3/report.docx\" or \"/Shared drives/Team/plan.pdf\")"`
+	Output   OutputPathFlag         `embed:""`
+	Name     ExportNameTemplateFlag `embed:""`
+	Format   string                 `name:"format" help:"Export format for Google Docs files: pdf|csv|xlsx|pptx|txt|png|docx|md|html|epub (default: inferred)"`
+	Revision string                 `name:"revision" help:"Download this revision ID instead of the head version (see drive revisions)"`
+	At       string                 `name:"at" help:"Download the newest revision saved at or before this time (RFC3339 or YYYY-MM-DD, which means midnight)"`
+	// Folder downloads.
+	Recursive     bool   `name:"recursive" help:"Download a folder and its subfolders into the --out directory"`
+	Zip           string `name:"zip" help:"Download a folder recursively into this zip file"`
+	ExportFormats string `name:"export-formats" help:"Export formats for Google files in folder downloads, e.g. doc=pdf,sheet=csv (defaults: doc=docx, sheet=xlsx, slides=pptx, drawing=png)"`
 }
 
 func (c *DriveDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -160,22 +216,50 @@ func (c *DriveDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	fileID := strings.TrimSpace(c.FileID)
-	if fileID == "" {
-		return usage("empty fileId")
+	ref, err := driveFileRef(c.FileID, c.Path)
+	if err != nil {
+		return err
 	}
 	if formatErr := validateDriveDownloadFormatFlag(c.Format); formatErr != nil {
 		return formatErr
 	}
+	nameTmpl, err := parseExportNameTemplate(c.Name.NameTemplate)
+	if err != nil {
+		return err
+	}
+	folderMode := c.Recursive || strings.TrimSpace(c.Zip) != ""
+	if folderMode && (c.Format != "" || c.Revision != "" || c.At != "" || nameTmpl != nil) {
+		return usage("--recursive/--zip cannot be combined with --format, --revision, --at, or --name-template (use --export-formats)")
+	}
+	revision := strings.TrimSpace(c.Revision)
+	var at time.Time
+	if strings.TrimSpace(c.At) != "" {
+		if revision != "" {
+			return usage("use either --revision or --at, not both")
+		}
+		if at, err = parseDriveRevisionAt(c.At); err != nil {
+			return err
+		}
+	}
+	var asOf time.Time
+	if revision == "" && at.IsZero() {
+		if asOf, err = driveAsOf(flags); err != nil {
+			return err
+		}
+	}
 
 	svc, err := newDriveService(ctx, account)
 	if err != nil {
 		return err
 	}
+	fileID, err := resolveDriveID(ctx, svc, ref)
+	if err != nil {
+		return err
+	}
 
 	meta, err := svc.Files.Get(fileID).
 		SupportsAllDrives(true).
-		Fields("id, name, mimeType").
+		Fields(driveExportNameFields + ", size, md5Checksum, sha256Checksum").
 		Context(ctx).
 		Do()
 	if err != nil {
@@ -184,29 +268,86 @@ func (c *DriveDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if meta.Name == "" {
 		return errors.New("file has no name")
 	}
+	if folderMode {
+		return c.runRecursive(ctx, svc, meta)
+	}
+	if meta.MimeType == driveMimeFolder {
+		return usagef("%s is a folder (use --recursive or --zip)", meta.Name)
+	}
 	if fileFormatErr := validateDriveDownloadFormatForFile(meta, c.Format); fileFormatErr != nil {
 		return fileFormatErr
 	}
 
-	destPath, err := resolveDriveDownloadDestPath(meta, c.Output.Path)
+	var modifiedTime string
+	if !at.IsZero() {
+		rev, revErr := resolveDriveRevisionAt(ctx, svc, meta.Id, at)
+		if revErr != nil {
+			return revErr
+		}
+		revision, modifiedTime = rev.Id, rev.ModifiedTime
+	}
+	if !asOf.IsZero() {
+		rev, revErr := resolveDriveRevisionAsOf(ctx, svc, meta, asOf)
+		if revErr != nil {
+			return revErr
+		}
+		if rev != nil {
+			revision, modifiedTime = rev.Id, rev.ModifiedTime
+		}
+	}
+
+	var destPath string
+	if nameTmpl != nil {
+		destPath, err = resolveTemplatedDestPath(nameTmpl, newExportNameData(meta, revision, c.Format), c.Output.Path)
+	} else {
+		pathMeta := meta
+		if revision != "" {
+			// Keep default filenames distinct from the head download.
+			renamed := *meta
+			renamed.Name = fmt.Sprintf("%s_rev%s", renamed.Name, revision)
+			pathMeta = &renamed
+		}
+		destPath, err = resolveDriveDownloadDestPath(pathMeta, c.Output.Path)
+	}
 	if err != nil {
 		return err
 	}
 
-	downloadedPath, size, err := downloadDriveFile(ctx, svc, meta, destPath, c.Format)
+	var (
+		downloadedPath string
+		size           int64
+	)
+	if revision != "" {
+		downloadedPath, size, err = downloadDriveRevision(ctx, account, svc, meta, revision, destPath, c.Format)
+	} else {
+		downloadedPath, size, err = downloadDriveFile(ctx, svc, meta, destPath, c.Format)
+	}
 	if err != nil {
 		return err
 	}
 
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+		out := map[string]any{
 			"path": downloadedPath,
 			"size": size,
-		})
+		}
+		if revision != "" {
+			out["revision"] = revision
+		}
+		if modifiedTime != "" {
+			out["revisionModifiedTime"] = modifiedTime
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, out)
 	}
 
 	u.Out().Printf("path\t%s", downloadedPath)
-	u.Out().Printf("size\t%s", formatDriveSize(size))
+	u.Out().Printf("size\t%s", humanSize(ctx, size))
+	if revision != "" {
+		u.Out().Printf("revision\t%s", revision)
+	}
+	if modifiedTime != "" {
+		u.Out().Printf("modified\t%s", humanDateTime(ctx, modifiedTime))
+	}
 	return nil
 }
 
@@ -223,14 +364,22 @@ func (c *DriveCopyCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveUploadCmd struct {
-	LocalPath           string `arg:"" name:"localPath" help:"Path to local file"`
-	Name                string `name:"name" help:"Override filename (create) or rename target (replace)"`
-	Parent              string `name:"parent" help:"Destination folder ID (create only)"`
-	ReplaceFileID       string `name:"replace" help:"Replace the content of an existing Drive file ID (preserves shared link/permissions)"`
-	MimeType            string `name:"mime-type" help:"Override MIME type inference"`
-	KeepRevisionForever bool   `name:"keep-revision-forever" help:"Keep the new head revision forever (binary files only)"`
-	Convert             bool   `name:"convert" help:"Auto-convert to native Google format based on file extension (create only)"`
-	ConvertTo           string `name:"convert-to" help:"Convert to a specific Google format: doc|sheet|slides (create only)"`
+	LocalPath           string        `arg:"" name:"localPath" help:"Path to local file"`
+	MorePaths           []string      `arg:"" optional:"" name:"morePaths" help:"More files to upload into the same --parent (bulk upload; checks Drive storage quota first)"`
+	Name                string        `name:"name" help:"Override filename (create) or rename target (replace)"`
+	Parent              string        `name:"parent" help:"Destination folder ID (create only)"`
+	ReplaceFileID       string        `name:"replace" help:"Replace the content of an existing Drive file ID (preserves shared link/permissions)"`
+	FileID              string        `name:"id" help:"Create the file with this pre-allocated ID from drive generate-ids (single file only; a retry with the same ID cannot create a duplicate)"`
+	MimeType            string        `name:"mime-type" aliases:"mime" help:"Override MIME type inference"`
+	KeepRevisionForever bool          `name:"keep-revision-forever" help:"Keep the new head revision forever (binary files only)"`
+	Convert             bool          `name:"convert" help:"Auto-convert to native Google format based on file extension (create only)"`
+	ConvertTo           string        `name:"convert-to" help:"Convert to a specific Google format: doc|sheet|slides (create only)"`
+	ChunkSize           string        `name:"chunk-size" help:"Resumable upload chunk size, e.g. 8MB or 512KB (rounded up to 256KB; 0 sends the file in a single request)" default:"16MB"`
+	ChunkRetry          time.Duration `name:"chunk-retry" help:"How long to keep retrying a failed chunk before giving up" default:"32s"`
+	NoProgress          bool          `name:"no-progress" help:"Hide the upload progress bar (shown on stderr when it is a terminal)"`
+	Recursive           bool          `name:"recursive" help:"Upload a directory tree into a new folder (named after the directory, or --name) under --parent"`
+	Workers             int           `name:"workers" help:"Concurrent file uploads with --recursive" default:"4"`
+	Retries             int           `name:"retries" help:"Retries per file on transient errors with --recursive" default:"3"`
 }
 
 type DriveMkdirCmd struct {
@@ -285,7 +434,8 @@ func (c *DriveMkdirCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveDeleteCmd struct {
-	FileID    string `arg:"" name:"fileId" help:"File ID"`
+	FileID    string `arg:"" name:"fileId" optional:"" help:"File ID or /path"`
+	Path      string `name:"path" help:"Drive path instead of a file ID (e.g. \"/My Drive/Projects/Q3/report.docx\")"`
 	Permanent bool   `name:"permanent" help:"Permanently delete instead of moving to trash" default:"false"`
 }
 
@@ -295,29 +445,51 @@ func (c *DriveDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
-	fileID := strings.TrimSpace(c.FileID)
-	if fileID == "" {
-		return usage("empty fileId")
+	ref, err := driveFileRef(c.FileID, c.Path)
+	if err != nil {
+		return err
 	}
 
 	action := "trash drive file"
 	if c.Permanent {
 		action = "permanently delete drive file"
 	}
-	if confirmErr := dryRunAndConfirmDestructive(ctx, flags, "drive.delete", map[string]any{
-		"file_id":   fileID,
-		"permanent": c.Permanent,
-	}, fmt.Sprintf("%s %s", action, fileID)); confirmErr != nil {
+
+	// A path is resolved (live, never from the name cache) before asking, so
+	// the prompt and --dry-run name the file that will actually go.
+	var svc *drive.Service
+	fileID, target := ref, ref
+	request := map[string]any{"file_id": ref, "permanent": c.Permanent}
+	if isDrivePath(ref) {
+		if svc, err = newDriveService(ctx, account); err != nil {
+			return err
+		}
+		if fileID, err = resolveDriveIDLive(ctx, svc, ref); err != nil {
+			return err
+		}
+		f, getErr := svc.Files.Get(fileID).SupportsAllDrives(true).Fields("id, name").Context(ctx).Do()
+		if getErr != nil {
+			return getErr
+		}
+		target = fmt.Sprintf("%q (%s, at %s)", f.Name, fileID, ref)
+		request["file_id"], request["name"], request["path"] = fileID, f.Name, ref
+	}
+	if confirmErr := dryRunAndConfirmDestructive(ctx, flags, "drive.delete", request, fmt.Sprintf("%s %s", action, target)); confirmErr != nil {
 		return confirmErr
 	}
 
-	svc, err := newDriveService(ctx, account)
-	if err != nil {
-		return err
+	if svc == nil {
+		if svc, err = newDriveService(ctx, account); err != nil {
+			return err
+		}
 	}
 
 	trashed := !c.Permanent
 	deleted := c.Permanent
+	journal := &bulkDestructiveOp{Op: "drive.delete", Action: "trash", Noun: "drive file", Undo: bulkUndoDriveUntrash}
+	if c.Permanent {
+		journal.Action, journal.Undo = "permanently delete", ""
+	}
 
 	if c.Permanent {
 		if err := svc.Files.Delete(fileID).SupportsAllDrives(true).Context(ctx).Do(); err != nil {
@@ -333,6 +505,7 @@ func (c *DriveDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 			return err
 		}
 	}
+	journalBulkDestructive(ctx, account, journal, []bulkItem{{ID: fileID}})
 	return writeResult(ctx, u,
 		kv("trashed", trashed),
 		kv("deleted", deleted),
@@ -341,8 +514,9 @@ func (c *DriveDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveMoveCmd struct {
-	FileID string `arg:"" name:"fileId" help:"File ID"`
-	Parent string `name:"parent" help:"New parent folder ID (required)"`
+	FileID string `arg:"" name:"fileId" optional:"" help:"File ID or /path"`
+	Path   string `name:"path" help:"Drive path instead of a file ID (e.g. \"/My Drive/Projects/Q3/report.docx\")"`
+	Parent string `name:"parent" help:"New parent folder ID or /path (required)"`
 }
 
 func (c *DriveMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -351,9 +525,9 @@ func (c *DriveMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
-	fileID := strings.TrimSpace(c.FileID)
-	if fileID == "" {
-		return usage("empty fileId")
+	ref, err := driveFileRef(c.FileID, c.Path)
+	if err != nil {
+		return err
 	}
 	parent := strings.TrimSpace(c.Parent)
 	if parent == "" {
@@ -364,6 +538,13 @@ func (c *DriveMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	fileID, err := resolveDriveID(ctx, svc, ref)
+	if err != nil {
+		return err
+	}
+	if parent, err = resolveDriveID(ctx, svc, parent); err != nil {
+		return err
+	}
 
 	meta, err := svc.Files.Get(fileID).
 		SupportsAllDrives(true).
@@ -397,7 +578,7 @@ func (c *DriveMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveRenameCmd struct {
-	FileID  string `arg:"" name:"fileId" help:"File ID"`
+	FileID  string `arg:"" name:"fileId" help:"File ID or /path"`
 	NewName string `arg:"" name:"newName" help:"New name"`
 }
 
@@ -420,6 +601,9 @@ func (c *DriveRenameCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	if fileID, err = resolveDriveID(ctx, svc, fileID); err != nil {
+		return err
+	}
 
 	updated, err := svc.Files.Update(fileID, &drive.File{Name: newName}).
 		SupportsAllDrives(true).
@@ -441,12 +625,13 @@ func (c *DriveRenameCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 type DriveShareCmd struct {
 	FileID       string `arg:"" name:"fileId" help:"File ID"`
-	To           string `name:"to" help:"Share target: anyone|user|domain"`
-	Anyone       bool   `name:"anyone" hidden:"" help:"(deprecated) Use --to=anyone"`
-	Email        string `name:"email" help:"User email (for --to=user)"`
+	To           string `name:"to" help:"Share target: anyone|user|group|domain"`
+	Anyone       bool   `name:"anyone" help:"Share with anyone who has the link (same as --to=anyone)"`
+	Email        string `name:"email" help:"User or group email (for --to=user or --to=group)"`
 	Domain       string `name:"domain" help:"Domain (for --to=domain; e.g. example.com)"`
-	Role         string `name:"role" help:"Permission: reader|writer" default:"reader"`
+	Role         string `name:"role" help:"Permission: reader|commenter|writer" default:"reader"`
 	Discoverable bool   `name:"discoverable" help:"Allow file discovery in search (anyone/domain only)"`
+	Expires      string `name:"expires" help:"Expire access after a duration (12h, 7d, 2w) or at a date/time; --to=user or --to=group only"`
 }
 
 func (c *DriveShareCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -496,6 +681,16 @@ func (c *DriveShareCmd) Run(ctx context.Context, flags *RootFlags) error {
 		if c.Discoverable {
 			return usage("--discoverable is only valid for --to=anyone or --to=domain")
 		}
+	case driveShareToGroup:
+		if email == "" {
+			return usage("missing --email for --to=group")
+		}
+		if domain != "" || c.Anyone {
+			return usage("--to=group cannot be combined with --anyone or --domain")
+		}
+		if c.Discoverable {
+			return usage("--discoverable is only valid for --to=anyone or --to=domain")
+		}
 	case driveShareToDomain:
 		if domain == "" {
 			return usage("missing --domain for --to=domain")
@@ -505,14 +700,26 @@ func (c *DriveShareCmd) Run(ctx context.Context, flags *RootFlags) error {
 		}
 	default:
 		// Should be guarded by enum, but keep a friendly message for future changes.
-		return usage("invalid --to (expected anyone|user|domain)")
+		return usage("invalid --to (expected anyone|user|group|domain)")
 	}
 	role := strings.TrimSpace(c.Role)
 	if role == "" {
 		role = drivePermRoleReader
 	}
-	if role != drivePermRoleReader && role != drivePermRoleWriter {
-		return usage("invalid --role (expected reader|writer)")
+	if role != drivePermRoleReader && role != drivePermRoleCommenter && role != drivePermRoleWriter {
+		return usage("invalid --role (expected reader|commenter|writer)")
+	}
+	var expires time.Time
+	if strings.TrimSpace(c.Expires) != "" {
+		expires, err = parseDriveExpiration(c.Expires, time.Now(), time.Local)
+		if err != nil {
+			return err
+		}
+		if to != driveShareToUser && to != driveShareToGroup {
+			// Drive rejects expirationTime on anyone/domain permissions; dropping
+			// it would leave the file shared with no end date.
+			return usagef("Drive cannot expire --to=%s sharing; share with --to=user or --to=group, or drop --expires", to)
+		}
 	}
 	if to == driveShareToAnyone {
 		if confirmErr := confirmDestructive(ctx, flags, fmt.Sprintf("share drive file %s with anyone (public)", fileID)); confirmErr != nil {
@@ -535,14 +742,17 @@ func (c *DriveShareCmd) Run(ctx context.Context, flags *RootFlags) error {
 		perm.Domain = domain
 		perm.AllowFileDiscovery = c.Discoverable
 	default:
-		perm.Type = "user"
+		perm.Type = to
 		perm.EmailAddress = email
+		if !expires.IsZero() {
+			perm.ExpirationTime = expires.UTC().Format(time.RFC3339)
+		}
 	}
 
 	created, err := svc.Permissions.Create(fileID, perm).
 		SupportsAllDrives(true).
 		SendNotificationEmail(false).
-		Fields("id, type, role, emailAddress, domain, allowFileDiscovery").
+		Fields("id, type, role, emailAddress, domain, allowFileDiscovery, expirationTime").
 		Context(ctx).
 		Do()
 	if err != nil {
@@ -564,9 +774,44 @@ func (c *DriveShareCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 	u.Out().Printf("link\t%s", link)
 	u.Out().Printf("permission_id\t%s", created.Id)
+	if created.ExpirationTime != "" {
+		u.Out().Printf("expires\t%s", created.ExpirationTime)
+	}
 	return nil
 }
 
+// parseDriveExpiration accepts a duration from now (12h, 7d, 2w) or a
+// date/time (a bare date means the end of that day). Drive requires the
+// expiry to be in the future and at most a year out.
+func parseDriveExpiration(expr string, now time.Time, loc *time.Location) (time.Time, error) {
+	expr = strings.TrimSpace(expr)
+	var t time.Time
+	if m := driveExpiresDurationPattern.FindStringSubmatch(strings.ToLower(expr)); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2] {
+		case "h":
+			t = now.Add(time.Duration(n) * time.Hour)
+		case "d":
+			t = now.AddDate(0, 0, n)
+		case "w":
+			t = now.AddDate(0, 0, 7*n)
+		}
+	} else {
+		parsed, err := parseTimeExprEndOfDay(expr, now, loc)
+		if err != nil {
+			return time.Time{}, usagef("invalid --expires %q (use 12h, 7d, 2w, or a date like 2026-01-31)", expr)
+		}
+		t = parsed
+	}
+	if !t.After(now) {
+		return time.Time{}, usagef("--expires %q is not in the future", expr)
+	}
+	if t.After(now.AddDate(1, 0, 0)) {
+		return time.Time{}, usagef("--expires %q is more than a year away (Drive's limit)", expr)
+	}
+	return t, nil
+}
+
 type DriveUnshareCmd struct {
 	FileID       string `arg:"" name:"fileId" help:"File ID"`
 	PermissionID string `arg:"" name:"permissionId" help:"Permission ID"`
@@ -607,13 +852,13 @@ func (c *DriveUnshareCmd) Run(ctx context.Context, flags *RootFlags) error {
 	)
 }
 
-type DrivePermissionsCmd struct {
+type DrivePermissionsListCmd struct {
 	FileID string `arg:"" name:"fileId" help:"File ID"`
 	Max    int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
 	Page   string `name:"page" aliases:"cursor" help:"Page token"`
 }
 
-func (c *DrivePermissionsCmd) Run(ctx context.Context, flags *RootFlags) error {
+func (c *DrivePermissionsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u := ui.FromContext(ctx)
 	account, err := requireAccount(flags)
 	if err != nil {
@@ -675,7 +920,7 @@ func (c *DrivePermissionsCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveURLCmd struct {
-	FileIDs []string `arg:"" name:"fileId" help:"File IDs"`
+	FileIDs []string `arg:"" name:"fileId" help:"File IDs or /paths"`
 }
 
 func (c *DriveURLCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -689,6 +934,11 @@ func (c *DriveURLCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	for i, ref := range c.FileIDs {
+		if c.FileIDs[i], err = resolveDriveID(ctx, svc, ref); err != nil {
+			return err
+		}
+	}
 
 	for _, id := range c.FileIDs {
 		link, err := driveWebLink(ctx, svc, id)
@@ -808,6 +1058,12 @@ func formatDateTime(iso string) string {
 }
 
 func formatDriveSize(bytes int64) string {
+	return formatSizeIn(nil, bytes)
+}
+
+// formatSizeIn renders a byte count with binary units; a non-nil locale
+// only changes the digit separators.
+func formatSizeIn(l *locale.Locale, bytes int64) string {
 	if bytes <= 0 {
 		return "-"
 	}
@@ -820,9 +1076,9 @@ func formatDriveSize(bytes int64) string {
 		i++
 	}
 	if i == 0 {
-		return fmt.Sprintf("%d B", bytes)
+		return l.Int(bytes) + " B"
 	}
-	return fmt.Sprintf("%.1f %s", b, units[i])
+	return l.Decimal(b, 1) + " " + units[i]
 }
 
 func guessMimeType(path string) string {
@@ -903,12 +1159,17 @@ func downloadDriveFile(ctx context.Context, svc *drive.Service, meta *drive.File
 		outPath = replaceExt(destPath, driveExportExtension(exportMimeType))
 		resp, err = driveExportDownload(ctx, svc, meta.Id, exportMimeType)
 	} else {
-		outPath = destPath
-		resp, err = driveDownload(ctx, svc, meta.Id)
+		return downloadDriveBlob(ctx, svc, meta, destPath)
 	}
 	if err != nil {
 		return "", 0, err
 	}
+	return writeDriveDownloadResponse(resp, outPath)
+}
+
+// writeDriveDownloadResponse streams a successful download response to
+// outPath and closes the body.
+func writeDriveDownloadResponse(resp *http.Response, outPath string) (string, int64, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
@@ -944,10 +1205,10 @@ func validateDriveDownloadFormatFlag(format string) error {
 		return nil
 	}
 	switch format {
-	case "pdf", "csv", "xlsx", "pptx", "txt", "png", "docx", "md", "html":
+	case "pdf", "csv", "xlsx", "pptx", "txt", "png", "docx", "md", "html", "epub":
 		return nil
 	default:
-		return usagef("invalid --format %q (use pdf|csv|xlsx|pptx|txt|png|docx|md|html)", format)
+		return usagef("invalid --format %q (use pdf|csv|xlsx|pptx|txt|png|docx|md|html|epub)", format)
 	}
 }
 
@@ -1012,8 +1273,10 @@ func driveExportMimeTypeForFormat(googleMimeType string, format string) (string,
 			return mimeTextMarkdown, nil
 		case "html":
 			return mimeHTML, nil
+		case "epub":
+			return mimeEPUB, nil
 		default:
-			return "", fmt.Errorf("invalid --format %q for Google Doc (use pdf|docx|txt|md|html)", format)
+			return "", fmt.Errorf("invalid --format %q for Google Doc (use pdf|docx|txt|md|html|epub)", format)
 		}
 	case driveMimeGoogleSheet:
 		switch format {
@@ -1072,6 +1335,8 @@ func driveExportExtension(mimeType string) string {
 		return extMD
 	case mimeHTML:
 		return extHTML
+	case mimeEPUB:
+		return extEPUB
 	default:
 		return extPDF
 	}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// DriveAboutCmd reports storage quota and the files using the most of it.
+// The Drive API only splits usage into Drive and Drive trash; the rest of
+// the account quota (Gmail and Photos) is reported as "other".
+type DriveAboutCmd struct {
+	Top int64 `name:"top" help:"List the N largest files you own (0 to skip)" default:"10"`
+}
+
+type driveAboutQuota struct {
+	Limit      int64 `json:"limit"`
+	Usage      int64 `json:"usage"`
+	Drive      int64 `json:"usageInDrive"`
+	DriveTrash int64 `json:"usageInDriveTrash"`
+	Other      int64 `json:"usageOther"`
+	Unlimited  bool  `json:"unlimited,omitempty"`
+}
+
+func (c *DriveAboutCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Top < 0 || c.Top > 1000 {
+		return usage("--top must be between 0 and 1000")
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	about, err := svc.About.Get().
+		Fields("user(displayName,emailAddress),storageQuota(limit,usage,usageInDrive,usageInDriveTrash)").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	quota := newDriveAboutQuota(about.StorageQuota)
+
+	largest := []*drive.File{}
+	if c.Top > 0 {
+		resp, listErr := svc.Files.List().
+			Q("'me' in owners and trashed = false").
+			OrderBy("quotaBytesUsed desc").
+			PageSize(c.Top).
+			Fields("files(id, name, mimeType, size, quotaBytesUsed, modifiedTime, webViewLink)").
+			Context(ctx).
+			Do()
+		if listErr != nil {
+			return fmt.Errorf("listing largest files: %w", listErr)
+		}
+		largest = resp.Files
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"user":         about.User,
+			"storageQuota": quota,
+			"largest":      largest,
+		})
+	}
+
+	if about.User != nil {
+		u.Out().Printf("user\t%s", about.User.EmailAddress)
+	}
+	if quota.Unlimited {
+		u.Out().Printf("limit\tunlimited")
+	} else {
+		u.Out().Printf("limit\t%s", humanSize(ctx, quota.Limit))
+	}
+	used := humanSize(ctx, quota.Usage)
+	if !quota.Unlimited && quota.Limit > 0 {
+		used = fmt.Sprintf("%s (%.1f%%)", used, float64(quota.Usage)*100/float64(quota.Limit))
+	}
+	u.Out().Printf("used\t%s", used)
+	u.Out().Printf("drive\t%s", humanSize(ctx, quota.Drive))
+	u.Out().Printf("drive_trash\t%s", humanSize(ctx, quota.DriveTrash))
+	u.Out().Printf("gmail_photos\t%s", humanSize(ctx, quota.Other))
+
+	if len(largest) == 0 {
+		return nil
+	}
+	u.Out().Println("")
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tSIZE\tMODIFIED\tNAME")
+	for _, f := range largest {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", f.Id, humanSize(ctx, f.QuotaBytesUsed), humanDateTime(ctx, f.ModifiedTime), f.Name)
+	}
+	return nil
+}
+
+func newDriveAboutQuota(q *drive.AboutStorageQuota) driveAboutQuota {
+	if q == nil {
+		return driveAboutQuota{Unlimited: true}
+	}
+	return driveAboutQuota{
+		Limit:      q.Limit,
+		Usage:      q.Usage,
+		Drive:      q.UsageInDrive,
+		DriveTrash: q.UsageInDriveTrash,
+		Other:      max(q.Usage-q.UsageInDrive, 0),
+		Unlimited:  q.Limit <= 0,
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDriveAboutCmd(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var listQuery, orderBy, pageSize string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/about":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"user": map[string]any{"emailAddress": "a@b.com"},
+				"storageQuota": map[string]any{
+					"limit": "16106127360", "usage": "9000", "usageInDrive": "6000", "usageInDriveTrash": "500",
+				},
+			})
+		case "/files":
+			listQuery, orderBy, pageSize = r.URL.Query().Get("q"), r.URL.Query().Get("orderBy"), r.URL.Query().Get("pageSize")
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "big", "name": "backup.zip", "quotaBytesUsed": "5000"},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveAboutCmd{}, []string{"--top", "3"}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("about: %v", err)
+		}
+	})
+	var got struct {
+		StorageQuota driveAboutQuota `json:"storageQuota"`
+		Largest      []drive.File    `json:"largest"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if got.StorageQuota.Other != 3000 || got.StorageQuota.DriveTrash != 500 || got.StorageQuota.Unlimited {
+		t.Fatalf("unexpected quota: %+v", got.StorageQuota)
+	}
+	if len(got.Largest) != 1 || got.Largest[0].Id != "big" {
+		t.Fatalf("unexpected largest: %+v", got.Largest)
+	}
+	if !strings.Contains(listQuery, "'me' in owners") || orderBy != "quotaBytesUsed desc" || pageSize != "3" {
+		t.Fatalf("unexpected files query q=%q orderBy=%q pageSize=%q", listQuery, orderBy, pageSize)
+	}
+}
+
+func TestNewDriveAboutQuotaUnlimited(t *testing.T) {
+	if q := newDriveAboutQuota(&drive.AboutStorageQuota{Usage: 10, UsageInDrive: 4}); !q.Unlimited || q.Other != 6 {
+		t.Fatalf("unexpected quota: %+v", q)
+	}
+}
@@ -0,0 +1,374 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// DriveArtifactsCmd treats a Drive folder as an immutable, content-addressed
+// artifact store: each blob is named by its SHA-256, stored once, marked
+// read-only, and listed in an index.json next to it.
+type DriveArtifactsCmd struct {
+	Push DriveArtifactsPushCmd `cmd:"" name:"push" help:"Store files by SHA-256 (content already in the repo is not uploaded again)"`
+	Pull DriveArtifactsPullCmd `cmd:"" name:"pull" help:"Download an artifact by SHA-256 or unique prefix and verify it"`
+	List DriveArtifactsListCmd `cmd:"" name:"list" aliases:"ls" help:"List artifacts in a repo folder"`
+}
+
+const (
+	driveArtifactShaKey   = "gogArtifactSha256"
+	driveArtifactNameKey  = "gogArtifactName"
+	driveArtifactIndexKey = "gogArtifactIndex"
+	driveArtifactIndex    = "index.json"
+	driveArtifactFields   = "id, name, size, sha256Checksum, createdTime, appProperties, webViewLink"
+)
+
+var driveArtifactDigestRe = regexp.MustCompile(`^[0-9a-f]{8,64}$`)
+
+type driveArtifact struct {
+	SHA256  string `json:"sha256"`
+	Name    string `json:"name"`
+	Size    int64  `json:"size"`
+	FileID  string `json:"fileId"`
+	Created string `json:"createdTime,omitempty"`
+}
+
+type DriveArtifactsPushCmd struct {
+	Paths   []string `arg:"" name:"file" help:"Local files to store"`
+	Repo    string   `name:"repo" required:"" help:"Repo folder ID"`
+	NoIndex bool     `name:"no-index" help:"Do not rewrite the repo's index.json"`
+}
+
+type driveArtifactPushResult struct {
+	driveArtifact
+	Status string `json:"status"`
+	Path   string `json:"path"`
+}
+
+func (c *DriveArtifactsPushCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	repo := normalizeGoogleID(strings.TrimSpace(c.Repo))
+	if repo == "" {
+		return usage("empty --repo")
+	}
+
+	results := make([]driveArtifactPushResult, 0, len(c.Paths))
+	for _, p := range c.Paths {
+		path, err := config.ExpandPath(strings.TrimSpace(p))
+		if err != nil {
+			return err
+		}
+		digest, size, err := sha256File(path)
+		if err != nil {
+			return err
+		}
+		results = append(results, driveArtifactPushResult{
+			driveArtifact: driveArtifact{SHA256: digest, Name: filepath.Base(path), Size: size},
+			Path:          path,
+		})
+	}
+	if len(results) == 0 {
+		return usage("no files to push")
+	}
+	if err := dryRunExit(ctx, flags, "drive.artifacts.push", map[string]any{
+		"repo":      repo,
+		"artifacts": results,
+	}); err != nil {
+		return err
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	for i := range results {
+		r := &results[i]
+		existing, err := findDriveArtifacts(ctx, svc, repo, r.SHA256)
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			r.FileID, r.Created, r.Status = existing[0].FileID, existing[0].Created, "exists"
+			continue
+		}
+		created, err := uploadDriveArtifact(ctx, svc, repo, r.Path, r.driveArtifact)
+		if err != nil {
+			return fmt.Errorf("push %s: %w", r.Path, err)
+		}
+		r.FileID, r.Created, r.Status = created.Id, created.CreatedTime, "uploaded"
+	}
+
+	if !c.NoIndex {
+		if err := writeDriveArtifactIndex(ctx, svc, repo); err != nil {
+			return fmt.Errorf("artifacts stored, but updating %s failed: %w", driveArtifactIndex, err)
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"repo": repo, "artifacts": results})
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "SHA256\tSTATUS\tSIZE\tNAME\tFILE_ID")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.SHA256, r.Status, humanSize(ctx, r.Size), r.Name, r.FileID)
+	}
+	u.Err().Printf("Pull with: gog drive artifacts pull <sha256> --repo %s", repo)
+	return nil
+}
+
+type DriveArtifactsPullCmd struct {
+	Digest string `arg:"" name:"sha256" help:"SHA-256 of the artifact (or a unique prefix of at least 8 hex chars)"`
+	Repo   string `name:"repo" required:"" help:"Repo folder ID"`
+	Output string `name:"out" short:"o" help:"Output path (default: the artifact's original name in the current directory)"`
+}
+
+func (c *DriveArtifactsPullCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	repo := normalizeGoogleID(strings.TrimSpace(c.Repo))
+	digest := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(c.Digest, "sha256:")))
+	if repo == "" {
+		return usage("empty --repo")
+	}
+	if !driveArtifactDigestRe.MatchString(digest) {
+		return usagef("invalid sha256 %q (expected 8-64 hex characters)", c.Digest)
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	lookup := digest
+	if len(digest) < 64 {
+		lookup = ""
+	}
+	candidates, err := findDriveArtifacts(ctx, svc, repo, lookup)
+	if err != nil {
+		return err
+	}
+	var matches []driveArtifact
+	for _, a := range candidates {
+		if strings.HasPrefix(a.SHA256, digest) {
+			matches = append(matches, a)
+		}
+	}
+	switch {
+	case len(matches) == 0:
+		return &ExitError{Code: exitCodeNotFound, Err: fmt.Errorf("no artifact %s in repo %s", digest, repo)}
+	case len(matches) > 1 && matches[0].SHA256 != matches[len(matches)-1].SHA256:
+		return usagef("prefix %s matches %d artifacts; use more characters", digest, len(matches))
+	}
+	art := matches[0]
+
+	outPath := strings.TrimSpace(c.Output)
+	if outPath == "" {
+		outPath = filepath.Base(art.Name)
+		if outPath == "" || outPath == "." || outPath == ".." || outPath == string(filepath.Separator) {
+			outPath = art.SHA256
+		}
+	}
+	// Verify against the content address itself, not whatever Drive reports.
+	path, size, err := downloadDriveBlob(ctx, svc, &drive.File{Id: art.FileID, Name: art.Name, Size: art.Size, Sha256Checksum: art.SHA256}, outPath)
+	if err != nil {
+		return err
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"artifact": art, "path": path, "size": size, "verified": true})
+	}
+	u.Out().Printf("path\t%s", path)
+	u.Out().Printf("sha256\t%s", art.SHA256)
+	u.Out().Printf("size\t%s", humanSize(ctx, size))
+	return nil
+}
+
+type DriveArtifactsListCmd struct {
+	Repo string `name:"repo" required:"" help:"Repo folder ID"`
+}
+
+func (c *DriveArtifactsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	repo := normalizeGoogleID(strings.TrimSpace(c.Repo))
+	if repo == "" {
+		return usage("empty --repo")
+	}
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	artifacts, err := findDriveArtifacts(ctx, svc, repo, "")
+	if err != nil {
+		return err
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"repo": repo, "artifacts": artifacts})
+	}
+	if len(artifacts) == 0 {
+		u.Err().Println("No artifacts")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "SHA256\tSIZE\tCREATED\tNAME")
+	for _, a := range artifacts {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.SHA256[:min(12, len(a.SHA256))], humanSize(ctx, a.Size), humanDateTime(ctx, a.Created), a.Name)
+	}
+	return nil
+}
+
+// findDriveArtifacts lists the repo's artifacts, oldest first. With digest
+// set, only that exact SHA-256 is returned.
+func findDriveArtifacts(ctx context.Context, svc *drive.Service, repo, digest string) ([]driveArtifact, error) {
+	q := fmt.Sprintf("'%s' in parents and trashed = false", repo)
+	if digest != "" {
+		q += fmt.Sprintf(" and appProperties has { key='%s' and value='%s' }", driveArtifactShaKey, digest)
+	}
+	files, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+		call := svc.Files.List().
+			Q(q).
+			Fields("nextPageToken", "files("+driveArtifactFields+")").
+			OrderBy("createdTime").
+			PageSize(1000).
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Files, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	artifacts := []driveArtifact{}
+	for _, f := range files {
+		if f == nil || f.AppProperties[driveArtifactShaKey] == "" {
+			continue
+		}
+		artifacts = append(artifacts, driveArtifact{
+			SHA256:  f.AppProperties[driveArtifactShaKey],
+			Name:    f.AppProperties[driveArtifactNameKey],
+			Size:    f.Size,
+			FileID:  f.Id,
+			Created: f.CreatedTime,
+		})
+	}
+	return artifacts, nil
+}
+
+func uploadDriveArtifact(ctx context.Context, svc *drive.Service, repo, path string, art driveArtifact) (*drive.File, error) {
+	f, err := os.Open(path) //nolint:gosec // user-provided upload path
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	meta := &drive.File{
+		Name:        art.SHA256,
+		Parents:     []string{repo},
+		Description: art.Name,
+		AppProperties: map[string]string{
+			driveArtifactShaKey:  art.SHA256,
+			driveArtifactNameKey: art.Name,
+		},
+		ContentRestrictions: []*drive.ContentRestriction{{
+			ReadOnly: true,
+			Reason:   "Content-addressed artifact (sha256 " + art.SHA256 + ")",
+		}},
+	}
+	return svc.Files.Create(meta).
+		SupportsAllDrives(true).
+		Media(f, gapi.ContentType(guessMimeType(path))).
+		Fields("id, createdTime").
+		Context(ctx).
+		Do()
+}
+
+// writeDriveArtifactIndex regenerates index.json from the artifacts' own
+// metadata, so concurrent pushers converge on the next write.
+func writeDriveArtifactIndex(ctx context.Context, svc *drive.Service, repo string) error {
+	artifacts, err := findDriveArtifacts(ctx, svc, repo, "")
+	if err != nil {
+		return err
+	}
+	payload, err := json.MarshalIndent(map[string]any{
+		"updated":   time.Now().UTC().Format(time.RFC3339),
+		"artifacts": artifacts,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	payload = append(payload, '\n')
+
+	resp, err := svc.Files.List().
+		Q(fmt.Sprintf("'%s' in parents and trashed = false and appProperties has { key='%s' and value='1' }", repo, driveArtifactIndexKey)).
+		Fields("files(id)").
+		PageSize(1).
+		SupportsAllDrives(true).
+		IncludeItemsFromAllDrives(true).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	media := gapi.ContentType("application/json")
+	if len(resp.Files) > 0 {
+		_, err = svc.Files.Update(resp.Files[0].Id, &drive.File{}).
+			SupportsAllDrives(true).
+			Media(bytes.NewReader(payload), media).
+			Fields("id").
+			Context(ctx).
+			Do()
+		return err
+	}
+	_, err = svc.Files.Create(&drive.File{
+		Name:          driveArtifactIndex,
+		Parents:       []string{repo},
+		AppProperties: map[string]string{driveArtifactIndexKey: "1"},
+	}).
+		SupportsAllDrives(true).
+		Media(bytes.NewReader(payload), media).
+		Fields("id").
+		Context(ctx).
+		Do()
+	return err
+}
+
+func sha256File(path string) (string, int64, error) {
+	f, err := os.Open(path) //nolint:gosec // user-provided path
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return "", 0, err
+	}
+	if !st.Mode().IsRegular() {
+		return "", 0, usagef("%s is not a regular file", path)
+	}
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
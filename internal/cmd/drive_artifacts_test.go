@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDriveArtifactsPushPull(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	existing := []byte("already stored")
+	existingSum := sha256.Sum256(existing)
+	existingSHA := hex.EncodeToString(existingSum[:])
+
+	var mu sync.Mutex
+	blobs := map[string][]byte{"art1": existing}
+	files := []map[string]any{{
+		"id": "art1", "name": existingSHA, "size": "14", "createdTime": "2025-01-01T00:00:00Z",
+		"appProperties": map[string]string{driveArtifactShaKey: existingSHA, driveArtifactNameKey: "old.txt"},
+	}}
+	var index []byte
+	var contentRestricted bool
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/upload"), "/drive/v3")
+		switch {
+		case r.Method == http.MethodGet && path == "/files":
+			q := r.URL.Query().Get("q")
+			if !strings.Contains(q, "'repo1' in parents") {
+				t.Errorf("unexpected q %q", q)
+			}
+			matched := []map[string]any{}
+			if !strings.Contains(q, driveArtifactIndexKey) {
+				for _, f := range files {
+					sha := f["appProperties"].(map[string]string)[driveArtifactShaKey]
+					if !strings.Contains(q, "value=") || strings.Contains(q, "value='"+sha+"'") {
+						matched = append(matched, f)
+					}
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": matched})
+		case r.Method == http.MethodPost && path == "/files":
+			meta, body := readDriveArtifactUpload(t, r)
+			if meta.AppProperties[driveArtifactIndexKey] == "1" {
+				index = body
+				_ = json.NewEncoder(w).Encode(map[string]any{"id": "index"})
+				return
+			}
+			contentRestricted = len(meta.ContentRestrictions) == 1 && meta.ContentRestrictions[0].ReadOnly
+			id := "art" + string(rune('0'+len(files)+1))
+			blobs[id] = body
+			files = append(files, map[string]any{
+				"id": id, "name": meta.Name, "size": "5", "createdTime": "2025-02-01T00:00:00Z",
+				"appProperties": meta.AppProperties,
+			})
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "createdTime": "2025-02-01T00:00:00Z"})
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "/files/") && r.URL.Query().Get("alt") == "media":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(blobs[strings.TrimPrefix(path, "/files/")])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old.txt")
+	newPath := filepath.Join(dir, "build.tar")
+	if err := os.WriteFile(oldPath, existing, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(newPath, []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	newSum := sha256.Sum256([]byte("hello"))
+	newSHA := hex.EncodeToString(newSum[:])
+
+	flags := &RootFlags{Account: "a@b.com"}
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveArtifactsPushCmd{}, []string{oldPath, newPath, "--repo", "repo1"}, newDocsJSONContext(t), flags); err != nil {
+			t.Fatalf("push: %v", err)
+		}
+	})
+	var pushed struct {
+		Artifacts []driveArtifactPushResult `json:"artifacts"`
+	}
+	if err := json.Unmarshal([]byte(out), &pushed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(pushed.Artifacts) != 2 || pushed.Artifacts[0].Status != "exists" || pushed.Artifacts[1].Status != "uploaded" || pushed.Artifacts[1].SHA256 != newSHA {
+		t.Fatalf("unexpected push result: %s", out)
+	}
+	if !contentRestricted {
+		t.Fatal("expected uploaded artifact to be read-only")
+	}
+	var idx struct {
+		Artifacts []driveArtifact `json:"artifacts"`
+	}
+	if err := json.Unmarshal(index, &idx); err != nil || len(idx.Artifacts) != 2 || idx.Artifacts[1].Name != "build.tar" {
+		t.Fatalf("unexpected index %s (%v)", index, err)
+	}
+
+	pullDir := t.TempDir()
+	t.Chdir(pullDir)
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveArtifactsPullCmd{}, []string{"sha256:" + newSHA[:10], "--repo", "repo1"}, newDocsJSONContext(t), flags); err != nil {
+			t.Fatalf("pull: %v", err)
+		}
+	})
+	if got, err := os.ReadFile(filepath.Join(pullDir, "build.tar")); err != nil || string(got) != "hello" {
+		t.Fatalf("pulled %q, %v", got, err)
+	}
+
+	// Corrupted content must not land at the output path.
+	blobs["art2"] = []byte("tampered")
+	err = runKong(t, &DriveArtifactsPullCmd{}, []string{newSHA, "--repo", "repo1", "--out", filepath.Join(pullDir, "bad.tar")}, newDocsJSONContext(t), flags)
+	if err == nil {
+		t.Fatal("expected checksum mismatch")
+	}
+	if _, statErr := os.Stat(filepath.Join(pullDir, "bad.tar")); !os.IsNotExist(statErr) {
+		t.Fatalf("expected no output file, stat err %v", statErr)
+	}
+
+	err = runKong(t, &DriveArtifactsPullCmd{}, []string{"ffffffff", "--repo", "repo1"}, newDocsJSONContext(t), flags)
+	if got := ExitCode(stableExitCode(err)); got != exitCodeNotFound {
+		t.Fatalf("expected not found exit code, got %d (%v)", got, err)
+	}
+}
+
+func readDriveArtifactUpload(t *testing.T, r *http.Request) (*drive.File, []byte) {
+	t.Helper()
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("content type: %v", err)
+	}
+	mr := multipart.NewReader(r.Body, params["boundary"])
+	metaPart, err := mr.NextPart()
+	if err != nil {
+		t.Fatalf("meta part: %v", err)
+	}
+	var meta drive.File
+	if err := json.NewDecoder(metaPart).Decode(&meta); err != nil {
+		t.Fatalf("meta: %v", err)
+	}
+	mediaPart, err := mr.NextPart()
+	if err != nil {
+		t.Fatalf("media part: %v", err)
+	}
+	body, _ := io.ReadAll(mediaPart)
+	return &meta, body
+}
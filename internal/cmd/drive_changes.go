@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type DriveChangesCmd struct {
+	SinceToken string `name:"since-token" help:"Start from this page token instead of the stored one"`
+	State      string `name:"state" help:"Page token state file (default: per-account file in the gog state dir)"`
+	NoSave     bool   `name:"no-save" help:"Do not store the new page token (peek without advancing)"`
+	DriveID    string `name:"drive" help:"Follow a shared drive instead of My Drive"`
+	Max        int64  `name:"max" aliases:"limit" help:"Changes fetched per API page" default:"100"`
+	Out        string `name:"out" short:"o" help:"Write NDJSON to this file (defaults to stdout)"`
+}
+
+const driveChangesListFields = "nextPageToken,newStartPageToken,changes(changeType,time,removed,fileId,driveId," +
+	"file(id,name,mimeType,trashed,modifiedTime,parents,size,md5Checksum,webViewLink,lastModifyingUser(displayName,emailAddress))," +
+	"drive(id,name))"
+
+type driveChangesState struct {
+	Account   string `json:"account"`
+	DriveID   string `json:"driveId,omitempty"`
+	PageToken string `json:"pageToken"`
+}
+
+// driveChangeRecord is one NDJSON line of the change feed.
+type driveChangeRecord struct {
+	Time         string   `json:"time,omitempty"`
+	ChangeType   string   `json:"changeType"`
+	FileID       string   `json:"fileId,omitempty"`
+	DriveID      string   `json:"driveId,omitempty"`
+	Removed      bool     `json:"removed"`
+	Name         string   `json:"name,omitempty"`
+	MimeType     string   `json:"mimeType,omitempty"`
+	Trashed      bool     `json:"trashed,omitempty"`
+	ModifiedTime string   `json:"modifiedTime,omitempty"`
+	ModifiedBy   string   `json:"modifiedBy,omitempty"`
+	Parents      []string `json:"parents,omitempty"`
+	Size         int64    `json:"size,omitempty"`
+	MD5          string   `json:"md5Checksum,omitempty"`
+	Link         string   `json:"link,omitempty"`
+}
+
+func (c *DriveChangesCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Max <= 0 {
+		return usage("--max must be > 0")
+	}
+	driveID := normalizeGoogleID(strings.TrimSpace(c.DriveID))
+
+	account, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+
+	statePath := strings.TrimSpace(c.State)
+	if statePath != "" {
+		statePath, err = config.ExpandPath(statePath)
+	} else {
+		statePath, err = driveChangesStatePath(account, driveID)
+	}
+	if err != nil {
+		return err
+	}
+	state, err := loadDriveChangesState(statePath)
+	if err != nil {
+		return err
+	}
+	if state != nil {
+		if !strings.EqualFold(state.Account, account) {
+			return usagef("state file %s belongs to %s, not %s", statePath, state.Account, account)
+		}
+		if state.DriveID != driveID {
+			return usagef("state file %s follows drive %q; use a new state file for a different drive", statePath, state.DriveID)
+		}
+	}
+
+	startToken := strings.TrimSpace(c.SinceToken)
+	if startToken == "" && state != nil {
+		startToken = state.PageToken
+	}
+
+	// With nothing to resume from, record where the feed starts now; the
+	// next run reports everything that changed after this point.
+	if startToken == "" {
+		call := svc.Changes.GetStartPageToken().SupportsAllDrives(true).Context(ctx)
+		if driveID != "" {
+			call = call.DriveId(driveID)
+		}
+		start, startErr := call.Do()
+		if startErr != nil {
+			return startErr
+		}
+		if !c.NoSave {
+			if err := saveDriveChangesState(statePath, driveChangesState{Account: account, DriveID: driveID, PageToken: start.StartPageToken}); err != nil {
+				return fmt.Errorf("saving changes state: %w", err)
+			}
+		}
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+				"initialized": true,
+				"pageToken":   start.StartPageToken,
+				"state":       statePath,
+				"saved":       !c.NoSave,
+			})
+		}
+		u.Err().Printf("Recorded start token %s in %s; run again to see changes", start.StartPageToken, statePath)
+		return nil
+	}
+
+	outPath := strings.TrimSpace(c.Out)
+	var w io.Writer = os.Stdout
+	if outPath != "" {
+		f, resolved, createErr := createUserOutputFile(outPath)
+		if createErr != nil {
+			return createErr
+		}
+		defer func() { _ = f.Close() }()
+		w, outPath = f, resolved
+	}
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	enc.SetEscapeHTML(false)
+
+	count := 0
+	pageToken := startToken
+	newToken := ""
+	for newToken == "" {
+		call := svc.Changes.List(pageToken).
+			PageSize(c.Max).
+			IncludeRemoved(true).
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			Fields(driveChangesListFields).
+			Context(ctx)
+		if driveID != "" {
+			call = call.DriveId(driveID)
+		}
+		resp, listErr := call.Do()
+		if listErr != nil {
+			return fmt.Errorf("listing changes from token %s: %w", pageToken, listErr)
+		}
+		for _, ch := range resp.Changes {
+			if ch == nil {
+				continue
+			}
+			if err := enc.Encode(newDriveChangeRecord(ch)); err != nil {
+				return err
+			}
+			count++
+		}
+		switch {
+		case resp.NewStartPageToken != "":
+			newToken = resp.NewStartPageToken
+		case resp.NextPageToken != "":
+			pageToken = resp.NextPageToken
+		default:
+			return errors.New("changes.list returned neither nextPageToken nor newStartPageToken")
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	if !c.NoSave {
+		if err := saveDriveChangesState(statePath, driveChangesState{Account: account, DriveID: driveID, PageToken: newToken}); err != nil {
+			return fmt.Errorf("saving changes state: %w", err)
+		}
+	}
+
+	// NDJSON owns stdout unless --out is set, so the summary goes to stderr.
+	if outPath == "" {
+		u.Err().Printf("%d change%s; next token %s", count, pluralS(count), newToken)
+		return nil
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"exported":  true,
+			"path":      outPath,
+			"changes":   count,
+			"pageToken": newToken,
+			"saved":     !c.NoSave,
+		})
+	}
+	u.Out().Printf("path\t%s", outPath)
+	u.Out().Printf("changes\t%d", count)
+	u.Out().Printf("page_token\t%s", newToken)
+	return nil
+}
+
+func newDriveChangeRecord(ch *drive.Change) driveChangeRecord {
+	rec := driveChangeRecord{
+		Time:       ch.Time,
+		ChangeType: ch.ChangeType,
+		FileID:     ch.FileId,
+		DriveID:    ch.DriveId,
+		Removed:    ch.Removed,
+	}
+	if f := ch.File; f != nil {
+		rec.Name = f.Name
+		rec.MimeType = f.MimeType
+		rec.Trashed = f.Trashed
+		rec.ModifiedTime = f.ModifiedTime
+		rec.Parents = f.Parents
+		rec.Size = f.Size
+		rec.MD5 = f.Md5Checksum
+		rec.Link = f.WebViewLink
+		if f.LastModifyingUser != nil {
+			rec.ModifiedBy = f.LastModifyingUser.EmailAddress
+			if rec.ModifiedBy == "" {
+				rec.ModifiedBy = f.LastModifyingUser.DisplayName
+			}
+		}
+	}
+	if ch.Drive != nil && rec.Name == "" {
+		rec.Name = ch.Drive.Name
+	}
+	return rec
+}
+
+func driveChangesStatePath(account, driveID string) (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	name := sanitizeAccountForPath(account)
+	if driveID != "" {
+		name += "-" + sanitizeAccountForPath(driveID)
+	}
+	return filepath.Join(dir, "state", "drive-changes", name+".json"), nil
+}
+
+func loadDriveChangesState(path string) (*driveChangesState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var state driveChangesState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing state file %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// saveDriveChangesState writes through a temp file so an interrupted run
+// never leaves a truncated state file behind.
+func saveDriveChangesState(path string, state driveChangesState) error {
+	payload, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(payload, '\n'), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDriveChangesCmd(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var listTokens []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/changes/startPageToken":
+			_ = json.NewEncoder(w).Encode(map[string]any{"startPageToken": "100"})
+		case r.URL.Path == "/changes":
+			token := r.URL.Query().Get("pageToken")
+			listTokens = append(listTokens, token)
+			switch token {
+			case "100":
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"nextPageToken": "101",
+					"changes": []map[string]any{{
+						"changeType": "file", "fileId": "f1", "time": "2025-03-01T10:00:00Z",
+						"file": map[string]any{"id": "f1", "name": "Plan", "mimeType": driveMimeGoogleDoc, "parents": []string{"p1"}},
+					}},
+				})
+			case "101":
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"newStartPageToken": "105",
+					"changes":           []map[string]any{{"changeType": "file", "fileId": "f2", "removed": true}},
+				})
+			default:
+				_ = json.NewEncoder(w).Encode(map[string]any{"newStartPageToken": token})
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := newDocsJSONContext(t)
+	statePath := filepath.Join(t.TempDir(), "changes.json")
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveChangesCmd{}, []string{"--state", statePath}, ctx, flags); err != nil {
+			t.Fatalf("init: %v", err)
+		}
+	})
+	if state, err := loadDriveChangesState(statePath); err != nil || state == nil || state.PageToken != "100" {
+		t.Fatalf("expected stored start token, got %+v %v", state, err)
+	}
+	if len(listTokens) != 0 {
+		t.Fatalf("first run should not list changes: %v", listTokens)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveChangesCmd{}, []string{"--state", statePath}, ctx, flags); err != nil {
+			t.Fatalf("changes: %v", err)
+		}
+	})
+	var records []driveChangeRecord
+	sc := bufio.NewScanner(strings.NewReader(out))
+	for sc.Scan() {
+		var rec driveChangeRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			t.Fatalf("ndjson line %q: %v", sc.Text(), err)
+		}
+		records = append(records, rec)
+	}
+	if len(records) != 2 || records[0].Name != "Plan" || records[0].Parents[0] != "p1" || !records[1].Removed {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+	if state, _ := loadDriveChangesState(statePath); state.PageToken != "105" {
+		t.Fatalf("expected advanced token 105, got %+v", state)
+	}
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveChangesCmd{}, []string{"--state", statePath, "--since-token", "100", "--no-save"}, ctx, flags); err != nil {
+			t.Fatalf("since-token: %v", err)
+		}
+	})
+	if state, _ := loadDriveChangesState(statePath); state.PageToken != "105" {
+		t.Fatalf("--no-save must keep the stored token, got %+v", state)
+	}
+
+	if err := os.WriteFile(statePath, []byte(`{"account":"other@b.com","pageToken":"1"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := runKong(t, &DriveChangesCmd{}, []string{"--state", statePath}, ctx, flags); err == nil || !strings.Contains(err.Error(), "belongs to") {
+		t.Fatalf("expected account mismatch error, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// DriveCleanupEmptyCmd finds folders under folderId that hold no files at any
+// depth. Only the topmost empty folder of each branch is trashed; its empty
+// subfolders go to the trash with it. folderId itself is never trashed.
+type DriveCleanupEmptyCmd struct {
+	FolderID string `arg:"" name:"folderId" help:"Folder to scan"`
+	Apply    bool   `name:"apply" help:"Move the empty folders to the trash"`
+}
+
+type driveEmptyFolder struct {
+	ID   string `json:"id"`
+	Path string `json:"path"`
+	// Nested counts the empty subfolders trashed along with this one.
+	Nested int `json:"nested"`
+}
+
+func (c *DriveCleanupEmptyCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	ref := strings.TrimSpace(c.FolderID)
+	if !isDrivePath(ref) {
+		ref = normalizeGoogleID(ref)
+	}
+	if ref == "" {
+		return usage("empty folderId")
+	}
+
+	account, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	folderID, err := resolveDriveIDLive(ctx, svc, ref)
+	if err != nil {
+		return err
+	}
+	root, err := svc.Files.Get(folderID).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	if root.MimeType != driveMimeFolder {
+		return usagef("%s is not a folder", root.Name)
+	}
+	tree := &driveTreeNode{ID: root.Id, Name: root.Name, MimeType: root.MimeType}
+	folders, _, err := walkDriveTree(ctx, svc, tree, 0, true)
+	if err != nil {
+		return err
+	}
+
+	empty := []driveEmptyFolder{}
+	collectDriveEmptyFolders(tree, "", &empty)
+	total := 0
+	for _, f := range empty {
+		total += 1 + f.Nested
+	}
+
+	trashed := 0
+	if c.Apply && len(empty) > 0 {
+		guard := &bulkDestructiveOp{Op: "drive.cleanup-empty", Action: "trash", Noun: "empty folder", Undo: bulkUndoDriveUntrash, Confirm: true}
+		ids := make([]string, 0, len(empty))
+		for _, f := range empty {
+			ids = append(ids, f.ID)
+			guard.Items = append(guard.Items, bulkItem{ID: f.ID, Label: f.Path + "/"})
+		}
+		if err := confirmBulkDestructive(ctx, flags, guard, map[string]any{
+			"folder_ids": ids,
+		}); err != nil {
+			return err
+		}
+		err := trashDriveFiles(ctx, svc, guard.Items, &trashed)
+		journalBulkDestructive(ctx, account, guard, guard.Items[:trashed])
+		if err != nil {
+			return err
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"folderId":     root.Id,
+			"scanned":      folders,
+			"emptyFolders": total,
+			"empty":        empty,
+			"trashed":      trashed,
+		})
+	}
+	if len(empty) == 0 {
+		u.Err().Printf("No empty folders among %d folder%s", folders, pluralS(folders))
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	fmt.Fprintln(w, "ACTION\tID\tNESTED\tPATH")
+	action := "empty"
+	if c.Apply {
+		action = "trashed"
+	}
+	for _, f := range empty {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s/\n", action, f.ID, f.Nested, f.Path)
+	}
+	flush()
+	if c.Apply {
+		u.Err().Printf("Trashed %d empty folder%s", total, pluralS(total))
+	} else {
+		u.Err().Printf("%d empty folder%s among %d; re-run with --apply to trash them", total, pluralS(total), folders)
+	}
+	return nil
+}
+
+// collectDriveEmptyFolders appends the topmost folders below n that hold no
+// files at any depth; branches that do hold files are descended into.
+func collectDriveEmptyFolders(n *driveTreeNode, prefix string, out *[]driveEmptyFolder) {
+	for _, child := range n.Children {
+		if child.MimeType != driveMimeFolder {
+			continue
+		}
+		p := path.Join(prefix, child.Name)
+		if driveFolderHasFiles(child) {
+			collectDriveEmptyFolders(child, p, out)
+			continue
+		}
+		*out = append(*out, driveEmptyFolder{ID: child.ID, Path: p, Nested: countDriveFolders(child)})
+	}
+}
+
+func driveFolderHasFiles(n *driveTreeNode) bool {
+	for _, child := range n.Children {
+		if child.MimeType != driveMimeFolder || driveFolderHasFiles(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func countDriveFolders(n *driveTreeNode) int {
+	count := 0
+	for _, child := range n.Children {
+		if child.MimeType == driveMimeFolder {
+			count += 1 + countDriveFolders(child)
+		}
+	}
+	return count
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDriveCleanupEmptyCmd(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var trashed []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files/top":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "top", "name": "Migration", "mimeType": driveMimeFolder})
+		case r.Method == http.MethodGet && r.URL.Path == "/files":
+			// The walk only attaches children of the level being listed.
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "a", "name": "A", "mimeType": driveMimeFolder, "parents": []string{"top"}},
+				{"id": "a1", "name": "A1", "mimeType": driveMimeFolder, "parents": []string{"a"}},
+				{"id": "b", "name": "B", "mimeType": driveMimeFolder, "parents": []string{"top"}},
+				{"id": "bf", "name": "keep.txt", "mimeType": "text/plain", "parents": []string{"b"}},
+				{"id": "c", "name": "C", "mimeType": driveMimeFolder, "parents": []string{"b"}},
+				{"id": "d", "name": "readme.txt", "mimeType": "text/plain", "parents": []string{"top"}},
+			}})
+		case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/files/"):
+			trashed = append(trashed, strings.TrimPrefix(r.URL.Path, "/files/"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "x", "trashed": true})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	ctx := newDocsJSONContext(t)
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveCleanupEmptyCmd{}, []string{"top"}, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("cleanup-empty: %v", err)
+		}
+	})
+	var report struct {
+		Scanned      int                `json:"scanned"`
+		EmptyFolders int                `json:"emptyFolders"`
+		Empty        []driveEmptyFolder `json:"empty"`
+		Trashed      int                `json:"trashed"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if report.Scanned != 4 || report.EmptyFolders != 3 || len(report.Empty) != 2 || report.Trashed != 0 || len(trashed) != 0 {
+		t.Fatalf("unexpected report: %s", out)
+	}
+	if report.Empty[0].ID != "a" || report.Empty[0].Nested != 1 || report.Empty[1].Path != "B/C" {
+		t.Fatalf("expected topmost empty folders A and B/C, got %+v", report.Empty)
+	}
+
+	if err := runKong(t, &DriveCleanupEmptyCmd{}, []string{"top", "--apply"}, ctx, &RootFlags{Account: "a@b.com", NoInput: true}); err == nil || !strings.Contains(err.Error(), "without --force") {
+		t.Fatalf("expected confirmation refusal, got %v", err)
+	}
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveCleanupEmptyCmd{}, []string{"top", "--apply"}, ctx, &RootFlags{Account: "a@b.com", Force: true}); err != nil {
+			t.Fatalf("cleanup-empty --apply: %v", err)
+		}
+	})
+	if strings.Join(trashed, ",") != "a,c" {
+		t.Fatalf("expected A and B/C trashed, got %v", trashed)
+	}
+}
@@ -10,12 +10,14 @@ import (
 
 // DriveCommentsCmd is the parent command for comments subcommands
 type DriveCommentsCmd struct {
-	List   DriveCommentsListCmd   `cmd:"" name:"list" aliases:"ls" help:"List comments on a file"`
-	Get    DriveCommentsGetCmd    `cmd:"" name:"get" aliases:"info,show" help:"Get a comment by ID"`
-	Create DriveCommentsCreateCmd `cmd:"" name:"create" aliases:"add,new" help:"Create a comment on a file"`
-	Update DriveCommentsUpdateCmd `cmd:"" name:"update" aliases:"edit,set" help:"Update a comment"`
-	Delete DriveCommentsDeleteCmd `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete a comment"`
-	Reply  DriveCommentReplyCmd   `cmd:"" name:"reply" aliases:"respond" help:"Reply to a comment"`
+	List    DriveCommentsListCmd    `cmd:"" name:"list" aliases:"ls" help:"List comments on a file"`
+	Get     DriveCommentsGetCmd     `cmd:"" name:"get" aliases:"info,show" help:"Get a comment by ID"`
+	Create  DriveCommentsCreateCmd  `cmd:"" name:"create" aliases:"add,new" help:"Create a comment on a file"`
+	Update  DriveCommentsUpdateCmd  `cmd:"" name:"update" aliases:"edit,set" help:"Update a comment"`
+	Delete  DriveCommentsDeleteCmd  `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete a comment"`
+	Reply   DriveCommentReplyCmd    `cmd:"" name:"reply" aliases:"respond" help:"Reply to a comment"`
+	Resolve DriveCommentsResolveCmd `cmd:"" name:"resolve" help:"Resolve a comment (optionally with a closing message)"`
+	Export  DriveCommentsExportCmd  `cmd:"" name:"export" help:"Export open and resolved comments across a folder as NDJSON"`
 }
 
 type DriveCommentsListCmd struct {
@@ -242,3 +244,41 @@ func (c *DriveCommentReplyCmd) Run(ctx context.Context, flags *RootFlags) error
 	}
 	return writeDriveReplyMutation(ctx, u, created, false, "", "", "")
 }
+
+// DriveCommentsResolveCmd resolves a comment on any Drive file (PDFs and
+// images included) by posting a reply with the resolve action.
+type DriveCommentsResolveCmd struct {
+	FileID    string `arg:"" name:"fileId" help:"File ID"`
+	CommentID string `arg:"" name:"commentId" help:"Comment ID"`
+	Message   string `name:"message" short:"m" help:"Optional message to include when resolving"`
+}
+
+func (c *DriveCommentsResolveCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	commentID := strings.TrimSpace(c.CommentID)
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+	if commentID == "" {
+		return usage("empty commentId")
+	}
+
+	if err := dryRunExit(ctx, flags, "drive.comments.resolve", map[string]any{
+		"file_id":    fileID,
+		"comment_id": commentID,
+		"message":    strings.TrimSpace(c.Message),
+	}); err != nil {
+		return err
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	created, err := resolveDriveComment(ctx, svc, fileID, commentID, c.Message)
+	if err != nil {
+		return err
+	}
+	return writeDriveReplyMutation(ctx, u, created, true, "fileId", fileID, commentID)
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type DriveCommentsExportCmd struct {
+	FolderID  string `arg:"" name:"folderId" help:"Folder ID (or a single file ID)"`
+	Recursive bool   `name:"recursive" help:"Include files in subfolders"`
+	Out       string `name:"out" short:"o" help:"Write NDJSON to this file (defaults to stdout)"`
+}
+
+// driveCommentExportRecord is one NDJSON line: a comment with its file and
+// replies, resolved or not.
+type driveCommentExportRecord struct {
+	FileID        string                    `json:"fileId"`
+	FileName      string                    `json:"fileName"`
+	Path          string                    `json:"path"`
+	MimeType      string                    `json:"mimeType,omitempty"`
+	CommentID     string                    `json:"commentId"`
+	Author        string                    `json:"author,omitempty"`
+	AuthorEmail   string                    `json:"authorEmail,omitempty"`
+	Content       string                    `json:"content"`
+	QuotedContent string                    `json:"quotedContent,omitempty"`
+	Resolved      bool                      `json:"resolved"`
+	CreatedTime   string                    `json:"createdTime,omitempty"`
+	ModifiedTime  string                    `json:"modifiedTime,omitempty"`
+	Replies       []driveCommentExportReply `json:"replies,omitempty"`
+}
+
+type driveCommentExportReply struct {
+	ID           string `json:"id"`
+	Author       string `json:"author,omitempty"`
+	AuthorEmail  string `json:"authorEmail,omitempty"`
+	Content      string `json:"content,omitempty"`
+	Action       string `json:"action,omitempty"`
+	CreatedTime  string `json:"createdTime,omitempty"`
+	ModifiedTime string `json:"modifiedTime,omitempty"`
+}
+
+type driveCommentExportFile struct {
+	id       string
+	name     string
+	path     string
+	mimeType string
+}
+
+func (c *DriveCommentsExportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	rootID := normalizeGoogleID(strings.TrimSpace(c.FolderID))
+	if rootID == "" {
+		return usage("empty folderId")
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	root, err := svc.Files.Get(rootID).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	files := []driveCommentExportFile{{id: root.Id, name: root.Name, path: root.Name, mimeType: root.MimeType}}
+	if root.MimeType == driveMimeFolder {
+		if files, err = listDriveCommentExportFiles(ctx, svc, root.Id, "", c.Recursive); err != nil {
+			return err
+		}
+	}
+
+	outPath := strings.TrimSpace(c.Out)
+	var w io.Writer = os.Stdout
+	if outPath != "" {
+		f, resolved, createErr := createUserOutputFile(outPath)
+		if createErr != nil {
+			return createErr
+		}
+		defer func() { _ = f.Close() }()
+		w, outPath = f, resolved
+	}
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	enc.SetEscapeHTML(false)
+
+	count, open, commented := 0, 0, 0
+	for _, f := range files {
+		fileID := f.id
+		comments, err := collectAllPages("", func(pageToken string) ([]*drive.Comment, string, error) {
+			return fetchDriveCommentsPage(ctx, svc, fileID, 100, pageToken, docsCommentListFields)
+		})
+		if err != nil {
+			return fmt.Errorf("comments on %s: %w", f.path, err)
+		}
+		if len(comments) > 0 {
+			commented++
+		}
+		for _, comment := range comments {
+			if comment == nil {
+				continue
+			}
+			if err := enc.Encode(newDriveCommentExportRecord(f, comment)); err != nil {
+				return err
+			}
+			count++
+			if !comment.Resolved {
+				open++
+			}
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if outPath == "" {
+		return nil
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"exported": true,
+			"path":     outPath,
+			"files":    len(files),
+			"comments": count,
+			"open":     open,
+			"resolved": count - open,
+		})
+	}
+	u.Out().Printf("Exported %d comments (%d open, %d resolved) from %d of %d files to %s", count, open, count-open, commented, len(files), outPath)
+	return nil
+}
+
+// listDriveCommentExportFiles lists the non-folder files in folderID, and
+// with recursive those of its subfolders, with slash-separated paths.
+func listDriveCommentExportFiles(ctx context.Context, svc *drive.Service, folderID, prefix string, recursive bool) ([]driveCommentExportFile, error) {
+	items, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+		call := svc.Files.List().
+			Q(fmt.Sprintf("'%s' in parents and trashed = false", folderID)).
+			Fields("nextPageToken, files(id, name, mimeType)").
+			OrderBy("name").
+			PageSize(1000).
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Files, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var files []driveCommentExportFile
+	var folders []*drive.File
+	for _, item := range items {
+		if item.MimeType == driveMimeFolder {
+			folders = append(folders, item)
+			continue
+		}
+		files = append(files, driveCommentExportFile{id: item.Id, name: item.Name, path: path.Join(prefix, item.Name), mimeType: item.MimeType})
+	}
+	if !recursive {
+		return files, nil
+	}
+	for _, folder := range folders {
+		sub, err := listDriveCommentExportFiles(ctx, svc, folder.Id, path.Join(prefix, folder.Name), true)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, sub...)
+	}
+	return files, nil
+}
+
+func newDriveCommentExportRecord(f driveCommentExportFile, c *drive.Comment) driveCommentExportRecord {
+	rec := driveCommentExportRecord{
+		FileID:       f.id,
+		FileName:     f.name,
+		Path:         f.path,
+		MimeType:     f.mimeType,
+		CommentID:    c.Id,
+		Content:      c.Content,
+		Resolved:     c.Resolved,
+		CreatedTime:  c.CreatedTime,
+		ModifiedTime: c.ModifiedTime,
+	}
+	if c.Author != nil {
+		rec.Author, rec.AuthorEmail = c.Author.DisplayName, c.Author.EmailAddress
+	}
+	if c.QuotedFileContent != nil {
+		rec.QuotedContent = c.QuotedFileContent.Value
+	}
+	for _, r := range c.Replies {
+		if r == nil || r.Deleted {
+			continue
+		}
+		reply := driveCommentExportReply{
+			ID:           r.Id,
+			Content:      r.Content,
+			Action:       r.Action,
+			CreatedTime:  r.CreatedTime,
+			ModifiedTime: r.ModifiedTime,
+		}
+		if r.Author != nil {
+			reply.Author, reply.AuthorEmail = r.Author.DisplayName, r.Author.EmailAddress
+		}
+		rec.Replies = append(rec.Replies, reply)
+	}
+	return rec
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDriveCommentsExportCmd_Recursive(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && path == "/files/root1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "root1", "name": "Project", "mimeType": driveMimeFolder})
+		case r.Method == http.MethodGet && path == "/files":
+			q := r.URL.Query().Get("q")
+			var files []map[string]any
+			switch {
+			case strings.Contains(q, "'root1' in parents"):
+				files = []map[string]any{
+					{"id": "sub1", "name": "Drafts", "mimeType": driveMimeFolder},
+					{"id": "doc1", "name": "Plan", "mimeType": driveMimeGoogleDoc},
+				}
+			case strings.Contains(q, "'sub1' in parents"):
+				files = []map[string]any{{"id": "doc2", "name": "Notes", "mimeType": driveMimeGoogleDoc}}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": files})
+		case r.Method == http.MethodGet && path == "/files/doc1/comments":
+			if r.URL.Query().Get("pageToken") == "" {
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"comments": []map[string]any{{
+						"id": "c1", "content": "Ship it?", "resolved": true, "createdTime": "2025-01-01T00:00:00Z",
+						"author":  map[string]any{"displayName": "Alice", "emailAddress": "alice@example.com"},
+						"replies": []map[string]any{{"id": "r1", "content": "Done", "action": "resolve", "author": map[string]any{"displayName": "Bob"}}, {"id": "r2", "deleted": true}},
+					}},
+					"nextPageToken": "p2",
+				})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"comments": []map[string]any{{"id": "c2", "content": "Budget <TBD>"}}})
+		case r.Method == http.MethodGet && path == "/files/doc2/comments":
+			_ = json.NewEncoder(w).Encode(map[string]any{"comments": []map[string]any{{"id": "c3", "content": "Typo", "quotedFileContent": map[string]any{"value": "teh"}}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	outPath := filepath.Join(t.TempDir(), "comments.ndjson")
+	summary := captureStdout(t, func() {
+		if err := runKong(t, &DriveCommentsExportCmd{}, []string{"root1", "--recursive", "-o", outPath}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("export: %v", err)
+		}
+	})
+	if !strings.Contains(summary, `"comments": 3`) || !strings.Contains(summary, `"open": 2`) {
+		t.Fatalf("unexpected summary: %s", summary)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 NDJSON lines, got %d:\n%s", len(lines), data)
+	}
+	var first, last driveCommentExportRecord
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("line 1: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[2]), &last); err != nil {
+		t.Fatalf("line 3: %v", err)
+	}
+	if first.Path != "Plan" || first.AuthorEmail != "alice@example.com" || !first.Resolved || len(first.Replies) != 1 || first.Replies[0].Action != "resolve" {
+		t.Fatalf("unexpected first record: %+v", first)
+	}
+	if !strings.Contains(lines[1], "Budget <TBD>") {
+		t.Fatalf("expected unescaped content, got %s", lines[1])
+	}
+	if last.Path != "Drafts/Notes" || last.QuotedContent != "teh" || last.Resolved {
+		t.Fatalf("unexpected last record: %+v", last)
+	}
+}
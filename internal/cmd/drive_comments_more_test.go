@@ -241,3 +241,51 @@ func TestDriveCommentsGetUpdateDeleteReply(t *testing.T) {
 		t.Fatalf("unexpected reply plain output: %q", plainReplyOut)
 	}
 }
+
+func TestDriveCommentsResolve_PDF(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var got struct {
+		Action  string `json:"action"`
+		Content string `json:"content"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || strings.TrimPrefix(r.URL.Path, "/drive/v3") != "/files/scan/comments/c1/replies" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "r1", "action": got.Action, "content": got.Content})
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "drive", "comments", "resolve", "scan", "c1", "-m", "Fixed in v2"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if got.Action != "resolve" || got.Content != "Fixed in v2" {
+		t.Fatalf("unexpected reply body: %+v", got)
+	}
+	var parsed struct {
+		Resolved bool   `json:"resolved"`
+		FileID   string `json:"fileId"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if !parsed.Resolved || parsed.FileID != "scan" {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// driveConvertExtensions maps --to shorthands to MIME types. Drive's
+// about.exportFormats/importFormats decide which pairs actually work.
+var driveConvertExtensions = map[string]string{
+	"pdf":  mimePDF,
+	"docx": mimeDocx,
+	"xlsx": mimeXlsx,
+	"pptx": mimePptx,
+	"csv":  mimeCSV,
+	"tsv":  "text/tab-separated-values",
+	"txt":  mimeTextPlain,
+	"md":   mimeTextMarkdown,
+	"html": mimeHTML,
+	"png":  mimePNG,
+	"jpg":  "image/jpeg",
+	"svg":  "image/svg+xml",
+	"rtf":  "application/rtf",
+	"epub": mimeEPUB,
+	"odt":  "application/vnd.oasis.opendocument.text",
+	"ods":  "application/x-vnd.oasis.opendocument.spreadsheet",
+	"odp":  "application/vnd.oasis.opendocument.presentation",
+}
+
+type DriveConvertCmd struct {
+	FileID string `arg:"" name:"fileId" help:"File ID"`
+	To     string `name:"to" help:"Target format: doc|sheet|slides, an extension (pdf, docx, xlsx, csv, md, ...), or a MIME type. Omit to list the targets available for the file."`
+	Name   string `name:"name" help:"Name for the converted file (default: source name with the new extension)"`
+	Parent string `name:"parent" help:"Destination folder ID (default: the source file's folder)"`
+}
+
+// driveConvertPlan describes how a conversion runs. Via is the Google
+// format used as an intermediate when neither side is a Google format.
+type driveConvertPlan struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Via  string `json:"via,omitempty"`
+}
+
+func (c *DriveConvertCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+
+	src, err := svc.Files.Get(fileID).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType, parents").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	about, err := svc.About.Get().Fields("exportFormats,importFormats").Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(c.To) == "" {
+		targets := driveConvertTargets(src.MimeType, about)
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+				"fileId":   src.Id,
+				"mimeType": src.MimeType,
+				"targets":  targets,
+			})
+		}
+		if len(targets) == 0 {
+			u.Err().Printf("No conversions available for %s", src.MimeType)
+			return nil
+		}
+		for _, t := range targets {
+			u.Out().Println(t)
+		}
+		return nil
+	}
+
+	target, err := resolveDriveConvertTarget(c.To)
+	if err != nil {
+		return err
+	}
+	plan, err := planDriveConvert(src.MimeType, target, about)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		name = driveConvertName(src, target)
+	}
+	parents := src.Parents
+	if p := strings.TrimSpace(c.Parent); p != "" {
+		parents = []string{p}
+	}
+
+	if err := dryRunExit(ctx, flags, "drive.convert", map[string]any{
+		"fileId":  src.Id,
+		"name":    name,
+		"parents": parents,
+		"plan":    plan,
+	}); err != nil {
+		return err
+	}
+
+	created, err := runDriveConvert(ctx, svc, src, plan, name, parents)
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"sourceId": src.Id,
+			"plan":     plan,
+			strFile:    created,
+		})
+	}
+	u.Out().Printf("id\t%s", created.Id)
+	u.Out().Printf("name\t%s", created.Name)
+	u.Out().Printf("mimeType\t%s", created.MimeType)
+	if created.WebViewLink != "" {
+		u.Out().Printf("link\t%s", created.WebViewLink)
+	}
+	return nil
+}
+
+func runDriveConvert(ctx context.Context, svc *drive.Service, src *drive.File, plan driveConvertPlan, name string, parents []string) (*drive.File, error) {
+	const fields = "id, name, mimeType, size, webViewLink"
+
+	// Importing into a Google format is a converting copy.
+	if isGoogleWorkspaceMime(plan.To) {
+		return svc.Files.Copy(src.Id, &drive.File{Name: name, MimeType: plan.To, Parents: parents}).
+			SupportsAllDrives(true).
+			Fields(fields).
+			Context(ctx).
+			Do()
+	}
+
+	exportID := src.Id
+	if plan.Via != "" {
+		tmp, err := svc.Files.Copy(src.Id, &drive.File{Name: name + " (converting)", MimeType: plan.Via, Parents: parents}).
+			SupportsAllDrives(true).
+			Fields("id").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, fmt.Errorf("import as %s: %w", plan.Via, err)
+		}
+		defer func() {
+			_ = svc.Files.Delete(tmp.Id).SupportsAllDrives(true).Context(context.WithoutCancel(ctx)).Do()
+		}()
+		exportID = tmp.Id
+	}
+
+	resp, err := driveExportDownload(ctx, svc, exportID, plan.To)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("export failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return svc.Files.Create(&drive.File{Name: name, MimeType: plan.To, Parents: parents}).
+		SupportsAllDrives(true).
+		Media(resp.Body, gapi.ContentType(plan.To)).
+		Fields(fields).
+		Context(ctx).
+		Do()
+}
+
+func resolveDriveConvertTarget(to string) (string, error) {
+	to = strings.TrimSpace(to)
+	if strings.Contains(to, "/") {
+		return to, nil
+	}
+	if mimeType, ok := googleConvertTargetMimeType(to); ok {
+		return mimeType, nil
+	}
+	if strings.EqualFold(to, "drawing") {
+		return driveMimeGoogleDrawing, nil
+	}
+	if mimeType, ok := driveConvertExtensions[strings.ToLower(strings.TrimPrefix(to, "."))]; ok {
+		return mimeType, nil
+	}
+	return "", usagef("unknown --to %q (use doc|sheet|slides|drawing, an extension, or a MIME type)", to)
+}
+
+func planDriveConvert(from, to string, about *drive.About) (driveConvertPlan, error) {
+	plan := driveConvertPlan{From: from, To: to}
+	switch {
+	case from == to:
+		return plan, usagef("file is already %s", to)
+	case isGoogleWorkspaceMime(from) && !isGoogleWorkspaceMime(to):
+		if slices.Contains(about.ExportFormats[from], to) {
+			return plan, nil
+		}
+	case !isGoogleWorkspaceMime(from) && isGoogleWorkspaceMime(to):
+		if slices.Contains(about.ImportFormats[from], to) {
+			return plan, nil
+		}
+	case !isGoogleWorkspaceMime(from):
+		// Binary to binary: import into a Google format that can export to
+		// the target.
+		for _, via := range about.ImportFormats[from] {
+			if slices.Contains(about.ExportFormats[via], to) {
+				plan.Via = via
+				return plan, nil
+			}
+		}
+	}
+	available := driveConvertTargets(from, about)
+	if len(available) == 0 {
+		return plan, fmt.Errorf("cannot convert %s: Drive has no conversions for this type", from)
+	}
+	return plan, fmt.Errorf("cannot convert %s to %s (available: %s)", from, to, strings.Join(available, ", "))
+}
+
+// driveConvertTargets lists every MIME type a file of the given type can be
+// converted to, directly or through an intermediate Google format.
+func driveConvertTargets(from string, about *drive.About) []string {
+	set := map[string]struct{}{}
+	if isGoogleWorkspaceMime(from) {
+		for _, to := range about.ExportFormats[from] {
+			set[to] = struct{}{}
+		}
+	} else {
+		for _, via := range about.ImportFormats[from] {
+			set[via] = struct{}{}
+			for _, to := range about.ExportFormats[via] {
+				set[to] = struct{}{}
+			}
+		}
+	}
+	delete(set, from)
+	out := make([]string, 0, len(set))
+	for to := range set {
+		out = append(out, to)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func driveConvertName(src *drive.File, target string) string {
+	name := src.Name
+	if !isGoogleWorkspaceMime(src.MimeType) {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	if isGoogleWorkspaceMime(target) {
+		return name
+	}
+	for ext, mimeType := range driveConvertExtensions {
+		if mimeType == target {
+			return name + "." + ext
+		}
+	}
+	return name
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func testDriveConvertAbout() *drive.About {
+	return &drive.About{
+		ExportFormats: map[string][]string{
+			driveMimeGoogleDoc: {mimePDF, mimeDocx, mimeTextPlain},
+		},
+		ImportFormats: map[string][]string{
+			mimeDocx: {driveMimeGoogleDoc},
+		},
+	}
+}
+
+func TestPlanDriveConvert(t *testing.T) {
+	about := testDriveConvertAbout()
+
+	plan, err := planDriveConvert(mimeDocx, driveMimeGoogleDoc, about)
+	if err != nil || plan.Via != "" {
+		t.Fatalf("import: %#v, %v", plan, err)
+	}
+	plan, err = planDriveConvert(driveMimeGoogleDoc, mimePDF, about)
+	if err != nil || plan.Via != "" {
+		t.Fatalf("export: %#v, %v", plan, err)
+	}
+	plan, err = planDriveConvert(mimeDocx, mimePDF, about)
+	if err != nil || plan.Via != driveMimeGoogleDoc {
+		t.Fatalf("expected conversion via Google Doc, got %#v, %v", plan, err)
+	}
+
+	_, err = planDriveConvert(driveMimeGoogleDoc, mimeXlsx, about)
+	if err == nil || !strings.Contains(err.Error(), "available: "+mimePDF+", "+mimeDocx) {
+		t.Fatalf("expected available targets in error, got %v", err)
+	}
+	_, err = planDriveConvert(mimePNG, mimePDF, about)
+	if err == nil || !strings.Contains(err.Error(), "no conversions") {
+		t.Fatalf("expected no conversions error, got %v", err)
+	}
+}
+
+func TestResolveDriveConvertTarget(t *testing.T) {
+	for in, want := range map[string]string{
+		"doc":             driveMimeGoogleDoc,
+		".PDF":            mimePDF,
+		"md":              mimeTextMarkdown,
+		"application/rtf": "application/rtf",
+	} {
+		got, err := resolveDriveConvertTarget(in)
+		if err != nil || got != want {
+			t.Fatalf("resolve %q = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := resolveDriveConvertTarget("nope"); err == nil {
+		t.Fatalf("expected error for unknown target")
+	}
+}
+
+func TestDriveConvertCmd_JSON(t *testing.T) {
+	origNew := newDriveService
+	origExport := driveExportDownload
+	t.Cleanup(func() {
+		newDriveService = origNew
+		driveExportDownload = origExport
+	})
+
+	var copies []drive.File
+	var uploads, deletes []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path == "/about" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(testDriveConvertAbout())
+		case strings.HasSuffix(path, "/copy") && r.Method == http.MethodPost:
+			var f drive.File
+			_ = json.NewDecoder(r.Body).Decode(&f)
+			copies = append(copies, f)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "copy1", "name": f.Name, "mimeType": f.MimeType})
+		case r.URL.Path == "/upload/drive/v3/files" && r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			uploads = append(uploads, string(body))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "up1", "name": "Report.pdf", "mimeType": mimePDF})
+		case strings.HasPrefix(path, "/files/") && r.Method == http.MethodDelete:
+			deletes = append(deletes, strings.TrimPrefix(path, "/files/"))
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasPrefix(path, "/files/") && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":       "src1",
+				"name":     "Report.docx",
+				"mimeType": mimeDocx,
+				"parents":  []string{"folder1"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	var exported []string
+	driveExportDownload = func(_ context.Context, _ *drive.Service, id, mimeType string) (*http.Response, error) {
+		exported = append(exported, id+" "+mimeType)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("%PDF-1.4"))}, nil
+	}
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveConvertCmd{}, []string{"src1", "--to", "doc"}, ctx, flags); err != nil {
+			t.Fatalf("convert to doc: %v", err)
+		}
+	})
+	var result struct {
+		File drive.File       `json:"file"`
+		Plan driveConvertPlan `json:"plan"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if result.File.Id != "copy1" || len(copies) != 1 || copies[0].Name != "Report" || copies[0].MimeType != driveMimeGoogleDoc {
+		t.Fatalf("unexpected import: %#v, copies=%#v", result, copies)
+	}
+	if len(copies[0].Parents) != 1 || copies[0].Parents[0] != "folder1" {
+		t.Fatalf("expected source folder, got %#v", copies[0].Parents)
+	}
+
+	copies = nil
+	out = captureStdout(t, func() {
+		if err := runKong(t, &DriveConvertCmd{}, []string{"src1", "--to", "pdf"}, ctx, flags); err != nil {
+			t.Fatalf("convert to pdf: %v", err)
+		}
+	})
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if result.File.Id != "up1" || result.Plan.Via != driveMimeGoogleDoc {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+	if len(copies) != 1 || copies[0].MimeType != driveMimeGoogleDoc {
+		t.Fatalf("expected intermediate copy, got %#v", copies)
+	}
+	if len(exported) != 1 || exported[0] != "copy1 "+mimePDF {
+		t.Fatalf("unexpected export: %q", exported)
+	}
+	if len(uploads) != 1 || !strings.Contains(uploads[0], "%PDF-1.4") || !strings.Contains(uploads[0], `"name":"Report.pdf"`) {
+		t.Fatalf("unexpected upload: %q", uploads)
+	}
+	if len(deletes) != 1 || deletes[0] != "copy1" {
+		t.Fatalf("expected intermediate cleanup, got %q", deletes)
+	}
+}
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// DriveDedupeCmd finds byte-identical uploads by md5Checksum and size.
+// Google Docs, Sheets, and Slides have no checksum and are never matched.
+type DriveDedupeCmd struct {
+	FolderID  string `arg:"" name:"folderId" optional:"" help:"Folder to scan (default: every file you own)"`
+	Recursive bool   `name:"recursive" help:"Include files in subfolders of folderId"`
+	MinSize   int64  `name:"min-size" help:"Ignore files smaller than this many bytes" default:"1"`
+	Apply     bool   `name:"apply" help:"Move all but the newest copy in each group to the trash"`
+}
+
+const driveDedupeFields = "nextPageToken, files(id, name, mimeType, size, md5Checksum, modifiedTime, parents, webViewLink)"
+
+type driveDedupeFile struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Path     string `json:"path,omitempty"`
+	Size     int64  `json:"size"`
+	Modified string `json:"modifiedTime"`
+	Link     string `json:"link,omitempty"`
+	md5      string
+}
+
+type driveDedupeGroup struct {
+	MD5        string            `json:"md5Checksum"`
+	Size       int64             `json:"size"`
+	Keep       driveDedupeFile   `json:"keep"`
+	Duplicates []driveDedupeFile `json:"duplicates"`
+}
+
+func (c *DriveDedupeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	folderID := normalizeGoogleID(strings.TrimSpace(c.FolderID))
+	if c.Recursive && folderID == "" {
+		return usage("--recursive needs a folderId")
+	}
+
+	account, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	var files []driveDedupeFile
+	if folderID != "" {
+		files, err = listDriveDedupeFolder(ctx, svc, folderID, "", c.Recursive, c.MinSize)
+	} else {
+		files, err = listDriveDedupeFiles(ctx, svc, fmt.Sprintf("'me' in owners and trashed = false and mimeType != '%s'", driveMimeFolder), "", c.MinSize)
+	}
+	if err != nil {
+		return err
+	}
+	groups := groupDriveDuplicates(files)
+
+	dupes, reclaimable := 0, int64(0)
+	for _, g := range groups {
+		dupes += len(g.Duplicates)
+		reclaimable += g.Size * int64(len(g.Duplicates))
+	}
+
+	trashed := 0
+	if c.Apply && dupes > 0 {
+		guard := &bulkDestructiveOp{Op: "drive.dedupe", Action: "trash", Noun: "duplicate file", Undo: bulkUndoDriveUntrash, Confirm: true}
+		ids := make([]string, 0, dupes)
+		for _, g := range groups {
+			for _, f := range g.Duplicates {
+				ids = append(ids, f.ID)
+				guard.Items = append(guard.Items, bulkItem{ID: f.ID, Label: orEmpty(f.Path, f.Name)})
+			}
+		}
+		if err := confirmBulkDestructive(ctx, flags, guard, map[string]any{
+			"file_ids":         ids,
+			"reclaimableBytes": reclaimable,
+		}); err != nil {
+			return err
+		}
+		err := trashDriveFiles(ctx, svc, guard.Items, &trashed)
+		journalBulkDestructive(ctx, account, guard, guard.Items[:trashed])
+		if err != nil {
+			return err
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"scanned":          len(files),
+			"groups":           groups,
+			"duplicates":       dupes,
+			"reclaimableBytes": reclaimable,
+			"trashed":          trashed,
+		})
+	}
+	if len(groups) == 0 {
+		u.Err().Printf("No duplicates among %d file%s", len(files), pluralS(len(files)))
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	fmt.Fprintln(w, "MD5\tSIZE\tACTION\tID\tMODIFIED\tNAME")
+	dupAction := "duplicate"
+	if c.Apply {
+		dupAction = "trashed"
+	}
+	name := func(f driveDedupeFile) string {
+		if f.Path != "" {
+			return f.Path
+		}
+		return f.Name
+	}
+	for _, g := range groups {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", g.MD5, humanSize(ctx, g.Size), "keep", g.Keep.ID, humanDateTime(ctx, g.Keep.Modified), name(g.Keep))
+		for _, f := range g.Duplicates {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", g.MD5, humanSize(ctx, g.Size), dupAction, f.ID, humanDateTime(ctx, f.Modified), name(f))
+		}
+	}
+	flush()
+	if c.Apply {
+		u.Err().Printf("Trashed %d duplicate file%s, %s reclaimable once the trash is emptied", trashed, pluralS(trashed), humanSize(ctx, reclaimable))
+	} else {
+		u.Err().Printf("%d duplicate file%s in %d group%s, %s reclaimable; re-run with --apply to trash them", dupes, pluralS(dupes), len(groups), pluralS(len(groups)), humanSize(ctx, reclaimable))
+	}
+	return nil
+}
+
+// trashDriveFiles moves items to the trash in order, counting successes in
+// trashed so callers can journal a partial run.
+func trashDriveFiles(ctx context.Context, svc *drive.Service, items []bulkItem, trashed *int) error {
+	pacer := newBulkPacer()
+	for _, item := range items {
+		if err := pacer.wait(ctx); err != nil {
+			return err
+		}
+		if _, err := svc.Files.Update(item.ID, &drive.File{Trashed: true}).
+			SupportsAllDrives(true).
+			Fields("id, trashed").
+			Context(ctx).
+			Do(); err != nil {
+			return fmt.Errorf("trash %s (%d of %d trashed): %w", item.ID, *trashed, len(items), err)
+		}
+		*trashed++
+	}
+	return nil
+}
+
+// groupDriveDuplicates groups files by md5Checksum and size, keeps the most
+// recently modified copy of each, and orders groups by reclaimable bytes.
+func groupDriveDuplicates(files []driveDedupeFile) []driveDedupeGroup {
+	type key struct {
+		md5  string
+		size int64
+	}
+	byKey := map[key][]driveDedupeFile{}
+	for _, f := range files {
+		k := key{md5: strings.ToLower(f.md5), size: f.Size}
+		byKey[k] = append(byKey[k], f)
+	}
+
+	groups := []driveDedupeGroup{}
+	for k, members := range byKey {
+		if len(members) < 2 {
+			continue
+		}
+		// RFC3339 timestamps from Drive sort lexically; ties fall back to ID
+		// so repeated runs keep the same copy.
+		sort.Slice(members, func(i, j int) bool {
+			if members[i].Modified != members[j].Modified {
+				return members[i].Modified > members[j].Modified
+			}
+			return members[i].ID < members[j].ID
+		})
+		groups = append(groups, driveDedupeGroup{MD5: k.md5, Size: k.size, Keep: members[0], Duplicates: members[1:]})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		wi := groups[i].Size * int64(len(groups[i].Duplicates))
+		wj := groups[j].Size * int64(len(groups[j].Duplicates))
+		if wi != wj {
+			return wi > wj
+		}
+		return groups[i].MD5 < groups[j].MD5
+	})
+	return groups
+}
+
+func listDriveDedupeFolder(ctx context.Context, svc *drive.Service, folderID, prefix string, recursive bool, minSize int64) ([]driveDedupeFile, error) {
+	files, err := listDriveDedupeFiles(ctx, svc, fmt.Sprintf("'%s' in parents and trashed = false", folderID), prefix, minSize)
+	if err != nil || !recursive {
+		return files, err
+	}
+	folders, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+		return fetchDriveDedupePage(ctx, svc, fmt.Sprintf("'%s' in parents and trashed = false and mimeType = '%s'", folderID, driveMimeFolder), pageToken)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, folder := range folders {
+		sub, err := listDriveDedupeFolder(ctx, svc, folder.Id, path.Join(prefix, folder.Name), true, minSize)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, sub...)
+	}
+	return files, nil
+}
+
+func listDriveDedupeFiles(ctx context.Context, svc *drive.Service, q, prefix string, minSize int64) ([]driveDedupeFile, error) {
+	items, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+		return fetchDriveDedupePage(ctx, svc, q, pageToken)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var files []driveDedupeFile
+	for _, item := range items {
+		if item == nil || item.Md5Checksum == "" || item.Size < minSize {
+			continue
+		}
+		f := driveDedupeFile{ID: item.Id, Name: item.Name, Size: item.Size, Modified: item.ModifiedTime, Link: item.WebViewLink, md5: item.Md5Checksum}
+		if prefix != "" {
+			f.Path = path.Join(prefix, item.Name)
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+func fetchDriveDedupePage(ctx context.Context, svc *drive.Service, q, pageToken string) ([]*drive.File, string, error) {
+	call := svc.Files.List().
+		Q(q).
+		Fields(driveDedupeFields).
+		PageSize(1000).
+		SupportsAllDrives(true).
+		IncludeItemsFromAllDrives(true).
+		Context(ctx)
+	if pageToken != "" {
+		call = call.PageToken(pageToken)
+	}
+	resp, err := call.Do()
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Files, resp.NextPageToken, nil
+}
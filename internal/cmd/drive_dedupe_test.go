@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestGroupDriveDuplicates(t *testing.T) {
+	groups := groupDriveDuplicates([]driveDedupeFile{
+		{ID: "a1", Size: 10, Modified: "2025-01-01T00:00:00Z", md5: "aaa"},
+		{ID: "a2", Size: 10, Modified: "2025-02-01T00:00:00Z", md5: "AAA"},
+		{ID: "a3", Size: 10, Modified: "2024-12-01T00:00:00Z", md5: "aaa"},
+		{ID: "b1", Size: 500, Modified: "2025-01-01T00:00:00Z", md5: "bbb"},
+		{ID: "b2", Size: 500, Modified: "2025-01-01T00:00:00Z", md5: "bbb"},
+		{ID: "c1", Size: 7, md5: "ccc"},
+		{ID: "c2", Size: 8, md5: "ccc"},
+	})
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %+v", groups)
+	}
+	if groups[0].MD5 != "bbb" || groups[0].Keep.ID != "b1" || len(groups[0].Duplicates) != 1 {
+		t.Fatalf("largest waste first with ID tie-break, got %+v", groups[0])
+	}
+	if groups[1].Keep.ID != "a2" || len(groups[1].Duplicates) != 2 {
+		t.Fatalf("newest copy kept, got %+v", groups[1])
+	}
+}
+
+func TestDriveDedupeCmd(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var queries, trashed []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files":
+			queries = append(queries, r.URL.Query().Get("q"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "old", "name": "photo.jpg", "size": "2048", "md5Checksum": "abc", "modifiedTime": "2024-01-01T00:00:00Z"},
+				{"id": "new", "name": "photo (1).jpg", "size": "2048", "md5Checksum": "abc", "modifiedTime": "2025-01-01T00:00:00Z"},
+				{"id": "doc", "name": "Notes", "mimeType": driveMimeGoogleDoc},
+			}})
+		case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/files/"):
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), `"trashed":true`) {
+				t.Errorf("expected trashed=true, got %s", body)
+			}
+			trashed = append(trashed, strings.TrimPrefix(r.URL.Path, "/files/"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "old", "trashed": true})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	ctx := newDocsJSONContext(t)
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveDedupeCmd{}, []string{}, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("dedupe: %v", err)
+		}
+	})
+	var report struct {
+		Scanned          int                `json:"scanned"`
+		Groups           []driveDedupeGroup `json:"groups"`
+		ReclaimableBytes int64              `json:"reclaimableBytes"`
+		Trashed          int                `json:"trashed"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if report.Scanned != 2 || len(report.Groups) != 1 || report.Groups[0].Keep.ID != "new" || report.ReclaimableBytes != 2048 || report.Trashed != 0 {
+		t.Fatalf("unexpected report: %s", out)
+	}
+	if !strings.Contains(queries[0], "'me' in owners") || len(trashed) != 0 {
+		t.Fatalf("report-only run: queries=%v trashed=%v", queries, trashed)
+	}
+
+	if err := runKong(t, &DriveDedupeCmd{}, []string{"--apply"}, ctx, &RootFlags{Account: "a@b.com", NoInput: true}); err == nil || !strings.Contains(err.Error(), "without --force") {
+		t.Fatalf("expected confirmation refusal, got %v", err)
+	}
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveDedupeCmd{}, []string{"folder1", "--apply"}, ctx, &RootFlags{Account: "a@b.com", Force: true}); err != nil {
+			t.Fatalf("dedupe --apply: %v", err)
+		}
+	})
+	if !strings.Contains(queries[len(queries)-1], "'folder1' in parents") || len(trashed) != 1 || trashed[0] != "old" {
+		t.Fatalf("expected only the older copy trashed: queries=%v trashed=%v", queries, trashed)
+	}
+}
@@ -0,0 +1,339 @@
+package cmd
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/drivetransfer"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+const driveFolderDownloadFields = "id, name, mimeType, size, md5Checksum, sha256Checksum"
+
+// driveFolderExportDefaults favors editable Office formats for folder
+// downloads, unlike single-file downloads which default to PDF/CSV.
+var driveFolderExportDefaults = map[string]string{
+	driveMimeGoogleDoc:     "docx",
+	driveMimeGoogleSheet:   "xlsx",
+	driveMimeGoogleSlides:  "pptx",
+	driveMimeGoogleDrawing: "png",
+}
+
+type driveFolderEntry struct {
+	Path     string `json:"path"`
+	ID       string `json:"id"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Exported string `json:"exportedAs,omitempty"`
+
+	file *drive.File
+}
+
+type driveFolderSkip struct {
+	Path     string `json:"path"`
+	ID       string `json:"id"`
+	MimeType string `json:"mimeType"`
+}
+
+// runRecursive downloads folder and its subfolders into a directory, or
+// into a zip archive with --zip. Google files are exported using
+// --export-formats; everything else is streamed and checksum-verified.
+func (c *DriveDownloadCmd) runRecursive(ctx context.Context, svc *drive.Service, folder *drive.File) error {
+	u := ui.FromContext(ctx)
+	if folder.MimeType != driveMimeFolder {
+		return usagef("%s is not a folder (drop --recursive/--zip to download a file)", folder.Name)
+	}
+	exports, err := parseDriveExportFormats(c.ExportFormats)
+	if err != nil {
+		return err
+	}
+
+	entries, dirs, skipped, err := listDriveFolderFiles(ctx, svc, folder.Id, exports)
+	if err != nil {
+		return err
+	}
+	for _, s := range skipped {
+		u.Err().Printf("Skipping %s (%s cannot be downloaded)", s.Path, s.MimeType)
+	}
+
+	var dest string
+	if zipPath := strings.TrimSpace(c.Zip); zipPath != "" {
+		dest, err = writeDriveFolderZip(ctx, svc, zipPath, driveSafeName(folder.Name), entries, exports)
+	} else {
+		dest, err = c.writeDriveFolderDir(ctx, svc, folder, entries, dirs, exports)
+	}
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"path":       dest,
+			"folderId":   folder.Id,
+			"files":      entries,
+			"skipped":    skipped,
+			"totalBytes": total,
+		})
+	}
+	u.Out().Printf("path\t%s", dest)
+	u.Out().Printf("files\t%d", len(entries))
+	u.Out().Printf("size\t%s", humanSize(ctx, total))
+	if len(skipped) > 0 {
+		u.Out().Printf("skipped\t%d", len(skipped))
+	}
+	return nil
+}
+
+func (c *DriveDownloadCmd) writeDriveFolderDir(ctx context.Context, svc *drive.Service, folder *drive.File, entries []*driveFolderEntry, dirs []string, exports map[string]string) (string, error) {
+	root := strings.TrimSpace(c.Output.Path)
+	if root == "" {
+		base, err := config.EnsureDriveDownloadsDir()
+		if err != nil {
+			return "", err
+		}
+		root = filepath.Join(base, driveSafeName(folder.Name))
+	}
+	root, err := config.ExpandPath(root)
+	if err != nil {
+		return "", err
+	}
+	for _, dir := range append([]string{"."}, dirs...) {
+		// #nosec G301 -- destination directory is explicitly chosen by the caller.
+		if err := os.MkdirAll(filepath.Join(root, filepath.FromSlash(dir)), 0o700); err != nil {
+			return "", err
+		}
+	}
+	for _, e := range entries {
+		outPath := filepath.Join(root, filepath.FromSlash(e.Path))
+		var size int64
+		if exportMime, ok := exports[e.MimeType]; ok {
+			resp, exportErr := driveExportDownload(ctx, svc, e.ID, exportMime)
+			if exportErr != nil {
+				return "", fmt.Errorf("export %s: %w", e.Path, exportErr)
+			}
+			_, size, err = writeDriveDownloadResponse(resp, outPath)
+		} else {
+			_, size, err = downloadDriveBlob(ctx, svc, e.file, outPath)
+		}
+		if err != nil {
+			return "", fmt.Errorf("download %s: %w", e.Path, err)
+		}
+		e.Size = size
+	}
+	return root, nil
+}
+
+// writeDriveFolderZip streams every entry into a zip under prefix/. The
+// archive is written to a temporary file and only renamed into place once
+// complete, so a failed run never leaves a truncated zip behind.
+func writeDriveFolderZip(ctx context.Context, svc *drive.Service, zipPath, prefix string, entries []*driveFolderEntry, exports map[string]string) (string, error) {
+	zipPath, err := config.ExpandPath(zipPath)
+	if err != nil {
+		return "", err
+	}
+	if dir := filepath.Dir(zipPath); dir != "." {
+		// #nosec G301 -- destination directory is explicitly chosen by the caller.
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return "", err
+		}
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(zipPath), "."+filepath.Base(zipPath)+".*")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	zw := zip.NewWriter(tmp)
+	for _, e := range entries {
+		if err := addDriveZipEntry(ctx, svc, zw, prefix+"/"+e.Path, e, exports); err != nil {
+			_ = tmp.Close()
+			return "", fmt.Errorf("download %s: %w", e.Path, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		_ = tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), zipPath); err != nil {
+		return "", err
+	}
+	return zipPath, nil
+}
+
+func addDriveZipEntry(ctx context.Context, svc *drive.Service, zw *zip.Writer, name string, e *driveFolderEntry, exports map[string]string) error {
+	var (
+		resp *http.Response
+		err  error
+	)
+	exportMime, exported := exports[e.MimeType]
+	if exported {
+		resp, err = driveExportDownload(ctx, svc, e.ID, exportMime)
+	} else {
+		resp, err = driveDownload(ctx, svc, e.ID)
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("download failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	var sum io.Writer = io.Discard
+	h, want, algo := drivetransfer.ChecksumHash(e.file)
+	if !exported && h != nil {
+		sum = h
+	}
+	n, err := io.Copy(io.MultiWriter(w, sum), resp.Body)
+	if err != nil {
+		return err
+	}
+	if !exported && h != nil {
+		if got := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(got, want) {
+			return fmt.Errorf("%s checksum mismatch: got %s, Drive reports %s", algo, got, want)
+		}
+	}
+	e.Size = n
+	return nil
+}
+
+// listDriveFolderFiles walks folderID breadth-first. Paths are slash-separated and
+// unique: names are made filesystem-safe, exported Google files get their
+// export extension, and duplicates get the file ID appended.
+func listDriveFolderFiles(ctx context.Context, svc *drive.Service, folderID string, exports map[string]string) ([]*driveFolderEntry, []string, []driveFolderSkip, error) {
+	var (
+		entries []*driveFolderEntry
+		dirs    []string
+		skipped []driveFolderSkip
+	)
+	used := map[string]bool{}
+	unique := func(rel, id string) string {
+		if used[rel] {
+			ext := path.Ext(rel)
+			rel = strings.TrimSuffix(rel, ext) + "_" + id + ext
+		}
+		used[rel] = true
+		return rel
+	}
+
+	type pending struct{ id, rel string }
+	queue := []pending{{id: folderID, rel: ""}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		children, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+			call := svc.Files.List().
+				Q(fmt.Sprintf("'%s' in parents and trashed = false", cur.id)).
+				Fields("nextPageToken", "files("+driveFolderDownloadFields+")").
+				OrderBy("folder,name").
+				PageSize(1000).
+				SupportsAllDrives(true).
+				IncludeItemsFromAllDrives(true).
+				Context(ctx)
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+			resp, err := call.Do()
+			if err != nil {
+				return nil, "", err
+			}
+			return resp.Files, resp.NextPageToken, nil
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for _, f := range children {
+			if f == nil {
+				continue
+			}
+			rel := path.Join(cur.rel, driveSafeName(f.Name))
+			switch exportMime, ok := exports[f.MimeType]; {
+			case f.MimeType == driveMimeFolder:
+				rel = unique(rel, f.Id)
+				dirs = append(dirs, rel)
+				queue = append(queue, pending{id: f.Id, rel: rel})
+			case ok:
+				rel = unique(rel+driveExportExtension(exportMime), f.Id)
+				entries = append(entries, &driveFolderEntry{Path: rel, ID: f.Id, MimeType: f.MimeType, Exported: exportMime, file: f})
+			case strings.HasPrefix(f.MimeType, "application/vnd.google-apps."):
+				skipped = append(skipped, driveFolderSkip{Path: rel, ID: f.Id, MimeType: f.MimeType})
+			default:
+				rel = unique(rel, f.Id)
+				entries = append(entries, &driveFolderEntry{Path: rel, ID: f.Id, Size: f.Size, MimeType: f.MimeType, file: f})
+			}
+		}
+	}
+	return entries, dirs, skipped, nil
+}
+
+// parseDriveExportFormats maps Google MIME types to export MIME types,
+// starting from driveFolderExportDefaults. Input looks like
+// "doc=pdf,sheet=csv".
+func parseDriveExportFormats(raw string) (map[string]string, error) {
+	formats := map[string]string{}
+	for googleMime, format := range driveFolderExportDefaults {
+		formats[googleMime] = format
+	}
+	for _, part := range splitCSV(raw) {
+		kind, format, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, usagef("invalid --export-formats entry %q (use kind=format, e.g. doc=pdf)", part)
+		}
+		var googleMime string
+		switch strings.ToLower(strings.TrimSpace(kind)) {
+		case "doc", "docs", "document":
+			googleMime = driveMimeGoogleDoc
+		case "sheet", "sheets", "spreadsheet":
+			googleMime = driveMimeGoogleSheet
+		case "slides", "slide", "presentation":
+			googleMime = driveMimeGoogleSlides
+		case "drawing", "drawings":
+			googleMime = driveMimeGoogleDrawing
+		default:
+			return nil, usagef("unknown kind %q in --export-formats (use doc, sheet, slides, drawing)", kind)
+		}
+		formats[googleMime] = strings.ToLower(strings.TrimSpace(format))
+	}
+	exports := make(map[string]string, len(formats))
+	for googleMime, format := range formats {
+		exportMime, err := driveExportMimeTypeForFormat(googleMime, format)
+		if err != nil {
+			return nil, usage(strings.Replace(err.Error(), "--format", "--export-formats", 1))
+		}
+		exports[googleMime] = exportMime
+	}
+	return exports, nil
+}
+
+// driveSafeName turns a Drive name into a single path component.
+func driveSafeName(name string) string {
+	name = strings.NewReplacer("/", "_", "\\", "_", "\x00", "").Replace(strings.TrimSpace(name))
+	if name == "" || name == "." || name == ".." {
+		return "untitled"
+	}
+	return name
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/md5" //nolint:gosec // Drive reports MD5 checksums
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestDriveDownloadCmd_Recursive(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	md5hex := func(s string) string {
+		sum := md5.Sum([]byte(s)) //nolint:gosec // test fixture
+		return hex.EncodeToString(sum[:])
+	}
+	blobs := map[string]string{"b1": "binary one", "b2": "nested", "b3": "same name"}
+	children := map[string][]map[string]any{
+		"root": {
+			{"id": "sub", "name": "Sub", "mimeType": driveMimeFolder},
+			{"id": "b1", "name": "notes.txt", "mimeType": "text/plain", "size": "10", "md5Checksum": md5hex(blobs["b1"])},
+			{"id": "b3", "name": "notes.txt", "mimeType": "text/plain", "size": "9", "md5Checksum": md5hex(blobs["b3"])},
+			{"id": "doc", "name": "Plan", "mimeType": driveMimeGoogleDoc},
+			{"id": "form", "name": "Survey", "mimeType": "application/vnd.google-apps.form"},
+		},
+		"sub": {
+			{"id": "b2", "name": "a/b.bin", "mimeType": "application/octet-stream", "size": "6", "md5Checksum": md5hex(blobs["b2"])},
+		},
+	}
+	var exportMime string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		switch {
+		case p == "/files" && r.Method == http.MethodGet:
+			q := r.URL.Query().Get("q")
+			parent := strings.TrimSuffix(strings.TrimPrefix(q, "'"), "' in parents and trashed = false")
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": children[parent]})
+		case p == "/files/doc/export":
+			exportMime = r.URL.Query().Get("mimeType")
+			_, _ = w.Write([]byte("exported doc"))
+		case strings.HasPrefix(p, "/files/") && r.URL.Query().Get("alt") == "media":
+			_, _ = w.Write([]byte(blobs[strings.TrimPrefix(p, "/files/")]))
+		case p == "/files/root":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "root", "name": "Project", "mimeType": driveMimeFolder})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+	dir := filepath.Join(t.TempDir(), "out")
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveDownloadCmd{}, []string{"root", "--recursive", "--out", dir}, ctx, flags); err != nil {
+			t.Fatalf("recursive download: %v", err)
+		}
+	})
+	var got struct {
+		Files   []driveFolderEntry `json:"files"`
+		Skipped []driveFolderSkip  `json:"skipped"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(got.Files) != 4 || len(got.Skipped) != 1 || got.Skipped[0].Path != "Survey" {
+		t.Fatalf("unexpected result: %s", out)
+	}
+	if exportMime != mimeDocx {
+		t.Fatalf("expected docx export by default, got %q", exportMime)
+	}
+	want := map[string]string{
+		"notes.txt":    "binary one",
+		"notes_b3.txt": "same name",
+		"Plan.docx":    "exported doc",
+		"Sub/a_b.bin":  "nested",
+	}
+	for rel, body := range want {
+		b, readErr := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+		if readErr != nil || string(b) != body {
+			t.Fatalf("%s = %q, %v", rel, b, readErr)
+		}
+	}
+
+	zipPath := filepath.Join(t.TempDir(), "project.zip")
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveDownloadCmd{}, []string{"root", "--zip", zipPath, "--export-formats", "doc=pdf"}, ctx, flags); err != nil {
+			t.Fatalf("zip download: %v", err)
+		}
+	})
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+	defer zr.Close()
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if f.Name == "Project/Sub/a_b.bin" {
+			rc, _ := f.Open()
+			b, _ := io.ReadAll(rc)
+			_ = rc.Close()
+			if string(b) != "nested" {
+				t.Fatalf("unexpected zip content %q", b)
+			}
+		}
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != "Project/Plan.pdf,Project/Sub/a_b.bin,Project/notes.txt,Project/notes_b3.txt" {
+		t.Fatalf("unexpected zip entries: %v", names)
+	}
+
+	// A checksum mismatch must not leave a zip behind.
+	blobs["b2"] = "tampered"
+	badZip := filepath.Join(t.TempDir(), "bad.zip")
+	if err := runKong(t, &DriveDownloadCmd{}, []string{"root", "--zip", badZip}, ctx, flags); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+	if _, statErr := os.Stat(badZip); !os.IsNotExist(statErr) {
+		t.Fatalf("expected no zip file, stat err %v", statErr)
+	}
+
+	if err := runKong(t, &DriveDownloadCmd{}, []string{"root"}, ctx, flags); err == nil || !strings.Contains(err.Error(), "--recursive") {
+		t.Fatalf("expected folder hint, got %v", err)
+	}
+	if err := runKong(t, &DriveDownloadCmd{}, []string{"root", "--recursive", "--export-formats", "sheet=docx"}, ctx, flags); ExitCode(stableExitCode(err)) != 2 {
+		t.Fatalf("expected usage error for invalid export format, got %v", err)
+	}
+}
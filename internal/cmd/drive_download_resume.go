@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/drivetransfer"
+)
+
+// downloadDriveBlob downloads a binary Drive file with resume and checksum
+// verification (see drivetransfer.Download), routing requests through the
+// swappable driveDownload hook for full downloads.
+func downloadDriveBlob(ctx context.Context, svc *drive.Service, meta *drive.File, outPath string) (string, int64, error) {
+	expanded, err := config.ExpandPath(strings.TrimSpace(outPath))
+	if err != nil {
+		return "", 0, err
+	}
+	return drivetransfer.Download(ctx, svc, meta, expanded, drivetransfer.Options{
+		Fetch: func(ctx context.Context, svc *drive.Service, fileID string, offset int64) (*http.Response, error) {
+			if offset > 0 {
+				return drivetransfer.DefaultFetch(ctx, svc, fileID, offset)
+			}
+			return driveDownload(ctx, svc, fileID)
+		},
+		OnResume: func(offset int64) {
+			if u := ui.FromContext(ctx); u != nil {
+				u.Err().Printf("Resuming download at %s", formatDriveSize(offset))
+			}
+		},
+	})
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+var errDriveNoRevisions = errors.New("no revisions")
+
+// parseDriveRevisionAt parses --at. Date-only values mean midnight local
+// time, so --at 2024-06-01 picks the last revision saved before that day.
+func parseDriveRevisionAt(expr string) (time.Time, error) {
+	at, err := parseTimeExpr(expr, time.Now(), time.Local)
+	if err != nil {
+		return time.Time{}, usagef("invalid --at: %v", err)
+	}
+	return at, nil
+}
+
+// resolveDriveRevisionAt returns the newest revision of fileID modified at
+// or before at.
+func resolveDriveRevisionAt(ctx context.Context, svc *drive.Service, fileID string, at time.Time) (*drive.Revision, error) {
+	var (
+		best     *drive.Revision
+		bestTime time.Time
+		earliest time.Time
+	)
+	err := svc.Revisions.List(fileID).
+		PageSize(1000).
+		Fields("nextPageToken", "revisions("+driveRevisionFields+")").
+		Pages(ctx, func(resp *drive.RevisionList) error {
+			for _, r := range resp.Revisions {
+				if r == nil || r.Id == "" {
+					continue
+				}
+				modified, err := time.Parse(time.RFC3339, r.ModifiedTime)
+				if err != nil {
+					continue
+				}
+				if earliest.IsZero() || modified.Before(earliest) {
+					earliest = modified
+				}
+				if modified.After(at) {
+					continue
+				}
+				if best == nil || modified.After(bestTime) {
+					best, bestTime = r, modified
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	if best == nil {
+		if earliest.IsZero() {
+			return nil, fmt.Errorf("file %s has %w", fileID, errDriveNoRevisions)
+		}
+		return nil, fmt.Errorf("no revision of %s at or before %s (earliest is %s)", fileID, at.Format(time.RFC3339), earliest.Format(time.RFC3339))
+	}
+	return best, nil
+}
+
+// driveAsOf returns the global --as-of time, or the zero time when unset.
+func driveAsOf(flags *RootFlags) (time.Time, error) {
+	if flags == nil || strings.TrimSpace(flags.AsOf) == "" {
+		return time.Time{}, nil
+	}
+	at, err := parseTimeExpr(strings.TrimSpace(flags.AsOf), time.Now(), time.Local)
+	if err != nil {
+		return time.Time{}, usagef("invalid --as-of: %v", err)
+	}
+	return at, nil
+}
+
+// asOfCommands are the commands that honor --as-of; any other command fails
+// instead of silently reading the current version.
+var asOfCommands = map[string]bool{
+	"download":       true,
+	"drive download": true,
+	"docs export":    true,
+	"sheets export":  true,
+	"slides export":  true,
+}
+
+// enforceAsOfSupport rejects --as-of on commands that cannot read a past
+// revision. command is kong's selected command path.
+func enforceAsOfSupport(command, asOf string) error {
+	if strings.TrimSpace(asOf) == "" {
+		return nil
+	}
+	var path []string
+	for _, f := range strings.Fields(command) {
+		if !strings.HasPrefix(f, "<") {
+			path = append(path, f)
+		}
+	}
+	name := strings.Join(path, " ")
+	if asOfCommands[name] {
+		return nil
+	}
+	return usagef("--as-of is not supported by %q (supported: drive download, docs|sheets|slides export)", name)
+}
+
+// resolveDriveRevisionAsOf picks the revision of meta to read for --as-of.
+// It is best-effort: files without a readable revision history (folders,
+// files you may only view) fall back to the head version with a warning and
+// a nil revision. A file whose history starts after at is still an error,
+// since its head cannot be part of the snapshot.
+func resolveDriveRevisionAsOf(ctx context.Context, svc *drive.Service, meta *drive.File, at time.Time) (*drive.Revision, error) {
+	rev, err := resolveDriveRevisionAt(ctx, svc, meta.Id, at)
+	if err == nil {
+		return rev, nil
+	}
+	var apiErr *gapi.Error
+	if errors.Is(err, errDriveNoRevisions) ||
+		(errors.As(err, &apiErr) && (apiErr.Code == http.StatusBadRequest || apiErr.Code == http.StatusForbidden)) {
+		if u := ui.FromContext(ctx); u != nil {
+			u.Err().Printf("--as-of: no revision history for %s (%s); using the current version", meta.Name, meta.Id)
+		}
+		return nil, nil
+	}
+	return nil, err
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestDriveDownloadCmd_At(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var downloaded string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/files/f1/revisions"):
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"revisions": []any{
+				map[string]any{"id": "r1", "modifiedTime": "2024-05-01T10:00:00Z"},
+				map[string]any{"id": "r2", "modifiedTime": "2024-05-31T23:00:00Z"},
+				map[string]any{"id": "r3", "modifiedTime": "2024-06-02T08:00:00Z"},
+			}})
+		case strings.Contains(r.URL.Path, "/files/f1/revisions/") && r.URL.Query().Get("alt") == "media":
+			downloaded = r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			_, _ = w.Write([]byte("v" + downloaded))
+		case strings.HasSuffix(r.URL.Path, "/files/f1"):
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "f1", "name": "build.tar", "mimeType": "application/x-tar"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+	dir := t.TempDir()
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveDownloadCmd{}, []string{"f1", "--at", "2024-06-01T00:00:00Z", "--out", dir}, ctx, flags); err != nil {
+			t.Fatalf("download: %v", err)
+		}
+	})
+	if downloaded != "r2" {
+		t.Fatalf("expected revision r2, got %q", downloaded)
+	}
+	var got struct {
+		Path     string `json:"path"`
+		Revision string `json:"revision"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if got.Revision != "r2" || filepath.Base(got.Path) != "f1_build.tar_revr2" {
+		t.Fatalf("unexpected output: %#v", got)
+	}
+	if b, err := os.ReadFile(got.Path); err != nil || string(b) != "vr2" {
+		t.Fatalf("unexpected file content %q: %v", b, err)
+	}
+
+	err = runKong(t, &DriveDownloadCmd{}, []string{"f1", "--at", "2024-01-01T00:00:00Z", "--out", dir}, ctx, flags)
+	if err == nil || !strings.Contains(err.Error(), "earliest is 2024-05-01T10:00:00Z") {
+		t.Fatalf("expected no-revision error, got %v", err)
+	}
+
+	if err := runKong(t, &DriveDownloadCmd{}, []string{"f1", "--revision", "r1", "--at", "2024-06-01"}, ctx, flags); err == nil {
+		t.Fatal("expected --revision with --at to be rejected")
+	}
+}
+
+func TestDriveDownloadCmd_AsOf(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var downloaded []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/files/f1/revisions"):
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"revisions": []any{
+				map[string]any{"id": "r1", "modifiedTime": "2024-05-01T10:00:00Z"},
+				map[string]any{"id": "r2", "modifiedTime": "2024-06-02T08:00:00Z"},
+			}})
+		case strings.HasSuffix(r.URL.Path, "/files/f2/revisions"):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 403, "message": "insufficientFilePermissions"}})
+		case r.URL.Query().Get("alt") == "media":
+			downloaded = append(downloaded, strings.TrimPrefix(r.URL.Path, "/files/"))
+			_, _ = w.Write([]byte("data"))
+		case strings.HasPrefix(r.URL.Path, "/files/"):
+			id := strings.TrimPrefix(r.URL.Path, "/files/")
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "name": id + ".bin", "mimeType": "application/octet-stream"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	flags := &RootFlags{Account: "a@b.com", AsOf: "2024-06-01T00:00:00Z"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+	dir := t.TempDir()
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveDownloadCmd{}, []string{"f1", "--out", dir}, ctx, flags); err != nil {
+			t.Fatalf("download f1: %v", err)
+		}
+		// No readable history: best-effort falls back to the head version.
+		if err := runKong(t, &DriveDownloadCmd{}, []string{"f2", "--out", dir}, ctx, flags); err != nil {
+			t.Fatalf("download f2: %v", err)
+		}
+		// An explicit --revision wins over the global snapshot time.
+		if err := runKong(t, &DriveDownloadCmd{}, []string{"f1", "--revision", "r2", "--out", dir}, ctx, flags); err != nil {
+			t.Fatalf("download f1 r2: %v", err)
+		}
+	})
+	if strings.Join(downloaded, ",") != "f1/revisions/r1,f2,f1/revisions/r2" {
+		t.Fatalf("unexpected downloads: %v", downloaded)
+	}
+
+	if _, err := driveAsOf(&RootFlags{AsOf: "not a time"}); err == nil {
+		t.Fatal("expected invalid --as-of error")
+	}
+}
+
+func TestAsOfRejectedOnUnsupportedCommands(t *testing.T) {
+	for _, args := range [][]string{
+		{"--as-of", "2024-06-30", "docs", "cat", "doc1"},
+		{"--as-of", "2024-06-30", "sheets", "get", "s1", "A1"},
+	} {
+		errOut := captureStderr(t, func() {
+			err := Execute(args)
+			if ExitCode(err) != 2 {
+				t.Fatalf("%v: expected usage error, got %v", args, err)
+			}
+		})
+		if !strings.Contains(errOut, "--as-of is not supported by") {
+			t.Fatalf("%v: unexpected stderr %q", args, errOut)
+		}
+	}
+
+	for _, command := range []string{"drive download <fileId>", "docs export <docId>", "download <fileId>"} {
+		if err := enforceAsOfSupport(command, "yesterday"); err != nil {
+			t.Fatalf("%s: %v", command, err)
+		}
+	}
+	if err := enforceAsOfSupport("docs cat <docId>", ""); err != nil {
+		t.Fatalf("unset --as-of: %v", err)
+	}
+}
@@ -7,8 +7,8 @@ import (
 
 	"google.golang.org/api/drive/v3"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 // DriveDrivesCmd lists all shared drives the user has access to.
@@ -71,7 +71,7 @@ func (c *DriveDrivesCmd) Run(ctx context.Context, flags *RootFlags) error {
 			"%s\t%s\t%s\n",
 			d.Id,
 			d.Name,
-			formatDateTime(d.CreatedTime),
+			humanDateTime(ctx, d.CreatedTime),
 		)
 	}
 	printNextPageHint(u, nextPageToken)
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+const driveExportAllManifest = "manifest.json"
+
+// driveExportAllRetryDelay is the base backoff between export attempts.
+var driveExportAllRetryDelay = 2 * time.Second
+
+// DriveExportAllCmd exports every Google Doc, Sheet, and Slides file under a
+// folder for archival. Failures don't stop the run; they are recorded in
+// manifest.json next to the exports.
+type DriveExportAllCmd struct {
+	FolderID string `arg:"" name:"folderId" help:"Folder to export (subfolders included)"`
+	Out      string `name:"out" short:"o" required:"" help:"Directory to write exports and manifest.json into"`
+	Format   string `name:"format" help:"Export format for every file (e.g. pdf, or docx/xlsx/pptx for a single kind)" default:"pdf"`
+	Workers  int    `name:"workers" help:"Concurrent exports" default:"4"`
+	Retries  int    `name:"retries" help:"Retries per file on rate limits and server errors" default:"3"`
+}
+
+type driveExportAllFile struct {
+	Path     string `json:"path"`
+	ID       string `json:"id"`
+	MimeType string `json:"mimeType"`
+	Size     int64  `json:"size,omitempty"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error,omitempty"`
+
+	exportMime string
+}
+
+func (c *DriveExportAllCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	folderID := normalizeGoogleID(strings.TrimSpace(c.FolderID))
+	if folderID == "" {
+		return usage("empty folderId")
+	}
+	outDir, err := config.ExpandPath(strings.TrimSpace(c.Out))
+	if err != nil {
+		return err
+	}
+	if outDir == "" {
+		return usage("empty --out")
+	}
+	if c.Workers < 1 {
+		return usage("--workers must be >= 1")
+	}
+	if c.Retries < 0 {
+		return usage("--retries must be >= 0")
+	}
+	exports, err := driveExportAllFormats(c.Format)
+	if err != nil {
+		return err
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	folder, err := svc.Files.Get(folderID).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	if folder.MimeType != driveMimeFolder {
+		return usagef("%s is not a folder", folder.Name)
+	}
+
+	entries, _, skipped, err := listDriveFolderFiles(ctx, svc, folder.Id, exports)
+	if err != nil {
+		return err
+	}
+	var files []*driveExportAllFile
+	for _, e := range entries {
+		if e.Exported == "" {
+			continue // only Google files are exported; uploads are left alone
+		}
+		files = append(files, &driveExportAllFile{Path: e.Path, ID: e.ID, MimeType: e.MimeType, exportMime: e.Exported})
+	}
+	if skipped == nil {
+		skipped = []driveFolderSkip{}
+	}
+
+	// #nosec G301 -- destination directory is explicitly chosen by the caller.
+	if err := os.MkdirAll(outDir, 0o700); err != nil {
+		return err
+	}
+	exportDriveFiles(ctx, svc, outDir, files, c.Workers, c.Retries)
+
+	exported := []*driveExportAllFile{}
+	failed := []*driveExportAllFile{}
+	for _, f := range files {
+		if f.Error != "" {
+			failed = append(failed, f)
+		} else {
+			exported = append(exported, f)
+		}
+	}
+	manifest := map[string]any{
+		"folderId":   folder.Id,
+		"folderName": folder.Name,
+		"format":     strings.ToLower(strings.TrimSpace(c.Format)),
+		"exportedAt": time.Now().UTC().Format(time.RFC3339),
+		"exported":   exported,
+		"failed":     failed,
+		"skipped":    skipped,
+	}
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	manifestPath := filepath.Join(outDir, driveExportAllManifest)
+	if err := os.WriteFile(manifestPath, append(raw, '\n'), 0o600); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		manifest["manifest"] = manifestPath
+		if err := outfmt.WriteJSON(ctx, os.Stdout, manifest); err != nil {
+			return err
+		}
+	} else {
+		for _, f := range failed {
+			u.Err().Printf("Failed %s: %s", f.Path, f.Error)
+		}
+		for _, s := range skipped {
+			u.Err().Printf("Skipping %s (%s cannot be exported as %s)", s.Path, s.MimeType, c.Format)
+		}
+		u.Out().Printf("path\t%s", outDir)
+		u.Out().Printf("exported\t%d", len(exported))
+		u.Out().Printf("failed\t%d", len(failed))
+		if len(skipped) > 0 {
+			u.Out().Printf("skipped\t%d", len(skipped))
+		}
+		u.Out().Printf("manifest\t%s", manifestPath)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d files failed to export (see %s)", len(failed), len(files), manifestPath)
+	}
+	return nil
+}
+
+// driveExportAllFormats maps Docs, Sheets, and Slides to the export MIME
+// type for format. Kinds that can't be exported as format are left out (and
+// end up skipped); it is a usage error if none can.
+func driveExportAllFormats(format string) (map[string]string, error) {
+	exports := map[string]string{}
+	var firstErr error
+	for _, googleMime := range []string{driveMimeGoogleDoc, driveMimeGoogleSheet, driveMimeGoogleSlides} {
+		exportMime, err := driveExportMimeTypeForFormat(googleMime, format)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		exports[googleMime] = exportMime
+	}
+	if len(exports) == 0 {
+		return nil, usage(firstErr.Error())
+	}
+	return exports, nil
+}
+
+func exportDriveFiles(ctx context.Context, svc *drive.Service, outDir string, files []*driveExportAllFile, workers, retries int) {
+	jobs := make(chan *driveExportAllFile)
+	var wg sync.WaitGroup
+	for range min(workers, max(len(files), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range jobs {
+				size, attempts, err := exportDriveFile(ctx, svc, f.ID, f.exportMime, filepath.Join(outDir, filepath.FromSlash(f.Path)), retries)
+				f.Size, f.Attempts = size, attempts
+				if err != nil {
+					f.Error = err.Error()
+				}
+			}
+		}()
+	}
+	for _, f := range files {
+		if ctx.Err() != nil {
+			f.Error = ctx.Err().Error()
+			continue
+		}
+		jobs <- f
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// exportDriveFile exports one file, retrying transient failures with linear
+// backoff.
+func exportDriveFile(ctx context.Context, svc *drive.Service, fileID, exportMime, outPath string, retries int) (int64, int, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := driveExportDownload(ctx, svc, fileID, exportMime)
+		if err == nil {
+			var size int64
+			_, size, err = writeDriveDownloadResponse(resp, outPath)
+			if err == nil {
+				return size, attempt, nil
+			}
+		}
+		if attempt > retries || !isRetryableError(err) {
+			return 0, attempt, err
+		}
+		select {
+		case <-ctx.Done():
+			return 0, attempt, ctx.Err()
+		case <-time.After(time.Duration(attempt) * driveExportAllRetryDelay):
+		}
+	}
+}
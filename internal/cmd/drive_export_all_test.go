@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+)
+
+func TestDriveExportAllFormats(t *testing.T) {
+	exports, err := driveExportAllFormats("pdf")
+	if err != nil || len(exports) != 3 {
+		t.Fatalf("pdf: %v, %v", exports, err)
+	}
+	exports, err = driveExportAllFormats("xlsx")
+	if err != nil || len(exports) != 1 || exports[driveMimeGoogleSheet] == "" {
+		t.Fatalf("xlsx: %v, %v", exports, err)
+	}
+	if _, err := driveExportAllFormats("bogus"); ExitCode(stableExitCode(err)) != 2 {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
+func TestDriveExportAllCmd(t *testing.T) {
+	origNew, origExport, origDelay := newDriveService, driveExportDownload, driveExportAllRetryDelay
+	t.Cleanup(func() {
+		newDriveService, driveExportDownload, driveExportAllRetryDelay = origNew, origExport, origDelay
+	})
+	driveExportAllRetryDelay = 0
+
+	children := map[string][]map[string]any{
+		"root": {
+			{"id": "doc1", "name": "Plan", "mimeType": driveMimeGoogleDoc},
+			{"id": "sub", "name": "Finance", "mimeType": driveMimeFolder},
+			{"id": "pdf1", "name": "scan.pdf", "mimeType": "application/pdf", "size": "10"},
+			{"id": "form1", "name": "Survey", "mimeType": "application/vnd.google-apps.form"},
+		},
+		"sub": {
+			{"id": "sheet1", "name": "Budget", "mimeType": driveMimeGoogleSheet},
+			{"id": "big", "name": "Huge deck", "mimeType": driveMimeGoogleSlides},
+		},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		switch {
+		case path == "/files/root":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "root", "name": "Archive", "mimeType": driveMimeFolder})
+		case path == "/files":
+			q := r.URL.Query().Get("q")
+			id := strings.TrimPrefix(q[:strings.Index(q, "' in parents")], "'")
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": children[id]})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+	driveExportDownload = func(_ context.Context, _ *drive.Service, id, mimeType string) (*http.Response, error) {
+		mu.Lock()
+		calls[id]++
+		n := calls[id]
+		mu.Unlock()
+		switch {
+		case mimeType != mimePDF:
+			t.Errorf("unexpected export mime %q", mimeType)
+		case id == "sheet1" && n == 1:
+			return nil, &googleapi.Error{Code: http.StatusTooManyRequests, Message: "rateLimitExceeded"}
+		case id == "big":
+			return nil, &googleapi.Error{Code: http.StatusForbidden, Message: "exportSizeLimitExceeded"}
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("%PDF " + id))}, nil
+	}
+
+	dir := t.TempDir()
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = Execute([]string{"--json", "--account", "a@b.com", "drive", "export-all", "root", "-o", dir, "--workers", "2"})
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "1 of 3 files failed") {
+		t.Fatalf("expected partial failure, got %v", runErr)
+	}
+	var manifest struct {
+		Exported []driveExportAllFile `json:"exported"`
+		Failed   []driveExportAllFile `json:"failed"`
+		Skipped  []driveFolderSkip    `json:"skipped"`
+	}
+	if err := json.Unmarshal([]byte(out), &manifest); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(manifest.Exported) != 2 || len(manifest.Failed) != 1 || manifest.Failed[0].ID != "big" || len(manifest.Skipped) != 1 {
+		t.Fatalf("unexpected manifest: %s", out)
+	}
+	for _, f := range manifest.Exported {
+		if f.ID == "sheet1" && f.Attempts != 2 {
+			t.Fatalf("expected sheet1 retried once, got %d attempts", f.Attempts)
+		}
+	}
+	if got, err := os.ReadFile(filepath.Join(dir, "Finance", "Budget.pdf")); err != nil || string(got) != "%PDF sheet1" {
+		t.Fatalf("Budget.pdf = %q, %v", got, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "scan.pdf")); !os.IsNotExist(err) {
+		t.Fatalf("uploads should not be exported, stat err %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, driveExportAllManifest)); err != nil {
+		t.Fatalf("manifest: %v", err)
+	}
+}
@@ -64,6 +64,12 @@ func TestDriveExportMimeTypeForFormat(t *testing.T) {
 			format:     "html",
 			wantMime:   "text/html",
 		},
+		{
+			name:       "doc_epub",
+			googleMime: "application/vnd.google-apps.document",
+			format:     "epub",
+			wantMime:   "application/epub+zip",
+		},
 		{
 			name:        "doc_invalid",
 			googleMime:  "application/vnd.google-apps.document",
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// DriveGenerateIDsCmd pre-allocates file IDs. Pipelines can record an ID
+// before uploading and pass it to drive upload --id: a retry with the same ID
+// fails instead of creating a second copy, and other files can reference the
+// ID before the upload finishes.
+type DriveGenerateIDsCmd struct {
+	Count int64  `name:"count" help:"Number of IDs to generate (1-1000)" default:"10"`
+	Space string `name:"space" help:"Space the IDs will be used in: drive|appDataFolder" default:"drive" enum:"drive,appDataFolder"`
+	Type  string `name:"type" help:"Item type the IDs will be used for: files|shortcuts (shortcuts only in the drive space)" default:"files" enum:"files,shortcuts"`
+}
+
+func (c *DriveGenerateIDsCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Count < 1 || c.Count > 1000 {
+		return usage("--count must be between 1 and 1000")
+	}
+	if c.Type == "shortcuts" && c.Space != "drive" {
+		return usage("--type shortcuts is only supported with --space drive")
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	resp, err := svc.Files.GenerateIds().
+		Count(c.Count).
+		Space(c.Space).
+		Type(c.Type).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"ids":   resp.Ids,
+			"space": orEmpty(resp.Space, c.Space),
+			"type":  c.Type,
+		})
+	}
+	for _, id := range resp.Ids {
+		u.Out().Println(id)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDriveGenerateIDsCmd(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var query, uploadBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/files/generateIds":
+			query = r.URL.RawQuery
+			_ = json.NewEncoder(w).Encode(map[string]any{"kind": "drive#generatedIds", "space": "drive", "ids": []string{"id1", "id2", "id3"}})
+		case strings.Contains(r.URL.Path, "/upload/") && r.Method == http.MethodPost:
+			b, _ := io.ReadAll(r.Body)
+			uploadBody = string(b)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "id1", "name": "a.txt"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	flags := &RootFlags{Account: "a@b.com"}
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveGenerateIDsCmd{}, []string{"--count", "3", "--type", "shortcuts"}, newDocsJSONContext(t), flags); err != nil {
+			t.Fatalf("generate-ids: %v", err)
+		}
+	})
+	for _, want := range []string{"count=3", "space=drive", "type=shortcuts"} {
+		if !strings.Contains(query, want) {
+			t.Fatalf("query %q missing %s", query, want)
+		}
+	}
+	var got struct {
+		IDs   []string `json:"ids"`
+		Space string   `json:"space"`
+		Type  string   `json:"type"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if strings.Join(got.IDs, ",") != "id1,id2,id3" || got.Space != "drive" || got.Type != "shortcuts" {
+		t.Fatalf("unexpected output: %s", out)
+	}
+
+	local := filepath.Join(t.TempDir(), "a.txt")
+	if err := os.WriteFile(local, []byte("data"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveUploadCmd{}, []string{local, "--id", "id1"}, newDocsJSONContext(t), flags); err != nil {
+			t.Fatalf("upload --id: %v", err)
+		}
+	})
+	if !strings.Contains(uploadBody, `"id":"id1"`) {
+		t.Fatalf("upload metadata missing pre-allocated id: %q", uploadBody)
+	}
+}
+
+func TestDriveGenerateIDsCmd_Validation(t *testing.T) {
+	flags := &RootFlags{Account: "a@b.com"}
+	for _, args := range [][]string{
+		{"--count", "0"},
+		{"--count", "1001"},
+		{"--space", "appDataFolder", "--type", "shortcuts"},
+	} {
+		err := runKong(t, &DriveGenerateIDsCmd{}, args, context.Background(), flags)
+		if err == nil || ExitCode(stableExitCode(err)) != 2 {
+			t.Fatalf("%v: expected usage error, got %v", args, err)
+		}
+	}
+	local := filepath.Join(t.TempDir(), "a.txt")
+	if err := os.WriteFile(local, []byte("data"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	for _, args := range [][]string{
+		{local, local, "--id", "id1"},
+		{local, "--id", "id1", "--replace", "file1"},
+	} {
+		err := runKong(t, &DriveUploadCmd{}, args, context.Background(), flags)
+		if err == nil || ExitCode(stableExitCode(err)) != 2 {
+			t.Fatalf("upload %v: expected usage error, got %v", args[1:], err)
+		}
+	}
+}
@@ -57,6 +57,9 @@ func TestDriveExportExtension(t *testing.T) {
 	if got := driveExportExtension("text/html"); got != ".html" {
 		t.Fatalf("unexpected: %q", got)
 	}
+	if got := driveExportExtension("application/epub+zip"); got != ".epub" {
+		t.Fatalf("unexpected: %q", got)
+	}
 	if got := driveExportExtension("nope"); got != ".pdf" {
 		t.Fatalf("unexpected: %q", got)
 	}
@@ -0,0 +1,422 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/drivelabels/v2"
+
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+var newDriveLabelsService = googleapi.NewDriveLabels
+
+// DriveLabelsCmd manages Drive Labels (classification and retention
+// metadata). Label definitions come from the Drive Labels API; reading and
+// changing a file's labels goes through the Drive API.
+type DriveLabelsCmd struct {
+	List   DriveLabelsListCmd   `cmd:"" name:"list" aliases:"ls" help:"List labels available to you, with their fields"`
+	Get    DriveLabelsGetCmd    `cmd:"" name:"get" aliases:"show" help:"Show the labels and field values applied to a file"`
+	Apply  DriveLabelsApplyCmd  `cmd:"" name:"apply" aliases:"set,add" help:"Apply a label to a file and set its fields"`
+	Remove DriveLabelsRemoveCmd `cmd:"" name:"remove" aliases:"rm,unset" help:"Remove a label (and its field values) from a file"`
+}
+
+const (
+	driveLabelFieldText      = "text"
+	driveLabelFieldInteger   = "integer"
+	driveLabelFieldDate      = "date"
+	driveLabelFieldSelection = "selection"
+	driveLabelFieldUser      = "user"
+)
+
+type DriveLabelsListCmd struct {
+	Max  int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
+	Page string `name:"page" aliases:"cursor" help:"Page token"`
+	All  bool   `name:"all" aliases:"all-pages,allpages" help:"Fetch all pages"`
+}
+
+func (c *DriveLabelsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Max <= 0 {
+		return usage("--max must be > 0")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newDriveLabelsService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	labels, nextPageToken, err := loadPagedItems(c.Page, c.All, func(pageToken string) ([]*drivelabels.GoogleAppsDriveLabelsV2Label, string, error) {
+		call := svc.Labels.List().
+			PublishedOnly(true).
+			View("LABEL_VIEW_FULL").
+			PageSize(c.Max).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Labels, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if labels == nil {
+			labels = []*drivelabels.GoogleAppsDriveLabelsV2Label{}
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"labels":        labels,
+			"nextPageToken": nextPageToken,
+		})
+	}
+	if len(labels) == 0 {
+		u.Err().Println("No labels")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tTITLE\tTYPE\tFIELDS")
+	for _, l := range labels {
+		title := ""
+		if l.Properties != nil {
+			title = l.Properties.Title
+		}
+		fields := make([]string, 0, len(l.Fields))
+		for _, f := range l.Fields {
+			fields = append(fields, fmt.Sprintf("%s:%s", driveLabelFieldName(f), driveLabelFieldType(f)))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", l.Id, title, strings.ToLower(l.LabelType), strings.Join(fields, ", "))
+	}
+	printNextPageHint(u, nextPageToken)
+	return nil
+}
+
+type DriveLabelsGetCmd struct {
+	FileID string `arg:"" name:"fileId" help:"File ID"`
+}
+
+func (c *DriveLabelsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	labels, err := collectAllPages("", func(pageToken string) ([]*drive.Label, string, error) {
+		call := svc.Files.ListLabels(fileID).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Labels, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if labels == nil {
+			labels = []*drive.Label{}
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"fileId": fileID,
+			"labels": labels,
+		})
+	}
+	if len(labels) == 0 {
+		u.Err().Println("No labels")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "LABEL\tFIELD\tVALUE")
+	for _, l := range labels {
+		if len(l.Fields) == 0 {
+			fmt.Fprintf(w, "%s\t-\t-\n", l.Id)
+			continue
+		}
+		ids := make([]string, 0, len(l.Fields))
+		for id := range l.Fields {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", l.Id, id, driveLabelFieldValue(l.Fields[id]))
+		}
+	}
+	return nil
+}
+
+type DriveLabelsApplyCmd struct {
+	FileID  string   `arg:"" name:"fileId" help:"File ID"`
+	LabelID string   `arg:"" name:"labelId" help:"Label ID (see 'drive labels list')"`
+	Fields  []string `name:"field" help:"Set a field: fieldId=value (field ID or display name; choice name for selections; repeat for multiple values; empty value clears)" sep:"none"`
+}
+
+func (c *DriveLabelsApplyCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	labelID := strings.TrimSpace(c.LabelID)
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+	if labelID == "" {
+		return usage("empty labelId")
+	}
+
+	account, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	mod := &drive.LabelModification{LabelId: labelID}
+	if len(c.Fields) > 0 {
+		labelsSvc, err := newDriveLabelsService(ctx, account)
+		if err != nil {
+			return err
+		}
+		label, err := labelsSvc.Labels.Get("labels/" + labelID).
+			View("LABEL_VIEW_FULL").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return err
+		}
+		if mod.FieldModifications, err = buildDriveLabelFieldModifications(label, c.Fields); err != nil {
+			return err
+		}
+	}
+
+	if err := dryRunExit(ctx, flags, "drive.labels.apply", map[string]any{
+		"file_id":      fileID,
+		"modification": mod,
+	}); err != nil {
+		return err
+	}
+	return modifyDriveLabels(ctx, u, svc, fileID, mod, "applied")
+}
+
+type DriveLabelsRemoveCmd struct {
+	FileID  string `arg:"" name:"fileId" help:"File ID"`
+	LabelID string `arg:"" name:"labelId" help:"Label ID"`
+}
+
+func (c *DriveLabelsRemoveCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	labelID := strings.TrimSpace(c.LabelID)
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+	if labelID == "" {
+		return usage("empty labelId")
+	}
+
+	if err := dryRunAndConfirmDestructive(ctx, flags, "drive.labels.remove", map[string]any{
+		"file_id":  fileID,
+		"label_id": labelID,
+	}, fmt.Sprintf("remove label %s (and its field values) from file %s", labelID, fileID)); err != nil {
+		return err
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	return modifyDriveLabels(ctx, u, svc, fileID, &drive.LabelModification{LabelId: labelID, RemoveLabel: true}, "removed")
+}
+
+func modifyDriveLabels(ctx context.Context, u *ui.UI, svc *drive.Service, fileID string, mod *drive.LabelModification, action string) error {
+	resp, err := svc.Files.ModifyLabels(fileID, &drive.ModifyLabelsRequest{
+		LabelModifications: []*drive.LabelModification{mod},
+	}).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		labels := resp.ModifiedLabels
+		if labels == nil {
+			labels = []*drive.Label{}
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"fileId":  fileID,
+			"labelId": mod.LabelId,
+			action:    true,
+			"labels":  labels,
+		})
+	}
+	u.Out().Printf("%s\t%s", action, mod.LabelId)
+	u.Out().Printf("fileId\t%s", fileID)
+	for _, fm := range mod.FieldModifications {
+		u.Out().Printf("field\t%s", fm.FieldId)
+	}
+	return nil
+}
+
+// buildDriveLabelFieldModifications turns --field key=value pairs into
+// typed field modifications. Keys match a field ID or display name;
+// selection values match a choice ID or display name. Repeating a key sets
+// several values; an empty value clears the field.
+func buildDriveLabelFieldModifications(label *drivelabels.GoogleAppsDriveLabelsV2Label, raw []string) ([]*drive.LabelFieldModification, error) {
+	var mods []*drive.LabelFieldModification
+	byField := map[string]*drive.LabelFieldModification{}
+	for _, kv := range raw {
+		key, value, ok := strings.Cut(kv, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" {
+			return nil, usagef("invalid --field %q (use fieldId=value)", kv)
+		}
+		field := findDriveLabelField(label, key)
+		if field == nil {
+			return nil, usagef("label %s has no field %q", label.Id, key)
+		}
+		mod := byField[field.Id]
+		if mod == nil {
+			mod = &drive.LabelFieldModification{FieldId: field.Id}
+			byField[field.Id] = mod
+			mods = append(mods, mod)
+		}
+		if value == "" {
+			mod.UnsetValues = true
+			continue
+		}
+
+		switch driveLabelFieldType(field) {
+		case driveLabelFieldText:
+			mod.SetTextValues = append(mod.SetTextValues, value)
+		case driveLabelFieldInteger:
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, usagef("field %q: %q is not an integer", key, value)
+			}
+			mod.SetIntegerValues = append(mod.SetIntegerValues, n)
+		case driveLabelFieldDate:
+			if _, err := time.Parse(time.DateOnly, value); err != nil {
+				return nil, usagef("field %q: %q is not a date (YYYY-MM-DD)", key, value)
+			}
+			mod.SetDateValues = append(mod.SetDateValues, value)
+		case driveLabelFieldSelection:
+			choiceID, ok := findDriveLabelChoice(field, value)
+			if !ok {
+				return nil, usagef("field %q has no choice %q", key, value)
+			}
+			mod.SetSelectionValues = append(mod.SetSelectionValues, choiceID)
+		case driveLabelFieldUser:
+			mod.SetUserValues = append(mod.SetUserValues, value)
+		default:
+			return nil, usagef("field %q has an unsupported type", key)
+		}
+	}
+	for _, mod := range mods {
+		if mod.UnsetValues && driveLabelModHasValues(mod) {
+			return nil, usagef("field %q: cannot both clear and set values", mod.FieldId)
+		}
+	}
+	return mods, nil
+}
+
+func driveLabelModHasValues(mod *drive.LabelFieldModification) bool {
+	return len(mod.SetTextValues)+len(mod.SetIntegerValues)+len(mod.SetDateValues)+len(mod.SetSelectionValues)+len(mod.SetUserValues) > 0
+}
+
+func findDriveLabelField(label *drivelabels.GoogleAppsDriveLabelsV2Label, key string) *drivelabels.GoogleAppsDriveLabelsV2Field {
+	for _, f := range label.Fields {
+		if f.Id == key {
+			return f
+		}
+	}
+	for _, f := range label.Fields {
+		if strings.EqualFold(driveLabelFieldName(f), key) {
+			return f
+		}
+	}
+	return nil
+}
+
+func findDriveLabelChoice(field *drivelabels.GoogleAppsDriveLabelsV2Field, value string) (string, bool) {
+	for _, ch := range field.SelectionOptions.Choices {
+		if ch.Id == value {
+			return ch.Id, true
+		}
+	}
+	for _, ch := range field.SelectionOptions.Choices {
+		if ch.Properties != nil && strings.EqualFold(ch.Properties.DisplayName, value) {
+			return ch.Id, true
+		}
+	}
+	return "", false
+}
+
+func driveLabelFieldName(f *drivelabels.GoogleAppsDriveLabelsV2Field) string {
+	if f.Properties != nil && f.Properties.DisplayName != "" {
+		return f.Properties.DisplayName
+	}
+	return f.Id
+}
+
+func driveLabelFieldType(f *drivelabels.GoogleAppsDriveLabelsV2Field) string {
+	switch {
+	case f.TextOptions != nil:
+		return driveLabelFieldText
+	case f.IntegerOptions != nil:
+		return driveLabelFieldInteger
+	case f.DateOptions != nil:
+		return driveLabelFieldDate
+	case f.SelectionOptions != nil:
+		return driveLabelFieldSelection
+	case f.UserOptions != nil:
+		return driveLabelFieldUser
+	default:
+		return ""
+	}
+}
+
+// driveLabelFieldValue formats an applied field value for table output.
+func driveLabelFieldValue(f drive.LabelField) string {
+	var values []string
+	switch f.ValueType {
+	case "text":
+		values = f.Text
+	case "integer":
+		for _, n := range f.Integer {
+			values = append(values, strconv.FormatInt(n, 10))
+		}
+	case "dateString":
+		values = f.DateString
+	case "selection":
+		values = f.Selection
+	case "user":
+		for _, usr := range f.User {
+			values = append(values, usr.EmailAddress)
+		}
+	}
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ", ")
+}
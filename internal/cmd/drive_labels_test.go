@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/drivelabels/v2"
+	"google.golang.org/api/option"
+)
+
+func testDriveLabel() *drivelabels.GoogleAppsDriveLabelsV2Label {
+	return &drivelabels.GoogleAppsDriveLabelsV2Label{
+		Id: "lbl1",
+		Fields: []*drivelabels.GoogleAppsDriveLabelsV2Field{
+			{Id: "f_class", Properties: &drivelabels.GoogleAppsDriveLabelsV2FieldProperties{DisplayName: "Classification"},
+				SelectionOptions: &drivelabels.GoogleAppsDriveLabelsV2FieldSelectionOptions{Choices: []*drivelabels.GoogleAppsDriveLabelsV2FieldSelectionOptionsChoice{
+					{Id: "c_conf", Properties: &drivelabels.GoogleAppsDriveLabelsV2FieldSelectionOptionsChoiceProperties{DisplayName: "Confidential"}},
+				}}},
+			{Id: "f_years", Properties: &drivelabels.GoogleAppsDriveLabelsV2FieldProperties{DisplayName: "Retention years"}, IntegerOptions: &drivelabels.GoogleAppsDriveLabelsV2FieldIntegerOptions{}},
+			{Id: "f_review", DateOptions: &drivelabels.GoogleAppsDriveLabelsV2FieldDateOptions{}},
+			{Id: "f_note", TextOptions: &drivelabels.GoogleAppsDriveLabelsV2FieldTextOptions{}},
+		},
+	}
+}
+
+func TestBuildDriveLabelFieldModifications(t *testing.T) {
+	mods, err := buildDriveLabelFieldModifications(testDriveLabel(), []string{
+		"classification=confidential",
+		"Retention years=7",
+		"f_review=2030-01-31",
+		"f_note=",
+	})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if len(mods) != 4 ||
+		mods[0].FieldId != "f_class" || mods[0].SetSelectionValues[0] != "c_conf" ||
+		mods[1].SetIntegerValues[0] != 7 ||
+		mods[2].SetDateValues[0] != "2030-01-31" ||
+		!mods[3].UnsetValues {
+		t.Fatalf("unexpected mods: %+v", mods)
+	}
+
+	for _, bad := range []string{"nope=1", "f_years=x", "f_review=31/01/2030", "f_class=Public", "novalue"} {
+		if _, err := buildDriveLabelFieldModifications(testDriveLabel(), []string{bad}); ExitCode(stableExitCode(err)) != 2 {
+			t.Fatalf("%q: expected usage error, got %v", bad, err)
+		}
+	}
+}
+
+func TestDriveLabelsApplyCmd(t *testing.T) {
+	origDrive, origLabels := newDriveService, newDriveLabelsService
+	t.Cleanup(func() { newDriveService, newDriveLabelsService = origDrive, origLabels })
+
+	labelsSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/labels/lbl1" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(testDriveLabel())
+	}))
+	defer labelsSrv.Close()
+	labelsSvc, err := drivelabels.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(labelsSrv.Client()),
+		option.WithEndpoint(labelsSrv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveLabelsService = func(context.Context, string) (*drivelabels.Service, error) { return labelsSvc, nil }
+
+	var got drive.ModifyLabelsRequest
+	driveSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || strings.TrimPrefix(r.URL.Path, "/drive/v3") != "/files/file1/modifyLabels" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"modifiedLabels": []map[string]any{{"id": "lbl1"}}})
+	}))
+	defer driveSrv.Close()
+	driveSvc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(driveSrv.Client()),
+		option.WithEndpoint(driveSrv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return driveSvc, nil }
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "drive", "labels", "apply", "file1", "lbl1", "--field", "Classification=Confidential", "--field", "f_years=3"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if len(got.LabelModifications) != 1 {
+		t.Fatalf("unexpected request: %+v", got)
+	}
+	mod := got.LabelModifications[0]
+	if mod.LabelId != "lbl1" || len(mod.FieldModifications) != 2 || mod.FieldModifications[0].SetSelectionValues[0] != "c_conf" || mod.FieldModifications[1].SetIntegerValues[0] != 3 {
+		t.Fatalf("unexpected modification: %+v", mod)
+	}
+	var parsed struct {
+		Applied bool          `json:"applied"`
+		Labels  []drive.Label `json:"labels"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil || !parsed.Applied || len(parsed.Labels) != 1 {
+		t.Fatalf("unexpected output %q: %v", out, err)
+	}
+}
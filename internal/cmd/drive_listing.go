@@ -5,28 +5,88 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"google.golang.org/api/drive/v3"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
-const driveFileListFields = "nextPageToken, files(id, name, mimeType, size, modifiedTime, parents, webViewLink)"
+const (
+	driveFileListFields  = "nextPageToken, files(id, name, mimeType, size, modifiedTime, parents, starred, webViewLink)"
+	driveDefaultOrderBy  = "modifiedTime desc"
+	driveMaxListPageSize = 1000
+)
 
 type driveFileListOptions struct {
 	query     string
 	max       int64
+	pageSize  int64
 	page      string
 	allDrives bool
+	orderBy   string
+}
+
+// driveLsOrder maps --order-by/--desc to a Drive API orderBy clause. Ordering
+// is always server-side so it holds across pages and page tokens.
+func driveLsOrder(orderBy string, desc bool) (string, error) {
+	direction := ""
+	if desc {
+		direction = " desc"
+	}
+	switch strings.ToLower(strings.TrimSpace(orderBy)) {
+	case "":
+		if desc {
+			return "", usage("--desc requires --order-by")
+		}
+		return driveDefaultOrderBy, nil
+	case "name":
+		return "name" + direction, nil
+	case "modifiedtime", "modified":
+		return "modifiedTime" + direction, nil
+	case "size":
+		// Drive cannot order by size; quotaBytesUsed is the closest field
+		// (folders and Google Docs editors files count as 0).
+		return "quotaBytesUsed" + direction + ",name", nil
+	default:
+		return "", usagef("invalid --order-by %q (use name|modifiedTime|size)", orderBy)
+	}
 }
 
 func (c *DriveLsCmd) Run(ctx context.Context, flags *RootFlags) error {
-	if c.All && strings.TrimSpace(c.Parent) != "" {
+	parent := strings.TrimSpace(c.Parent)
+	if arg := normalizeGoogleID(strings.TrimSpace(c.Folder)); arg != "" {
+		if !c.Tree {
+			return usage("positional folderId requires --tree (use --parent <folderId> for a flat listing)")
+		}
+		if parent != "" && parent != arg {
+			return usage("folderId and --parent name different folders")
+		}
+		parent = arg
+	}
+	if c.All && parent != "" {
 		return usage("--all cannot be combined with --parent")
 	}
+	if c.Starred && c.Tree {
+		return usage("--starred cannot be combined with --tree")
+	}
+	if c.Tree {
+		return c.runTree(ctx, flags, parent)
+	}
+	if c.Depth != 0 {
+		return usage("--depth requires --tree")
+	}
+
+	if c.PageSize < 0 {
+		return usage("--page-size must be positive")
+	}
+	orderBy, err := driveLsOrder(c.OrderBy, c.Desc)
+	if err != nil {
+		return err
+	}
 
-	folderID := strings.TrimSpace(c.Parent)
+	folderID := parent
 	if folderID == "" {
 		folderID = "root"
 	}
@@ -36,16 +96,27 @@ func (c *DriveLsCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	query := buildDriveListQuery(folderID, c.Query)
-	if c.All {
-		query = buildDriveAllListQuery(c.Query)
+	userQuery := strings.TrimSpace(c.Query)
+	if c.Starred {
+		if userQuery != "" {
+			userQuery = "starred = true and (" + userQuery + ")"
+		} else {
+			userQuery = "starred = true"
+		}
+	}
+	query := buildDriveListQuery(folderID, userQuery)
+	// Starred files live anywhere, so --starred without --parent lists them all.
+	if c.All || (c.Starred && parent == "") {
+		query = buildDriveAllListQuery(userQuery)
 	}
 
 	resp, err := listDriveFiles(ctx, svc, driveFileListOptions{
 		query:     query,
 		max:       c.Max,
+		pageSize:  c.PageSize,
 		page:      c.Page,
 		allDrives: c.AllDrives,
+		orderBy:   orderBy,
 	})
 	if err != nil {
 		return err
@@ -56,8 +127,12 @@ func (c *DriveLsCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 func (c *DriveSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 	query := strings.TrimSpace(strings.Join(c.Query, " "))
-	if query == "" {
-		return usage("missing query")
+	clauses, err := c.Filters.clauses(time.Now(), time.Local)
+	if err != nil {
+		return err
+	}
+	if query == "" && len(clauses) == 0 {
+		return usage("missing query (or a filter such as --name-contains, --mime, --owner)")
 	}
 
 	_, svc, err := requireDriveService(ctx, flags)
@@ -66,7 +141,7 @@ func (c *DriveSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 
 	resp, err := listDriveFiles(ctx, svc, driveFileListOptions{
-		query:     buildDriveSearchQuery(query, c.RawQuery),
+		query:     buildDriveStructuredSearchQuery(query, c.RawQuery, clauses),
 		max:       c.Max,
 		page:      c.Page,
 		allDrives: c.AllDrives,
@@ -78,14 +153,54 @@ func (c *DriveSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return writeDriveFileList(ctx, resp, "No results")
 }
 
+// listDriveFiles fetches up to opts.max files. When opts.pageSize is smaller
+// than the limit it follows page tokens until the limit is reached; the
+// returned nextPageToken continues exactly after the last returned file.
 func listDriveFiles(ctx context.Context, svc *drive.Service, opts driveFileListOptions) (*drive.FileList, error) {
-	call := svc.Files.List().
-		Q(opts.query).
-		PageSize(opts.max).
-		PageToken(opts.page).
-		OrderBy("modifiedTime desc")
-	call = driveFilesListCallWithDriveSupport(call, opts.allDrives)
-	return call.Fields(driveFileListFields).Context(ctx).Do()
+	orderBy := opts.orderBy
+	if orderBy == "" {
+		orderBy = driveDefaultOrderBy
+	}
+	pageSize := opts.pageSize
+	if pageSize <= 0 {
+		pageSize = opts.max
+	}
+	pageSize = min(pageSize, driveMaxListPageSize)
+
+	out := &drive.FileList{}
+	pageToken := opts.page
+	seen := map[string]bool{}
+	for {
+		size := pageSize
+		if opts.max > 0 {
+			size = min(size, opts.max-int64(len(out.Files)))
+		}
+		call := svc.Files.List().
+			Q(opts.query).
+			PageSize(size).
+			PageToken(pageToken).
+			OrderBy(orderBy)
+		call = driveFilesListCallWithDriveSupport(call, opts.allDrives)
+		resp, err := call.Fields(driveFileListFields).Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+		out.Files = append(out.Files, resp.Files...)
+		out.NextPageToken = resp.NextPageToken
+
+		// A single request already asked for the whole limit; Drive may return
+		// short pages, but following them would change what one page means.
+		if resp.NextPageToken == "" || pageSize >= opts.max || int64(len(out.Files)) >= opts.max {
+			break
+		}
+		if seen[resp.NextPageToken] {
+			return nil, fmt.Errorf("pagination loop: repeated page token %q", resp.NextPageToken)
+		}
+		seen[resp.NextPageToken] = true
+		pageToken = resp.NextPageToken
+	}
+
+	return out, nil
 }
 
 func writeDriveFileList(ctx context.Context, resp *drive.FileList, emptyMessage string) error {
@@ -112,8 +227,8 @@ func writeDriveFileList(ctx context.Context, resp *drive.FileList, emptyMessage
 			f.Id,
 			f.Name,
 			driveType(f.MimeType),
-			formatDriveSize(f.Size),
-			formatDateTime(f.ModifiedTime),
+			humanSize(ctx, f.Size),
+			humanDateTime(ctx, f.ModifiedTime),
 		)
 	}
 	printNextPageHint(u, resp.NextPageToken)
@@ -13,8 +13,8 @@ import (
 	"google.golang.org/api/drive/v3"
 	"google.golang.org/api/option"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 func TestDriveLsCmd_TextAndJSON(t *testing.T) {
@@ -187,3 +187,68 @@ func TestDriveLsCmd_NoAllDrives(t *testing.T) {
 		t.Fatalf("execute: %v", execErr)
 	}
 }
+
+func TestDriveLsCmd_PageSizeFollowsPagesUpToLimit(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var sizes []string
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requireQuery(t, r, "orderBy", "name desc")
+		sizes = append(sizes, r.URL.Query().Get("pageSize"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("pageToken") == "" {
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"files":         []map[string]any{{"id": "a", "name": "c"}, {"id": "b", "name": "b"}},
+				"nextPageToken": "p2",
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"files":         []map[string]any{{"id": "c", "name": "a"}},
+			"nextPageToken": "p3",
+		})
+	}))
+	defer closeSrv()
+	newDriveService = stubDriveService(svc)
+
+	out := captureStdout(t, func() {
+		ctx := outfmt.WithMode(context.Background(), outfmt.Mode{JSON: true})
+		if err := runKong(t, &DriveLsCmd{}, []string{"--max", "3", "--page-size", "2", "--order-by", "name", "--desc"}, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	})
+
+	if strings.Join(sizes, ",") != "2,1" {
+		t.Fatalf("expected page sizes 2,1, got %v", sizes)
+	}
+	var parsed struct {
+		Files         []*drive.File `json:"files"`
+		NextPageToken string        `json:"nextPageToken"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("decode: %v (%q)", err, out)
+	}
+	if len(parsed.Files) != 3 || parsed.NextPageToken != "p3" {
+		t.Fatalf("unexpected result: %d files, next=%q", len(parsed.Files), parsed.NextPageToken)
+	}
+}
+
+func TestDriveLsOrder(t *testing.T) {
+	if got, err := driveLsOrder("", false); err != nil || got != driveDefaultOrderBy {
+		t.Fatalf("default: %q %v", got, err)
+	}
+	if got, err := driveLsOrder("modifiedTime", false); err != nil || got != "modifiedTime" {
+		t.Fatalf("modifiedTime: %q %v", got, err)
+	}
+	if _, err := driveLsOrder("", true); err == nil {
+		t.Fatalf("expected --desc without --order-by to fail")
+	}
+	if _, err := driveLsOrder("owner", false); err == nil {
+		t.Fatalf("expected invalid field to fail")
+	}
+
+	if got, err := driveLsOrder("size", true); err != nil || got != "quotaBytesUsed desc,name" {
+		t.Fatalf("size: %q %v", got, err)
+	}
+}
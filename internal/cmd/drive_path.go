@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/idcache"
+)
+
+// Drive paths address files the way the web UI shows them:
+// "/My Drive/Projects/Q3/report.docx", "/Shared drives/Team/Plans/q3.pdf",
+// or "/Projects/Q3/report.docx" (My Drive implied). Drive IDs never start
+// with "/", so any fileId argument may be a path.
+const (
+	driveMyDriveSegment      = "My Drive"
+	driveSharedDrivesSegment = "Shared drives"
+)
+
+var errDrivePathNotFound = errors.New("not found")
+
+func isDrivePath(ref string) bool {
+	return strings.HasPrefix(strings.TrimSpace(ref), "/")
+}
+
+// driveFileRef returns the file reference from a positional fileId or
+// --path; exactly one of them must be set.
+func driveFileRef(fileID, path string) (string, error) {
+	fileID, path = strings.TrimSpace(fileID), strings.TrimSpace(path)
+	switch {
+	case fileID != "" && path != "":
+		return "", usage("use either fileId or --path, not both")
+	case path != "":
+		if !isDrivePath(path) {
+			return "", usagef("--path must start with / (e.g. \"/My Drive/Projects/report.docx\"), got %q", path)
+		}
+		return path, nil
+	case fileID == "":
+		return "", usage("empty fileId (or use --path)")
+	}
+	return fileID, nil
+}
+
+// resolveDriveID returns ref unchanged unless it is a Drive path, which is
+// resolved to the ID of the file it names.
+func resolveDriveID(ctx context.Context, svc *drive.Service, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if !isDrivePath(ref) {
+		return ref, nil
+	}
+	id, err := resolveDrivePath(ctx, svc, ref, true)
+	if errors.Is(err, errDrivePathNotFound) {
+		// A cached folder may have been renamed or moved; retry live once.
+		id, err = resolveDrivePath(ctx, svc, ref, false)
+	}
+	if errors.Is(err, errDrivePathNotFound) {
+		return "", &ExitError{Code: exitCodeNotFound, Err: err}
+	}
+	return id, err
+}
+
+// resolveDriveIDLive is resolveDriveID without the name cache, for
+// destructive commands: a folder renamed or replaced since it was cached
+// must not send the delete to whatever now sits at the old ID.
+func resolveDriveIDLive(ctx context.Context, svc *drive.Service, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if !isDrivePath(ref) {
+		return ref, nil
+	}
+	id, err := resolveDrivePath(ctx, svc, ref, false)
+	if errors.Is(err, errDrivePathNotFound) {
+		return "", &ExitError{Code: exitCodeNotFound, Err: err}
+	}
+	return id, err
+}
+
+func splitDrivePath(path string) []string {
+	var parts []string
+	for _, p := range strings.Split(path, "/") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
+
+// resolveDrivePath walks path one component at a time. Folder components
+// (and shared drive names) come from the name→ID cache when useCache is set;
+// the final component is always looked up live.
+func resolveDrivePath(ctx context.Context, svc *drive.Service, path string, useCache bool) (string, error) {
+	parts := splitDrivePath(path)
+	parentID, driveID, prefix := "root", "", "/"+driveMyDriveSegment
+	switch {
+	case len(parts) > 0 && parts[0] == driveMyDriveSegment:
+		parts = parts[1:]
+	case len(parts) > 0 && parts[0] == driveSharedDrivesSegment:
+		if len(parts) < 2 {
+			return "", usagef("path %q needs a shared drive name after /%s/", path, driveSharedDrivesSegment)
+		}
+		prefix = "/" + driveSharedDrivesSegment + "/" + parts[1]
+		parts = parts[2:]
+	}
+	if len(parts) == 0 && !strings.HasPrefix(prefix, "/"+driveSharedDrivesSegment) {
+		return "root", nil
+	}
+
+	var cached map[string]string
+	cache, account, cacheOK := nameCacheFor(ctx)
+	if cacheOK && useCache {
+		cached, _ = cache.Get(account, idcache.KindDriveFolders, "")
+	}
+	learned := map[string]string{}
+	for k, v := range cached {
+		learned[k] = v
+	}
+
+	if strings.HasPrefix(prefix, "/"+driveSharedDrivesSegment) {
+		id, ok := cached[prefix]
+		if !ok {
+			var err error
+			if id, err = lookupSharedDriveID(ctx, svc, strings.TrimPrefix(prefix, "/"+driveSharedDrivesSegment+"/")); err != nil {
+				return "", err
+			}
+			learned[prefix] = id
+		}
+		parentID, driveID = id, id
+		if len(parts) == 0 {
+			return id, nil
+		}
+	}
+
+	for i, name := range parts {
+		prefix += "/" + name
+		last := i == len(parts)-1
+		if id, ok := cached[prefix]; ok && !last {
+			parentID = id
+			continue
+		}
+		id, err := lookupDriveChild(ctx, svc, parentID, driveID, name, !last)
+		if err != nil {
+			if errors.Is(err, errDrivePathNotFound) {
+				return "", fmt.Errorf("drive path %s: %q %w", path, prefix, errDrivePathNotFound)
+			}
+			return "", err
+		}
+		if !last {
+			learned[prefix] = id
+		}
+		parentID = id
+	}
+
+	if cacheOK && len(learned) > len(cached) {
+		if err := cache.Put(account, idcache.KindDriveFolders, "", learned); err != nil {
+			slog.Debug("name cache write failed", "kind", idcache.KindDriveFolders, "err", err)
+		}
+	}
+	return parentID, nil
+}
+
+func lookupDriveChild(ctx context.Context, svc *drive.Service, parentID, driveID, name string, folder bool) (string, error) {
+	q := fmt.Sprintf("'%s' in parents and name = '%s' and trashed = false", parentID, escapeDriveQueryString(name))
+	if folder {
+		q += fmt.Sprintf(" and mimeType = '%s'", driveMimeFolder)
+	}
+	call := svc.Files.List().
+		Q(q).
+		Fields("files(id, name, mimeType)").
+		PageSize(10).
+		SupportsAllDrives(true).
+		IncludeItemsFromAllDrives(true).
+		Context(ctx)
+	if driveID != "" {
+		call = call.Corpora("drive").DriveId(driveID)
+	}
+	resp, err := call.Do()
+	if err != nil {
+		return "", err
+	}
+	switch len(resp.Files) {
+	case 0:
+		return "", errDrivePathNotFound
+	case 1:
+		return resp.Files[0].Id, nil
+	}
+	ids := make([]string, 0, len(resp.Files))
+	for _, f := range resp.Files {
+		ids = append(ids, f.Id)
+	}
+	return "", usagef("%q is ambiguous (%d items with that name: %s); use an ID", name, len(ids), strings.Join(ids, ", "))
+}
+
+func lookupSharedDriveID(ctx context.Context, svc *drive.Service, name string) (string, error) {
+	resp, err := svc.Drives.List().
+		Q(fmt.Sprintf("name = '%s'", escapeDriveQueryString(name))).
+		Fields("drives(id, name)").
+		PageSize(10).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return "", err
+	}
+	switch len(resp.Drives) {
+	case 0:
+		return "", fmt.Errorf("shared drive %q %w", name, errDrivePathNotFound)
+	case 1:
+		return resp.Drives[0].Id, nil
+	}
+	return "", usagef("shared drive name %q is ambiguous; use an ID", name)
+}
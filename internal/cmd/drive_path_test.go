@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/internal/idcache"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+var driveTestChildQueryRe = regexp.MustCompile(`^'([^']*)' in parents and name = '((?:\\.|[^'])*)'`)
+
+func TestResolveDriveID_Paths(t *testing.T) {
+	type node struct{ id, mime string }
+	tree := map[string][]node{
+		"root/Projects":  {{"projects", driveMimeFolder}},
+		"projects/Q3":    {{"q3", driveMimeFolder}},
+		"q3/report.docx": {{"doc1", "application/vnd.google-apps.document"}},
+		"team/plan.pdf":  {{"plan", "application/pdf"}},
+		"root/dup":       {{"d1", "text/plain"}, {"d2", "text/plain"}},
+	}
+	var lookups []string
+	var sharedCorpora string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/drives":
+			_ = json.NewEncoder(w).Encode(map[string]any{"drives": []map[string]any{{"id": "team", "name": "Team"}}})
+		case "/files":
+			q := r.URL.Query().Get("q")
+			m := driveTestChildQueryRe.FindStringSubmatch(q)
+			if m == nil {
+				t.Fatalf("unexpected q %q", q)
+			}
+			key := m[1] + "/" + strings.ReplaceAll(m[2], `\'`, "'")
+			lookups = append(lookups, key)
+			if m[1] == "team" {
+				sharedCorpora = r.URL.Query().Get("corpora") + ":" + r.URL.Query().Get("driveId")
+			}
+			folderOnly := strings.Contains(q, "mimeType = '"+driveMimeFolder+"'")
+			files := []map[string]any{}
+			for _, n := range tree[key] {
+				if !folderOnly || n.mime == driveMimeFolder {
+					files = append(files, map[string]any{"id": n.id, "mimeType": n.mime})
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": files})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	ctx := withTestNameCache(t)
+
+	id, err := resolveDriveID(ctx, svc, "/My Drive/Projects/Q3/report.docx")
+	if err != nil || id != "doc1" || len(lookups) != 3 {
+		t.Fatalf("resolve = %q, %v (lookups %v)", id, err, lookups)
+	}
+
+	// Folder components now come from the cache; the leaf is always live.
+	lookups = nil
+	if id, err = resolveDriveID(ctx, svc, "/Projects/Q3/report.docx"); err != nil || id != "doc1" || len(lookups) != 1 {
+		t.Fatalf("cached resolve = %q, %v (lookups %v)", id, err, lookups)
+	}
+
+	// The cached Q3 folder was replaced: the stale cache is bypassed once.
+	tree["projects/Q3"] = []node{{"q3b", driveMimeFolder}}
+	tree["q3b/report.docx"] = []node{{"doc2", "application/vnd.google-apps.document"}}
+	delete(tree, "q3/report.docx")
+	if id, err = resolveDriveID(ctx, svc, "/My Drive/Projects/Q3/report.docx"); err != nil || id != "doc2" {
+		t.Fatalf("stale resolve = %q, %v", id, err)
+	}
+
+	if id, err = resolveDriveID(ctx, svc, "/Shared drives/Team/plan.pdf"); err != nil || id != "plan" || sharedCorpora != "drive:team" {
+		t.Fatalf("shared drive resolve = %q, %v (corpora %q)", id, err, sharedCorpora)
+	}
+
+	_, err = resolveDriveID(ctx, svc, "/Projects/missing.txt")
+	if got := ExitCode(stableExitCode(err)); got != exitCodeNotFound {
+		t.Fatalf("expected not found, got %d (%v)", got, err)
+	}
+	if _, err = resolveDriveID(ctx, svc, "/dup"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected ambiguous error, got %v", err)
+	}
+	if id, err = resolveDriveID(ctx, svc, "abc123"); err != nil || id != "abc123" {
+		t.Fatalf("plain ID = %q, %v", id, err)
+	}
+}
+
+func TestDriveFileRef(t *testing.T) {
+	if _, err := driveFileRef("id1", "/x"); err == nil {
+		t.Fatal("expected error for fileId and --path")
+	}
+	if _, err := driveFileRef("", "x"); err == nil {
+		t.Fatal("expected error for relative --path")
+	}
+	if _, err := driveFileRef("", ""); err == nil {
+		t.Fatal("expected error without fileId or --path")
+	}
+	if ref, err := driveFileRef("", " /a/b "); err != nil || ref != "/a/b" {
+		t.Fatalf("ref = %q, %v", ref, err)
+	}
+}
+
+func TestDriveGetCmd_Path(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/files":
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{{"id": "doc1"}}})
+		case "/files/doc1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "doc1", "name": "report.docx"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveGetCmd{}, []string{"--path", "/report.docx"}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("get: %v", err)
+		}
+	})
+	if !strings.Contains(out, `"id": "doc1"`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
+
+func TestDriveDeleteCmd_PathResolvedLiveBeforeConfirm(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var mutated bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method != http.MethodGet:
+			mutated = true
+			http.Error(w, "unexpected mutation", http.StatusBadRequest)
+		case r.URL.Path == "/files":
+			m := driveTestChildQueryRe.FindStringSubmatch(r.URL.Query().Get("q"))
+			ids := map[string]string{"root/Projects": "projects", "projects/report.docx": "doc1", "stale/report.docx": "wrong"}
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{{"id": ids[m[1]+"/"+m[2]]}}})
+		case r.URL.Path == "/files/doc1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "doc1", "name": "report.docx"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	ctx := withTestNameCache(t)
+	storeNameCache(ctx, idcache.KindDriveFolders, "", map[string]string{"/My Drive/Projects": "stale"})
+
+	out := captureStdout(t, func() {
+		err := runKong(t, &DriveDeleteCmd{}, []string{"--path", "/Projects/report.docx"}, outfmt.WithMode(ctx, outfmt.Mode{JSON: true}), &RootFlags{Account: "a@b.com", DryRun: true})
+		if ExitCode(err) != 0 {
+			t.Fatalf("expected dry-run exit, got %v", err)
+		}
+	})
+	var payload struct {
+		Request map[string]any `json:"request"`
+	}
+	if err := json.Unmarshal([]byte(out), &payload); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, out)
+	}
+	if payload.Request["file_id"] != "doc1" || payload.Request["name"] != "report.docx" || payload.Request["path"] != "/Projects/report.docx" {
+		t.Fatalf("unexpected request: %#v", payload.Request)
+	}
+	if mutated {
+		t.Fatal("dry run must not modify the file")
+	}
+
+	err = runKong(t, &DriveDeleteCmd{}, []string{"--path", "/Projects/report.docx"}, ctx, &RootFlags{Account: "a@b.com", NoInput: true})
+	if err == nil || !strings.Contains(err.Error(), `"report.docx" (doc1, at /Projects/report.docx)`) {
+		t.Fatalf("expected confirmation naming the resolved file, got %v", err)
+	}
+}
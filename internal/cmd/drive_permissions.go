@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+const drivePermissionFields = "id, type, role, emailAddress, domain, displayName, allowFileDiscovery, pendingOwner"
+
+// DrivePermissionsCmd groups permission management. `drive permissions
+// <fileId>` still lists, via the default subcommand.
+type DrivePermissionsCmd struct {
+	List   DrivePermissionsListCmd   `cmd:"" name:"list" aliases:"ls" default:"withargs" help:"List permissions on a file"`
+	Add    DrivePermissionsAddCmd    `cmd:"" name:"add" aliases:"create" help:"Grant a user, group, domain, or anyone access to a file"`
+	Update DrivePermissionsUpdateCmd `cmd:"" name:"update" help:"Change a permission's role or pending-owner state"`
+	Remove DrivePermissionsRemoveCmd `cmd:"" name:"remove" aliases:"rm,delete" help:"Remove a permission from a file"`
+}
+
+type DrivePermissionsAddCmd struct {
+	FileID       string `arg:"" name:"fileId" help:"File ID"`
+	Type         string `name:"type" required:"" enum:"user,group,domain,anyone" help:"Grantee type: user|group|domain|anyone"`
+	Email        string `name:"email" help:"User or group email (for --type=user|group)"`
+	Domain       string `name:"domain" help:"Domain (for --type=domain; e.g. example.com)"`
+	Role         string `name:"role" help:"Role: reader|commenter|writer|fileOrganizer|organizer|owner (default: reader; writer with --pending-owner)"`
+	Notify       bool   `name:"notify" help:"Send a notification email (user/group only)"`
+	Message      string `name:"message" help:"Message for the notification email (implies --notify)"`
+	Discoverable bool   `name:"discoverable" help:"Allow file discovery in search (anyone/domain only)"`
+	PendingOwner bool   `name:"pending-owner" help:"Grant writer access and ask the user to accept ownership (My Drive files only)"`
+}
+
+func (c *DrivePermissionsAddCmd) Run(ctx context.Context, flags *RootFlags) error {
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+	perm, err := c.permission()
+	if err != nil {
+		return err
+	}
+	notify := c.Notify || strings.TrimSpace(c.Message) != ""
+	if notify && perm.Type != driveShareToUser && perm.Type != "group" {
+		return usage("--notify and --message are only valid for --type=user or --type=group")
+	}
+	transfer := perm.Role == drivePermRoleOwner
+	if transfer {
+		// Drive always emails the new owner.
+		notify = true
+	}
+
+	request := map[string]any{
+		"file_id":            fileID,
+		"permission":         perm,
+		"notify":             notify,
+		"message":            strings.TrimSpace(c.Message),
+		"transfer_ownership": transfer,
+	}
+	switch {
+	case transfer:
+		err = dryRunAndConfirmDestructive(ctx, flags, "drive.permissions.add", request, fmt.Sprintf("transfer ownership of drive file %s to %s", fileID, perm.EmailAddress))
+	case perm.Type == driveShareToAnyone:
+		err = dryRunAndConfirmDestructive(ctx, flags, "drive.permissions.add", request, fmt.Sprintf("share drive file %s with anyone (public)", fileID))
+	default:
+		err = dryRunExit(ctx, flags, "drive.permissions.add", request)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	call := svc.Permissions.Create(fileID, perm).
+		SupportsAllDrives(true).
+		SendNotificationEmail(notify).
+		Fields(drivePermissionFields).
+		Context(ctx)
+	if msg := strings.TrimSpace(c.Message); msg != "" {
+		call = call.EmailMessage(msg)
+	}
+	if transfer {
+		call = call.TransferOwnership(true)
+	}
+	created, err := call.Do()
+	if err != nil {
+		return err
+	}
+	return writeDrivePermission(ctx, fileID, created)
+}
+
+// permission validates the grantee flags and builds the permission to create.
+func (c *DrivePermissionsAddCmd) permission() (*drive.Permission, error) {
+	typ := strings.TrimSpace(c.Type)
+	email := strings.TrimSpace(c.Email)
+	domain := strings.TrimSpace(c.Domain)
+	perm := &drive.Permission{Type: typ}
+	switch typ {
+	case driveShareToUser, "group":
+		if email == "" {
+			return nil, usagef("missing --email for --type=%s", typ)
+		}
+		if domain != "" {
+			return nil, usagef("--type=%s cannot be combined with --domain", typ)
+		}
+		if c.Discoverable {
+			return nil, usage("--discoverable is only valid for --type=anyone or --type=domain")
+		}
+		perm.EmailAddress = email
+	case driveShareToDomain:
+		if domain == "" {
+			return nil, usage("missing --domain for --type=domain")
+		}
+		if email != "" {
+			return nil, usage("--type=domain cannot be combined with --email")
+		}
+		perm.Domain = domain
+		perm.AllowFileDiscovery = c.Discoverable
+	case driveShareToAnyone:
+		if email != "" || domain != "" {
+			return nil, usage("--type=anyone cannot be combined with --email or --domain")
+		}
+		perm.AllowFileDiscovery = c.Discoverable
+	default:
+		return nil, usage("invalid --type (expected user|group|domain|anyone)")
+	}
+
+	role := strings.TrimSpace(c.Role)
+	if role == "" {
+		role = drivePermRoleReader
+		if c.PendingOwner {
+			role = drivePermRoleWriter
+		}
+	}
+	role, err := normalizeDrivePermissionRole(role)
+	if err != nil {
+		return nil, err
+	}
+	if role == drivePermRoleOwner && typ != driveShareToUser {
+		return nil, usage("--role=owner is only valid for --type=user")
+	}
+	if c.PendingOwner {
+		if typ != driveShareToUser {
+			return nil, usage("--pending-owner is only valid for --type=user")
+		}
+		if role != drivePermRoleWriter {
+			return nil, usage("--pending-owner requires --role=writer")
+		}
+		perm.PendingOwner = true
+	}
+	perm.Role = role
+	return perm, nil
+}
+
+type DrivePermissionsUpdateCmd struct {
+	FileID       string `arg:"" name:"fileId" help:"File ID"`
+	PermissionID string `arg:"" name:"permissionId" help:"Permission ID"`
+	Role         string `name:"role" help:"New role: reader|commenter|writer|fileOrganizer|organizer|owner"`
+	PendingOwner *bool  `name:"pending-owner" help:"Offer ownership to this user (writer only); --pending-owner=false withdraws the offer"`
+}
+
+func (c *DrivePermissionsUpdateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	permissionID := strings.TrimSpace(c.PermissionID)
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+	if permissionID == "" {
+		return usage("empty permissionId")
+	}
+	if strings.TrimSpace(c.Role) == "" && c.PendingOwner == nil {
+		return usage("nothing to update (set --role and/or --pending-owner)")
+	}
+
+	perm := &drive.Permission{}
+	if strings.TrimSpace(c.Role) != "" {
+		role, err := normalizeDrivePermissionRole(c.Role)
+		if err != nil {
+			return err
+		}
+		perm.Role = role
+	}
+	if c.PendingOwner != nil {
+		if *c.PendingOwner && perm.Role != "" && perm.Role != drivePermRoleWriter {
+			return usage("--pending-owner requires --role=writer")
+		}
+		perm.PendingOwner = *c.PendingOwner
+		perm.ForceSendFields = append(perm.ForceSendFields, "PendingOwner")
+	}
+	transfer := perm.Role == drivePermRoleOwner
+
+	request := map[string]any{
+		"file_id":            fileID,
+		"permission_id":      permissionID,
+		"permission":         perm,
+		"transfer_ownership": transfer,
+	}
+	var err error
+	if transfer {
+		err = dryRunAndConfirmDestructive(ctx, flags, "drive.permissions.update", request, fmt.Sprintf("transfer ownership of drive file %s to permission %s", fileID, permissionID))
+	} else {
+		err = dryRunExit(ctx, flags, "drive.permissions.update", request)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	call := svc.Permissions.Update(fileID, permissionID, perm).
+		SupportsAllDrives(true).
+		Fields(drivePermissionFields).
+		Context(ctx)
+	if transfer {
+		call = call.TransferOwnership(true)
+	}
+	updated, err := call.Do()
+	if err != nil {
+		return err
+	}
+	return writeDrivePermission(ctx, fileID, updated)
+}
+
+type DrivePermissionsRemoveCmd struct {
+	FileID       string `arg:"" name:"fileId" help:"File ID"`
+	PermissionID string `arg:"" name:"permissionId" help:"Permission ID"`
+}
+
+func (c *DrivePermissionsRemoveCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return (&DriveUnshareCmd{FileID: c.FileID, PermissionID: c.PermissionID}).Run(ctx, flags)
+}
+
+// normalizeDrivePermissionRole accepts roles case-insensitively and returns
+// the API spelling.
+func normalizeDrivePermissionRole(role string) (string, error) {
+	for _, r := range []string{drivePermRoleReader, drivePermRoleCommenter, drivePermRoleWriter, drivePermRoleFileOrganizer, drivePermRoleOrganizer, drivePermRoleOwner} {
+		if strings.EqualFold(strings.TrimSpace(role), r) {
+			return r, nil
+		}
+	}
+	return "", usagef("invalid --role %q (expected reader|commenter|writer|fileOrganizer|organizer|owner)", role)
+}
+
+func writeDrivePermission(ctx context.Context, fileID string, p *drive.Permission) error {
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"fileId":       fileID,
+			"permissionId": p.Id,
+			"permission":   p,
+		})
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Printf("permission_id\t%s", p.Id)
+	u.Out().Printf("type\t%s", p.Type)
+	u.Out().Printf("role\t%s", p.Role)
+	if target := firstNonEmpty(p.EmailAddress, p.Domain); target != "" {
+		u.Out().Printf("grantee\t%s", target)
+	}
+	if p.PendingOwner {
+		u.Out().Printf("pending_owner\ttrue")
+	}
+	return nil
+}
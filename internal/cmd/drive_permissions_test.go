@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDrivePermissionsAddCmd_Permission(t *testing.T) {
+	perm, err := (&DrivePermissionsAddCmd{Type: "group", Email: "team@example.com", Role: "Commenter"}).permission()
+	if err != nil || perm.Type != "group" || perm.Role != drivePermRoleCommenter || perm.EmailAddress != "team@example.com" {
+		t.Fatalf("unexpected group permission: %+v, %v", perm, err)
+	}
+	perm, err = (&DrivePermissionsAddCmd{Type: "user", Email: "a@example.com", PendingOwner: true}).permission()
+	if err != nil || perm.Role != drivePermRoleWriter || !perm.PendingOwner {
+		t.Fatalf("expected writer pending owner, got %+v, %v", perm, err)
+	}
+
+	for _, tc := range []struct {
+		cmd  DrivePermissionsAddCmd
+		want string
+	}{
+		{DrivePermissionsAddCmd{Type: "user"}, "missing --email"},
+		{DrivePermissionsAddCmd{Type: "domain", Domain: "example.com", Email: "a@example.com"}, "cannot be combined"},
+		{DrivePermissionsAddCmd{Type: "anyone", Role: "editor"}, "invalid --role"},
+		{DrivePermissionsAddCmd{Type: "domain", Domain: "example.com", Role: "owner"}, "only valid for --type=user"},
+		{DrivePermissionsAddCmd{Type: "user", Email: "a@example.com", Role: "reader", PendingOwner: true}, "requires --role=writer"},
+		{DrivePermissionsAddCmd{Type: "user", Email: "a@example.com", Discoverable: true}, "--discoverable"},
+	} {
+		if _, err := tc.cmd.permission(); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%+v: expected %q error, got %v", tc.cmd, tc.want, err)
+		}
+	}
+}
+
+func TestDrivePermissionsCmd_AddUpdateRemove(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var calls []*http.Request
+	var bodies []drive.Permission
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r)
+		var p drive.Permission
+		_ = json.NewDecoder(r.Body).Decode(&p)
+		bodies = append(bodies, p)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/files/f1/permissions":
+			p.Id = "p9"
+			_ = json.NewEncoder(w).Encode(p)
+		case r.Method == http.MethodPatch && r.URL.Path == "/files/f1/permissions/p9":
+			p.Id = "p9"
+			p.Type = "user"
+			_ = json.NewEncoder(w).Encode(p)
+		case r.Method == http.MethodDelete && r.URL.Path == "/files/f1/permissions/p9":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	flags := &RootFlags{Account: "a@b.com", Force: true}
+	ctx := newDocsJSONContext(t)
+
+	out := captureStdout(t, func() {
+		args := []string{"add", "f1", "--type", "user", "--email", "new@example.com", "--role", "writer", "--message", "Take a look"}
+		if err := runKong(t, &DrivePermissionsCmd{}, args, ctx, flags); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	})
+	q := calls[0].URL.Query()
+	if q.Get("sendNotificationEmail") != "true" || q.Get("emailMessage") != "Take a look" || q.Get("transferOwnership") != "" {
+		t.Fatalf("unexpected add query: %s", calls[0].URL.RawQuery)
+	}
+	if bodies[0].Role != "writer" || bodies[0].EmailAddress != "new@example.com" {
+		t.Fatalf("unexpected add body: %+v", bodies[0])
+	}
+	if !strings.Contains(out, `"permissionId": "p9"`) {
+		t.Fatalf("unexpected add output: %s", out)
+	}
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DrivePermissionsCmd{}, []string{"update", "f1", "p9", "--pending-owner"}, ctx, flags); err != nil {
+			t.Fatalf("update pending owner: %v", err)
+		}
+		if err := runKong(t, &DrivePermissionsCmd{}, []string{"update", "f1", "p9", "--role", "owner"}, ctx, flags); err != nil {
+			t.Fatalf("update owner: %v", err)
+		}
+	})
+	if !bodies[1].PendingOwner || bodies[1].Role != "" {
+		t.Fatalf("unexpected pending-owner body: %+v", bodies[1])
+	}
+	if calls[2].URL.Query().Get("transferOwnership") != "true" || bodies[2].Role != "owner" {
+		t.Fatalf("unexpected transfer call: %s %+v", calls[2].URL.RawQuery, bodies[2])
+	}
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DrivePermissionsCmd{}, []string{"remove", "f1", "p9"}, ctx, flags); err != nil {
+			t.Fatalf("remove: %v", err)
+		}
+	})
+	if len(calls) != 4 || calls[3].Method != http.MethodDelete {
+		t.Fatalf("expected delete call, got %d calls", len(calls))
+	}
+
+	if err := runKong(t, &DrivePermissionsCmd{}, []string{"update", "f1", "p9"}, ctx, flags); err == nil || !strings.Contains(err.Error(), "nothing to update") {
+		t.Fatalf("expected nothing-to-update error, got %v", err)
+	}
+	if err := runKong(t, &DrivePermissionsCmd{}, []string{"add", "f1", "--type", "anyone", "--role", "owner"}, ctx, &RootFlags{Account: "a@b.com", NoInput: true}); err == nil || !strings.Contains(err.Error(), "only valid for --type=user") {
+		t.Fatalf("expected owner type error, got %v", err)
+	}
+	if err := runKong(t, &DrivePermissionsCmd{}, []string{"add", "f1", "--type", "anyone"}, ctx, &RootFlags{Account: "a@b.com", NoInput: true}); err == nil || !strings.Contains(err.Error(), "without --force") {
+		t.Fatalf("expected confirmation error for public share, got %v", err)
+	}
+}
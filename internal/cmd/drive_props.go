@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// Drive caps each custom property at 124 bytes of key plus value.
+const driveMaxPropertyBytes = 124
+
+const (
+	drivePropScopePublic = "properties"
+	drivePropScopeApp    = "appProperties"
+)
+
+// DrivePropsCmd manages custom file properties. properties are visible to
+// every app; appProperties (--app) are private to this OAuth client.
+type DrivePropsCmd struct {
+	Get    DrivePropsGetCmd    `cmd:"" name:"get" aliases:"list,ls" help:"Show custom properties of a file"`
+	Set    DrivePropsSetCmd    `cmd:"" name:"set" help:"Set custom properties (key=value)"`
+	Delete DrivePropsDeleteCmd `cmd:"" name:"delete" aliases:"rm,unset" help:"Delete custom properties"`
+}
+
+type DrivePropsGetCmd struct {
+	FileID string   `arg:"" name:"fileId" help:"File ID or /path"`
+	Keys   []string `arg:"" name:"key" optional:"" help:"Only show these keys"`
+}
+
+func (c *DrivePropsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	fileID, err := resolveDrivePropsFile(ctx, svc, c.FileID)
+	if err != nil {
+		return err
+	}
+	f, err := svc.Files.Get(fileID).
+		SupportsAllDrives(true).
+		Fields("id, name, properties, appProperties").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	props := filterDriveProps(f.Properties, c.Keys)
+	appProps := filterDriveProps(f.AppProperties, c.Keys)
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"id":            f.Id,
+			"name":          f.Name,
+			"properties":    props,
+			"appProperties": appProps,
+		})
+	}
+	if len(props) == 0 && len(appProps) == 0 {
+		u.Err().Println("No properties")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "SCOPE\tKEY\tVALUE")
+	for _, scope := range []struct {
+		name  string
+		props map[string]string
+	}{{drivePropScopePublic, props}, {drivePropScopeApp, appProps}} {
+		keys := make([]string, 0, len(scope.props))
+		for k := range scope.props {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", scope.name, k, scope.props[k])
+		}
+	}
+	return nil
+}
+
+type DrivePropsSetCmd struct {
+	FileID string   `arg:"" name:"fileId" help:"File ID or /path"`
+	Pairs  []string `arg:"" name:"key=value" help:"Properties to set"`
+	App    bool     `name:"app" help:"Set appProperties (private to this OAuth client) instead of properties"`
+}
+
+func (c *DrivePropsSetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	props := make(map[string]string, len(c.Pairs))
+	for _, pair := range c.Pairs {
+		k, v, ok := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return usagef("invalid property %q (use key=value)", pair)
+		}
+		if len(k)+len(v) > driveMaxPropertyBytes {
+			return usagef("property %q is too long (key and value may total %d bytes)", k, driveMaxPropertyBytes)
+		}
+		props[k] = v
+	}
+	if len(props) == 0 {
+		return usage("no properties to set")
+	}
+
+	update := &drive.File{Properties: props}
+	if c.App {
+		update = &drive.File{AppProperties: props}
+	}
+	return updateDriveProps(ctx, flags, c.FileID, update, "drive.props.set", c.App)
+}
+
+type DrivePropsDeleteCmd struct {
+	FileID string   `arg:"" name:"fileId" help:"File ID or /path"`
+	Keys   []string `arg:"" name:"key" help:"Property keys to delete"`
+	App    bool     `name:"app" help:"Delete appProperties instead of properties"`
+}
+
+func (c *DrivePropsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	// Drive clears a property when its value is sent as null; NullFields
+	// entries of the form Field.key do that for a single map key, as long as
+	// the (empty) map itself is force-sent.
+	field := "Properties"
+	if c.App {
+		field = "AppProperties"
+	}
+	update := &drive.File{ForceSendFields: []string{field}}
+	for _, k := range c.Keys {
+		if k = strings.TrimSpace(k); k != "" {
+			update.NullFields = append(update.NullFields, field+"."+k)
+		}
+	}
+	if len(update.NullFields) == 0 {
+		return usage("no property keys to delete")
+	}
+	return updateDriveProps(ctx, flags, c.FileID, update, "drive.props.delete", c.App)
+}
+
+func updateDriveProps(ctx context.Context, flags *RootFlags, ref string, update *drive.File, op string, app bool) error {
+	u := ui.FromContext(ctx)
+	scope := drivePropScopePublic
+	if app {
+		scope = drivePropScopeApp
+	}
+	if err := dryRunExit(ctx, flags, op, map[string]any{
+		"file_id":       strings.TrimSpace(ref),
+		"scope":         scope,
+		"properties":    update.Properties,
+		"appProperties": update.AppProperties,
+		"delete":        update.NullFields,
+	}); err != nil {
+		return err
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	fileID, err := resolveDrivePropsFile(ctx, svc, ref)
+	if err != nil {
+		return err
+	}
+	updated, err := svc.Files.Update(fileID, update).
+		SupportsAllDrives(true).
+		Fields("id, name, properties, appProperties").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{strFile: updated})
+	}
+	props := updated.Properties
+	if app {
+		props = updated.AppProperties
+	}
+	u.Out().Printf("id\t%s", updated.Id)
+	u.Out().Printf("name\t%s", updated.Name)
+	u.Out().Printf("%s\t%d", scope, len(props))
+	return nil
+}
+
+func resolveDrivePropsFile(ctx context.Context, svc *drive.Service, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if !isDrivePath(ref) {
+		ref = normalizeGoogleID(ref)
+	}
+	if ref == "" {
+		return "", usage("empty fileId")
+	}
+	return resolveDriveID(ctx, svc, ref)
+}
+
+func filterDriveProps(props map[string]string, keys []string) map[string]string {
+	if len(keys) == 0 {
+		return props
+	}
+	out := map[string]string{}
+	for _, k := range keys {
+		if v, ok := props[strings.TrimSpace(k)]; ok {
+			out[strings.TrimSpace(k)] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDrivePropsSetAndDelete(t *testing.T) {
+	orig := newDriveService
+	t.Cleanup(func() { newDriveService = orig })
+
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || strings.TrimPrefix(r.URL.Path, "/drive/v3") != "/files/file1" {
+			http.NotFound(w, r)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "file1", "name": "Doc", "properties": map[string]string{"project": "apollo"}})
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "drive", "props", "set", "file1", "project=apollo", "stage=review"}); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		if err := Execute([]string{"--json", "--account", "a@b.com", "drive", "props", "delete", "file1", "stage", "--app"}); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+	})
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 updates, got %v", bodies)
+	}
+	var set, del map[string]map[string]any
+	if err := json.Unmarshal([]byte(bodies[0]), &set); err != nil || set["properties"]["project"] != "apollo" || set["properties"]["stage"] != "review" {
+		t.Fatalf("unexpected set body %s: %v", bodies[0], err)
+	}
+	if err := json.Unmarshal([]byte(bodies[1]), &del); err != nil {
+		t.Fatalf("decode delete body: %v", err)
+	}
+	if v, ok := del["appProperties"]["stage"]; !ok || v != nil {
+		t.Fatalf("expected appProperties.stage=null, got %s", bodies[1])
+	}
+
+	err = Execute([]string{"--account", "a@b.com", "drive", "props", "set", "file1", "novalue"})
+	if ExitCode(stableExitCode(err)) != 2 {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
@@ -0,0 +1,387 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+const (
+	driveRetentionTrash          = "trash"
+	driveRetentionPruneRevisions = "prune-revisions"
+)
+
+type DriveRetentionCmd struct {
+	Run DriveRetentionRunCmd `cmd:"" name:"run" help:"Apply retention rules from a policy file"`
+}
+
+// DriveRetentionRunCmd applies storage rules to Drive folders. A policy file
+// looks like:
+//
+//	rules:
+//	  - name: old exports
+//	    folder: 1AbCdEfG
+//	    recursive: true
+//	    mime: [pdf, sheet]
+//	    name_contains: export
+//	    older_than: 90d
+//	    action: trash
+//	  - name: trim revisions
+//	    folder: 1AbCdEfG
+//	    keep_revisions: 10
+//
+// Rules are evaluated against the current state of Drive, so running the same
+// policy again only touches what became eligible since the last run. A file
+// trashed by one rule is skipped by later rules.
+type DriveRetentionRunCmd struct {
+	Policy string `name:"policy" aliases:"file" short:"f" required:"" help:"Policy file (YAML or JSON; - for stdin)"`
+}
+
+type driveRetentionPolicy struct {
+	Rules []driveRetentionRule `yaml:"rules" json:"rules"`
+}
+
+type driveRetentionRule struct {
+	Name          string   `yaml:"name" json:"name,omitempty"`
+	Folder        string   `yaml:"folder" json:"folder"`
+	Recursive     bool     `yaml:"recursive" json:"recursive,omitempty"`
+	Mime          []string `yaml:"mime" json:"mime,omitempty"`
+	NameContains  string   `yaml:"name_contains" json:"name_contains,omitempty"`
+	OlderThan     string   `yaml:"older_than" json:"older_than,omitempty"`
+	Action        string   `yaml:"action" json:"action"`
+	KeepRevisions int      `yaml:"keep_revisions" json:"keep_revisions,omitempty"`
+}
+
+// driveRetentionItem is one planned change: trashing a file, or deleting one
+// of its revisions when RevisionID is set.
+type driveRetentionItem struct {
+	FileID       string `json:"fileId"`
+	Name         string `json:"name"`
+	RevisionID   string `json:"revisionId,omitempty"`
+	ModifiedTime string `json:"modifiedTime,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+type driveRetentionResult struct {
+	Name    string               `json:"name"`
+	Action  string               `json:"action"`
+	Matched int                  `json:"matched"`
+	Applied int                  `json:"applied"`
+	Failed  int                  `json:"failed,omitempty"`
+	Items   []driveRetentionItem `json:"items"`
+}
+
+func (c *DriveRetentionRunCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	b, err := resolveInlineOrFileBytes("@" + strings.TrimPrefix(strings.TrimSpace(c.Policy), "@"))
+	if err != nil {
+		return fmt.Errorf("read --policy: %w", err)
+	}
+	policy, err := parseDriveRetentionPolicy(b)
+	if err != nil {
+		return err
+	}
+
+	account, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	results, err := planDriveRetention(ctx, svc, policy, time.Now())
+	if err != nil {
+		return err
+	}
+
+	request := map[string]any{
+		"account": account,
+		"rules":   results,
+	}
+	revisions := 0
+	guard := &bulkDestructiveOp{Op: "drive.retention.run", Action: "trash", Noun: "file", Undo: bulkUndoDriveUntrash}
+	for _, r := range results {
+		if r.Action == driveRetentionPruneRevisions {
+			revisions += len(r.Items)
+			continue
+		}
+		for _, item := range r.Items {
+			guard.Items = append(guard.Items, bulkItem{ID: item.FileID, Label: item.Name})
+		}
+	}
+	// Trashing goes through the bulk guard (threshold prompt, journal undo);
+	// deleted revisions cannot be restored, so they always ask.
+	if len(guard.Items) > 0 {
+		err = confirmBulkDestructive(ctx, flags, guard, request)
+	} else {
+		err = dryRunExit(ctx, flags, "drive.retention.run", request)
+	}
+	if err != nil {
+		return err
+	}
+	if revisions > 0 {
+		if err := confirmDestructiveChecked(ctx, flags, fmt.Sprintf("permanently delete %d revision%s", revisions, pluralS(revisions))); err != nil {
+			return err
+		}
+	}
+
+	total, failed := 0, 0
+	var trashedItems []bulkItem
+	pacer := newBulkPacer()
+	for i := range results {
+		r := &results[i]
+		for j := range r.Items {
+			item := &r.Items[j]
+			if err := pacer.wait(ctx); err != nil {
+				journalBulkDestructive(ctx, account, guard, trashedItems)
+				return err
+			}
+			var err error
+			if item.RevisionID != "" {
+				err = svc.Revisions.Delete(item.FileID, item.RevisionID).Context(ctx).Do()
+			} else {
+				_, err = svc.Files.Update(item.FileID, &drive.File{Trashed: true}).
+					SupportsAllDrives(true).
+					Fields("id").
+					Context(ctx).
+					Do()
+			}
+			if err != nil {
+				item.Error = err.Error()
+				r.Failed++
+				continue
+			}
+			if item.RevisionID == "" {
+				trashedItems = append(trashedItems, bulkItem{ID: item.FileID, Label: item.Name})
+			}
+			r.Applied++
+		}
+		total += r.Applied
+		failed += r.Failed
+	}
+	journalBulkDestructive(ctx, account, guard, trashedItems)
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"rules":  results,
+			"total":  total,
+			"failed": failed,
+		}); err != nil {
+			return err
+		}
+	} else {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "RULE\tACTION\tMATCHED\tAPPLIED\tFAILED")
+		for _, r := range results {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", oneLineTSV(r.Name), r.Action, r.Matched, r.Applied, r.Failed)
+		}
+		flush()
+		u.Err().Printf("Applied retention policy: %d change%s", total, pluralS(total))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d retention changes failed", failed, total+failed)
+	}
+	return nil
+}
+
+// parseDriveRetentionPolicy decodes and validates a policy. A rule with
+// keep_revisions and no action prunes revisions.
+func parseDriveRetentionPolicy(b []byte) (driveRetentionPolicy, error) {
+	var p driveRetentionPolicy
+	if err := yaml.Unmarshal(b, &p); err != nil {
+		return driveRetentionPolicy{}, fmt.Errorf("invalid policy file: %w", err)
+	}
+	if len(p.Rules) == 0 {
+		return driveRetentionPolicy{}, usage("policy file has no rules")
+	}
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		r.Folder = normalizeGoogleID(strings.TrimSpace(r.Folder))
+		r.OlderThan = strings.TrimSpace(r.OlderThan)
+		r.Action = strings.ToLower(strings.TrimSpace(r.Action))
+		if r.Action == "" && r.KeepRevisions > 0 {
+			r.Action = driveRetentionPruneRevisions
+		}
+		if strings.TrimSpace(r.Name) == "" {
+			r.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		if r.Folder == "" {
+			return driveRetentionPolicy{}, usagef("%s: folder is required", r.Name)
+		}
+		if r.OlderThan != "" && !driveAgePattern.MatchString(strings.ToLower(r.OlderThan)) {
+			return driveRetentionPolicy{}, usagef("%s: invalid older_than %q (use e.g. 30d, 12w, 6m, 1y)", r.Name, r.OlderThan)
+		}
+		for _, m := range r.Mime {
+			if _, err := driveSearchMimeClause(strings.TrimSpace(m)); err != nil {
+				return driveRetentionPolicy{}, usagef("%s: invalid mime %q", r.Name, m)
+			}
+		}
+		switch r.Action {
+		case driveRetentionTrash:
+			// Trashing everything in a folder is never what a retention rule
+			// means, so an age is required.
+			if r.OlderThan == "" {
+				return driveRetentionPolicy{}, usagef("%s: trash rules need older_than", r.Name)
+			}
+		case driveRetentionPruneRevisions:
+			if r.KeepRevisions < 1 {
+				return driveRetentionPolicy{}, usagef("%s: keep_revisions must be >= 1", r.Name)
+			}
+		default:
+			return driveRetentionPolicy{}, usagef("%s: unknown action %q (use trash or prune-revisions)", r.Name, r.Action)
+		}
+	}
+	return p, nil
+}
+
+// planDriveRetention evaluates rules in order and lists the changes each
+// would make. Files claimed by an earlier trash rule are left alone.
+func planDriveRetention(ctx context.Context, svc *drive.Service, policy driveRetentionPolicy, now time.Time) ([]driveRetentionResult, error) {
+	trashed := map[string]bool{}
+	results := make([]driveRetentionResult, 0, len(policy.Rules))
+	for _, rule := range policy.Rules {
+		files, err := listDriveRetentionFiles(ctx, svc, rule, now)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		res := driveRetentionResult{Name: rule.Name, Action: rule.Action, Items: []driveRetentionItem{}}
+		for _, f := range files {
+			if trashed[f.Id] {
+				continue
+			}
+			switch rule.Action {
+			case driveRetentionTrash:
+				trashed[f.Id] = true
+				res.Matched++
+				res.Items = append(res.Items, driveRetentionItem{FileID: f.Id, Name: f.Name, ModifiedTime: f.ModifiedTime})
+			case driveRetentionPruneRevisions:
+				// Revisions of Google Docs, Sheets, and Slides cannot be deleted.
+				if strings.HasPrefix(f.MimeType, "application/vnd.google-apps.") {
+					continue
+				}
+				revs, err := listDriveRevisionsToPrune(ctx, svc, f.Id, rule.KeepRevisions)
+				if err != nil {
+					return nil, fmt.Errorf("rule %q: %s: %w", rule.Name, f.Name, err)
+				}
+				if len(revs) > 0 {
+					res.Matched++
+				}
+				for _, rev := range revs {
+					res.Items = append(res.Items, driveRetentionItem{FileID: f.Id, Name: f.Name, RevisionID: rev.Id, ModifiedTime: rev.ModifiedTime})
+				}
+			}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// listDriveRetentionFiles returns the non-folder files a rule applies to,
+// walking subfolders when the rule is recursive.
+func listDriveRetentionFiles(ctx context.Context, svc *drive.Service, rule driveRetentionRule, now time.Time) ([]*drive.File, error) {
+	filters := []string{"trashed = false", fmt.Sprintf("mimeType != '%s'", driveMimeFolder)}
+	if rule.OlderThan != "" {
+		cutoff, err := parseDriveAgeCutoff(rule.OlderThan, now, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, fmt.Sprintf("modifiedTime < '%s'", cutoff.UTC().Format(time.RFC3339)))
+	}
+	if name := strings.TrimSpace(rule.NameContains); name != "" {
+		filters = append(filters, fmt.Sprintf("name contains '%s'", escapeDriveQueryString(name)))
+	}
+	if len(rule.Mime) > 0 {
+		anyOf := make([]string, 0, len(rule.Mime))
+		for _, m := range rule.Mime {
+			clause, err := driveSearchMimeClause(strings.TrimSpace(m))
+			if err != nil {
+				return nil, err
+			}
+			anyOf = append(anyOf, clause)
+		}
+		filters = append(filters, "("+strings.Join(anyOf, " or ")+")")
+	}
+
+	folders := []string{rule.Folder}
+	var out []*drive.File
+	for len(folders) > 0 {
+		folder := folders[0]
+		folders = folders[1:]
+		parent := fmt.Sprintf("'%s' in parents", escapeDriveQueryString(folder))
+		files, err := listDriveRetentionQuery(ctx, svc, parent+" and "+strings.Join(filters, " and "))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, files...)
+		if !rule.Recursive {
+			continue
+		}
+		subfolders, err := listDriveRetentionQuery(ctx, svc, fmt.Sprintf("%s and trashed = false and mimeType = '%s'", parent, driveMimeFolder))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range subfolders {
+			folders = append(folders, f.Id)
+		}
+	}
+	return out, nil
+}
+
+func listDriveRetentionQuery(ctx context.Context, svc *drive.Service, q string) ([]*drive.File, error) {
+	return collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+		call := svc.Files.List().
+			Q(q).
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			PageSize(1000).
+			Fields("nextPageToken", "files(id, name, mimeType, modifiedTime)").
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Files, resp.NextPageToken, nil
+	})
+}
+
+// listDriveRevisionsToPrune returns the revisions older than the newest keep,
+// skipping revisions pinned with keepForever.
+func listDriveRevisionsToPrune(ctx context.Context, svc *drive.Service, fileID string, keep int) ([]*drive.Revision, error) {
+	revs, err := collectAllPages("", func(pageToken string) ([]*drive.Revision, string, error) {
+		call := svc.Revisions.List(fileID).
+			PageSize(1000).
+			Fields("nextPageToken", "revisions(id, modifiedTime, keepForever)").
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Revisions, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(revs) <= keep {
+		return nil, nil
+	}
+	sort.SliceStable(revs, func(i, j int) bool { return revs[i].ModifiedTime < revs[j].ModifiedTime })
+	var prune []*drive.Revision
+	for _, r := range revs[:len(revs)-keep] {
+		if !r.KeepForever {
+			prune = append(prune, r)
+		}
+	}
+	return prune, nil
+}
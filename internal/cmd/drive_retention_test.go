@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestParseDriveRetentionPolicy(t *testing.T) {
+	p, err := parseDriveRetentionPolicy([]byte("rules:\n  - folder: https://drive.google.com/drive/folders/abc\n    keep_revisions: 3\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if r := p.Rules[0]; r.Folder != "abc" || r.Action != driveRetentionPruneRevisions || r.Name != "rule 1" {
+		t.Fatalf("unexpected rule %+v", r)
+	}
+
+	for _, bad := range []string{
+		"rules: []",
+		"rules:\n  - older_than: 30d\n    action: trash\n",
+		"rules:\n  - folder: f\n    action: trash\n",
+		"rules:\n  - folder: f\n    older_than: soon\n    action: trash\n",
+		"rules:\n  - folder: f\n    action: prune-revisions\n",
+		"rules:\n  - folder: f\n    older_than: 30d\n    action: shred\n",
+		"rules:\n  - folder: f\n    older_than: 30d\n    mime: [spreadsheet]\n    action: trash\n",
+	} {
+		if _, err := parseDriveRetentionPolicy([]byte(bad)); ExitCode(stableExitCode(err)) != 2 {
+			t.Fatalf("%q: expected usage error, got %v", bad, err)
+		}
+	}
+}
+
+func TestDriveRetentionRun(t *testing.T) {
+	orig := newDriveService
+	t.Cleanup(func() { newDriveService = orig })
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var mu sync.Mutex
+	var trashed, deleted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && path == "/files":
+			q := r.URL.Query().Get("q")
+			var files []map[string]any
+			switch {
+			case strings.Contains(q, "mimeType = '"+driveMimeFolder+"'") && strings.Contains(q, "'root' in parents"):
+				files = []map[string]any{{"id": "sub", "name": "Sub", "mimeType": driveMimeFolder}}
+			case strings.Contains(q, "mimeType = '"+driveMimeFolder+"'"):
+			case strings.Contains(q, "modifiedTime <") && strings.Contains(q, "'root' in parents"):
+				files = []map[string]any{{"id": "old1", "name": "export-1.pdf", "mimeType": "application/pdf", "modifiedTime": "2020-01-01T00:00:00.000Z"}}
+			case strings.Contains(q, "modifiedTime <") && strings.Contains(q, "'sub' in parents"):
+				files = []map[string]any{{"id": "old2", "name": "export-2.pdf", "mimeType": "application/pdf", "modifiedTime": "2020-01-02T00:00:00.000Z"}}
+			case strings.Contains(q, "'root' in parents"):
+				files = []map[string]any{
+					{"id": "old1", "name": "export-1.pdf", "mimeType": "application/pdf"},
+					{"id": "bin", "name": "data.bin", "mimeType": "application/octet-stream"},
+					{"id": "gdoc", "name": "Notes", "mimeType": "application/vnd.google-apps.document"},
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": files})
+		case r.Method == http.MethodGet && path == "/files/bin/revisions":
+			_ = json.NewEncoder(w).Encode(map[string]any{"revisions": []map[string]any{
+				{"id": "r4", "modifiedTime": "2024-04-01T00:00:00.000Z"},
+				{"id": "r1", "modifiedTime": "2024-01-01T00:00:00.000Z"},
+				{"id": "r2", "modifiedTime": "2024-02-01T00:00:00.000Z", "keepForever": true},
+				{"id": "r3", "modifiedTime": "2024-03-01T00:00:00.000Z"},
+			}})
+		case r.Method == http.MethodPatch && strings.HasPrefix(path, "/files/"):
+			var f drive.File
+			_ = json.NewDecoder(r.Body).Decode(&f)
+			if !f.Trashed {
+				t.Errorf("expected trashed=true for %s", path)
+			}
+			trashed = append(trashed, strings.TrimPrefix(path, "/files/"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": strings.TrimPrefix(path, "/files/")})
+		case r.Method == http.MethodDelete && strings.HasPrefix(path, "/files/bin/revisions/"):
+			deleted = append(deleted, strings.TrimPrefix(path, "/files/bin/revisions/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	policy := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(policy, []byte(`rules:
+  - name: old exports
+    folder: root
+    recursive: true
+    mime: [pdf]
+    older_than: 90d
+    action: trash
+  - name: trim revisions
+    folder: root
+    keep_revisions: 2
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	dry := captureStdout(t, func() {
+		_ = Execute([]string{"--json", "--dry-run", "--account", "a@b.com", "drive", "retention", "run", "--policy", policy})
+	})
+	if !strings.Contains(dry, `"dry_run": true`) || !strings.Contains(dry, `"revisionId": "r1"`) || len(trashed)+len(deleted) != 0 {
+		t.Fatalf("unexpected dry run %q (trashed=%v deleted=%v)", dry, trashed, deleted)
+	}
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--force", "--account", "a@b.com", "drive", "retention", "run", "--policy", policy}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	sort.Strings(trashed)
+	if strings.Join(trashed, ",") != "old1,old2" || strings.Join(deleted, ",") != "r1" {
+		t.Fatalf("trashed=%v deleted=%v", trashed, deleted)
+	}
+	var parsed struct {
+		Rules []driveRetentionResult `json:"rules"`
+		Total int                    `json:"total"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil || parsed.Total != 3 || parsed.Rules[1].Matched != 1 {
+		t.Fatalf("unexpected output %q: %v", out, err)
+	}
+
+	// Trashed files are journaled for `journal undo`; revisions are not.
+	entries, err := readBulkJournal()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one journal entry, got %v (%v)", entries, err)
+	}
+	if e := entries[0]; e.Op != "drive.retention.run" || e.Undo != bulkUndoDriveUntrash || e.Count != 2 {
+		t.Fatalf("unexpected journal entry: %#v", e)
+	}
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type DriveRevisionsCmd struct {
+	List     DriveRevisionsListCmd     `cmd:"" name:"list" aliases:"ls" default:"withargs" help:"List a file's revisions"`
+	Get      DriveRevisionsGetCmd      `cmd:"" name:"get" help:"Show revision metadata"`
+	Download DriveRevisionsDownloadCmd `cmd:"" name:"download" help:"Download a revision"`
+	Keep     DriveRevisionsKeepCmd     `cmd:"" name:"keep" aliases:"pin" help:"Keep a revision forever (binary files; --off to unpin)"`
+}
+
+// driveRevisionDetailFields adds the binary-file metadata that docs
+// revisions does not need.
+const driveRevisionDetailFields = driveRevisionFields + ",mimeType,originalFilename,md5Checksum,published"
+
+type DriveRevisionsListCmd struct {
+	FileID string `arg:"" name:"fileId" help:"File ID"`
+	Max    int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
+	Page   string `name:"page" aliases:"cursor" help:"Page token"`
+	All    bool   `name:"all" aliases:"all-pages,allpages" help:"Fetch all pages"`
+}
+
+func (c *DriveRevisionsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+	if c.Max <= 0 {
+		return usage("--max must be > 0")
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	revisions, nextPageToken, err := loadPagedItems(c.Page, c.All, func(pageToken string) ([]*drive.Revision, string, error) {
+		call := svc.Revisions.List(fileID).
+			PageSize(c.Max).
+			Fields("nextPageToken", "revisions("+driveRevisionDetailFields+")").
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Revisions, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if revisions == nil {
+			revisions = []*drive.Revision{}
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"fileId":        fileID,
+			"revisions":     revisions,
+			"nextPageToken": nextPageToken,
+		})
+	}
+	if len(revisions) == 0 {
+		u.Err().Println("No revisions")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tMODIFIED\tAUTHOR\tSIZE\tKEEP")
+	for _, r := range revisions {
+		size := "-"
+		if r.Size > 0 {
+			size = humanSize(ctx, r.Size)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", r.Id, humanDateTime(ctx, r.ModifiedTime), driveRevisionAuthor(r), size, r.KeepForever)
+	}
+	printNextPageHint(u, nextPageToken)
+	return nil
+}
+
+type DriveRevisionsGetCmd struct {
+	FileID     string `arg:"" name:"fileId" help:"File ID"`
+	RevisionID string `arg:"" name:"revisionId" help:"Revision ID"`
+}
+
+func (c *DriveRevisionsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	revisionID := strings.TrimSpace(c.RevisionID)
+	if fileID == "" || revisionID == "" {
+		return usage("fileId and revisionId are required")
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	rev, err := svc.Revisions.Get(fileID, revisionID).Fields(driveRevisionDetailFields + ",exportLinks").Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"revision": rev})
+	}
+	u.Out().Printf("id\t%s", rev.Id)
+	u.Out().Printf("modified\t%s", humanDateTime(ctx, rev.ModifiedTime))
+	if author := driveRevisionAuthor(rev); author != "" {
+		u.Out().Printf("author\t%s", author)
+	}
+	if rev.OriginalFilename != "" {
+		u.Out().Printf("filename\t%s", rev.OriginalFilename)
+	}
+	if rev.MimeType != "" {
+		u.Out().Printf("mime\t%s", rev.MimeType)
+	}
+	if rev.Size > 0 {
+		u.Out().Printf("size\t%s", humanSize(ctx, rev.Size))
+	}
+	if rev.Md5Checksum != "" {
+		u.Out().Printf("md5\t%s", rev.Md5Checksum)
+	}
+	u.Out().Printf("keep_forever\t%t", rev.KeepForever)
+	return nil
+}
+
+type DriveRevisionsDownloadCmd struct {
+	FileID     string                 `arg:"" name:"fileId" help:"File ID"`
+	RevisionID string                 `arg:"" name:"revisionId" help:"Revision ID"`
+	Output     OutputPathFlag         `embed:""`
+	Name       ExportNameTemplateFlag `embed:""`
+	Format     string                 `name:"format" help:"Export format for Google Docs files: pdf|csv|xlsx|pptx|txt|png|docx|md|html|epub (default: inferred)"`
+}
+
+func (c *DriveRevisionsDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if strings.TrimSpace(c.RevisionID) == "" {
+		return usage("empty revisionId")
+	}
+	return (&DriveDownloadCmd{
+		FileID:   c.FileID,
+		Output:   c.Output,
+		Name:     c.Name,
+		Format:   c.Format,
+		Revision: c.RevisionID,
+	}).Run(ctx, flags)
+}
+
+type DriveRevisionsKeepCmd struct {
+	FileID     string `arg:"" name:"fileId" help:"File ID"`
+	RevisionID string `arg:"" name:"revisionId" help:"Revision ID"`
+	Off        bool   `name:"off" help:"Clear keepForever so Drive may purge the revision again"`
+}
+
+func (c *DriveRevisionsKeepCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	revisionID := strings.TrimSpace(c.RevisionID)
+	if fileID == "" || revisionID == "" {
+		return usage("fileId and revisionId are required")
+	}
+	keep := !c.Off
+	if err := dryRunExit(ctx, flags, "drive.revisions.keep", map[string]any{
+		"file_id":      fileID,
+		"revision_id":  revisionID,
+		"keep_forever": keep,
+	}); err != nil {
+		return err
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	// keepForever=false is the zero value, so it must be sent explicitly.
+	rev, err := svc.Revisions.Update(fileID, revisionID, &drive.Revision{
+		KeepForever:     keep,
+		ForceSendFields: []string{"KeepForever"},
+	}).Fields("id,keepForever").Context(ctx).Do()
+	if err != nil {
+		var gerr *gapi.Error
+		if errors.As(err, &gerr) && gerr.Code == http.StatusBadRequest {
+			return fmt.Errorf("keep revision %s: %w (keepForever only applies to binary files, at most 200 revisions per file)", revisionID, err)
+		}
+		return err
+	}
+	return writeResult(ctx, u,
+		kv("fileId", fileID),
+		kv("revisionId", rev.Id),
+		kv("keepForever", rev.KeepForever),
+	)
+}
+
+func driveRevisionAuthor(r *drive.Revision) string {
+	if r == nil || r.LastModifyingUser == nil {
+		return ""
+	}
+	if r.LastModifyingUser.EmailAddress != "" {
+		return r.LastModifyingUser.EmailAddress
+	}
+	return r.LastModifyingUser.DisplayName
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDriveRevisionsCmds(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var updates []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files/f1/revisions":
+			_ = json.NewEncoder(w).Encode(map[string]any{"revisions": []map[string]any{
+				{"id": "r1", "modifiedTime": "2025-03-01T10:00:00Z", "size": "1024", "originalFilename": "report.pdf"},
+				{"id": "r2", "modifiedTime": "2025-03-02T10:00:00Z", "size": "2048", "keepForever": true},
+			}})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/f1/revisions/r1" && r.URL.Query().Get("alt") == "media":
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte("old bytes"))
+		case r.Method == http.MethodGet && r.URL.Path == "/files/f1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "f1", "name": "report.pdf", "mimeType": "application/pdf"})
+		case r.Method == http.MethodPatch && r.URL.Path == "/files/f1/revisions/r1":
+			body, _ := io.ReadAll(r.Body)
+			updates = append(updates, string(body))
+			var rev map[string]any
+			_ = json.Unmarshal(body, &rev)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "r1", "keepForever": rev["keepForever"]})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := newDocsJSONContext(t)
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveRevisionsCmd{}, []string{"f1"}, ctx, flags); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	var listed struct {
+		FileID    string           `json:"fileId"`
+		Revisions []drive.Revision `json:"revisions"`
+	}
+	if err := json.Unmarshal([]byte(out), &listed); err != nil || listed.FileID != "f1" || len(listed.Revisions) != 2 || !listed.Revisions[1].KeepForever {
+		t.Fatalf("unexpected list output: %v %s", err, out)
+	}
+
+	dest := filepath.Join(t.TempDir(), "old.pdf")
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveRevisionsCmd{}, []string{"download", "f1", "r1", "--out", dest}, ctx, flags); err != nil {
+			t.Fatalf("download: %v", err)
+		}
+	})
+	if b, err := os.ReadFile(dest); err != nil || string(b) != "old bytes" {
+		t.Fatalf("unexpected download: %q, %v", b, err)
+	}
+
+	for _, args := range [][]string{{"keep", "f1", "r1"}, {"keep", "f1", "r1", "--off"}} {
+		_ = captureStdout(t, func() {
+			if err := runKong(t, &DriveRevisionsCmd{}, args, ctx, flags); err != nil {
+				t.Fatalf("%v: %v", args, err)
+			}
+		})
+	}
+	if len(updates) != 2 || !strings.Contains(updates[0], `"keepForever":true`) || !strings.Contains(updates[1], `"keepForever":false`) {
+		t.Fatalf("unexpected keepForever updates: %v", updates)
+	}
+}
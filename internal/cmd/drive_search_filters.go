@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// driveSearchFilters are the structured `drive search` flags that compile
+// into Drive query-language clauses.
+type driveSearchFilters struct {
+	NameContains   string   `name:"name-contains" help:"Name contains this text"`
+	Mime           []string `name:"mime" help:"Type: doc|sheet|slides|folder|pdf|image|video|audio or a MIME type (repeatable or comma-separated; any of)"`
+	ModifiedAfter  string   `name:"modified-after" help:"Modified after this date/time (YYYY-MM-DD, RFC3339, today, yesterday, monday)"`
+	ModifiedBefore string   `name:"modified-before" help:"Modified before this date/time"`
+	Owner          string   `name:"owner" help:"Owned by this email (or 'me')"`
+	InFolder       string   `name:"in-folder" help:"Directly inside this folder ID"`
+	Starred        bool     `name:"starred" help:"Only starred files"`
+	Trashed        bool     `name:"trashed" help:"Only files in the trash"`
+	Props          []string `name:"prop" help:"Custom property key=value (repeatable; all must match)" sep:"none"`
+	AppProps       []string `name:"app-prop" help:"appProperties key=value set by this OAuth client (repeatable; all must match)" sep:"none"`
+}
+
+// clauses returns the query clauses for the set flags, in flag order.
+func (f driveSearchFilters) clauses(now time.Time, loc *time.Location) ([]string, error) {
+	var out []string
+	if name := strings.TrimSpace(f.NameContains); name != "" {
+		out = append(out, fmt.Sprintf("name contains '%s'", escapeDriveQueryString(name)))
+	}
+	if len(f.Mime) > 0 {
+		var anyOf []string
+		for _, m := range f.Mime {
+			m = strings.TrimSpace(m)
+			if m == "" {
+				continue
+			}
+			clause, err := driveSearchMimeClause(m)
+			if err != nil {
+				return nil, err
+			}
+			anyOf = append(anyOf, clause)
+		}
+		switch len(anyOf) {
+		case 0:
+		case 1:
+			out = append(out, anyOf[0])
+		default:
+			out = append(out, "("+strings.Join(anyOf, " or ")+")")
+		}
+	}
+	if expr := strings.TrimSpace(f.ModifiedAfter); expr != "" {
+		t, err := parseTimeExpr(expr, now, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --modified-after: %w", err)
+		}
+		out = append(out, fmt.Sprintf("modifiedTime > '%s'", t.UTC().Format(time.RFC3339)))
+	}
+	if expr := strings.TrimSpace(f.ModifiedBefore); expr != "" {
+		t, err := parseTimeExpr(expr, now, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --modified-before: %w", err)
+		}
+		out = append(out, fmt.Sprintf("modifiedTime < '%s'", t.UTC().Format(time.RFC3339)))
+	}
+	if owner := strings.TrimSpace(f.Owner); owner != "" {
+		out = append(out, fmt.Sprintf("'%s' in owners", escapeDriveQueryString(owner)))
+	}
+	if folder := normalizeGoogleID(strings.TrimSpace(f.InFolder)); folder != "" {
+		out = append(out, fmt.Sprintf("'%s' in parents", escapeDriveQueryString(folder)))
+	}
+	if f.Starred {
+		out = append(out, "starred = true")
+	}
+	if f.Trashed {
+		out = append(out, "trashed = true")
+	}
+	for _, set := range []struct {
+		flag, field string
+		pairs       []string
+	}{{"--prop", "properties", f.Props}, {"--app-prop", "appProperties", f.AppProps}} {
+		for _, pair := range set.pairs {
+			k, v, ok := strings.Cut(pair, "=")
+			k = strings.TrimSpace(k)
+			if !ok || k == "" {
+				return nil, usagef("invalid %s %q (use key=value)", set.flag, pair)
+			}
+			out = append(out, fmt.Sprintf("%s has { key='%s' and value='%s' }", set.field, escapeDriveQueryString(k), escapeDriveQueryString(v)))
+		}
+	}
+	return out, nil
+}
+
+func driveSearchMimeClause(value string) (string, error) {
+	switch strings.ToLower(value) {
+	case "folder":
+		return fmt.Sprintf("mimeType = '%s'", driveMimeFolder), nil
+	case "pdf":
+		return fmt.Sprintf("mimeType = '%s'", mimePDF), nil
+	case "image", "video", "audio":
+		return fmt.Sprintf("mimeType contains '%s/'", strings.ToLower(value)), nil
+	}
+	if mimeType, ok := googleConvertTargetMimeType(value); ok {
+		return fmt.Sprintf("mimeType = '%s'", mimeType), nil
+	}
+	if !strings.Contains(value, "/") {
+		return "", usagef("invalid --mime %q (use doc|sheet|slides|folder|pdf|image|video|audio or a MIME type)", value)
+	}
+	return fmt.Sprintf("mimeType = '%s'", escapeDriveQueryString(value)), nil
+}
+
+// buildDriveStructuredSearchQuery ANDs the free-text (or raw) query with
+// the filter clauses. Trashed files stay excluded unless a clause says
+// otherwise.
+func buildDriveStructuredSearchQuery(text string, rawQuery bool, clauses []string) string {
+	text = strings.TrimSpace(text)
+	if len(clauses) == 0 {
+		return buildDriveSearchQuery(text, rawQuery)
+	}
+	parts := make([]string, 0, len(clauses)+1)
+	switch {
+	case text == "":
+	case rawQuery || looksLikeDriveQueryLanguage(text):
+		parts = append(parts, "("+text+")")
+	default:
+		parts = append(parts, fmt.Sprintf("fullText contains '%s'", escapeDriveQueryString(text)))
+	}
+	parts = append(parts, clauses...)
+	return buildDriveFilterQuery(strings.Join(parts, " and "))
+}
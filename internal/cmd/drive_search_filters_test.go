@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDriveSearchFilters_Clauses(t *testing.T) {
+	now := time.Date(2025, 3, 12, 15, 0, 0, 0, time.UTC)
+	got, err := driveSearchFilters{
+		NameContains:  "Q1 'plan'",
+		Mime:          []string{"sheet", "pdf", "image"},
+		ModifiedAfter: "2025-01-01",
+		Owner:         "me",
+		InFolder:      "https://drive.google.com/drive/folders/abc123",
+		Starred:       true,
+	}.clauses(now, time.UTC)
+	if err != nil {
+		t.Fatalf("clauses: %v", err)
+	}
+	want := []string{
+		`name contains 'Q1 \'plan\''`,
+		"(mimeType = 'application/vnd.google-apps.spreadsheet' or mimeType = 'application/pdf' or mimeType contains 'image/')",
+		"modifiedTime > '2025-01-01T00:00:00Z'",
+		"'me' in owners",
+		"'abc123' in parents",
+		"starred = true",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected clauses:\n%s", strings.Join(got, "\n"))
+	}
+
+	if _, err := (driveSearchFilters{Mime: []string{"spreadsheet"}}).clauses(now, time.UTC); err == nil || !strings.Contains(err.Error(), "invalid --mime") {
+		t.Fatalf("expected invalid --mime error, got %v", err)
+	}
+	if _, err := (driveSearchFilters{ModifiedBefore: "soon"}).clauses(now, time.UTC); err == nil || !strings.Contains(err.Error(), "--modified-before") {
+		t.Fatalf("expected invalid --modified-before error, got %v", err)
+	}
+
+	got, err = driveSearchFilters{Props: []string{"project=o'neil"}, AppProps: []string{"state=done"}}.clauses(now, time.UTC)
+	if err != nil {
+		t.Fatalf("clauses: %v", err)
+	}
+	if strings.Join(got, "\n") != "properties has { key='project' and value='o\\'neil' }\nappProperties has { key='state' and value='done' }" {
+		t.Fatalf("unexpected prop clauses:\n%s", strings.Join(got, "\n"))
+	}
+	if _, err := (driveSearchFilters{Props: []string{"project"}}).clauses(now, time.UTC); err == nil || !strings.Contains(err.Error(), "invalid --prop") {
+		t.Fatalf("expected invalid --prop error, got %v", err)
+	}
+}
+
+func TestBuildDriveStructuredSearchQuery(t *testing.T) {
+	cases := []struct {
+		text    string
+		raw     bool
+		clauses []string
+		want    string
+	}{
+		{"budget", false, nil, "fullText contains 'budget' and trashed = false"},
+		{"budget", false, []string{"starred = true"}, "fullText contains 'budget' and starred = true and trashed = false"},
+		{"", false, []string{"'me' in owners"}, "'me' in owners and trashed = false"},
+		{"name = 'a' or name = 'b'", true, []string{"trashed = true"}, "(name = 'a' or name = 'b') and trashed = true"},
+	}
+	for _, tc := range cases {
+		if got := buildDriveStructuredSearchQuery(tc.text, tc.raw, tc.clauses); got != tc.want {
+			t.Fatalf("buildDriveStructuredSearchQuery(%q, %v, %v) = %q, want %q", tc.text, tc.raw, tc.clauses, got, tc.want)
+		}
+	}
+}
+
+func TestDriveSearchCmd_FilterFlagsOnly(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/files" {
+			http.NotFound(w, r)
+			return
+		}
+		gotQuery = r.URL.Query().Get("q")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{{"id": "f1", "name": "Report"}}})
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	_ = captureStdout(t, func() {
+		args := []string{"--name-contains", "Report", "--mime", "doc,folder", "--trashed"}
+		if err := runKong(t, &DriveSearchCmd{}, args, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("search: %v", err)
+		}
+	})
+	want := "name contains 'Report' and (mimeType = 'application/vnd.google-apps.document' or mimeType = 'application/vnd.google-apps.folder') and trashed = true"
+	if gotQuery != want {
+		t.Fatalf("unexpected q:\n got %s\nwant %s", gotQuery, want)
+	}
+}
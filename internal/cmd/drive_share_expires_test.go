@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestParseDriveExpiration(t *testing.T) {
+	now := time.Date(2025, 3, 7, 10, 0, 0, 0, time.UTC)
+	for expr, want := range map[string]time.Time{
+		"12h":        now.Add(12 * time.Hour),
+		"7d":         now.AddDate(0, 0, 7),
+		"2W":         now.AddDate(0, 0, 14),
+		"2025-03-31": time.Date(2025, 3, 31, 23, 59, 59, 999999999, time.UTC),
+	} {
+		got, err := parseDriveExpiration(expr, now, time.UTC)
+		if err != nil || !got.Equal(want) {
+			t.Fatalf("parseDriveExpiration(%q) = %v, %v; want %v", expr, got, err, want)
+		}
+	}
+	for expr, want := range map[string]string{
+		"soon":       "invalid --expires",
+		"2025-01-01": "not in the future",
+		"400d":       "more than a year",
+	} {
+		if _, err := parseDriveExpiration(expr, now, time.UTC); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("parseDriveExpiration(%q): expected %q error, got %v", expr, want, err)
+		}
+	}
+}
+
+func TestDriveShareCmd_Expires(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var created []drive.Permission
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/files/f1/permissions":
+			var p drive.Permission
+			_ = json.NewDecoder(r.Body).Decode(&p)
+			created = append(created, p)
+			p.Id = "p1"
+			_ = json.NewEncoder(w).Encode(p)
+		case r.Method == http.MethodGet && r.URL.Path == "/files/f1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "f1", "webViewLink": "https://drive.google.com/file/d/f1/view"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	var outBuf, errBuf bytes.Buffer
+	u, err := ui.New(ui.Options{Stdout: &outBuf, Stderr: &errBuf, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{})
+	flags := &RootFlags{Account: "a@b.com", Force: true}
+
+	if err := runKong(t, &DriveShareCmd{}, []string{"f1", "--to", "user", "--email", "x@example.com", "--role", "commenter", "--expires", "7d"}, ctx, flags); err != nil {
+		t.Fatalf("share user: %v", err)
+	}
+	exp, err := time.Parse(time.RFC3339, created[0].ExpirationTime)
+	if err != nil || created[0].Role != "commenter" {
+		t.Fatalf("unexpected user permission: %+v", created[0])
+	}
+	if d := time.Until(exp); d < 6*24*time.Hour || d > 8*24*time.Hour {
+		t.Fatalf("expected expiry ~7 days out, got %v", exp)
+	}
+	if !strings.Contains(outBuf.String(), "link\thttps://drive.google.com/file/d/f1/view") || !strings.Contains(outBuf.String(), "expires\t") {
+		t.Fatalf("unexpected output: %q", outBuf.String())
+	}
+
+	if err := runKong(t, &DriveShareCmd{}, []string{"f1", "--to", "group", "--email", "team@example.com", "--expires", "2w"}, ctx, flags); err != nil {
+		t.Fatalf("share group: %v", err)
+	}
+	if created[1].Type != "group" || created[1].EmailAddress != "team@example.com" || created[1].ExpirationTime == "" {
+		t.Fatalf("expected expiring group permission, got %+v", created[1])
+	}
+
+	// Anyone links cannot expire in Drive, so asking for one must not
+	// silently create a permanent public link.
+	err = runKong(t, &DriveShareCmd{}, []string{"f1", "--anyone", "--role", "reader", "--expires", "7d"}, ctx, flags)
+	if err == nil || !strings.Contains(err.Error(), "cannot expire --to=anyone") {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+	if len(created) != 2 {
+		t.Fatalf("expected no permission for --anyone --expires, got %+v", created[2:])
+	}
+}
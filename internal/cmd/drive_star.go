@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type DriveStarCmd struct {
+	FileIDs []string `arg:"" name:"fileId" help:"File IDs or /paths"`
+}
+
+func (c *DriveStarCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return setDriveStarred(ctx, flags, c.FileIDs, true)
+}
+
+type DriveUnstarCmd struct {
+	FileIDs []string `arg:"" name:"fileId" help:"File IDs or /paths"`
+}
+
+func (c *DriveUnstarCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return setDriveStarred(ctx, flags, c.FileIDs, false)
+}
+
+func setDriveStarred(ctx context.Context, flags *RootFlags, fileIDs []string, starred bool) error {
+	u := ui.FromContext(ctx)
+	ids := make([]string, 0, len(fileIDs))
+	for _, id := range fileIDs {
+		if id = strings.TrimSpace(id); !isDrivePath(id) {
+			id = normalizeGoogleID(id)
+		}
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return usage("empty fileId")
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+
+	files := make([]*drive.File, 0, len(ids))
+	for _, ref := range ids {
+		id, err := resolveDriveID(ctx, svc, ref)
+		if err != nil {
+			return err
+		}
+		// Starred is a bool, so false must be sent explicitly.
+		updated, err := svc.Files.Update(id, &drive.File{Starred: starred, ForceSendFields: []string{"Starred"}}).
+			SupportsAllDrives(true).
+			Fields("id, name, starred").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return err
+		}
+		files = append(files, updated)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"files": files})
+	}
+	for _, f := range files {
+		u.Out().Printf("%s\t%s\tstarred=%t", f.Id, f.Name, f.Starred)
+	}
+	return nil
+}
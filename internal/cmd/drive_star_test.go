@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDriveStarUnstarAndListStarred(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var patched []map[string]any
+	var listQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/files/"):
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			patched = append(patched, body)
+			starred, _ := body["starred"].(bool)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": strings.TrimPrefix(r.URL.Path, "/files/"), "name": "Plan", "starred": starred})
+		case r.Method == http.MethodGet && r.URL.Path == "/files":
+			listQuery = r.URL.Query().Get("q")
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{{"id": "f1", "name": "Plan", "starred": true}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	flags := &RootFlags{Account: "a@b.com"}
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveStarCmd{}, []string{"f1", "f2"}, newDocsJSONContext(t), flags); err != nil {
+			t.Fatalf("star: %v", err)
+		}
+		if err := runKong(t, &DriveUnstarCmd{}, []string{"f1"}, newDocsJSONContext(t), flags); err != nil {
+			t.Fatalf("unstar: %v", err)
+		}
+	})
+	if len(patched) != 3 || patched[0]["starred"] != true || patched[2]["starred"] != false {
+		t.Fatalf("unexpected patches: %+v", patched)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveLsCmd{}, []string{"--starred"}, newDocsJSONContext(t), flags); err != nil {
+			t.Fatalf("ls: %v", err)
+		}
+	})
+	if listQuery != "starred = true and trashed = false" {
+		t.Fatalf("unexpected query %q", listQuery)
+	}
+	if !strings.Contains(out, `"starred": true`) {
+		t.Fatalf("expected starred in output: %s", out)
+	}
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveLsCmd{}, []string{"--starred", "--parent", "folder1", "--query", "name contains 'x'"}, newDocsJSONContext(t), flags); err != nil {
+			t.Fatalf("ls parent: %v", err)
+		}
+	})
+	if listQuery != "starred = true and (name contains 'x') and 'folder1' in parents and trashed = false" {
+		t.Fatalf("unexpected query %q", listQuery)
+	}
+}
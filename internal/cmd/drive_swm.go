@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+var driveAgePattern = regexp.MustCompile(`^(\d+)([dwmy])$`)
+
+type DriveSwmCmd struct {
+	List DriveSwmListCmd `cmd:"" name:"list" aliases:"ls" default:"withargs" help:"List items shared with me; optionally shortcut them into a folder or leave the shares"`
+}
+
+type DriveSwmListCmd struct {
+	Owner         string `name:"owner" help:"Only items owned by this email"`
+	OlderThan     string `name:"older-than" help:"Only items shared with me before this (30d, 12w, 6m, 1y, or a date)"`
+	Max           int    `name:"max" aliases:"limit" help:"Max items (0 = all)" default:"100"`
+	AddShortcutTo string `name:"add-shortcut-to" help:"Create a shortcut to each item in this folder of my Drive (skips items already shortcut there)"`
+	Remove        bool   `name:"remove" help:"Remove myself from each item's sharing (direct shares only; asks for confirmation)"`
+}
+
+type driveSwmItem struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	MimeType   string `json:"mimeType"`
+	Owner      string `json:"owner,omitempty"`
+	SharedBy   string `json:"sharedBy,omitempty"`
+	SharedTime string `json:"sharedWithMeTime,omitempty"`
+	Action     string `json:"action,omitempty"`
+	ShortcutID string `json:"shortcutId,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+const (
+	driveSwmShortcut = "shortcut"
+	driveSwmSkipped  = "skipped"
+	driveSwmRemoved  = "removed"
+	driveSwmFailed   = "failed"
+)
+
+func (c *DriveSwmListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	folderID := normalizeGoogleID(strings.TrimSpace(c.AddShortcutTo))
+	if folderID != "" && c.Remove {
+		return usage("use --add-shortcut-to or --remove, not both")
+	}
+	if c.Max < 0 {
+		return usage("--max must be >= 0")
+	}
+	var cutoff time.Time
+	if expr := strings.TrimSpace(c.OlderThan); expr != "" {
+		t, err := parseDriveAgeCutoff(expr, time.Now(), time.Local)
+		if err != nil {
+			return err
+		}
+		cutoff = t
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	items, err := listDriveSharedWithMe(ctx, svc, strings.TrimSpace(c.Owner), cutoff, c.Max)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case folderID != "" && len(items) > 0:
+		if err := dryRunExit(ctx, flags, "drive.swm.shortcut", map[string]any{
+			"folder_id": folderID,
+			"file_ids":  driveSwmIDs(items),
+		}); err != nil {
+			return err
+		}
+		if err := addDriveSwmShortcuts(ctx, svc, folderID, items); err != nil {
+			return err
+		}
+	case c.Remove && len(items) > 0:
+		if err := dryRunAndConfirmDestructive(ctx, flags, "drive.swm.remove", map[string]any{
+			"file_ids": driveSwmIDs(items),
+		}, fmt.Sprintf("remove yourself from %d shared item%s", len(items), pluralS(len(items)))); err != nil {
+			return err
+		}
+		if err := removeDriveSwmAccess(ctx, svc, items); err != nil {
+			return err
+		}
+	}
+
+	counts := map[string]int{}
+	for _, it := range items {
+		counts[it.Action]++
+	}
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"files":            items,
+			"count":            len(items),
+			"shortcutsCreated": counts[driveSwmShortcut],
+			"removed":          counts[driveSwmRemoved],
+			"failed":           counts[driveSwmFailed],
+		}); err != nil {
+			return err
+		}
+	} else {
+		if len(items) == 0 {
+			u.Err().Println("No shared items")
+			return nil
+		}
+		w, flush := tableWriter(ctx)
+		acting := folderID != "" || c.Remove
+		header := "ID\tNAME\tOWNER\tSHARED"
+		if acting {
+			header += "\tACTION"
+		}
+		fmt.Fprintln(w, header)
+		for _, it := range items {
+			line := fmt.Sprintf("%s\t%s\t%s\t%s", it.ID, it.Name, orEmpty(it.Owner, "-"), humanDateTime(ctx, it.SharedTime))
+			if acting {
+				action := it.Action
+				if it.Error != "" {
+					action += ": " + it.Error
+				}
+				line += "\t" + action
+			}
+			fmt.Fprintln(w, line)
+		}
+		flush()
+		switch {
+		case folderID != "":
+			u.Err().Printf("Created %d shortcut%s in %s (%d already there)", counts[driveSwmShortcut], pluralS(counts[driveSwmShortcut]), folderID, counts[driveSwmSkipped])
+		case c.Remove:
+			u.Err().Printf("Removed yourself from %d item%s", counts[driveSwmRemoved], pluralS(counts[driveSwmRemoved]))
+		}
+	}
+	if n := counts[driveSwmFailed]; n > 0 {
+		return fmt.Errorf("%d of %d item%s failed", n, len(items), pluralS(len(items)))
+	}
+	return nil
+}
+
+// parseDriveAgeCutoff turns an age (30d, 12w, 6m, 1y) into the instant that
+// long ago; anything else is parsed as a date/time.
+func parseDriveAgeCutoff(expr string, now time.Time, loc *time.Location) (time.Time, error) {
+	if m := driveAgePattern.FindStringSubmatch(strings.ToLower(expr)); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2] {
+		case "d":
+			return now.AddDate(0, 0, -n), nil
+		case "w":
+			return now.AddDate(0, 0, -7*n), nil
+		case "m":
+			return now.AddDate(0, -n, 0), nil
+		default:
+			return now.AddDate(-n, 0, 0), nil
+		}
+	}
+	t, err := parseTimeExpr(expr, now, loc)
+	if err != nil {
+		return time.Time{}, usagef("invalid --older-than %q (use 30d, 12w, 6m, 1y, or a date like 2025-01-31)", expr)
+	}
+	return t, nil
+}
+
+// listDriveSharedWithMe returns shared-with-me items, newest share first.
+// Drive's query language cannot filter on sharedWithMeTime, so --older-than
+// is applied to the fetched pages.
+func listDriveSharedWithMe(ctx context.Context, svc *drive.Service, owner string, cutoff time.Time, limit int) ([]driveSwmItem, error) {
+	q := "sharedWithMe = true and trashed = false"
+	if owner != "" {
+		q += fmt.Sprintf(" and '%s' in owners", escapeDriveQueryString(owner))
+	}
+	files, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+		call := svc.Files.List().
+			Q(q).
+			OrderBy("sharedWithMeTime desc").
+			PageSize(1000).
+			Fields("nextPageToken, files(id, name, mimeType, sharedWithMeTime, owners(emailAddress), sharingUser(emailAddress))").
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Files, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]driveSwmItem, 0, len(files))
+	for _, f := range files {
+		if f == nil {
+			continue
+		}
+		if !cutoff.IsZero() {
+			shared, parseErr := time.Parse(time.RFC3339, f.SharedWithMeTime)
+			if parseErr != nil || !shared.Before(cutoff) {
+				continue
+			}
+		}
+		it := driveSwmItem{ID: f.Id, Name: f.Name, MimeType: f.MimeType, SharedTime: f.SharedWithMeTime}
+		if len(f.Owners) > 0 && f.Owners[0] != nil {
+			it.Owner = f.Owners[0].EmailAddress
+		}
+		if f.SharingUser != nil {
+			it.SharedBy = f.SharingUser.EmailAddress
+		}
+		items = append(items, it)
+		if limit > 0 && len(items) == limit {
+			break
+		}
+	}
+	return items, nil
+}
+
+// addDriveSwmShortcuts creates one shortcut per item in folderID, skipping
+// targets that already have a shortcut there so reruns are idempotent.
+func addDriveSwmShortcuts(ctx context.Context, svc *drive.Service, folderID string, items []driveSwmItem) error {
+	existing, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+		call := svc.Files.List().
+			Q(fmt.Sprintf("'%s' in parents and mimeType = '%s' and trashed = false", escapeDriveQueryString(folderID), driveMimeShortcut)).
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			PageSize(1000).
+			Fields("nextPageToken, files(id, shortcutDetails(targetId))").
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Files, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return fmt.Errorf("list shortcuts in %s: %w", folderID, err)
+	}
+	have := map[string]string{}
+	for _, f := range existing {
+		if f != nil && f.ShortcutDetails != nil {
+			have[f.ShortcutDetails.TargetId] = f.Id
+		}
+	}
+
+	for i := range items {
+		it := &items[i]
+		if id, ok := have[it.ID]; ok {
+			it.Action, it.ShortcutID = driveSwmSkipped, id
+			continue
+		}
+		created, err := svc.Files.Create(&drive.File{
+			Name:            it.Name,
+			MimeType:        driveMimeShortcut,
+			Parents:         []string{folderID},
+			ShortcutDetails: &drive.FileShortcutDetails{TargetId: it.ID},
+		}).SupportsAllDrives(true).Fields("id").Context(ctx).Do()
+		if err != nil {
+			it.Action, it.Error = driveSwmFailed, err.Error()
+			continue
+		}
+		it.Action, it.ShortcutID = driveSwmShortcut, created.Id
+		have[it.ID] = created.Id
+	}
+	return nil
+}
+
+// removeDriveSwmAccess deletes the caller's own permission on each item.
+// Access through a group, domain, or link has no per-user permission to
+// delete, so those items fail individually.
+func removeDriveSwmAccess(ctx context.Context, svc *drive.Service, items []driveSwmItem) error {
+	about, err := svc.About.Get().Fields("user(permissionId)").Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("look up your permission ID: %w", err)
+	}
+	if about.User == nil || about.User.PermissionId == "" {
+		return fmt.Errorf("look up your permission ID: empty response")
+	}
+	for i := range items {
+		it := &items[i]
+		if err := svc.Permissions.Delete(it.ID, about.User.PermissionId).SupportsAllDrives(true).Context(ctx).Do(); err != nil {
+			if isNotFoundAPIError(err) {
+				it.Action, it.Error = driveSwmFailed, "not shared with you directly (group, domain, or link access)"
+			} else {
+				it.Action, it.Error = driveSwmFailed, err.Error()
+			}
+			continue
+		}
+		it.Action = driveSwmRemoved
+	}
+	return nil
+}
+
+func driveSwmIDs(items []driveSwmItem) []string {
+	ids := make([]string, 0, len(items))
+	for _, it := range items {
+		ids = append(ids, it.ID)
+	}
+	return ids
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestParseDriveAgeCutoff(t *testing.T) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	for expr, want := range map[string]time.Time{
+		"30d":        now.AddDate(0, 0, -30),
+		"2w":         now.AddDate(0, 0, -14),
+		"6M":         now.AddDate(0, -6, 0),
+		"1y":         now.AddDate(-1, 0, 0),
+		"2025-01-15": time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	} {
+		got, err := parseDriveAgeCutoff(expr, now, time.UTC)
+		if err != nil || !got.Equal(want) {
+			t.Fatalf("parseDriveAgeCutoff(%q) = %v, %v; want %v", expr, got, err, want)
+		}
+	}
+	if _, err := parseDriveAgeCutoff("ages", now, time.UTC); err == nil {
+		t.Fatal("expected error for invalid --older-than")
+	}
+}
+
+func TestDriveSwmListCmd(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	old := time.Now().AddDate(0, -3, 0).UTC().Format(time.RFC3339)
+	recent := time.Now().AddDate(0, 0, -1).UTC().Format(time.RFC3339)
+	var listQueries []string
+	var shortcuts []drive.File
+	var deleted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files":
+			q := r.URL.Query().Get("q")
+			listQueries = append(listQueries, q)
+			if strings.Contains(q, "'mine' in parents") {
+				_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+					{"id": "s1", "shortcutDetails": map[string]any{"targetId": "a"}},
+				}})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "n", "name": "New", "sharedWithMeTime": recent, "owners": []map[string]any{{"emailAddress": "bob@example.com"}}},
+				{"id": "a", "name": "Alpha", "sharedWithMeTime": old, "owners": []map[string]any{{"emailAddress": "bob@example.com"}}},
+				{"id": "b", "name": "Beta", "sharedWithMeTime": old, "owners": []map[string]any{{"emailAddress": "bob@example.com"}}},
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/files":
+			var f drive.File
+			_ = json.NewDecoder(r.Body).Decode(&f)
+			shortcuts = append(shortcuts, f)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "s-" + f.ShortcutDetails.TargetId})
+		case r.Method == http.MethodGet && r.URL.Path == "/about":
+			_ = json.NewEncoder(w).Encode(map[string]any{"user": map[string]any{"permissionId": "me123"}})
+		case r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/permissions/me123"):
+			if strings.HasPrefix(r.URL.Path, "/files/b/") {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 404, "message": "Permission not found"}})
+				return
+			}
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	flags := &RootFlags{Account: "a@b.com", Force: true}
+	ctx := newDocsJSONContext(t)
+
+	out := captureStdout(t, func() {
+		args := []string{"--owner", "bob@example.com", "--older-than", "30d", "--add-shortcut-to", "mine"}
+		if err := runKong(t, &DriveSwmCmd{}, args, ctx, flags); err != nil {
+			t.Fatalf("shortcut: %v", err)
+		}
+	})
+	if !strings.Contains(listQueries[0], "sharedWithMe = true") || !strings.Contains(listQueries[0], "'bob@example.com' in owners") {
+		t.Fatalf("unexpected list query: %s", listQueries[0])
+	}
+	if len(shortcuts) != 1 || shortcuts[0].ShortcutDetails.TargetId != "b" || shortcuts[0].Parents[0] != "mine" || shortcuts[0].MimeType != driveMimeShortcut {
+		t.Fatalf("expected one new shortcut to b, got %+v", shortcuts)
+	}
+	var parsed struct {
+		Files            []driveSwmItem `json:"files"`
+		ShortcutsCreated int            `json:"shortcutsCreated"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(parsed.Files) != 2 || parsed.Files[0].Action != driveSwmSkipped || parsed.ShortcutsCreated != 1 {
+		t.Fatalf("unexpected result: %s", out)
+	}
+
+	_ = captureStdout(t, func() {
+		err := runKong(t, &DriveSwmCmd{}, []string{"list", "--older-than", "30d", "--remove"}, ctx, flags)
+		if err == nil || !strings.Contains(err.Error(), "1 of 2 items failed") {
+			t.Fatalf("expected partial failure, got %v", err)
+		}
+	})
+	if len(deleted) != 1 || deleted[0] != "/files/a/permissions/me123" {
+		t.Fatalf("unexpected deletes: %v", deleted)
+	}
+
+	if err := runKong(t, &DriveSwmCmd{}, []string{"--remove", "--add-shortcut-to", "x"}, ctx, flags); err == nil || !strings.Contains(err.Error(), "not both") {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // matches Drive's md5Checksum
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func driveSyncTestMD5(s string) string {
+	sum := md5.Sum([]byte(s)) //nolint:gosec // test fixture
+	return hex.EncodeToString(sum[:])
+}
+
+func TestPlanDriveSync(t *testing.T) {
+	local := map[string]driveSyncFile{
+		"same.txt":   {Path: "same.txt", MD5: driveSyncTestMD5("same")},
+		"edited.txt": {Path: "edited.txt", MD5: driveSyncTestMD5("edited locally")},
+		"both.txt":   {Path: "both.txt", MD5: driveSyncTestMD5("local")},
+		"new.txt":    {Path: "new.txt", MD5: driveSyncTestMD5("new")},
+		"gone.txt":   {Path: "gone.txt", MD5: driveSyncTestMD5("gone")},
+	}
+	remote := map[string]driveSyncFile{
+		"same.txt":   {Path: "same.txt", MD5: driveSyncTestMD5("same")},
+		"edited.txt": {Path: "edited.txt", MD5: driveSyncTestMD5("v1")},
+		"both.txt":   {Path: "both.txt", MD5: driveSyncTestMD5("remote")},
+		"drive.txt":  {Path: "drive.txt", MD5: driveSyncTestMD5("drive")},
+	}
+	state := &driveSyncState{Files: map[string]driveSyncStateFile{
+		"same.txt":   {MD5: driveSyncTestMD5("same")},
+		"edited.txt": {MD5: driveSyncTestMD5("v1")},
+		"both.txt":   {MD5: driveSyncTestMD5("v1")},
+		"gone.txt":   {MD5: driveSyncTestMD5("gone")},
+	}}
+	hash := func(f driveSyncFile) (string, error) { return f.MD5, nil }
+	plan := func(opts driveSyncPlanOptions) string {
+		t.Helper()
+		actions, err := planDriveSync(local, remote, state, opts, hash)
+		if err != nil {
+			t.Fatalf("plan: %v", err)
+		}
+		var parts []string
+		for _, a := range actions {
+			parts = append(parts, a.Action+" "+a.Path)
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	if got, want := plan(driveSyncPlanOptions{mode: driveSyncPush, delete: true}),
+		"update both.txt, delete-remote drive.txt, update edited.txt, upload gone.txt, upload new.txt"; got != want {
+		t.Fatalf("push:\n got %s\nwant %s", got, want)
+	}
+	if got, want := plan(driveSyncPlanOptions{mode: driveSyncPull}),
+		"download both.txt, download drive.txt, download edited.txt"; got != want {
+		t.Fatalf("pull:\n got %s\nwant %s", got, want)
+	}
+	if got, want := plan(driveSyncPlanOptions{mode: driveSyncTwoWay}),
+		"conflict both.txt, download drive.txt, update edited.txt, skip gone.txt, upload new.txt"; got != want {
+		t.Fatalf("two-way:\n got %s\nwant %s", got, want)
+	}
+	if got, want := plan(driveSyncPlanOptions{mode: driveSyncTwoWay, delete: true, prefer: "remote"}),
+		"download both.txt, download drive.txt, update edited.txt, delete-local gone.txt, upload new.txt"; got != want {
+		t.Fatalf("two-way --delete --prefer remote:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestPlanDriveSync_TwoWayDeleteKeepsEdits(t *testing.T) {
+	local := map[string]driveSyncFile{
+		"edited-local.txt": {Path: "edited-local.txt", MD5: driveSyncTestMD5("v2 local")},
+		"clean-local.txt":  {Path: "clean-local.txt", MD5: driveSyncTestMD5("v1")},
+	}
+	remote := map[string]driveSyncFile{
+		"edited-remote.txt": {Path: "edited-remote.txt", MD5: driveSyncTestMD5("v2 remote")},
+		"clean-remote.txt":  {Path: "clean-remote.txt", MD5: driveSyncTestMD5("v1")},
+	}
+	state := &driveSyncState{Files: map[string]driveSyncStateFile{
+		"edited-local.txt":  {MD5: driveSyncTestMD5("v1")},
+		"clean-local.txt":   {MD5: driveSyncTestMD5("v1")},
+		"edited-remote.txt": {MD5: driveSyncTestMD5("v1")},
+		"clean-remote.txt":  {MD5: driveSyncTestMD5("v1")},
+	}}
+	hash := func(f driveSyncFile) (string, error) { return f.MD5, nil }
+	plan := func(opts driveSyncPlanOptions) string {
+		t.Helper()
+		actions, err := planDriveSync(local, remote, state, opts, hash)
+		if err != nil {
+			t.Fatalf("plan: %v", err)
+		}
+		var parts []string
+		for _, a := range actions {
+			parts = append(parts, a.Action+" "+a.Path)
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	if got, want := plan(driveSyncPlanOptions{mode: driveSyncTwoWay, delete: true}),
+		"delete-local clean-local.txt, delete-remote clean-remote.txt, conflict edited-local.txt, conflict edited-remote.txt"; got != want {
+		t.Fatalf("two-way --delete:\n got %s\nwant %s", got, want)
+	}
+	if got, want := plan(driveSyncPlanOptions{mode: driveSyncTwoWay, delete: true, prefer: "local"}),
+		"delete-local clean-local.txt, delete-remote clean-remote.txt, upload edited-local.txt, delete-remote edited-remote.txt"; got != want {
+		t.Fatalf("two-way --delete --prefer local:\n got %s\nwant %s", got, want)
+	}
+	if got, want := plan(driveSyncPlanOptions{mode: driveSyncTwoWay, delete: true, prefer: "remote"}),
+		"delete-local clean-local.txt, delete-remote clean-remote.txt, delete-local edited-local.txt, download edited-remote.txt"; got != want {
+		t.Fatalf("two-way --delete --prefer remote:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestDriveSyncCmd_TwoWay(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var uploads, folders []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files":
+			q := r.URL.Query().Get("q")
+			var files []map[string]any
+			switch {
+			case strings.Contains(q, "'root1' in parents"):
+				files = []map[string]any{
+					{"id": "r1", "name": "remote.txt", "mimeType": "text/plain", "size": "10", "md5Checksum": driveSyncTestMD5("from drive"), "modifiedTime": "2024-05-01T10:00:00Z"},
+					{"id": "r2", "name": "same.txt", "mimeType": "text/plain", "size": "4", "md5Checksum": driveSyncTestMD5("same")},
+					{"id": "r3", "name": "Doc", "mimeType": "application/vnd.google-apps.document"},
+					{"id": "d1", "name": "sub", "mimeType": "application/vnd.google-apps.folder"},
+				}
+			case strings.Contains(q, "'d1' in parents"):
+				files = []map[string]any{}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": files})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/r1" && r.URL.Query().Get("alt") == "media":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = io.WriteString(w, "from drive")
+		case r.Method == http.MethodPost && r.URL.Path == "/files":
+			var f drive.File
+			_ = json.NewDecoder(r.Body).Decode(&f)
+			folders = append(folders, f.Name+" in "+strings.Join(f.Parents, ","))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "d2"})
+		case r.Method == http.MethodPost && r.URL.Path == "/upload/drive/v3/files":
+			body, _ := io.ReadAll(r.Body)
+			uploads = append(uploads, string(body))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "u1"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{"same.txt": "same", "local.txt": "mine", "sub2/new.txt": "new"} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveSyncCmd{}, []string{dir, "root1", "--two-way"}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("sync: %v", err)
+		}
+	})
+
+	var parsed struct {
+		Actions []driveSyncAction `json:"actions"`
+		Native  int               `json:"skippedNative"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(parsed.Actions) != 3 || parsed.Native != 1 {
+		t.Fatalf("unexpected result: %s", out)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, "remote.txt")); string(got) != "from drive" {
+		t.Fatalf("expected remote.txt downloaded, got %q", got)
+	}
+	if st, _ := os.Stat(filepath.Join(dir, "remote.txt")); st == nil || st.ModTime().UTC().Format("2006-01-02") != "2024-05-01" {
+		t.Fatalf("expected remote modification time on download, got %v", st)
+	}
+	if len(folders) != 1 || folders[0] != "sub2 in root1" {
+		t.Fatalf("expected sub2 folder created, got %v", folders)
+	}
+	if len(uploads) != 2 || !strings.Contains(uploads[0]+uploads[1], `"parents":["d2"]`) {
+		t.Fatalf("expected two uploads, one into sub2, got %v", uploads)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, driveSyncStateName))
+	if err != nil {
+		t.Fatalf("state: %v", err)
+	}
+	var state driveSyncState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("state json: %v", err)
+	}
+	if state.FolderID != "root1" || len(state.Files) != 4 || state.Files["same.txt"].RemoteID != "r2" || state.Files["local.txt"].RemoteID != "u1" {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+}
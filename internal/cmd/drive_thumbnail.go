@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// driveThumbnailFetch downloads a thumbnailLink. Like revision export links,
+// these live outside the Drive API and need an authenticated client.
+var driveThumbnailFetch = driveRevisionExportDownload
+
+var driveThumbnailSizeSuffix = regexp.MustCompile(`=s\d+$`)
+
+type DriveThumbnailCmd struct {
+	FileID string `arg:"" name:"fileId" help:"File ID or /path"`
+	Out    string `name:"out" short:"o" aliases:"output" help:"Output file (default: <fileId>_thumb.<ext> in the current directory)"`
+	Size   int64  `name:"size" help:"Longest side in pixels (0 = Drive's default, usually 220)" default:"0"`
+}
+
+func (c *DriveThumbnailCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Size < 0 {
+		return usage("--size must be >= 0")
+	}
+	ref := strings.TrimSpace(c.FileID)
+	if !isDrivePath(ref) {
+		ref = normalizeGoogleID(ref)
+	}
+	if ref == "" {
+		return usage("empty fileId")
+	}
+
+	account, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	fileID, err := resolveDriveID(ctx, svc, ref)
+	if err != nil {
+		return err
+	}
+
+	// thumbnailLink is short-lived, so a link that stopped working is
+	// replaced by re-reading the metadata once.
+	var resp *http.Response
+	for attempt := 0; attempt < 2; attempt++ {
+		meta, getErr := svc.Files.Get(fileID).
+			SupportsAllDrives(true).
+			Fields("id, name, hasThumbnail, thumbnailLink").
+			Context(ctx).
+			Do()
+		if getErr != nil {
+			return getErr
+		}
+		if meta.ThumbnailLink == "" {
+			return fmt.Errorf("%s has no thumbnail (Drive may not have generated one yet)", orEmpty(meta.Name, fileID))
+		}
+		resp, err = driveThumbnailFetch(ctx, account, driveThumbnailURL(meta.ThumbnailLink, c.Size))
+		if err != nil {
+			return err
+		}
+		if !driveThumbnailExpired(resp.StatusCode) || attempt == 1 {
+			break
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	dest := strings.TrimSpace(c.Out)
+	if dest == "" {
+		dest = fileID + "_thumb" + driveThumbnailExt(resp.Header.Get("Content-Type"))
+	} else if dest, err = config.ExpandPath(dest); err != nil {
+		resp.Body.Close()
+		return err
+	}
+	path, n, err := writeDriveDownloadResponse(resp, dest)
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"id":   fileID,
+			"path": path,
+			"size": n,
+		})
+	}
+	u.Out().Printf("path\t%s", path)
+	u.Out().Printf("size\t%s", humanSize(ctx, n))
+	return nil
+}
+
+// driveThumbnailURL asks for a specific size. Drive thumbnail links end in
+// "=s<px>" (googleusercontent) or carry an sz=s<px> query parameter.
+func driveThumbnailURL(link string, size int64) string {
+	if size <= 0 {
+		return link
+	}
+	px := "s" + strconv.FormatInt(size, 10)
+	if parsed, err := url.Parse(link); err == nil && parsed.Query().Has("sz") {
+		q := parsed.Query()
+		q.Set("sz", px)
+		parsed.RawQuery = q.Encode()
+		return parsed.String()
+	}
+	if driveThumbnailSizeSuffix.MatchString(link) {
+		return driveThumbnailSizeSuffix.ReplaceAllString(link, "="+px)
+	}
+	return link + "=" + px
+}
+
+func driveThumbnailExpired(status int) bool {
+	return status == http.StatusForbidden || status == http.StatusNotFound || status == http.StatusGone
+}
+
+func driveThumbnailExt(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	default:
+		return ".png"
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDriveThumbnailURL(t *testing.T) {
+	cases := map[string]string{
+		"https://lh3.googleusercontent.com/abc=s220":                "https://lh3.googleusercontent.com/abc=s640",
+		"https://lh3.googleusercontent.com/abc":                     "https://lh3.googleusercontent.com/abc=s640",
+		"https://docs.google.com/feeds/vt?gd=true&id=x&sz=s220&v=1": "https://docs.google.com/feeds/vt?gd=true&id=x&sz=s640&v=1",
+	}
+	for in, want := range cases {
+		if got := driveThumbnailURL(in, 640); got != want {
+			t.Fatalf("%s: got %s want %s", in, got, want)
+		}
+	}
+	if got := driveThumbnailURL("https://x/abc=s220", 0); got != "https://x/abc=s220" {
+		t.Fatalf("size 0 should keep the link, got %s", got)
+	}
+}
+
+func TestDriveThumbnailCmd_RefreshesExpiredLink(t *testing.T) {
+	origDrive, origFetch := newDriveService, driveThumbnailFetch
+	t.Cleanup(func() { newDriveService, driveThumbnailFetch = origDrive, origFetch })
+
+	gets := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.TrimPrefix(r.URL.Path, "/drive/v3") != "/files/file1" {
+			http.NotFound(w, r)
+			return
+		}
+		gets++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "file1", "name": "photo.jpg", "hasThumbnail": true,
+			"thumbnailLink": "https://lh3.example/thumb" + string(rune('0'+gets)) + "=s220"})
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	var fetched []string
+	driveThumbnailFetch = func(_ context.Context, _ string, link string) (*http.Response, error) {
+		fetched = append(fetched, link)
+		if len(fetched) == 1 {
+			return &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader("expired"))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": []string{"image/jpeg"}}, Body: io.NopCloser(strings.NewReader("JPEGDATA"))}, nil
+	}
+
+	dest := filepath.Join(t.TempDir(), "thumb.jpg")
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "drive", "thumbnail", "file1", "-o", dest, "--size", "640"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if gets != 2 || len(fetched) != 2 || fetched[1] != "https://lh3.example/thumb2=s640" {
+		t.Fatalf("gets=%d fetched=%v", gets, fetched)
+	}
+	if b, _ := os.ReadFile(dest); string(b) != "JPEGDATA" {
+		t.Fatalf("unexpected thumbnail %q", b)
+	}
+}
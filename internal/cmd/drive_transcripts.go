@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// DriveTranscriptsCmd pairs the Meet recordings and transcript docs in a
+// folder (usually "Meet Recordings"), optionally exports the transcripts,
+// and looks up the calendar event each meeting came from.
+type DriveTranscriptsCmd struct {
+	FolderID string `arg:"" name:"folderId" help:"Folder holding Meet recordings and transcripts"`
+	OutDir   string `name:"out-dir" aliases:"output-dir" help:"Export transcripts into this directory (default: list only)"`
+	Format   string `name:"format" help:"Transcript export format: txt|vtt" enum:"txt,vtt" default:"txt"`
+	Calendar string `name:"calendar" help:"Calendar to match meetings against" default:"primary"`
+	NoEvents bool   `name:"no-events" help:"Skip the calendar event lookup"`
+}
+
+const driveTranscriptFields = "id, name, mimeType, createdTime, webViewLink"
+
+// driveMeetNameRe splits Meet artifact names such as
+// "Weekly sync (2025-01-14 10:02 GMT+1) - Transcript" or
+// "Weekly sync – 2025/01/14 10:02 CET – Recording" into title, stamp, and kind.
+var driveMeetNameRe = regexp.MustCompile(`^(.*?)\s*(?:\(|[-–]\s+)(\d{4}[-/]\d{2}[-/]\d{2}[^)–]*?)\)?(?:\s*[-–]\s*(Transcript|Recording))?(?:\.\w+)?$`)
+
+var driveTranscriptStampRe = regexp.MustCompile(`^(\d{1,2}):(\d{2}):(\d{2})$`)
+
+type driveMeetFile struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	MimeType string `json:"mimeType"`
+	Created  string `json:"createdTime,omitempty"`
+	Link     string `json:"link,omitempty"`
+}
+
+type driveMeetArtifact struct {
+	Title      string         `json:"title"`
+	Started    string         `json:"started,omitempty"`
+	Recording  *driveMeetFile `json:"recording,omitempty"`
+	Transcript *driveMeetFile `json:"transcript,omitempty"`
+	EventID    string         `json:"eventId,omitempty"`
+	EventTitle string         `json:"eventSummary,omitempty"`
+	EventStart string         `json:"eventStart,omitempty"`
+	EventLink  string         `json:"eventLink,omitempty"`
+	Path       string         `json:"path,omitempty"`
+	key        string
+	created    time.Time
+}
+
+func (c *DriveTranscriptsCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	folderID := normalizeGoogleID(strings.TrimSpace(c.FolderID))
+	if folderID == "" {
+		return usage("empty folderId")
+	}
+
+	account, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	files, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+		call := svc.Files.List().
+			Q(fmt.Sprintf("'%s' in parents and trashed = false", folderID)).
+			Fields("nextPageToken", "files("+driveTranscriptFields+")").
+			OrderBy("createdTime desc").
+			PageSize(1000).
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Files, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return err
+	}
+	artifacts := groupDriveMeetArtifacts(files)
+
+	if !c.NoEvents && len(artifacts) > 0 {
+		calSvc, err := newCalendarService(ctx, account)
+		if err != nil {
+			return err
+		}
+		calID, err := resolveCalendarSelector(ctx, calSvc, c.Calendar, true)
+		if err != nil {
+			return err
+		}
+		if err := linkDriveMeetEvents(ctx, calSvc, calID, artifacts); err != nil {
+			return fmt.Errorf("look up calendar events (use --no-events to skip): %w", err)
+		}
+	}
+
+	if dir := strings.TrimSpace(c.OutDir); dir != "" {
+		for _, a := range artifacts {
+			if a.Transcript == nil {
+				continue
+			}
+			outPath := filepath.Join(dir, driveSafeName(a.key)+"."+c.Format)
+			if a.Path, err = exportDriveTranscript(ctx, svc, a.Transcript.ID, outPath, c.Format); err != nil {
+				return fmt.Errorf("export %s: %w", a.Transcript.Name, err)
+			}
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"meetings": artifacts})
+	}
+	if len(artifacts) == 0 {
+		u.Err().Println("No Meet recordings or transcripts found")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "STARTED\tTITLE\tRECORDING\tTRANSCRIPT\tEVENT\tPATH")
+	fileID := func(f *driveMeetFile) string {
+		if f == nil {
+			return "-"
+		}
+		return f.ID
+	}
+	for _, a := range artifacts {
+		event := a.EventID
+		if event == "" {
+			event = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", a.Started, a.Title, fileID(a.Recording), fileID(a.Transcript), event, a.Path)
+	}
+	return nil
+}
+
+// groupDriveMeetArtifacts pairs recordings (video files) with transcripts
+// (Google Docs named "... Transcript") that share a meeting title and
+// timestamp. Other files are ignored. Newest meetings come first.
+func groupDriveMeetArtifacts(files []*drive.File) []*driveMeetArtifact {
+	byKey := map[string]*driveMeetArtifact{}
+	var out []*driveMeetArtifact
+	for _, f := range files {
+		isVideo := strings.HasPrefix(f.MimeType, "video/")
+		isDoc := f.MimeType == driveMimeGoogleDoc
+		if !isVideo && !isDoc {
+			continue
+		}
+		m := driveMeetNameRe.FindStringSubmatch(strings.TrimSpace(f.Name))
+		if m == nil {
+			continue
+		}
+		title, stamp, kind := strings.TrimSpace(m[1]), strings.TrimSpace(m[2]), m[3]
+		if isDoc && kind != "Transcript" {
+			continue
+		}
+		key := title + " " + strings.NewReplacer("/", "-", ":", "").Replace(stamp)
+		a := byKey[key]
+		if a == nil {
+			a = &driveMeetArtifact{Title: title, Started: stamp, key: key}
+			byKey[key] = a
+			out = append(out, a)
+		}
+		mf := &driveMeetFile{ID: f.Id, Name: f.Name, MimeType: f.MimeType, Created: f.CreatedTime, Link: f.WebViewLink}
+		if isVideo {
+			a.Recording = mf
+		} else {
+			a.Transcript = mf
+		}
+		if t, err := time.Parse(time.RFC3339, f.CreatedTime); err == nil && (a.created.IsZero() || t.Before(a.created)) {
+			a.created = t
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].created.After(out[j].created) })
+	return out
+}
+
+// linkDriveMeetEvents matches each meeting to a calendar event. Meet attaches
+// its artifacts to the event, so an attachment fileId match wins; otherwise
+// an event with the same title running when the first artifact was written.
+func linkDriveMeetEvents(ctx context.Context, svc *calendar.Service, calendarID string, artifacts []*driveMeetArtifact) error {
+	var from, to time.Time
+	for _, a := range artifacts {
+		if a.created.IsZero() {
+			continue
+		}
+		if from.IsZero() || a.created.Before(from) {
+			from = a.created
+		}
+		if a.created.After(to) {
+			to = a.created
+		}
+	}
+	if from.IsZero() {
+		return nil
+	}
+	// Artifacts are written after the call ends; look back far enough to
+	// catch the start of long meetings.
+	from, to = from.Add(-24*time.Hour), to.Add(time.Hour)
+
+	events, err := collectAllPages("", func(pageToken string) ([]*calendar.Event, string, error) {
+		resp, err := calendarEventsListCall(ctx, svc, calendarID, from.Format(time.RFC3339), to.Format(time.RFC3339), 2500, "", "", "", "", pageToken).Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	byFile := map[string]*calendar.Event{}
+	for _, ev := range events {
+		for _, att := range ev.Attachments {
+			if att != nil && att.FileId != "" {
+				byFile[att.FileId] = ev
+			}
+		}
+	}
+	for _, a := range artifacts {
+		var match *calendar.Event
+		for _, f := range []*driveMeetFile{a.Transcript, a.Recording} {
+			if f != nil && match == nil {
+				match = byFile[f.ID]
+			}
+		}
+		if match == nil && !a.created.IsZero() {
+			for _, ev := range events {
+				start, end := eventStart(ev), eventEnd(ev)
+				if !strings.EqualFold(strings.TrimSpace(ev.Summary), a.Title) || start == "" {
+					continue
+				}
+				st, errS := time.Parse(time.RFC3339, start)
+				en, errE := time.Parse(time.RFC3339, end)
+				if errS != nil || errE != nil {
+					continue
+				}
+				if !a.created.Before(st) && !a.created.After(en.Add(2*time.Hour)) {
+					match = ev
+				}
+			}
+		}
+		if match != nil {
+			a.EventID, a.EventTitle, a.EventStart, a.EventLink = match.Id, match.Summary, eventStart(match), match.HtmlLink
+		}
+	}
+	return nil
+}
+
+// exportDriveTranscript exports a transcript doc as plain text, converting it
+// to WebVTT when format is "vtt".
+func exportDriveTranscript(ctx context.Context, svc *drive.Service, docID, outPath, format string) (string, error) {
+	resp, err := driveExportDownload(ctx, svc, docID, "text/plain")
+	if err != nil {
+		return "", err
+	}
+	body, err := driveDiffResponseBody(resp)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	text, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	content := strings.TrimPrefix(string(text), "\ufeff")
+	if format == "vtt" {
+		if content, err = driveTranscriptToVTT(content); err != nil {
+			return "", err
+		}
+	}
+	f, resolved, err := createUserOutputFile(outPath)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.WriteString(f, content); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	return resolved, f.Close()
+}
+
+// driveTranscriptToVTT converts a Meet transcript export into WebVTT. Meet
+// writes an "HH:MM:SS" line every few minutes followed by "Speaker: text"
+// lines; each block's span is shared evenly between its lines, and the last
+// block gets five seconds per line. Text before the first timestamp (title,
+// attendee list) is dropped.
+func driveTranscriptToVTT(text string) (string, error) {
+	type block struct {
+		start time.Duration
+		lines []string
+	}
+	var blocks []*block
+	sc := bufio.NewScanner(strings.NewReader(text))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if m := driveTranscriptStampRe.FindStringSubmatch(line); m != nil {
+			h, _ := strconv.Atoi(m[1])
+			mi, _ := strconv.Atoi(m[2])
+			s, _ := strconv.Atoi(m[3])
+			blocks = append(blocks, &block{start: time.Duration(h)*time.Hour + time.Duration(mi)*time.Minute + time.Duration(s)*time.Second})
+			continue
+		}
+		if line == "" || len(blocks) == 0 {
+			continue
+		}
+		cur := blocks[len(blocks)-1]
+		cur.lines = append(cur.lines, line)
+	}
+	if err := sc.Err(); err != nil {
+		return "", err
+	}
+	if len(blocks) == 0 {
+		return "", fmt.Errorf("transcript has no timestamps; use --format txt")
+	}
+
+	var b strings.Builder
+	b.WriteString("WEBVTT\n")
+	for i, blk := range blocks {
+		if len(blk.lines) == 0 {
+			continue
+		}
+		end := blk.start + time.Duration(len(blk.lines))*5*time.Second
+		if i+1 < len(blocks) && blocks[i+1].start > blk.start {
+			end = blocks[i+1].start
+		}
+		step := (end - blk.start) / time.Duration(len(blk.lines))
+		for j, line := range blk.lines {
+			from := blk.start + time.Duration(j)*step
+			fmt.Fprintf(&b, "\n%s --> %s\n", formatVTTTime(from), formatVTTTime(from+step))
+			if speaker, said, ok := strings.Cut(line, ": "); ok && speaker != "" && !strings.ContainsAny(speaker, "<>") {
+				fmt.Fprintf(&b, "<v %s>%s\n", speaker, said)
+			} else {
+				b.WriteString(line + "\n")
+			}
+		}
+	}
+	return b.String(), nil
+}
+
+func formatVTTTime(d time.Duration) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3_600_000, ms/60_000%60, ms/1000%60, ms%1000)
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestGroupDriveMeetArtifacts(t *testing.T) {
+	got := groupDriveMeetArtifacts([]*drive.File{
+		{Id: "v1", Name: "Weekly sync (2025-01-14 10:02 GMT+1)", MimeType: "video/mp4", CreatedTime: "2025-01-14T10:40:00Z"},
+		{Id: "d1", Name: "Weekly sync (2025-01-14 10:02 GMT+1) - Transcript", MimeType: driveMimeGoogleDoc, CreatedTime: "2025-01-14T10:39:00Z"},
+		{Id: "d2", Name: "Q1 - planning – 2025/02/03 09:00 CET – Transcript", MimeType: driveMimeGoogleDoc, CreatedTime: "2025-02-03T09:50:00Z"},
+		{Id: "n1", Name: "Q1 - planning – 2025/02/03 09:00 CET – Notes by Gemini", MimeType: driveMimeGoogleDoc},
+		{Id: "x1", Name: "notes.txt", MimeType: "text/plain"},
+	})
+	if len(got) != 2 {
+		t.Fatalf("expected 2 meetings, got %d: %+v", len(got), got)
+	}
+	if got[0].Title != "Q1 - planning" || got[0].Started != "2025/02/03 09:00 CET" || got[0].Transcript.ID != "d2" || got[0].Recording != nil {
+		t.Fatalf("unexpected newest meeting: %+v", got[0])
+	}
+	if got[1].Title != "Weekly sync" || got[1].Recording.ID != "v1" || got[1].Transcript.ID != "d1" {
+		t.Fatalf("unexpected paired meeting: %+v", got[1])
+	}
+}
+
+func TestDriveTranscriptToVTT(t *testing.T) {
+	got, err := driveTranscriptToVTT("Weekly sync - Transcript\nAttendees\nAda, Bob\n\n00:00:00\n\nAda: Hello.\nBob: Hi there.\n00:00:10\n\nAda: Done.\n")
+	if err != nil {
+		t.Fatalf("vtt: %v", err)
+	}
+	want := "WEBVTT\n\n00:00:00.000 --> 00:00:05.000\n<v Ada>Hello.\n\n00:00:05.000 --> 00:00:10.000\n<v Bob>Hi there.\n\n00:00:10.000 --> 00:00:15.000\n<v Ada>Done.\n"
+	if got != want {
+		t.Fatalf("unexpected vtt:\n%s", got)
+	}
+	if _, err := driveTranscriptToVTT("no timestamps here"); err == nil {
+		t.Fatal("expected error without timestamps")
+	}
+}
+
+func TestDriveTranscriptsCmd(t *testing.T) {
+	origDrive, origCal, origExport := newDriveService, newCalendarService, driveExportDownload
+	t.Cleanup(func() { newDriveService, newCalendarService, driveExportDownload = origDrive, origCal, origExport })
+
+	driveSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.TrimPrefix(r.URL.Path, "/drive/v3") != "/files" {
+			http.NotFound(w, r)
+			return
+		}
+		if q := r.URL.Query().Get("q"); !strings.Contains(q, "'rec' in parents") {
+			t.Errorf("unexpected q %q", q)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+			{"id": "v1", "name": "Weekly sync (2025-01-14 10:02 GMT+1)", "mimeType": "video/mp4", "createdTime": "2025-01-14T10:40:00Z"},
+			{"id": "d1", "name": "Weekly sync (2025-01-14 10:02 GMT+1) - Transcript", "mimeType": driveMimeGoogleDoc, "createdTime": "2025-01-14T10:39:00Z"},
+			{"id": "d2", "name": "Retro (2025-01-13 15:00 GMT+1) - Transcript", "mimeType": driveMimeGoogleDoc, "createdTime": "2025-01-13T14:45:00Z"},
+		}})
+	}))
+	defer driveSrv.Close()
+	driveSvc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(driveSrv.Client()),
+		option.WithEndpoint(driveSrv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return driveSvc, nil }
+
+	calSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/calendars/primary/events") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{
+			// Linked through the attachment Meet added to the event.
+			{"id": "e1", "summary": "Team weekly", "start": map[string]any{"dateTime": "2025-01-14T09:00:00Z"}, "end": map[string]any{"dateTime": "2025-01-14T09:30:00Z"},
+				"attachments": []map[string]any{{"fileId": "d1", "fileUrl": "https://docs.google.com/document/d/d1"}}},
+			// Linked by title and time.
+			{"id": "e2", "summary": "Retro", "start": map[string]any{"dateTime": "2025-01-13T14:00:00Z"}, "end": map[string]any{"dateTime": "2025-01-13T14:30:00Z"}},
+		}})
+	}))
+	defer calSrv.Close()
+	calSvc, err := calendar.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(calSrv.Client()),
+		option.WithEndpoint(calSrv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return calSvc, nil }
+
+	var exported []string
+	driveExportDownload = func(_ context.Context, _ *drive.Service, id, mimeType string) (*http.Response, error) {
+		exported = append(exported, id+" "+mimeType)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("\ufeffTranscript\n00:00:00\nAda: Hi.\n")),
+		}, nil
+	}
+
+	dir := t.TempDir()
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "drive", "transcripts", "rec", "--out-dir", dir, "--format", "vtt"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	var parsed struct {
+		Meetings []driveMeetArtifact `json:"meetings"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(parsed.Meetings) != 2 || parsed.Meetings[0].EventID != "e1" || parsed.Meetings[1].EventID != "e2" {
+		t.Fatalf("unexpected meetings: %s", out)
+	}
+	if len(exported) != 2 || exported[0] != "d1 text/plain" {
+		t.Fatalf("unexpected exports: %v", exported)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "Weekly sync 2025-01-14 1002 GMT+1.vtt"))
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	if string(data) != "WEBVTT\n\n00:00:00.000 --> 00:00:05.000\n<v Ada>Hi.\n" {
+		t.Fatalf("unexpected export:\n%s", data)
+	}
+}
@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+const (
+	driveTransferAuto    = "auto"
+	driveTransferDirect  = "direct"
+	driveTransferPending = "pending"
+
+	driveTransferFields = "id, name, mimeType, ownedByMe, driveId"
+)
+
+// DriveTransferOwnershipCmd hands files to another user. Within one
+// Workspace domain Drive transfers ownership directly; elsewhere (consumer
+// accounts, other domains) the new owner has to accept, so the user is made
+// a writer with pendingOwner set.
+type DriveTransferOwnershipCmd struct {
+	FileID    string `arg:"" name:"fileId" help:"File or folder ID"`
+	To        string `name:"to" required:"" help:"Email of the new owner"`
+	Mode      string `name:"mode" help:"Transfer flow: auto (direct within your Workspace domain, pending otherwise), direct, or pending" enum:"auto,direct,pending" default:"auto"`
+	Recursive bool   `name:"recursive" help:"For folders, also transfer every file and subfolder you own inside it"`
+	Message   string `name:"message" help:"Message for the notification email"`
+}
+
+type driveTransferResult struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Mode         string `json:"mode,omitempty"`
+	PermissionID string `json:"permissionId,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+func (c *DriveTransferOwnershipCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	to := strings.ToLower(strings.TrimSpace(c.To))
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+	if !strings.Contains(to, "@") {
+		return usagef("invalid --to %q (expected an email address)", c.To)
+	}
+
+	account, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	mode := c.Mode
+	if mode == driveTransferAuto {
+		mode = driveTransferModeFor(account, to)
+	}
+
+	root, err := svc.Files.Get(fileID).
+		SupportsAllDrives(true).
+		Fields(driveTransferFields).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	if root.DriveId != "" {
+		return usagef("%s is in a shared drive; shared drive items are owned by the drive, not a user", root.Name)
+	}
+	if !root.OwnedByMe {
+		return usagef("you do not own %s", root.Name)
+	}
+	files := []*drive.File{root}
+	if c.Recursive && root.MimeType == driveMimeFolder {
+		owned, err := listOwnedDriveDescendants(ctx, svc, root.Id)
+		if err != nil {
+			return err
+		}
+		files = append(files, owned...)
+	}
+
+	request := map[string]any{
+		"file_id":   fileID,
+		"to":        to,
+		"mode":      mode,
+		"recursive": c.Recursive,
+		"files":     len(files),
+	}
+	action := fmt.Sprintf("transfer ownership of %s (%d item%s) to %s", root.Name, len(files), pluralS(len(files)), to)
+	if mode == driveTransferPending {
+		action = fmt.Sprintf("offer ownership of %s (%d item%s) to %s", root.Name, len(files), pluralS(len(files)), to)
+	}
+	if err := dryRunAndConfirmDestructive(ctx, flags, "drive.transfer_ownership", request, action); err != nil {
+		return err
+	}
+
+	results := make([]driveTransferResult, 0, len(files))
+	failed := 0
+	for _, f := range files {
+		res := driveTransferResult{ID: f.Id, Name: f.Name, Mode: mode}
+		perm, err := transferDriveOwnership(ctx, svc, f.Id, to, mode, strings.TrimSpace(c.Message))
+		if err != nil {
+			res.Error = err.Error()
+			failed++
+		} else {
+			res.PermissionID = perm.Id
+		}
+		results = append(results, res)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"to":    to,
+			"mode":  mode,
+			"files": results,
+		}); err != nil {
+			return err
+		}
+	} else {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "ID\tNAME\tRESULT")
+		for _, r := range results {
+			result := "transferred"
+			switch {
+			case r.Error != "":
+				result = "failed: " + r.Error
+			case r.Mode == driveTransferPending:
+				result = "pending acceptance"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", r.ID, r.Name, result)
+		}
+		flush()
+		if failed == 0 && mode == driveTransferPending {
+			u.Err().Printf("%s must accept ownership in Drive (Shared with me) to complete the transfer", to)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed to transfer", failed, len(files))
+	}
+	return nil
+}
+
+// driveTransferModeFor picks the direct flow only when both accounts are in
+// the same Workspace domain; Drive rejects direct transfers everywhere else.
+func driveTransferModeFor(account, to string) string {
+	if isConsumerAccount(account) || isConsumerAccount(to) {
+		return driveTransferPending
+	}
+	if !strings.EqualFold(emailDomain(account), emailDomain(to)) {
+		return driveTransferPending
+	}
+	return driveTransferDirect
+}
+
+func emailDomain(email string) string {
+	_, domain, _ := strings.Cut(strings.TrimSpace(email), "@")
+	return domain
+}
+
+func transferDriveOwnership(ctx context.Context, svc *drive.Service, fileID, to, mode, message string) (*drive.Permission, error) {
+	if mode == driveTransferDirect {
+		call := svc.Permissions.Create(fileID, &drive.Permission{Type: driveShareToUser, Role: drivePermRoleOwner, EmailAddress: to}).
+			TransferOwnership(true).
+			SendNotificationEmail(true).
+			Fields(drivePermissionFields).
+			Context(ctx)
+		if message != "" {
+			call = call.EmailMessage(message)
+		}
+		return call.Do()
+	}
+
+	// A user who already has access keeps their permission; it is promoted
+	// to writer and marked as pending owner.
+	existing, err := findDriveUserPermission(ctx, svc, fileID, to)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return svc.Permissions.Update(fileID, existing.Id, &drive.Permission{Role: drivePermRoleWriter, PendingOwner: true}).
+			Fields(drivePermissionFields).
+			Context(ctx).
+			Do()
+	}
+	call := svc.Permissions.Create(fileID, &drive.Permission{Type: driveShareToUser, Role: drivePermRoleWriter, EmailAddress: to, PendingOwner: true}).
+		SendNotificationEmail(true).
+		Fields(drivePermissionFields).
+		Context(ctx)
+	if message != "" {
+		call = call.EmailMessage(message)
+	}
+	return call.Do()
+}
+
+func findDriveUserPermission(ctx context.Context, svc *drive.Service, fileID, email string) (*drive.Permission, error) {
+	perms, err := collectAllPages("", func(pageToken string) ([]*drive.Permission, string, error) {
+		call := svc.Permissions.List(fileID).
+			Fields("nextPageToken", "permissions("+drivePermissionFields+")").
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Permissions, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range perms {
+		if p.Type == driveShareToUser && strings.EqualFold(p.EmailAddress, email) {
+			return p, nil
+		}
+	}
+	return nil, nil //nolint:nilnil // nil permission means the user has no access yet
+}
+
+// listOwnedDriveDescendants walks folderID breadth-first and returns the
+// files and folders under it that the caller owns. Folders owned by others
+// are still descended into.
+func listOwnedDriveDescendants(ctx context.Context, svc *drive.Service, folderID string) ([]*drive.File, error) {
+	var owned []*drive.File
+	queue := []string{folderID}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		children, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+			call := svc.Files.List().
+				Q(fmt.Sprintf("'%s' in parents and trashed = false", cur)).
+				Fields("nextPageToken", "files("+driveTransferFields+")").
+				PageSize(1000).
+				Context(ctx)
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+			resp, err := call.Do()
+			if err != nil {
+				return nil, "", err
+			}
+			return resp.Files, resp.NextPageToken, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range children {
+			if f.MimeType == driveMimeFolder {
+				queue = append(queue, f.Id)
+			}
+			if f.OwnedByMe {
+				owned = append(owned, f)
+			}
+		}
+	}
+	return owned, nil
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDriveTransferModeFor(t *testing.T) {
+	cases := []struct {
+		account, to, want string
+	}{
+		{"a@corp.com", "b@corp.com", driveTransferDirect},
+		{"a@corp.com", "b@CORP.com", driveTransferDirect},
+		{"a@corp.com", "b@other.com", driveTransferPending},
+		{"a@gmail.com", "b@gmail.com", driveTransferPending},
+		{"a@corp.com", "b@gmail.com", driveTransferPending},
+	}
+	for _, tc := range cases {
+		if got := driveTransferModeFor(tc.account, tc.to); got != tc.want {
+			t.Fatalf("%s -> %s: got %q want %q", tc.account, tc.to, got, tc.want)
+		}
+	}
+}
+
+func newDriveTransferTestServer(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	orig := newDriveService
+	t.Cleanup(func() { newDriveService = orig })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+}
+
+func TestDriveTransferOwnership_RecursiveDirect(t *testing.T) {
+	var mu sync.Mutex
+	created := map[string]drive.Permission{}
+	newDriveTransferTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && path == "/files/root1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "root1", "name": "Project", "mimeType": driveMimeFolder, "ownedByMe": true})
+		case r.Method == http.MethodGet && path == "/files":
+			var files []map[string]any
+			switch q := r.URL.Query().Get("q"); {
+			case strings.Contains(q, "'root1'"):
+				files = []map[string]any{
+					{"id": "sub1", "name": "Sub", "mimeType": driveMimeFolder, "ownedByMe": false},
+					{"id": "doc1", "name": "Doc", "mimeType": "text/plain", "ownedByMe": true},
+				}
+			case strings.Contains(q, "'sub1'"):
+				files = []map[string]any{{"id": "doc2", "name": "Nested", "mimeType": "text/plain", "ownedByMe": true}}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": files})
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/permissions"):
+			if r.URL.Query().Get("transferOwnership") != "true" {
+				t.Errorf("expected transferOwnership=true on %s", path)
+			}
+			var p drive.Permission
+			_ = json.NewDecoder(r.Body).Decode(&p)
+			mu.Lock()
+			created[strings.TrimSuffix(strings.TrimPrefix(path, "/files/"), "/permissions")] = p
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "perm1", "role": "owner"})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--force", "--account", "me@corp.com", "drive", "transfer-ownership", "root1", "--to", "New@corp.com", "--recursive"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if len(created) != 3 {
+		t.Fatalf("expected 3 transfers (sub folder owned by someone else is skipped), got %v", created)
+	}
+	for _, id := range []string{"root1", "doc1", "doc2"} {
+		if p := created[id]; p.Role != drivePermRoleOwner || p.EmailAddress != "new@corp.com" {
+			t.Fatalf("unexpected permission for %s: %+v", id, p)
+		}
+	}
+	var parsed struct {
+		Mode  string                `json:"mode"`
+		Files []driveTransferResult `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil || parsed.Mode != driveTransferDirect || len(parsed.Files) != 3 {
+		t.Fatalf("unexpected output %q: %v", out, err)
+	}
+}
+
+func TestDriveTransferOwnership_PendingPromotesExistingPermission(t *testing.T) {
+	var updated drive.Permission
+	var updatedPath string
+	newDriveTransferTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && path == "/files/doc1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "doc1", "name": "Doc", "mimeType": "text/plain", "ownedByMe": true})
+		case r.Method == http.MethodGet && path == "/files/doc1/permissions":
+			_ = json.NewEncoder(w).Encode(map[string]any{"permissions": []map[string]any{
+				{"id": "p0", "type": "user", "role": "owner", "emailAddress": "me@gmail.com"},
+				{"id": "p1", "type": "user", "role": "reader", "emailAddress": "friend@gmail.com"},
+			}})
+		case r.Method == http.MethodPatch:
+			updatedPath = path
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "p1", "role": "writer", "pendingOwner": true})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--force", "--account", "me@gmail.com", "drive", "transfer-ownership", "doc1", "--to", "friend@gmail.com"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if updatedPath != "/files/doc1/permissions/p1" || updated.Role != drivePermRoleWriter || !updated.PendingOwner {
+		t.Fatalf("unexpected update %s: %+v", updatedPath, updated)
+	}
+}
+
+func TestDriveTransferOwnership_RejectsNotOwned(t *testing.T) {
+	newDriveTransferTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "doc1", "name": "Doc", "ownedByMe": false})
+	})
+	err := Execute([]string{"--force", "--account", "me@corp.com", "drive", "transfer-ownership", "doc1", "--to", "b@corp.com"})
+	if ExitCode(stableExitCode(err)) != 2 || !strings.Contains(err.Error(), "do not own") {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
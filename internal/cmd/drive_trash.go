@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type DriveTrashCmd struct {
+	List    DriveTrashListCmd    `cmd:"" name:"list" aliases:"ls" default:"withargs" help:"List trashed files"`
+	Restore DriveTrashRestoreCmd `cmd:"" name:"restore" aliases:"untrash" help:"Restore trashed files"`
+	Empty   DriveTrashEmptyCmd   `cmd:"" name:"empty" help:"Permanently delete trashed files (all, or only those trashed before --older-than)"`
+}
+
+const driveTrashListFields = "nextPageToken, files(id, name, mimeType, size, trashedTime, trashingUser(emailAddress), ownedByMe)"
+
+type DriveTrashListCmd struct {
+	Max       int64  `name:"max" aliases:"limit" help:"Max results" default:"20"`
+	Page      string `name:"page" aliases:"cursor" help:"Page token"`
+	All       bool   `name:"all" aliases:"all-pages,allpages" help:"Fetch all pages"`
+	OlderThan string `name:"older-than" help:"Only files trashed before this (30d, 12w, 6m, 1y, or a date; fetches all pages)"`
+	FailEmpty bool   `name:"fail-empty" aliases:"non-empty,require-results" help:"Exit with code 3 if no results"`
+}
+
+func (c *DriveTrashListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Max <= 0 {
+		return usage("--max must be > 0")
+	}
+	var cutoff time.Time
+	if expr := strings.TrimSpace(c.OlderThan); expr != "" {
+		t, err := parseDriveAgeCutoff(expr, time.Now(), time.Local)
+		if err != nil {
+			return err
+		}
+		cutoff = t
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	fetch := func(pageToken string) ([]*drive.File, string, error) {
+		return fetchDriveTrashPage(ctx, svc, "trashed = true", c.Max, pageToken)
+	}
+	// Drive cannot filter on trashedTime, so an age filter needs every page.
+	files, nextPageToken, err := loadPagedItems(c.Page, c.All || !cutoff.IsZero(), fetch)
+	if err != nil {
+		return err
+	}
+	files = filterDriveTrashedBefore(files, cutoff)
+
+	if outfmt.IsJSON(ctx) {
+		return writePagedJSONResult(ctx, map[string]any{
+			"files":         files,
+			"nextPageToken": nextPageToken,
+		}, len(files), c.FailEmpty)
+	}
+	if len(files) == 0 {
+		u.Err().Println("Trash is empty")
+		return failEmptyExit(c.FailEmpty)
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tNAME\tSIZE\tTRASHED\tBY")
+	for _, f := range files {
+		by := "-"
+		if f.TrashingUser != nil && f.TrashingUser.EmailAddress != "" {
+			by = f.TrashingUser.EmailAddress
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", f.Id, f.Name, humanSize(ctx, f.Size), humanDateTime(ctx, f.TrashedTime), by)
+	}
+	printNextPageHint(u, nextPageToken)
+	return nil
+}
+
+type DriveTrashRestoreCmd struct {
+	FileIDs []string `arg:"" name:"fileId" help:"File IDs to restore"`
+}
+
+func (c *DriveTrashRestoreCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	ids := make([]string, 0, len(c.FileIDs))
+	for _, raw := range c.FileIDs {
+		if id := normalizeGoogleID(strings.TrimSpace(raw)); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return usage("empty fileId")
+	}
+	if err := dryRunExit(ctx, flags, "drive.trash.restore", map[string]any{
+		"file_ids": ids,
+	}); err != nil {
+		return err
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	restored := make([]*drive.File, 0, len(ids))
+	for _, id := range ids {
+		// Trashed=false is the zero value, so it must be sent explicitly.
+		f, err := svc.Files.Update(id, &drive.File{Trashed: false, ForceSendFields: []string{"Trashed"}}).
+			SupportsAllDrives(true).
+			Fields("id, name, trashed, webViewLink").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return fmt.Errorf("restore %s: %w", id, err)
+		}
+		restored = append(restored, f)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"restored": restored})
+	}
+	for _, f := range restored {
+		u.Out().Printf("restored\t%s\t%s", f.Id, f.Name)
+	}
+	return nil
+}
+
+type DriveTrashEmptyCmd struct {
+	OlderThan string `name:"older-than" help:"Only delete files trashed before this (30d, 12w, 6m, 1y, or a date); default: empty the whole trash"`
+}
+
+func (c *DriveTrashEmptyCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	expr := strings.TrimSpace(c.OlderThan)
+	if expr == "" {
+		if err := dryRunAndConfirmDestructive(ctx, flags, "drive.trash.empty", map[string]any{}, "permanently delete everything in your Drive trash"); err != nil {
+			return err
+		}
+		_, svc, err := requireDriveService(ctx, flags)
+		if err != nil {
+			return err
+		}
+		if err := svc.Files.EmptyTrash().Context(ctx).Do(); err != nil {
+			return err
+		}
+		return writeResult(ctx, u, kv("emptied", true))
+	}
+
+	cutoff, err := parseDriveAgeCutoff(expr, time.Now(), time.Local)
+	if err != nil {
+		return err
+	}
+	account, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	// Only the owner can permanently delete a file, so others' items in the
+	// trash are left alone.
+	files, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+		return fetchDriveTrashPage(ctx, svc, "trashed = true and 'me' in owners", 1000, pageToken)
+	})
+	if err != nil {
+		return err
+	}
+	files = filterDriveTrashedBefore(files, cutoff)
+	if len(files) == 0 {
+		return writeResult(ctx, u,
+			kv("deleted", 0),
+			kv("olderThan", expr),
+		)
+	}
+
+	// Permanent deletes cannot be undone, so the journal only records them.
+	guard := &bulkDestructiveOp{Op: "drive.trash.empty", Action: "permanently delete", Noun: "trashed file", Confirm: true}
+	ids := make([]string, 0, len(files))
+	for _, f := range files {
+		ids = append(ids, f.Id)
+		guard.Items = append(guard.Items, bulkItem{ID: f.Id, Label: f.Name})
+	}
+	if err := confirmBulkDestructive(ctx, flags, guard, map[string]any{
+		"older_than": expr,
+		"file_ids":   ids,
+	}); err != nil {
+		return err
+	}
+	deleted := 0
+	pacer := newBulkPacer()
+	for _, id := range ids {
+		err := pacer.wait(ctx)
+		if err == nil {
+			err = svc.Files.Delete(id).SupportsAllDrives(true).Context(ctx).Do()
+		}
+		if err != nil {
+			journalBulkDestructive(ctx, account, guard, guard.Items[:deleted])
+			return fmt.Errorf("delete %s (%d of %d deleted): %w", id, deleted, len(ids), err)
+		}
+		deleted++
+	}
+	journalBulkDestructive(ctx, account, guard, guard.Items)
+	return writeResult(ctx, u,
+		kv("deleted", len(ids)),
+		kv("olderThan", expr),
+	)
+}
+
+func fetchDriveTrashPage(ctx context.Context, svc *drive.Service, q string, pageSize int64, pageToken string) ([]*drive.File, string, error) {
+	call := svc.Files.List().
+		Q(q).
+		PageSize(min(pageSize, 1000)).
+		Fields(driveTrashListFields).
+		Context(ctx)
+	if pageToken != "" {
+		call = call.PageToken(pageToken)
+	}
+	resp, err := call.Do()
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Files, resp.NextPageToken, nil
+}
+
+// filterDriveTrashedBefore keeps files trashed before cutoff; a zero cutoff
+// keeps everything.
+func filterDriveTrashedBefore(files []*drive.File, cutoff time.Time) []*drive.File {
+	if cutoff.IsZero() {
+		return files
+	}
+	out := files[:0]
+	for _, f := range files {
+		if f == nil {
+			continue
+		}
+		trashed, err := time.Parse(time.RFC3339, f.TrashedTime)
+		if err == nil && trashed.Before(cutoff) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDriveTrashCmds(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	old := time.Now().AddDate(0, -2, 0).UTC().Format(time.RFC3339)
+	recent := time.Now().AddDate(0, 0, -2).UTC().Format(time.RFC3339)
+	var queries, updates, deletes []string
+	emptied := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files":
+			queries = append(queries, r.URL.Query().Get("q"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "old", "name": "Old.pdf", "trashedTime": old},
+				{"id": "new", "name": "New.pdf", "trashedTime": recent},
+			}})
+		case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/files/"):
+			body, _ := io.ReadAll(r.Body)
+			updates = append(updates, string(body))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": strings.TrimPrefix(r.URL.Path, "/files/"), "name": "Old.pdf"})
+		case r.Method == http.MethodDelete && r.URL.Path == "/files/trash":
+			emptied = true
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/files/"):
+			deletes = append(deletes, strings.TrimPrefix(r.URL.Path, "/files/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	flags := &RootFlags{Account: "a@b.com", Force: true}
+	ctx := newDocsJSONContext(t)
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveTrashCmd{}, []string{"--older-than", "30d"}, ctx, flags); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	var listed struct {
+		Files []drive.File `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(out), &listed); err != nil || len(listed.Files) != 1 || listed.Files[0].Id != "old" {
+		t.Fatalf("unexpected list output: %v %s", err, out)
+	}
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveTrashCmd{}, []string{"restore", "old"}, ctx, flags); err != nil {
+			t.Fatalf("restore: %v", err)
+		}
+	})
+	if len(updates) != 1 || !strings.Contains(updates[0], `"trashed":false`) {
+		t.Fatalf("expected explicit trashed=false, got %v", updates)
+	}
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveTrashCmd{}, []string{"empty", "--older-than", "30d"}, ctx, flags); err != nil {
+			t.Fatalf("empty --older-than: %v", err)
+		}
+	})
+	if !strings.Contains(queries[len(queries)-1], "'me' in owners") || len(deletes) != 1 || deletes[0] != "old" || emptied {
+		t.Fatalf("expected only the old owned file deleted: queries=%v deletes=%v emptied=%v", queries, deletes, emptied)
+	}
+	entries, err := readBulkJournal()
+	if err != nil || len(entries) != 1 || entries[0].Op != "drive.trash.empty" || entries[0].Count != 1 || entries[0].Undo != "" {
+		t.Fatalf("expected one journaled permanent delete, got %#v (%v)", entries, err)
+	}
+	if err := runKong(t, &DriveTrashCmd{}, []string{"empty", "--older-than", "30d"}, ctx, &RootFlags{Account: "a@b.com", NoInput: true}); err == nil || !strings.Contains(err.Error(), "refusing to permanently delete 1 trashed file without --force") {
+		t.Fatalf("expected bulk guard refusal, got %v", err)
+	}
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveTrashCmd{}, []string{"empty"}, ctx, flags); err != nil {
+			t.Fatalf("empty: %v", err)
+		}
+	})
+	if !emptied {
+		t.Fatal("expected emptyTrash call")
+	}
+
+	if err := runKong(t, &DriveTrashCmd{}, []string{"empty"}, ctx, &RootFlags{Account: "a@b.com", NoInput: true}); err == nil || !strings.Contains(err.Error(), "without --force") {
+		t.Fatalf("expected confirmation refusal, got %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+const (
+	driveTreeFields = "nextPageToken, files(id, name, mimeType, size, modifiedTime, parents)"
+	// driveTreeParentsPerQuery caps the "'a' in parents or ..." clauses per
+	// list call, keeping the query well under Drive's length limit.
+	driveTreeParentsPerQuery = 40
+)
+
+// driveTreeNode is a file or folder with its children, as written by
+// `drive ls --tree --json`.
+type driveTreeNode struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	MimeType     string           `json:"mimeType"`
+	Size         int64            `json:"size,omitempty"`
+	ModifiedTime string           `json:"modifiedTime,omitempty"`
+	ParentID     string           `json:"parentId,omitempty"`
+	Depth        int              `json:"depth"`
+	Children     []*driveTreeNode `json:"children,omitempty"`
+	// Truncated marks a folder whose children were not listed because of --depth.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+func (c *DriveLsCmd) runTree(ctx context.Context, flags *RootFlags, folderID string) error {
+	switch {
+	case c.All:
+		return usage("--tree cannot be combined with --all")
+	case strings.TrimSpace(c.Page) != "":
+		return usage("--tree cannot be combined with --page")
+	case strings.TrimSpace(c.Query) != "":
+		return usage("--tree cannot be combined with --query")
+	case c.Depth < 0:
+		return usage("--depth must be 0 (unlimited) or positive")
+	}
+	if folderID == "" {
+		folderID = "root"
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	root, err := svc.Files.Get(folderID).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType, modifiedTime").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	if root.MimeType != driveMimeFolder {
+		return usagef("%s is not a folder", folderID)
+	}
+
+	tree := &driveTreeNode{ID: root.Id, Name: root.Name, MimeType: root.MimeType, ModifiedTime: root.ModifiedTime}
+	folders, files, err := walkDriveTree(ctx, svc, tree, c.Depth, c.AllDrives)
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"tree":    tree,
+			"folders": folders,
+			"files":   files,
+		})
+	}
+	if outfmt.IsPlain(ctx) {
+		w, flush := tableWriter(ctx)
+		defer flush()
+		fmt.Fprintln(w, "PATH\tID\tTYPE\tSIZE\tMODIFIED")
+		walkDriveTreeNodes(tree, "", func(n *driveTreeNode, p string) {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p, n.ID, driveType(n.MimeType), formatDriveSize(n.Size), formatDateTime(n.ModifiedTime))
+		})
+		return nil
+	}
+
+	u := ui.FromContext(ctx)
+	u.Out().Printf("%s/  (%s)", tree.Name, tree.ID)
+	printDriveTree(ctx, u, tree.Children, "")
+	u.Err().Printf("%d folder%s, %d file%s", folders, pluralS(folders), files, pluralS(files))
+	return nil
+}
+
+// walkDriveTree fills in root's descendants breadth-first, listing the
+// children of a whole level of folders per batch of queries. maxDepth 0
+// means unlimited.
+func walkDriveTree(ctx context.Context, svc *drive.Service, root *driveTreeNode, maxDepth int, allDrives bool) (int, int, error) {
+	folders, files := 0, 0
+	level := []*driveTreeNode{root}
+	for depth := 1; len(level) > 0; depth++ {
+		if maxDepth > 0 && depth > maxDepth {
+			for _, n := range level {
+				n.Truncated = true
+			}
+			break
+		}
+		byID := make(map[string]*driveTreeNode, len(level))
+		ids := make([]string, 0, len(level))
+		for _, n := range level {
+			byID[n.ID] = n
+			ids = append(ids, n.ID)
+		}
+
+		var next []*driveTreeNode
+		for start := 0; start < len(ids); start += driveTreeParentsPerQuery {
+			batch := ids[start:min(start+driveTreeParentsPerQuery, len(ids))]
+			children, err := listDriveTreeChildren(ctx, svc, batch, allDrives)
+			if err != nil {
+				return 0, 0, err
+			}
+			for _, f := range children {
+				for _, parentID := range f.Parents {
+					parent, ok := byID[parentID]
+					if !ok {
+						continue
+					}
+					node := &driveTreeNode{
+						ID:           f.Id,
+						Name:         f.Name,
+						MimeType:     f.MimeType,
+						Size:         f.Size,
+						ModifiedTime: f.ModifiedTime,
+						ParentID:     parentID,
+						Depth:        depth,
+					}
+					parent.Children = append(parent.Children, node)
+					if f.MimeType == driveMimeFolder {
+						folders++
+						next = append(next, node)
+					} else {
+						files++
+					}
+				}
+			}
+		}
+		for _, n := range level {
+			sortDriveTreeChildren(n.Children)
+		}
+		level = next
+	}
+	return folders, files, nil
+}
+
+func listDriveTreeChildren(ctx context.Context, svc *drive.Service, parentIDs []string, allDrives bool) ([]*drive.File, error) {
+	clauses := make([]string, 0, len(parentIDs))
+	for _, id := range parentIDs {
+		clauses = append(clauses, fmt.Sprintf("'%s' in parents", id))
+	}
+	query := fmt.Sprintf("(%s) and trashed = false", strings.Join(clauses, " or "))
+	return collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+		call := svc.Files.List().
+			Q(query).
+			PageSize(driveMaxListPageSize).
+			PageToken(pageToken)
+		call = driveFilesListCallWithDriveSupport(call, allDrives)
+		resp, err := call.Fields(driveTreeFields).Context(ctx).Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Files, resp.NextPageToken, nil
+	})
+}
+
+// sortDriveTreeChildren puts folders first, then orders by name.
+func sortDriveTreeChildren(nodes []*driveTreeNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		fi, fj := nodes[i].MimeType == driveMimeFolder, nodes[j].MimeType == driveMimeFolder
+		if fi != fj {
+			return fi
+		}
+		return strings.ToLower(nodes[i].Name) < strings.ToLower(nodes[j].Name)
+	})
+}
+
+func walkDriveTreeNodes(n *driveTreeNode, prefix string, fn func(*driveTreeNode, string)) {
+	for _, child := range n.Children {
+		p := path.Join(prefix, child.Name)
+		fn(child, p)
+		walkDriveTreeNodes(child, p, fn)
+	}
+}
+
+func printDriveTree(ctx context.Context, u *ui.UI, nodes []*driveTreeNode, indent string) {
+	for i, n := range nodes {
+		branch, nextIndent := "├── ", indent+"│   "
+		if i == len(nodes)-1 {
+			branch, nextIndent = "└── ", indent+"    "
+		}
+		switch {
+		case n.MimeType != driveMimeFolder && n.Size > 0:
+			u.Out().Printf("%s%s%s  (%s, %s)", indent, branch, n.Name, n.ID, humanSize(ctx, n.Size))
+		case n.MimeType != driveMimeFolder:
+			u.Out().Printf("%s%s%s  (%s)", indent, branch, n.Name, n.ID)
+		case n.Truncated:
+			u.Out().Printf("%s%s%s/  (%s) …", indent, branch, n.Name, n.ID)
+		default:
+			u.Out().Printf("%s%s%s/  (%s)", indent, branch, n.Name, n.ID)
+		}
+		printDriveTree(ctx, u, n.Children, nextIndent)
+	}
+}
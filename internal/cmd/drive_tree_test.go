@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestDriveLsCmd_Tree(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files/top":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "top", "name": "Project", "mimeType": driveMimeFolder})
+		case r.Method == http.MethodGet && r.URL.Path == "/files":
+			q := r.URL.Query().Get("q")
+			queries = append(queries, q)
+			var files []map[string]any
+			if strings.Contains(q, "'top' in parents") {
+				files = append(files,
+					map[string]any{"id": "f1", "name": "readme.txt", "mimeType": "text/plain", "size": "2048", "parents": []string{"top"}},
+					map[string]any{"id": "a", "name": "Assets", "mimeType": driveMimeFolder, "parents": []string{"top"}},
+					map[string]any{"id": "b", "name": "Briefs", "mimeType": driveMimeFolder, "parents": []string{"top"}},
+				)
+			}
+			if strings.Contains(q, "'a' in parents") && strings.Contains(q, "'b' in parents") {
+				files = append(files,
+					map[string]any{"id": "b1", "name": "Brief", "mimeType": driveMimeGoogleDoc, "parents": []string{"b"}},
+					map[string]any{"id": "a1", "name": "Logos", "mimeType": driveMimeFolder, "parents": []string{"a"}},
+				)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": files})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	flags := &RootFlags{Account: "a@b.com"}
+
+	var outBuf bytes.Buffer
+	u, err := ui.New(ui.Options{Stdout: &outBuf, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{})
+	if err := runKong(t, &DriveLsCmd{}, []string{"top", "--tree", "--depth", "2"}, ctx, flags); err != nil {
+		t.Fatalf("tree: %v", err)
+	}
+	want := strings.Join([]string{
+		"Project/  (top)",
+		"├── Assets/  (a)",
+		"│   └── Logos/  (a1) …",
+		"├── Briefs/  (b)",
+		"│   └── Brief  (b1)",
+		"└── readme.txt  (f1, 2.0 KB)",
+	}, "\n")
+	if got := strings.TrimSpace(outBuf.String()); got != want {
+		t.Fatalf("unexpected tree:\n%s\nwant:\n%s", got, want)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("expected one list call per level, got %d: %v", len(queries), queries)
+	}
+
+	jsonOut := captureStdout(t, func() {
+		if err := runKong(t, &DriveLsCmd{}, []string{"--parent", "top", "--tree"}, newDocsJSONContext(t), flags); err != nil {
+			t.Fatalf("tree json: %v", err)
+		}
+	})
+	var parsed struct {
+		Tree    driveTreeNode `json:"tree"`
+		Folders int           `json:"folders"`
+		Files   int           `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(jsonOut), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, jsonOut)
+	}
+	if parsed.Folders != 3 || parsed.Files != 2 || len(parsed.Tree.Children) != 3 {
+		t.Fatalf("unexpected json: %s", jsonOut)
+	}
+	assets := parsed.Tree.Children[0]
+	if assets.ID != "a" || assets.ParentID != "top" || len(assets.Children) != 1 || assets.Children[0].Depth != 2 || assets.Children[0].ParentID != "a" {
+		t.Fatalf("unexpected parent/child relations: %+v", assets)
+	}
+
+	if err := runKong(t, &DriveLsCmd{}, []string{"--parent", "top", "--depth", "1"}, ctx, flags); err == nil || !strings.Contains(err.Error(), "--depth requires --tree") {
+		t.Fatalf("expected --depth usage error, got %v", err)
+	}
+}
@@ -6,14 +6,17 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"google.golang.org/api/drive/v3"
 	gapi "google.golang.org/api/googleapi"
 
 	"github.com/steipete/gogcli/internal/config"
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/drivetransfer"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type driveUploadOptions struct {
@@ -21,19 +24,36 @@ type driveUploadOptions struct {
 	fileName            string
 	parent              string
 	replaceFileID       string
+	fileID              string
 	mimeType            string
 	convertMimeType     string
 	isExplicitName      bool
 	keepRevisionForever bool
 	convert             bool
+	chunkSize           int
+	chunkRetry          time.Duration
+	progress            *driveUploadProgress
 }
 
 func (c *DriveUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if strings.TrimSpace(c.FileID) != "" && (c.Recursive || len(c.MorePaths) > 0) {
+		return usage("--id uploads a single file; it cannot be combined with --recursive or more paths")
+	}
+	if c.Recursive {
+		return c.runRecursive(ctx, flags)
+	}
+	if len(c.MorePaths) > 0 {
+		return c.runBulk(ctx, flags)
+	}
+
 	opts, err := prepareDriveUpload(c)
 	if err != nil {
 		return err
 	}
 
+	if st, statErr := os.Stat(opts.localPath); statErr == nil && st.IsDir() {
+		return usagef("%s is a directory (use --recursive)", opts.localPath)
+	}
 	file, err := os.Open(opts.localPath)
 	if err != nil {
 		return err
@@ -48,6 +68,9 @@ func (c *DriveUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if opts.replaceFileID == "" {
 		return runDriveCreateUpload(ctx, svc, file, opts)
 	}
+	if err := ensureDriveUnlocked(ctx, flags, opts.replaceFileID); err != nil {
+		return err
+	}
 	return runDriveReplaceUpload(ctx, svc, file, opts)
 }
 
@@ -67,11 +90,27 @@ func prepareDriveUpload(c *DriveUploadCmd) (driveUploadOptions, error) {
 		fileName:            strings.TrimSpace(c.Name),
 		parent:              strings.TrimSpace(c.Parent),
 		replaceFileID:       strings.TrimSpace(c.ReplaceFileID),
+		fileID:              strings.TrimSpace(c.FileID),
 		mimeType:            strings.TrimSpace(c.MimeType),
 		keepRevisionForever: c.KeepRevisionForever,
 	}
 	opts.isExplicitName = opts.fileName != ""
 
+	opts.chunkSize, err = parseDriveChunkSize(c.ChunkSize)
+	if err != nil {
+		return driveUploadOptions{}, err
+	}
+	if c.ChunkRetry < 0 {
+		return driveUploadOptions{}, usage("--chunk-retry must be >= 0")
+	}
+	opts.chunkRetry = c.ChunkRetry
+	if !c.NoProgress && stderrIsTerminal() {
+		opts.progress = newDriveUploadProgress(os.Stderr, opts.localPath)
+	}
+
+	if opts.replaceFileID != "" && opts.fileID != "" {
+		return driveUploadOptions{}, usage("--id cannot be combined with --replace")
+	}
 	if opts.replaceFileID != "" && opts.parent != "" {
 		return driveUploadOptions{}, usage("--parent cannot be combined with --replace (use drive move)")
 	}
@@ -95,7 +134,15 @@ func prepareDriveUpload(c *DriveUploadCmd) (driveUploadOptions, error) {
 }
 
 func runDriveCreateUpload(ctx context.Context, svc *drive.Service, file io.Reader, opts driveUploadOptions) error {
-	meta := &drive.File{Name: opts.fileName}
+	created, err := createDriveUpload(ctx, svc, file, opts)
+	if err != nil {
+		return err
+	}
+	return writeDriveUploadResult(ctx, created, false, "")
+}
+
+func createDriveUpload(ctx context.Context, svc *drive.Service, file io.Reader, opts driveUploadOptions) (*drive.File, error) {
+	meta := &drive.File{Id: opts.fileID, Name: opts.fileName}
 	if opts.parent != "" {
 		meta.Parents = []string{opts.parent}
 	}
@@ -106,50 +153,19 @@ func runDriveCreateUpload(ctx context.Context, svc *drive.Service, file io.Reade
 		}
 	}
 
-	call := svc.Files.Create(meta).
-		SupportsAllDrives(true).
-		Media(file, gapi.ContentType(opts.mimeType)).
-		Fields("id, name, mimeType, size, webViewLink").
-		Context(ctx)
-	if opts.keepRevisionForever {
-		call = call.KeepRevisionForever(true)
-	}
-
-	created, err := call.Do()
-	if err != nil {
-		return err
-	}
-	return writeDriveUploadResult(ctx, created, false, "")
+	created, err := drivetransfer.Upload(ctx, svc, meta, file, opts.transferOptions())
+	opts.progress.finish()
+	return created, err
 }
 
 func runDriveReplaceUpload(ctx context.Context, svc *drive.Service, file io.Reader, opts driveUploadOptions) error {
-	existing, err := svc.Files.Get(opts.replaceFileID).
-		SupportsAllDrives(true).
-		Fields("id, mimeType").
-		Context(ctx).
-		Do()
-	if err != nil {
-		return err
-	}
-	if strings.HasPrefix(existing.MimeType, "application/vnd.google-apps.") {
-		return fmt.Errorf("cannot replace content for Google Workspace files (mimeType=%s)", existing.MimeType)
-	}
-
 	meta := &drive.File{}
 	if opts.fileName != "" {
 		meta.Name = opts.fileName
 	}
 
-	call := svc.Files.Update(opts.replaceFileID, meta).
-		SupportsAllDrives(true).
-		Media(file, gapi.ContentType(opts.mimeType)).
-		Fields("id, name, mimeType, size, webViewLink").
-		Context(ctx)
-	if opts.keepRevisionForever {
-		call = call.KeepRevisionForever(true)
-	}
-
-	updated, err := call.Do()
+	updated, err := drivetransfer.Replace(ctx, svc, opts.replaceFileID, meta, file, opts.transferOptions())
+	opts.progress.finish()
 	if err != nil {
 		return err
 	}
@@ -169,6 +185,10 @@ func writeDriveUploadResult(ctx context.Context, file *drive.File, replaced bool
 
 	u.Out().Printf("id\t%s", file.Id)
 	u.Out().Printf("name\t%s", file.Name)
+	// Uploads only come back Google-native when --convert imported them.
+	if strings.HasPrefix(file.MimeType, "application/vnd.google-apps.") {
+		u.Out().Printf("converted\t%s", file.MimeType)
+	}
 	if replaced {
 		u.Out().Printf("replaced\t%t", true)
 	}
@@ -177,3 +197,102 @@ func writeDriveUploadResult(ctx context.Context, file *drive.File, replaced bool
 	}
 	return nil
 }
+
+// transferOptions maps the upload flags onto drivetransfer. A --chunk-size
+// of 0 turns off the resumable protocol.
+func (o driveUploadOptions) transferOptions() drivetransfer.UploadOptions {
+	opts := drivetransfer.UploadOptions{
+		MimeType:            o.mimeType,
+		ChunkSize:           o.chunkSize,
+		ChunkRetry:          o.chunkRetry,
+		KeepRevisionForever: o.keepRevisionForever,
+	}
+	if opts.ChunkSize == 0 {
+		opts.ChunkSize = -1
+	}
+	if o.progress != nil {
+		opts.OnProgress = o.progress.update
+	}
+	return opts
+}
+
+// parseDriveChunkSize parses sizes like 16MB, 512KB, or a plain byte count.
+// Units are binary (1KB = 1024 bytes).
+func parseDriveChunkSize(raw string) (int, error) {
+	s := strings.ToUpper(strings.TrimSpace(raw))
+	if s == "" {
+		return gapi.DefaultUploadChunkSize, nil
+	}
+	mult := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		mult   int64
+	}{
+		{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1},
+	} {
+		if trimmed, ok := strings.CutSuffix(s, unit.suffix); ok {
+			s, mult = strings.TrimSpace(trimmed), unit.mult
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, usagef("invalid --chunk-size %q (use e.g. 8MB, 512KB, or 0)", raw)
+	}
+	if n > (1<<31-1)/mult {
+		return 0, usagef("--chunk-size %q is too large", raw)
+	}
+	return int(n * mult), nil
+}
+
+const driveUploadProgressWidth = 30
+
+// driveUploadProgress draws a single-line progress bar, updated after each
+// uploaded chunk. A nil progress is a no-op.
+type driveUploadProgress struct {
+	w     io.Writer
+	name  string
+	size  int64
+	drawn bool
+}
+
+func newDriveUploadProgress(w io.Writer, localPath string) *driveUploadProgress {
+	p := &driveUploadProgress{w: w, name: filepath.Base(localPath)}
+	if st, err := os.Stat(localPath); err == nil {
+		p.size = st.Size()
+	}
+	return p
+}
+
+func (p *driveUploadProgress) update(current, total int64) {
+	if p == nil {
+		return
+	}
+	if total <= 0 {
+		total = p.size
+	}
+	if total <= 0 {
+		fmt.Fprintf(p.w, "\rUploading %s  %s", p.name, formatDriveSize(current))
+		p.drawn = true
+		return
+	}
+	current = min(current, total)
+	filled := int(current * driveUploadProgressWidth / total)
+	fmt.Fprintf(p.w, "\rUploading %s  [%s%s] %3d%%  %s / %s",
+		p.name,
+		strings.Repeat("#", filled),
+		strings.Repeat("-", driveUploadProgressWidth-filled),
+		current*100/total,
+		formatDriveSize(current),
+		formatDriveSize(total),
+	)
+	p.drawn = true
+}
+
+func (p *driveUploadProgress) finish() {
+	if p == nil || !p.drawn {
+		return
+	}
+	fmt.Fprintln(p.w)
+	p.drawn = false
+}
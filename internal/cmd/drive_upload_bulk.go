@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// runBulk uploads every path into the same parent. Total size is checked
+// against the remaining storage quota before the first byte is sent.
+func (c *DriveUploadCmd) runBulk(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if strings.TrimSpace(c.Name) != "" {
+		return usage("--name cannot be used when uploading multiple files")
+	}
+	if strings.TrimSpace(c.ReplaceFileID) != "" {
+		return usage("--replace cannot be used when uploading multiple files")
+	}
+
+	paths := append([]string{c.LocalPath}, c.MorePaths...)
+	items := make([]driveUploadOptions, 0, len(paths))
+	var total int64
+	for _, p := range paths {
+		single := *c
+		single.LocalPath = p
+		single.MorePaths = nil
+		opts, err := prepareDriveUpload(&single)
+		if err != nil {
+			return err
+		}
+		st, err := os.Stat(opts.localPath)
+		if err != nil {
+			return err
+		}
+		if !st.Mode().IsRegular() {
+			return usagef("not a regular file: %s", opts.localPath)
+		}
+		items = append(items, opts)
+		// Converted Google Docs, Sheets, and Slides do not use storage quota.
+		if !opts.convert {
+			total += st.Size()
+		}
+	}
+
+	if err := dryRunExit(ctx, flags, "drive.upload", map[string]any{
+		"files":      paths,
+		"parent":     strings.TrimSpace(c.Parent),
+		"totalBytes": total,
+	}); err != nil {
+		return err
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	if err := driveQuotaPreflight(ctx, flags, svc, total, strings.TrimSpace(c.Parent)); err != nil {
+		return err
+	}
+
+	uploaded := make([]*drive.File, 0, len(items))
+	for _, opts := range items {
+		created, err := uploadDriveBulkItem(ctx, svc, opts)
+		if err != nil {
+			if len(uploaded) > 0 {
+				u.Err().Printf("Uploaded %d of %d files before the failure", len(uploaded), len(items))
+			}
+			return fmt.Errorf("uploading %s: %w", opts.localPath, err)
+		}
+		uploaded = append(uploaded, created)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"files":      uploaded,
+			"totalBytes": total,
+		})
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tNAME\tLINK")
+	for _, f := range uploaded {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", f.Id, f.Name, f.WebViewLink)
+	}
+	return nil
+}
+
+func uploadDriveBulkItem(ctx context.Context, svc *drive.Service, opts driveUploadOptions) (*drive.File, error) {
+	file, err := os.Open(opts.localPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return createDriveUpload(ctx, svc, file, opts)
+}
+
+// driveQuotaPreflight makes sure needed bytes fit in the account's remaining
+// Drive storage. When they do not, it prompts (or fails without a terminal);
+// --force downgrades the check to a warning. Uploads into a shared drive are
+// not limited by the user's quota and are always allowed.
+func driveQuotaPreflight(ctx context.Context, flags *RootFlags, svc *drive.Service, needed int64, parent string) error {
+	u := ui.FromContext(ctx)
+	if needed <= 0 {
+		return nil
+	}
+
+	about, err := svc.About.Get().Fields("storageQuota(limit,usage)").Context(ctx).Do()
+	if err != nil {
+		u.Err().Printf("warning: could not check Drive storage quota: %v", err)
+		return nil
+	}
+	remaining, limited := driveQuotaRemaining(about.StorageQuota)
+	if !limited || needed <= remaining {
+		return nil
+	}
+
+	if parent != "" {
+		folder, getErr := svc.Files.Get(parent).SupportsAllDrives(true).Fields("id, driveId").Context(ctx).Do()
+		if getErr == nil && folder.DriveId != "" {
+			return nil
+		}
+	}
+
+	left := "0 B"
+	if remaining > 0 {
+		left = formatDriveSize(remaining)
+	}
+	action := fmt.Sprintf("upload %s with only %s of Drive storage left", formatDriveSize(needed), left)
+	if flags != nil && flags.Force {
+		u.Err().Printf("warning: about to %s", action)
+		return nil
+	}
+	return confirmDestructiveChecked(ctx, flags, action)
+}
+
+// driveQuotaRemaining returns the free bytes and whether the account has a
+// storage limit at all.
+func driveQuotaRemaining(q *drive.AboutStorageQuota) (int64, bool) {
+	if q == nil || q.Limit <= 0 {
+		return 0, false
+	}
+	return max(q.Limit-q.Usage, 0), true
+}
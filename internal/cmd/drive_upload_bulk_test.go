@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestDriveQuotaRemaining(t *testing.T) {
+	if _, limited := driveQuotaRemaining(&drive.AboutStorageQuota{Usage: 10}); limited {
+		t.Fatal("expected unlimited quota without a limit")
+	}
+	if left, limited := driveQuotaRemaining(&drive.AboutStorageQuota{Limit: 100, Usage: 40}); !limited || left != 60 {
+		t.Fatalf("unexpected remaining: %d %v", left, limited)
+	}
+	if left, _ := driveQuotaRemaining(&drive.AboutStorageQuota{Limit: 100, Usage: 140}); left != 0 {
+		t.Fatalf("expected over-quota accounts to report 0, got %d", left)
+	}
+}
+
+func TestDriveUploadCmd_BulkQuotaPreflight(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	quotaLimit := int64(1000)
+	var uploads int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/about"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"storageQuota": map[string]any{
+				"limit": fmt.Sprint(quotaLimit),
+				"usage": "990",
+			}})
+		case strings.HasPrefix(r.URL.Path, "/upload/drive/v3/files") && r.Method == http.MethodPost:
+			uploads++
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": fmt.Sprintf("f%d", uploads), "name": "x"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.txt")
+	b := filepath.Join(dir, "b.txt")
+	for _, p := range []string{a, b} {
+		if writeErr := os.WriteFile(p, []byte("0123456789"), 0o600); writeErr != nil {
+			t.Fatalf("WriteFile: %v", writeErr)
+		}
+	}
+
+	flags := &RootFlags{Account: "a@b.com", NoInput: true}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+
+	// 20 bytes needed, 10 left: refuse before uploading anything.
+	err = runKong(t, &DriveUploadCmd{}, []string{a, b}, ctx, flags)
+	if err == nil || !strings.Contains(err.Error(), "refusing to upload 20 B with only 10 B of Drive storage left") {
+		t.Fatalf("expected quota refusal, got %v", err)
+	}
+	if uploads != 0 {
+		t.Fatalf("expected no uploads, got %d", uploads)
+	}
+
+	quotaLimit = 0 // unlimited
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveUploadCmd{}, []string{a, b}, ctx, flags); err != nil {
+			t.Fatalf("bulk upload: %v", err)
+		}
+	})
+	var got struct {
+		Files      []*drive.File `json:"files"`
+		TotalBytes int64         `json:"totalBytes"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if uploads != 2 || len(got.Files) != 2 || got.TotalBytes != 20 {
+		t.Fatalf("unexpected result: uploads=%d %#v", uploads, got)
+	}
+
+	if err := runKong(t, &DriveUploadCmd{}, []string{a, b, "--name", "x"}, ctx, flags); err == nil {
+		t.Fatal("expected --name to be rejected for bulk uploads")
+	}
+}
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// driveUploadRetryDelay is the base backoff between attempts for one file;
+// tests shrink it.
+var driveUploadRetryDelay = time.Second
+
+type driveUploadTreeFile struct {
+	Path     string `json:"path"`
+	ID       string `json:"id,omitempty"`
+	Size     int64  `json:"size"`
+	Attempts int    `json:"attempts,omitempty"`
+	Error    string `json:"error,omitempty"`
+
+	opts driveUploadOptions
+	dir  string
+}
+
+// runRecursive mirrors a local directory into a new Drive folder under
+// --parent. Folders are created first, in tree order; files then upload
+// through a worker pool, each retried on transient API errors. One failed
+// file does not stop the others. For incremental updates of an existing
+// folder, use drive sync.
+func (c *DriveUploadCmd) runRecursive(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if len(c.MorePaths) > 0 {
+		return usage("--recursive takes a single directory")
+	}
+	if strings.TrimSpace(c.ReplaceFileID) != "" {
+		return usage("--replace cannot be combined with --recursive")
+	}
+	if strings.TrimSpace(c.ConvertTo) != "" {
+		return usage("--convert-to cannot be combined with --recursive (use --convert)")
+	}
+	if c.Workers < 1 {
+		return usage("--workers must be >= 1")
+	}
+	if c.Retries < 0 {
+		return usage("--retries must be >= 0")
+	}
+	root, err := config.ExpandPath(strings.TrimSpace(c.LocalPath))
+	if err != nil {
+		return err
+	}
+	st, err := os.Stat(root)
+	if err != nil {
+		return err
+	}
+	if !st.IsDir() {
+		return usagef("not a directory: %s (drop --recursive to upload a file)", root)
+	}
+	rootName := strings.TrimSpace(c.Name)
+	if rootName == "" {
+		rootName = filepath.Base(filepath.Clean(root))
+	}
+
+	dirs, files, skipped, err := c.scanUploadTree(root)
+	if err != nil {
+		return err
+	}
+	for _, s := range skipped {
+		u.Err().Printf("Skipping %s (not a regular file)", s)
+	}
+	var total int64
+	for _, f := range files {
+		// Converted Google Docs, Sheets, and Slides do not use storage quota.
+		if !f.opts.convert {
+			total += f.Size
+		}
+	}
+
+	if err := dryRunExit(ctx, flags, "drive.upload", map[string]any{
+		"directory":  root,
+		"name":       rootName,
+		"parent":     strings.TrimSpace(c.Parent),
+		"folders":    dirs,
+		"files":      len(files),
+		"totalBytes": total,
+	}); err != nil {
+		return err
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	if err := driveQuotaPreflight(ctx, flags, svc, total, strings.TrimSpace(c.Parent)); err != nil {
+		return err
+	}
+
+	rootFolder, err := createDriveFolder(ctx, svc, rootName, strings.TrimSpace(c.Parent))
+	if err != nil {
+		return fmt.Errorf("create folder %s: %w", rootName, err)
+	}
+	folderIDs := map[string]string{".": rootFolder.Id}
+	for _, dir := range dirs {
+		created, err := createDriveFolder(ctx, svc, path.Base(dir), folderIDs[path.Dir(dir)])
+		if err != nil {
+			return fmt.Errorf("create folder %s: %w", dir, err)
+		}
+		folderIDs[dir] = created.Id
+	}
+
+	uploadDriveTree(ctx, svc, files, folderIDs, c.Workers, c.Retries)
+
+	var failed int
+	for _, f := range files {
+		if f.Error != "" {
+			failed++
+		}
+	}
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"folder":     rootFolder,
+			"folders":    len(dirs) + 1,
+			"files":      files,
+			"failed":     failed,
+			"totalBytes": total,
+		}); err != nil {
+			return err
+		}
+	} else {
+		u.Out().Printf("folder\t%s", rootFolder.Id)
+		if rootFolder.WebViewLink != "" {
+			u.Out().Printf("link\t%s", rootFolder.WebViewLink)
+		}
+		u.Out().Printf("folders\t%d", len(dirs)+1)
+		u.Out().Printf("files\t%d", len(files)-failed)
+		u.Out().Printf("size\t%s", humanSize(ctx, total))
+		for _, f := range files {
+			if f.Error != "" {
+				u.Err().Printf("Failed %s after %d attempt%s: %s", f.Path, f.Attempts, pluralS(f.Attempts), f.Error)
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed to upload", failed, len(files))
+	}
+	return nil
+}
+
+// scanUploadTree walks root and returns its subdirectories (slash paths,
+// parents before children), the files to upload, and any skipped entries
+// such as symlinks and sockets.
+func (c *DriveUploadCmd) scanUploadTree(root string) ([]string, []*driveUploadTreeFile, []string, error) {
+	var (
+		dirs    []string
+		files   []*driveUploadTreeFile
+		skipped []string
+	)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		switch {
+		case rel == ".":
+			return nil
+		case d.IsDir():
+			dirs = append(dirs, rel)
+			return nil
+		case !d.Type().IsRegular():
+			skipped = append(skipped, rel)
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		single := *c
+		single.LocalPath = p
+		single.MorePaths = nil
+		single.Name = ""
+		single.Parent = ""
+		single.NoProgress = true
+		// --convert applies to the files it can convert; the rest upload as-is.
+		if _, ok := googleConvertMimeType(p); !ok {
+			single.Convert = false
+		}
+		opts, err := prepareDriveUpload(&single)
+		if err != nil {
+			return err
+		}
+		files = append(files, &driveUploadTreeFile{Path: rel, Size: info.Size(), opts: opts, dir: path.Dir(rel)})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sort.Strings(dirs)
+	return dirs, files, skipped, nil
+}
+
+func uploadDriveTree(ctx context.Context, svc *drive.Service, files []*driveUploadTreeFile, folderIDs map[string]string, workers, retries int) {
+	jobs := make(chan *driveUploadTreeFile)
+	var wg sync.WaitGroup
+	for range min(workers, max(len(files), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range jobs {
+				f.opts.parent = folderIDs[f.dir]
+				created, attempts, err := uploadDriveTreeFile(ctx, svc, f.opts, retries)
+				f.Attempts = attempts
+				if err != nil {
+					f.Error = err.Error()
+					continue
+				}
+				f.ID = created.Id
+			}
+		}()
+	}
+	for _, f := range files {
+		if ctx.Err() != nil {
+			f.Error = ctx.Err().Error()
+			continue
+		}
+		jobs <- f
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// uploadDriveTreeFile uploads one file, retrying transient failures with
+// linear backoff. The file is reopened for each attempt.
+func uploadDriveTreeFile(ctx context.Context, svc *drive.Service, opts driveUploadOptions, retries int) (*drive.File, int, error) {
+	for attempt := 1; ; attempt++ {
+		created, err := uploadDriveBulkItem(ctx, svc, opts)
+		if err == nil {
+			return created, attempt, nil
+		}
+		if attempt > retries || !isRetryableError(err) {
+			return nil, attempt, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, attempt, ctx.Err()
+		case <-time.After(time.Duration(attempt) * driveUploadRetryDelay):
+		}
+	}
+}
+
+func createDriveFolder(ctx context.Context, svc *drive.Service, name, parent string) (*drive.File, error) {
+	meta := &drive.File{Name: name, MimeType: driveMimeFolder}
+	if parent != "" {
+		meta.Parents = []string{parent}
+	}
+	return svc.Files.Create(meta).
+		SupportsAllDrives(true).
+		Fields("id, name, webViewLink").
+		Context(ctx).
+		Do()
+}
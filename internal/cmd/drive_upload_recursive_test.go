@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestDriveUploadCmd_Recursive(t *testing.T) {
+	origNew, origDelay := newDriveService, driveUploadRetryDelay
+	t.Cleanup(func() { newDriveService, driveUploadRetryDelay = origNew, origDelay })
+	driveUploadRetryDelay = 0
+
+	var (
+		mu       sync.Mutex
+		nextID   int
+		parents  = map[string]string{} // name -> parent id
+		flaky    = 1                   // first attempt for c.txt fails with 503
+		attempts = map[string]int{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/files") {
+			if strings.HasSuffix(r.URL.Path, "/about") {
+				_ = json.NewEncoder(w).Encode(map[string]any{"storageQuota": map[string]any{}})
+				return
+			}
+			http.NotFound(w, r)
+			return
+		}
+		var meta drive.File
+		if strings.HasPrefix(r.URL.Path, "/upload/") {
+			_, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			part, err := multipart.NewReader(r.Body, params["boundary"]).NextPart()
+			if err != nil {
+				t.Errorf("multipart: %v", err)
+				return
+			}
+			_ = json.NewDecoder(part).Decode(&meta)
+		} else {
+			_ = json.NewDecoder(r.Body).Decode(&meta)
+		}
+		attempts[meta.Name]++
+		if meta.Name == "c.txt" && flaky > 0 {
+			flaky--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":{"code":503,"message":"backend error"}}`))
+			return
+		}
+		if meta.Name == "bad.txt" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":{"code":403,"message":"nope"}}`))
+			return
+		}
+		nextID++
+		id := fmt.Sprintf("id%d", nextID)
+		if len(meta.Parents) == 1 {
+			parents[meta.Name] = meta.Parents[0]
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "name": meta.Name})
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	root := filepath.Join(t.TempDir(), "dist")
+	for rel, body := range map[string]string{"a.txt": "a", "sub/b.txt": "bb", "sub/deep/c.txt": "ccc"} {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveUploadCmd{}, []string{root, "--recursive", "--parent", "p0", "--workers", "2"}, ctx, flags); err != nil {
+			t.Fatalf("recursive upload: %v", err)
+		}
+	})
+	var got struct {
+		Folder     *drive.File           `json:"folder"`
+		Folders    int                   `json:"folders"`
+		Files      []driveUploadTreeFile `json:"files"`
+		TotalBytes int64                 `json:"totalBytes"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if got.Folders != 3 || len(got.Files) != 3 || got.TotalBytes != 6 {
+		t.Fatalf("unexpected result: %s", out)
+	}
+	root0 := got.Folder.Id
+	if parents["dist"] != "p0" || parents["a.txt"] != root0 || parents["sub"] != root0 ||
+		parents["b.txt"] != parents["deep"] || parents["c.txt"] == "" || parents["c.txt"] == parents["b.txt"] {
+		t.Fatalf("unexpected folder structure: %v", parents)
+	}
+	if attempts["c.txt"] != 2 {
+		t.Fatalf("expected c.txt retried once, got %d attempts", attempts["c.txt"])
+	}
+
+	// Non-retryable failures are reported per file and fail the command.
+	if err := os.WriteFile(filepath.Join(root, "bad.txt"), []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_ = captureStdout(t, func() {
+		err = runKong(t, &DriveUploadCmd{}, []string{root, "--recursive", "--retries", "5"}, ctx, flags)
+	})
+	if err == nil || !strings.Contains(err.Error(), "1 of 4 files failed") || attempts["bad.txt"] != 1 {
+		t.Fatalf("expected one failed file without retries, got %v (attempts %d)", err, attempts["bad.txt"])
+	}
+
+	if err := runKong(t, &DriveUploadCmd{}, []string{root}, ctx, flags); err == nil || !strings.Contains(err.Error(), "--recursive") {
+		t.Fatalf("expected directory hint, got %v", err)
+	}
+}
@@ -17,8 +17,8 @@ import (
 	"google.golang.org/api/drive/v3"
 	"google.golang.org/api/option"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 func TestDriveUpload_Replace_JSON(t *testing.T) {
@@ -421,3 +421,60 @@ func TestDriveUpload_Create_KeepRevisionForever(t *testing.T) {
 		t.Fatalf("expected keepRevisionForever query param set")
 	}
 }
+
+func TestDriveUpload_Create_Convert(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	var sent *drive.File
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/upload/drive/v3/files" || r.Method != http.MethodPost {
+			http.NotFound(w, r)
+			return
+		}
+		sent, _ = readDriveArtifactUpload(t, r)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":          "doc1",
+			"name":        sent.Name,
+			"mimeType":    sent.MimeType,
+			"webViewLink": "https://docs.google.com/document/d/doc1/edit",
+		})
+	}))
+	defer srv.Close()
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	local := filepath.Join(t.TempDir(), "Report.docx")
+	if writeErr := os.WriteFile(local, []byte("PK"), 0o600); writeErr != nil {
+		t.Fatalf("WriteFile: %v", writeErr)
+	}
+
+	var stdout bytes.Buffer
+	u, uiErr := ui.New(ui.Options{Stdout: &stdout, Stderr: io.Discard, Color: "never"})
+	if uiErr != nil {
+		t.Fatalf("ui.New: %v", uiErr)
+	}
+	ctx := ui.WithUI(context.Background(), u)
+
+	if err := runKong(t, &DriveUploadCmd{}, []string{local, "--convert"}, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	if sent == nil || sent.MimeType != driveMimeGoogleDoc || sent.Name != "Report" {
+		t.Fatalf("unexpected upload metadata: %+v", sent)
+	}
+	out := stdout.String()
+	for _, want := range []string{"id\tdoc1", "converted\t" + driveMimeGoogleDoc, "link\thttps://docs.google.com/document/d/doc1/edit"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in output:\n%s", want, out)
+		}
+	}
+}
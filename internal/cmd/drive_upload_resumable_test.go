@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+)
+
+func TestParseDriveChunkSize(t *testing.T) {
+	cases := map[string]int{
+		"":      gapi.DefaultUploadChunkSize,
+		"0":     0,
+		"16MB":  16 << 20,
+		"512kb": 512 << 10,
+		"1 G":   1 << 30,
+		"1024":  1024,
+	}
+	for in, want := range cases {
+		got, err := parseDriveChunkSize(in)
+		if err != nil || got != want {
+			t.Errorf("parseDriveChunkSize(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"-1MB", "lots", "4GB"} {
+		if _, err := parseDriveChunkSize(bad); err == nil {
+			t.Errorf("parseDriveChunkSize(%q): expected error", bad)
+		}
+	}
+}
+
+func TestDriveUploadProgress(t *testing.T) {
+	var buf bytes.Buffer
+	p := &driveUploadProgress{w: &buf, name: "big.iso", size: 4 << 20}
+	p.update(1<<20, 0)
+	p.finish()
+	got := buf.String()
+	if !strings.Contains(got, "\rUploading big.iso  [#######-----------------------]  25%  1.0 MB / 4.0 MB") || !strings.HasSuffix(got, "\n") {
+		t.Fatalf("unexpected progress line: %q", got)
+	}
+
+	var nilProgress *driveUploadProgress
+	nilProgress.update(1, 2)
+	nilProgress.finish()
+}
+
+func TestDriveUpload_ResumableRetriesChunk(t *testing.T) {
+	origNew := newDriveService
+	t.Cleanup(func() { newDriveService = origNew })
+
+	const size = 600 << 10
+	content := bytes.Repeat([]byte("x"), size)
+
+	var (
+		mu       sync.Mutex
+		received []byte
+		ranges   []string
+		failed   bool
+		srvURL   string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/upload/drive/v3/files":
+			if r.URL.Query().Get("uploadType") != "resumable" {
+				t.Errorf("expected resumable upload, got %q", r.URL.RawQuery)
+			}
+			w.Header().Set("Location", srvURL+"/session")
+			return
+		case r.URL.Path == "/session":
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			defer mu.Unlock()
+			rng := r.Header.Get("Content-Range")
+			ranges = append(ranges, rng)
+			if len(received) > 0 && !failed {
+				failed = true
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			received = append(received, body...)
+			if !strings.HasSuffix(rng, "/*") {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]any{"id": "f1", "name": "big.bin"})
+				return
+			}
+			// The client asks for 200 plus an override header instead of 308.
+			w.Header().Set("X-Http-Status-Code-Override", "308")
+			w.Header().Set("Range", "bytes=0-"+strconv.Itoa(len(received)-1))
+			return
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	srvURL = srv.URL
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	local := filepath.Join(t.TempDir(), "big.bin")
+	if err := os.WriteFile(local, content, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveUploadCmd{}, []string{local, "--chunk-size", "256KB", "--chunk-retry", "10s"}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("upload: %v", err)
+		}
+	})
+	if !strings.Contains(out, `"id": "f1"`) {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if !bytes.Equal(received, content) {
+		t.Fatalf("server received %d bytes, want %d", len(received), size)
+	}
+	if len(ranges) != 4 || ranges[1] != ranges[2] {
+		t.Fatalf("expected 3 chunks with one retried, got %v", ranges)
+	}
+}
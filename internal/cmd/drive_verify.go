@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/drivetransfer"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+const (
+	driveVerifyOK        = "ok"
+	driveVerifyMissing   = "missing"
+	driveVerifyModified  = "modified"
+	driveVerifyExtra     = "extra"
+	driveVerifyUnchecked = "unchecked"
+)
+
+// DriveVerifyCmd checks a local copy of a folder (from drive download
+// --recursive or drive sync) against Drive. Paths follow the same naming as
+// the recursive download, so exported Google files are matched by name; their
+// content cannot be compared and is reported as unchecked.
+type DriveVerifyCmd struct {
+	LocalDir      string `arg:"" name:"localDir" help:"Local copy of the folder"`
+	FolderID      string `arg:"" name:"folderId" help:"Drive folder to compare against"`
+	ExportFormats string `name:"export-formats" help:"Export formats the local copy was made with, e.g. doc=pdf,sheet=csv (defaults: doc=docx, sheet=xlsx, slides=pptx, drawing=png)"`
+	SizeOnly      bool   `name:"size-only" help:"Compare sizes only; skip hashing local files"`
+}
+
+type driveVerifyEntry struct {
+	Path      string `json:"path"`
+	Status    string `json:"status"`
+	ID        string `json:"id,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+	LocalSize int64  `json:"localSize,omitempty"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+func (c *DriveVerifyCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	localDir, err := config.ExpandPath(strings.TrimSpace(c.LocalDir))
+	if err != nil {
+		return err
+	}
+	if st, statErr := os.Stat(localDir); statErr != nil || !st.IsDir() {
+		return usagef("%s is not a directory", c.LocalDir)
+	}
+	folderID := normalizeGoogleID(strings.TrimSpace(c.FolderID))
+	if folderID == "" {
+		return usage("empty folderId")
+	}
+	exports, err := parseDriveExportFormats(c.ExportFormats)
+	if err != nil {
+		return err
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	folder, err := svc.Files.Get(folderID).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	if folder.MimeType != driveMimeFolder {
+		return usagef("%s is not a folder", folder.Name)
+	}
+	remote, _, _, err := listDriveFolderFiles(ctx, svc, folder.Id, exports)
+	if err != nil {
+		return err
+	}
+	local, err := listDriveVerifyLocalFiles(localDir)
+	if err != nil {
+		return err
+	}
+
+	results := make([]driveVerifyEntry, 0, len(remote))
+	for _, e := range remote {
+		res := driveVerifyEntry{Path: e.Path, ID: e.ID, Size: e.Size}
+		localSize, ok := local[e.Path]
+		delete(local, e.Path)
+		switch {
+		case !ok:
+			res.Status = driveVerifyMissing
+		case e.Exported != "":
+			res.Status, res.LocalSize, res.Detail = driveVerifyUnchecked, localSize, "exported Google file"
+		default:
+			res.LocalSize = localSize
+			res.Status, res.Detail = verifyDriveLocalFile(filepath.Join(localDir, filepath.FromSlash(e.Path)), e, localSize, c.SizeOnly)
+		}
+		results = append(results, res)
+	}
+	for p, size := range local {
+		results = append(results, driveVerifyEntry{Path: p, Status: driveVerifyExtra, LocalSize: size})
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Path < results[j].Path })
+
+	counts := map[string]int{driveVerifyOK: 0, driveVerifyMissing: 0, driveVerifyModified: 0, driveVerifyExtra: 0, driveVerifyUnchecked: 0}
+	for _, r := range results {
+		counts[r.Status]++
+	}
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"localDir": localDir,
+			"folderId": folder.Id,
+			"counts":   counts,
+			"files":    results,
+		}); err != nil {
+			return err
+		}
+	} else {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "STATUS\tPATH\tDETAIL")
+		for _, r := range results {
+			if r.Status != driveVerifyOK {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", r.Status, r.Path, r.Detail)
+			}
+		}
+		flush()
+		u.Err().Printf("%d ok, %d missing, %d modified, %d extra, %d unchecked", counts[driveVerifyOK], counts[driveVerifyMissing], counts[driveVerifyModified], counts[driveVerifyExtra], counts[driveVerifyUnchecked])
+	}
+	if bad := counts[driveVerifyMissing] + counts[driveVerifyModified] + counts[driveVerifyExtra]; bad > 0 {
+		return &ExitError{Code: 1, Err: fmt.Errorf("%d of %d files differ from Drive", bad, len(results))}
+	}
+	return nil
+}
+
+// listDriveVerifyLocalFiles maps slash-separated relative paths to sizes,
+// leaving out partial downloads and drive sync state.
+func listDriveVerifyLocalFiles(root string) (map[string]int64, error) {
+	files := map[string]int64{}
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !d.Type().IsRegular() || strings.HasSuffix(p, drivetransfer.PartialSuffix) || d.Name() == driveSyncStateName {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = info.Size()
+		return nil
+	})
+	return files, err
+}
+
+func verifyDriveLocalFile(path string, e *driveFolderEntry, localSize int64, sizeOnly bool) (string, string) {
+	if localSize != e.Size {
+		return driveVerifyModified, fmt.Sprintf("size %d, Drive has %d", localSize, e.Size)
+	}
+	h, want, algo := drivetransfer.ChecksumHash(e.file)
+	if sizeOnly || h == nil {
+		return driveVerifyOK, ""
+	}
+	f, err := os.Open(path) //nolint:gosec // path is inside the directory being verified
+	if err != nil {
+		return driveVerifyModified, err.Error()
+	}
+	defer f.Close()
+	if _, err := io.Copy(h, f); err != nil {
+		return driveVerifyModified, err.Error()
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(got, want) {
+		return driveVerifyModified, algo + " checksum differs"
+	}
+	return driveVerifyOK, ""
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // Drive reports MD5 checksums
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestDriveVerifyCmd(t *testing.T) {
+	orig := newDriveService
+	t.Cleanup(func() { newDriveService = orig })
+
+	md5hex := func(s string) string {
+		sum := md5.Sum([]byte(s)) //nolint:gosec // test fixture
+		return hex.EncodeToString(sum[:])
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path == "/files/root":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "root", "name": "Backup", "mimeType": driveMimeFolder})
+		case path == "/files" && strings.Contains(r.URL.Query().Get("q"), "'root' in parents"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "sub", "name": "sub", "mimeType": driveMimeFolder},
+				{"id": "a", "name": "a.txt", "mimeType": "text/plain", "size": "5", "md5Checksum": md5hex("hello")},
+				{"id": "b", "name": "b.txt", "mimeType": "text/plain", "size": "5", "md5Checksum": md5hex("world")},
+				{"id": "doc", "name": "Notes", "mimeType": driveMimeGoogleDoc},
+			}})
+		case path == "/files" && strings.Contains(r.URL.Query().Get("q"), "'sub' in parents"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": []map[string]any{
+				{"id": "c", "name": "c.bin", "mimeType": "application/octet-stream", "size": "3"},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	dir := t.TempDir()
+	for name, body := range map[string]string{
+		"a.txt":            "hello",
+		"b.txt":            "WORLD",
+		"Notes.docx":       "exported",
+		"extra.txt":        "x",
+		"b.txt.part":       "partial",
+		driveSyncStateName: "{}",
+		"sub/.keep":        "",
+	} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = Execute([]string{"--json", "--account", "a@b.com", "drive", "verify", dir, "root"})
+	})
+	var exitErr *ExitError
+	if !errors.As(runErr, &exitErr) || exitErr.Code != 1 {
+		t.Fatalf("expected exit 1, got %v", runErr)
+	}
+	var parsed struct {
+		Counts map[string]int     `json:"counts"`
+		Files  []driveVerifyEntry `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("decode %q: %v", out, err)
+	}
+	got := map[string]string{}
+	for _, f := range parsed.Files {
+		got[f.Path] = f.Status
+	}
+	want := map[string]string{
+		"a.txt":      driveVerifyOK,
+		"b.txt":      driveVerifyModified,
+		"Notes.docx": driveVerifyUnchecked,
+		"sub/c.bin":  driveVerifyMissing,
+		"extra.txt":  driveVerifyExtra,
+		"sub/.keep":  driveVerifyExtra,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected files %v", got)
+	}
+	for p, s := range want {
+		if got[p] != s {
+			t.Fatalf("%s: got %q want %q (all: %v)", p, got[p], s, got)
+		}
+	}
+}
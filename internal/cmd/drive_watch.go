@@ -0,0 +1,424 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const driveWatchFileFields = "id,name,mimeType,trashed,modifiedTime,parents,size,md5Checksum,webViewLink,lastModifyingUser(displayName,emailAddress)"
+
+// runDriveWatchHook runs the --exec command for one event with the event
+// JSON on stdin. Tests swap it out.
+var runDriveWatchHook = func(ctx context.Context, command string, payload []byte) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command) //nolint:gosec // the hook command is supplied by the user
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec // the hook command is supplied by the user
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// DriveWatchCmd receives Drive push notifications itself instead of polling.
+// A file is watched with files.watch. A folder is watched through the change
+// feed (changes.watch), since files.watch on a folder only reports changes to
+// the folder's own metadata. Drive only delivers to public HTTPS addresses,
+// so --url is usually a tunnel or reverse proxy in front of --listen.
+type DriveWatchCmd struct {
+	FileID      string        `arg:"" name:"fileId" help:"File or folder ID (or /path) to watch"`
+	Listen      string        `name:"listen" help:"Address the built-in receiver listens on" default:"127.0.0.1:8789"`
+	Path        string        `name:"path" help:"Receiver path" default:"/drive-notify"`
+	URL         string        `name:"url" aliases:"public-url" help:"Public HTTPS URL that reaches the receiver, e.g. a tunnel (default with --tls-cert: https://<listen><path>)"`
+	TLSCert     string        `name:"tls-cert" help:"Serve HTTPS with this certificate (PEM)"`
+	TLSKey      string        `name:"tls-key" help:"Private key for --tls-cert (PEM)"`
+	TTL         time.Duration `name:"ttl" help:"Requested channel lifetime (Drive caps file channels at 1 day and folder channels at 1 week)" default:"1h"`
+	RenewBefore time.Duration `name:"renew-before" help:"Replace the channel this long before it expires" default:"5m"`
+	Exec        string        `name:"exec" help:"Run this shell command per event with the event JSON on stdin, instead of printing NDJSON"`
+	MaxEvents   int           `name:"max-events" help:"Exit after this many events (0 = run until interrupted)"`
+}
+
+// driveWatchEvent is one NDJSON line (or --exec payload) of drive watch.
+// State is Drive's X-Goog-Resource-State; Changed lists what changed on a
+// watched file (content, properties, parents, ...).
+type driveWatchEvent struct {
+	State   string `json:"state"`
+	Changed string `json:"changed,omitempty"`
+	driveChangeRecord
+}
+
+func (c *DriveWatchCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	ref := strings.TrimSpace(c.FileID)
+	if !isDrivePath(ref) {
+		ref = normalizeGoogleID(ref)
+	}
+	if ref == "" {
+		return usage("empty fileId")
+	}
+	host, _, err := net.SplitHostPort(strings.TrimSpace(c.Listen))
+	if err != nil {
+		return usagef("invalid --listen %q: %v", c.Listen, err)
+	}
+	if !strings.HasPrefix(c.Path, "/") {
+		return usage("--path must start with '/'")
+	}
+	if (c.TLSCert == "") != (c.TLSKey == "") {
+		return usage("--tls-cert and --tls-key go together")
+	}
+	if c.TTL <= 0 {
+		return usage("--ttl must be > 0")
+	}
+	if c.RenewBefore < 0 || c.RenewBefore >= c.TTL {
+		return usage("--renew-before must be >= 0 and shorter than --ttl")
+	}
+	if c.MaxEvents < 0 {
+		return usage("--max-events must be >= 0")
+	}
+	address := strings.TrimSpace(c.URL)
+	switch {
+	case address == "" && c.TLSCert != "" && host != "":
+		address = "https://" + strings.TrimSpace(c.Listen) + c.Path
+	case address == "":
+		return usage("--url is required: Drive only delivers notifications to a public HTTPS address (e.g. a tunnel to --listen)")
+	case !strings.HasPrefix(strings.ToLower(address), "https://"):
+		return usage("--url must be https://; Drive rejects plain HTTP receivers")
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	fileID, err := resolveDriveID(ctx, svc, ref)
+	if err != nil {
+		return err
+	}
+	target, err := svc.Files.Get(fileID).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType, driveId").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	token, err := newDriveWatchToken()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events := 0
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetEscapeHTML(false)
+	w := &driveWatcher{
+		ctx:      ctx,
+		svc:      svc,
+		target:   target,
+		folder:   target.MimeType == driveMimeFolder,
+		token:    token,
+		channels: map[string]string{},
+		children: map[string]bool{},
+		logf:     u.Err().Printf,
+		emit: func(ev driveWatchEvent) error {
+			if c.Exec != "" {
+				payload, err := json.Marshal(ev)
+				if err != nil {
+					return err
+				}
+				if err := runDriveWatchHook(ctx, c.Exec, payload); err != nil {
+					u.Err().Printf("drive watch: --exec failed for %s: %v", ev.FileID, err)
+				}
+			} else if err := enc.Encode(ev); err != nil {
+				return err
+			}
+			events++
+			if c.MaxEvents > 0 && events >= c.MaxEvents {
+				cancel()
+			}
+			return nil
+		},
+	}
+	if w.folder {
+		call := svc.Changes.GetStartPageToken().SupportsAllDrives(true).Context(ctx)
+		if target.DriveId != "" {
+			call = call.DriveId(target.DriveId)
+		}
+		start, startErr := call.Do()
+		if startErr != nil {
+			return startErr
+		}
+		w.pageToken = start.StartPageToken
+	}
+
+	// Listen before registering: Drive posts a sync message right away.
+	ln, err := net.Listen("tcp", strings.TrimSpace(c.Listen))
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle(c.Path, w)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	serveErr := make(chan error, 1)
+	go func() {
+		if c.TLSCert != "" {
+			serveErr <- srv.ServeTLS(ln, c.TLSCert, c.TLSKey)
+			return
+		}
+		serveErr <- srv.Serve(ln)
+	}()
+	defer func() {
+		shutdownCtx, stop := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer stop()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	ch, err := w.open(ctx, address, c.TTL)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		stopCtx, stop := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer stop()
+		if err := w.stop(stopCtx, ch); err != nil {
+			u.Err().Printf("drive watch: stopping channel %s: %v", ch.Id, err)
+		}
+	}()
+	u.Err().Printf("drive watch: %s (%s) via %s; listening on %s%s", target.Name, target.Id, address, ln.Addr(), c.Path)
+
+	timer := time.NewTimer(driveWatchRenewIn(ch, c.TTL, c.RenewBefore))
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-serveErr:
+			if errors.Is(err, http.ErrServerClosed) {
+				return nil
+			}
+			return err
+		case <-timer.C:
+		}
+		next, openErr := w.open(ctx, address, c.TTL)
+		if openErr != nil {
+			u.Err().Printf("drive watch: renewing channel failed, retrying in 1m: %v", openErr)
+			timer.Reset(time.Minute)
+			continue
+		}
+		// The old channel stays registered until the new one exists, so no
+		// notification falls in between.
+		if err := w.stop(ctx, ch); err != nil {
+			u.Err().Printf("drive watch: stopping channel %s: %v", ch.Id, err)
+		}
+		ch = next
+		timer.Reset(driveWatchRenewIn(ch, c.TTL, c.RenewBefore))
+	}
+}
+
+// driveWatchRenewIn is how long to wait before replacing ch. Drive may grant
+// a shorter lifetime than requested, so its expiration wins over ttl.
+func driveWatchRenewIn(ch *drive.Channel, ttl, renewBefore time.Duration) time.Duration {
+	lifetime := ttl
+	if ch.Expiration > 0 {
+		lifetime = time.Until(time.UnixMilli(ch.Expiration))
+	}
+	return max(lifetime-renewBefore, 10*time.Second)
+}
+
+func newDriveWatchToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate channel token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// driveWatcher owns the notification channels for one target and turns
+// notifications into events. It is the receiver's http.Handler.
+type driveWatcher struct {
+	ctx    context.Context
+	svc    *drive.Service
+	target *drive.File
+	folder bool
+	token  string
+	emit   func(driveWatchEvent) error
+	logf   func(string, ...any)
+
+	mu       sync.Mutex
+	channels map[string]string // channel ID -> resource ID
+
+	// pollMu serializes notifications so the change feed is read in order.
+	pollMu    sync.Mutex
+	pageToken string
+	// children remembers files seen in the folder so that permanent deletes
+	// and moves out of it, which no longer carry the folder as a parent,
+	// are still reported.
+	children map[string]bool
+}
+
+func (w *driveWatcher) open(ctx context.Context, address string, ttl time.Duration) (*drive.Channel, error) {
+	id, err := newDriveWatchToken()
+	if err != nil {
+		return nil, err
+	}
+	req := &drive.Channel{
+		Id:         "gog-" + id,
+		Type:       "web_hook",
+		Address:    address,
+		Token:      w.token,
+		Expiration: time.Now().Add(ttl).UnixMilli(),
+	}
+	var ch *drive.Channel
+	if w.folder {
+		w.pollMu.Lock()
+		pageToken := w.pageToken
+		w.pollMu.Unlock()
+		call := w.svc.Changes.Watch(pageToken, req).
+			IncludeRemoved(true).
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			Context(ctx)
+		if w.target.DriveId != "" {
+			call = call.DriveId(w.target.DriveId)
+		}
+		ch, err = call.Do()
+	} else {
+		ch, err = w.svc.Files.Watch(w.target.Id, req).SupportsAllDrives(true).Context(ctx).Do()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("register notification channel: %w", err)
+	}
+	w.mu.Lock()
+	w.channels[ch.Id] = ch.ResourceId
+	w.mu.Unlock()
+	return ch, nil
+}
+
+func (w *driveWatcher) stop(ctx context.Context, ch *drive.Channel) error {
+	w.mu.Lock()
+	delete(w.channels, ch.Id)
+	w.mu.Unlock()
+	return w.svc.Channels.Stop(&drive.Channel{Id: ch.Id, ResourceId: ch.ResourceId}).Context(ctx).Do()
+}
+
+func (w *driveWatcher) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.mu.Lock()
+	_, known := w.channels[r.Header.Get("X-Goog-Channel-ID")]
+	w.mu.Unlock()
+	if !known || subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Goog-Channel-Token")), []byte(w.token)) != 1 {
+		http.Error(rw, "unknown channel", http.StatusForbidden)
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(r.Body, 1<<16))
+	rw.WriteHeader(http.StatusOK)
+
+	state := r.Header.Get("X-Goog-Resource-State")
+	if state == "sync" {
+		return
+	}
+	if err := w.handle(state, r.Header.Get("X-Goog-Changed")); err != nil {
+		w.logf("drive watch: %v", err)
+	}
+}
+
+func (w *driveWatcher) handle(state, changed string) error {
+	w.pollMu.Lock()
+	defer w.pollMu.Unlock()
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	if !w.folder {
+		ev := driveWatchEvent{State: state, Changed: changed, driveChangeRecord: driveChangeRecord{Time: now, ChangeType: "file", FileID: w.target.Id}}
+		f, err := w.svc.Files.Get(w.target.Id).
+			SupportsAllDrives(true).
+			Fields(driveWatchFileFields).
+			Context(w.ctx).
+			Do()
+		switch {
+		case err == nil:
+			ev.driveChangeRecord = newDriveChangeRecord(&drive.Change{Time: now, ChangeType: "file", FileId: f.Id, File: f})
+		case state == "remove":
+			ev.Removed = true
+		default:
+			return fmt.Errorf("read %s after %s notification: %w", w.target.Id, state, err)
+		}
+		return w.emit(ev)
+	}
+
+	for {
+		call := w.svc.Changes.List(w.pageToken).
+			IncludeRemoved(true).
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			Fields(driveChangesListFields).
+			Context(w.ctx)
+		// The page token came from the shared drive's change log, so the
+		// listing has to read that same log.
+		if w.target.DriveId != "" {
+			call = call.DriveId(w.target.DriveId)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return fmt.Errorf("listing changes from token %s: %w", w.pageToken, err)
+		}
+		for _, ch := range resp.Changes {
+			if ch == nil || !w.inFolder(ch) {
+				continue
+			}
+			if err := w.emit(driveWatchEvent{State: state, driveChangeRecord: newDriveChangeRecord(ch)}); err != nil {
+				return err
+			}
+		}
+		switch {
+		case resp.NewStartPageToken != "":
+			w.pageToken = resp.NewStartPageToken
+			return nil
+		case resp.NextPageToken != "":
+			w.pageToken = resp.NextPageToken
+		default:
+			return errors.New("changes.list returned neither nextPageToken nor newStartPageToken")
+		}
+	}
+}
+
+// inFolder reports whether a change concerns the watched folder or one of
+// its direct children, keeping track of the children seen so far.
+func (w *driveWatcher) inFolder(ch *drive.Change) bool {
+	if ch.FileId == w.target.Id {
+		return true
+	}
+	if ch.File != nil && slices.Contains(ch.File.Parents, w.target.Id) {
+		w.children[ch.FileId] = !ch.Removed
+		return true
+	}
+	if w.children[ch.FileId] {
+		delete(w.children, ch.FileId)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func newDriveWatchTestService(t *testing.T, handler http.HandlerFunc) *drive.Service {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	return svc
+}
+
+func postDriveNotification(w *driveWatcher, channelID, token, state string) int {
+	req := httptest.NewRequest(http.MethodPost, "/drive-notify", nil)
+	req.Header.Set("X-Goog-Channel-ID", channelID)
+	req.Header.Set("X-Goog-Channel-Token", token)
+	req.Header.Set("X-Goog-Resource-State", state)
+	req.Header.Set("X-Goog-Changed", "content")
+	rec := httptest.NewRecorder()
+	w.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestDriveWatcherFolder(t *testing.T) {
+	var watchBody, stopBody map[string]any
+	svc := newDriveWatchTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/changes/watch":
+			if r.URL.Query().Get("pageToken") != "10" {
+				t.Errorf("watch from token %q", r.URL.Query().Get("pageToken"))
+			}
+			_ = json.NewDecoder(r.Body).Decode(&watchBody)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": watchBody["id"], "resourceId": "res1", "expiration": "1700000000000"})
+		case r.URL.Path == "/channels/stop":
+			_ = json.NewDecoder(r.Body).Decode(&stopBody)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/changes" && r.URL.Query().Get("pageToken") == "10":
+			_ = json.NewEncoder(w).Encode(map[string]any{"nextPageToken": "11", "changes": []map[string]any{
+				{"changeType": "file", "fileId": "a", "file": map[string]any{"id": "a", "name": "a.txt", "parents": []string{"folder"}}},
+				{"changeType": "file", "fileId": "elsewhere", "file": map[string]any{"id": "elsewhere", "parents": []string{"other"}}},
+			}})
+		case r.URL.Path == "/changes" && r.URL.Query().Get("pageToken") == "11":
+			_ = json.NewEncoder(w).Encode(map[string]any{"newStartPageToken": "12", "changes": []map[string]any{
+				{"changeType": "file", "fileId": "a", "removed": true},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	var events []driveWatchEvent
+	w := &driveWatcher{
+		ctx:       context.Background(),
+		svc:       svc,
+		target:    &drive.File{Id: "folder", MimeType: driveMimeFolder},
+		folder:    true,
+		token:     "secret",
+		channels:  map[string]string{},
+		children:  map[string]bool{},
+		pageToken: "10",
+		logf:      t.Logf,
+		emit: func(ev driveWatchEvent) error {
+			events = append(events, ev)
+			return nil
+		},
+	}
+	ch, err := w.open(context.Background(), "https://hooks.example.com/drive-notify", time.Hour)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if watchBody["type"] != "web_hook" || watchBody["token"] != "secret" || watchBody["address"] != "https://hooks.example.com/drive-notify" {
+		t.Fatalf("unexpected watch request: %v", watchBody)
+	}
+
+	if code := postDriveNotification(w, ch.Id, "wrong", "change"); code != http.StatusForbidden {
+		t.Fatalf("bad token: got %d", code)
+	}
+	if code := postDriveNotification(w, ch.Id, "secret", "sync"); code != http.StatusOK || len(events) != 0 {
+		t.Fatalf("sync: got %d, events %v", code, events)
+	}
+	if code := postDriveNotification(w, ch.Id, "secret", "change"); code != http.StatusOK {
+		t.Fatalf("change: got %d", code)
+	}
+	if len(events) != 2 || events[0].FileID != "a" || events[0].Name != "a.txt" || !events[1].Removed || w.pageToken != "12" {
+		t.Fatalf("expected child change then its removal, got %+v (token %s)", events, w.pageToken)
+	}
+
+	if err := w.stop(context.Background(), ch); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if stopBody["id"] != ch.Id || stopBody["resourceId"] != "res1" {
+		t.Fatalf("unexpected stop request: %v", stopBody)
+	}
+	if code := postDriveNotification(w, ch.Id, "secret", "change"); code != http.StatusForbidden {
+		t.Fatalf("stopped channel: got %d", code)
+	}
+}
+
+func TestDriveWatcherSharedDriveFolder(t *testing.T) {
+	var listDriveIDs []string
+	svc := newDriveWatchTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/changes" {
+			http.NotFound(w, r)
+			return
+		}
+		listDriveIDs = append(listDriveIDs, r.URL.Query().Get("driveId"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"newStartPageToken": "6", "changes": []map[string]any{
+			{"changeType": "file", "fileId": "a", "file": map[string]any{"id": "a", "name": "a.txt", "parents": []string{"folder"}}},
+		}})
+	})
+
+	var events []driveWatchEvent
+	w := &driveWatcher{
+		ctx:       context.Background(),
+		svc:       svc,
+		target:    &drive.File{Id: "folder", MimeType: driveMimeFolder, DriveId: "sd1"},
+		folder:    true,
+		children:  map[string]bool{},
+		pageToken: "5",
+		logf:      t.Logf,
+		emit: func(ev driveWatchEvent) error {
+			events = append(events, ev)
+			return nil
+		},
+	}
+	if err := w.handle("change", ""); err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+	if len(listDriveIDs) != 1 || listDriveIDs[0] != "sd1" {
+		t.Fatalf("expected changes.list scoped to the shared drive, got %v", listDriveIDs)
+	}
+	if len(events) != 1 || events[0].FileID != "a" || w.pageToken != "6" {
+		t.Fatalf("unexpected events %+v (token %s)", events, w.pageToken)
+	}
+}
+
+func TestDriveWatcherFile(t *testing.T) {
+	svc := newDriveWatchTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/files/f1/watch":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "ch1", "resourceId": "res1"})
+		case "/files/f1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "f1", "name": "report.pdf", "modifiedTime": "2026-01-02T03:04:05Z"})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	var events []driveWatchEvent
+	w := &driveWatcher{
+		ctx:      context.Background(),
+		svc:      svc,
+		target:   &drive.File{Id: "f1"},
+		token:    "secret",
+		channels: map[string]string{},
+		logf:     t.Logf,
+		emit: func(ev driveWatchEvent) error {
+			events = append(events, ev)
+			return nil
+		},
+	}
+	if _, err := w.open(context.Background(), "https://hooks.example.com/x", time.Hour); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if code := postDriveNotification(w, "ch1", "secret", "update"); code != http.StatusOK {
+		t.Fatalf("update: got %d", code)
+	}
+	if len(events) != 1 || events[0].State != "update" || events[0].Changed != "content" || events[0].Name != "report.pdf" {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+}
+
+func TestDriveWatchRenewIn(t *testing.T) {
+	ch := &drive.Channel{Expiration: time.Now().Add(30 * time.Minute).UnixMilli()}
+	if got := driveWatchRenewIn(ch, time.Hour, 5*time.Minute); got < 24*time.Minute || got > 25*time.Minute {
+		t.Fatalf("expected renewal ~25m before the granted expiry, got %s", got)
+	}
+	if got := driveWatchRenewIn(&drive.Channel{}, time.Hour, 5*time.Minute); got != 55*time.Minute {
+		t.Fatalf("expected ttl-based renewal, got %s", got)
+	}
+}
+
+func TestDriveWatchCmdValidation(t *testing.T) {
+	for _, args := range [][]string{
+		{"f1"},
+		{"f1", "--url", "http://example.com/hook"},
+		{"f1", "--url", "https://example.com/hook", "--tls-cert", "cert.pem"},
+		{"f1", "--url", "https://example.com/hook", "--ttl", "5m", "--renew-before", "10m"},
+		{"f1", "--url", "https://example.com/hook", "--listen", "8080"},
+	} {
+		err := runKong(t, &DriveWatchCmd{}, args, context.Background(), &RootFlags{Account: "a@b.com"})
+		if err == nil || ExitCode(stableExitCode(err)) != 2 {
+			t.Fatalf("%v: expected usage error, got %v", args, err)
+		}
+	}
+}
@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 // dryRunExit prints the intended operation and exits successfully (exit code 0).
@@ -7,7 +7,7 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 func TestDryRunExit_JSON_IgnoresResultsOnlyTransform(t *testing.T) {
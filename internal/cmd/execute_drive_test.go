@@ -14,8 +14,8 @@ import (
 	"google.golang.org/api/drive/v3"
 
 	"github.com/steipete/gogcli/internal/config"
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 func TestExecute_DriveGet_JSON(t *testing.T) {
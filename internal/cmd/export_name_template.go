@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+// driveExportNameFields is the metadata needed to render --name-template.
+const driveExportNameFields = "id, name, mimeType, headRevisionId, version, modifiedTime"
+
+var exportNameNow = time.Now
+
+// exportNameData is the value --name-template is executed against.
+type exportNameData struct {
+	Title    string
+	Name     string
+	ID       string
+	Revision string
+	Version  string
+	Format   string
+	Ext      string
+	MimeType string
+	Modified time.Time
+}
+
+func parseExportNameTemplate(raw string) (*template.Template, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil //nolint:nilnil // nil template means the flag was not set
+	}
+	tmpl, err := template.New("name").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"now": func(layout string) string { return exportNameNow().Format(layout) },
+		}).
+		Parse(raw)
+	if err != nil {
+		return nil, usagef("invalid --name-template: %v", err)
+	}
+	return tmpl, nil
+}
+
+func newExportNameData(meta *drive.File, revision, format string) exportNameData {
+	ext := exportNameExt(meta, format)
+	title := meta.Name
+	if !isGoogleWorkspaceMime(meta.MimeType) {
+		title = strings.TrimSuffix(meta.Name, filepath.Ext(meta.Name))
+	}
+	if revision == "" {
+		revision = meta.HeadRevisionId
+	}
+	data := exportNameData{
+		Title:    title,
+		Name:     meta.Name,
+		ID:       meta.Id,
+		Revision: revision,
+		Format:   strings.TrimPrefix(ext, "."),
+		Ext:      ext,
+		MimeType: meta.MimeType,
+	}
+	if meta.Version > 0 {
+		data.Version = strconv.FormatInt(meta.Version, 10)
+	}
+	if t, err := time.Parse(time.RFC3339, meta.ModifiedTime); err == nil {
+		data.Modified = t
+	}
+	return data
+}
+
+func isGoogleWorkspaceMime(mimeType string) bool {
+	return strings.HasPrefix(mimeType, "application/vnd.google-apps.")
+}
+
+func exportNameExt(meta *drive.File, format string) string {
+	if !isGoogleWorkspaceMime(meta.MimeType) {
+		return filepath.Ext(meta.Name)
+	}
+	mimeType := driveExportMimeType(meta.MimeType)
+	if f := strings.ToLower(strings.TrimSpace(format)); f != "" && f != formatAuto {
+		if m, err := driveExportMimeTypeForFormat(meta.MimeType, f); err == nil {
+			mimeType = m
+		}
+	}
+	return driveExportExtension(mimeType)
+}
+
+// resolveTemplatedDestPath renders tmpl into a single filename inside the
+// --out directory (or the default downloads dir when --out is empty).
+func resolveTemplatedDestPath(tmpl *template.Template, data exportNameData, outPathFlag string) (string, error) {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", usagef("render --name-template: %v", err)
+	}
+	name := strings.TrimSpace(b.String())
+	name = strings.NewReplacer("/", "_", "\\", "_").Replace(name)
+	if name == "" || name == "." || name == ".." {
+		return "", usagef("--name-template rendered an invalid filename %q", b.String())
+	}
+
+	if strings.TrimSpace(outPathFlag) == "" {
+		dir, err := config.EnsureDriveDownloadsDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, name), nil
+	}
+
+	dir, err := config.ExpandPath(outPathFlag)
+	if err != nil {
+		return "", err
+	}
+	if !isDirIntent(outPathFlag, dir) {
+		return "", usage("--name-template requires --out to be a directory (add a trailing slash to create it)")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("create output dir: %w", err)
+	}
+	return filepath.Join(dir, name), nil
+}
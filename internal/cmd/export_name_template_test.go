@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestResolveTemplatedDestPath(t *testing.T) {
+	origNow := exportNameNow
+	t.Cleanup(func() { exportNameNow = origNow })
+	exportNameNow = func() time.Time { return time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC) }
+
+	tmpl, err := parseExportNameTemplate(`{{.Title}}-{{.Revision}}-{{now "2006-01-02"}}{{.Ext}}`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	meta := &drive.File{Id: "id1", Name: "Q1/Plan", MimeType: "application/vnd.google-apps.spreadsheet", Version: 12}
+	data := newExportNameData(meta, "r9", "pdf")
+	if data.Ext != ".pdf" || data.Format != "pdf" || data.Version != "12" {
+		t.Fatalf("unexpected data: %#v", data)
+	}
+
+	dir := t.TempDir()
+	got, err := resolveTemplatedDestPath(tmpl, data, dir)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if want := filepath.Join(dir, "Q1_Plan-r9-2026-03-04.pdf"); got != want {
+		t.Fatalf("got %q want %q", got, want)
+	}
+
+	if _, err := resolveTemplatedDestPath(tmpl, data, filepath.Join(dir, "file.pdf")); err == nil {
+		t.Fatalf("expected error when --out is a file path")
+	}
+	if _, err := parseExportNameTemplate("{{.Nope"); err == nil {
+		t.Fatalf("expected parse error")
+	}
+	bad, _ := parseExportNameTemplate("{{.Missing}}")
+	if _, err := resolveTemplatedDestPath(bad, data, dir); err == nil {
+		t.Fatalf("expected unknown field error")
+	}
+}
+
+func TestExecute_SheetsExport_NameTemplate(t *testing.T) {
+	origNew := newDriveService
+	origExport := driveExportDownload
+	t.Cleanup(func() {
+		newDriveService = origNew
+		driveExportDownload = origExport
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || !strings.Contains(r.URL.Path, "/files/s1") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":           "s1",
+			"name":         "Budget",
+			"mimeType":     "application/vnd.google-apps.spreadsheet",
+			"version":      "42",
+			"modifiedTime": "2026-02-01T10:00:00Z",
+		})
+	}))
+	defer srv.Close()
+
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	driveExportDownload = func(context.Context, *drive.Service, string, string) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("xlsx"))}, nil
+	}
+
+	outDir := t.TempDir()
+	stdout := captureStdout(t, func() {
+		if execErr := Execute([]string{
+			"--json", "--account", "a@b.com",
+			"sheets", "export", "s1",
+			"--out", outDir,
+			"--name-template", `{{.Title}}-v{{.Version}}-{{.Modified.Format "20060102"}}{{.Ext}}`,
+		}); execErr != nil {
+			t.Fatalf("Execute: %v", execErr)
+		}
+	})
+
+	var parsed struct {
+		Path string `json:"path"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &parsed); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, stdout)
+	}
+	if want := filepath.Join(outDir, "Budget-v42-20260201.xlsx"); parsed.Path != want {
+		t.Fatalf("got %q want %q", parsed.Path, want)
+	}
+}
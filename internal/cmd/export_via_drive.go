@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
 
 	"github.com/steipete/gogcli/internal/config"
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type exportViaDriveOptions struct {
@@ -19,6 +22,17 @@ type exportViaDriveOptions struct {
 	KindLabel     string
 	DefaultFormat string
 	FormatHelp    string
+	// Revision exports a historical revision instead of the head version.
+	Revision string
+	// NameTemplate renders the output filename (see ExportNameTemplateFlag).
+	NameTemplate string
+	// NameSuffix is appended to the default filename (ignored with NameTemplate).
+	NameSuffix string
+	// DryRunExtra adds command-specific fields to the dry-run payload.
+	DryRunExtra map[string]any
+	// Prepare may swap in a different file to export, such as a trimmed
+	// temporary copy. cleanup runs once the download finishes or fails.
+	Prepare func(ctx context.Context, account string, svc *drive.Service, meta *drive.File) (exportMeta *drive.File, cleanup func(), err error)
 }
 
 const defaultExportFormat = "pdf"
@@ -53,6 +67,19 @@ func exportViaDrive(ctx context.Context, flags *RootFlags, opts exportViaDriveOp
 		format = defaultExportFormat
 	}
 
+	revision := strings.TrimSpace(opts.Revision)
+	var asOf time.Time
+	if revision == "" {
+		var err error
+		if asOf, err = driveAsOf(flags); err != nil {
+			return err
+		}
+	}
+	nameTmpl, err := parseExportNameTemplate(opts.NameTemplate)
+	if err != nil {
+		return err
+	}
+
 	op := strings.TrimSpace(opts.Op)
 	if op == "" {
 		op = "drive.export"
@@ -63,25 +90,34 @@ func exportViaDrive(ctx context.Context, flags *RootFlags, opts exportViaDriveOp
 			defaultDownloadsDir = dir
 		}
 	}
-	if err := dryRunExit(ctx, flags, op, map[string]any{
+	payload := map[string]any{
 		"id":                    id,
 		"out":                   outPathFlag,
 		"default_downloads_dir": defaultDownloadsDir,
 		"format":                format,
 		"expected_mime":         strings.TrimSpace(opts.ExpectedMime),
 		"kind":                  strings.TrimSpace(opts.KindLabel),
-	}); err != nil {
+		"revision":              revision,
+		"name_template":         strings.TrimSpace(opts.NameTemplate),
+	}
+	if !asOf.IsZero() {
+		payload["as_of"] = asOf.Format(time.RFC3339)
+	}
+	for k, v := range opts.DryRunExtra {
+		payload[k] = v
+	}
+	if err := dryRunExit(ctx, flags, op, payload); err != nil {
 		return err
 	}
 
-	_, svc, err := requireDriveService(ctx, flags)
+	account, svc, err := requireDriveService(ctx, flags)
 	if err != nil {
 		return err
 	}
 
 	meta, err := svc.Files.Get(id).
 		SupportsAllDrives(true).
-		Fields("id, name, mimeType").
+		Fields(driveExportNameFields).
 		Context(ctx).
 		Do()
 	if err != nil {
@@ -97,21 +133,73 @@ func exportViaDrive(ctx context.Context, flags *RootFlags, opts exportViaDriveOp
 		}
 		return fmt.Errorf("file is not a %s (mimeType=%q)", label, meta.MimeType)
 	}
+	var revisionModified string
+	if !asOf.IsZero() {
+		rev, revErr := resolveDriveRevisionAsOf(ctx, svc, meta, asOf)
+		if revErr != nil {
+			return revErr
+		}
+		if rev != nil {
+			revision, revisionModified = rev.Id, rev.ModifiedTime
+		}
+	}
 
-	destPath, err := resolveDriveDownloadDestPath(meta, outPathFlag)
+	var destPath string
+	if nameTmpl != nil {
+		destPath, err = resolveTemplatedDestPath(nameTmpl, newExportNameData(meta, revision, format), outPathFlag)
+	} else {
+		pathMeta := meta
+		if revision != "" || opts.NameSuffix != "" {
+			// Keep default filenames distinct from the full head export.
+			renamed := *meta
+			if revision != "" {
+				renamed.Name = fmt.Sprintf("%s_rev%s", renamed.Name, revision)
+			}
+			renamed.Name += opts.NameSuffix
+			pathMeta = &renamed
+		}
+		destPath, err = resolveDriveDownloadDestPath(pathMeta, outPathFlag)
+	}
 	if err != nil {
 		return err
 	}
 
-	downloadedPath, size, err := downloadDriveFile(ctx, svc, meta, destPath, format)
+	exportMeta := meta
+	if opts.Prepare != nil {
+		var cleanup func()
+		exportMeta, cleanup, err = opts.Prepare(ctx, account, svc, meta)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+	}
+
+	var (
+		downloadedPath string
+		size           int64
+	)
+	if revision != "" {
+		downloadedPath, size, err = downloadDriveRevision(ctx, account, svc, exportMeta, revision, destPath, format)
+	} else {
+		downloadedPath, size, err = downloadDriveFile(ctx, svc, exportMeta, destPath, format)
+	}
 	if err != nil {
 		return err
 	}
 
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"path": downloadedPath, "size": size})
+		out := map[string]any{"path": downloadedPath, "size": size}
+		if revisionModified != "" {
+			out["revision"] = revision
+			out["revisionModifiedTime"] = revisionModified
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, out)
 	}
 	u.Out().Printf("path\t%s", downloadedPath)
-	u.Out().Printf("size\t%s", formatDriveSize(size))
+	u.Out().Printf("size\t%s", humanSize(ctx, size))
+	if revisionModified != "" {
+		u.Out().Printf("revision\t%s", revision)
+		u.Out().Printf("modified\t%s", humanDateTime(ctx, revisionModified))
+	}
 	return nil
 }
@@ -11,3 +11,7 @@ type OutputPathRequiredFlag struct {
 type OutputDirFlag struct {
 	Dir string `name:"out-dir" aliases:"output-dir" help:"Directory to write attachments to (default: current directory)"`
 }
+
+type ExportNameTemplateFlag struct {
+	NameTemplate string `name:"name-template" help:"Go template for the output filename, written into --out as a directory. Fields: .Title .Name .ID .Revision .Version .Format .Ext .MimeType .Modified; func: now \"2006-01-02\""`
+}
@@ -5,23 +5,28 @@ import "github.com/steipete/gogcli/internal/googleapi"
 var newGmailService = googleapi.NewGmail
 
 type GmailCmd struct {
-	Search     GmailSearchCmd     `cmd:"" name:"search" aliases:"find,query,ls,list" group:"Read" help:"Search threads using Gmail query syntax"`
-	Messages   GmailMessagesCmd   `cmd:"" name:"messages" aliases:"message,msg,msgs" group:"Read" help:"Message operations"`
-	Thread     GmailThreadCmd     `cmd:"" name:"thread" aliases:"threads,read" group:"Organize" help:"Thread operations (get, modify)"`
-	Get        GmailGetCmd        `cmd:"" name:"get" aliases:"info,show" group:"Read" help:"Get a message (full|metadata|raw)"`
-	Attachment GmailAttachmentCmd `cmd:"" name:"attachment" group:"Read" help:"Download a single attachment"`
-	URL        GmailURLCmd        `cmd:"" name:"url" group:"Read" help:"Print Gmail web URLs for threads"`
-	History    GmailHistoryCmd    `cmd:"" name:"history" group:"Read" help:"Gmail history"`
-
-	Labels  GmailLabelsCmd   `cmd:"" name:"labels" aliases:"label" group:"Organize" help:"Label operations"`
-	Batch   GmailBatchCmd    `cmd:"" name:"batch" group:"Organize" help:"Batch operations"`
-	Archive GmailArchiveCmd  `cmd:"" name:"archive" group:"Organize" help:"Archive messages (remove from inbox)"`
-	Read    GmailReadCmd     `cmd:"" name:"mark-read" aliases:"read-messages" group:"Organize" help:"Mark messages as read"`
-	Unread  GmailUnreadCmd   `cmd:"" name:"unread" aliases:"mark-unread" group:"Organize" help:"Mark messages as unread"`
-	Trash   GmailTrashMsgCmd `cmd:"" name:"trash" group:"Organize" help:"Move messages to trash"`
+	Search      GmailSearchCmd      `cmd:"" name:"search" aliases:"find,query,ls,list" group:"Read" help:"Search threads using Gmail query syntax"`
+	Messages    GmailMessagesCmd    `cmd:"" name:"messages" aliases:"message,msg,msgs" group:"Read" help:"Message operations"`
+	Thread      GmailThreadCmd      `cmd:"" name:"thread" aliases:"threads,read" group:"Organize" help:"Thread operations (get, modify)"`
+	Get         GmailGetCmd         `cmd:"" name:"get" aliases:"info,show" group:"Read" help:"Get a message (full|metadata|raw)"`
+	Attachment  GmailAttachmentCmd  `cmd:"" name:"attachment" group:"Read" help:"Download a single attachment"`
+	Attachments GmailAttachmentsCmd `cmd:"" name:"attachments" group:"Read" help:"Attachment operations (cat)"`
+	URL         GmailURLCmd         `cmd:"" name:"url" group:"Read" help:"Print Gmail web URLs for threads"`
+	History     GmailHistoryCmd     `cmd:"" name:"history" group:"Read" help:"Gmail history"`
+	Export      GmailExportCmd      `cmd:"" name:"export" group:"Read" help:"Export messages as .eml files (--incremental for cheap repeat runs)"`
+
+	Labels   GmailLabelsCmd   `cmd:"" name:"labels" aliases:"label" group:"Organize" help:"Label operations"`
+	Batch    GmailBatchCmd    `cmd:"" name:"batch" group:"Organize" help:"Batch operations"`
+	Archive  GmailArchiveCmd  `cmd:"" name:"archive" group:"Organize" help:"Archive messages (remove from inbox)"`
+	Read     GmailReadCmd     `cmd:"" name:"mark-read" aliases:"read-messages" group:"Organize" help:"Mark messages as read"`
+	Unread   GmailUnreadCmd   `cmd:"" name:"unread" aliases:"mark-unread" group:"Organize" help:"Mark messages as unread"`
+	Trash    GmailTrashMsgCmd `cmd:"" name:"trash" group:"Organize" help:"Move messages to trash"`
+	Policy   GmailPolicyCmd   `cmd:"" name:"policy" group:"Organize" help:"Retention policies (run rules from a YAML file)"`
+	Estimate GmailEstimateCmd `cmd:"" name:"estimate" group:"Organize" help:"Estimate messages, space, and top attachments a cleanup would affect"`
 
 	Send      GmailSendCmd      `cmd:"" name:"send" group:"Write" help:"Send an email"`
 	AutoReply GmailAutoReplyCmd `cmd:"" name:"autoreply" group:"Write" help:"Reply once to matching messages"`
+	Responder GmailResponderCmd `cmd:"" name:"responder" group:"Write" help:"Templated auto-acks from a rules file, rate-limited per sender"`
 	Track     GmailTrackCmd     `cmd:"" name:"track" group:"Write" help:"Email open tracking"`
 	Drafts    GmailDraftsCmd    `cmd:"" name:"drafts" aliases:"draft" group:"Write" help:"Draft operations"`
 
@@ -8,8 +8,8 @@ import (
 
 	"google.golang.org/api/gmail/v1"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 // GmailArchiveCmd archives messages (removes INBOX label).
@@ -114,6 +114,16 @@ func gmailBulkLabelOp(ctx context.Context, flags *RootFlags, messageIDs []string
 		return nil
 	}
 
+	// Trashing goes through the bulk guard: large runs need the count typed
+	// back, and every run is journaled so `gog journal undo` can restore it.
+	var guard *bulkDestructiveOp
+	if gmailLabelsInclude(addLabels, "TRASH") {
+		guard = &bulkDestructiveOp{Op: dryRunOp, Action: "trash", Noun: "message", Items: bulkItemsFromIDs(ids), Undo: bulkUndoGmailUntrash}
+		if err := confirmBulkDestructive(ctx, flags, guard, nil); err != nil {
+			return err
+		}
+	}
+
 	// Resolve label names to IDs
 	idMap, err := fetchLabelNameToID(svc)
 	if err != nil {
@@ -124,6 +134,7 @@ func gmailBulkLabelOp(ctx context.Context, flags *RootFlags, messageIDs []string
 
 	// Batch modify in chunks of 1000 (API limit)
 	total := 0
+	pacer := newBulkPacer()
 	for i := 0; i < len(ids); i += 1000 {
 		end := i + 1000
 		if end > len(ids) {
@@ -141,11 +152,21 @@ func gmailBulkLabelOp(ctx context.Context, flags *RootFlags, messageIDs []string
 			req.RemoveLabelIds = removeIDs
 		}
 
-		if err := svc.Users.Messages.BatchModify("me", req).Do(); err != nil {
+		err := pacer.wait(ctx)
+		if err == nil {
+			err = svc.Users.Messages.BatchModify("me", req).Do()
+		}
+		if err != nil {
+			if guard != nil {
+				journalBulkDestructive(ctx, account, guard, guard.Items[:total])
+			}
 			return fmt.Errorf("batch modify failed at offset %d: %w", i, err)
 		}
 		total += len(chunk)
 	}
+	if guard != nil {
+		journalBulkDestructive(ctx, account, guard, guard.Items)
+	}
 
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GmailAttachmentsCmd groups attachment operations that do not write files.
+type GmailAttachmentsCmd struct {
+	Cat GmailAttachmentsCatCmd `cmd:"" name:"cat" help:"Write decoded attachment bytes to stdout"`
+}
+
+type GmailAttachmentsCatCmd struct {
+	MessageID    string `arg:"" name:"messageId" help:"Message ID"`
+	AttachmentID string `arg:"" name:"attachmentId" help:"Attachment ID"`
+}
+
+func (c *GmailAttachmentsCatCmd) Run(ctx context.Context, flags *RootFlags) error {
+	messageID := normalizeGmailMessageID(c.MessageID)
+	attachmentID := strings.TrimSpace(c.AttachmentID)
+	if messageID == "" || attachmentID == "" {
+		return usage("messageId/attachmentId required")
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	body, err := svc.Users.Messages.Attachments.Get("me", messageID, attachmentID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	if body == nil || body.Data == "" {
+		return errors.New("empty attachment data")
+	}
+
+	// Decode while copying so large attachments are not held twice in memory.
+	// Gmail can return padded base64url; stripping padding accepts both.
+	dec := base64.NewDecoder(base64.RawURLEncoding, strings.NewReader(strings.TrimRight(body.Data, "=")))
+	// Binary bytes skip --redact's text filter.
+	if _, err := io.Copy(rawStdout(), dec); err != nil {
+		return fmt.Errorf("write attachment: %w", err)
+	}
+	return nil
+}
@@ -15,7 +15,7 @@ import (
 	"google.golang.org/api/gmail/v1"
 	"google.golang.org/api/option"
 
-	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 func TestDownloadAttachmentToPath_MissingOutPath(t *testing.T) {
@@ -296,3 +296,33 @@ func httptestServerForAttachment(t *testing.T, data string) *httptest.Server {
 		})
 	}))
 }
+
+func TestGmailAttachmentsCat_WritesDecodedBytes(t *testing.T) {
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+
+	payload := []byte{0x89, 'P', 'N', 'G', 0x00, 0xff, '\n'}
+	for _, enc := range []*base64.Encoding{base64.RawURLEncoding, base64.URLEncoding} {
+		srv := httptestServerForAttachment(t, enc.EncodeToString(payload))
+		gsvc, err := gmail.NewService(context.Background(),
+			option.WithoutAuthentication(),
+			option.WithHTTPClient(srv.Client()),
+			option.WithEndpoint(srv.URL+"/"),
+		)
+		if err != nil {
+			t.Fatalf("NewService: %v", err)
+		}
+		newGmailService = func(context.Context, string) (*gmail.Service, error) { return gsvc, nil }
+
+		out := captureStdout(t, func() {
+			if err := Execute([]string{"--account", "a@b.com", "gmail", "attachments", "cat", "m1", "a1"}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+		srv.Close()
+
+		if out != string(payload) {
+			t.Fatalf("unexpected bytes: %q", out)
+		}
+	}
+}
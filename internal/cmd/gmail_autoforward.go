@@ -8,8 +8,8 @@ import (
 	"github.com/alecthomas/kong"
 	"google.golang.org/api/gmail/v1"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type GmailAutoForwardCmd struct {
@@ -8,6 +8,8 @@ import (
 	"net/mail"
 	"strings"
 	"testing"
+
+	"github.com/steipete/gogcli/pkg/gmailmime"
 )
 
 func TestRunGmailAutoReply_RepliesAndArchives(t *testing.T) {
@@ -209,7 +211,7 @@ func TestAutoReplyRecipients(t *testing.T) {
 }
 
 func TestSendMessageOptionsHeadersReachRFC822(t *testing.T) {
-	raw, err := buildRFC822(mailOptions{
+	raw, err := gmailmime.Build(gmailmime.Message{
 		From:              "bot@example.com",
 		To:                []string{"user@example.com"},
 		Subject:           "Hi",
@@ -217,7 +219,7 @@ func TestSendMessageOptionsHeadersReachRFC822(t *testing.T) {
 		AdditionalHeaders: map[string]string{"X-Test": "1"},
 	}, nil)
 	if err != nil {
-		t.Fatalf("buildRFC822: %v", err)
+		t.Fatalf("gmailmime.Build: %v", err)
 	}
 	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
 	if err != nil {
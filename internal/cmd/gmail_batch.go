@@ -7,8 +7,8 @@ import (
 
 	"google.golang.org/api/gmail/v1"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type GmailBatchCmd struct {
@@ -34,7 +34,8 @@ func (c *GmailBatchDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return usage("missing messageId")
 	}
 
-	if confirmErr := confirmDestructive(ctx, flags, "permanently delete gmail messages"); confirmErr != nil {
+	guard := &bulkDestructiveOp{Op: "gmail.batch.delete", Action: "permanently delete", Noun: "message", Items: bulkItemsFromIDs(ids), Confirm: true}
+	if confirmErr := confirmBulkDestructive(ctx, flags, guard, map[string]any{"message_ids": ids}); confirmErr != nil {
 		return confirmErr
 	}
 
@@ -54,6 +55,7 @@ func (c *GmailBatchDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	journalBulkDestructive(ctx, account, guard, guard.Items)
 
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
@@ -70,6 +72,7 @@ type GmailBatchModifyCmd struct {
 	MessageIDs []string `arg:"" name:"messageId" help:"Message IDs"`
 	Add        string   `name:"add" help:"Labels to add (comma-separated, name or ID)"`
 	Remove     string   `name:"remove" help:"Labels to remove (comma-separated, name or ID)"`
+	Thread     *bool    `name:"thread" negatable:"" help:"Apply the change to each message's whole thread (default from config gmail_label_scope)"`
 }
 
 func (c *GmailBatchModifyCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -91,10 +94,12 @@ func (c *GmailBatchModifyCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return errors.New("must specify --add and/or --remove")
 	}
 
+	threadScope := gmailThreadScope(c.Thread)
 	if err := dryRunExit(ctx, flags, "gmail.batch.modify", map[string]any{
-		"message_ids": ids,
-		"add":         addLabels,
-		"remove":      removeLabels,
+		"message_ids":  ids,
+		"add":          addLabels,
+		"remove":       removeLabels,
+		"thread_scope": threadScope,
 	}); err != nil {
 		return err
 	}
@@ -109,10 +114,58 @@ func (c *GmailBatchModifyCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	addIDs, removeIDs, err := resolveModifyLabelIDs(svc, addLabels, removeLabels)
+	addIDs, removeIDs, err := resolveModifyLabelIDs(ctx, svc, addLabels, removeLabels)
 	if err != nil {
 		return err
 	}
+	var threadIDs []string
+	if threadScope {
+		threadIDs, err = gmailThreadsOfMessages(ctx, svc, ids)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Adding TRASH is a bulk trash, guarded and journaled like gmail trash.
+	// Thread scope trashes whole threads, so every message in them counts.
+	var guard *bulkDestructiveOp
+	if gmailLabelsInclude(addIDs, "TRASH") {
+		trashIDs := ids
+		if threadScope {
+			trashIDs, err = gmailThreadMessageIDs(ctx, svc, threadIDs)
+			if err != nil {
+				return err
+			}
+		}
+		guard = &bulkDestructiveOp{Op: "gmail.batch.modify", Action: "trash", Noun: "message", Items: bulkItemsFromIDs(trashIDs), Undo: bulkUndoGmailUntrash}
+		if err := confirmBulkDestructive(ctx, flags, guard, nil); err != nil {
+			return err
+		}
+	}
+
+	if threadScope {
+		var pacer *bulkPacer
+		if guard != nil {
+			pacer = newBulkPacer()
+		}
+		if err := modifyGmailThreads(ctx, svc, threadIDs, addIDs, removeIDs, pacer); err != nil {
+			return err
+		}
+		if guard != nil {
+			journalBulkDestructive(ctx, account, guard, guard.Items)
+		}
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+				"modified":      ids,
+				"count":         len(ids),
+				"threads":       threadIDs,
+				"addedLabels":   addIDs,
+				"removedLabels": removeIDs,
+			})
+		}
+		u.Out().Printf("Modified %d threads (%d messages)", len(threadIDs), len(ids))
+		return nil
+	}
 
 	err = svc.Users.Messages.BatchModify("me", &gmail.BatchModifyMessagesRequest{
 		Ids:            ids,
@@ -122,6 +175,9 @@ func (c *GmailBatchModifyCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	if guard != nil {
+		journalBulkDestructive(ctx, account, guard, guard.Items)
+	}
 
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
@@ -8,6 +8,7 @@ import (
 	"google.golang.org/api/gmail/v1"
 
 	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/pkg/gmailmime"
 )
 
 type composeFromResult struct {
@@ -27,10 +28,10 @@ func expandComposeAttachmentPaths(paths []string) ([]string, error) {
 	return expanded, nil
 }
 
-func attachmentsFromPaths(paths []string) []mailAttachment {
-	attachments := make([]mailAttachment, 0, len(paths))
+func attachmentsFromPaths(paths []string) []gmailmime.Attachment {
+	attachments := make([]gmailmime.Attachment, 0, len(paths))
 	for _, path := range paths {
-		attachments = append(attachments, mailAttachment{Path: path})
+		attachments = append(attachments, gmailmime.Attachment{Path: path})
 	}
 	return attachments
 }
@@ -10,8 +10,9 @@ import (
 	"google.golang.org/api/gmail/v1"
 
 	"github.com/steipete/gogcli/internal/config"
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/gmailmime"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type GmailDraftsCmd struct {
@@ -21,6 +22,7 @@ type GmailDraftsCmd struct {
 	Send   GmailDraftsSendCmd   `cmd:"" name:"send" aliases:"post" help:"Send a draft"`
 	Create GmailDraftsCreateCmd `cmd:"" name:"create" aliases:"add,new" help:"Create a draft"`
 	Update GmailDraftsUpdateCmd `cmd:"" name:"update" aliases:"edit,set" help:"Update a draft"`
+	Bulk   GmailDraftsBulkCmd   `cmd:"" name:"bulk" help:"Create one personalized draft per CSV row from a template (nothing is sent)"`
 }
 
 type GmailDraftsListCmd struct {
@@ -303,7 +305,7 @@ func buildDraftMessage(ctx context.Context, svc *gmail.Service, account string,
 	threadID := info.ThreadID
 	atts := attachmentsFromPaths(input.Attach)
 
-	raw, err := buildRFC822(mailOptions{
+	raw, err := gmailmime.Build(gmailmime.Message{
 		From:        from.header,
 		To:          splitCSV(input.To),
 		Cc:          splitCSV(input.Cc),
@@ -315,7 +317,7 @@ func buildDraftMessage(ctx context.Context, svc *gmail.Service, account string,
 		InReplyTo:   inReplyTo,
 		References:  references,
 		Attachments: atts,
-	}, &rfc822Config{allowMissingTo: true})
+	}, &gmailmime.BuildOptions{AllowMissingTo: true})
 	if err != nil {
 		return nil, "", err
 	}
@@ -0,0 +1,325 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/gmailmime"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// GmailDraftsBulkCmd creates one personalized draft per CSV row. Nothing is
+// sent; the drafts are left for review in Gmail.
+type GmailDraftsBulkCmd struct {
+	Template     string   `name:"template" required:"" help:"Body template file with {{column}} placeholders; may start with Subject:/Cc:/Bcc:/Reply-To: header lines followed by a blank line" type:"existingfile"`
+	HTMLTemplate string   `name:"html-template" help:"Optional HTML body template file with {{column}} placeholders" type:"existingfile"`
+	CSV          string   `name:"csv" required:"" help:"CSV file with a header row; one draft is created per data row ('-' for stdin)"`
+	ToColumn     string   `name:"to-column" help:"CSV column holding the recipient address" default:"email"`
+	Subject      string   `name:"subject" help:"Subject template (overrides a Subject: line in the template)"`
+	Cc           string   `name:"cc" help:"CC recipients for every draft (comma-separated, placeholders allowed)"`
+	Bcc          string   `name:"bcc" help:"BCC recipients for every draft (comma-separated, placeholders allowed)"`
+	ReplyTo      string   `name:"reply-to" help:"Reply-To header address"`
+	Attach       []string `name:"attach" help:"Attachment file path added to every draft (repeatable)"`
+	From         string   `name:"from" help:"Send from this email address (must be a verified send-as alias)"`
+}
+
+// draftTemplate is a parsed --template file.
+type draftTemplate struct {
+	Subject  string
+	Cc       string
+	Bcc      string
+	ReplyTo  string
+	Body     string
+	BodyHTML string
+}
+
+// bulkDraft is one rendered row.
+type bulkDraft struct {
+	Row      int    `json:"row"`
+	To       string `json:"to"`
+	Subject  string `json:"subject"`
+	DraftID  string `json:"draftId,omitempty"`
+	ThreadID string `json:"threadId,omitempty"`
+
+	cc, bcc, replyTo, body, bodyHTML string
+}
+
+var draftPlaceholderPattern = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+
+func (c *GmailDraftsBulkCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	tmpl, err := c.loadTemplate()
+	if err != nil {
+		return err
+	}
+	header, rows, err := readDraftsCSV(c.CSV)
+	if err != nil {
+		return err
+	}
+	drafts, err := renderBulkDrafts(tmpl, header, rows, c.ToColumn)
+	if err != nil {
+		return err
+	}
+
+	attachPaths, err := expandComposeAttachmentPaths(c.Attach)
+	if err != nil {
+		return err
+	}
+
+	preview := make([]map[string]any, 0, len(drafts))
+	for _, d := range drafts {
+		preview = append(preview, map[string]any{"row": d.Row, "to": d.To, "subject": d.Subject})
+	}
+	if dryRunErr := dryRunExit(ctx, flags, "gmail.drafts.bulk", map[string]any{
+		"count":       len(drafts),
+		"drafts":      preview,
+		"attachments": attachPaths,
+		"from":        strings.TrimSpace(c.From),
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	account, svc, err := requireGmailService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	sendAsList, sendAsListErr := listSendAs(ctx, svc)
+	from, err := resolveComposeFrom(ctx, svc, account, c.From, sendAsList, sendAsListErr)
+	if err != nil {
+		return err
+	}
+
+	for i := range drafts {
+		d := &drafts[i]
+		raw, buildErr := gmailmime.Build(gmailmime.Message{
+			From:        from.header,
+			To:          splitCSV(d.To),
+			Cc:          splitCSV(d.cc),
+			Bcc:         splitCSV(d.bcc),
+			ReplyTo:     d.replyTo,
+			Subject:     d.Subject,
+			Body:        d.body,
+			BodyHTML:    d.bodyHTML,
+			Attachments: attachmentsFromPaths(attachPaths),
+		}, nil)
+		if buildErr != nil {
+			return fmt.Errorf("row %d: %w", d.Row, buildErr)
+		}
+		draft, createErr := svc.Users.Drafts.Create("me", &gmail.Draft{
+			Message: &gmail.Message{Raw: base64.RawURLEncoding.EncodeToString(raw)},
+		}).Context(ctx).Do()
+		if createErr != nil {
+			if i > 0 {
+				u.Err().Printf("Created %d of %d drafts before the failure", i, len(drafts))
+			}
+			return fmt.Errorf("row %d (%s): %w", d.Row, d.To, createErr)
+		}
+		d.DraftID = draft.Id
+		if draft.Message != nil {
+			d.ThreadID = draft.Message.ThreadId
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"count":  len(drafts),
+			"drafts": drafts,
+		})
+	}
+
+	w, flush := tableWriter(ctx)
+	fmt.Fprintln(w, "ROW\tTO\tDRAFT_ID\tSUBJECT")
+	for _, d := range drafts {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", d.Row, d.To, d.DraftID, sanitizeTab(d.Subject))
+	}
+	flush()
+	u.Err().Printf("Created %d drafts (not sent)", len(drafts))
+	return nil
+}
+
+func (c *GmailDraftsBulkCmd) loadTemplate() (draftTemplate, error) {
+	data, err := os.ReadFile(c.Template)
+	if err != nil {
+		return draftTemplate{}, fmt.Errorf("read template: %w", err)
+	}
+	tmpl := parseDraftTemplate(string(data))
+	if c.HTMLTemplate != "" {
+		html, readErr := os.ReadFile(c.HTMLTemplate)
+		if readErr != nil {
+			return draftTemplate{}, fmt.Errorf("read html template: %w", readErr)
+		}
+		tmpl.BodyHTML = string(html)
+	}
+	if s := strings.TrimSpace(c.Subject); s != "" {
+		tmpl.Subject = s
+	}
+	if s := strings.TrimSpace(c.Cc); s != "" {
+		tmpl.Cc = s
+	}
+	if s := strings.TrimSpace(c.Bcc); s != "" {
+		tmpl.Bcc = s
+	}
+	if s := strings.TrimSpace(c.ReplyTo); s != "" {
+		tmpl.ReplyTo = s
+	}
+	if strings.TrimSpace(tmpl.Subject) == "" {
+		return draftTemplate{}, usage("required: --subject or a Subject: line in the template")
+	}
+	if strings.TrimSpace(tmpl.Body) == "" && strings.TrimSpace(tmpl.BodyHTML) == "" {
+		return draftTemplate{}, usage("template body is empty")
+	}
+	return tmpl, nil
+}
+
+// parseDraftTemplate splits leading "Header: value" lines from the body.
+// Header parsing only applies when the first line is a known header.
+func parseDraftTemplate(text string) draftTemplate {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	var tmpl draftTemplate
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" && i > 0 {
+			tmpl.Body = strings.Join(lines[i+1:], "\n")
+			return tmpl
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			break
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "subject":
+			tmpl.Subject = value
+		case "cc":
+			tmpl.Cc = value
+		case "bcc":
+			tmpl.Bcc = value
+		case "reply-to":
+			tmpl.ReplyTo = value
+		default:
+			return draftTemplate{Body: text}
+		}
+	}
+	return draftTemplate{Body: text}
+}
+
+func readDraftsCSV(path string) ([]string, [][]string, error) {
+	var r io.Reader
+	if strings.TrimSpace(path) == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(path) //nolint:gosec // user-provided path
+		if err != nil {
+			return nil, nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read csv: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, nil, errors.New("csv needs a header row and at least one data row")
+	}
+	header := records[0]
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	return header, records[1:], nil
+}
+
+// renderBulkDrafts fills the template for every row. All rows are validated
+// before anything is created so a typo does not leave a partial batch.
+func renderBulkDrafts(tmpl draftTemplate, header []string, rows [][]string, toColumn string) ([]bulkDraft, error) {
+	toColumn = strings.TrimSpace(toColumn)
+	toIdx := -1
+	for i, name := range header {
+		if strings.EqualFold(name, toColumn) {
+			toIdx = i
+			break
+		}
+	}
+	if toIdx < 0 {
+		return nil, usagef("csv has no %q column (use --to-column)", toColumn)
+	}
+
+	drafts := make([]bulkDraft, 0, len(rows))
+	for i, row := range rows {
+		rowNum := i + 2 // 1-based, after the header
+		if isBlankCSVRow(row) {
+			continue
+		}
+		values := make(map[string]string, len(header))
+		for j, name := range header {
+			if j < len(row) {
+				values[strings.ToLower(name)] = row[j]
+			} else {
+				values[strings.ToLower(name)] = ""
+			}
+		}
+
+		var missing []string
+		fill := func(s string) string {
+			return draftPlaceholderPattern.ReplaceAllStringFunc(s, func(m string) string {
+				key := strings.ToLower(draftPlaceholderPattern.FindStringSubmatch(m)[1])
+				v, ok := values[key]
+				if !ok {
+					missing = append(missing, key)
+					return m
+				}
+				return v
+			})
+		}
+
+		d := bulkDraft{
+			Row:      rowNum,
+			Subject:  fill(tmpl.Subject),
+			cc:       fill(tmpl.Cc),
+			bcc:      fill(tmpl.Bcc),
+			replyTo:  fill(tmpl.ReplyTo),
+			body:     fill(tmpl.Body),
+			bodyHTML: fill(tmpl.BodyHTML),
+		}
+		if toIdx < len(row) {
+			d.To = strings.TrimSpace(row[toIdx])
+		}
+		if len(missing) > 0 {
+			return nil, usagef("row %d: unknown placeholder(s) %s", rowNum, strings.Join(missing, ", "))
+		}
+		if d.To == "" {
+			return nil, usagef("row %d: empty %s", rowNum, toColumn)
+		}
+		drafts = append(drafts, d)
+	}
+	if len(drafts) == 0 {
+		return nil, errors.New("csv has no data rows")
+	}
+	return drafts, nil
+}
+
+func isBlankCSVRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
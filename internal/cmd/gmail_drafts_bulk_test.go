@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestParseDraftTemplate(t *testing.T) {
+	tmpl := parseDraftTemplate("Subject: Hi {{name}}\r\nCc: boss@example.com\r\n\r\nHello {{name}},\r\nSee you.\r\n")
+	if tmpl.Subject != "Hi {{name}}" || tmpl.Cc != "boss@example.com" {
+		t.Fatalf("unexpected headers: %#v", tmpl)
+	}
+	if tmpl.Body != "Hello {{name}},\nSee you.\n" {
+		t.Fatalf("unexpected body: %q", tmpl.Body)
+	}
+
+	plain := parseDraftTemplate("Note: this is the body\n\nmore")
+	if plain.Subject != "" || plain.Body != "Note: this is the body\n\nmore" {
+		t.Fatalf("expected the whole text as body, got %#v", plain)
+	}
+}
+
+func TestRenderBulkDrafts(t *testing.T) {
+	tmpl := draftTemplate{Subject: "Hi {{ Name }}", Body: "Dear {{name}}, your code is {{code}}."}
+	header := []string{"Email", "Name", "code"}
+
+	drafts, err := renderBulkDrafts(tmpl, header, [][]string{
+		{"jane@example.com", "Jane", "A1"},
+		{"", "", ""},
+		{"bob@example.com", "Bob"},
+	}, "email")
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if len(drafts) != 2 {
+		t.Fatalf("expected blank row to be skipped, got %d drafts", len(drafts))
+	}
+	if drafts[0].To != "jane@example.com" || drafts[0].Subject != "Hi Jane" || drafts[0].body != "Dear Jane, your code is A1." {
+		t.Fatalf("unexpected first draft: %#v", drafts[0])
+	}
+	if drafts[1].Row != 4 || drafts[1].body != "Dear Bob, your code is ." {
+		t.Fatalf("unexpected second draft: %#v", drafts[1])
+	}
+
+	_, err = renderBulkDrafts(draftTemplate{Subject: "x", Body: "{{nope}}"}, header, [][]string{{"a@b.com", "A", "1"}}, "email")
+	if err == nil || !strings.Contains(err.Error(), "row 2: unknown placeholder(s) nope") {
+		t.Fatalf("expected unknown placeholder error, got %v", err)
+	}
+	_, err = renderBulkDrafts(tmpl, header, [][]string{{"", "A", "1"}}, "email")
+	if err == nil || !strings.Contains(err.Error(), "empty email") {
+		t.Fatalf("expected empty recipient error, got %v", err)
+	}
+	_, err = renderBulkDrafts(tmpl, header, [][]string{{"a@b.com"}}, "to")
+	if err == nil || !strings.Contains(err.Error(), `no "to" column`) {
+		t.Fatalf("expected missing column error, got %v", err)
+	}
+}
+
+func TestGmailDraftsBulkCmd_JSON(t *testing.T) {
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+
+	var raws []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/settings/sendAs"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"sendAs": []any{}})
+		case strings.HasSuffix(r.URL.Path, "/users/me/drafts") && r.Method == http.MethodPost:
+			var d gmail.Draft
+			_ = json.NewDecoder(r.Body).Decode(&d)
+			raw, _ := base64.RawURLEncoding.DecodeString(d.Message.Raw)
+			raws = append(raws, string(raw))
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":      "d" + string(rune('0'+len(raws))),
+				"message": map[string]any{"id": "m", "threadId": "t"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	dir := t.TempDir()
+	tmplPath := filepath.Join(dir, "t.md")
+	csvPath := filepath.Join(dir, "recipients.csv")
+	if err := os.WriteFile(tmplPath, []byte("Subject: Welcome, {{name}}\n\nHi {{name}}!\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.WriteFile(csvPath, []byte("email,name\njane@example.com,Jane\nbob@example.com,Bob\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	flags := &RootFlags{Account: "me@example.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+	out := captureStdout(t, func() {
+		if err := runKong(t, &GmailDraftsBulkCmd{}, []string{"--template", tmplPath, "--csv", csvPath}, ctx, flags); err != nil {
+			t.Fatalf("bulk: %v", err)
+		}
+	})
+
+	if len(raws) != 2 {
+		t.Fatalf("expected 2 drafts, got %d", len(raws))
+	}
+	if !strings.Contains(raws[0], "To: jane@example.com") || !strings.Contains(raws[0], "Subject: Welcome, Jane") || !strings.Contains(raws[0], "Hi Jane!") {
+		t.Fatalf("unexpected first draft:\n%s", raws[0])
+	}
+	if !strings.Contains(raws[1], "To: bob@example.com") {
+		t.Fatalf("unexpected second draft:\n%s", raws[1])
+	}
+
+	var result struct {
+		Count  int         `json:"count"`
+		Drafts []bulkDraft `json:"drafts"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if result.Count != 2 || result.Drafts[1].DraftID != "d2" || result.Drafts[1].Row != 3 {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+}
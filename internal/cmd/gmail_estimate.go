@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// GmailEstimateCmd sizes up a prospective cleanup rule without touching
+// any message: how many messages it matches, how much space they take, and
+// which attachments dominate.
+type GmailEstimateCmd struct {
+	Labels    []string `name:"label" help:"Only messages with this label (can be repeated; all must match)"`
+	OlderThan string   `name:"older-than" help:"Only messages older than this (e.g. 30d, 6m, 2y)"`
+	Query     string   `name:"query" help:"Additional Gmail search terms"`
+	Max       int64    `name:"max" aliases:"limit" help:"Stop after this many messages (reported as truncated)" default:"5000"`
+	Top       int      `name:"top" help:"Largest attachments to list" default:"10"`
+}
+
+const gmailEstimatePartFields = "filename,mimeType,body(size,attachmentId)"
+
+// gmailEstimateFields selects part sizes and filenames four levels deep
+// without pulling body data; deeper parts come back whole.
+const gmailEstimateFields = "id,threadId,sizeEstimate,internalDate,payload(headers," + gmailEstimatePartFields + "," +
+	"parts(" + gmailEstimatePartFields + ",parts(" + gmailEstimatePartFields + ",parts(" + gmailEstimatePartFields + ",parts))))"
+
+type gmailEstimateAttachment struct {
+	MessageID string `json:"messageId"`
+	ThreadID  string `json:"threadId"`
+	Filename  string `json:"filename"`
+	MimeType  string `json:"mimeType,omitempty"`
+	Size      int64  `json:"size"`
+	Subject   string `json:"subject,omitempty"`
+	From      string `json:"from,omitempty"`
+	Date      string `json:"date,omitempty"`
+}
+
+type gmailEstimate struct {
+	Query           string                    `json:"query"`
+	Messages        int                       `json:"messages"`
+	Truncated       bool                      `json:"truncated"`
+	TotalBytes      int64                     `json:"totalBytes"`
+	AttachmentBytes int64                     `json:"attachmentBytes"`
+	Attachments     int                       `json:"attachments"`
+	Oldest          string                    `json:"oldest,omitempty"`
+	Newest          string                    `json:"newest,omitempty"`
+	TopAttachments  []gmailEstimateAttachment `json:"topAttachments"`
+}
+
+func (c *GmailEstimateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	query, err := c.query()
+	if err != nil {
+		return err
+	}
+	if c.Max <= 0 {
+		return usage("--max must be > 0")
+	}
+	if c.Top < 0 {
+		return usage("--top must be >= 0")
+	}
+
+	_, svc, err := requireGmailService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	ids, err := listGmailMessageIDs(ctx, svc, query, c.Max+1, false)
+	if err != nil {
+		return err
+	}
+	est := gmailEstimate{Query: query, TopAttachments: []gmailEstimateAttachment{}}
+	if int64(len(ids)) > c.Max {
+		ids, est.Truncated = ids[:c.Max], true
+	}
+
+	messages, err := fetchGmailEstimateMessages(ctx, svc, ids)
+	if err != nil {
+		return err
+	}
+	var oldest, newest int64
+	var attachments []gmailEstimateAttachment
+	for _, msg := range messages {
+		est.Messages++
+		est.TotalBytes += msg.SizeEstimate
+		if msg.InternalDate > 0 {
+			if oldest == 0 || msg.InternalDate < oldest {
+				oldest = msg.InternalDate
+			}
+			newest = max(newest, msg.InternalDate)
+		}
+		for _, a := range collectAttachments(msg.Payload) {
+			est.Attachments++
+			est.AttachmentBytes += a.Size
+			attachments = append(attachments, gmailEstimateAttachment{
+				MessageID: msg.Id,
+				ThreadID:  msg.ThreadId,
+				Filename:  a.Filename,
+				MimeType:  a.MimeType,
+				Size:      a.Size,
+				Subject:   headerValue(msg.Payload, "Subject"),
+				From:      headerValue(msg.Payload, "From"),
+				Date:      formatGmailEstimateDate(msg.InternalDate),
+			})
+		}
+	}
+	est.Oldest, est.Newest = formatGmailEstimateDate(oldest), formatGmailEstimateDate(newest)
+	sort.SliceStable(attachments, func(i, j int) bool { return attachments[i].Size > attachments[j].Size })
+	if len(attachments) > c.Top {
+		attachments = attachments[:c.Top]
+	}
+	est.TopAttachments = append(est.TopAttachments, attachments...)
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, est)
+	}
+	u.Out().Printf("query\t%s", est.Query)
+	count := strconv.Itoa(est.Messages)
+	if est.Truncated {
+		count += "+ (truncated; raise --max)"
+	}
+	u.Out().Printf("messages\t%s", count)
+	u.Out().Printf("size\t%s", humanSize(ctx, est.TotalBytes))
+	u.Out().Printf("attachments\t%d (%s)", est.Attachments, humanSize(ctx, est.AttachmentBytes))
+	if est.Oldest != "" {
+		u.Out().Printf("oldest\t%s", humanDateTime(ctx, est.Oldest))
+		u.Out().Printf("newest\t%s", humanDateTime(ctx, est.Newest))
+	}
+	if len(est.TopAttachments) == 0 {
+		return nil
+	}
+	u.Out().Println("")
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "SIZE\tFILENAME\tDATE\tFROM\tSUBJECT\tMESSAGE_ID")
+	for _, a := range est.TopAttachments {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", humanSize(ctx, a.Size), a.Filename, humanDateTime(ctx, a.Date), a.From, a.Subject, a.MessageID)
+	}
+	return nil
+}
+
+func (c *GmailEstimateCmd) query() (string, error) {
+	var parts []string
+	for _, label := range c.Labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+		parts = append(parts, "label:"+strings.ReplaceAll(label, " ", "-"))
+	}
+	if olderThan := strings.TrimSpace(c.OlderThan); olderThan != "" {
+		if !gmailPolicyAgeRe.MatchString(olderThan) {
+			return "", usagef("invalid --older-than %q (use e.g. 30d, 6m, 2y)", olderThan)
+		}
+		parts = append(parts, "older_than:"+olderThan)
+	}
+	if q := strings.TrimSpace(c.Query); q != "" {
+		parts = append(parts, q)
+	}
+	if len(parts) == 0 {
+		return "", usage("set --label, --older-than, or --query to describe the messages to estimate")
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// fetchGmailEstimateMessages fetches sizes and part metadata with bounded
+// concurrency. Messages deleted since the search are skipped.
+func fetchGmailEstimateMessages(ctx context.Context, svc *gmail.Service, ids []string) ([]*gmail.Message, error) {
+	const maxConcurrency = 10
+	sem := make(chan struct{}, maxConcurrency)
+	out := make([]*gmail.Message, len(ids))
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(idx int, messageID string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[idx] = ctx.Err()
+				return
+			}
+			msg, err := svc.Users.Messages.Get("me", messageID).
+				Format(gmailFormatFull).
+				Fields(gmailEstimateFields).
+				Context(ctx).
+				Do()
+			if err != nil {
+				if isNotFoundAPIError(err) {
+					return
+				}
+				errs[idx] = fmt.Errorf("message %s: %w", messageID, err)
+				return
+			}
+			out[idx] = msg
+		}(i, id)
+	}
+	wg.Wait()
+
+	messages := make([]*gmail.Message, 0, len(ids))
+	for i, msg := range out {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if msg != nil {
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
+}
+
+func formatGmailEstimateDate(internalDate int64) string {
+	if internalDate <= 0 {
+		return ""
+	}
+	return time.UnixMilli(internalDate).UTC().Format(time.RFC3339)
+}
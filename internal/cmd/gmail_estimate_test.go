@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+)
+
+func TestGmailEstimateQuery(t *testing.T) {
+	q, err := (&GmailEstimateCmd{Labels: []string{"Old Newsletters"}, OlderThan: "2y", Query: "has:attachment"}).query()
+	if err != nil || q != "label:Old-Newsletters older_than:2y has:attachment" {
+		t.Fatalf("query = %q, %v", q, err)
+	}
+	if _, err := (&GmailEstimateCmd{OlderThan: "2 years"}).query(); err == nil {
+		t.Fatal("expected invalid --older-than error")
+	}
+	if _, err := (&GmailEstimateCmd{}).query(); err == nil {
+		t.Fatal("expected error without any filter")
+	}
+}
+
+func TestGmailEstimateCmd(t *testing.T) {
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+
+	var query string
+	svc, cleanup := newGmailServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/users/me/messages"):
+			query = r.URL.Query().Get("q")
+			_ = json.NewEncoder(w).Encode(map[string]any{"messages": []map[string]any{{"id": "m1"}, {"id": "m2"}, {"id": "m3"}}})
+		case strings.HasSuffix(r.URL.Path, "/users/me/messages/m1"):
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id": "m1", "threadId": "t1", "sizeEstimate": 3000000, "internalDate": "1600000000000",
+				"payload": map[string]any{
+					"headers": []map[string]any{{"name": "Subject", "value": "Scans"}},
+					"parts": []map[string]any{
+						{"mimeType": "text/plain", "body": map[string]any{"size": 10}},
+						{"filename": "scan.pdf", "mimeType": "application/pdf", "body": map[string]any{"size": 2500000, "attachmentId": "a1"}},
+						{"filename": "small.png", "mimeType": "image/png", "body": map[string]any{"size": 400, "attachmentId": "a2"}},
+					},
+				},
+			})
+		case strings.HasSuffix(r.URL.Path, "/users/me/messages/m2"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "m2", "threadId": "t2", "sizeEstimate": 5000, "internalDate": "1500000000000", "payload": map[string]any{}})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer cleanup()
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		err := runKong(t, &GmailEstimateCmd{}, []string{"--label", "Receipts", "--older-than", "2y", "--top", "1", "--max", "2"}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"})
+		if err != nil {
+			t.Fatalf("estimate: %v", err)
+		}
+	})
+	var est gmailEstimate
+	if err := json.Unmarshal([]byte(out), &est); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if query != "label:Receipts older_than:2y" {
+		t.Fatalf("unexpected query %q", query)
+	}
+	if !est.Truncated || est.Messages != 2 || est.TotalBytes != 3005000 || est.Attachments != 2 || est.AttachmentBytes != 2500400 {
+		t.Fatalf("unexpected estimate: %s", out)
+	}
+	if len(est.TopAttachments) != 1 || est.TopAttachments[0].Filename != "scan.pdf" || est.TopAttachments[0].Subject != "Scans" {
+		t.Fatalf("unexpected top attachments: %+v", est.TopAttachments)
+	}
+	if est.Oldest != "2017-07-14T02:40:00Z" || est.Newest != "2020-09-13T12:26:40Z" {
+		t.Fatalf("unexpected date range: %s .. %s", est.Oldest, est.Newest)
+	}
+}
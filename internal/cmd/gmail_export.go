@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+const (
+	gmailExportModeFull        = "full"
+	gmailExportModeIncremental = "incremental"
+)
+
+type GmailExportCmd struct {
+	Query            string `name:"query" short:"q" help:"Gmail search query (default: all mail)"`
+	Out              string `name:"out" short:"o" required:"" help:"Directory to write <messageId>.eml files into"`
+	Max              int64  `name:"max" aliases:"limit" help:"Max messages for a full export (0 = no limit)" default:"0"`
+	IncludeSpamTrash bool   `name:"include-spam-trash" help:"Include messages from Spam and Trash in a full export"`
+	Incremental      string `name:"incremental" help:"State file holding the last historyId; later runs export only new or changed messages"`
+}
+
+// gmailExportState is persisted by --incremental between runs.
+type gmailExportState struct {
+	Account   string `json:"account"`
+	Query     string `json:"query,omitempty"`
+	HistoryID string `json:"historyId"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+func (c *GmailExportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	query := strings.TrimSpace(c.Query)
+	outDir, err := config.ExpandPath(strings.TrimSpace(c.Out))
+	if err != nil {
+		return err
+	}
+	if outDir == "" {
+		return usage("empty --out")
+	}
+
+	var statePath string
+	var state *gmailExportState
+	if strings.TrimSpace(c.Incremental) != "" {
+		statePath, err = config.ExpandPath(strings.TrimSpace(c.Incremental))
+		if err != nil {
+			return err
+		}
+		state, err = loadGmailExportState(statePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	account, svc, err := requireGmailService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	if state != nil {
+		if !strings.EqualFold(state.Account, account) {
+			return usagef("state file %s belongs to %s, not %s", statePath, state.Account, account)
+		}
+		if state.Query != query {
+			return usagef("state file %s was recorded for query %q; use a new state file for a different query", statePath, state.Query)
+		}
+	}
+
+	// Take the mailbox historyId before listing so changes made while the
+	// export runs are picked up next time rather than lost.
+	profile, err := svc.Users.GetProfile("me").Fields("historyId").Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	mode := gmailExportModeFull
+	var ids []string
+	if state != nil && state.HistoryID != "" {
+		ids, err = c.changedIDs(ctx, svc, state.HistoryID, query)
+		switch {
+		case err == nil:
+			mode = gmailExportModeIncremental
+		case isStaleHistoryError(err):
+			u.Err().Printf("warning: historyId %s has expired; running a full export", state.HistoryID)
+		default:
+			return err
+		}
+	}
+	if mode == gmailExportModeFull {
+		ids, err = listGmailMessageIDs(ctx, svc, query, c.Max, c.IncludeSpamTrash)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(outDir, 0o700); err != nil {
+		return err
+	}
+	exported := make([]string, 0, len(ids))
+	var skipped int
+	for _, id := range ids {
+		ok, exportErr := exportGmailMessage(ctx, svc, outDir, id)
+		if exportErr != nil {
+			return fmt.Errorf("exporting message %s: %w", id, exportErr)
+		}
+		if !ok {
+			skipped++
+			continue
+		}
+		exported = append(exported, id)
+	}
+
+	historyID := formatHistoryID(profile.HistoryId)
+	if statePath != "" {
+		if err := saveGmailExportState(statePath, gmailExportState{
+			Account:   account,
+			Query:     query,
+			HistoryID: historyID,
+			UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+		}); err != nil {
+			return fmt.Errorf("saving export state: %w", err)
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"mode":      mode,
+			"out":       outDir,
+			"exported":  len(exported),
+			"skipped":   skipped,
+			"historyId": historyID,
+			"messages":  exported,
+		})
+	}
+	u.Out().Printf("mode\t%s", mode)
+	u.Out().Printf("out\t%s", outDir)
+	u.Out().Printf("exported\t%d", len(exported))
+	if skipped > 0 {
+		u.Out().Printf("skipped\t%d", skipped)
+	}
+	u.Out().Printf("history_id\t%s", historyID)
+	return nil
+}
+
+// changedIDs returns messages added or relabeled since historyID. History
+// cannot be filtered by a search query, so with --query the changes are
+// intersected with the query's current matches.
+func (c *GmailExportCmd) changedIDs(ctx context.Context, svc *gmail.Service, historyID, query string) ([]string, error) {
+	startID, err := parseHistoryID(historyID)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := collectAllPages("", func(pageToken string) ([]string, string, error) {
+		call := svc.Users.History.List("me").StartHistoryId(startID).MaxResults(500).Context(ctx)
+		call.HistoryTypes("messageAdded", "labelAdded", "labelRemoved")
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return collectHistoryMessageIDs(resp).FetchIDs, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	changed = uniqueGmailIDs(changed)
+	if query == "" || len(changed) == 0 {
+		return changed, nil
+	}
+
+	matching, err := listGmailMessageIDs(ctx, svc, query, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	match := make(map[string]bool, len(matching))
+	for _, id := range matching {
+		match[id] = true
+	}
+	var out []string
+	for _, id := range changed {
+		if match[id] {
+			out = append(out, id)
+		}
+	}
+	return out, nil
+}
+
+func uniqueGmailIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	out := ids[:0]
+	for _, id := range ids {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func listGmailMessageIDs(ctx context.Context, svc *gmail.Service, query string, limit int64, includeSpamTrash bool) ([]string, error) {
+	var ids []string
+	pageToken := ""
+	for {
+		call := svc.Users.Messages.List("me").MaxResults(500).IncludeSpamTrash(includeSpamTrash).Context(ctx)
+		if query != "" {
+			call = call.Q(query)
+		}
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range resp.Messages {
+			if m == nil || m.Id == "" {
+				continue
+			}
+			ids = append(ids, m.Id)
+			if limit > 0 && int64(len(ids)) >= limit {
+				return ids, nil
+			}
+		}
+		if resp.NextPageToken == "" {
+			return ids, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// exportGmailMessage writes the raw RFC 822 message to dir/<id>.eml. It
+// reports false when the message no longer exists.
+func exportGmailMessage(ctx context.Context, svc *gmail.Service, dir, id string) (bool, error) {
+	msg, err := svc.Users.Messages.Get("me", id).Format(gmailFormatRaw).Context(ctx).Do()
+	if err != nil {
+		if isNotFoundAPIError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(msg.Raw, "="))
+	if err != nil {
+		return false, fmt.Errorf("decoding raw message: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, filepath.Base(id)+".eml"), raw, 0o600); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func loadGmailExportState(path string) (*gmailExportState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var state gmailExportState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing state file %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// saveGmailExportState writes through a temp file so an interrupted run
+// never leaves a truncated state file behind.
+func saveGmailExportState(path string, state gmailExportState) error {
+	payload, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return err
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(payload, '\n'), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
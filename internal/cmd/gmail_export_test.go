@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestGmailExportCmd_Incremental(t *testing.T) {
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+
+	profileHistory := "100"
+	var historyCalls, listCalls int
+	var gotStart string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := r.URL.Path
+		switch {
+		case strings.HasSuffix(path, "/users/me/profile"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"emailAddress": "a@b.com", "historyId": profileHistory})
+		case strings.HasSuffix(path, "/users/me/history"):
+			historyCalls++
+			gotStart = r.URL.Query().Get("startHistoryId")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"historyId": "150",
+				"history": []any{
+					map[string]any{"messagesAdded": []any{map[string]any{"message": map[string]any{"id": "m3"}}}},
+					map[string]any{"labelsAdded": []any{map[string]any{"message": map[string]any{"id": "m3"}}}},
+					map[string]any{"messagesAdded": []any{map[string]any{"message": map[string]any{"id": "gone"}}}},
+				},
+			})
+		case strings.HasSuffix(path, "/users/me/messages"):
+			listCalls++
+			_ = json.NewEncoder(w).Encode(map[string]any{"messages": []any{
+				map[string]any{"id": "m1"}, map[string]any{"id": "m2"},
+			}})
+		case strings.Contains(path, "/users/me/messages/"):
+			id := path[strings.LastIndex(path, "/")+1:]
+			if id == "gone" {
+				http.Error(w, `{"error":{"code":404,"message":"Not Found"}}`, http.StatusNotFound)
+				return
+			}
+			raw := base64.RawURLEncoding.EncodeToString([]byte("Subject: " + id + "\r\n\r\nbody\r\n"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "raw": raw})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	dir := t.TempDir()
+	outDir := filepath.Join(dir, "archive")
+	statePath := filepath.Join(dir, "state.json")
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+
+	type result struct {
+		Mode     string   `json:"mode"`
+		Exported int      `json:"exported"`
+		Skipped  int      `json:"skipped"`
+		Messages []string `json:"messages"`
+	}
+	run := func() result {
+		t.Helper()
+		out := captureStdout(t, func() {
+			if err := runKong(t, &GmailExportCmd{}, []string{"--out", outDir, "--incremental", statePath}, ctx, flags); err != nil {
+				t.Fatalf("export: %v", err)
+			}
+		})
+		var got result
+		if err := json.Unmarshal([]byte(out), &got); err != nil {
+			t.Fatalf("unmarshal: %v (output: %q)", err, out)
+		}
+		return got
+	}
+
+	first := run()
+	if first.Mode != gmailExportModeFull || first.Exported != 2 || historyCalls != 0 {
+		t.Fatalf("unexpected first run: %#v (history calls %d)", first, historyCalls)
+	}
+	if data, err := os.ReadFile(filepath.Join(outDir, "m1.eml")); err != nil || !strings.HasPrefix(string(data), "Subject: m1") {
+		t.Fatalf("unexpected m1.eml: %q %v", data, err)
+	}
+
+	profileHistory = "150"
+	second := run()
+	if second.Mode != gmailExportModeIncremental || second.Exported != 1 || second.Skipped != 1 || second.Messages[0] != "m3" {
+		t.Fatalf("unexpected second run: %#v", second)
+	}
+	if gotStart != "100" || listCalls != 1 {
+		t.Fatalf("expected history from 100 without relisting, got start=%q lists=%d", gotStart, listCalls)
+	}
+
+	var state gmailExportState
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatalf("read state: %v", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil || state.HistoryID != "150" || state.Account != "a@b.com" {
+		t.Fatalf("unexpected state: %s (%v)", data, err)
+	}
+
+	err = runKong(t, &GmailExportCmd{}, []string{"--out", outDir, "--incremental", statePath, "-q", "from:boss"}, ctx, flags)
+	if err == nil || !strings.Contains(err.Error(), "recorded for query") {
+		t.Fatalf("expected query mismatch error, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+// gmailThreadScope reports whether label edits should apply to whole
+// threads. An explicit --thread/--no-thread wins over the gmail_label_scope
+// config key.
+func gmailThreadScope(flag *bool) bool {
+	if flag != nil {
+		return *flag
+	}
+	cfg, ok := readConfigOptional()
+	return ok && cfg.GmailLabelScope == config.GmailLabelScopeThread
+}
+
+// modifyGmailThreadsOfMessages applies a label change to every thread that
+// contains one of messageIDs, so a conversation is never left half-labeled.
+// It returns the thread IDs in first-seen order.
+func modifyGmailThreadsOfMessages(ctx context.Context, svc *gmail.Service, messageIDs, addIDs, removeIDs []string) ([]string, error) {
+	threadIDs, err := gmailThreadsOfMessages(ctx, svc, messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := modifyGmailThreads(ctx, svc, threadIDs, addIDs, removeIDs, nil); err != nil {
+		return nil, err
+	}
+	return threadIDs, nil
+}
+
+// gmailThreadsOfMessages returns the threads of messageIDs in first-seen
+// order.
+func gmailThreadsOfMessages(ctx context.Context, svc *gmail.Service, messageIDs []string) ([]string, error) {
+	threadIDs := make([]string, 0, len(messageIDs))
+	seen := map[string]bool{}
+	for _, id := range messageIDs {
+		msg, err := svc.Users.Messages.Get("me", id).
+			Format("minimal").
+			Fields("id,threadId").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, fmt.Errorf("look up thread of message %s: %w", id, err)
+		}
+		if msg.ThreadId == "" || seen[msg.ThreadId] {
+			continue
+		}
+		seen[msg.ThreadId] = true
+		threadIDs = append(threadIDs, msg.ThreadId)
+	}
+	return threadIDs, nil
+}
+
+// gmailThreadMessageIDs lists every message in threadIDs, so a thread-wide
+// trash can be counted and journaled per message.
+func gmailThreadMessageIDs(ctx context.Context, svc *gmail.Service, threadIDs []string) ([]string, error) {
+	var ids []string
+	for _, threadID := range threadIDs {
+		thread, err := svc.Users.Threads.Get("me", threadID).
+			Format("minimal").
+			Fields("id,messages(id)").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, fmt.Errorf("list messages of thread %s: %w", threadID, err)
+		}
+		for _, msg := range thread.Messages {
+			if msg != nil && msg.Id != "" {
+				ids = append(ids, msg.Id)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// modifyGmailThreads applies a label change to each thread, paced by pacer
+// when it is set.
+func modifyGmailThreads(ctx context.Context, svc *gmail.Service, threadIDs, addIDs, removeIDs []string, pacer *bulkPacer) error {
+	for _, threadID := range threadIDs {
+		if err := pacer.wait(ctx); err != nil {
+			return err
+		}
+		if _, err := svc.Users.Threads.Modify("me", threadID, &gmail.ModifyThreadRequest{
+			AddLabelIds:    addIDs,
+			RemoveLabelIds: removeIDs,
+		}).Context(ctx).Do(); err != nil {
+			return fmt.Errorf("modify thread %s: %w", threadID, err)
+		}
+	}
+	return nil
+}
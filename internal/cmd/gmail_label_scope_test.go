@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+func TestGmailBatchModify_ThreadScopeFromConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+	if err := config.WriteConfig(config.File{GmailLabelScope: config.GmailLabelScopeThread}); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+
+	threadOf := map[string]string{"m1": "t1", "m2": "t1", "m3": "t2"}
+	var modified []string
+	var batchCalls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/gmail/v1/users/me")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path == "/labels":
+			_ = json.NewEncoder(w).Encode(map[string]any{"labels": []map[string]any{
+				{"id": "Label_1", "name": "Clients", "type": "user"},
+			}})
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "/messages/"):
+			id := strings.TrimPrefix(path, "/messages/")
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "threadId": threadOf[id]})
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/modify") && strings.HasPrefix(path, "/threads/"):
+			var body gmail.ModifyThreadRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if len(body.AddLabelIds) != 1 || body.AddLabelIds[0] != "Label_1" {
+				t.Errorf("unexpected thread modify body: %+v", body)
+			}
+			modified = append(modified, strings.TrimSuffix(strings.TrimPrefix(path, "/threads/"), "/modify"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "x"})
+		case path == "/messages/batchModify":
+			batchCalls++
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "gmail", "batch", "modify", "m1", "m2", "m3", "--add", "Clients"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if strings.Join(modified, ",") != "t1,t2" || batchCalls != 0 {
+		t.Fatalf("expected thread modifies t1,t2 and no batchModify, got %v / %d", modified, batchCalls)
+	}
+	var parsed struct {
+		Threads []string `json:"threads"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil || len(parsed.Threads) != 2 {
+		t.Fatalf("unexpected output %q: %v", out, err)
+	}
+
+	// --no-thread overrides the config.
+	modified = nil
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "gmail", "batch", "modify", "m1", "--add", "Clients", "--no-thread"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if len(modified) != 0 || batchCalls != 1 {
+		t.Fatalf("expected message-scoped batchModify, got threads %v / %d batch calls", modified, batchCalls)
+	}
+}
+
+func TestGmailBatchModify_ThreadScopeTrashCountsWholeThreads(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+
+	threadOf := map[string]string{"m1": "t1", "m3": "t2"}
+	threadMessages := map[string][]string{"t1": {"m1", "m2"}, "t2": {"m3", "m4"}}
+	var modified []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/gmail/v1/users/me")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path == "/labels":
+			_ = json.NewEncoder(w).Encode(map[string]any{"labels": []map[string]any{
+				{"id": "TRASH", "name": "TRASH", "type": "system"},
+			}})
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "/messages/"):
+			id := strings.TrimPrefix(path, "/messages/")
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "threadId": threadOf[id]})
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "/threads/"):
+			id := strings.TrimPrefix(path, "/threads/")
+			msgs := []map[string]any{}
+			for _, m := range threadMessages[id] {
+				msgs = append(msgs, map[string]any{"id": m})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "messages": msgs})
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/modify") && strings.HasPrefix(path, "/threads/"):
+			modified = append(modified, strings.TrimSuffix(strings.TrimPrefix(path, "/threads/"), "/modify"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "x"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	// The refusal names the count, which must cover both whole threads.
+	if err := config.WriteConfig(config.File{BulkConfirmThreshold: 4}); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	err = Execute([]string{"--no-input", "--account", "a@b.com", "gmail", "batch", "modify", "m1", "m3", "--add", "TRASH", "--thread"})
+	if err == nil || !strings.Contains(err.Error(), "trash 4 messages") || len(modified) != 0 {
+		t.Fatalf("expected refusal counting all 4 thread messages, got %v (modified %v)", err, modified)
+	}
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--force", "--account", "a@b.com", "gmail", "batch", "modify", "m1", "m3", "--add", "TRASH", "--thread"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if strings.Join(modified, ",") != "t1,t2" {
+		t.Fatalf("expected both threads trashed, got %v", modified)
+	}
+	entries, err := readBulkJournal()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one journal entry, got %v (%v)", entries, err)
+	}
+	var journaled []string
+	for _, item := range entries[0].Items {
+		journaled = append(journaled, item.ID)
+	}
+	if strings.Join(journaled, ",") != "m1,m2,m3,m4" {
+		t.Fatalf("expected every thread message journaled, got %v", journaled)
+	}
+}
@@ -8,8 +8,9 @@ import (
 
 	"google.golang.org/api/gmail/v1"
 
-	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/idcache"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type GmailLabelsCmd struct {
@@ -184,6 +185,7 @@ func (c *GmailLabelsRenameCmd) Run(ctx context.Context, flags *RootFlags) error
 	if err != nil {
 		return mapLabelCreateError(err, newName)
 	}
+	invalidateNameCache(ctx, idcache.KindGmailLabels, "")
 
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"label": updated})
@@ -376,6 +378,7 @@ func (c *GmailLabelsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error
 	if err := svc.Users.Labels.Delete("me", label.Id).Context(ctx).Do(); err != nil {
 		return err
 	}
+	invalidateNameCache(ctx, idcache.KindGmailLabels, "")
 
 	return writeResult(ctx, u,
 		kv("deleted", true),
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strconv"
@@ -8,6 +9,8 @@ import (
 
 	"google.golang.org/api/gmail/v1"
 	"google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/idcache"
 )
 
 func resolveLabelIDs(labels []string, nameToID map[string]string) []string {
@@ -31,8 +34,22 @@ func resolveLabelIDs(labels []string, nameToID map[string]string) []string {
 	return out
 }
 
-func resolveModifyLabelIDs(svc *gmail.Service, addLabels, removeLabels []string) ([]string, []string, error) {
-	idMap, err := fetchLabelNameToID(svc)
+// gmailLabelsInclude reports whether labels names want, ignoring case and
+// surrounding space like the rest of the label lookups.
+func gmailLabelsInclude(labels []string, want string) bool {
+	for _, label := range labels {
+		if strings.EqualFold(strings.TrimSpace(label), want) {
+			return true
+		}
+	}
+	return false
+}
+
+func resolveModifyLabelIDs(ctx context.Context, svc *gmail.Service, addLabels, removeLabels []string) ([]string, []string, error) {
+	wanted := labelLookupKeys(append(append([]string{}, addLabels...), removeLabels...))
+	idMap, err := cachedNameMap(ctx, idcache.KindGmailLabels, "", wanted, func() (map[string]string, error) {
+		return fetchLabelNameToID(svc)
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -40,6 +57,18 @@ func resolveModifyLabelIDs(svc *gmail.Service, addLabels, removeLabels []string)
 	return resolveLabelIDs(addLabels, idMap), resolveLabelIDs(removeLabels, idMap), nil
 }
 
+// labelLookupKeys returns the lower-cased keys resolveLabelIDs will look up,
+// matching the key shape produced by fetchLabelNameToID.
+func labelLookupKeys(labels []string) []string {
+	keys := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if trimmed := strings.TrimSpace(label); trimmed != "" {
+			keys = append(keys, strings.ToLower(trimmed))
+		}
+	}
+	return keys
+}
+
 func looksLikeCustomLabelID(raw string) bool {
 	trimmed := strings.TrimSpace(raw)
 	if !strings.HasPrefix(strings.ToLower(trimmed), "label_") {
@@ -68,3 +68,12 @@ func TestIsDuplicateLabelError(t *testing.T) {
 		t.Fatalf("expected non-duplicate")
 	}
 }
+
+func TestGmailLabelsInclude(t *testing.T) {
+	if !gmailLabelsInclude([]string{"INBOX", " trash "}, "TRASH") {
+		t.Fatal("expected case-insensitive match")
+	}
+	if gmailLabelsInclude([]string{"Trashed"}, "TRASH") || gmailLabelsInclude(nil, "TRASH") {
+		t.Fatal("unexpected match")
+	}
+}
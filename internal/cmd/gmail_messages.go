@@ -10,8 +10,8 @@ import (
 
 	"google.golang.org/api/gmail/v1"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type GmailMessagesCmd struct {
@@ -153,6 +153,7 @@ type GmailMessagesModifyCmd struct {
 	MessageID string `arg:"" name:"messageId" help:"Message ID"`
 	Add       string `name:"add" help:"Labels to add (comma-separated, name or ID)"`
 	Remove    string `name:"remove" help:"Labels to remove (comma-separated, name or ID)"`
+	Thread    *bool  `name:"thread" negatable:"" help:"Apply the change to the message's whole thread (default from config gmail_label_scope)"`
 }
 
 func (c *GmailMessagesModifyCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -168,10 +169,12 @@ func (c *GmailMessagesModifyCmd) Run(ctx context.Context, flags *RootFlags) erro
 		return usage("must specify --add and/or --remove")
 	}
 
+	threadScope := gmailThreadScope(c.Thread)
 	if err := dryRunExit(ctx, flags, "gmail.messages.modify", map[string]any{
-		"message_id": messageID,
-		"add":        addLabels,
-		"remove":     removeLabels,
+		"message_id":   messageID,
+		"add":          addLabels,
+		"remove":       removeLabels,
+		"thread_scope": threadScope,
 	}); err != nil {
 		return err
 	}
@@ -186,11 +189,28 @@ func (c *GmailMessagesModifyCmd) Run(ctx context.Context, flags *RootFlags) erro
 		return err
 	}
 
-	addIDs, removeIDs, err := resolveModifyLabelIDs(svc, addLabels, removeLabels)
+	addIDs, removeIDs, err := resolveModifyLabelIDs(ctx, svc, addLabels, removeLabels)
 	if err != nil {
 		return err
 	}
 
+	if threadScope {
+		threadIDs, err := modifyGmailThreadsOfMessages(ctx, svc, []string{messageID}, addIDs, removeIDs)
+		if err != nil {
+			return err
+		}
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+				"modified":      messageID,
+				"threads":       threadIDs,
+				"addedLabels":   addIDs,
+				"removedLabels": removeIDs,
+			})
+		}
+		u.Out().Printf("Modified thread %s", strings.Join(threadIDs, ", "))
+		return nil
+	}
+
 	_, err = svc.Users.Messages.Modify("me", messageID, &gmail.ModifyMessageRequest{
 		AddLabelIds:    addIDs,
 		RemoveLabelIds: removeIDs,
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"google.golang.org/api/gmail/v1"
+	"gopkg.in/yaml.v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type GmailPolicyCmd struct {
+	Run GmailPolicyRunCmd `cmd:"" name:"run" help:"Apply retention rules from a policy file"`
+}
+
+// GmailPolicyRunCmd applies an ordered list of retention rules. A policy file
+// looks like:
+//
+//	rules:
+//	  - name: receipts
+//	    query: label:receipts
+//	    action: keep
+//	  - name: old alerts
+//	    query: label:alerts
+//	    older_than: 30d
+//	    action: trash
+type GmailPolicyRunCmd struct {
+	File string `name:"file" short:"f" required:"" help:"Policy file (YAML or JSON; - for stdin)"`
+	Max  int64  `name:"max" aliases:"limit" help:"Max messages per rule (0 = no limit)" default:"0"`
+}
+
+type gmailPolicy struct {
+	Rules []gmailPolicyRule `yaml:"rules" json:"rules"`
+}
+
+type gmailPolicyRule struct {
+	Name      string `yaml:"name" json:"name,omitempty"`
+	Query     string `yaml:"query" json:"query"`
+	OlderThan string `yaml:"older_than" json:"older_than,omitempty"`
+	Action    string `yaml:"action" json:"action"`
+}
+
+// gmailPolicyResult is the per-rule summary.
+type gmailPolicyResult struct {
+	Name    string `json:"name"`
+	Action  string `json:"action"`
+	Query   string `json:"query"`
+	Matched int    `json:"matched"`
+	Applied int    `json:"applied"`
+
+	ids []string
+}
+
+// gmailPolicyActions maps each action to the labels it adds and removes. The
+// extra query term excludes messages the action already applied to, so a
+// re-run only touches new matches.
+var gmailPolicyActions = map[string]struct {
+	add, remove []string
+	pending     string
+}{
+	"trash":     {add: []string{"TRASH"}, remove: []string{"INBOX"}},
+	"archive":   {remove: []string{"INBOX"}, pending: "in:inbox"},
+	"mark-read": {remove: []string{"UNREAD"}, pending: "is:unread"},
+	"keep":      {},
+}
+
+var gmailPolicyAgeRe = regexp.MustCompile(`^\d+[dmy]$`)
+
+func (c *GmailPolicyRunCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Max < 0 {
+		return usage("--max must be >= 0")
+	}
+	b, err := resolveInlineOrFileBytes("@" + strings.TrimPrefix(strings.TrimSpace(c.File), "@"))
+	if err != nil {
+		return fmt.Errorf("read --file: %w", err)
+	}
+	policy, err := parseGmailPolicy(b)
+	if err != nil {
+		return err
+	}
+
+	account, svc, err := requireGmailService(ctx, flags)
+	if err != nil {
+		return err
+	}
+
+	results, err := planGmailPolicy(ctx, svc, policy, c.Max)
+	if err != nil {
+		return err
+	}
+
+	if err := dryRunExit(ctx, flags, "gmail.policy.run", map[string]any{
+		"account": account,
+		"rules":   results,
+	}); err != nil {
+		return err
+	}
+
+	total := 0
+	for i := range results {
+		r := &results[i]
+		action := gmailPolicyActions[r.Action]
+		for start := 0; start < len(r.ids); start += 1000 {
+			chunk := r.ids[start:min(start+1000, len(r.ids))]
+			if err := svc.Users.Messages.BatchModify("me", &gmail.BatchModifyMessagesRequest{
+				Ids:            chunk,
+				AddLabelIds:    action.add,
+				RemoveLabelIds: action.remove,
+			}).Context(ctx).Do(); err != nil {
+				return fmt.Errorf("rule %q: batch modify failed at offset %d: %w", r.Name, start, err)
+			}
+			r.Applied += len(chunk)
+		}
+		total += r.Applied
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"rules": results,
+			"total": total,
+		})
+	}
+
+	w, flush := tableWriter(ctx)
+	fmt.Fprintln(w, "RULE\tACTION\tMATCHED\tAPPLIED")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", oneLineTSV(r.Name), r.Action, r.Matched, r.Applied)
+	}
+	flush()
+	u.Err().Printf("Applied policy to %d message%s", total, pluralS(total))
+	return nil
+}
+
+// parseGmailPolicy decodes and validates a policy. YAML is a superset of
+// JSON, so both formats go through the same decoder.
+func parseGmailPolicy(b []byte) (gmailPolicy, error) {
+	var p gmailPolicy
+	if err := yaml.Unmarshal(b, &p); err != nil {
+		return gmailPolicy{}, fmt.Errorf("invalid policy file: %w", err)
+	}
+	if len(p.Rules) == 0 {
+		return gmailPolicy{}, usage("policy file has no rules")
+	}
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		r.Query = strings.TrimSpace(r.Query)
+		r.OlderThan = strings.TrimSpace(r.OlderThan)
+		r.Action = strings.ToLower(strings.TrimSpace(r.Action))
+		if r.Action == "never" {
+			r.Action = "keep"
+		}
+		if strings.TrimSpace(r.Name) == "" {
+			r.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		if r.Query == "" && r.OlderThan == "" {
+			return gmailPolicy{}, usagef("%s: query or older_than is required", r.Name)
+		}
+		if _, ok := gmailPolicyActions[r.Action]; !ok {
+			return gmailPolicy{}, usagef("%s: unknown action %q (use trash, archive, mark-read, keep)", r.Name, r.Action)
+		}
+		if r.OlderThan != "" && !gmailPolicyAgeRe.MatchString(r.OlderThan) {
+			return gmailPolicy{}, usagef("%s: invalid older_than %q (use e.g. 30d, 6m, 1y)", r.Name, r.OlderThan)
+		}
+	}
+	return p, nil
+}
+
+// gmailPolicyQuery builds the Gmail search for a rule.
+func gmailPolicyQuery(r gmailPolicyRule) string {
+	parts := make([]string, 0, 3)
+	if r.Query != "" {
+		parts = append(parts, r.Query)
+	}
+	if r.OlderThan != "" {
+		parts = append(parts, "older_than:"+r.OlderThan)
+	}
+	if pending := gmailPolicyActions[r.Action].pending; pending != "" {
+		parts = append(parts, pending)
+	}
+	return strings.Join(parts, " ")
+}
+
+// planGmailPolicy evaluates rules in order. Messages matched by a keep rule
+// are protected from every later rule, and each message is acted on by the
+// first action rule that matches it.
+func planGmailPolicy(ctx context.Context, svc *gmail.Service, policy gmailPolicy, limit int64) ([]gmailPolicyResult, error) {
+	claimed := map[string]bool{}
+	results := make([]gmailPolicyResult, 0, len(policy.Rules))
+	for _, rule := range policy.Rules {
+		query := gmailPolicyQuery(rule)
+		ids, err := listGmailMessageIDs(ctx, svc, query, limit, false)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		res := gmailPolicyResult{Name: rule.Name, Action: rule.Action, Query: query}
+		for _, id := range uniqueGmailIDs(ids) {
+			if claimed[id] {
+				continue
+			}
+			claimed[id] = true
+			res.Matched++
+			if rule.Action != "keep" {
+				res.ids = append(res.ids, id)
+			}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestGmailPolicyRun(t *testing.T) {
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+
+	var queries []string
+	var modifies []gmail.BatchModifyMessagesRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/users/me/messages") && r.Method == http.MethodGet:
+			q := r.URL.Query().Get("q")
+			queries = append(queries, q)
+			var ids []string
+			switch {
+			case strings.HasPrefix(q, "label:receipts"):
+				ids = []string{"r1"}
+			case strings.HasPrefix(q, "label:alerts"):
+				ids = []string{"a1", "r1", "a2"}
+			}
+			msgs := make([]any, 0, len(ids))
+			for _, id := range ids {
+				msgs = append(msgs, map[string]any{"id": id})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"messages": msgs})
+		case strings.HasSuffix(r.URL.Path, "/users/me/messages/batchModify") && r.Method == http.MethodPost:
+			var req gmail.BatchModifyMessagesRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("decode: %v", err)
+			}
+			modifies = append(modifies, req)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	policy := `rules:
+  - name: receipts
+    query: label:receipts
+    action: never
+  - name: alerts
+    query: label:alerts
+    older_than: 30d
+    action: trash
+  - query: label:alerts
+    action: archive
+`
+	if err := os.WriteFile(path, []byte(policy), 0o600); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+	out := captureStdout(t, func() {
+		if err := runKong(t, &GmailPolicyRunCmd{}, []string{"--file", path}, ctx, flags); err != nil {
+			t.Fatalf("run: %v", err)
+		}
+	})
+
+	wantQueries := []string{"label:receipts", "label:alerts older_than:30d", "label:alerts in:inbox"}
+	if strings.Join(queries, "|") != strings.Join(wantQueries, "|") {
+		t.Fatalf("unexpected queries: %q", queries)
+	}
+	if len(modifies) != 1 {
+		t.Fatalf("expected one batchModify, got %#v", modifies)
+	}
+	if got := strings.Join(modifies[0].Ids, ","); got != "a1,a2" {
+		t.Fatalf("protected or duplicate ids modified: %s", got)
+	}
+	if strings.Join(modifies[0].AddLabelIds, ",") != "TRASH" || strings.Join(modifies[0].RemoveLabelIds, ",") != "INBOX" {
+		t.Fatalf("unexpected labels: %#v", modifies[0])
+	}
+
+	var got struct {
+		Rules []gmailPolicyResult `json:"rules"`
+		Total int                 `json:"total"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if got.Total != 2 || len(got.Rules) != 3 {
+		t.Fatalf("unexpected summary: %#v", got)
+	}
+	if r := got.Rules[0]; r.Action != "keep" || r.Matched != 1 || r.Applied != 0 {
+		t.Fatalf("unexpected keep rule: %#v", r)
+	}
+	if r := got.Rules[2]; r.Name != "rule 3" || r.Matched != 0 {
+		t.Fatalf("unexpected archive rule: %#v", r)
+	}
+}
+
+func TestParseGmailPolicy_Invalid(t *testing.T) {
+	for _, in := range []string{
+		`rules: []`,
+		`{"rules":[{"query":"label:x","action":"delete"}]}`,
+		`{"rules":[{"query":"label:x","older_than":"30 days","action":"trash"}]}`,
+		`{"rules":[{"action":"trash"}]}`,
+	} {
+		if _, err := parseGmailPolicy([]byte(in)); err == nil {
+			t.Fatalf("expected error for %s", in)
+		}
+	}
+}
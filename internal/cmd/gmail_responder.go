@@ -0,0 +1,516 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+	"gopkg.in/yaml.v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+type GmailResponderCmd struct {
+	Run GmailResponderRunCmd `cmd:"" name:"run" help:"Reply to matching messages using templated rules"`
+}
+
+// GmailResponderRunCmd answers new mail with per-rule templates. A rules
+// file looks like:
+//
+//	label: AutoAcked
+//	rules:
+//	  - name: support
+//	    query: to:support@example.com in:inbox newer_than:1d
+//	    body: |
+//	      Hi {{.FromName}}, we got "{{.Subject}}" and will reply within a day.
+//	    rate_limit: 1/24h
+//	    mark_read: true
+//
+// Each message is handled by the first rule that matches it and is labeled
+// afterwards, so reruns never answer the same message twice.
+type GmailResponderRunCmd struct {
+	Rules    string        `name:"rules" short:"f" required:"" help:"Rules file (YAML or JSON; - for stdin)"`
+	State    string        `name:"state" help:"Rate-limit state file (default: per-account file in the gog state dir)"`
+	Max      int64         `name:"max" aliases:"limit" help:"Max matching messages per rule per pass" default:"50"`
+	Interval time.Duration `name:"interval" help:"Poll again after this long and keep running until interrupted (0 = single pass)" default:"0"`
+}
+
+type gmailResponderConfig struct {
+	Label string               `yaml:"label" json:"label,omitempty"`
+	From  string               `yaml:"from" json:"from,omitempty"`
+	Rules []gmailResponderRule `yaml:"rules" json:"rules"`
+}
+
+type gmailResponderRule struct {
+	Name      string `yaml:"name" json:"name,omitempty"`
+	Query     string `yaml:"query" json:"query"`
+	Subject   string `yaml:"subject" json:"subject,omitempty"`
+	Body      string `yaml:"body" json:"body,omitempty"`
+	BodyHTML  string `yaml:"body_html" json:"body_html,omitempty"`
+	ReplyTo   string `yaml:"reply_to" json:"reply_to,omitempty"`
+	RateLimit string `yaml:"rate_limit" json:"rate_limit,omitempty"`
+	MarkRead  bool   `yaml:"mark_read" json:"mark_read,omitempty"`
+	Archive   bool   `yaml:"archive" json:"archive,omitempty"`
+
+	limit   int
+	window  time.Duration
+	subject *template.Template
+	body    *template.Template
+	html    *htmltemplate.Template
+}
+
+// gmailResponderData is the template data for subject and body templates.
+type gmailResponderData struct {
+	From      string
+	FromName  string
+	FromEmail string
+	Subject   string
+	Date      string
+	Rule      string
+}
+
+type gmailResponderResult struct {
+	Rule           string `json:"rule"`
+	Action         string `json:"action"`
+	MessageID      string `json:"messageId"`
+	ThreadID       string `json:"threadId,omitempty"`
+	Sender         string `json:"sender,omitempty"`
+	ReplyMessageID string `json:"replyMessageId,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+	Subject        string `json:"subject,omitempty"`
+}
+
+type gmailResponderSummary struct {
+	Label   string                 `json:"label"`
+	Matched int                    `json:"matched"`
+	Replied int                    `json:"replied"`
+	Skipped int                    `json:"skipped"`
+	Results []gmailResponderResult `json:"results"`
+}
+
+// gmailResponderState records recent replies per rule and sender so the
+// rate limit holds across runs and restarts.
+type gmailResponderState struct {
+	Account string                            `json:"account"`
+	Replies map[string]map[string][]time.Time `json:"replies"`
+}
+
+const gmailResponderDefaultLabel = "AutoResponded"
+
+var gmailResponderRateRe = regexp.MustCompile(`^(\d+)\s*/\s*(\d+)([mhdw])$`)
+
+func (c *GmailResponderRunCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Max <= 0 {
+		return usage("--max must be > 0")
+	}
+	if c.Interval < 0 {
+		return usage("--interval must be >= 0")
+	}
+	b, err := resolveInlineOrFileBytes("@" + strings.TrimPrefix(strings.TrimSpace(c.Rules), "@"))
+	if err != nil {
+		return fmt.Errorf("read --rules: %w", err)
+	}
+	cfg, err := parseGmailResponderConfig(b)
+	if err != nil {
+		return err
+	}
+
+	if err := dryRunExit(ctx, flags, "gmail.responder.run", map[string]any{
+		"label":    cfg.Label,
+		"from":     cfg.From,
+		"rules":    cfg.Rules,
+		"max":      c.Max,
+		"interval": c.Interval.String(),
+	}); err != nil {
+		return err
+	}
+
+	account, svc, err := requireGmailService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	statePath := strings.TrimSpace(c.State)
+	if statePath != "" {
+		statePath, err = config.ExpandPath(statePath)
+	} else {
+		statePath, err = gmailResponderStatePath(account)
+	}
+	if err != nil {
+		return err
+	}
+	state, err := loadGmailResponderState(statePath, account)
+	if err != nil {
+		return err
+	}
+
+	if c.Interval == 0 {
+		summary, err := runGmailResponder(ctx, svc, account, cfg, state, statePath, c.Max, time.Now)
+		if err != nil {
+			return err
+		}
+		return writeGmailResponderSummary(ctx, u, summary)
+	}
+
+	// In daemon mode every acted-on message is one JSON line, regardless of
+	// --json, so the output can be piped into a log collector.
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetEscapeHTML(false)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+		summary, passErr := runGmailResponder(ctx, svc, account, cfg, state, statePath, c.Max, time.Now)
+		if passErr != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			// Keep running through transient failures; the next pass retries.
+			u.Err().Printf("responder pass failed: %v", passErr)
+		}
+		for _, r := range summary.Results {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		timer.Reset(c.Interval)
+	}
+}
+
+func writeGmailResponderSummary(ctx context.Context, u *ui.UI, summary gmailResponderSummary) error {
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"responder": summary})
+	}
+	if len(summary.Results) == 0 {
+		u.Out().Println("No matching messages")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	fmt.Fprintln(w, "RULE\tACTION\tMESSAGE\tSENDER\tREPLY_MESSAGE\tREASON\tSUBJECT")
+	for _, r := range summary.Results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			oneLineTSV(r.Rule), r.Action, r.MessageID, r.Sender, r.ReplyMessageID, r.Reason, sanitizeTab(r.Subject))
+	}
+	flush()
+	u.Out().Printf("matched\t%d", summary.Matched)
+	u.Out().Printf("replied\t%d", summary.Replied)
+	u.Out().Printf("skipped\t%d", summary.Skipped)
+	return nil
+}
+
+// parseGmailResponderConfig decodes and validates a rules file and compiles
+// its templates, so a typo fails before any mail is read.
+func parseGmailResponderConfig(b []byte) (gmailResponderConfig, error) {
+	var cfg gmailResponderConfig
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return gmailResponderConfig{}, fmt.Errorf("invalid rules file: %w", err)
+	}
+	if len(cfg.Rules) == 0 {
+		return gmailResponderConfig{}, usage("rules file has no rules")
+	}
+	cfg.Label = strings.TrimSpace(cfg.Label)
+	if cfg.Label == "" {
+		cfg.Label = gmailResponderDefaultLabel
+	}
+	cfg.From = strings.TrimSpace(cfg.From)
+	for i := range cfg.Rules {
+		r := &cfg.Rules[i]
+		if strings.TrimSpace(r.Name) == "" {
+			r.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		r.Query = strings.TrimSpace(r.Query)
+		if r.Query == "" {
+			return gmailResponderConfig{}, usagef("%s: query is required", r.Name)
+		}
+		if strings.TrimSpace(r.Body) == "" && strings.TrimSpace(r.BodyHTML) == "" {
+			return gmailResponderConfig{}, usagef("%s: body or body_html is required", r.Name)
+		}
+		limit, window, err := parseGmailResponderRate(r.RateLimit)
+		if err != nil {
+			return gmailResponderConfig{}, usagef("%s: %v", r.Name, err)
+		}
+		r.limit, r.window = limit, window
+
+		if r.subject, err = template.New("subject").Option("missingkey=error").Parse(r.Subject); err != nil {
+			return gmailResponderConfig{}, usagef("%s: invalid subject template: %v", r.Name, err)
+		}
+		if r.body, err = template.New("body").Option("missingkey=error").Parse(r.Body); err != nil {
+			return gmailResponderConfig{}, usagef("%s: invalid body template: %v", r.Name, err)
+		}
+		if r.html, err = htmltemplate.New("body_html").Option("missingkey=error").Parse(r.BodyHTML); err != nil {
+			return gmailResponderConfig{}, usagef("%s: invalid body_html template: %v", r.Name, err)
+		}
+	}
+	return cfg, nil
+}
+
+// parseGmailResponderRate parses "N/window" (1/24h, 3/7d, 1/30m). Empty
+// means one reply per sender per day; "none" disables the limit.
+func parseGmailResponderRate(expr string) (int, time.Duration, error) {
+	expr = strings.ToLower(strings.TrimSpace(expr))
+	switch expr {
+	case "":
+		return 1, 24 * time.Hour, nil
+	case "none":
+		return 0, 0, nil
+	}
+	m := gmailResponderRateRe.FindStringSubmatch(expr)
+	if m == nil {
+		return 0, 0, fmt.Errorf("invalid rate_limit %q (use e.g. 1/24h, 3/7d, or none)", expr)
+	}
+	n, _ := strconv.Atoi(m[1])
+	span, _ := strconv.Atoi(m[2])
+	if n <= 0 || span <= 0 {
+		return 0, 0, fmt.Errorf("invalid rate_limit %q: count and window must be > 0", expr)
+	}
+	unit := map[string]time.Duration{"m": time.Minute, "h": time.Hour, "d": 24 * time.Hour, "w": 7 * 24 * time.Hour}[m[3]]
+	return n, time.Duration(span) * unit, nil
+}
+
+// runGmailResponder does one pass over all rules. Matches that are bulk
+// mail or over the sender's rate limit are still labeled, so they are not
+// reconsidered on every poll.
+func runGmailResponder(ctx context.Context, svc *gmail.Service, account string, cfg gmailResponderConfig, state *gmailResponderState, statePath string, limit int64, now func() time.Time) (gmailResponderSummary, error) {
+	summary := gmailResponderSummary{Label: cfg.Label}
+
+	sendAsList, sendAsListErr := listSendAs(ctx, svc)
+	from, err := resolveComposeFrom(ctx, svc, account, cfg.From, sendAsList, sendAsListErr)
+	if err != nil {
+		return summary, err
+	}
+	labelID, err := ensureLabelExists(ctx, svc, cfg.Label)
+	if err != nil {
+		return summary, err
+	}
+	selfAddrs := []string{account}
+	if from.sendingEmail != "" && !strings.EqualFold(from.sendingEmail, account) {
+		selfAddrs = append(selfAddrs, from.sendingEmail)
+	}
+	exclude := fmt.Sprintf(" -label:%s", strings.ReplaceAll(cfg.Label, " ", "-"))
+
+	claimed := map[string]bool{}
+	for i := range cfg.Rules {
+		rule := &cfg.Rules[i]
+		ids, err := searchMessageIDs(ctx, svc, rule.Query+exclude, limit)
+		if err != nil {
+			return summary, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		for _, id := range ids {
+			if claimed[id] {
+				continue
+			}
+			claimed[id] = true
+			summary.Matched++
+
+			result, err := respondToGmailMessage(ctx, svc, from.header, selfAddrs, labelID, rule, id, state, now())
+			if err != nil {
+				return summary, fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+			summary.Results = append(summary.Results, result)
+			if result.Action != "replied" {
+				summary.Skipped++
+				continue
+			}
+			summary.Replied++
+			if err := saveGmailResponderState(statePath, state); err != nil {
+				return summary, fmt.Errorf("saving responder state: %w", err)
+			}
+		}
+	}
+	return summary, nil
+}
+
+func respondToGmailMessage(ctx context.Context, svc *gmail.Service, fromHeader string, selfAddrs []string, labelID string, rule *gmailResponderRule, messageID string, state *gmailResponderState, now time.Time) (gmailResponderResult, error) {
+	result := gmailResponderResult{Rule: rule.Name, MessageID: messageID, Action: autoReplyActionSkipped}
+	msg, err := fetchMessageForAutoReply(ctx, svc, messageID)
+	if err != nil {
+		return result, err
+	}
+	if msg == nil {
+		result.Reason = "missing_message"
+		return result, nil
+	}
+	result.ThreadID = msg.ThreadId
+	result.Subject = headerValue(msg.Payload, "Subject")
+	if hasMessageLabel(msg, labelID) {
+		result.Reason = "already_labeled"
+		return result, nil
+	}
+
+	replyMeta := replyInfoFromMessage(msg, false)
+	recipients := autoReplyRecipients(replyMeta, selfAddrs)
+	skip, reason := shouldSkipAutoReplyMessage(msg)
+	switch {
+	case skip:
+		result.Reason = reason
+	case len(recipients) == 0:
+		result.Reason = "no_reply_recipient"
+	default:
+		result.Sender = strings.ToLower(recipients[0])
+		if !state.allow(rule, result.Sender, now) {
+			result.Reason = "rate_limited"
+		}
+	}
+	if result.Reason != "" {
+		return result, modifyAutoReplyThread(ctx, svc, msg.ThreadId, labelID, false, false)
+	}
+
+	data := gmailResponderTemplateData(msg, rule.Name)
+	subject, body, bodyHTML, err := rule.render(data)
+	if err != nil {
+		return result, err
+	}
+	sendResults, err := sendGmailBatches(ctx, svc, sendMessageOptions{
+		FromAddr:  fromHeader,
+		ReplyTo:   strings.TrimSpace(rule.ReplyTo),
+		Subject:   autoReplySubject(subject, result.Subject),
+		Body:      body,
+		BodyHTML:  bodyHTML,
+		ReplyInfo: replyMeta,
+		Headers: map[string]string{
+			"Auto-Submitted":           "auto-replied",
+			"X-Auto-Response-Suppress": "All",
+		},
+	}, []sendBatch{{To: recipients}})
+	if err != nil {
+		return result, err
+	}
+	state.record(rule.Name, result.Sender, now)
+	if len(sendResults) > 0 {
+		result.ReplyMessageID = sendResults[0].MessageID
+	}
+	if err := modifyAutoReplyThread(ctx, svc, msg.ThreadId, labelID, rule.Archive, rule.MarkRead); err != nil {
+		return result, err
+	}
+	result.Action = "replied"
+	return result, nil
+}
+
+func gmailResponderTemplateData(msg *gmail.Message, rule string) gmailResponderData {
+	data := gmailResponderData{
+		From:    headerValue(msg.Payload, "From"),
+		Subject: headerValue(msg.Payload, "Subject"),
+		Date:    headerValue(msg.Payload, "Date"),
+		Rule:    rule,
+	}
+	if addr, err := mail.ParseAddress(data.From); err == nil {
+		data.FromName, data.FromEmail = addr.Name, addr.Address
+	} else {
+		data.FromEmail = strings.TrimSpace(data.From)
+	}
+	if data.FromName == "" {
+		data.FromName = data.FromEmail
+	}
+	return data
+}
+
+func (r *gmailResponderRule) render(data gmailResponderData) (subject, body, bodyHTML string, err error) {
+	var b bytes.Buffer
+	if err := r.subject.Execute(&b, data); err != nil {
+		return "", "", "", fmt.Errorf("subject template: %w", err)
+	}
+	subject = strings.Join(strings.Fields(b.String()), " ")
+	b.Reset()
+	if err := r.body.Execute(&b, data); err != nil {
+		return "", "", "", fmt.Errorf("body template: %w", err)
+	}
+	body = b.String()
+	b.Reset()
+	if err := r.html.Execute(&b, data); err != nil {
+		return "", "", "", fmt.Errorf("body_html template: %w", err)
+	}
+	return subject, body, b.String(), nil
+}
+
+// allow reports whether sender is under the rule's limit, pruning replies
+// that have aged out of the window.
+func (s *gmailResponderState) allow(rule *gmailResponderRule, sender string, now time.Time) bool {
+	if rule.limit == 0 {
+		return true
+	}
+	var recent []time.Time
+	for _, t := range s.Replies[rule.Name][sender] {
+		if now.Sub(t) < rule.window {
+			recent = append(recent, t)
+		}
+	}
+	if s.Replies[rule.Name] != nil {
+		if len(recent) == 0 {
+			delete(s.Replies[rule.Name], sender)
+		} else {
+			s.Replies[rule.Name][sender] = recent
+		}
+	}
+	return len(recent) < rule.limit
+}
+
+func (s *gmailResponderState) record(rule, sender string, now time.Time) {
+	if s.Replies == nil {
+		s.Replies = map[string]map[string][]time.Time{}
+	}
+	if s.Replies[rule] == nil {
+		s.Replies[rule] = map[string][]time.Time{}
+	}
+	s.Replies[rule][sender] = append(s.Replies[rule][sender], now.UTC())
+}
+
+func gmailResponderStatePath(account string) (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "state", "gmail-responder", sanitizeAccountForPath(account)+".json"), nil
+}
+
+func loadGmailResponderState(path, account string) (*gmailResponderState, error) {
+	state := &gmailResponderState{Account: account}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parsing state file %s: %w", path, err)
+	}
+	if !strings.EqualFold(state.Account, account) {
+		return nil, usagef("state file %s belongs to %s, not %s", path, state.Account, account)
+	}
+	return state, nil
+}
+
+// saveGmailResponderState writes through a temp file so an interrupted run
+// never leaves a truncated state file behind.
+func saveGmailResponderState(path string, state *gmailResponderState) error {
+	payload, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(payload, '\n'), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
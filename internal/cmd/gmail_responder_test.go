@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseGmailResponderRate(t *testing.T) {
+	for expr, want := range map[string]struct {
+		n int
+		d time.Duration
+	}{
+		"":       {1, 24 * time.Hour},
+		"none":   {0, 0},
+		"3/7d":   {3, 7 * 24 * time.Hour},
+		"1/30m":  {1, 30 * time.Minute},
+		"2 / 1w": {2, 7 * 24 * time.Hour},
+	} {
+		n, d, err := parseGmailResponderRate(expr)
+		if err != nil || n != want.n || d != want.d {
+			t.Fatalf("parseGmailResponderRate(%q) = %d, %v, %v", expr, n, d, err)
+		}
+	}
+	for _, expr := range []string{"daily", "0/1h", "1/0h", "1/2y"} {
+		if _, _, err := parseGmailResponderRate(expr); err == nil {
+			t.Fatalf("expected error for %q", expr)
+		}
+	}
+}
+
+func TestParseGmailResponderConfig_Errors(t *testing.T) {
+	for input, want := range map[string]string{
+		"rules: []":            "no rules",
+		"rules:\n  - body: hi": "query is required",
+		"rules:\n  - query: x": "body or body_html is required",
+		"rules:\n  - query: x\n    body: '{{.Nope'": "invalid body template",
+	} {
+		if _, err := parseGmailResponderConfig([]byte(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("%q: expected %q error, got %v", input, want, err)
+		}
+	}
+}
+
+func TestRunGmailResponder_TemplatesAndRateLimits(t *testing.T) {
+	var sent []string
+	var queries []string
+	modified := map[string][]any{}
+
+	messages := map[string]map[string]any{
+		"m1": {"from": "Ada Lovelace <ada@example.com>", "subject": "Printer broken", "thread": "t1"},
+		"m2": {"from": "ada@example.com", "subject": "Still broken", "thread": "t2"},
+		"m3": {"from": "news@example.com", "subject": "Weekly", "thread": "t3", "list": true},
+	}
+	svc, cleanup := newGmailServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/gmail/v1")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && path == "/users/me/settings/sendAs":
+			_ = json.NewEncoder(w).Encode(map[string]any{"sendAs": []map[string]any{}})
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "/users/me/labels"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"labels": []map[string]any{
+				{"id": "Label_9", "name": "Support Acked", "type": "user"},
+			}})
+		case r.Method == http.MethodGet && path == "/users/me/messages":
+			queries = append(queries, r.URL.Query().Get("q"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"messages": []map[string]any{
+				{"id": "m1"}, {"id": "m2"}, {"id": "m3"},
+			}})
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "/users/me/messages/"):
+			id := strings.TrimPrefix(path, "/users/me/messages/")
+			m := messages[id]
+			headers := []map[string]any{
+				{"name": "From", "value": m["from"]},
+				{"name": "Subject", "value": m["subject"]},
+				{"name": "Message-ID", "value": "<" + id + "@example.com>"},
+			}
+			if m["list"] == true {
+				headers = append(headers, map[string]any{"name": "List-Id", "value": "<news.example.com>"})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id": id, "threadId": m["thread"], "payload": map[string]any{"headers": headers},
+			})
+		case r.Method == http.MethodPost && path == "/users/me/messages/send":
+			var payload map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			raw, _ := base64.RawURLEncoding.DecodeString(payload["raw"].(string))
+			sent = append(sent, string(raw))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "reply1", "threadId": "t1"})
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/modify"):
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			thread := strings.TrimSuffix(strings.TrimPrefix(path, "/users/me/threads/"), "/modify")
+			modified[thread], _ = body["removeLabelIds"].([]any)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": thread})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer cleanup()
+
+	cfg, err := parseGmailResponderConfig([]byte(`
+label: Support Acked
+rules:
+  - name: support
+    query: to:support@example.com
+    body: "Hi {{.FromName}}, we received {{.Subject}}."
+    rate_limit: 1/24h
+    mark_read: true
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	statePath := filepath.Join(t.TempDir(), "responder.json")
+	state, err := loadGmailResponderState(statePath, "me@example.com")
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	now := time.Date(2025, 3, 7, 10, 0, 0, 0, time.UTC)
+	summary, err := runGmailResponder(context.Background(), svc, "me@example.com", cfg, state, statePath, 10, func() time.Time { return now })
+	if err != nil {
+		t.Fatalf("runGmailResponder: %v", err)
+	}
+	if queries[0] != "to:support@example.com -label:Support-Acked" {
+		t.Fatalf("unexpected query: %q", queries[0])
+	}
+	if summary.Replied != 1 || summary.Skipped != 2 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	if summary.Results[1].Reason != "rate_limited" || summary.Results[2].Reason != "list_id" {
+		t.Fatalf("unexpected skip reasons: %+v", summary.Results)
+	}
+	if len(sent) != 1 || !strings.Contains(sent[0], "Hi Ada Lovelace, we received Printer broken.") || !strings.Contains(sent[0], "Subject: Re: Printer broken") {
+		t.Fatalf("unexpected reply: %v", sent)
+	}
+	if len(modified) != 3 || len(modified["t1"]) != 1 || len(modified["t2"]) != 0 || len(modified["t3"]) != 0 {
+		t.Fatalf("expected every match labeled and only the reply marked read: %v", modified)
+	}
+
+	// The limit survives a restart and expires with its window.
+	reloaded, err := loadGmailResponderState(statePath, "me@example.com")
+	if err != nil {
+		t.Fatalf("reload state: %v", err)
+	}
+	if reloaded.allow(&cfg.Rules[0], "ada@example.com", now.Add(time.Hour)) {
+		t.Fatal("expected sender to stay rate limited after reload")
+	}
+	if !reloaded.allow(&cfg.Rules[0], "ada@example.com", now.Add(25*time.Hour)) {
+		t.Fatal("expected rate limit to expire after its window")
+	}
+	if _, err := loadGmailResponderState(statePath, "other@example.com"); err == nil || !strings.Contains(err.Error(), "belongs to") {
+		t.Fatalf("expected account mismatch error, got %v", err)
+	}
+}
@@ -7,13 +7,16 @@ import (
 	"html"
 	"net/mail"
 	"os"
+	"regexp"
 	"strings"
+	"time"
 
 	"google.golang.org/api/gmail/v1"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/tracking"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/gmailmime"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type GmailSendCmd struct {
@@ -29,9 +32,12 @@ type GmailSendCmd struct {
 	ReplyAll         bool     `name:"reply-all" help:"Auto-populate recipients from original message (requires --reply-to-message-id or --thread-id)"`
 	ReplyTo          string   `name:"reply-to" help:"Reply-To header address"`
 	Attach           []string `name:"attach" help:"Attachment file path (repeatable)"`
+	ViaDrive         bool     `name:"via-drive" help:"Upload attachments that would exceed Gmail's 25MB limit to Drive, share them, and link them in the body"`
+	DriveShare       string   `name:"drive-share" help:"How --via-drive shares uploads: recipients (reader access for each recipient) or anyone (with the link; asks for confirmation)" enum:"recipients,anyone" default:"recipients"`
 	From             string   `name:"from" help:"Send from this email address (must be a verified send-as alias)"`
 	Track            bool     `name:"track" help:"Enable open tracking (requires tracking setup)"`
 	TrackSplit       bool     `name:"track-split" help:"Send tracked messages separately per recipient"`
+	TrackClicks      bool     `name:"track-clicks" help:"Also route http(s) links through the tracking endpoint to record clicks (tracking worker or 'gog hooks serve'; requires --track)"`
 	Quote            bool     `name:"quote" help:"Include quoted original message in reply (requires --reply-to-message-id or --thread-id)"`
 }
 
@@ -57,8 +63,9 @@ type sendMessageOptions struct {
 	BodyHTML    string
 	ReplyInfo   *replyInfo
 	Headers     map[string]string
-	Attachments []mailAttachment
+	Attachments []gmailmime.Attachment
 	Track       bool
+	TrackClicks bool
 	TrackingCfg *tracking.Config
 }
 
@@ -100,6 +107,9 @@ func (c *GmailSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if c.TrackSplit && !c.Track {
 		return usage("--track-split requires --track")
 	}
+	if c.TrackClicks && !c.Track {
+		return usage("--track-clicks requires --track")
+	}
 	if c.Track && strings.TrimSpace(c.BodyHTML) == "" {
 		return fmt.Errorf("--track requires --body-html (pixel must be in HTML)")
 	}
@@ -108,6 +118,13 @@ func (c *GmailSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	var driveFiles []gmailDriveAttachment
+	if c.ViaDrive {
+		attachPaths, driveFiles, err = splitDriveAttachments(attachPaths, gmailAttachmentLimit)
+		if err != nil {
+			return err
+		}
+	}
 
 	if dryRunErr := dryRunExit(ctx, flags, "gmail.send", map[string]any{
 		"to":                  splitCSV(c.To),
@@ -122,12 +139,21 @@ func (c *GmailSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 		"body_len":            len(strings.TrimSpace(body)),
 		"body_html_len":       len(strings.TrimSpace(c.BodyHTML)),
 		"attachments":         attachPaths,
+		"via_drive":           driveFiles,
+		"drive_share":         c.DriveShare,
 		"track":               c.Track,
 		"track_split":         c.TrackSplit,
+		"track_clicks":        c.TrackClicks,
 	}); dryRunErr != nil {
 		return dryRunErr
 	}
 
+	if len(driveFiles) > 0 && c.DriveShare != gmailDriveShareRecipients {
+		if err := confirmDestructiveChecked(ctx, flags, fmt.Sprintf("share %d --via-drive attachment%s with anyone who has the link (public)", len(driveFiles), pluralS(len(driveFiles)))); err != nil {
+			return err
+		}
+	}
+
 	account, svc, err := requireGmailService(ctx, flags)
 	if err != nil {
 		return err
@@ -165,6 +191,14 @@ func (c *GmailSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 	bccRecipients := splitCSV(c.Bcc)
 
+	if len(driveFiles) > 0 {
+		recipients := parseEmailAddresses(strings.Join(append(append(append([]string{}, toRecipients...), ccRecipients...), bccRecipients...), ", "))
+		if err := uploadDriveAttachments(ctx, flags, driveFiles, c.DriveShare, recipients); err != nil {
+			return err
+		}
+		body, htmlBody = appendDriveAttachmentLinks(body, htmlBody, driveFiles)
+	}
+
 	atts := attachmentsFromPaths(attachPaths)
 
 	var trackingCfg *tracking.Config
@@ -185,6 +219,7 @@ func (c *GmailSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 		ReplyInfo:   replyInfo,
 		Attachments: atts,
 		Track:       c.Track,
+		TrackClicks: c.TrackClicks,
 		TrackingCfg: trackingCfg,
 	}, batches)
 	if err != nil {
@@ -307,18 +342,29 @@ func sendGmailBatches(ctx context.Context, svc *gmail.Service, opts sendMessageO
 			if recipient == "" {
 				recipient = strings.TrimSpace(firstRecipient(batch.To, batch.Cc, batch.Bcc))
 			}
-			pixelURL, blob, pixelErr := tracking.GeneratePixelURL(opts.TrackingCfg, recipient, opts.Subject)
+			sentAt := time.Now().Unix()
+			pixelURL, blob, pixelErr := tracking.GeneratePixelURLAt(opts.TrackingCfg, recipient, opts.Subject, sentAt)
 			if pixelErr != nil {
 				return nil, fmt.Errorf("generate tracking pixel: %w", pixelErr)
 			}
 			trackingID = blob
 
+			if opts.TrackClicks {
+				var linkErr error
+				htmlBody, linkErr = rewriteTrackedLinksHTML(htmlBody, func(target string) (string, error) {
+					return tracking.GenerateClickURL(opts.TrackingCfg, recipient, opts.Subject, sentAt, target)
+				})
+				if linkErr != nil {
+					return nil, fmt.Errorf("generate tracking link: %w", linkErr)
+				}
+			}
+
 			// Inject pixel into HTML body (prefer before </body> / </html>)
 			pixelHTML := tracking.GeneratePixelHTML(pixelURL)
 			htmlBody = injectTrackingPixelHTML(htmlBody, pixelHTML)
 		}
 
-		raw, err := buildRFC822(mailOptions{
+		raw, err := gmailmime.Build(gmailmime.Message{
 			From:              opts.FromAddr,
 			To:                batch.To,
 			Cc:                batch.Cc,
@@ -438,6 +484,30 @@ func firstRecipient(toRecipients, ccRecipients, bccRecipients []string) string {
 	return ""
 }
 
+var trackedLinkHrefRe = regexp.MustCompile(`(?i)(<a\b[^>]*?\bhref\s*=\s*)(["'])(https?://[^"']+)(["'])`)
+
+// rewriteTrackedLinksHTML replaces the href of every http(s) anchor with the
+// URL wrap returns for it. mailto:, relative, and anchor links are kept.
+func rewriteTrackedLinksHTML(htmlBody string, wrap func(target string) (string, error)) (string, error) {
+	var firstErr error
+	out := trackedLinkHrefRe.ReplaceAllStringFunc(htmlBody, func(m string) string {
+		parts := trackedLinkHrefRe.FindStringSubmatch(m)
+		if firstErr != nil || parts[2] != parts[4] {
+			return m
+		}
+		wrapped, err := wrap(html.UnescapeString(parts[3]))
+		if err != nil {
+			firstErr = err
+			return m
+		}
+		return parts[1] + parts[2] + html.EscapeString(wrapped) + parts[4]
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return out, nil
+}
+
 func injectTrackingPixelHTML(htmlBody, pixelHTML string) string {
 	lower := strings.ToLower(htmlBody)
 	if i := strings.LastIndex(lower, "</body>"); i != -1 {
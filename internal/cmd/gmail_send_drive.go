@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// gmailAttachmentLimit is Gmail's cap on attachments per message. It applies
+// to the encoded message, so attachments are measured by gmailEncodedSize.
+var gmailAttachmentLimit int64 = 25 << 20
+
+const gmailDriveShareRecipients = "recipients"
+
+type gmailDriveAttachment struct {
+	Path string `json:"path"`
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+	ID   string `json:"id,omitempty"`
+	Link string `json:"link,omitempty"`
+}
+
+// splitDriveAttachments picks the attachments to send through Drive: every
+// file whose encoded size is over the limit, then the largest remaining ones
+// until what is left fits in the message. Paths keep their original order.
+func splitDriveAttachments(paths []string, limit int64) (keep []string, viaDrive []gmailDriveAttachment, err error) {
+	sizes := make(map[string]int64, len(paths))
+	encoded := make(map[string]int64, len(paths))
+	for _, p := range paths {
+		st, statErr := os.Stat(p)
+		if statErr != nil {
+			return nil, nil, statErr
+		}
+		sizes[p] = st.Size()
+		encoded[p] = gmailEncodedSize(st.Size())
+	}
+
+	bySize := append([]string(nil), paths...)
+	sort.SliceStable(bySize, func(i, j int) bool { return sizes[bySize[i]] > sizes[bySize[j]] })
+	var total int64
+	for _, p := range paths {
+		total += encoded[p]
+	}
+	upload := make(map[string]bool)
+	for _, p := range bySize {
+		if encoded[p] <= limit && total <= limit {
+			break
+		}
+		upload[p] = true
+		total -= encoded[p]
+	}
+
+	for _, p := range paths {
+		if upload[p] {
+			viaDrive = append(viaDrive, gmailDriveAttachment{Path: p, Name: filepath.Base(p), Size: sizes[p]})
+			continue
+		}
+		keep = append(keep, p)
+	}
+	return keep, viaDrive, nil
+}
+
+// gmailEncodedSize is how many bytes an attachment of n bytes takes in the
+// message: base64 (4 bytes per 3) wrapped at 76 columns with CRLF.
+func gmailEncodedSize(n int64) int64 {
+	b64 := (n + 2) / 3 * 4
+	return b64 + 2*((b64+75)/76)
+}
+
+// uploadDriveAttachments uploads each file to the root of the account's
+// Drive and shares it: with anyone who has the link, or with each recipient
+// as a reader (without Drive's notification email).
+func uploadDriveAttachments(ctx context.Context, flags *RootFlags, files []gmailDriveAttachment, share string, recipients []string) error {
+	u := ui.FromContext(ctx)
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+
+	for i := range files {
+		f := &files[i]
+		opts, err := prepareDriveUpload(&DriveUploadCmd{LocalPath: f.Path})
+		if err != nil {
+			return err
+		}
+		created, err := uploadDriveBulkItem(ctx, svc, opts)
+		if err != nil {
+			return fmt.Errorf("upload %s to Drive: %w", f.Name, err)
+		}
+		f.ID, f.Link = created.Id, created.WebViewLink
+
+		if err := shareDriveAttachment(ctx, svc, created.Id, share, recipients); err != nil {
+			deleteDriveFileBestEffort(ctx, svc, created.Id)
+			return fmt.Errorf("share %s: %w", f.Name, err)
+		}
+		if f.Link == "" {
+			if f.Link, err = driveWebLink(ctx, svc, created.Id); err != nil {
+				return err
+			}
+		}
+		if u != nil {
+			u.Err().Printf("Uploaded %s (%s) to Drive: %s", f.Name, formatDriveSize(f.Size), f.Link)
+		}
+	}
+	return nil
+}
+
+func shareDriveAttachment(ctx context.Context, svc *drive.Service, fileID, share string, recipients []string) error {
+	if share != gmailDriveShareRecipients {
+		_, err := svc.Permissions.Create(fileID, &drive.Permission{Type: "anyone", Role: "reader"}).
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		return err
+	}
+	for _, email := range recipients {
+		_, err := svc.Permissions.Create(fileID, &drive.Permission{Type: "user", Role: "reader", EmailAddress: email}).
+			SupportsAllDrives(true).
+			SendNotificationEmail(false).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return fmt.Errorf("%s: %w", email, err)
+		}
+	}
+	return nil
+}
+
+// appendDriveAttachmentLinks adds a list of the Drive links to whichever of
+// the plain and HTML bodies are set.
+func appendDriveAttachmentLinks(body, htmlBody string, files []gmailDriveAttachment) (string, string) {
+	if len(files) == 0 {
+		return body, htmlBody
+	}
+	if body != "" {
+		var b strings.Builder
+		b.WriteString(strings.TrimRight(body, "\n"))
+		b.WriteString("\n\nShared via Google Drive:\n")
+		for _, f := range files {
+			fmt.Fprintf(&b, "- %s (%s): %s\n", f.Name, formatDriveSize(f.Size), f.Link)
+		}
+		body = b.String()
+	}
+	if htmlBody != "" {
+		var b strings.Builder
+		b.WriteString("<p>Shared via Google Drive:</p><ul>")
+		for _, f := range files {
+			fmt.Fprintf(&b, `<li><a href="%s">%s</a> (%s)</li>`, html.EscapeString(f.Link), html.EscapeString(f.Name), formatDriveSize(f.Size))
+		}
+		b.WriteString("</ul>")
+		htmlBody = injectTrackingPixelHTML(htmlBody, b.String())
+	}
+	return body, htmlBody
+}
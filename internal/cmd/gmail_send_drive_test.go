@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestSplitDriveAttachments(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, size int) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, make([]byte, size), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		return p
+	}
+	small, mid, big, huge := write("small.txt", 2), write("mid.bin", 6), write("big.bin", 8), write("huge.iso", 20)
+
+	// Encoded sizes (base64 + CRLF): small 6, mid 10, big 14, huge 30.
+	keep, viaDrive, err := splitDriveAttachments([]string{small, huge, mid, big}, 20)
+	if err != nil {
+		t.Fatalf("split: %v", err)
+	}
+	if len(keep) != 2 || keep[0] != small || keep[1] != mid {
+		t.Fatalf("unexpected keep: %v", keep)
+	}
+	if len(viaDrive) != 2 || viaDrive[0].Name != "huge.iso" || viaDrive[1].Name != "big.bin" || viaDrive[1].Size != 8 {
+		t.Fatalf("unexpected viaDrive: %#v", viaDrive)
+	}
+
+	keep, viaDrive, err = splitDriveAttachments([]string{small, mid}, 20)
+	if err != nil || len(keep) != 2 || len(viaDrive) != 0 {
+		t.Fatalf("expected everything kept, got %v %v %v", keep, viaDrive, err)
+	}
+
+	// 18 raw bytes fit a 20-byte limit, but not once base64-encoded.
+	edge := write("edge.bin", 18)
+	keep, viaDrive, err = splitDriveAttachments([]string{edge}, 20)
+	if err != nil || len(keep) != 0 || len(viaDrive) != 1 {
+		t.Fatalf("expected edge.bin via Drive, got %v %v %v", keep, viaDrive, err)
+	}
+}
+
+func TestGmailSendCmd_ViaDrive(t *testing.T) {
+	origGmail, origDrive, origLimit := newGmailService, newDriveService, gmailAttachmentLimit
+	t.Cleanup(func() { newGmailService, newDriveService, gmailAttachmentLimit = origGmail, origDrive, origLimit })
+	gmailAttachmentLimit = 16
+
+	var raw string
+	gmailSvc, cleanup := newGmailServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/gmail/v1")
+		if r.Method == http.MethodPost && path == "/users/me/messages/send" {
+			var msg gmail.Message
+			_ = json.NewDecoder(r.Body).Decode(&msg)
+			b, _ := base64.URLEncoding.DecodeString(msg.Raw)
+			raw = string(b)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "m1", "threadId": "t1"})
+			return
+		}
+		http.NotFound(w, r)
+	})
+	defer cleanup()
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return gmailSvc, nil }
+
+	var perms []drive.Permission
+	driveSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/upload/drive/v3/files":
+			_, _ = io.Copy(io.Discard, r.Body)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "f1", "name": "big.iso", "webViewLink": "https://drive.example/f1"})
+		case r.Method == http.MethodPost && r.URL.Path == "/files/f1/permissions":
+			var p drive.Permission
+			_ = json.NewDecoder(r.Body).Decode(&p)
+			perms = append(perms, p)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "p1"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer driveSrv.Close()
+	driveSvc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(driveSrv.Client()),
+		option.WithEndpoint(driveSrv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return driveSvc, nil }
+
+	dir := t.TempDir()
+	big := filepath.Join(dir, "big.iso")
+	small := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(big, []byte(strings.Repeat("x", 32)), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.WriteFile(small, []byte("hi"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+
+	_ = captureStdout(t, func() {
+		args := []string{"--to", "Ann <ann@example.com>", "--subject", "Files", "--body", "See attached.", "--attach", big, "--attach", small, "--via-drive", "--drive-share", "recipients"}
+		if err := runKong(t, &GmailSendCmd{}, args, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("send: %v", err)
+		}
+	})
+
+	if len(perms) != 1 || perms[0].Type != "user" || perms[0].EmailAddress != "ann@example.com" || perms[0].Role != "reader" {
+		t.Fatalf("unexpected permissions: %#v", perms)
+	}
+	if !strings.Contains(raw, "Shared via Google Drive:") || !strings.Contains(raw, "big.iso (32 B): https://drive.example/f1") {
+		t.Fatalf("expected Drive link in body, got:\n%s", raw)
+	}
+	if strings.Contains(raw, `filename="big.iso"`) || !strings.Contains(raw, `filename="notes.txt"`) {
+		t.Fatalf("expected only notes.txt attached, got:\n%s", raw)
+	}
+
+	// Public links need an explicit confirmation.
+	perms, raw = nil, ""
+	args := []string{"--to", "ann@example.com", "--subject", "Files", "--body", "x", "--attach", big, "--via-drive", "--drive-share", "anyone"}
+	err = runKong(t, &GmailSendCmd{}, args, ctx, &RootFlags{Account: "a@b.com", NoInput: true})
+	if err == nil || !strings.Contains(err.Error(), "without --force") {
+		t.Fatalf("expected confirmation error, got %v", err)
+	}
+	if len(perms) != 0 || raw != "" {
+		t.Fatalf("expected nothing shared or sent, got perms=%#v", perms)
+	}
+}
@@ -7,9 +7,9 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/tracking"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 func TestResolveTrackingConfig(t *testing.T) {
@@ -91,3 +91,21 @@ func TestWriteSendResults_JSONMultiple(t *testing.T) {
 		t.Fatalf("unexpected json output: %q", out)
 	}
 }
+
+func TestRewriteTrackedLinksHTML(t *testing.T) {
+	in := `<p><a href="https://example.com/?a=1&amp;b=2">x</a> <a href='mailto:me@example.com'>m</a> <A HREF="#top">t</A></p>`
+	var targets []string
+	out, err := rewriteTrackedLinksHTML(in, func(target string) (string, error) {
+		targets = append(targets, target)
+		return "https://track.example/c/blob?x=1&y=2", nil
+	})
+	if err != nil {
+		t.Fatalf("rewrite: %v", err)
+	}
+	if len(targets) != 1 || targets[0] != "https://example.com/?a=1&b=2" {
+		t.Fatalf("unexpected targets: %v", targets)
+	}
+	if !strings.Contains(out, `href="https://track.example/c/blob?x=1&amp;y=2"`) || !strings.Contains(out, "mailto:me@example.com") || !strings.Contains(out, `"#top"`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
@@ -20,8 +20,8 @@ import (
 	"google.golang.org/api/gmail/v1"
 
 	"github.com/steipete/gogcli/internal/config"
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 // HTML stripping patterns for cleaner text output.
@@ -212,7 +212,7 @@ func (c *GmailThreadModifyCmd) Run(ctx context.Context, flags *RootFlags) error
 		return err
 	}
 
-	addIDs, removeIDs, err := resolveModifyLabelIDs(svc, addLabels, removeLabels)
+	addIDs, removeIDs, err := resolveModifyLabelIDs(ctx, svc, addLabels, removeLabels)
 	if err != nil {
 		return err
 	}
@@ -5,4 +5,5 @@ type GmailTrackCmd struct {
 	Setup  GmailTrackSetupCmd  `cmd:"" help:"Set up email tracking (deploy Cloudflare Worker)"`
 	Opens  GmailTrackOpensCmd  `cmd:"" help:"Query email opens"`
 	Status GmailTrackStatusCmd `cmd:"" help:"Show tracking configuration status"`
+	Report GmailTrackReportCmd `cmd:"" help:"Summarize opens and clicks recorded by 'gog hooks serve'"`
 }
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steipete/gogcli/internal/tracking"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// GmailTrackReportCmd summarizes opens and clicks recorded by
+// `gog hooks serve`, one row per tracked message.
+type GmailTrackReportCmd struct {
+	To    string `name:"to" help:"Filter by recipient email"`
+	Since string `name:"since" help:"Only activity after this time (e.g., '24h', '2024-01-01')"`
+}
+
+type gmailTrackReportRow struct {
+	TrackingID   string   `json:"tracking_id,omitempty"`
+	Recipient    string   `json:"recipient"`
+	SubjectHash  string   `json:"subject_hash"`
+	SentAt       string   `json:"sent_at"`
+	Opens        int      `json:"opens"`
+	Clicks       int      `json:"clicks"`
+	FirstOpen    string   `json:"first_open,omitempty"`
+	LastActivity string   `json:"last_activity"`
+	URLs         []string `json:"urls,omitempty"`
+}
+
+func (c *GmailTrackReportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	var since time.Time
+	if strings.TrimSpace(c.Since) != "" {
+		s, err := parseTrackingSince(c.Since)
+		if err != nil {
+			return err
+		}
+		since, _ = time.Parse(time.RFC3339Nano, s)
+	}
+
+	path, err := tracking.EventLogPath(account)
+	if err != nil {
+		return err
+	}
+	events, err := tracking.ReadEvents(path)
+	if err != nil {
+		return err
+	}
+	rows := gmailTrackReport(events, strings.TrimSpace(c.To), since)
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"messages": rows, "log": path})
+	}
+	if len(rows) == 0 {
+		u.Err().Printf("No tracked activity in %s (events are recorded by `gog hooks serve`)", path)
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "RECIPIENT\tSENT\tOPENS\tCLICKS\tFIRST_OPEN\tLAST_ACTIVITY\tURLS")
+	for _, r := range rows {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\t%s\n", r.Recipient, humanDateTime(ctx, r.SentAt), r.Opens, r.Clicks,
+			humanDateTime(ctx, r.FirstOpen), humanDateTime(ctx, r.LastActivity), strings.Join(r.URLs, " "))
+	}
+	return nil
+}
+
+// gmailTrackReport groups events by sent message (recipient, subject hash,
+// send time), most recent activity first.
+func gmailTrackReport(events []tracking.Event, to string, since time.Time) []gmailTrackReportRow {
+	type key struct {
+		recipient, subject string
+		sentAt             int64
+	}
+	byMsg := map[key]*gmailTrackReportRow{}
+	for _, ev := range events {
+		if to != "" && !strings.EqualFold(ev.Recipient, to) {
+			continue
+		}
+		if !since.IsZero() {
+			if at, err := time.Parse(time.RFC3339, ev.At); err != nil || at.Before(since) {
+				continue
+			}
+		}
+		k := key{strings.ToLower(ev.Recipient), ev.SubjectHash, ev.SentAt}
+		row, ok := byMsg[k]
+		if !ok {
+			row = &gmailTrackReportRow{Recipient: ev.Recipient, SubjectHash: ev.SubjectHash}
+			if ev.SentAt > 0 {
+				row.SentAt = time.Unix(ev.SentAt, 0).UTC().Format(time.RFC3339)
+			}
+			byMsg[k] = row
+		}
+		switch ev.Kind {
+		case tracking.EventOpen:
+			row.Opens++
+			if row.TrackingID == "" {
+				row.TrackingID = ev.TrackingID
+			}
+			if row.FirstOpen == "" || ev.At < row.FirstOpen {
+				row.FirstOpen = ev.At
+			}
+		case tracking.EventClick:
+			row.Clicks++
+			if ev.URL != "" && !slices.Contains(row.URLs, ev.URL) {
+				row.URLs = append(row.URLs, ev.URL)
+			}
+		}
+		if ev.At > row.LastActivity {
+			row.LastActivity = ev.At
+		}
+	}
+	rows := make([]gmailTrackReportRow, 0, len(byMsg))
+	for _, row := range byMsg {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].LastActivity != rows[j].LastActivity {
+			return rows[i].LastActivity > rows[j].LastActivity
+		}
+		return rows[i].Recipient < rows[j].Recipient
+	})
+	return rows
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/steipete/gogcli/internal/tracking"
+)
+
+func TestGmailTrackReport(t *testing.T) {
+	setupTrackingEnv(t)
+
+	path, err := tracking.EventLogPath("a@b.com")
+	if err != nil {
+		t.Fatalf("EventLogPath: %v", err)
+	}
+	log := &tracking.EventLog{Path: path}
+	for _, ev := range []tracking.Event{
+		{Kind: tracking.EventOpen, At: "2025-01-02T10:00:00Z", TrackingID: "tid1", Recipient: "x@example.com", SubjectHash: "s1", SentAt: 1735800000},
+		{Kind: tracking.EventOpen, At: "2025-01-02T09:00:00Z", TrackingID: "tid1", Recipient: "x@example.com", SubjectHash: "s1", SentAt: 1735800000},
+		{Kind: tracking.EventClick, At: "2025-01-02T11:00:00Z", Recipient: "X@example.com", SubjectHash: "s1", SentAt: 1735800000, URL: "https://example.com/a"},
+		{Kind: tracking.EventClick, At: "2025-01-02T11:05:00Z", Recipient: "x@example.com", SubjectHash: "s1", SentAt: 1735800000, URL: "https://example.com/a"},
+		{Kind: tracking.EventOpen, At: "2024-06-01T00:00:00Z", TrackingID: "tid2", Recipient: "y@example.com", SubjectHash: "s2", SentAt: 1717000000},
+	} {
+		if err := log.Append(ev); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "gmail", "track", "report", "--since", "2025-01-01"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	var report struct {
+		Messages []gmailTrackReportRow `json:"messages"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(report.Messages) != 1 {
+		t.Fatalf("expected one message after --since, got %s", out)
+	}
+	got := report.Messages[0]
+	if got.TrackingID != "tid1" || got.Opens != 2 || got.Clicks != 2 || got.FirstOpen != "2025-01-02T09:00:00Z" ||
+		got.LastActivity != "2025-01-02T11:05:00Z" || len(got.URLs) != 1 || got.SentAt != "2025-01-02T06:40:00Z" {
+		t.Fatalf("unexpected row: %+v", got)
+	}
+
+	text := captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "gmail", "track", "report", "--to", "y@example.com"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if !strings.Contains(text, "y@example.com") || strings.Contains(text, "x@example.com") {
+		t.Fatalf("unexpected table: %q", text)
+	}
+}
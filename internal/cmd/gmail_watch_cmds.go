@@ -15,8 +15,8 @@ import (
 	"google.golang.org/api/idtoken"
 
 	"github.com/steipete/gogcli/internal/authclient"
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 var (
@@ -11,8 +11,8 @@ import (
 
 	"github.com/steipete/gogcli/internal/errfmt"
 	"github.com/steipete/gogcli/internal/googleapi"
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 var newCloudIdentityService = googleapi.NewCloudIdentityGroups
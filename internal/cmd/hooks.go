@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steipete/gogcli/internal/tracking"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// HooksCmd groups self-hosted HTTP endpoints.
+type HooksCmd struct {
+	Serve HooksServeCmd `cmd:"" help:"Serve the email tracking pixel and click redirects, recording opens/clicks locally"`
+}
+
+// HooksServeCmd is a self-hosted alternative to the Cloudflare tracking
+// worker. Point `gmail track setup --worker-url` at wherever this server is
+// reachable (reverse proxy or tunnel); events go to a local NDJSON log that
+// `gmail track report` summarizes.
+type HooksServeCmd struct {
+	Bind string `name:"bind" help:"Bind address" default:"127.0.0.1"`
+	Port int    `name:"port" help:"Listen port" default:"8787"`
+}
+
+func (c *HooksServeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Port <= 0 || c.Port > 65535 {
+		return usage("--port must be between 1 and 65535")
+	}
+	account, cfg, err := loadTrackingConfigForAccount(flags)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(cfg.TrackingKey) == "" {
+		return fmt.Errorf("tracking not configured; run 'gog gmail track setup --worker-url <public url of this server>' first")
+	}
+	logPath, err := tracking.EventLogPath(account)
+	if err != nil {
+		return err
+	}
+	events := &tracking.EventLog{Path: logPath}
+
+	record := func(ev tracking.Event) error {
+		if err := events.Append(ev); err != nil {
+			return err
+		}
+		u.Err().Printf("hooks: %s %s %s", ev.Kind, ev.Recipient, ev.URL)
+		return nil
+	}
+
+	addr := net.JoinHostPort(c.Bind, strconv.Itoa(c.Port))
+	u.Err().Printf("hooks: listening on %s (public URL %s); events -> %s", addr, cfg.WorkerURL, logPath)
+
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           tracking.Handler(cfg.TrackingKey, record, u.Err().Printf),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return listenAndServe(httpServer)
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/steipete/gogcli/internal/tracking"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func TestHooksServeCmd_Validation(t *testing.T) {
+	setupTrackingEnv(t)
+
+	origListen := listenAndServe
+	t.Cleanup(func() { listenAndServe = origListen })
+	listenAndServe = func(*http.Server) error {
+		t.Fatalf("server must not start")
+		return nil
+	}
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := ui.WithUI(context.Background(), u)
+
+	if err := runKong(t, &HooksServeCmd{}, []string{"--port", "0"}, ctx, &RootFlags{Account: "a@b.com"}); err == nil || !strings.Contains(err.Error(), "--port") {
+		t.Fatalf("expected port error, got %v", err)
+	}
+	if err := runKong(t, &HooksServeCmd{}, nil, ctx, &RootFlags{Account: "a@b.com"}); err == nil || !strings.Contains(err.Error(), "tracking not configured") {
+		t.Fatalf("expected setup error, got %v", err)
+	}
+}
+
+func TestHooksServeCmd_RecordsClicks(t *testing.T) {
+	setupTrackingEnv(t)
+
+	_ = captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--account", "a@b.com", "--no-input", "gmail", "track", "setup", "--worker-url", "https://t.example.com"}); err != nil {
+				t.Fatalf("setup: %v", err)
+			}
+		})
+	})
+
+	origListen := listenAndServe
+	t.Cleanup(func() { listenAndServe = origListen })
+	var srv *http.Server
+	listenAndServe = func(s *http.Server) error {
+		srv = s
+		return nil
+	}
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	if err := runKong(t, &HooksServeCmd{}, []string{"--port", "9999"}, ui.WithUI(context.Background(), u), &RootFlags{Account: "a@b.com"}); err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+	if srv == nil || srv.Addr != "127.0.0.1:9999" {
+		t.Fatalf("unexpected server: %#v", srv)
+	}
+
+	cfg, err := tracking.LoadConfig("a@b.com")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	clickURL, err := tracking.GenerateClickURL(cfg, "x@example.com", "Hi", 1735800000, "https://example.com/a")
+	if err != nil {
+		t.Fatalf("GenerateClickURL: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, strings.TrimPrefix(clickURL, cfg.WorkerURL), nil))
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != "https://example.com/a" {
+		t.Fatalf("unexpected response: %d %v", rec.Code, rec.Header())
+	}
+
+	logPath, err := tracking.EventLogPath("a@b.com")
+	if err != nil {
+		t.Fatalf("EventLogPath: %v", err)
+	}
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read event log: %v", err)
+	}
+	if !strings.Contains(string(data), `"kind":"click"`) || !strings.Contains(string(data), "https://example.com/a") {
+		t.Fatalf("unexpected event log: %s", data)
+	}
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	ggoogleapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// InspectCmd works out what an opaque ID (or Google URL) refers to by
+// asking each service in turn.
+type InspectCmd struct {
+	Target string `arg:"" name:"id" help:"ID or Google URL to identify"`
+}
+
+type inspectResult struct {
+	ID       string `json:"id"`
+	Service  string `json:"service"`
+	Type     string `json:"type"`
+	Title    string `json:"title,omitempty"`
+	Owner    string `json:"owner,omitempty"`
+	Link     string `json:"link,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+	ThreadID string `json:"threadId,omitempty"`
+}
+
+// inspectProbe looks an ID up in one service. A 404 or 400 from lookup
+// means the ID is not of that kind.
+type inspectProbe struct {
+	service string
+	lookup  func(ctx context.Context, account, target string) (*inspectResult, error)
+}
+
+var inspectDriveTypes = map[string]string{
+	driveMimeGoogleDoc:                      "doc",
+	driveMimeGoogleSheet:                    "sheet",
+	driveMimeGoogleSlides:                   "slides",
+	driveMimeGoogleDrawing:                  "drawing",
+	driveMimeFolder:                         "folder",
+	driveMimeShortcut:                       "shortcut",
+	"application/vnd.google-apps.form":      "form",
+	"application/vnd.google-apps.script":    "apps-script",
+	"application/vnd.google-apps.site":      "site",
+	"application/vnd.google-apps.jam":       "jamboard",
+	"application/vnd.google-apps.map":       "map",
+	"application/vnd.google-apps.vid":       "vid",
+	"application/vnd.google-apps.drive-sdk": "app-file",
+	"application/vnd.google-apps.unknown":   strFile,
+}
+
+func (c *InspectCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	target := strings.TrimSpace(c.Target)
+	if target == "" {
+		return usage("empty id")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	var notFound error
+	var skipped []string
+	for _, probe := range inspectProbesFor(target) {
+		res, probeErr := probe.lookup(ctx, account, target)
+		if probeErr != nil {
+			if isInspectMiss(probeErr) {
+				notFound = probeErr
+				continue
+			}
+			// A missing scope or disabled API only rules out this service.
+			skipped = append(skipped, fmt.Sprintf("%s: %v", probe.service, probeErr))
+			continue
+		}
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(ctx, os.Stdout, res)
+		}
+		u.Out().Printf("type\t%s", res.Type)
+		u.Out().Printf("service\t%s", res.Service)
+		u.Out().Printf("id\t%s", res.ID)
+		if res.Title != "" {
+			u.Out().Printf("title\t%s", res.Title)
+		}
+		if res.Owner != "" {
+			u.Out().Printf("owner\t%s", res.Owner)
+		}
+		if res.MimeType != "" {
+			u.Out().Printf("mime\t%s", res.MimeType)
+		}
+		if res.ThreadID != "" && res.ThreadID != res.ID {
+			u.Out().Printf("thread\t%s", res.ThreadID)
+		}
+		if res.Link != "" {
+			u.Out().Printf("link\t%s", res.Link)
+		}
+		return nil
+	}
+
+	for _, s := range skipped {
+		u.Err().Printf("skipped %s", s)
+	}
+	msg := fmt.Sprintf("%s is not a Drive file, Gmail message/thread, Calendar event, or calendar visible to %s", target, account)
+	if notFound != nil {
+		return fmt.Errorf("%s: %w", msg, notFound)
+	}
+	return errors.New(msg)
+}
+
+// inspectProbesFor orders the probes: a recognized URL goes straight to its
+// service, and Gmail is only asked about IDs in its hex format.
+func inspectProbesFor(target string) []inspectProbe {
+	drive := inspectProbe{"drive", inspectDrive}
+	gmail := inspectProbe{"gmail", inspectGmail}
+	event := inspectProbe{"calendar", inspectCalendarEvent}
+	cal := inspectProbe{"calendar", inspectCalendar}
+
+	if u := parseMaybeURL(target); u != nil {
+		switch strings.TrimPrefix(strings.ToLower(u.Host), "www.") {
+		case "mail.google.com", "gmail.google.com":
+			return []inspectProbe{gmail}
+		case "calendar.google.com":
+			return []inspectProbe{event}
+		default:
+			return []inspectProbe{drive}
+		}
+	}
+
+	if strings.Contains(target, "@") {
+		return []inspectProbe{cal}
+	}
+	if looksLikeHexID(target) {
+		return []inspectProbe{gmail, drive, event}
+	}
+	return []inspectProbe{drive, event}
+}
+
+func isInspectMiss(err error) bool {
+	var gerr *ggoogleapi.Error
+	if errors.As(err, &gerr) {
+		return gerr.Code == http.StatusNotFound || gerr.Code == http.StatusBadRequest
+	}
+	return false
+}
+
+func inspectDrive(ctx context.Context, account, target string) (*inspectResult, error) {
+	id := normalizeGoogleID(target)
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	f, err := svc.Files.Get(id).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType, webViewLink, owners(emailAddress), driveId").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+	kind := inspectDriveTypes[f.MimeType]
+	if kind == "" {
+		kind = strFile
+	}
+	res := &inspectResult{ID: f.Id, Service: "drive", Type: kind, Title: f.Name, Link: f.WebViewLink, MimeType: f.MimeType}
+	if len(f.Owners) > 0 && f.Owners[0] != nil {
+		res.Owner = f.Owners[0].EmailAddress
+	} else if f.DriveId != "" {
+		res.Owner = "shared drive " + f.DriveId
+	}
+	return res, nil
+}
+
+// inspectGmail tries the ID as a message, then as a thread. A thread's ID
+// is the ID of its first message, so a hit as a message is reported with
+// its thread.
+func inspectGmail(ctx context.Context, account, target string) (*inspectResult, error) {
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	link := func(threadID string) string {
+		return fmt.Sprintf("https://mail.google.com/mail/?authuser=%s#all/%s", url.QueryEscape(account), threadID)
+	}
+
+	msgID := normalizeGmailMessageID(target)
+	msg, err := svc.Users.Messages.Get("me", msgID).
+		Format(gmailFormatMetadata).
+		MetadataHeaders("Subject", "From").
+		Fields("id,threadId,payload/headers").
+		Context(ctx).
+		Do()
+	if err == nil {
+		return &inspectResult{
+			ID:       msg.Id,
+			Service:  "gmail",
+			Type:     "gmail-message",
+			Title:    headerValue(msg.Payload, "Subject"),
+			Owner:    headerValue(msg.Payload, "From"),
+			Link:     link(msg.ThreadId),
+			ThreadID: msg.ThreadId,
+		}, nil
+	}
+	if !isInspectMiss(err) {
+		return nil, err
+	}
+
+	threadID := normalizeGmailThreadID(target)
+	thread, err := svc.Users.Threads.Get("me", threadID).
+		Format(gmailFormatMetadata).
+		MetadataHeaders("Subject", "From").
+		Fields("id,messages(payload/headers)").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+	res := &inspectResult{ID: thread.Id, Service: "gmail", Type: "gmail-thread", Link: link(thread.Id), ThreadID: thread.Id}
+	if len(thread.Messages) > 0 && thread.Messages[0] != nil {
+		res.Title = headerValue(thread.Messages[0].Payload, "Subject")
+		res.Owner = headerValue(thread.Messages[0].Payload, "From")
+	}
+	return res, nil
+}
+
+func inspectCalendarEvent(ctx context.Context, account, target string) (*inspectResult, error) {
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	e, err := svc.Events.Get(primaryCalendarID, normalizeCalendarEventID(target)).
+		Fields("id,summary,htmlLink,organizer(email),recurringEventId").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+	res := &inspectResult{ID: e.Id, Service: "calendar", Type: "calendar-event", Title: e.Summary, Link: e.HtmlLink}
+	if e.Organizer != nil {
+		res.Owner = e.Organizer.Email
+	}
+	return res, nil
+}
+
+func inspectCalendar(ctx context.Context, account, target string) (*inspectResult, error) {
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	cal, err := svc.Calendars.Get(target).Fields("id,summary,timeZone").Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &inspectResult{
+		ID:      cal.Id,
+		Service: "calendar",
+		Type:    "calendar",
+		Title:   cal.Summary,
+		Link:    "https://calendar.google.com/calendar/r?cid=" + url.QueryEscape(cal.Id),
+	}, nil
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+)
+
+func TestInspectProbesFor(t *testing.T) {
+	for target, want := range map[string][]string{
+		"1a2b3c4d5e6f7a8b": {"gmail", "drive", "calendar"},
+		"1AbCdEfGhIjKlMnOpQrStUvWxYz_0123456789-abcd":              {"drive", "calendar"},
+		"team@group.calendar.google.com":                           {"calendar"},
+		"https://mail.google.com/mail/u/0/#inbox/1a2b3c4d5e6f7a8b": {"gmail"},
+		"https://docs.google.com/document/d/abc123/edit":           {"drive"},
+	} {
+		var got []string
+		for _, p := range inspectProbesFor(target) {
+			got = append(got, p.service)
+		}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Fatalf("inspectProbesFor(%q) = %v, want %v", target, got, want)
+		}
+	}
+}
+
+func TestInspectCmd(t *testing.T) {
+	origDrive, origGmail, origCal := newDriveService, newGmailService, newCalendarService
+	t.Cleanup(func() { newDriveService, newGmailService, newCalendarService = origDrive, origGmail, origCal })
+
+	notFound := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 404, "message": "Not Found"}})
+	}
+	driveSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/files/doc1" {
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id": "doc1", "name": "Plan", "mimeType": driveMimeGoogleDoc,
+				"webViewLink": "https://docs.google.com/document/d/doc1/edit",
+				"owners":      []map[string]any{{"emailAddress": "owner@example.com"}},
+			})
+			return
+		}
+		notFound(w)
+	}))
+	defer driveSrv.Close()
+	gmailSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/users/me/threads/18c0ffee18c0ffee") {
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "18c0ffee18c0ffee", "messages": []map[string]any{{
+				"payload": map[string]any{"headers": []map[string]any{{"name": "Subject", "value": "Hello"}, {"name": "From", "value": "x@example.com"}}},
+			}}})
+			return
+		}
+		notFound(w)
+	}))
+	defer gmailSrv.Close()
+	calSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		notFound(w)
+	}))
+	defer calSrv.Close()
+
+	opts := func(srv *httptest.Server) []option.ClientOption {
+		return []option.ClientOption{option.WithoutAuthentication(), option.WithHTTPClient(srv.Client()), option.WithEndpoint(srv.URL + "/")}
+	}
+	driveSvc, _ := drive.NewService(context.Background(), opts(driveSrv)...)
+	gmailSvc, _ := gmail.NewService(context.Background(), opts(gmailSrv)...)
+	calSvc, _ := calendar.NewService(context.Background(), opts(calSrv)...)
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return driveSvc, nil }
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return gmailSvc, nil }
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return calSvc, nil }
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := newDocsJSONContext(t)
+
+	for id, want := range map[string]inspectResult{
+		"doc1":             {ID: "doc1", Service: "drive", Type: "doc", Title: "Plan", Owner: "owner@example.com"},
+		"18c0ffee18c0ffee": {ID: "18c0ffee18c0ffee", Service: "gmail", Type: "gmail-thread", Title: "Hello", Owner: "x@example.com"},
+	} {
+		out := captureStdout(t, func() {
+			if err := runKong(t, &InspectCmd{}, []string{id}, ctx, flags); err != nil {
+				t.Fatalf("inspect %s: %v", id, err)
+			}
+		})
+		var got inspectResult
+		if err := json.Unmarshal([]byte(out), &got); err != nil {
+			t.Fatalf("json: %v\n%s", err, out)
+		}
+		if got.ID != want.ID || got.Service != want.Service || got.Type != want.Type || got.Title != want.Title || got.Owner != want.Owner || got.Link == "" {
+			t.Fatalf("inspect %s = %+v, want %+v", id, got, want)
+		}
+	}
+
+	err := runKong(t, &InspectCmd{}, []string{"missing"}, ctx, flags)
+	if err == nil || !strings.Contains(err.Error(), "is not a Drive file") || ExitCode(stableExitCode(err)) != exitCodeNotFound {
+		t.Fatalf("expected not-found error, got %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// JournalCmd reads the bulk journal written by Gmail trash/delete, Drive
+// delete/dedupe/cleanup-empty, and Calendar delete.
+type JournalCmd struct {
+	List JournalListCmd `cmd:"" default:"withargs" aliases:"ls" help:"List recent bulk trash and delete runs"`
+	Undo JournalUndoCmd `cmd:"" help:"Restore what a trash run moved to the trash (default: the latest one)"`
+}
+
+type JournalListCmd struct {
+	Limit int `name:"limit" aliases:"max" help:"Show at most this many runs, newest first (0 = all)" default:"20"`
+}
+
+type journalListEntry struct {
+	bulkJournalEntry
+	Undone bool `json:"undone,omitempty"`
+}
+
+func (c *JournalListCmd) Run(ctx context.Context, _ *RootFlags) error {
+	u := ui.FromContext(ctx)
+	entries, err := readBulkJournal()
+	if err != nil {
+		return err
+	}
+	undone := undoneBulkJournalIDs(entries)
+
+	list := []journalListEntry{}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if c.Limit > 0 && len(list) >= c.Limit {
+			break
+		}
+		list = append(list, journalListEntry{bulkJournalEntry: entries[i], Undone: undone[entries[i].ID]})
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"entries": list})
+	}
+	if len(list) == 0 {
+		u.Err().Println("Journal is empty")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tTIME\tOP\tCOUNT\tUNDO\tACCOUNT")
+	for _, e := range list {
+		undo := "-"
+		switch {
+		case e.Undone:
+			undo = "undone"
+		case e.UndoOf != "":
+			undo = "undo of " + e.UndoOf
+		case e.Undo != "":
+			undo = "available"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n", e.ID, humanDateTime(ctx, e.Time), e.Op, e.Count, undo, orEmpty(e.Account, "-"))
+	}
+	return nil
+}
+
+type JournalUndoCmd struct {
+	EntryID string `arg:"" name:"entryId" optional:"" help:"Journal entry ID from journal list (default: the latest run that can be undone)"`
+}
+
+func (c *JournalUndoCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	entries, err := readBulkJournal()
+	if err != nil {
+		return err
+	}
+	entry, err := findUndoableBulkJournalEntry(entries, strings.TrimSpace(c.EntryID))
+	if err != nil {
+		return err
+	}
+	if err := dryRunExit(ctx, flags, "journal.undo", map[string]any{
+		"entry_id": entry.ID,
+		"op":       entry.Op,
+		"count":    entry.Count,
+	}); err != nil {
+		return err
+	}
+
+	account := entry.Account
+	if account == "" {
+		if account, err = requireAccount(flags); err != nil {
+			return err
+		}
+	}
+	var restored []bulkItem
+	switch entry.Undo {
+	case bulkUndoGmailUntrash:
+		restored, err = undoGmailTrash(ctx, account, entry.Items)
+	case bulkUndoDriveUntrash:
+		restored, err = undoDriveTrash(ctx, account, entry.Items)
+	default:
+		return usagef("journal entry %s (%s) has an unknown undo kind %q", entry.ID, entry.Op, entry.Undo)
+	}
+	if len(restored) > 0 {
+		if journalErr := appendBulkJournal(bulkJournalEntry{
+			Account: account,
+			Op:      "journal.undo",
+			Action:  "restore",
+			Count:   len(restored),
+			Items:   restored,
+			UndoOf:  entry.ID,
+		}); journalErr != nil {
+			u.Err().Printf("warning: %v", journalErr)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("undo %s (%d of %d restored): %w", entry.ID, len(restored), len(entry.Items), err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"undone":   entry.ID,
+			"op":       entry.Op,
+			"restored": len(restored),
+		})
+	}
+	u.Out().Printf("Restored %d item%s trashed by %s (%s)", len(restored), pluralS(len(restored)), entry.ID, entry.Op)
+	return nil
+}
+
+func undoneBulkJournalIDs(entries []bulkJournalEntry) map[string]bool {
+	undone := map[string]bool{}
+	for _, e := range entries {
+		if e.UndoOf != "" {
+			undone[e.UndoOf] = true
+		}
+	}
+	return undone
+}
+
+func findUndoableBulkJournalEntry(entries []bulkJournalEntry, id string) (bulkJournalEntry, error) {
+	undone := undoneBulkJournalIDs(entries)
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if id == "" {
+			if e.Undo != "" && !undone[e.ID] {
+				return e, nil
+			}
+			continue
+		}
+		if e.ID != id {
+			continue
+		}
+		switch {
+		case undone[e.ID]:
+			return e, usagef("journal entry %s was already undone", id)
+		case e.Undo == "":
+			return e, usagef("journal entry %s (%s %d) cannot be undone", id, e.Action, e.Count)
+		}
+		return e, nil
+	}
+	if id == "" {
+		return bulkJournalEntry{}, usage("no journal entry can be undone")
+	}
+	return bulkJournalEntry{}, usagef("journal entry %s not found", id)
+}
+
+// undoGmailTrash takes the TRASH label off again. Other labels were left in
+// place by the trash, so messages reappear where they were except the inbox.
+func undoGmailTrash(ctx context.Context, account string, items []bulkItem) ([]bulkItem, error) {
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	restored := make([]bulkItem, 0, len(items))
+	for i := 0; i < len(items); i += 1000 {
+		chunk := items[i:min(i+1000, len(items))]
+		ids := make([]string, 0, len(chunk))
+		for _, item := range chunk {
+			ids = append(ids, item.ID)
+		}
+		if err := svc.Users.Messages.BatchModify("me", &gmail.BatchModifyMessagesRequest{
+			Ids:            ids,
+			RemoveLabelIds: []string{"TRASH"},
+		}).Context(ctx).Do(); err != nil {
+			return restored, err
+		}
+		restored = append(restored, chunk...)
+	}
+	return restored, nil
+}
+
+func undoDriveTrash(ctx context.Context, account string, items []bulkItem) ([]bulkItem, error) {
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	restored := make([]bulkItem, 0, len(items))
+	for _, item := range items {
+		if _, err := svc.Files.Update(item.ID, &drive.File{Trashed: false, ForceSendFields: []string{"Trashed"}}).
+			SupportsAllDrives(true).
+			Fields("id, trashed").
+			Context(ctx).
+			Do(); err != nil {
+			return restored, err
+		}
+		restored = append(restored, item)
+	}
+	return restored, nil
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/steipete/gogcli/internal/locale"
+)
+
+// The human* helpers render values for text tables and key/value output in
+// the --lang locale. Execute only attaches a locale in human mode, so with
+// --json/--plain (or without --lang) they return the canonical text.
+
+// humanDateTime renders an RFC3339 API timestamp; the canonical form is
+// formatDateTime's minute-precision text.
+func humanDateTime(ctx context.Context, iso string) string {
+	l := locale.FromContext(ctx)
+	if l == nil {
+		return formatDateTime(iso)
+	}
+	t, err := time.Parse(time.RFC3339, iso)
+	if err != nil {
+		return formatDateTime(iso)
+	}
+	return l.DateTime(t)
+}
+
+// humanEventTime renders an event start/end as returned by eventStart or
+// formatEventLocal: an RFC3339 date-time, or a bare date for all-day events.
+func humanEventTime(ctx context.Context, value string) string {
+	l := locale.FromContext(ctx)
+	if l == nil || value == "" {
+		return value
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return l.DateTime(t)
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return l.Date(t)
+	}
+	return value
+}
+
+// humanWeekday translates an English weekday name (as stored in the
+// *DayOfWeek fields).
+func humanWeekday(ctx context.Context, name string) string {
+	l := locale.FromContext(ctx)
+	if l == nil {
+		return name
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if d.String() == name {
+			return l.Weekday(d)
+		}
+	}
+	return name
+}
+
+func humanSize(ctx context.Context, bytes int64) string {
+	return formatSizeIn(locale.FromContext(ctx), bytes)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/locale"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func TestHumanFormatHelpers(t *testing.T) {
+	de, err := locale.Parse("de")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	ctx := locale.WithLocale(context.Background(), de)
+	plain := context.Background()
+
+	if got := humanDateTime(plain, "2025-03-07T14:05:09.123Z"); got != "2025-03-07 14:05" {
+		t.Fatalf("canonical datetime = %q", got)
+	}
+	if got := humanDateTime(ctx, "2025-03-07T14:05:09.123Z"); got != "07.03.2025 14:05" {
+		t.Fatalf("de datetime = %q", got)
+	}
+	if got := humanSize(plain, 1536); got != "1.5 KB" {
+		t.Fatalf("canonical size = %q", got)
+	}
+	if got := humanSize(ctx, 1536); got != "1,5 KB" {
+		t.Fatalf("de size = %q", got)
+	}
+	if got := humanEventTime(ctx, "2025-03-08"); got != "08.03.2025" {
+		t.Fatalf("de all-day = %q", got)
+	}
+	if got := humanWeekday(ctx, "Saturday"); got != "Samstag" {
+		t.Fatalf("de weekday = %q", got)
+	}
+}
+
+func TestRenderCalendarEventsTable_Lang(t *testing.T) {
+	l, err := locale.Parse("en-US")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := locale.WithLocale(ui.WithUI(context.Background(), u), l)
+	events := []*eventWithCalendar{{
+		Event: &calendar.Event{
+			Id:      "e1",
+			Summary: "Standup",
+			Start:   &calendar.EventDateTime{DateTime: "2025-03-07T09:30:00-05:00"},
+			End:     &calendar.EventDateTime{DateTime: "2025-03-07T09:45:00-05:00"},
+		},
+		CalendarID: "primary",
+	}}
+
+	out := captureStdout(t, func() {
+		if err := renderCalendarEventsTable(ctx, events, "", false, false, false, false, calendarDisplayZones{}); err != nil {
+			t.Fatalf("render: %v", err)
+		}
+	})
+	if !strings.Contains(out, "03/07/2025 9:30 AM") || !strings.Contains(out, "03/07/2025 9:45 AM") {
+		t.Fatalf("expected en-US times, got:\n%s", out)
+	}
+}
+
+func TestExecute_InvalidLang(t *testing.T) {
+	_ = captureStderr(t, func() {
+		err := Execute([]string{"--lang", "not a language", "version"})
+		if err == nil || ExitCode(err) != 2 {
+			t.Fatalf("expected usage error, got %v", err)
+		}
+	})
+}
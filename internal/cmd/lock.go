@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// LockCmd is an advisory lock convention for Drive files (Docs, Sheets, or
+// anything else): the lock lives in the file's appProperties, so every job
+// using the same OAuth client sees it. Writers opt in with --respect-locks.
+// Drive has no compare-and-swap, so acquiring is best-effort: two jobs racing
+// within the settle window can both believe they won.
+type LockCmd struct {
+	Acquire LockAcquireCmd `cmd:"" help:"Take the lock on a file, best-effort (fails with exit 8 while someone else holds it)"`
+	Release LockReleaseCmd `cmd:"" help:"Release a lock you hold (or any lock with --force)"`
+	Status  LockStatusCmd  `cmd:"" help:"Show who holds the lock on a file"`
+}
+
+const (
+	driveLockTokenKey   = "gogLockToken"
+	driveLockOwnerKey   = "gogLockOwner"
+	driveLockExpiresKey = "gogLockExpires"
+	driveLockTokenEnv   = "GOG_LOCK_TOKEN"
+)
+
+// driveLockSettle is how long acquire waits before re-reading its lock, so a
+// concurrent acquirer's write has time to land and be noticed.
+var driveLockSettle = 2 * time.Second
+
+type driveLock struct {
+	FileID  string `json:"fileId"`
+	Token   string `json:"token,omitempty"`
+	Owner   string `json:"owner,omitempty"`
+	Expires string `json:"expires,omitempty"`
+}
+
+func parseDriveLock(f *drive.File) driveLock {
+	lock := driveLock{FileID: f.Id}
+	if f.AppProperties == nil {
+		return lock
+	}
+	lock.Token = f.AppProperties[driveLockTokenKey]
+	lock.Owner = f.AppProperties[driveLockOwnerKey]
+	lock.Expires = f.AppProperties[driveLockExpiresKey]
+	return lock
+}
+
+// heldAt reports whether the lock is set and unexpired at now. A lock with
+// an unparsable expiry is treated as expired rather than stuck forever.
+func (l driveLock) heldAt(now time.Time) bool {
+	if l.Token == "" {
+		return false
+	}
+	exp, err := time.Parse(time.RFC3339, l.Expires)
+	return err == nil && now.Before(exp)
+}
+
+func (l driveLock) busyError() error {
+	return &ExitError{Code: exitCodeRetryable, Err: fmt.Errorf("file %s is locked by %s until %s", l.FileID, l.Owner, l.Expires)}
+}
+
+type LockAcquireCmd struct {
+	FileID string        `arg:"" name:"fileId" help:"Drive file ID (doc, sheet, ...)"`
+	TTL    time.Duration `name:"ttl" help:"Lock lifetime; re-acquire with the same token to extend" default:"10m"`
+	Token  string        `name:"token" help:"Lock token (default: $GOG_LOCK_TOKEN, or a new random token)"`
+	Owner  string        `name:"owner" help:"Human-readable holder shown to other jobs (default: account and hostname)"`
+}
+
+func (c *LockAcquireCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+	if c.TTL <= 0 {
+		return usage("--ttl must be > 0")
+	}
+	token := strings.TrimSpace(c.Token)
+	if token == "" {
+		token = strings.TrimSpace(os.Getenv(driveLockTokenEnv))
+	}
+	if token == "" {
+		var err error
+		if token, err = newDriveLockToken(); err != nil {
+			return err
+		}
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	owner := strings.TrimSpace(c.Owner)
+	if owner == "" {
+		owner = account
+		if host, hostErr := os.Hostname(); hostErr == nil && host != "" {
+			owner += " on " + host
+		}
+	}
+	lock := driveLock{
+		FileID:  fileID,
+		Token:   token,
+		Owner:   owner,
+		Expires: time.Now().Add(c.TTL).UTC().Format(time.RFC3339),
+	}
+	if err := dryRunExit(ctx, flags, "lock.acquire", lock); err != nil {
+		return err
+	}
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	current, err := getDriveLock(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
+	if current.heldAt(time.Now()) && current.Token != token {
+		return current.busyError()
+	}
+	_, err = svc.Files.Update(fileID, &drive.File{AppProperties: map[string]string{
+		driveLockTokenKey:   lock.Token,
+		driveLockOwnerKey:   lock.Owner,
+		driveLockExpiresKey: lock.Expires,
+	}}).SupportsAllDrives(true).Fields("id").Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	// Drive has no compare-and-swap, so the last writer wins. Reading back
+	// right away and again after a settle delay catches most lost races: a
+	// competitor that wrote after us shows up in one of the reads. Two jobs
+	// writing further apart than that are not detected, which is why locks
+	// are documented as best-effort.
+	if err := confirmDriveLockHeld(ctx, svc, fileID, token); err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(driveLockSettle):
+	}
+	if err := confirmDriveLockHeld(ctx, svc, fileID, token); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, lock)
+	}
+	u.Out().Printf("file_id\t%s", lock.FileID)
+	u.Out().Printf("token\t%s", lock.Token)
+	u.Out().Printf("owner\t%s", lock.Owner)
+	u.Out().Printf("expires\t%s", lock.Expires)
+	u.Err().Printf("Export %s=%s so --respect-locks writes from this job pass; release with: gog lock release %s", driveLockTokenEnv, lock.Token, fileID)
+	return nil
+}
+
+type LockReleaseCmd struct {
+	FileID string `arg:"" name:"fileId" help:"Drive file ID"`
+	Token  string `name:"token" help:"Lock token (default: $GOG_LOCK_TOKEN)"`
+}
+
+func (c *LockReleaseCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+	token := strings.TrimSpace(c.Token)
+	if token == "" {
+		token = strings.TrimSpace(os.Getenv(driveLockTokenEnv))
+	}
+	if token == "" && !flags.Force {
+		return usagef("pass --token or set %s (or --force to break someone else's lock)", driveLockTokenEnv)
+	}
+
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	current, err := getDriveLock(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
+	if current.Token == "" {
+		return writeResult(ctx, u, kv("fileId", fileID), kv("released", false))
+	}
+	if current.Token != token && !flags.Force && current.heldAt(time.Now()) {
+		return fmt.Errorf("file %s is locked by %s with a different token (use --force to break it)", fileID, current.Owner)
+	}
+	if err := dryRunExit(ctx, flags, "lock.release", current); err != nil {
+		return err
+	}
+	_, err = svc.Files.Update(fileID, &drive.File{
+		AppProperties:   map[string]string{},
+		ForceSendFields: []string{"AppProperties"},
+		NullFields: []string{
+			"AppProperties." + driveLockTokenKey,
+			"AppProperties." + driveLockOwnerKey,
+			"AppProperties." + driveLockExpiresKey,
+		},
+	}).SupportsAllDrives(true).Fields("id").Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	return writeResult(ctx, u, kv("fileId", fileID), kv("released", true), kv("owner", current.Owner))
+}
+
+type LockStatusCmd struct {
+	FileID string `arg:"" name:"fileId" help:"Drive file ID"`
+}
+
+func (c *LockStatusCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	fileID := normalizeGoogleID(strings.TrimSpace(c.FileID))
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+	_, svc, err := requireDriveService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	current, err := getDriveLock(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
+	held := current.heldAt(time.Now())
+	if outfmt.IsJSON(ctx) {
+		current.Token = ""
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"lock": current, "locked": held})
+	}
+	u.Out().Printf("locked\t%t", held)
+	if current.Owner != "" {
+		u.Out().Printf("owner\t%s", current.Owner)
+		u.Out().Printf("expires\t%s", current.Expires)
+	}
+	return nil
+}
+
+func confirmDriveLockHeld(ctx context.Context, svc *drive.Service, fileID, token string) error {
+	current, err := getDriveLock(ctx, svc, fileID)
+	if err != nil {
+		return err
+	}
+	if current.Token != token {
+		return current.busyError()
+	}
+	return nil
+}
+
+func getDriveLock(ctx context.Context, svc *drive.Service, fileID string) (driveLock, error) {
+	f, err := svc.Files.Get(fileID).
+		SupportsAllDrives(true).
+		Fields("id, appProperties").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return driveLock{}, err
+	}
+	return parseDriveLock(f), nil
+}
+
+// ensureDriveUnlocked is the --respect-locks gate for write commands: it
+// fails with exit 8 while another job holds an unexpired lock on fileID.
+// The caller's own lock is recognized via $GOG_LOCK_TOKEN.
+func ensureDriveUnlocked(ctx context.Context, flags *RootFlags, fileID string) error {
+	if flags == nil || !flags.RespectLocks || flags.DryRun {
+		return nil
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	current, err := getDriveLock(ctx, svc, fileID)
+	if err != nil {
+		return fmt.Errorf("check lock: %w", err)
+	}
+	if current.heldAt(time.Now()) && current.Token != strings.TrimSpace(os.Getenv(driveLockTokenEnv)) {
+		return current.busyError()
+	}
+	return nil
+}
+
+func newDriveLockToken() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate lock token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
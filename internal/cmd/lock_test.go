@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/docs/v1"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func TestLockAcquireRelease(t *testing.T) {
+	origNew, origSettle := newDriveService, driveLockSettle
+	t.Cleanup(func() { newDriveService, driveLockSettle = origNew, origSettle })
+	driveLockSettle = 0
+
+	var mu sync.Mutex
+	props := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.HasSuffix(r.URL.Path, "/files/doc1") {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPatch {
+			body, _ := io.ReadAll(r.Body)
+			var patch struct {
+				AppProperties map[string]*string `json:"appProperties"`
+			}
+			if err := json.Unmarshal(body, &patch); err != nil {
+				t.Errorf("patch body: %v", err)
+			}
+			for k, v := range patch.AppProperties {
+				if v == nil {
+					delete(props, k)
+				} else {
+					props[k] = *v
+				}
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "doc1", "appProperties": props})
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	out := captureStdout(t, func() {
+		if err := runKong(t, &LockAcquireCmd{}, []string{"doc1", "--ttl", "5m", "--owner", "job-a"}, newDocsJSONContext(t), flags); err != nil {
+			t.Fatalf("acquire: %v", err)
+		}
+	})
+	var lock driveLock
+	if err := json.Unmarshal([]byte(out), &lock); err != nil || lock.Token == "" || props[driveLockOwnerKey] != "job-a" {
+		t.Fatalf("unexpected acquire output %s (%v), props %v", out, err, props)
+	}
+
+	// Another job can neither take the lock nor write with --respect-locks.
+	err = runKong(t, &LockAcquireCmd{}, []string{"doc1", "--token", "other"}, newDocsJSONContext(t), flags)
+	if got := ExitCode(stableExitCode(err)); got != exitCodeRetryable {
+		t.Fatalf("expected busy exit code, got %d (%v)", got, err)
+	}
+	gated := &RootFlags{Account: "a@b.com", RespectLocks: true}
+	if err := ensureDriveUnlocked(context.Background(), gated, "doc1"); ExitCode(stableExitCode(err)) != exitCodeRetryable {
+		t.Fatalf("expected locked write to fail, got %v", err)
+	}
+	t.Setenv(driveLockTokenEnv, lock.Token)
+	if err := ensureDriveUnlocked(context.Background(), gated, "doc1"); err != nil {
+		t.Fatalf("holder should pass the gate: %v", err)
+	}
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &LockReleaseCmd{}, []string{"doc1"}, newDocsJSONContext(t), flags); err != nil {
+			t.Fatalf("release: %v", err)
+		}
+	})
+	if len(props) != 0 {
+		t.Fatalf("expected lock properties cleared, got %v", props)
+	}
+
+	// Expired locks do not block.
+	props[driveLockTokenKey] = "stale"
+	props[driveLockExpiresKey] = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	t.Setenv(driveLockTokenEnv, "")
+	if err := ensureDriveUnlocked(context.Background(), gated, "doc1"); err != nil {
+		t.Fatalf("expired lock should not block: %v", err)
+	}
+}
+
+func TestLockAcquire_LostRaceAfterSettle(t *testing.T) {
+	origNew, origSettle := newDriveService, driveLockSettle
+	t.Cleanup(func() { newDriveService, driveLockSettle = origNew, origSettle })
+	driveLockSettle = time.Millisecond
+
+	var mu sync.Mutex
+	props := map[string]string{}
+	getsAfterPatch := -1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPatch:
+			var patch struct {
+				AppProperties map[string]string `json:"appProperties"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&patch)
+			for k, v := range patch.AppProperties {
+				props[k] = v
+			}
+			getsAfterPatch = 0
+		case http.MethodGet:
+			if getsAfterPatch >= 0 {
+				getsAfterPatch++
+			}
+			// A competing job overwrites the lock after our first read-back.
+			if getsAfterPatch == 2 {
+				props[driveLockTokenKey] = "rival"
+				props[driveLockOwnerKey] = "job-b"
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "doc1", "appProperties": props})
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+
+	err = runKong(t, &LockAcquireCmd{}, []string{"doc1", "--token", "mine"}, newDocsJSONContext(t), &RootFlags{Account: "a@b.com"})
+	if got := ExitCode(stableExitCode(err)); got != exitCodeRetryable || !strings.Contains(err.Error(), "job-b") {
+		t.Fatalf("expected lost race to report busy, got %d (%v)", got, err)
+	}
+}
+
+func TestDocsWrite_RespectsLocks(t *testing.T) {
+	origDrive, origDocs := newDriveService, newDocsService
+	t.Cleanup(func() { newDriveService, newDocsService = origDrive, origDocs })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "doc1", "appProperties": map[string]string{
+			driveLockTokenKey:   "other-job",
+			driveLockOwnerKey:   "job-b",
+			driveLockExpiresKey: time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		}})
+	}))
+	defer srv.Close()
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return svc, nil }
+	newDocsService = func(context.Context, string) (*docs.Service, error) {
+		t.Fatal("docs write must not reach the Docs API while the file is locked")
+		return nil, nil
+	}
+
+	flags := &RootFlags{Account: "a@b.com", RespectLocks: true}
+	err = runKong(t, &DocsWriteCmd{}, []string{"doc1", "--text", "hello"}, newDocsJSONContext(t), flags)
+	if got := ExitCode(stableExitCode(err)); got != exitCodeRetryable {
+		t.Fatalf("expected locked docs write to exit %d, got %d (%v)", exitCodeRetryable, got, err)
+	}
+}
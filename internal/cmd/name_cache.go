@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/steipete/gogcli/internal/idcache"
+)
+
+var openNameCache = idcache.Default
+
+type nameCacheAccountKey struct{}
+
+// withNameCacheAccount records how to resolve the active account for the
+// name→ID cache. Resolution is deferred (and memoized) so commands that never
+// look up a name never touch account resolution on its behalf.
+func withNameCacheAccount(ctx context.Context, resolve func() (string, error)) context.Context {
+	var (
+		once    sync.Once
+		account string
+	)
+	lookup := func() string {
+		once.Do(func() {
+			if a, err := resolve(); err == nil {
+				account = a
+			}
+		})
+		return account
+	}
+	return context.WithValue(ctx, nameCacheAccountKey{}, lookup)
+}
+
+func nameCacheFor(ctx context.Context) (*idcache.Cache, string, bool) {
+	lookup, ok := ctx.Value(nameCacheAccountKey{}).(func() string)
+	if !ok {
+		return nil, "", false
+	}
+	account := lookup()
+	if account == "" {
+		return nil, "", false
+	}
+	cache, err := openNameCache()
+	if err != nil {
+		if !errors.Is(err, idcache.ErrDisabled) {
+			slog.Debug("name cache unavailable", "err", err)
+		}
+		return nil, "", false
+	}
+	return cache, account, true
+}
+
+// cachedNameMap returns the name→ID mapping for kind/scope, serving it from
+// the local cache when every wanted key is present and the entry is fresh.
+// Otherwise it calls fetch and refreshes the cache, so a renamed or newly
+// created resource costs exactly one extra list call.
+func cachedNameMap(ctx context.Context, kind idcache.Kind, scope string, wanted []string, fetch func() (map[string]string, error)) (map[string]string, error) {
+	cache, account, ok := nameCacheFor(ctx)
+	if ok {
+		if names, hit := cache.Get(account, kind, scope); hit && hasAllNames(names, wanted) {
+			return names, nil
+		}
+	}
+
+	names, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if err := cache.Put(account, kind, scope, names); err != nil {
+			slog.Debug("name cache write failed", "kind", kind, "err", err)
+		}
+	}
+	return names, nil
+}
+
+// storeNameCache replaces a cached mapping with one the caller just fetched.
+func storeNameCache(ctx context.Context, kind idcache.Kind, scope string, names map[string]string) {
+	cache, account, ok := nameCacheFor(ctx)
+	if !ok {
+		return
+	}
+	if err := cache.Put(account, kind, scope, names); err != nil {
+		slog.Debug("name cache write failed", "kind", kind, "err", err)
+	}
+}
+
+// invalidateNameCache drops a cached mapping after a mutation that renames,
+// creates, or deletes one of its resources.
+func invalidateNameCache(ctx context.Context, kind idcache.Kind, scope string) {
+	cache, account, ok := nameCacheFor(ctx)
+	if !ok {
+		return
+	}
+	if err := cache.Invalidate(account, kind, scope); err != nil {
+		slog.Debug("name cache invalidate failed", "kind", kind, "err", err)
+	}
+}
+
+func hasAllNames(names map[string]string, wanted []string) bool {
+	for _, w := range wanted {
+		if _, ok := names[w]; !ok {
+			return false
+		}
+	}
+	return true
+}
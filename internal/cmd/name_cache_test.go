@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steipete/gogcli/internal/idcache"
+)
+
+func withTestNameCache(t *testing.T) context.Context {
+	t.Helper()
+
+	cache := idcache.New(t.TempDir(), time.Hour)
+	orig := openNameCache
+	openNameCache = func() (*idcache.Cache, error) { return cache, nil }
+	t.Cleanup(func() { openNameCache = orig })
+
+	return withNameCacheAccount(context.Background(), func() (string, error) { return "a@b.com", nil })
+}
+
+func TestCachedNameMap_HitsAndRefreshesOnMissingName(t *testing.T) {
+	ctx := withTestNameCache(t)
+
+	calls := 0
+	labels := map[string]string{"work": "Label_1"}
+	fetch := func() (map[string]string, error) {
+		calls++
+		out := make(map[string]string, len(labels))
+		for k, v := range labels {
+			out[k] = v
+		}
+		return out, nil
+	}
+
+	for range 2 {
+		got, err := cachedNameMap(ctx, idcache.KindGmailLabels, "", []string{"work"}, fetch)
+		if err != nil {
+			t.Fatalf("cachedNameMap: %v", err)
+		}
+		if got["work"] != "Label_1" {
+			t.Fatalf("unexpected map: %v", got)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected one fetch for repeated lookups, got %d", calls)
+	}
+
+	labels["new"] = "Label_2"
+	got, err := cachedNameMap(ctx, idcache.KindGmailLabels, "", []string{"new"}, fetch)
+	if err != nil {
+		t.Fatalf("cachedNameMap: %v", err)
+	}
+	if got["new"] != "Label_2" || calls != 2 {
+		t.Fatalf("expected refresh on unknown name, calls=%d map=%v", calls, got)
+	}
+
+	invalidateNameCache(ctx, idcache.KindGmailLabels, "")
+	if _, err := cachedNameMap(ctx, idcache.KindGmailLabels, "", []string{"work"}, fetch); err != nil {
+		t.Fatalf("cachedNameMap: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected refetch after invalidate, got %d calls", calls)
+	}
+}
+
+func TestCachedNameMap_NoAccountBypassesCache(t *testing.T) {
+	calls := 0
+	fetch := func() (map[string]string, error) {
+		calls++
+		return map[string]string{"a": "1"}, nil
+	}
+
+	for range 2 {
+		if _, err := cachedNameMap(context.Background(), idcache.KindCalendars, "", []string{"a"}, fetch); err != nil {
+			t.Fatalf("cachedNameMap: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("expected no caching without an account, got %d calls", calls)
+	}
+}
+
+func TestCalendarNameCacheEntries_SkipsAmbiguous(t *testing.T) {
+	data := &calendarSelectionData{bySummary: map[string][]string{
+		"team": {"a@group", "b@group"},
+		"home": {"c@group"},
+	}}
+
+	got := calendarNameCacheEntries(data)
+	if _, ok := got["team"]; ok {
+		t.Fatalf("ambiguous summary should not be cached: %v", got)
+	}
+	if got["home"] != "c@group" {
+		t.Fatalf("expected unique summary cached, got %v", got)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"google.golang.org/api/calendar/v3"
 	"google.golang.org/api/tasks/v1"
 
+	"github.com/steipete/gogcli/internal/idcache"
 	"github.com/steipete/gogcli/internal/selectorutil"
 )
 
@@ -136,7 +137,29 @@ func resolveCalendarID(ctx context.Context, svc *calendar.Service, input string)
 		return in, nil
 	}
 
-	ids, err := resolveCalendarInputs(ctx, svc, []string{in}, calendarResolveOptions{
+	key := strings.ToLower(in)
+	var fetched *calendarSelectionData
+	names, err := cachedNameMap(ctx, idcache.KindCalendars, "", []string{key}, func() (map[string]string, error) {
+		data, fetchErr := buildCalendarSelectionData(ctx, svc)
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+		fetched = data
+		return calendarNameCacheEntries(data), nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if id, ok := names[key]; ok {
+		return id, nil
+	}
+	if fetched == nil {
+		if fetched, err = buildCalendarSelectionData(ctx, svc); err != nil {
+			return "", err
+		}
+	}
+
+	ids, err := resolveCalendarInputsWithData(fetched, []string{in}, calendarResolveOptions{
 		strict: false,
 	})
 	if err != nil {
@@ -148,6 +171,19 @@ func resolveCalendarID(ctx context.Context, svc *calendar.Service, input string)
 	return ids[0], nil
 }
 
+// calendarNameCacheEntries flattens selection data into name→ID cache
+// entries. Ambiguous summaries are left out so they always go through the
+// full resolution path and its ambiguity error.
+func calendarNameCacheEntries(data *calendarSelectionData) map[string]string {
+	out := make(map[string]string, len(data.bySummary))
+	for summary, ids := range data.bySummary {
+		if len(ids) == 1 {
+			out[summary] = ids[0]
+		}
+	}
+	return out
+}
+
 func resolveCalendarInputs(ctx context.Context, svc *calendar.Service, inputs []string, opts calendarResolveOptions) ([]string, error) {
 	if len(inputs) == 0 {
 		return nil, nil
@@ -158,6 +194,10 @@ func resolveCalendarInputs(ctx context.Context, svc *calendar.Service, inputs []
 		return nil, err
 	}
 
+	return resolveCalendarInputsWithData(data, inputs, opts)
+}
+
+func resolveCalendarInputsWithData(data *calendarSelectionData, inputs []string, opts calendarResolveOptions) ([]string, error) {
 	out := make([]string, 0, len(inputs))
 	seen := make(map[string]struct{}, len(inputs))
 	var unrecognized []string
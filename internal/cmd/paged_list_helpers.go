@@ -4,7 +4,7 @@ import (
 	"context"
 	"os"
 
-	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type pageFetchFunc[T any] func(pageToken string) ([]T, string, error)
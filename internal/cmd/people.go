@@ -4,8 +4,8 @@ import (
 	"context"
 	"os"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type PeopleCmd struct {
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/steipete/gogcli/internal/redact"
+)
+
+// realStdout and realStderr are the process's own files while redactStdio
+// has replaced os.Stdout and os.Stderr with pipes; nil otherwise.
+var realStdout, realStderr *os.File
+
+// rawStdout is where binary output (attachment bytes, file contents) goes:
+// the real stdout, since the redactor only understands text.
+func rawStdout() *os.File {
+	if realStdout != nil {
+		return realStdout
+	}
+	return os.Stdout
+}
+
+// stderrIsTerminal checks the real stderr, not the redacting pipe.
+func stderrIsTerminal() bool {
+	f := os.Stderr
+	if realStderr != nil {
+		f = realStderr
+	}
+	return term.IsTerminal(int(f.Fd())) //nolint:gosec // os file descriptor fits int on supported targets
+}
+
+// redactStdio routes os.Stdout and os.Stderr through r so every command's
+// text output is masked, including text written directly to os.Stdout.
+// Binary output bypasses it via rawStdout. The returned func flushes the
+// pipes and restores the original files.
+func redactStdio(r *redact.Redactor) (func(), error) {
+	origOut, origErr := os.Stdout, os.Stderr
+
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		_ = outR.Close()
+		_ = outW.Close()
+		return nil, err
+	}
+
+	done := make(chan struct{}, 2)
+	pump := func(dst *os.File, src *os.File) {
+		_ = r.Copy(dst, src)
+		_ = src.Close()
+		done <- struct{}{}
+	}
+	go pump(origOut, outR)
+	go pump(origErr, errR)
+
+	os.Stdout, os.Stderr = outW, errW
+	realStdout, realStderr = origOut, origErr
+	return func() {
+		os.Stdout, os.Stderr = origOut, origErr
+		realStdout, realStderr = nil, nil
+		_ = outW.Close()
+		_ = errW.Close()
+		<-done
+		<-done
+	}, nil
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/steipete/gogcli/internal/redact"
+)
+
+func TestRedactStdio(t *testing.T) {
+	origErr := os.Stderr
+	out := captureStdout(t, func() {
+		restore, err := redactStdio(redact.New())
+		if err != nil {
+			t.Fatalf("redactStdio: %v", err)
+		}
+		fmt.Fprintln(os.Stdout, "owner\tAlice <alice@corp.com>")
+		fmt.Fprintln(os.Stdout, "id\t1AbC")
+		restore()
+	})
+	if os.Stderr != origErr {
+		t.Fatalf("stderr was not restored")
+	}
+	if out != "owner\tPerson 1 <user1@example.com>\nid\t1AbC\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestRedactStdio_RawStdoutBypassesRedactor(t *testing.T) {
+	payload := "\x89PNG\r\n\x1a\x00alice@corp.com\xff"
+	out := captureStdout(t, func() {
+		restore, err := redactStdio(redact.New())
+		if err != nil {
+			t.Fatalf("redactStdio: %v", err)
+		}
+		if rawStdout() == os.Stdout {
+			t.Fatalf("rawStdout should be the real stdout while redacting")
+		}
+		_, _ = rawStdout().WriteString(payload)
+		restore()
+	})
+	if out != payload {
+		t.Fatalf("binary output was altered: %q", out)
+	}
+	if rawStdout() != os.Stdout {
+		t.Fatalf("rawStdout not restored")
+	}
+}
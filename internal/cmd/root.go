@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/alecthomas/kong"
 	"golang.org/x/term"
@@ -14,10 +15,13 @@ import (
 	"github.com/steipete/gogcli/internal/authclient"
 	"github.com/steipete/gogcli/internal/config"
 	"github.com/steipete/gogcli/internal/errfmt"
+	"github.com/steipete/gogcli/internal/googleapi"
 	"github.com/steipete/gogcli/internal/googleauth"
-	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/locale"
+	"github.com/steipete/gogcli/internal/redact"
 	"github.com/steipete/gogcli/internal/secrets"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 const (
@@ -41,6 +45,10 @@ type RootFlags struct {
 	Force          bool   `help:"Skip confirmations for destructive commands" aliases:"yes,assume-yes" short:"y"`
 	NoInput        bool   `help:"Never prompt; fail instead (useful for CI)" aliases:"non-interactive,noninteractive"`
 	Verbose        bool   `help:"Enable verbose logging" short:"v"`
+	Redact         bool   `help:"Mask email addresses and names in output (keeps IDs; for sharing output in bug reports and demos)"`
+	Lang           string `help:"Language for dates, times, and numbers in human output (e.g. de, en-GB, pt-BR; JSON/plain stay canonical)" default:"${lang}"`
+	RespectLocks   bool   `name:"respect-locks" help:"Make docs/sheets writes and drive upload --replace fail (exit 8) while another job holds a 'gog lock' on the file; your own lock is recognized via GOG_LOCK_TOKEN" env:"GOG_RESPECT_LOCKS"`
+	AsOf           string `name:"as-of" help:"Best-effort snapshot for drive download and docs|sheets|slides export: use each file's newest revision at or before this time where revisions exist (RFC3339, YYYY-MM-DD, or relative like yesterday); other commands reject it"`
 }
 
 type CLI struct {
@@ -53,8 +61,9 @@ type CLI struct {
 	Ls       DriveLsCmd       `cmd:"" name:"ls" aliases:"list" help:"List Drive files (alias for 'drive ls')"`
 	Search   DriveSearchCmd   `cmd:"" name:"search" aliases:"find" help:"Search Drive files (alias for 'drive search')"`
 	Open     OpenCmd          `cmd:"" name:"open" aliases:"browse" help:"Print a best-effort web URL for a Google URL/ID (offline)"`
+	Inspect  InspectCmd       `cmd:"" name:"inspect" aliases:"whatis" help:"Identify what a bare ID refers to (Drive, Gmail, Calendar) with its title, owner, and link"`
 	Download DriveDownloadCmd `cmd:"" name:"download" aliases:"dl" help:"Download a Drive file (alias for 'drive download')"`
-	Upload   DriveUploadCmd   `cmd:"" name:"upload" aliases:"up,put" help:"Upload a file to Drive (alias for 'drive upload')"`
+	Upload   DriveUploadCmd   `cmd:"" name:"upload" aliases:"up,put" help:"Upload files to Drive (alias for 'drive upload')"`
 	Login    AuthAddCmd       `cmd:"" name:"login" help:"Authorize and store a refresh token (alias for 'auth add')"`
 	Logout   AuthRemoveCmd    `cmd:"" name:"logout" help:"Remove a stored refresh token (alias for 'auth remove')"`
 	Status   AuthStatusCmd    `cmd:"" name:"status" aliases:"st" help:"Show auth/config status (alias for 'auth status')"`
@@ -70,6 +79,8 @@ type CLI struct {
 	Calendar   CalendarCmd           `cmd:"" aliases:"cal" help:"Google Calendar"`
 	Classroom  ClassroomCmd          `cmd:"" aliases:"class" help:"Google Classroom"`
 	Time       TimeCmd               `cmd:"" help:"Local time utilities"`
+	Lock       LockCmd               `cmd:"" help:"Advisory locks on Drive files so automation jobs take turns (appProperties-based)"`
+	Hooks      HooksCmd              `cmd:"" aliases:"hook" help:"Self-hosted HTTP endpoints (email tracking pixel and click redirects)"`
 	Gmail      GmailCmd              `cmd:"" aliases:"mail,email" help:"Gmail"`
 	Chat       ChatCmd               `cmd:"" help:"Google Chat"`
 	Contacts   ContactsCmd           `cmd:"" aliases:"contact" help:"Google Contacts"`
@@ -79,10 +90,12 @@ type CLI struct {
 	Sheets     SheetsCmd             `cmd:"" aliases:"sheet" help:"Google Sheets"`
 	Forms      FormsCmd              `cmd:"" aliases:"form" help:"Google Forms"`
 	AppScript  AppScriptCmd          `cmd:"" name:"appscript" aliases:"script,apps-script" help:"Google Apps Script"`
+	Run        RunCmd                `cmd:"" name:"run" help:"Run a multi-step scenario file (YAML) across services, passing outputs between steps"`
 	Config     ConfigCmd             `cmd:"" help:"Manage configuration"`
+	Journal    JournalCmd            `cmd:"" help:"Bulk trash/delete journal: list past runs and undo trashes"`
 	ExitCodes  AgentExitCodesCmd     `cmd:"" name:"exit-codes" aliases:"exitcodes" help:"Print stable exit codes (alias for 'agent exit-codes')"`
 	Agent      AgentCmd              `cmd:"" help:"Agent-friendly helpers"`
-	Schema     SchemaCmd             `cmd:"" help:"Machine-readable command/flag schema" aliases:"help-json,helpjson"`
+	Schema     SchemaCmd             `cmd:"" help:"Machine-readable command/flag schema" aliases:"help-json,helpjson,commands"`
 	VersionCmd VersionCmd            `cmd:"" name:"version" help:"Print version"`
 	Completion CompletionCmd         `cmd:"" help:"Generate shell completion scripts"`
 	Complete   CompletionInternalCmd `cmd:"" name:"__complete" hidden:"" help:"Internal completion helper"`
@@ -126,6 +139,26 @@ func Execute(args []string) (err error) {
 		_, _ = fmt.Fprintln(os.Stderr, errfmt.Format(err))
 		return err
 	}
+	if err = enforceAsOfSupport(kctx.Command(), cli.AsOf); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, errfmt.Format(err))
+		return err
+	}
+
+	// Opt-in "agent mode": default to JSON when stdout is piped/non-TTY.
+	// We intentionally do this after parsing so `--plain` can override it.
+	if envBool("GOG_AUTO_JSON") && !cli.JSON && !cli.Plain && !term.IsTerminal(int(os.Stdout.Fd())) { //nolint:gosec // os file descriptor fits int on supported targets
+		cli.JSON = true
+	}
+
+	var redactor *redact.Redactor
+	if cli.Redact {
+		redactor = redact.New()
+		restore, redirectErr := redactStdio(redactor)
+		if redirectErr != nil {
+			return redirectErr
+		}
+		defer restore()
+	}
 
 	logLevel := slog.LevelWarn
 	if cli.Verbose {
@@ -135,25 +168,46 @@ func Execute(args []string) (err error) {
 		Level: logLevel,
 	})))
 
-	// Opt-in "agent mode": default to JSON when stdout is piped/non-TTY.
-	// We intentionally do this after parsing so `--plain` can override it.
-	if envBool("GOG_AUTO_JSON") && !cli.JSON && !cli.Plain && !term.IsTerminal(int(os.Stdout.Fd())) { //nolint:gosec // os file descriptor fits int on supported targets
-		cli.JSON = true
-	}
-
 	mode, err := outfmt.FromFlags(cli.JSON, cli.Plain)
 	if err != nil {
 		return newUsageError(err)
 	}
 
+	loc, err := locale.Parse(cli.Lang)
+	if err != nil {
+		return newUsageError(fmt.Errorf("--lang: %w", err))
+	}
+
+	// Resolve --as-of once so every file in the run is read at the same instant.
+	asOf, err := driveAsOf(&cli.RootFlags)
+	if err != nil {
+		return err
+	}
+	if !asOf.IsZero() {
+		cli.AsOf = asOf.UTC().Format(time.RFC3339)
+	}
+
 	ctx := context.Background()
 	ctx = outfmt.WithMode(ctx, mode)
-	ctx = outfmt.WithJSONTransform(ctx, outfmt.JSONTransform{
+	if !mode.JSON && !mode.Plain {
+		ctx = locale.WithLocale(ctx, loc)
+	}
+	jsonTransform := outfmt.JSONTransform{
 		ResultsOnly: cli.ResultsOnly,
 		Select:      splitCommaList(cli.Select),
-	})
+	}
+	if redactor != nil {
+		jsonTransform.Redact = redactor.Value
+	}
+	ctx = outfmt.WithJSONTransform(ctx, jsonTransform)
 	ctx = authclient.WithClient(ctx, cli.Client)
 	ctx = authclient.WithAccessToken(ctx, directAccessToken(&cli.RootFlags))
+	if redactor != nil {
+		// Learn personal names from API responses so human output masks
+		// them too, not just JSON name fields.
+		ctx = googleapi.WithTransportWrapper(ctx, redactor.Transport)
+	}
+	ctx = withNameCacheAccount(ctx, func() (string, error) { return requireAccount(&cli.RootFlags) })
 
 	uiColor := cli.Color
 	if outfmt.IsJSON(ctx) || outfmt.IsPlain(ctx) {
@@ -311,6 +365,7 @@ func newParser(description string) (*kong.Kong, *CLI, error) {
 		"client":           envOr("GOG_CLIENT", ""),
 		"enabled_commands": envOr("GOG_ENABLE_COMMANDS", ""),
 		"json":             boolString(envMode.JSON),
+		"lang":             envOr("GOG_LANG", ""),
 		"plain":            boolString(envMode.Plain),
 		"version":          VersionString(),
 	}
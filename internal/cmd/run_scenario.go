@@ -0,0 +1,361 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// scenarioRetryDelay is the base backoff between attempts of a step.
+var scenarioRetryDelay = 2 * time.Second
+
+// runScenarioStep runs one gog invocation and returns its stdout. It is a
+// variable so tests can run steps without spawning a process.
+var runScenarioStep = execScenarioStep
+
+// RunCmd executes a scenario: an ordered list of gog commands where later
+// steps can use the JSON output of earlier ones. Step args are Go templates
+// over .vars and .steps.<id>. A scenario looks like:
+//
+//	name: client review
+//	vars:
+//	  client: Acme
+//	  reviewer: bob@example.com
+//	steps:
+//	  - id: folder
+//	    args: [drive, mkdir, "{{.vars.client}} review"]
+//	  - id: doc
+//	    args: [docs, create, "{{.vars.client}} brief", --parent, "{{.steps.folder.folder.id}}"]
+//	    retries: 2
+//	  - id: share
+//	    args: [drive, share, "{{.steps.doc.file.id}}", --to, user, --email, "{{.vars.reviewer}}", --role, writer]
+//	  - id: mail
+//	    args: [gmail, send, --to, "{{.vars.reviewer}}", --subject, "Review: {{.vars.client}}", --body, "{{.steps.doc.file.webViewLink}}"]
+//
+// The run stops at the first failed step; the report lists what ran, what
+// failed, and what was skipped.
+type RunCmd struct {
+	File    string   `arg:"" name:"scenario" help:"Scenario file (YAML or JSON; - for stdin)"`
+	Vars    []string `name:"var" help:"Set a scenario variable (key=value; can be repeated; overrides the file)" sep:"none"`
+	Retries int      `name:"retries" help:"Retries per step on rate limits and transient errors (steps may set their own)" default:"0"`
+	Report  string   `name:"report" help:"Also write the JSON report to this file"`
+}
+
+type scenario struct {
+	Name  string         `yaml:"name" json:"name,omitempty"`
+	Vars  map[string]any `yaml:"vars" json:"vars,omitempty"`
+	Steps []scenarioStep `yaml:"steps" json:"steps"`
+}
+
+type scenarioStep struct {
+	ID      string   `yaml:"id" json:"id"`
+	Args    []string `yaml:"args" json:"args"`
+	Account string   `yaml:"account" json:"account,omitempty"`
+	Retries *int     `yaml:"retries" json:"retries,omitempty"`
+
+	tmpls []*template.Template
+}
+
+type scenarioStepResult struct {
+	ID         string   `json:"id"`
+	Args       []string `json:"args,omitempty"`
+	Status     string   `json:"status"`
+	Attempts   int      `json:"attempts,omitempty"`
+	DurationMs int64    `json:"durationMs,omitempty"`
+	ExitCode   int      `json:"exitCode,omitempty"`
+	Output     any      `json:"output,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+const (
+	scenarioStepOK      = "ok"
+	scenarioStepFailed  = "failed"
+	scenarioStepSkipped = "skipped"
+)
+
+func (c *RunCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Retries < 0 {
+		return usage("--retries must be >= 0")
+	}
+	b, err := resolveInlineOrFileBytes("@" + strings.TrimPrefix(strings.TrimSpace(c.File), "@"))
+	if err != nil {
+		return fmt.Errorf("read scenario: %w", err)
+	}
+	sc, err := parseScenario(b)
+	if err != nil {
+		return err
+	}
+	for _, kv := range c.Vars {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return usagef("invalid --var %q (use key=value)", kv)
+		}
+		sc.Vars[strings.TrimSpace(k)] = v
+	}
+
+	// Step args depend on earlier outputs, so --dry-run reports the plan
+	// with the templates unrendered.
+	if err := dryRunExit(ctx, flags, "run.scenario", sc); err != nil {
+		return err
+	}
+
+	results := runScenario(ctx, flags, sc, c.Retries)
+	var failed *scenarioStepResult
+	for i := range results {
+		if results[i].Status == scenarioStepFailed {
+			failed = &results[i]
+		}
+	}
+	report := map[string]any{
+		"scenario": sc.Name,
+		"ok":       failed == nil,
+		"steps":    results,
+	}
+	if path := strings.TrimSpace(c.Report); path != "" {
+		f, _, err := createUserOutputFile(path)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		encErr := enc.Encode(report)
+		if closeErr := f.Close(); encErr == nil {
+			encErr = closeErr
+		}
+		if encErr != nil {
+			return encErr
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, report); err != nil {
+			return err
+		}
+	} else {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "STEP\tSTATUS\tATTEMPTS\tERROR")
+		for _, r := range results {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", r.ID, r.Status, r.Attempts, r.Error)
+		}
+		flush()
+		if failed == nil {
+			u.Err().Printf("Scenario finished: %d step%s ok", len(results), pluralS(len(results)))
+		}
+	}
+	if failed != nil {
+		code := failed.ExitCode
+		if code == 0 {
+			code = 1
+		}
+		return &ExitError{Code: code, Err: fmt.Errorf("scenario step %q failed: %s", failed.ID, failed.Error)}
+	}
+	return nil
+}
+
+// parseScenario decodes a scenario file and parses every step template up
+// front, so typos fail before anything runs. YAML is a superset of JSON, so
+// both formats go through the same decoder.
+func parseScenario(b []byte) (*scenario, error) {
+	var sc scenario
+	if err := yaml.Unmarshal(b, &sc); err != nil {
+		return nil, fmt.Errorf("invalid scenario: %w", err)
+	}
+	if len(sc.Steps) == 0 {
+		return nil, usage("scenario has no steps")
+	}
+	if sc.Vars == nil {
+		sc.Vars = map[string]any{}
+	}
+	seen := map[string]bool{}
+	for i := range sc.Steps {
+		step := &sc.Steps[i]
+		step.ID = strings.TrimSpace(step.ID)
+		if step.ID == "" {
+			step.ID = fmt.Sprintf("step%d", i+1)
+		}
+		if seen[step.ID] {
+			return nil, usagef("step %d: duplicate id %q", i+1, step.ID)
+		}
+		seen[step.ID] = true
+		if len(step.Args) == 0 {
+			return nil, usagef("step %q: args are required", step.ID)
+		}
+		if step.Retries != nil && *step.Retries < 0 {
+			return nil, usagef("step %q: retries must be >= 0", step.ID)
+		}
+		for _, arg := range step.Args {
+			tmpl, err := template.New(step.ID).Option("missingkey=error").Parse(arg)
+			if err != nil {
+				return nil, usagef("step %q: invalid template %q: %v", step.ID, arg, err)
+			}
+			step.tmpls = append(step.tmpls, tmpl)
+		}
+	}
+	return &sc, nil
+}
+
+// runScenario runs the steps in order, stopping at the first failure.
+func runScenario(ctx context.Context, flags *RootFlags, sc *scenario, defaultRetries int) []scenarioStepResult {
+	outputs := map[string]any{}
+	data := map[string]any{"vars": sc.Vars, "steps": outputs}
+	results := make([]scenarioStepResult, 0, len(sc.Steps))
+	stopped := false
+	for i := range sc.Steps {
+		step := &sc.Steps[i]
+		res := scenarioStepResult{ID: step.ID}
+		if stopped {
+			res.Status = scenarioStepSkipped
+			results = append(results, res)
+			continue
+		}
+
+		args, err := renderScenarioArgs(step, data)
+		if err != nil {
+			res.Status, res.Error, res.ExitCode = scenarioStepFailed, err.Error(), scenarioExitCode(err)
+			results = append(results, res)
+			stopped = true
+			continue
+		}
+		res.Args = args
+		retries := defaultRetries
+		if step.Retries != nil {
+			retries = *step.Retries
+		}
+
+		started := time.Now()
+		out, attempts, err := runScenarioStepWithRetries(ctx, scenarioArgv(flags, step, args), retries)
+		res.Attempts = attempts
+		res.DurationMs = time.Since(started).Milliseconds()
+		if err != nil {
+			res.Status, res.Error, res.ExitCode = scenarioStepFailed, err.Error(), scenarioExitCode(err)
+			results = append(results, res)
+			stopped = true
+			continue
+		}
+		res.Status = scenarioStepOK
+		res.Output = decodeScenarioOutput(out)
+		outputs[step.ID] = res.Output
+		results = append(results, res)
+	}
+	return results
+}
+
+func renderScenarioArgs(step *scenarioStep, data map[string]any) ([]string, error) {
+	args := make([]string, 0, len(step.tmpls))
+	for _, tmpl := range step.tmpls {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			return nil, usagef("render args: %v", err)
+		}
+		args = append(args, b.String())
+	}
+	return args, nil
+}
+
+// scenarioArgv builds the child command line: JSON output so the next step
+// can read it, no prompts, and the parent's account unless the step picks
+// its own.
+func scenarioArgv(flags *RootFlags, step *scenarioStep, args []string) []string {
+	argv := []string{"--json", "--no-input"}
+	account := strings.TrimSpace(step.Account)
+	if account == "" && flags != nil {
+		account = strings.TrimSpace(flags.Account)
+	}
+	if account != "" {
+		argv = append(argv, "--account", account)
+	}
+	if flags != nil && strings.TrimSpace(flags.Client) != "" {
+		argv = append(argv, "--client", flags.Client)
+	}
+	if flags != nil && flags.Force {
+		argv = append(argv, "--force")
+	}
+	return append(argv, args...)
+}
+
+func runScenarioStepWithRetries(ctx context.Context, argv []string, retries int) ([]byte, int, error) {
+	for attempt := 1; ; attempt++ {
+		out, err := runScenarioStep(ctx, argv)
+		if err == nil {
+			return out, attempt, nil
+		}
+		code := scenarioExitCode(err)
+		if attempt > retries || (code != exitCodeRateLimited && code != exitCodeRetryable) {
+			return nil, attempt, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, attempt, ctx.Err()
+		case <-time.After(time.Duration(attempt) * scenarioRetryDelay):
+		}
+	}
+}
+
+// execScenarioStep re-runs the current gog binary with argv. Stderr is
+// passed through for progress and kept for the error message.
+func execScenarioStep(ctx context.Context, argv []string) ([]byte, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	child := exec.CommandContext(ctx, exe, argv...) //nolint:gosec // re-runs this binary with scenario-provided args
+	child.Stdout = &stdout
+	child.Stderr = io.MultiWriter(os.Stderr, &stderr)
+	child.Stdin = nil
+	if err := child.Run(); err != nil {
+		msg := lastNonEmptyLine(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, &ExitError{Code: exitErr.ExitCode(), Err: errors.New(msg)}
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+func scenarioExitCode(err error) int {
+	var ee *ExitError
+	if errors.As(stableExitCode(err), &ee) {
+		return ee.Code
+	}
+	return 1
+}
+
+// decodeScenarioOutput returns the step's JSON output, or its trimmed text
+// when it printed something else.
+func decodeScenarioOutput(out []byte) any {
+	trimmed := bytes.TrimSpace(out)
+	if len(trimmed) == 0 {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err == nil {
+		return v
+	}
+	return string(trimmed)
+}
+
+func lastNonEmptyLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseScenario(t *testing.T) {
+	sc, err := parseScenario([]byte("steps:\n  - args: [drive, mkdir, x]\n  - id: b\n    args: [drive, ls]\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if sc.Steps[0].ID != "step1" || sc.Steps[1].ID != "b" || sc.Vars == nil {
+		t.Fatalf("unexpected scenario: %+v", sc)
+	}
+	for name, raw := range map[string]string{
+		"no steps":  "name: x\n",
+		"duplicate": "steps:\n  - {id: a, args: [x]}\n  - {id: a, args: [y]}\n",
+		"no args":   "steps:\n  - id: a\n",
+		"template":  "steps:\n  - {id: a, args: [\"{{.vars.x\"]}\n",
+	} {
+		if _, err := parseScenario([]byte(raw)); ExitCode(stableExitCode(err)) != 2 {
+			t.Fatalf("%s: expected usage error, got %v", name, err)
+		}
+	}
+}
+
+func TestRunScenarioCmd(t *testing.T) {
+	origStep, origDelay := runScenarioStep, scenarioRetryDelay
+	t.Cleanup(func() { runScenarioStep, scenarioRetryDelay = origStep, origDelay })
+	scenarioRetryDelay = 0
+
+	var calls [][]string
+	runScenarioStep = func(_ context.Context, argv []string) ([]byte, error) {
+		calls = append(calls, argv)
+		switch argv[len(argv)-2] {
+		case "mkdir":
+			return []byte(`{"folder":{"id":"f1","name":"Acme review"}}`), nil
+		case "create":
+			if len(calls) == 2 {
+				return nil, &ExitError{Code: exitCodeRateLimited, Err: errors.New("rate limited")}
+			}
+			return []byte(`{"file":{"id":"d1"}}`), nil
+		}
+		return nil, &ExitError{Code: exitCodePermissionDenied, Err: errors.New("forbidden")}
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "scenario.yaml")
+	if err := os.WriteFile(path, []byte(`name: review
+vars:
+  client: Acme
+steps:
+  - id: folder
+    args: [drive, mkdir, "{{.vars.client}} review"]
+  - id: doc
+    retries: 1
+    args: [docs, create, "{{.steps.folder.folder.id}}"]
+  - id: share
+    account: other@b.com
+    args: [drive, share, "{{.steps.doc.file.id}}"]
+  - id: mail
+    args: [gmail, send]
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = Execute([]string{"--json", "--account", "a@b.com", "run", path, "--var", "client=Acme", "--report", filepath.Join(dir, "report.json")})
+	})
+	if ExitCode(runErr) != exitCodePermissionDenied || !strings.Contains(runErr.Error(), `"share"`) {
+		t.Fatalf("expected share to fail with exit 6, got %v", runErr)
+	}
+	var report struct {
+		OK    bool                 `json:"ok"`
+		Steps []scenarioStepResult `json:"steps"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	got := make([]string, 0, len(report.Steps))
+	for _, s := range report.Steps {
+		got = append(got, s.ID+"="+s.Status)
+	}
+	if report.OK || strings.Join(got, ",") != "folder=ok,doc=ok,share=failed,mail=skipped" || report.Steps[1].Attempts != 2 {
+		t.Fatalf("unexpected report: %s", out)
+	}
+	if strings.Join(calls[0], "|") != "--json|--no-input|--account|a@b.com|drive|mkdir|Acme review" {
+		t.Fatalf("unexpected argv: %q", calls[0])
+	}
+	if last := calls[len(calls)-1]; last[3] != "other@b.com" || last[len(last)-1] != "d1" {
+		t.Fatalf("unexpected share argv: %q", last)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "report.json")); err != nil {
+		t.Fatalf("report file: %v", err)
+	}
+}
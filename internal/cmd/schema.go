@@ -9,7 +9,9 @@ import (
 
 	"github.com/alecthomas/kong"
 
-	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/googleauth"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type SchemaCmd struct {
@@ -38,6 +40,9 @@ type schemaNode struct {
 	Positionals  []schemaArg   `json:"positionals,omitempty"`
 	Subcommands  []*schemaNode `json:"subcommands,omitempty"`
 	Requirements []string      `json:"requirements,omitempty"`
+	Service      string        `json:"service,omitempty"`
+	Services     []string      `json:"services,omitempty"`
+	Scopes       []string      `json:"scopes,omitempty"`
 }
 
 type schemaFlag struct {
@@ -168,6 +173,15 @@ func buildSchemaNode(node *kong.Node, hide bool) *schemaNode {
 	out.Flags = schemaFlags(node, hide)
 	out.Positionals = schemaPositionals(node)
 	out.Requirements = schemaRequirements(node, hide)
+	if auth, ok := schemaCommandAuthFor(node); ok {
+		out.Service = string(auth.services[0])
+		if len(auth.services) > 1 {
+			for _, svc := range auth.services {
+				out.Services = append(out.Services, string(svc))
+			}
+		}
+		out.Scopes = auth.scopeList()
+	}
 
 	children := make([]*kong.Node, 0, len(node.Children))
 	for _, child := range node.Children {
@@ -188,6 +202,94 @@ func buildSchemaNode(node *kong.Node, hide bool) *schemaNode {
 	return out
 }
 
+// schemaShortcutServices maps top-level desire-path shortcuts to the
+// service of the command they alias.
+var schemaShortcutServices = map[string]googleauth.Service{
+	"send":     googleauth.ServiceGmail,
+	"ls":       googleauth.ServiceDrive,
+	"search":   googleauth.ServiceDrive,
+	"download": googleauth.ServiceDrive,
+	"upload":   googleauth.ServiceDrive,
+	"me":       googleauth.ServicePeople,
+	"whoami":   googleauth.ServicePeople,
+}
+
+// schemaCommandAuth is what a command authorizes against: the services it
+// calls (the first is its own) and, when it asks for less than those
+// services' full scope sets, the exact scopes.
+type schemaCommandAuth struct {
+	services []googleauth.Service
+	scopes   []string
+}
+
+func (a schemaCommandAuth) scopeList() []string {
+	if len(a.scopes) > 0 {
+		return a.scopes
+	}
+	var out []string
+	seen := map[string]bool{}
+	for _, svc := range a.services {
+		scopes, err := googleauth.Scopes(svc)
+		if err != nil {
+			continue
+		}
+		for _, s := range scopes {
+			if !seen[s] {
+				seen[s] = true
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+// schemaCommandAuthOverrides covers commands whose auth differs from their
+// top-level service, keyed by command path; a key also covers the commands
+// below it. Keep in sync when a command starts calling another API.
+var schemaCommandAuthOverrides = map[string]schemaCommandAuth{
+	"calendar from-sheet":  {services: []googleauth.Service{googleauth.ServiceCalendar, googleauth.ServiceSheets}},
+	"calendar rooms":       {services: []googleauth.Service{googleauth.ServiceAdmin}, scopes: []string{googleapi.ScopeAdminDirectoryResourceCalendarRO}},
+	"calendar rsvp-report": {services: []googleauth.Service{googleauth.ServiceCalendar, googleauth.ServiceGmail}},
+	"calendar team":        {services: []googleauth.Service{googleauth.ServiceCalendar, googleauth.ServiceGroups}},
+	"calendar users":       {services: []googleauth.Service{googleauth.ServiceContacts}, scopes: []string{googleapi.ScopeDirectoryRO}},
+	"contacts enrich":      {services: []googleauth.Service{googleauth.ServiceContacts, googleauth.ServiceGmail}},
+	"drive labels":         {services: []googleauth.Service{googleauth.ServiceDrive}, scopes: []string{"https://www.googleapis.com/auth/drive", googleapi.ScopeDriveLabelsRO}},
+	"drive transcripts":    {services: []googleauth.Service{googleauth.ServiceDrive, googleauth.ServiceCalendar}},
+	"gmail send":           {services: []googleauth.Service{googleauth.ServiceGmail, googleauth.ServiceDrive}},
+	"send":                 {services: []googleauth.Service{googleauth.ServiceGmail, googleauth.ServiceDrive}},
+	"inspect":              {services: []googleauth.Service{googleauth.ServiceDrive, googleauth.ServiceGmail, googleauth.ServiceCalendar}},
+	"journal undo":         {services: []googleauth.Service{googleauth.ServiceDrive, googleauth.ServiceGmail}},
+	"lock":                 {services: []googleauth.Service{googleauth.ServiceDrive}},
+}
+
+// schemaCommandAuthFor returns what a command authorizes against: an
+// override for it or a parent command, else its top-level service. Local
+// commands (auth, config, time, ...) have none.
+func schemaCommandAuthFor(node *kong.Node) (schemaCommandAuth, bool) {
+	var path []string
+	top := node
+	for n := node; n != nil && n.Type == kong.CommandNode; n = n.Parent {
+		path = append([]string{n.Name}, path...)
+		top = n
+	}
+	if len(path) == 0 {
+		return schemaCommandAuth{}, false
+	}
+	for i := len(path); i > 0; i-- {
+		if auth, ok := schemaCommandAuthOverrides[strings.Join(path[:i], " ")]; ok {
+			return auth, true
+		}
+	}
+	if svc, ok := schemaShortcutServices[top.Name]; ok {
+		return schemaCommandAuth{services: []googleauth.Service{svc}}, true
+	}
+	svc, err := googleauth.ParseService(top.Name)
+	if err != nil {
+		return schemaCommandAuth{}, false
+	}
+	return schemaCommandAuth{services: []googleauth.Service{svc}}, true
+}
+
 func schemaNodeType(node *kong.Node) string {
 	if node == nil {
 		return ""
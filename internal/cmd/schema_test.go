@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
+
+	"github.com/steipete/gogcli/internal/googleapi"
 )
 
 func TestSplitCommandPath_SplitsWhitespaceWithinArgs(t *testing.T) {
@@ -43,3 +46,78 @@ func TestExecute_Schema_QuotedCommandPathToken(t *testing.T) {
 		t.Fatalf("expected non-empty command path")
 	}
 }
+
+func TestExecute_Commands_IncludesServiceScopes(t *testing.T) {
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"commands", "--json", "send"}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+
+	var doc struct {
+		Command struct {
+			Service string   `json:"service"`
+			Scopes  []string `json:"scopes"`
+		} `json:"command"`
+	}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("unmarshal: %v out=%q", err, out)
+	}
+	if doc.Command.Service != "gmail" || len(doc.Command.Scopes) == 0 {
+		t.Fatalf("expected gmail scopes for send shortcut, got %+v", doc.Command)
+	}
+}
+
+func TestExecute_Commands_PerCommandScopes(t *testing.T) {
+	describe := func(path ...string) (service string, services, scopes []string) {
+		t.Helper()
+		out := captureStdout(t, func() {
+			_ = captureStderr(t, func() {
+				if err := Execute(append([]string{"commands", "--json"}, path...)); err != nil {
+					t.Fatalf("Execute: %v", err)
+				}
+			})
+		})
+		var doc struct {
+			Command struct {
+				Service  string   `json:"service"`
+				Services []string `json:"services"`
+				Scopes   []string `json:"scopes"`
+			} `json:"command"`
+		}
+		if err := json.Unmarshal([]byte(out), &doc); err != nil {
+			t.Fatalf("unmarshal: %v out=%q", err, out)
+		}
+		return doc.Command.Service, doc.Command.Services, doc.Command.Scopes
+	}
+	hasScope := func(scopes []string, want string) bool {
+		for _, s := range scopes {
+			if s == want {
+				return true
+			}
+		}
+		return false
+	}
+
+	// gmail send uploads --via-drive attachments, so it needs Drive too.
+	if svc, all, scopes := describe("gmail", "send"); svc != "gmail" || strings.Join(all, ",") != "gmail,drive" || !hasScope(scopes, "https://www.googleapis.com/auth/drive") {
+		t.Fatalf("gmail send: %s %v %v", svc, all, scopes)
+	}
+	if svc, _, scopes := describe("lock", "acquire"); svc != "drive" || len(scopes) == 0 {
+		t.Fatalf("lock acquire: %s %v", svc, scopes)
+	}
+	if svc, _, scopes := describe("drive", "labels", "list"); svc != "drive" || !hasScope(scopes, googleapi.ScopeDriveLabelsRO) {
+		t.Fatalf("drive labels list: %s %v", svc, scopes)
+	}
+	if _, _, scopes := describe("drive", "ls"); hasScope(scopes, googleapi.ScopeDriveLabelsRO) {
+		t.Fatalf("drive ls should not request the labels scope: %v", scopes)
+	}
+	if svc, _, scopes := describe("calendar", "rooms"); svc != "admin" || strings.Join(scopes, ",") != googleapi.ScopeAdminDirectoryResourceCalendarRO {
+		t.Fatalf("calendar rooms: %s %v", svc, scopes)
+	}
+	if svc, _, scopes := describe("journal", "list"); svc != "" || len(scopes) != 0 {
+		t.Fatalf("journal list should be local, got %s %v", svc, scopes)
+	}
+}
@@ -11,8 +11,8 @@ import (
 	"google.golang.org/api/sheets/v4"
 
 	"github.com/steipete/gogcli/internal/googleapi"
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 var newSheetsService = googleapi.NewSheets
@@ -40,7 +40,9 @@ type SheetsCmd struct {
 	Notes         SheetsNotesCmd         `cmd:"" name:"notes" help:"Get cell notes from a range"`
 	UpdateNote    SheetsUpdateNoteCmd    `cmd:"" name:"update-note" aliases:"set-note" help:"Set or clear a cell note"`
 	FindReplace   SheetsFindReplaceCmd   `cmd:"" name:"find-replace" help:"Find and replace text across a spreadsheet"`
+	Tx            SheetsTxCmd            `cmd:"" name:"tx" aliases:"transaction" help:"Validate a file of update/append/clear ops, then apply them all in one atomic batch update"`
 	Links         SheetsLinksCmd         `cmd:"" name:"links" aliases:"hyperlinks" help:"Get cell hyperlinks from a range"`
+	Deps          SheetsDepsCmd          `cmd:"" name:"deps" aliases:"dependencies" help:"Report which ranges feed a cell's formulas (or, with --reverse, what depends on a range)"`
 	Named         SheetsNamedRangesCmd   `cmd:"" name:"named-ranges" aliases:"namedranges,nr" help:"Manage named ranges"`
 	Metadata      SheetsMetadataCmd      `cmd:"" name:"metadata" aliases:"info" help:"Get spreadsheet metadata"`
 	Create        SheetsCreateCmd        `cmd:"" name:"create" aliases:"new" help:"Create a new spreadsheet"`
@@ -52,9 +54,10 @@ type SheetsCmd struct {
 }
 
 type SheetsExportCmd struct {
-	SpreadsheetID string         `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
-	Output        OutputPathFlag `embed:""`
-	Format        string         `name:"format" help:"Export format: pdf|xlsx|csv" default:"xlsx"`
+	SpreadsheetID string                 `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Output        OutputPathFlag         `embed:""`
+	Name          ExportNameTemplateFlag `embed:""`
+	Format        string                 `name:"format" help:"Export format: pdf|xlsx|csv" default:"xlsx"`
 }
 
 func (c *SheetsExportCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -65,6 +68,7 @@ func (c *SheetsExportCmd) Run(ctx context.Context, flags *RootFlags) error {
 		KindLabel:     "Google Sheet",
 		DefaultFormat: "xlsx",
 		FormatHelp:    "Export format: pdf|xlsx|csv",
+		NameTemplate:  c.Name.NameTemplate,
 	}, c.SpreadsheetID, c.Output.Path, c.Format)
 }
 
@@ -83,10 +87,11 @@ func (c *SheetsCopyCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type SheetsGetCmd struct {
-	SpreadsheetID     string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
-	Range             string `arg:"" name:"range" help:"Range (A1 notation or named range name; e.g. Sheet1!A1:B10 or MyNamedRange)"`
-	MajorDimension    string `name:"dimension" help:"Major dimension: ROWS or COLUMNS"`
-	ValueRenderOption string `name:"render" help:"Value render option: FORMATTED_VALUE, UNFORMATTED_VALUE, or FORMULA"`
+	SpreadsheetID     string   `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Range             string   `arg:"" optional:"" name:"range" help:"Range (A1 notation, named range name, or header columns; e.g. Sheet1!A1:B10, MyNamedRange, or 'Data'[email,amount])"`
+	Ranges            []string `name:"ranges" help:"More ranges, comma-separated or repeated; all ranges are read in one batchGet call"`
+	MajorDimension    string   `name:"dimension" help:"Major dimension: ROWS or COLUMNS"`
+	ValueRenderOption string   `name:"render" help:"Value render option: FORMATTED_VALUE, UNFORMATTED_VALUE, or FORMULA"`
 }
 
 func (c *SheetsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -101,6 +106,9 @@ func (c *SheetsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if spreadsheetID == "" {
 		return usage("empty spreadsheetId")
 	}
+	if len(c.Ranges) > 0 {
+		return c.runBatch(ctx, account, spreadsheetID)
+	}
 	if strings.TrimSpace(rangeSpec) == "" {
 		return usage("empty range")
 	}
@@ -109,6 +117,10 @@ func (c *SheetsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	rangeSpec, err = resolveSheetsHeaderRange(ctx, svc, spreadsheetID, rangeSpec)
+	if err != nil {
+		return err
+	}
 
 	call := svc.Spreadsheets.Values.Get(spreadsheetID, rangeSpec)
 	if strings.TrimSpace(c.MajorDimension) != "" {
@@ -149,10 +161,11 @@ func (c *SheetsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 type SheetsUpdateCmd struct {
 	SpreadsheetID      string   `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
-	Range              string   `arg:"" name:"range" help:"Range (A1 notation or named range name; e.g. Sheet1!A1:B2 or MyNamedRange)"`
+	Range              string   `arg:"" optional:"" name:"range" help:"Range (A1 notation, named range name, or header columns; e.g. Sheet1!A1:B2, MyNamedRange, or 'Data'[email,amount])"`
 	Values             []string `arg:"" optional:"" name:"values" help:"Values (comma-separated rows, pipe-separated cells)"`
 	ValueInput         string   `name:"input" help:"Value input option: RAW or USER_ENTERED" default:"USER_ENTERED"`
 	ValuesJSON         string   `name:"values-json" help:"Values as JSON 2D array"`
+	BatchJSON          string   `name:"batch-json" help:"JSON object mapping ranges to 2D value arrays, written in one batchUpdate call (instead of range and values)"`
 	CopyValidationFrom string   `name:"copy-validation-from" help:"Copy data validation from an A1 range or named range (e.g. 'Sheet1!A2:D2' or MyNamedRange) to the updated cells"`
 }
 
@@ -164,6 +177,12 @@ func (c *SheetsUpdateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if spreadsheetID == "" {
 		return usage("empty spreadsheetId")
 	}
+	if err := ensureDriveUnlocked(ctx, flags, spreadsheetID); err != nil {
+		return err
+	}
+	if strings.TrimSpace(c.BatchJSON) != "" {
+		return c.runBatch(ctx, flags, spreadsheetID)
+	}
 	if strings.TrimSpace(rangeSpec) == "" {
 		return usage("empty range")
 	}
@@ -220,6 +239,10 @@ func (c *SheetsUpdateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	rangeSpec, err = resolveSheetsHeaderRangeLive(ctx, svc, spreadsheetID, rangeSpec)
+	if err != nil {
+		return err
+	}
 
 	vr := &sheets.ValueRange{
 		Values: values,
@@ -257,7 +280,7 @@ func (c *SheetsUpdateCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 type SheetsAppendCmd struct {
 	SpreadsheetID      string   `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
-	Range              string   `arg:"" name:"range" help:"Range (A1 notation or named range name; e.g. Sheet1!A:C or MyNamedRange)"`
+	Range              string   `arg:"" name:"range" help:"Range (A1 notation, named range name, or header columns; e.g. Sheet1!A:C, MyNamedRange, or 'Data'[email,amount])"`
 	Values             []string `arg:"" optional:"" name:"values" help:"Values (comma-separated rows, pipe-separated cells)"`
 	ValueInput         string   `name:"input" help:"Value input option: RAW or USER_ENTERED" default:"USER_ENTERED"`
 	Insert             string   `name:"insert" help:"Insert data option: OVERWRITE or INSERT_ROWS"`
@@ -273,6 +296,9 @@ func (c *SheetsAppendCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if spreadsheetID == "" {
 		return usage("empty spreadsheetId")
 	}
+	if err := ensureDriveUnlocked(ctx, flags, spreadsheetID); err != nil {
+		return err
+	}
 	if strings.TrimSpace(rangeSpec) == "" {
 		return usage("empty range")
 	}
@@ -329,6 +355,10 @@ func (c *SheetsAppendCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	rangeSpec, err = resolveSheetsHeaderRangeLive(ctx, svc, spreadsheetID, rangeSpec)
+	if err != nil {
+		return err
+	}
 
 	vr := &sheets.ValueRange{
 		Values: values,
@@ -369,7 +399,7 @@ func (c *SheetsAppendCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 type SheetsClearCmd struct {
 	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
-	Range         string `arg:"" name:"range" help:"Range (A1 notation or named range name; e.g. Sheet1!A1:B2 or MyNamedRange)"`
+	Range         string `arg:"" name:"range" help:"Range (A1 notation, named range name, or header columns; e.g. Sheet1!A1:B2, MyNamedRange, or 'Data'[email,amount])"`
 }
 
 func (c *SheetsClearCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -379,6 +409,9 @@ func (c *SheetsClearCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if spreadsheetID == "" {
 		return usage("empty spreadsheetId")
 	}
+	if err := ensureDriveUnlocked(ctx, flags, spreadsheetID); err != nil {
+		return err
+	}
 	if strings.TrimSpace(rangeSpec) == "" {
 		return usage("empty range")
 	}
@@ -399,6 +432,10 @@ func (c *SheetsClearCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	rangeSpec, err = resolveSheetsHeaderRangeLive(ctx, svc, spreadsheetID, rangeSpec)
+	if err != nil {
+		return err
+	}
 
 	resp, err := svc.Spreadsheets.Values.Clear(spreadsheetID, rangeSpec, &sheets.ClearValuesRequest{}).Do()
 	if err != nil {
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// runBatch reads the positional range plus every --ranges entry with a
+// single values.batchGet call.
+func (c *SheetsGetCmd) runBatch(ctx context.Context, account, spreadsheetID string) error {
+	u := ui.FromContext(ctx)
+	var ranges []string
+	if r := strings.TrimSpace(cleanRange(c.Range)); r != "" {
+		ranges = append(ranges, r)
+	}
+	for _, r := range c.Ranges {
+		if r = strings.TrimSpace(cleanRange(r)); r != "" {
+			ranges = append(ranges, r)
+		}
+	}
+	if len(ranges) == 0 {
+		return usage("empty --ranges")
+	}
+
+	svc, err := newSheetsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	ranges, err = resolveSheetsHeaderRanges(ctx, svc, spreadsheetID, ranges)
+	if err != nil {
+		return err
+	}
+
+	call := svc.Spreadsheets.Values.BatchGet(spreadsheetID).Ranges(ranges...)
+	if strings.TrimSpace(c.MajorDimension) != "" {
+		call = call.MajorDimension(c.MajorDimension)
+	}
+	if strings.TrimSpace(c.ValueRenderOption) != "" {
+		call = call.ValueRenderOption(c.ValueRenderOption)
+	}
+	resp, err := call.Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		out := make([]map[string]any, 0, len(resp.ValueRanges))
+		for _, vr := range resp.ValueRanges {
+			if vr == nil {
+				continue
+			}
+			out = append(out, map[string]any{"range": vr.Range, "values": vr.Values})
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"valueRanges": out})
+	}
+
+	for i, vr := range resp.ValueRanges {
+		if vr == nil {
+			continue
+		}
+		if i > 0 {
+			u.Out().Println("")
+		}
+		u.Out().Printf("# %s", vr.Range)
+		if len(vr.Values) == 0 {
+			u.Err().Printf("No data found in %s", vr.Range)
+			continue
+		}
+		w, flush := tableWriter(ctx)
+		for _, row := range vr.Values {
+			cells := make([]string, len(row))
+			for j, cell := range row {
+				cells[j] = fmt.Sprintf("%v", cell)
+			}
+			fmt.Fprintln(w, strings.Join(cells, "\t"))
+		}
+		flush()
+	}
+	return nil
+}
+
+// runBatch writes every range in --batch-json with a single
+// values.batchUpdate call.
+func (c *SheetsUpdateCmd) runBatch(ctx context.Context, flags *RootFlags, spreadsheetID string) error {
+	u := ui.FromContext(ctx)
+	if strings.TrimSpace(c.Range) != "" || len(c.Values) > 0 || strings.TrimSpace(c.ValuesJSON) != "" {
+		return usage("--batch-json replaces the range and values arguments; do not combine them")
+	}
+	if strings.TrimSpace(c.CopyValidationFrom) != "" {
+		return usage("--copy-validation-from is not supported with --batch-json")
+	}
+
+	b, err := resolveInlineOrFileBytes(c.BatchJSON)
+	if err != nil {
+		return fmt.Errorf("read --batch-json: %w", err)
+	}
+	data, err := parseSheetsBatchValues(b)
+	if err != nil {
+		return err
+	}
+
+	valueInputOption := strings.TrimSpace(c.ValueInput)
+	if valueInputOption == "" {
+		valueInputOption = "USER_ENTERED"
+	}
+
+	if err := dryRunExit(ctx, flags, "sheets.update.batch", map[string]any{
+		"spreadsheet_id":     spreadsheetID,
+		"data":               data,
+		"value_input_option": valueInputOption,
+	}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newSheetsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	for _, vr := range data {
+		if vr.Range, err = resolveSheetsHeaderRangeLive(ctx, svc, spreadsheetID, vr.Range); err != nil {
+			return err
+		}
+	}
+
+	resp, err := svc.Spreadsheets.Values.BatchUpdate(spreadsheetID, &sheets.BatchUpdateValuesRequest{
+		ValueInputOption: valueInputOption,
+		Data:             data,
+	}).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		updates := make([]map[string]any, 0, len(resp.Responses))
+		for _, r := range resp.Responses {
+			if r == nil {
+				continue
+			}
+			updates = append(updates, map[string]any{
+				"updatedRange":   r.UpdatedRange,
+				"updatedRows":    r.UpdatedRows,
+				"updatedColumns": r.UpdatedColumns,
+				"updatedCells":   r.UpdatedCells,
+			})
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"totalUpdatedCells": resp.TotalUpdatedCells,
+			"updatedRanges":     len(updates),
+			"responses":         updates,
+		})
+	}
+
+	u.Out().Printf("Updated %d cells across %d ranges", resp.TotalUpdatedCells, len(resp.Responses))
+	return nil
+}
+
+// parseSheetsBatchValues decodes {"Sheet1!A1:B2": [["a","b"]], ...} into
+// value ranges ordered by range for a stable request.
+func parseSheetsBatchValues(b []byte) ([]*sheets.ValueRange, error) {
+	var raw map[string][][]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("invalid --batch-json (want an object of range -> 2D array): %w", err)
+	}
+	if len(raw) == 0 {
+		return nil, usage("--batch-json has no ranges")
+	}
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		if strings.TrimSpace(k) == "" {
+			return nil, usage("--batch-json has an empty range key")
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	data := make([]*sheets.ValueRange, 0, len(keys))
+	for _, k := range keys {
+		data = append(data, &sheets.ValueRange{Range: cleanRange(strings.TrimSpace(k)), Values: raw[k]})
+	}
+	return data, nil
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestSheetsBatchGetAndUpdate(t *testing.T) {
+	origNew := newSheetsService
+	t.Cleanup(func() { newSheetsService = origNew })
+
+	var gotRanges []string
+	var gotUpdate sheets.BatchUpdateValuesRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/spreadsheets/s1/values:batchGet") && r.Method == http.MethodGet:
+			gotRanges = r.URL.Query()["ranges"]
+			_ = json.NewEncoder(w).Encode(map[string]any{"valueRanges": []any{
+				map[string]any{"range": "A!A1:B1", "values": [][]any{{"a", "b"}}},
+				map[string]any{"range": "B!C1:C2", "values": [][]any{{"1"}, {"2"}}},
+			}})
+		case strings.HasSuffix(r.URL.Path, "/spreadsheets/s1/values:batchUpdate") && r.Method == http.MethodPost:
+			if err := json.NewDecoder(r.Body).Decode(&gotUpdate); err != nil {
+				t.Errorf("decode: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"totalUpdatedCells": 3,
+				"responses": []any{
+					map[string]any{"updatedRange": "A!A1:B1", "updatedCells": 2},
+					map[string]any{"updatedRange": "B!C1", "updatedCells": 1},
+				},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc, err := sheets.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newSheetsService = func(context.Context, string) (*sheets.Service, error) { return svc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &SheetsGetCmd{}, []string{"s1", "--ranges", `A!A1:B1,B!C1:C2`}, ctx, flags); err != nil {
+			t.Fatalf("get: %v", err)
+		}
+	})
+	if strings.Join(gotRanges, ",") != "A!A1:B1,B!C1:C2" {
+		t.Fatalf("unexpected ranges: %v", gotRanges)
+	}
+	var got struct {
+		ValueRanges []struct {
+			Range  string  `json:"range"`
+			Values [][]any `json:"values"`
+		} `json:"valueRanges"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal: %v (output: %q)", err, out)
+	}
+	if len(got.ValueRanges) != 2 || got.ValueRanges[1].Range != "B!C1:C2" {
+		t.Fatalf("unexpected output: %#v", got)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runKong(t, &SheetsUpdateCmd{}, []string{"s1", "--batch-json", `{"B!C1":[["x"]],"A!A1:B1":[["a","b"]]}`}, ctx, flags); err != nil {
+			t.Fatalf("update: %v", err)
+		}
+	})
+	if gotUpdate.ValueInputOption != "USER_ENTERED" || len(gotUpdate.Data) != 2 || gotUpdate.Data[0].Range != "A!A1:B1" {
+		t.Fatalf("unexpected batchUpdate request: %#v", gotUpdate)
+	}
+	if !strings.Contains(out, `"totalUpdatedCells": 3`) {
+		t.Fatalf("unexpected update output: %q", out)
+	}
+
+	if err := runKong(t, &SheetsUpdateCmd{}, []string{"s1", "A1", "x", "--batch-json", `{"A1":[["y"]]}`}, ctx, flags); err == nil {
+		t.Fatal("expected --batch-json with a range to be rejected")
+	}
+}
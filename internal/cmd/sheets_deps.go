@@ -0,0 +1,444 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+const sheetsDepsFields = "namedRanges(name,range),sheets(properties(sheetId,title),data(startRow,startColumn,rowData(values(userEnteredValue(formulaValue)))))"
+
+type SheetsDepsCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Cell          string `name:"cell" aliases:"range" required:"" help:"Cell or range to analyze (eg. 'Summary!B2'; defaults to the first tab)"`
+	Reverse       bool   `name:"reverse" aliases:"dependents" help:"List formulas that depend on --cell instead of what feeds it"`
+	Depth         int    `name:"depth" help:"Levels of references to follow (0 = all)" default:"1"`
+}
+
+// sheetsFormulaCell is one cell holding a formula, with the ranges the
+// formula reads from.
+type sheetsFormulaCell struct {
+	A1      string
+	Range   a1Range
+	Formula string
+	Refs    []sheetsFormulaRef
+}
+
+type sheetsFormulaRef struct {
+	A1         string
+	NamedRange string
+	Range      a1Range
+}
+
+type sheetsDepEdge struct {
+	Depth      int    `json:"depth"`
+	Cell       string `json:"cell"`
+	Formula    string `json:"formula"`
+	Ref        string `json:"ref"`
+	RefSheet   string `json:"refSheet"`
+	NamedRange string `json:"namedRange,omitempty"`
+}
+
+func (c *SheetsDepsCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	spreadsheetID := normalizeGoogleID(strings.TrimSpace(c.SpreadsheetID))
+	if spreadsheetID == "" {
+		return usage("empty spreadsheetId")
+	}
+	target, err := parseA1Range(c.Cell)
+	if err != nil {
+		return usagef("invalid --cell: %v", err)
+	}
+	if c.Depth < 0 {
+		return usage("--depth must be >= 0")
+	}
+
+	_, svc, err := requireSheetsService(ctx, flags)
+	if err != nil {
+		return err
+	}
+
+	resp, err := svc.Spreadsheets.Get(spreadsheetID).
+		IncludeGridData(true).
+		Fields(sheetsDepsFields).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	if target.SheetName == "" {
+		if len(resp.Sheets) == 0 || resp.Sheets[0].Properties == nil {
+			return fmt.Errorf("spreadsheet has no sheets")
+		}
+		target.SheetName = resp.Sheets[0].Properties.Title
+	}
+
+	cells := collectSheetsFormulaCells(resp)
+	edges := sheetsFormulaDeps(cells, target, c.Reverse, c.Depth)
+
+	direction := "precedents"
+	if c.Reverse {
+		direction = "dependents"
+	}
+	sheetSet := map[string]struct{}{}
+	for _, e := range edges {
+		if c.Reverse {
+			sheetSet[cellSheetTitle(e.Cell)] = struct{}{}
+		} else {
+			sheetSet[e.RefSheet] = struct{}{}
+		}
+	}
+	sheetNames := make([]string, 0, len(sheetSet))
+	for name := range sheetSet {
+		sheetNames = append(sheetNames, name)
+	}
+	sort.Strings(sheetNames)
+
+	if outfmt.IsJSON(ctx) {
+		if edges == nil {
+			edges = []sheetsDepEdge{}
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"cell":          formatA1Range(target),
+			"direction":     direction,
+			"edges":         edges,
+			"sheets":        sheetNames,
+		})
+	}
+
+	if len(edges) == 0 {
+		u.Err().Println("No formula dependencies found")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "DEPTH\tCELL\tREF\tFORMULA")
+	for _, e := range edges {
+		ref := e.Ref
+		if e.NamedRange != "" {
+			ref = e.NamedRange + " (" + e.Ref + ")"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", e.Depth, e.Cell, ref, oneLine(e.Formula))
+	}
+	return nil
+}
+
+// sheetsFormulaDeps walks the formula graph breadth-first from target. In
+// forward mode it follows formulas inside the frontier to the ranges they
+// read; in reverse mode it finds formulas reading the frontier. Each formula
+// cell is visited once, so cycles terminate.
+func sheetsFormulaDeps(cells []sheetsFormulaCell, target a1Range, reverse bool, maxDepth int) []sheetsDepEdge {
+	var edges []sheetsDepEdge
+	visited := make([]bool, len(cells))
+	frontier := []a1Range{target}
+
+	for depth := 1; len(frontier) > 0 && (maxDepth == 0 || depth <= maxDepth); depth++ {
+		var next []a1Range
+		for i, cell := range cells {
+			if visited[i] {
+				continue
+			}
+			if !reverse {
+				if !a1RangeOverlapsAny(cell.Range, frontier) {
+					continue
+				}
+				visited[i] = true
+				for _, ref := range cell.Refs {
+					edges = append(edges, newSheetsDepEdge(depth, cell, ref))
+					next = append(next, ref.Range)
+				}
+				continue
+			}
+
+			matched := false
+			for _, ref := range cell.Refs {
+				if a1RangeOverlapsAny(ref.Range, frontier) {
+					edges = append(edges, newSheetsDepEdge(depth, cell, ref))
+					matched = true
+				}
+			}
+			if matched {
+				visited[i] = true
+				next = append(next, cell.Range)
+			}
+		}
+		frontier = next
+	}
+	return edges
+}
+
+func newSheetsDepEdge(depth int, cell sheetsFormulaCell, ref sheetsFormulaRef) sheetsDepEdge {
+	return sheetsDepEdge{
+		Depth:      depth,
+		Cell:       cell.A1,
+		Formula:    cell.Formula,
+		Ref:        ref.A1,
+		RefSheet:   ref.Range.SheetName,
+		NamedRange: ref.NamedRange,
+	}
+}
+
+func collectSheetsFormulaCells(ss *sheets.Spreadsheet) []sheetsFormulaCell {
+	titles := map[int64]string{}
+	for _, sheet := range ss.Sheets {
+		if sheet != nil && sheet.Properties != nil {
+			titles[sheet.Properties.SheetId] = sheet.Properties.Title
+		}
+	}
+	named := map[string]sheetsFormulaRef{}
+	for _, nr := range ss.NamedRanges {
+		if nr == nil || nr.Range == nil {
+			continue
+		}
+		title, ok := titles[nr.Range.SheetId]
+		if !ok {
+			continue
+		}
+		rng := gridRangeToA1Range(title, nr.Range)
+		named[strings.ToLower(nr.Name)] = sheetsFormulaRef{A1: formatA1Range(rng), NamedRange: nr.Name, Range: rng}
+	}
+
+	var cells []sheetsFormulaCell
+	for _, sheet := range ss.Sheets {
+		if sheet == nil || sheet.Properties == nil {
+			continue
+		}
+		title := sheet.Properties.Title
+		for _, data := range sheet.Data {
+			if data == nil {
+				continue
+			}
+			for ri, row := range data.RowData {
+				if row == nil {
+					continue
+				}
+				for ci, value := range row.Values {
+					if value == nil || value.UserEnteredValue == nil || value.UserEnteredValue.FormulaValue == nil {
+						continue
+					}
+					r := int(data.StartRow) + ri + 1
+					col := int(data.StartColumn) + ci + 1
+					formula := *value.UserEnteredValue.FormulaValue
+					cells = append(cells, sheetsFormulaCell{
+						A1:      formatA1Cell(title, r, col),
+						Range:   a1Range{SheetName: title, StartRow: r, EndRow: r, StartCol: col, EndCol: col},
+						Formula: formula,
+						Refs:    parseFormulaRefs(formula, title, named),
+					})
+				}
+			}
+		}
+	}
+	return cells
+}
+
+// parseFormulaRefs extracts A1 references and named ranges from a formula.
+// String literals and function names are skipped; unqualified references
+// resolve against sheet.
+func parseFormulaRefs(formula, sheet string, named map[string]sheetsFormulaRef) []sheetsFormulaRef {
+	var refs []sheetsFormulaRef
+	seen := map[string]bool{}
+	add := func(sheetName, ref string) {
+		if sheetName == "" {
+			if nr, ok := named[strings.ToLower(ref)]; ok {
+				if !seen[nr.A1] {
+					seen[nr.A1] = true
+					refs = append(refs, nr)
+				}
+				return
+			}
+			sheetName = sheet
+		}
+		rng, err := parseA1Range(ref)
+		if err != nil {
+			return
+		}
+		rng.SheetName = sheetName
+		a1 := formatA1Range(rng)
+		if !seen[a1] {
+			seen[a1] = true
+			refs = append(refs, sheetsFormulaRef{A1: a1, Range: rng})
+		}
+	}
+
+	s := formula
+	for i := 0; i < len(s); {
+		switch ch := s[i]; {
+		case ch == '"':
+			i = skipFormulaString(s, i)
+		case ch == '\'':
+			var name strings.Builder
+			j := i + 1
+			for j < len(s) {
+				if s[j] == '\'' {
+					if j+1 < len(s) && s[j+1] == '\'' {
+						name.WriteByte('\'')
+						j += 2
+						continue
+					}
+					break
+				}
+				name.WriteByte(s[j])
+				j++
+			}
+			i = j + 1
+			if i < len(s) && s[i] == '!' {
+				var ref string
+				ref, i = readFormulaRef(s, i+1)
+				if ref != "" {
+					add(name.String(), ref)
+				}
+			}
+		case isFormulaWordChar(ch):
+			word, end := readFormulaWord(s, i)
+			i = end
+			switch {
+			case i < len(s) && s[i] == '!':
+				var ref string
+				ref, i = readFormulaRef(s, i+1)
+				if ref != "" {
+					add(word, ref)
+				}
+			case i < len(s) && s[i] == '(':
+				// Function call.
+			default:
+				if i < len(s) && s[i] == ':' {
+					if second, end := readFormulaWord(s, i+1); second != "" {
+						word += ":" + second
+						i = end
+					}
+				}
+				add("", word)
+			}
+		default:
+			i++
+		}
+	}
+	return refs
+}
+
+func skipFormulaString(s string, i int) int {
+	for i++; i < len(s); i++ {
+		if s[i] != '"' {
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '"' {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return i
+}
+
+func readFormulaRef(s string, i int) (string, int) {
+	ref, i := readFormulaWord(s, i)
+	if ref != "" && i < len(s) && s[i] == ':' {
+		if second, end := readFormulaWord(s, i+1); second != "" {
+			return ref + ":" + second, end
+		}
+	}
+	return ref, i
+}
+
+func readFormulaWord(s string, i int) (string, int) {
+	start := i
+	for i < len(s) && isFormulaWordChar(s[i]) {
+		i++
+	}
+	return s[start:i], i
+}
+
+func isFormulaWordChar(ch byte) bool {
+	return ch == '_' || ch == '.' || ch == '$' ||
+		(ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9')
+}
+
+// gridRangeToA1Range converts a GridRange (0-based, end-exclusive) into the
+// 1-based, inclusive a1Range form where 0 means unbounded.
+func gridRangeToA1Range(sheetTitle string, gr *sheets.GridRange) a1Range {
+	rng := a1Range{SheetName: sheetTitle, EndRow: int(gr.EndRowIndex), EndCol: int(gr.EndColumnIndex)}
+	if gr.StartRowIndex > 0 {
+		rng.StartRow = int(gr.StartRowIndex) + 1
+	}
+	if gr.StartColumnIndex > 0 {
+		rng.StartCol = int(gr.StartColumnIndex) + 1
+	}
+	return rng
+}
+
+func formatA1Range(r a1Range) string {
+	ref := func(row, col int) string {
+		out := ""
+		if col > 0 {
+			out, _ = colIndexToLetters(col)
+		}
+		if row > 0 {
+			out += fmt.Sprintf("%d", row)
+		}
+		return out
+	}
+	startRow, startCol := r.StartRow, r.StartCol
+	if startRow == 0 && r.EndRow > 0 {
+		startRow = 1
+	}
+	if startCol == 0 && r.EndCol > 0 {
+		startCol = 1
+	}
+	start := ref(startRow, startCol)
+	end := ref(r.EndRow, r.EndCol)
+	if start == "" && end == "" {
+		return r.SheetName
+	}
+	body := start
+	// Only a single cell collapses; "C:C" and "2:2" keep both ends.
+	if end != "" && (end != start || startRow == 0 || startCol == 0) {
+		body += ":" + end
+	}
+	return formatSheetPrefix(r.SheetName) + body
+}
+
+func a1RangeOverlapsAny(r a1Range, others []a1Range) bool {
+	for _, o := range others {
+		if a1RangesOverlap(r, o) {
+			return true
+		}
+	}
+	return false
+}
+
+func a1RangesOverlap(a, b a1Range) bool {
+	return strings.EqualFold(a.SheetName, b.SheetName) &&
+		a1SpansOverlap(a.StartRow, a.EndRow, b.StartRow, b.EndRow) &&
+		a1SpansOverlap(a.StartCol, a.EndCol, b.StartCol, b.EndCol)
+}
+
+// a1SpansOverlap compares 1-based inclusive spans where 0 bounds are open.
+func a1SpansOverlap(aLo, aHi, bLo, bHi int) bool {
+	if aHi > 0 && bLo > aHi {
+		return false
+	}
+	if bHi > 0 && aLo > bHi {
+		return false
+	}
+	return true
+}
+
+func cellSheetTitle(a1 string) string {
+	sheet, _, err := splitA1Sheet(a1)
+	if err != nil {
+		return ""
+	}
+	return sheet
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestParseFormulaRefs(t *testing.T) {
+	named := map[string]sheetsFormulaRef{
+		"rates": {A1: "Data!D1:D10", NamedRange: "Rates", Range: a1Range{SheetName: "Data", StartRow: 1, EndRow: 10, StartCol: 4, EndCol: 4}},
+	}
+	refs := parseFormulaRefs(`=SUM(Data!$A$2:A100)+'Q1 ''Plan'''!B3*Rates+LEN("C5")+A1+VLOOKUP(x,Data!C:C,2)+LOG10(2)`, "Summary", named)
+
+	var got []string
+	for _, r := range refs {
+		got = append(got, r.A1)
+	}
+	want := []string{"Data!A2:A100", "'Q1 ''Plan'''!B3", "Data!D1:D10", "Summary!A1", "Data!C:C"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("refs = %q, want %q", got, want)
+	}
+	if refs[2].NamedRange != "Rates" {
+		t.Fatalf("expected named range, got %#v", refs[2])
+	}
+}
+
+func TestSheetsDepsCmd_JSON(t *testing.T) {
+	origNew := newSheetsService
+	t.Cleanup(func() { newSheetsService = origNew })
+
+	formula := func(f string) map[string]any {
+		return map[string]any{"userEnteredValue": map[string]any{"formulaValue": f}}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"sheets": []any{
+				map[string]any{
+					"properties": map[string]any{"sheetId": 0, "title": "Summary"},
+					"data": []any{map[string]any{"rowData": []any{
+						map[string]any{"values": []any{map[string]any{}}},
+						map[string]any{"values": []any{map[string]any{}, formula("=Calc!A1*2")}},
+					}}},
+				},
+				map[string]any{
+					"properties": map[string]any{"sheetId": 1, "title": "Calc"},
+					"data": []any{map[string]any{"rowData": []any{
+						map[string]any{"values": []any{formula("=SUM(Data!B:B)")}},
+					}}},
+				},
+				map[string]any{
+					"properties": map[string]any{"sheetId": 2, "title": "Data"},
+					"data":       []any{map[string]any{}},
+				},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	svc, err := sheets.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newSheetsService = func(context.Context, string) (*sheets.Service, error) { return svc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+
+	run := func(args ...string) (result struct {
+		Cell      string          `json:"cell"`
+		Direction string          `json:"direction"`
+		Edges     []sheetsDepEdge `json:"edges"`
+		Sheets    []string        `json:"sheets"`
+	},
+	) {
+		t.Helper()
+		out := captureStdout(t, func() {
+			if err := runKong(t, &SheetsDepsCmd{}, append([]string{"s1"}, args...), ctx, flags); err != nil {
+				t.Fatalf("deps: %v", err)
+			}
+		})
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("unmarshal: %v (output: %q)", err, out)
+		}
+		return result
+	}
+
+	direct := run("--cell", "Summary!B2")
+	if direct.Direction != "precedents" || len(direct.Edges) != 1 || direct.Edges[0].Ref != "Calc!A1" {
+		t.Fatalf("unexpected direct deps: %#v", direct)
+	}
+
+	all := run("--cell", "B2", "--depth", "0")
+	if len(all.Edges) != 2 || all.Edges[1].Ref != "Data!B:B" || all.Edges[1].Depth != 2 {
+		t.Fatalf("unexpected transitive deps: %#v", all.Edges)
+	}
+	if !reflect.DeepEqual(all.Sheets, []string{"Calc", "Data"}) {
+		t.Fatalf("unexpected sheets: %q", all.Sheets)
+	}
+
+	reverse := run("--cell", "Data!B7", "--reverse", "--depth", "0")
+	if reverse.Direction != "dependents" || len(reverse.Edges) != 2 ||
+		reverse.Edges[0].Cell != "Calc!A1" || reverse.Edges[1].Cell != "Summary!B2" {
+		t.Fatalf("unexpected dependents: %#v", reverse.Edges)
+	}
+}
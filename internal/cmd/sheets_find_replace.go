@@ -7,8 +7,8 @@ import (
 
 	"google.golang.org/api/sheets/v4"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type SheetsFindReplaceCmd struct {
@@ -29,6 +29,9 @@ func (c *SheetsFindReplaceCmd) Run(ctx context.Context, flags *RootFlags) error
 	if spreadsheetID == "" {
 		return usage("empty spreadsheetId")
 	}
+	if err := ensureDriveUnlocked(ctx, flags, spreadsheetID); err != nil {
+		return err
+	}
 	if c.Find == "" {
 		return usage("find text cannot be empty")
 	}
@@ -11,8 +11,8 @@ import (
 
 	"google.golang.org/api/sheets/v4"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type SheetsFormatCmd struct {
@@ -81,6 +81,10 @@ func (c *SheetsFormatCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
+	rangeSpec, err = resolveSheetsHeaderRangeLive(ctx, svc, spreadsheetID, rangeSpec)
+	if err != nil {
+		return err
+	}
 	catalog, err := fetchSpreadsheetRangeCatalog(ctx, svc, spreadsheetID)
 	if err != nil {
 		return err
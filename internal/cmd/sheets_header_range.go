@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/idcache"
+)
+
+// sheetsHeaderRange addresses columns by their header (row 1) text instead of
+// letters, e.g. 'Data'[email,amount], so scripts keep working when columns
+// are reordered.
+type sheetsHeaderRange struct {
+	SheetName string
+	Columns   []string
+}
+
+// parseSheetsHeaderRange recognizes Sheet[col,...] ranges. ok=false means the
+// spec is plain A1 or a named range and should be passed through unchanged.
+func parseSheetsHeaderRange(spec string) (sheetsHeaderRange, bool, error) {
+	in := strings.TrimSpace(cleanRange(spec))
+	if !strings.HasSuffix(in, "]") {
+		return sheetsHeaderRange{}, false, nil
+	}
+	open := strings.LastIndex(in, "[")
+	if open <= 0 {
+		return sheetsHeaderRange{}, false, nil
+	}
+	sheetPart := strings.TrimSpace(in[:open])
+	if !strings.HasPrefix(sheetPart, "'") && strings.Contains(sheetPart, "!") {
+		return sheetsHeaderRange{}, false, nil
+	}
+	sheetName, err := unquoteSheetName(sheetPart)
+	if err != nil {
+		return sheetsHeaderRange{}, true, usagef("invalid header range %q: %v", in, err)
+	}
+
+	rawCols := strings.Split(in[open+1:len(in)-1], ",")
+	cols := make([]string, 0, len(rawCols))
+	for _, col := range rawCols {
+		col = strings.TrimSpace(col)
+		if col == "" {
+			return sheetsHeaderRange{}, true, usagef("empty column name in %q", in)
+		}
+		cols = append(cols, col)
+	}
+	return sheetsHeaderRange{SheetName: sheetName, Columns: cols}, true, nil
+}
+
+// validateSheetRangeSpec checks an A1 range before any API call. Header
+// ranges only get a syntax check here; their columns resolve later.
+func validateSheetRangeSpec(spec, label string) error {
+	if _, ok, err := parseSheetsHeaderRange(spec); ok || err != nil {
+		return err
+	}
+	_, err := parseSheetRange(spec, label)
+	return err
+}
+
+// resolveSheetsHeaderRange rewrites a header range into A1 covering the data
+// rows below the header (e.g. 'Data'[email,amount] -> Data!C2:D). Other specs
+// are returned unchanged without an API call. Header positions may come from
+// the name cache, so only read-only commands use it; writes go through
+// resolveSheetsHeaderRangeLive.
+func resolveSheetsHeaderRange(ctx context.Context, svc *sheets.Service, spreadsheetID, spec string) (string, error) {
+	return resolveSheetsHeaderRangeWith(spec, func(hr sheetsHeaderRange) (map[string]string, error) {
+		wanted := make([]string, 0, len(hr.Columns))
+		for _, col := range hr.Columns {
+			wanted = append(wanted, normalizeSheetsHeader(col))
+		}
+		return cachedNameMap(ctx, idcache.KindSheetHeaders, sheetsHeaderCacheScope(spreadsheetID, hr.SheetName), wanted, func() (map[string]string, error) {
+			return fetchSheetsHeaderColumns(ctx, svc, spreadsheetID, hr.SheetName)
+		})
+	})
+}
+
+// resolveSheetsHeaderRangeLive is resolveSheetsHeaderRange for mutating
+// commands: it always reads the header row, because a column moved or deleted
+// since the cache was filled would send the write to the wrong column. The
+// fresh mapping replaces the cached one.
+func resolveSheetsHeaderRangeLive(ctx context.Context, svc *sheets.Service, spreadsheetID, spec string) (string, error) {
+	return resolveSheetsHeaderRangeWith(spec, func(hr sheetsHeaderRange) (map[string]string, error) {
+		headers, err := fetchSheetsHeaderColumns(ctx, svc, spreadsheetID, hr.SheetName)
+		if err != nil {
+			return nil, err
+		}
+		storeNameCache(ctx, idcache.KindSheetHeaders, sheetsHeaderCacheScope(spreadsheetID, hr.SheetName), headers)
+		return headers, nil
+	})
+}
+
+func resolveSheetsHeaderRangeWith(spec string, lookup func(sheetsHeaderRange) (map[string]string, error)) (string, error) {
+	hr, ok, err := parseSheetsHeaderRange(spec)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return spec, nil
+	}
+	headers, err := lookup(hr)
+	if err != nil {
+		return "", err
+	}
+	return sheetsHeaderRangeA1(hr, headers)
+}
+
+func sheetsHeaderCacheScope(spreadsheetID, sheetName string) string {
+	return spreadsheetID + "!" + sheetName
+}
+
+func resolveSheetsHeaderRanges(ctx context.Context, svc *sheets.Service, spreadsheetID string, specs []string) ([]string, error) {
+	out := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		resolved, err := resolveSheetsHeaderRange(ctx, svc, spreadsheetID, spec)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, resolved)
+	}
+	return out, nil
+}
+
+// fetchSheetsHeaderColumns maps normalized header text to column letters.
+// Headers that appear more than once map to "" so lookups can report them as
+// ambiguous instead of silently picking one.
+func fetchSheetsHeaderColumns(ctx context.Context, svc *sheets.Service, spreadsheetID, title string) (map[string]string, error) {
+	resp, err := svc.Spreadsheets.Values.Get(spreadsheetID, formatSheetPrefix(title)+"1:1").Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("read header row of %q: %w", title, err)
+	}
+
+	out := make(map[string]string)
+	if len(resp.Values) == 0 {
+		return out, nil
+	}
+	for i, cell := range resp.Values[0] {
+		key := normalizeSheetsHeader(fmt.Sprint(cell))
+		if key == "" {
+			continue
+		}
+		if _, dup := out[key]; dup {
+			out[key] = ""
+			continue
+		}
+		letters, err := colIndexToLetters(i + 1)
+		if err != nil {
+			return nil, err
+		}
+		out[key] = letters
+	}
+	return out, nil
+}
+
+func sheetsHeaderRangeA1(hr sheetsHeaderRange, headers map[string]string) (string, error) {
+	letters := make([]string, 0, len(hr.Columns))
+	adjacent := true
+	var first int
+	for i, col := range hr.Columns {
+		l, ok := headers[normalizeSheetsHeader(col)]
+		if !ok {
+			return "", usagef("unknown column %q in header row of %q", col, hr.SheetName)
+		}
+		if l == "" {
+			return "", usagef("ambiguous column %q: header appears more than once in %q", col, hr.SheetName)
+		}
+		idx, err := colLettersToIndex(l)
+		if err != nil {
+			return "", err
+		}
+		if i == 0 {
+			first = idx
+		} else if idx != first+i {
+			adjacent = false
+		}
+		letters = append(letters, l)
+	}
+	if !adjacent {
+		return "", usagef("columns [%s] are not adjacent in %q (%s); address them as separate ranges",
+			strings.Join(hr.Columns, ","), hr.SheetName, strings.Join(letters, ","))
+	}
+	return fmt.Sprintf("%s%s2:%s", formatSheetPrefix(hr.SheetName), letters[0], letters[len(letters)-1]), nil
+}
+
+func normalizeSheetsHeader(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestParseSheetsHeaderRange(t *testing.T) {
+	tests := []struct {
+		in     string
+		ok     bool
+		sheet  string
+		cols   []string
+		errSub string
+	}{
+		{in: "'Data'[email,amount]", ok: true, sheet: "Data", cols: []string{"email", "amount"}},
+		{in: "Data[ email ]", ok: true, sheet: "Data", cols: []string{"email"}},
+		{in: "'My ''Tab'''[Total Due]", ok: true, sheet: "My 'Tab'", cols: []string{"Total Due"}},
+		{in: "Sheet1!A1:B2", ok: false},
+		{in: "MyNamedRange", ok: false},
+		{in: "[email]", ok: false},
+		{in: "Data[email,]", ok: true, errSub: "empty column name"},
+		{in: "'Data[email]", ok: true, errSub: "invalid header range"},
+	}
+	for _, tt := range tests {
+		hr, ok, err := parseSheetsHeaderRange(tt.in)
+		if ok != tt.ok {
+			t.Fatalf("%q: ok=%v, want %v", tt.in, ok, tt.ok)
+		}
+		if tt.errSub != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.errSub) {
+				t.Fatalf("%q: expected error containing %q, got %v", tt.in, tt.errSub, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: %v", tt.in, err)
+		}
+		if !ok {
+			continue
+		}
+		if hr.SheetName != tt.sheet || strings.Join(hr.Columns, "|") != strings.Join(tt.cols, "|") {
+			t.Fatalf("%q: got %#v", tt.in, hr)
+		}
+	}
+}
+
+func TestSheetsHeaderRangeA1(t *testing.T) {
+	headers := map[string]string{"id": "A", "email": "C", "amount": "D", "note": "F", "dup": ""}
+	tests := []struct {
+		hr     sheetsHeaderRange
+		want   string
+		errSub string
+	}{
+		{hr: sheetsHeaderRange{SheetName: "Data", Columns: []string{"Email", "amount"}}, want: "Data!C2:D"},
+		{hr: sheetsHeaderRange{SheetName: "Q1 Sales", Columns: []string{"note"}}, want: "'Q1 Sales'!F2:F"},
+		{hr: sheetsHeaderRange{SheetName: "Data", Columns: []string{"amount", "email"}}, errSub: "not adjacent"},
+		{hr: sheetsHeaderRange{SheetName: "Data", Columns: []string{"id", "email"}}, errSub: "(A,C)"},
+		{hr: sheetsHeaderRange{SheetName: "Data", Columns: []string{"missing"}}, errSub: "unknown column"},
+		{hr: sheetsHeaderRange{SheetName: "Data", Columns: []string{"dup"}}, errSub: "ambiguous column"},
+	}
+	for _, tt := range tests {
+		got, err := sheetsHeaderRangeA1(tt.hr, headers)
+		if tt.errSub != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.errSub) {
+				t.Fatalf("%v: expected error containing %q, got %v", tt.hr.Columns, tt.errSub, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%v: %v", tt.hr.Columns, err)
+		}
+		if got != tt.want {
+			t.Fatalf("%v: got %q, want %q", tt.hr.Columns, got, tt.want)
+		}
+	}
+}
+
+func TestResolveSheetsHeaderRange_ReadsHeaderRow(t *testing.T) {
+	var gotRanges []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/values/") {
+			http.NotFound(w, r)
+			return
+		}
+		gotRanges = append(gotRanges, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"range":  "Data!1:1",
+			"values": [][]any{{"Name", "", "Email", "Amount", "Email "}},
+		})
+	}))
+	defer srv.Close()
+
+	svc, err := sheets.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	got, err := resolveSheetsHeaderRange(context.Background(), svc, "s1", "Data[name]")
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if got != "Data!A2:A" {
+		t.Fatalf("got %q", got)
+	}
+	if len(gotRanges) != 1 || !strings.HasSuffix(gotRanges[0], "/values/Data!1:1") {
+		t.Fatalf("unexpected header fetches: %v", gotRanges)
+	}
+
+	// Duplicate headers (after trimming) must not silently pick a column.
+	if _, err := resolveSheetsHeaderRange(context.Background(), svc, "s1", "Data[email]"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected ambiguous error, got %v", err)
+	}
+
+	// Plain A1 passes through without an API call.
+	gotRanges = nil
+	plain, err := resolveSheetsHeaderRange(context.Background(), svc, "s1", "Sheet1!A1:B2")
+	if err != nil || plain != "Sheet1!A1:B2" || len(gotRanges) != 0 {
+		t.Fatalf("plain range: got %q err=%v fetches=%v", plain, err, gotRanges)
+	}
+}
+
+func TestResolveSheetsHeaderRangeLive_IgnoresStaleCache(t *testing.T) {
+	ctx := withTestNameCache(t)
+
+	headerRow := []any{"Email", "Amount"}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"range": "Data!1:1", "values": [][]any{headerRow}})
+	}))
+	defer srv.Close()
+
+	svc, err := sheets.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	if got, err := resolveSheetsHeaderRange(ctx, svc, "s1", "Data[amount]"); err != nil || got != "Data!B2:B" {
+		t.Fatalf("cached read: got %q err=%v", got, err)
+	}
+
+	// Someone moves the column; the cached mapping still points at B.
+	headerRow = []any{"Amount", "Email"}
+	if got, _ := resolveSheetsHeaderRange(ctx, svc, "s1", "Data[amount]"); got != "Data!B2:B" {
+		t.Fatalf("expected cached read to reuse stale mapping, got %q", got)
+	}
+	got, err := resolveSheetsHeaderRangeLive(ctx, svc, "s1", "Data[amount]")
+	if err != nil || got != "Data!A2:A" {
+		t.Fatalf("live: got %q err=%v", got, err)
+	}
+	// The live read refreshed the cache for later reads.
+	if got, _ := resolveSheetsHeaderRange(ctx, svc, "s1", "Data[amount]"); got != "Data!A2:A" {
+		t.Fatalf("expected refreshed cache, got %q", got)
+	}
+}
@@ -7,8 +7,9 @@ import (
 
 	"google.golang.org/api/sheets/v4"
 
-	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/idcache"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type SheetsInsertCmd struct {
@@ -107,6 +108,9 @@ func (c *SheetsInsertCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if _, err := svc.Spreadsheets.BatchUpdate(spreadsheetID, req).Do(); err != nil {
 		return err
 	}
+	if apiDimension == "COLUMNS" {
+		invalidateNameCache(ctx, idcache.KindSheetHeaders, sheetsHeaderCacheScope(spreadsheetID, sheetName))
+	}
 
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
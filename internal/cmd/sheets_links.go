@@ -8,8 +8,8 @@ import (
 
 	"google.golang.org/api/sheets/v4"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type SheetsLinksCmd struct {
@@ -33,6 +33,10 @@ func (c *SheetsLinksCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	rangeSpec, err = resolveSheetsHeaderRange(ctx, svc, spreadsheetID, rangeSpec)
+	if err != nil {
+		return err
+	}
 
 	resp, err := svc.Spreadsheets.Get(spreadsheetID).
 		Ranges(rangeSpec).
@@ -29,8 +29,7 @@ func (c *SheetsMergeCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	rangeInfo, err := parseSheetRange(rangeSpec, "merge")
-	if err != nil {
+	if err := validateSheetRangeSpec(rangeSpec, "merge"); err != nil {
 		return err
 	}
 
@@ -39,6 +38,14 @@ func (c *SheetsMergeCmd) Run(ctx context.Context, flags *RootFlags) error {
 		"range":          rangeSpec,
 		"type":           mergeType,
 	}, func(ctx context.Context, svc *sheets.Service) (map[string]any, string, error) {
+		rangeSpec, err := resolveSheetsHeaderRangeLive(ctx, svc, spreadsheetID, rangeSpec)
+		if err != nil {
+			return nil, "", err
+		}
+		rangeInfo, err := parseSheetRange(rangeSpec, "merge")
+		if err != nil {
+			return nil, "", err
+		}
 		sheetIDs, err := fetchSheetIDMap(ctx, svc, spreadsheetID)
 		if err != nil {
 			return nil, "", err
@@ -80,8 +87,7 @@ func (c *SheetsUnmergeCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return usage("empty range")
 	}
 
-	rangeInfo, err := parseSheetRange(rangeSpec, "unmerge")
-	if err != nil {
+	if err := validateSheetRangeSpec(rangeSpec, "unmerge"); err != nil {
 		return err
 	}
 
@@ -89,6 +95,14 @@ func (c *SheetsUnmergeCmd) Run(ctx context.Context, flags *RootFlags) error {
 		"spreadsheet_id": spreadsheetID,
 		"range":          rangeSpec,
 	}, func(ctx context.Context, svc *sheets.Service) (map[string]any, string, error) {
+		rangeSpec, err := resolveSheetsHeaderRangeLive(ctx, svc, spreadsheetID, rangeSpec)
+		if err != nil {
+			return nil, "", err
+		}
+		rangeInfo, err := parseSheetRange(rangeSpec, "unmerge")
+		if err != nil {
+			return nil, "", err
+		}
 		sheetIDs, err := fetchSheetIDMap(ctx, svc, spreadsheetID)
 		if err != nil {
 			return nil, "", err
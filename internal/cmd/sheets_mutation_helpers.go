@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"google.golang.org/api/sheets/v4"
 
-	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/idcache"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 func runSheetsMutation(
@@ -45,6 +47,9 @@ func runSheetsMutation(
 }
 
 func resolveSheetIDByNameOrFirst(ctx context.Context, svc *sheets.Service, spreadsheetID, sheetName string) (int64, string, error) {
+	if wanted := strings.TrimSpace(sheetName); wanted != "" {
+		return resolveSheetIDByNameCached(ctx, svc, spreadsheetID, wanted)
+	}
 	catalog, err := fetchSpreadsheetRangeCatalog(ctx, svc, spreadsheetID)
 	if err != nil {
 		return 0, "", err
@@ -52,6 +57,40 @@ func resolveSheetIDByNameOrFirst(ctx context.Context, svc *sheets.Service, sprea
 	return resolveSheetIDByNameOrFirstWithCatalog(catalog, sheetName)
 }
 
+// resolveSheetIDByNameCached resolves an exact tab title through the name→ID
+// cache (scoped per spreadsheet), falling back to a catalog fetch on a miss.
+func resolveSheetIDByNameCached(ctx context.Context, svc *sheets.Service, spreadsheetID, title string) (int64, string, error) {
+	var catalog *spreadsheetRangeCatalog
+	names, err := cachedNameMap(ctx, idcache.KindSheetTabs, spreadsheetID, []string{title}, func() (map[string]string, error) {
+		fetched, fetchErr := fetchSpreadsheetRangeCatalog(ctx, svc, spreadsheetID)
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+		catalog = fetched
+		out := make(map[string]string, len(fetched.Sheets))
+		for _, props := range fetched.Sheets {
+			if props != nil {
+				out[props.Title] = strconv.FormatInt(props.SheetId, 10)
+			}
+		}
+		return out, nil
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	if raw, ok := names[title]; ok {
+		if id, parseErr := strconv.ParseInt(raw, 10, 64); parseErr == nil {
+			return id, title, nil
+		}
+	}
+	if catalog == nil {
+		if catalog, err = fetchSpreadsheetRangeCatalog(ctx, svc, spreadsheetID); err != nil {
+			return 0, "", err
+		}
+	}
+	return resolveSheetIDByNameOrFirstWithCatalog(catalog, title)
+}
+
 func resolveSheetIDByNameOrFirstWithCatalog(catalog *spreadsheetRangeCatalog, sheetName string) (int64, string, error) {
 	if catalog == nil {
 		return 0, "", fmt.Errorf("missing spreadsheet range catalog")
@@ -10,8 +10,8 @@ import (
 
 	"google.golang.org/api/sheets/v4"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type SheetsNamedRangesCmd struct {
@@ -186,6 +186,10 @@ func (c *SheetsNamedRangesAddCmd) Run(ctx context.Context, flags *RootFlags) err
 		return err
 	}
 
+	rangeSpec, err = resolveSheetsHeaderRangeLive(ctx, svc, spreadsheetID, rangeSpec)
+	if err != nil {
+		return err
+	}
 	r, err := parseSheetRange(rangeSpec, "range")
 	if err != nil {
 		return err
@@ -292,6 +296,10 @@ func (c *SheetsNamedRangesUpdateCmd) Run(ctx context.Context, flags *RootFlags)
 		fields = append(fields, "name")
 	}
 	if newRangeSpec != "" {
+		newRangeSpec, err = resolveSheetsHeaderRangeLive(ctx, svc, spreadsheetID, newRangeSpec)
+		if err != nil {
+			return err
+		}
 		parsedRange, parseErr := parseSheetRange(newRangeSpec, "range")
 		if parseErr != nil {
 			return parseErr
@@ -7,8 +7,8 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type SheetsNotesCmd struct {
@@ -32,6 +32,10 @@ func (c *SheetsNotesCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	rangeSpec, err = resolveSheetsHeaderRange(ctx, svc, spreadsheetID, rangeSpec)
+	if err != nil {
+		return err
+	}
 
 	resp, err := svc.Spreadsheets.Get(spreadsheetID).
 		Ranges(rangeSpec).
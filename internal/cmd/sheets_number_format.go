@@ -31,8 +31,7 @@ func (c *SheetsNumberFormatCmd) Run(ctx context.Context, flags *RootFlags) error
 	}
 	pattern := strings.TrimSpace(c.Pattern)
 
-	rangeInfo, err := parseSheetRange(rangeSpec, "number-format")
-	if err != nil {
+	if err := validateSheetRangeSpec(rangeSpec, "number-format"); err != nil {
 		return err
 	}
 
@@ -42,6 +41,14 @@ func (c *SheetsNumberFormatCmd) Run(ctx context.Context, flags *RootFlags) error
 		"type":           numberType,
 		"pattern":        pattern,
 	}, func(ctx context.Context, svc *sheets.Service) (map[string]any, string, error) {
+		rangeSpec, err := resolveSheetsHeaderRangeLive(ctx, svc, spreadsheetID, rangeSpec)
+		if err != nil {
+			return nil, "", err
+		}
+		rangeInfo, err := parseSheetRange(rangeSpec, "number-format")
+		if err != nil {
+			return nil, "", err
+		}
 		sheetIDs, err := fetchSheetIDMap(ctx, svc, spreadsheetID)
 		if err != nil {
 			return nil, "", err
@@ -10,8 +10,8 @@ import (
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/sheets/v4"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type SheetsReadFormatCmd struct {
@@ -49,6 +49,10 @@ func (c *SheetsReadFormatCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	rangeSpec, err = resolveSheetsHeaderRange(ctx, svc, spreadsheetID, rangeSpec)
+	if err != nil {
+		return err
+	}
 
 	source := "userEnteredFormat"
 	if c.Effective {
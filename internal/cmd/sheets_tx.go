@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+	"gopkg.in/yaml.v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+// SheetsTxCmd validates a list of value edits against the spreadsheet and
+// then applies all of them in one spreadsheets.batchUpdate, which Sheets
+// applies atomically. An ops file looks like:
+//
+//	input: USER_ENTERED
+//	ops:
+//	  - op: update
+//	    range: Summary!A1:B2
+//	    values: [[Total, "=SUM(Data!B:B)"], [Updated, 2025-01-31]]
+//	  - op: append
+//	    range: Log!A:C
+//	    values: [[2025-01-31, import, ok]]
+//	  - op: clear
+//	    range: Staging!A2:F
+type SheetsTxCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	File          string `name:"file" short:"f" required:"" help:"Ops file (YAML or JSON; - for stdin)"`
+	ValueInput    string `name:"input" help:"Value input option: RAW or USER_ENTERED (overrides the file's input)"`
+}
+
+type sheetsTx struct {
+	Input string       `yaml:"input" json:"input,omitempty"`
+	Ops   []sheetsTxOp `yaml:"ops" json:"ops"`
+}
+
+type sheetsTxOp struct {
+	Op     string  `yaml:"op" json:"op"`
+	Range  string  `yaml:"range" json:"range"`
+	Values [][]any `yaml:"values" json:"values,omitempty"`
+}
+
+const (
+	sheetsTxUpdate = "update"
+	sheetsTxAppend = "append"
+	sheetsTxClear  = "clear"
+)
+
+func (c *SheetsTxCmd) Run(ctx context.Context, flags *RootFlags) error {
+	spreadsheetID := normalizeGoogleID(strings.TrimSpace(c.SpreadsheetID))
+	if spreadsheetID == "" {
+		return usage("empty spreadsheetId")
+	}
+	if err := ensureDriveUnlocked(ctx, flags, spreadsheetID); err != nil {
+		return err
+	}
+	b, err := resolveInlineOrFileBytes("@" + strings.TrimPrefix(strings.TrimSpace(c.File), "@"))
+	if err != nil {
+		return fmt.Errorf("read --file: %w", err)
+	}
+	tx, err := parseSheetsTx(b)
+	if err != nil {
+		return err
+	}
+	valueInput := strings.ToUpper(strings.TrimSpace(c.ValueInput))
+	if valueInput == "" {
+		valueInput = strings.ToUpper(strings.TrimSpace(tx.Input))
+	}
+	if valueInput == "" {
+		valueInput = "USER_ENTERED"
+	}
+	if valueInput != "USER_ENTERED" && valueInput != "RAW" {
+		return usagef("invalid value input option %q (use RAW or USER_ENTERED)", valueInput)
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newSheetsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	for i := range tx.Ops {
+		if tx.Ops[i].Range, err = resolveSheetsHeaderRangeLive(ctx, svc, spreadsheetID, tx.Ops[i].Range); err != nil {
+			return err
+		}
+	}
+	catalog, err := fetchSpreadsheetRangeCatalog(ctx, svc, spreadsheetID)
+	if err != nil {
+		return err
+	}
+	requests, err := buildSheetsTxRequests(tx, catalog, valueInput)
+	if err != nil {
+		return err
+	}
+
+	// Validation needs the spreadsheet, so --dry-run reports a checked plan.
+	if err := dryRunExit(ctx, flags, "sheets.tx", map[string]any{
+		"spreadsheet_id":     spreadsheetID,
+		"value_input_option": valueInput,
+		"ops":                tx.Ops,
+		"validated":          true,
+	}); err != nil {
+		return err
+	}
+	if err := applySheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{Requests: requests}); err != nil {
+		return fmt.Errorf("transaction failed, no changes applied: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"applied":       len(tx.Ops),
+		})
+	}
+	ui.FromContext(ctx).Out().Printf("Applied %d operation%s to %s in one batch update", len(tx.Ops), pluralS(len(tx.Ops)), spreadsheetID)
+	return nil
+}
+
+// parseSheetsTx decodes and normalizes an ops file. YAML is a superset of
+// JSON, so both formats go through the same decoder.
+func parseSheetsTx(b []byte) (sheetsTx, error) {
+	var tx sheetsTx
+	if err := yaml.Unmarshal(b, &tx); err != nil {
+		return sheetsTx{}, fmt.Errorf("invalid ops file: %w", err)
+	}
+	if len(tx.Ops) == 0 {
+		return sheetsTx{}, usage("ops file has no ops")
+	}
+	for i := range tx.Ops {
+		op := &tx.Ops[i]
+		op.Op = strings.ToLower(strings.TrimSpace(op.Op))
+		op.Range = cleanRange(strings.TrimSpace(op.Range))
+		switch op.Op {
+		case sheetsTxUpdate, sheetsTxAppend:
+			if len(op.Values) == 0 {
+				return sheetsTx{}, usagef("op %d (%s): values are required", i+1, op.Op)
+			}
+		case sheetsTxClear:
+			if len(op.Values) > 0 {
+				return sheetsTx{}, usagef("op %d (clear): values are not allowed", i+1)
+			}
+		default:
+			return sheetsTx{}, usagef("op %d: invalid op %q (use update|append|clear)", i+1, op.Op)
+		}
+		if op.Range == "" {
+			return sheetsTx{}, usagef("op %d (%s): range is required", i+1, op.Op)
+		}
+	}
+	return tx, nil
+}
+
+// buildSheetsTxRequests checks every op against the spreadsheet (sheet and
+// named range exist, values fit the range and the grid, cells are scalars)
+// and returns the batchUpdate requests. Nothing is built unless all ops pass.
+func buildSheetsTxRequests(tx sheetsTx, catalog *spreadsheetRangeCatalog, valueInput string) ([]*sheets.Request, error) {
+	grids := make(map[int64]*sheets.GridProperties, len(catalog.Sheets))
+	for _, props := range catalog.Sheets {
+		if props != nil && props.GridProperties != nil {
+			grids[props.SheetId] = props.GridProperties
+		}
+	}
+
+	requests := make([]*sheets.Request, 0, len(tx.Ops))
+	for i, op := range tx.Ops {
+		label := fmt.Sprintf("op %d (%s %s)", i+1, op.Op, op.Range)
+		gr, err := resolveGridRangeWithCatalog(op.Range, catalog, op.Op)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", label, err)
+		}
+		rows, err := sheetsTxRows(op.Values, valueInput)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", label, err)
+		}
+		if err := checkSheetsTxShape(op, gr, grids[gr.SheetId]); err != nil {
+			return nil, fmt.Errorf("%s: %w", label, err)
+		}
+
+		switch op.Op {
+		case sheetsTxUpdate:
+			start := &sheets.GridRange{
+				SheetId:          gr.SheetId,
+				StartRowIndex:    gr.StartRowIndex,
+				EndRowIndex:      gr.StartRowIndex + int64(len(op.Values)),
+				StartColumnIndex: gr.StartColumnIndex,
+				EndColumnIndex:   gr.StartColumnIndex + int64(sheetsTxWidth(op.Values)),
+				ForceSendFields:  []string{"SheetId", "StartRowIndex", "StartColumnIndex"},
+			}
+			requests = append(requests, &sheets.Request{UpdateCells: &sheets.UpdateCellsRequest{
+				Range:  start,
+				Rows:   rows,
+				Fields: "userEnteredValue",
+			}})
+		case sheetsTxAppend:
+			requests = append(requests, &sheets.Request{AppendCells: &sheets.AppendCellsRequest{
+				SheetId:         gr.SheetId,
+				Rows:            rows,
+				Fields:          "userEnteredValue",
+				ForceSendFields: []string{"SheetId"},
+			}})
+		case sheetsTxClear:
+			requests = append(requests, &sheets.Request{UpdateCells: &sheets.UpdateCellsRequest{
+				Range:  gr,
+				Fields: "userEnteredValue",
+			}})
+		}
+	}
+	return requests, nil
+}
+
+// checkSheetsTxShape rejects values that overflow a bounded range, writes
+// past the sheet's grid, and appends that do not start at column A (append
+// requests always fill rows from the first column).
+func checkSheetsTxShape(op sheetsTxOp, gr *sheets.GridRange, grid *sheets.GridProperties) error {
+	height, width := int64(len(op.Values)), int64(sheetsTxWidth(op.Values))
+	if op.Op == sheetsTxAppend {
+		if gr.StartColumnIndex != 0 {
+			return usage("append ranges must start at column A")
+		}
+		if gr.EndColumnIndex > 0 && width > gr.EndColumnIndex {
+			return usagef("rows have %d columns but the range has %d", width, gr.EndColumnIndex)
+		}
+		return nil
+	}
+	// A single cell is an anchor, as with `sheets update`.
+	anchor := gr.EndRowIndex-gr.StartRowIndex == 1 && gr.EndColumnIndex-gr.StartColumnIndex == 1
+	if op.Op == sheetsTxUpdate && !anchor {
+		if gr.EndRowIndex > 0 && height > gr.EndRowIndex-gr.StartRowIndex {
+			return usagef("%d rows of values do not fit the range's %d rows", height, gr.EndRowIndex-gr.StartRowIndex)
+		}
+		if gr.EndColumnIndex > 0 && width > gr.EndColumnIndex-gr.StartColumnIndex {
+			return usagef("%d columns of values do not fit the range's %d columns", width, gr.EndColumnIndex-gr.StartColumnIndex)
+		}
+	}
+	if grid == nil {
+		return nil
+	}
+	lastRow, lastCol := gr.EndRowIndex, gr.EndColumnIndex
+	if op.Op == sheetsTxUpdate {
+		lastRow, lastCol = gr.StartRowIndex+height, gr.StartColumnIndex+width
+	}
+	if grid.RowCount > 0 && lastRow > grid.RowCount {
+		return usagef("range reaches row %d but the sheet has %d rows", lastRow, grid.RowCount)
+	}
+	if grid.ColumnCount > 0 && lastCol > grid.ColumnCount {
+		return usagef("range reaches column %d but the sheet has %d columns", lastCol, grid.ColumnCount)
+	}
+	return nil
+}
+
+func sheetsTxWidth(values [][]any) int {
+	width := 0
+	for _, row := range values {
+		width = max(width, len(row))
+	}
+	return width
+}
+
+// sheetsTxRows converts scalar cells to CellData. With USER_ENTERED,
+// strings starting with "=" become formulas; everything else is stored as
+// typed, without Sheets' locale parsing of dates and numbers in strings.
+func sheetsTxRows(values [][]any, valueInput string) ([]*sheets.RowData, error) {
+	rows := make([]*sheets.RowData, 0, len(values))
+	for r, row := range values {
+		cells := make([]*sheets.CellData, 0, len(row))
+		for c, v := range row {
+			cell := &sheets.CellData{}
+			switch x := v.(type) {
+			case nil:
+			case string:
+				if valueInput == "USER_ENTERED" && strings.HasPrefix(x, "=") {
+					cell.UserEnteredValue = &sheets.ExtendedValue{FormulaValue: &x}
+				} else {
+					cell.UserEnteredValue = &sheets.ExtendedValue{StringValue: &x}
+				}
+			case time.Time:
+				// Unquoted YAML dates; keep them as the text the user wrote.
+				text := x.Format("2006-01-02")
+				if x.Hour() != 0 || x.Minute() != 0 || x.Second() != 0 {
+					text = x.Format(time.RFC3339)
+				}
+				cell.UserEnteredValue = &sheets.ExtendedValue{StringValue: &text}
+			case bool:
+				cell.UserEnteredValue = &sheets.ExtendedValue{BoolValue: &x}
+			case int:
+				n := float64(x)
+				cell.UserEnteredValue = &sheets.ExtendedValue{NumberValue: &n}
+			case int64:
+				n := float64(x)
+				cell.UserEnteredValue = &sheets.ExtendedValue{NumberValue: &n}
+			case uint64:
+				n := float64(x)
+				cell.UserEnteredValue = &sheets.ExtendedValue{NumberValue: &n}
+			case float64:
+				cell.UserEnteredValue = &sheets.ExtendedValue{NumberValue: &x}
+			default:
+				return nil, usagef("cell [%d][%d] must be a string, number, boolean, or null (got %T)", r+1, c+1, v)
+			}
+			cells = append(cells, cell)
+		}
+		rows = append(rows, &sheets.RowData{Values: cells})
+	}
+	return rows, nil
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func TestParseSheetsTx(t *testing.T) {
+	tx, err := parseSheetsTx([]byte(`
+ops:
+  - op: Update
+    range: "'Q1 Data'!A1:B2"
+    values: [[Total, "=SUM(B2:B9)"], [2025-01-31, 42]]
+  - op: clear
+    range: Staging!A2:F
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(tx.Ops) != 2 || tx.Ops[0].Op != sheetsTxUpdate || tx.Ops[1].Range != "Staging!A2:F" {
+		t.Fatalf("unexpected ops: %+v", tx.Ops)
+	}
+	rows, err := sheetsTxRows(tx.Ops[0].Values, "USER_ENTERED")
+	if err != nil {
+		t.Fatalf("rows: %v", err)
+	}
+	if got := rows[0].Values[1].UserEnteredValue.FormulaValue; got == nil || *got != "=SUM(B2:B9)" {
+		t.Fatalf("expected formula, got %+v", rows[0].Values[1].UserEnteredValue)
+	}
+	if got := rows[1].Values[0].UserEnteredValue.StringValue; got == nil || *got != "2025-01-31" {
+		t.Fatalf("expected date text, got %+v", rows[1].Values[0].UserEnteredValue)
+	}
+	if got := rows[1].Values[1].UserEnteredValue.NumberValue; got == nil || *got != 42 {
+		t.Fatalf("expected number, got %+v", rows[1].Values[1].UserEnteredValue)
+	}
+
+	for _, bad := range []string{
+		`ops: []`,
+		"ops:\n  - op: delete\n    range: A!A1",
+		"ops:\n  - op: update\n    range: A!A1",
+		"ops:\n  - op: clear\n    range: A!A1\n    values: [[x]]",
+	} {
+		if _, err := parseSheetsTx([]byte(bad)); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+	if _, err := sheetsTxRows([][]any{{map[string]any{"a": 1}}}, "RAW"); err == nil || !strings.Contains(err.Error(), "cell [1][1]") {
+		t.Fatalf("expected cell type error, got %v", err)
+	}
+}
+
+func TestSheetsTxCmd(t *testing.T) {
+	origNew := newSheetsService
+	t.Cleanup(func() { newSheetsService = origNew })
+
+	var batches []sheets.BatchUpdateSpreadsheetRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v4")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(path, "/spreadsheets/s1") && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"spreadsheetId": "s1",
+				"sheets": []map[string]any{
+					{"properties": map[string]any{"sheetId": 0, "title": "Data", "gridProperties": map[string]any{"rowCount": 10, "columnCount": 4}}},
+					{"properties": map[string]any{"sheetId": 5, "title": "Log", "gridProperties": map[string]any{"rowCount": 100, "columnCount": 3}}},
+				},
+			})
+		case strings.Contains(path, "/spreadsheets/s1:batchUpdate") && r.Method == http.MethodPost:
+			var req sheets.BatchUpdateSpreadsheetRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			batches = append(batches, req)
+			_ = json.NewEncoder(w).Encode(map[string]any{"spreadsheetId": "s1"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc, err := sheets.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newSheetsService = func(context.Context, string) (*sheets.Service, error) { return svc, nil }
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := ui.WithUI(context.Background(), u)
+	flags := &RootFlags{Account: "a@b.com"}
+	dir := t.TempDir()
+	run := func(spec string) error {
+		p := filepath.Join(dir, "ops.yaml")
+		if err := os.WriteFile(p, []byte(spec), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		return runKong(t, &SheetsTxCmd{}, []string{"s1", "--file", p}, ctx, flags)
+	}
+
+	err = run(`
+ops:
+  - {op: update, range: "Data!B2:C3", values: [[1, 2], [3]]}
+  - {op: append, range: "Log!A:C", values: [[done, true]]}
+  - {op: clear, range: "Data!D1:D10"}
+`)
+	if err != nil {
+		t.Fatalf("tx: %v", err)
+	}
+	if len(batches) != 1 || len(batches[0].Requests) != 3 {
+		t.Fatalf("expected one batchUpdate with 3 requests, got %+v", batches)
+	}
+	upd := batches[0].Requests[0].UpdateCells
+	if upd == nil || upd.Range.StartRowIndex != 1 || upd.Range.EndRowIndex != 3 || upd.Range.StartColumnIndex != 1 || upd.Range.EndColumnIndex != 3 {
+		t.Fatalf("unexpected update range: %+v", upd)
+	}
+	if app := batches[0].Requests[1].AppendCells; app == nil || app.SheetId != 5 || len(app.Rows) != 1 {
+		t.Fatalf("unexpected append: %+v", app)
+	}
+	if clr := batches[0].Requests[2].UpdateCells; clr == nil || len(clr.Rows) != 0 || clr.Fields != "userEnteredValue" {
+		t.Fatalf("unexpected clear: %+v", clr)
+	}
+
+	for spec, want := range map[string]string{
+		"ops:\n  - {op: update, range: 'Data!A1', values: [[ok]]}\n  - {op: update, range: 'Missing!A1', values: [[x]]}": `unknown sheet "Missing"`,
+		"ops:\n  - {op: update, range: 'Data!A1:B1', values: [[a, b, c]]}":                                               "do not fit",
+		"ops:\n  - {op: update, range: 'Data!A10', values: [[a], [b]]}":                                                  "sheet has 10 rows",
+		"ops:\n  - {op: append, range: 'Log!B:C', values: [[a]]}":                                                        "must start at column A",
+	} {
+		batches = nil
+		if err := run(spec); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q error, got %v", want, err)
+		}
+		if len(batches) != 0 {
+			t.Fatalf("expected no batchUpdate after a failed validation, got %d", len(batches))
+		}
+	}
+}
@@ -8,8 +8,8 @@ import (
 
 	"google.golang.org/api/sheets/v4"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type SheetsUpdateNoteCmd struct {
@@ -50,8 +50,7 @@ func (c *SheetsUpdateNoteCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return usage("provide --note or --note-file")
 	}
 
-	parsed, err := parseSheetRange(rangeSpec, "note")
-	if err != nil {
+	if err := validateSheetRangeSpec(rangeSpec, "note"); err != nil {
 		return err
 	}
 
@@ -72,6 +71,14 @@ func (c *SheetsUpdateNoteCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	rangeSpec, err = resolveSheetsHeaderRangeLive(ctx, svc, spreadsheetID, rangeSpec)
+	if err != nil {
+		return err
+	}
+	parsed, err := parseSheetRange(rangeSpec, "note")
+	if err != nil {
+		return err
+	}
 
 	sheetIDs, err := fetchSheetIDMap(ctx, svc, spreadsheetID)
 	if err != nil {
@@ -14,6 +14,10 @@ func copyDataValidation(ctx context.Context, svc *sheets.Service, spreadsheetID,
 		return err
 	}
 
+	sourceA1, err = resolveSheetsHeaderRangeLive(ctx, svc, spreadsheetID, sourceA1)
+	if err != nil {
+		return err
+	}
 	sourceGrid, err := resolveGridRangeWithCatalog(sourceA1, catalog, "copy-validation-from")
 	if err != nil {
 		return err
@@ -10,8 +10,8 @@ import (
 	"google.golang.org/api/drive/v3"
 
 	"github.com/steipete/gogcli/internal/googleapi"
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 // Debug flag for slides creation
@@ -35,18 +35,32 @@ type SlidesCmd struct {
 }
 
 type SlidesExportCmd struct {
-	PresentationID string         `arg:"" name:"presentationId" help:"Presentation ID"`
-	Output         OutputPathFlag `embed:""`
-	Format         string         `name:"format" help:"Export format: pdf|pptx" default:"pptx"`
+	PresentationID string                 `arg:"" name:"presentationId" help:"Presentation ID"`
+	Output         OutputPathFlag         `embed:""`
+	Name           ExportNameTemplateFlag `embed:""`
+	Format         string                 `name:"format" help:"Export format: pdf|pptx" default:"pptx"`
+	Slides         string                 `name:"slides" help:"Only export these slides, 1-based (e.g. 1,3-5); exports a temporary trimmed copy"`
 }
 
 func (c *SlidesExportCmd) Run(ctx context.Context, flags *RootFlags) error {
-	return exportViaDrive(ctx, flags, exportViaDriveOptions{
+	opts := exportViaDriveOptions{
 		ArgName:       "presentationId",
 		ExpectedMime:  "application/vnd.google-apps.presentation",
 		KindLabel:     "Google Slides presentation",
 		DefaultFormat: "pptx",
-	}, c.PresentationID, c.Output.Path, c.Format)
+		NameTemplate:  c.Name.NameTemplate,
+	}
+	if spec := strings.TrimSpace(c.Slides); spec != "" {
+		selection, err := parseSlideSelection(spec)
+		if err != nil {
+			return err
+		}
+		opts.Op = "slides.export"
+		opts.NameSuffix = "_slides" + strings.ReplaceAll(spec, " ", "")
+		opts.DryRunExtra = map[string]any{"slides": selection}
+		opts.Prepare = slidesSelectionExport(selection)
+	}
+	return exportViaDrive(ctx, flags, opts, c.PresentationID, c.Output.Path, c.Format)
 }
 
 type SlidesInfoCmd struct {
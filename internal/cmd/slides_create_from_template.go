@@ -11,8 +11,8 @@ import (
 	"google.golang.org/api/drive/v3"
 	"google.golang.org/api/slides/v1"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 type SlidesCreateFromTemplateCmd struct {
@@ -151,11 +151,17 @@ func (c *SlidesCreateFromTemplateCmd) Run(ctx context.Context, flags *RootFlags)
 
 // parseReplacements combines replacements from --replace flags and --replacements file
 func (c *SlidesCreateFromTemplateCmd) parseReplacements() (map[string]string, error) {
+	return parseTemplateReplacements(c.Replacements, c.Replace)
+}
+
+// parseTemplateReplacements loads key/value pairs from an optional JSON file
+// and then applies key=value pairs on top.
+func parseTemplateReplacements(path string, pairs []string) (map[string]string, error) {
 	result := make(map[string]string)
 
 	// Load from JSON file first
-	if c.Replacements != "" {
-		data, err := os.ReadFile(c.Replacements)
+	if path != "" {
+		data, err := os.ReadFile(path)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read replacements file: %w", err)
 		}
@@ -193,7 +199,7 @@ func (c *SlidesCreateFromTemplateCmd) parseReplacements() (map[string]string, er
 	}
 
 	// Process --replace flags (these override file values)
-	for _, replacement := range c.Replace {
+	for _, replacement := range pairs {
 		parts := strings.SplitN(replacement, "=", 2)
 		if len(parts) != 2 {
 			return nil, fmt.Errorf("invalid replacement format %q (expected key=value)", replacement)
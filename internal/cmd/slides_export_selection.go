@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/slides/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// parseSlideSelection parses a 1-based slide list such as "1,3-5" into
+// sorted, de-duplicated slide numbers.
+func parseSlideSelection(spec string) ([]int, error) {
+	seen := map[int]bool{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(part, "-")
+		first, err := strconv.Atoi(strings.TrimSpace(lo))
+		if err != nil || first < 1 {
+			return nil, usagef("invalid --slides entry %q (want e.g. 1,3-5)", part)
+		}
+		last := first
+		if isRange {
+			last, err = strconv.Atoi(strings.TrimSpace(hi))
+			if err != nil || last < first {
+				return nil, usagef("invalid --slides range %q (want e.g. 3-5)", part)
+			}
+		}
+		for n := first; n <= last; n++ {
+			seen[n] = true
+		}
+	}
+	if len(seen) == 0 {
+		return nil, usage("empty --slides")
+	}
+	out := make([]int, 0, len(seen))
+	for n := range seen {
+		out = append(out, n)
+	}
+	sort.Ints(out)
+	return out, nil
+}
+
+// slidesSelectionExport copies the deck, deletes every slide not in
+// selection from the copy, and hands the copy to exportViaDrive. The copy
+// is deleted again once the export is done.
+func slidesSelectionExport(selection []int) func(context.Context, string, *drive.Service, *drive.File) (*drive.File, func(), error) {
+	return func(ctx context.Context, account string, svc *drive.Service, meta *drive.File) (*drive.File, func(), error) {
+		u := ui.FromContext(ctx)
+		copied, err := svc.Files.Copy(meta.Id, &drive.File{Name: meta.Name + " (gog export)"}).
+			SupportsAllDrives(true).
+			Fields("id, name, mimeType").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, nil, fmt.Errorf("copy presentation: %w", err)
+		}
+		cleanup := func() {
+			if delErr := svc.Files.Delete(copied.Id).SupportsAllDrives(true).Context(ctx).Do(); delErr != nil {
+				u.Err().Printf("warning: failed to delete temporary copy %s: %v", copied.Id, delErr)
+			}
+		}
+
+		if err := trimSlidesToSelection(ctx, account, copied.Id, selection); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		exportMeta := *copied
+		exportMeta.MimeType = meta.MimeType
+		return &exportMeta, cleanup, nil
+	}
+}
+
+func trimSlidesToSelection(ctx context.Context, account, presentationID string, selection []int) error {
+	slidesSvc, err := newSlidesService(ctx, account)
+	if err != nil {
+		return err
+	}
+	pres, err := slidesSvc.Presentations.Get(presentationID).Fields("slides(objectId)").Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	if last := selection[len(selection)-1]; last > len(pres.Slides) {
+		return usagef("slide %d out of range (presentation has %d slides)", last, len(pres.Slides))
+	}
+
+	keep := make(map[int]bool, len(selection))
+	for _, n := range selection {
+		keep[n] = true
+	}
+	var requests []*slides.Request
+	for i, slide := range pres.Slides {
+		if slide == nil || keep[i+1] {
+			continue
+		}
+		requests = append(requests, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: slide.ObjectId}})
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+	_, err = slidesSvc.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{Requests: requests}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("delete unselected slides: %w", err)
+	}
+	return nil
+}
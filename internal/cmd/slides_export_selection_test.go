@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+	"google.golang.org/api/slides/v1"
+
+	"github.com/steipete/gogcli/pkg/outfmt"
+)
+
+func TestParseSlideSelection(t *testing.T) {
+	got, err := parseSlideSelection("4, 1,3-5")
+	if err != nil || !reflect.DeepEqual(got, []int{1, 3, 4, 5}) {
+		t.Fatalf("unexpected selection: %v %v", got, err)
+	}
+	for _, bad := range []string{"0", "5-3", "a", ","} {
+		if _, err := parseSlideSelection(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestSlidesExportCmd_SelectedSlides(t *testing.T) {
+	origDrive, origSlides := newDriveService, newSlidesService
+	t.Cleanup(func() { newDriveService, newSlidesService = origDrive, origSlides })
+
+	var deleted []string
+	var exportedFrom string
+	var copyDeleted bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/files/p1" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "p1", "name": "Deck", "mimeType": "application/vnd.google-apps.presentation"})
+		case r.URL.Path == "/files/p1/copy" && r.Method == http.MethodPost:
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "tmp1", "name": "Deck (gog export)", "mimeType": "application/vnd.google-apps.presentation"})
+		case strings.HasSuffix(r.URL.Path, "/presentations/tmp1") && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{"slides": []any{
+				map[string]any{"objectId": "s1"}, map[string]any{"objectId": "s2"},
+				map[string]any{"objectId": "s3"}, map[string]any{"objectId": "s4"},
+			}})
+		case strings.HasSuffix(r.URL.Path, "/presentations/tmp1:batchUpdate"):
+			var req slides.BatchUpdatePresentationRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			for _, rr := range req.Requests {
+				deleted = append(deleted, rr.DeleteObject.ObjectId)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"presentationId": "tmp1"})
+		case strings.HasSuffix(r.URL.Path, "/export"):
+			exportedFrom = r.URL.Path
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte("%PDF-excerpt"))
+		case r.URL.Path == "/files/tmp1" && r.Method == http.MethodDelete:
+			copyDeleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	opts := []option.ClientOption{
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL + "/"),
+	}
+	driveSvc, err := drive.NewService(context.Background(), opts...)
+	if err != nil {
+		t.Fatalf("drive.NewService: %v", err)
+	}
+	slidesSvc, err := slides.NewService(context.Background(), opts...)
+	if err != nil {
+		t.Fatalf("slides.NewService: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return driveSvc, nil }
+	newSlidesService = func(context.Context, string) (*slides.Service, error) { return slidesSvc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx := outfmt.WithMode(newDocsCmdContext(t), outfmt.Mode{JSON: true})
+	outDir := t.TempDir()
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &SlidesExportCmd{}, []string{"p1", "--format", "pdf", "--slides", "1,3", "--out", outDir}, ctx, flags); err != nil {
+			t.Fatalf("export: %v", err)
+		}
+	})
+	if !reflect.DeepEqual(deleted, []string{"s2", "s4"}) || exportedFrom != "/files/tmp1/export" || !copyDeleted {
+		t.Fatalf("unexpected calls: deleted=%v export=%q copyDeleted=%v", deleted, exportedFrom, copyDeleted)
+	}
+	var parsed struct {
+		Path string `json:"path"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if filepath.Base(parsed.Path) != "p1_Deck_slides1,3.pdf" {
+		t.Fatalf("unexpected path: %q", parsed.Path)
+	}
+	if data, err := os.ReadFile(parsed.Path); err != nil || string(data) != "%PDF-excerpt" {
+		t.Fatalf("unexpected file contents: %q %v", data, err)
+	}
+
+	copyDeleted = false
+	err = runKong(t, &SlidesExportCmd{}, []string{"p1", "--format", "pdf", "--slides", "9", "--out", outDir}, ctx, flags)
+	if err == nil || !strings.Contains(err.Error(), "out of range") || !copyDeleted {
+		t.Fatalf("expected out-of-range error with cleanup, got %v (copyDeleted=%v)", err, copyDeleted)
+	}
+}
@@ -10,8 +10,8 @@ import (
 	"github.com/alecthomas/kong"
 	"google.golang.org/api/tasks/v1"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 const (
@@ -23,6 +23,9 @@ func TestMain(m *testing.M) {
 	_ = os.MkdirAll(xdg, 0o755)
 	_ = os.Setenv("HOME", home)
 	_ = os.Setenv("XDG_CONFIG_HOME", xdg)
+	// Tests share one config dir; keep the name→ID cache from leaking
+	// label/calendar maps between tests that reuse the same fake account.
+	_ = os.Setenv("GOG_ID_CACHE_TTL", "off")
 
 	code := m.Run()
 
@@ -6,8 +6,8 @@ import (
 	"os"
 	"testing"
 
-	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/pkg/outfmt"
 )
 
 func TestTimeNowCmd_JSON(t *testing.T) {
@@ -18,6 +18,14 @@ type File struct {
 	AccountClients  map[string]string `json:"account_clients,omitempty"`
 	ClientDomains   map[string]string `json:"client_domains,omitempty"`
 	CalendarAliases map[string]string `json:"calendar_aliases,omitempty"`
+	GmailLabelScope string            `json:"gmail_label_scope,omitempty"`
+
+	BulkConfirmThreshold int `json:"bulk_confirm_threshold,omitempty"`
+	BulkMaxPerSecond     int `json:"bulk_max_per_second,omitempty"`
+
+	HTTPMaxIdleConns int    `json:"http_max_idle_conns,omitempty"`
+	HTTPIdleTimeout  string `json:"http_idle_timeout,omitempty"`
+	HTTPPingInterval string `json:"http_ping_interval,omitempty"`
 }
 
 var errConfigLockTimeout = errors.New("acquire config lock timeout")
@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -10,10 +11,33 @@ import (
 type Key string
 
 const (
-	KeyTimezone       Key = "timezone"
-	KeyKeyringBackend Key = "keyring_backend"
+	KeyTimezone        Key = "timezone"
+	KeyKeyringBackend  Key = "keyring_backend"
+	KeyGmailLabelScope Key = "gmail_label_scope"
+
+	KeyBulkConfirmThreshold Key = "bulk_confirm_threshold"
+	KeyBulkMaxPerSecond     Key = "bulk_max_per_second"
+
+	KeyHTTPMaxIdleConns Key = "http_max_idle_conns"
+	KeyHTTPIdleTimeout  Key = "http_idle_timeout"
+	KeyHTTPPingInterval Key = "http_ping_interval"
+)
+
+// Values for gmail_label_scope: whether label edits on a message also apply
+// to the rest of its thread.
+const (
+	GmailLabelScopeMessage = "message"
+	GmailLabelScopeThread  = "thread"
 )
 
+// DefaultBulkConfirmThreshold is the item count at which bulk trash and
+// delete commands ask for the count to be typed back instead of y/N.
+const DefaultBulkConfirmThreshold = 50
+
+// DefaultBulkMaxPerSecond caps the API calls per second a guarded bulk
+// trash/delete run makes, so large runs stay under per-user rate limits.
+const DefaultBulkMaxPerSecond = 10
+
 type KeySpec struct {
 	Key       Key
 	Get       func(File) string
@@ -25,6 +49,12 @@ type KeySpec struct {
 var keyOrder = []Key{
 	KeyTimezone,
 	KeyKeyringBackend,
+	KeyGmailLabelScope,
+	KeyBulkConfirmThreshold,
+	KeyBulkMaxPerSecond,
+	KeyHTTPMaxIdleConns,
+	KeyHTTPIdleTimeout,
+	KeyHTTPPingInterval,
 }
 
 var keySpecs = map[Key]KeySpec{
@@ -65,14 +95,162 @@ var keySpecs = map[Key]KeySpec{
 			return "(not set, using auto)"
 		},
 	},
+	KeyGmailLabelScope: {
+		Key: KeyGmailLabelScope,
+		Get: func(cfg File) string {
+			return cfg.GmailLabelScope
+		},
+		Set: func(cfg *File, value string) error {
+			value = strings.ToLower(strings.TrimSpace(value))
+			if value != GmailLabelScopeMessage && value != GmailLabelScopeThread {
+				return fmt.Errorf("%w: %s must be %s or %s, got %q", errInvalidConfigValue, KeyGmailLabelScope, GmailLabelScopeMessage, GmailLabelScopeThread, value)
+			}
+			cfg.GmailLabelScope = value
+
+			return nil
+		},
+		Unset: func(cfg *File) {
+			cfg.GmailLabelScope = ""
+		},
+		EmptyHint: func() string {
+			return "(not set, using message)"
+		},
+	},
+	KeyBulkConfirmThreshold: {
+		Key: KeyBulkConfirmThreshold,
+		Get: func(cfg File) string {
+			if cfg.BulkConfirmThreshold <= 0 {
+				return ""
+			}
+
+			return strconv.Itoa(cfg.BulkConfirmThreshold)
+		},
+		Set: func(cfg *File, value string) error {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || n <= 0 {
+				return fmt.Errorf("%w: %s must be a positive integer, got %q", errInvalidConfigValue, KeyBulkConfirmThreshold, value)
+			}
+			cfg.BulkConfirmThreshold = n
+
+			return nil
+		},
+		Unset: func(cfg *File) {
+			cfg.BulkConfirmThreshold = 0
+		},
+		EmptyHint: func() string {
+			return fmt.Sprintf("(not set, using %d)", DefaultBulkConfirmThreshold)
+		},
+	},
+	KeyBulkMaxPerSecond: {
+		Key: KeyBulkMaxPerSecond,
+		Get: func(cfg File) string {
+			if cfg.BulkMaxPerSecond <= 0 {
+				return ""
+			}
+
+			return strconv.Itoa(cfg.BulkMaxPerSecond)
+		},
+		Set: func(cfg *File, value string) error {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || n <= 0 {
+				return fmt.Errorf("%w: %s must be a positive integer, got %q", errInvalidConfigValue, KeyBulkMaxPerSecond, value)
+			}
+			cfg.BulkMaxPerSecond = n
+
+			return nil
+		},
+		Unset: func(cfg *File) {
+			cfg.BulkMaxPerSecond = 0
+		},
+		EmptyHint: func() string {
+			return fmt.Sprintf("(not set, using %d)", DefaultBulkMaxPerSecond)
+		},
+	},
+	KeyHTTPMaxIdleConns: {
+		Key: KeyHTTPMaxIdleConns,
+		Get: func(cfg File) string {
+			if cfg.HTTPMaxIdleConns <= 0 {
+				return ""
+			}
+
+			return strconv.Itoa(cfg.HTTPMaxIdleConns)
+		},
+		Set: func(cfg *File, value string) error {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || n <= 0 {
+				return fmt.Errorf("%w: %s must be a positive integer, got %q", errInvalidConfigValue, KeyHTTPMaxIdleConns, value)
+			}
+			cfg.HTTPMaxIdleConns = n
+
+			return nil
+		},
+		Unset: func(cfg *File) {
+			cfg.HTTPMaxIdleConns = 0
+		},
+		EmptyHint: func() string {
+			return "(not set, using default)"
+		},
+	},
+	KeyHTTPIdleTimeout: {
+		Key: KeyHTTPIdleTimeout,
+		Get: func(cfg File) string {
+			return cfg.HTTPIdleTimeout
+		},
+		Set: func(cfg *File, value string) error {
+			normalized, err := normalizePositiveDuration(KeyHTTPIdleTimeout, value)
+			if err != nil {
+				return err
+			}
+			cfg.HTTPIdleTimeout = normalized
+
+			return nil
+		},
+		Unset: func(cfg *File) {
+			cfg.HTTPIdleTimeout = ""
+		},
+		EmptyHint: func() string {
+			return "(not set, using default)"
+		},
+	},
+	KeyHTTPPingInterval: {
+		Key: KeyHTTPPingInterval,
+		Get: func(cfg File) string {
+			return cfg.HTTPPingInterval
+		},
+		Set: func(cfg *File, value string) error {
+			normalized, err := normalizePositiveDuration(KeyHTTPPingInterval, value)
+			if err != nil {
+				return err
+			}
+			cfg.HTTPPingInterval = normalized
+
+			return nil
+		},
+		Unset: func(cfg *File) {
+			cfg.HTTPPingInterval = ""
+		},
+		EmptyHint: func() string {
+			return "(not set, HTTP/2 health pings disabled)"
+		},
+	},
 }
 
 var (
 	errUnknownConfigKey     = errors.New("unknown config key")
 	errConfigKeyCannotSet   = errors.New("config key cannot be set")
 	errConfigKeyCannotUnset = errors.New("config key cannot be unset")
+	errInvalidConfigValue   = errors.New("invalid config value")
 )
 
+func normalizePositiveDuration(key Key, value string) (string, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || d <= 0 {
+		return "", fmt.Errorf("%w: %s must be a positive duration like 90s or 2m, got %q", errInvalidConfigValue, key, value)
+	}
+
+	return d.String(), nil
+}
+
 func (k Key) String() string {
 	return string(k)
 }
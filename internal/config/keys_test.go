@@ -0,0 +1,104 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHTTPTuningKeys(t *testing.T) {
+	var cfg File
+
+	if err := SetValue(&cfg, KeyHTTPMaxIdleConns, "32"); err != nil {
+		t.Fatalf("set max idle conns: %v", err)
+	}
+
+	if err := SetValue(&cfg, KeyHTTPIdleTimeout, "90s"); err != nil {
+		t.Fatalf("set idle timeout: %v", err)
+	}
+
+	if err := SetValue(&cfg, KeyHTTPPingInterval, "30s"); err != nil {
+		t.Fatalf("set ping interval: %v", err)
+	}
+
+	if got := GetValue(cfg, KeyHTTPMaxIdleConns); got != "32" {
+		t.Fatalf("max idle conns = %q", got)
+	}
+
+	if got := GetValue(cfg, KeyHTTPIdleTimeout); got != "1m30s" {
+		t.Fatalf("idle timeout = %q", got)
+	}
+
+	if got := GetValue(cfg, KeyHTTPPingInterval); got != "30s" {
+		t.Fatalf("ping interval = %q", got)
+	}
+
+	if err := UnsetValue(&cfg, KeyHTTPMaxIdleConns); err != nil {
+		t.Fatalf("unset: %v", err)
+	}
+
+	if got := GetValue(cfg, KeyHTTPMaxIdleConns); got != "" {
+		t.Fatalf("expected empty after unset, got %q", got)
+	}
+}
+
+func TestHTTPTuningKeys_Invalid(t *testing.T) {
+	var cfg File
+
+	for key, value := range map[Key]string{
+		KeyHTTPMaxIdleConns: "0",
+		KeyHTTPIdleTimeout:  "later",
+		KeyHTTPPingInterval: "-5s",
+	} {
+		if err := SetValue(&cfg, key, value); !errors.Is(err, errInvalidConfigValue) {
+			t.Fatalf("%s=%q: expected invalid value error, got %v", key, value, err)
+		}
+	}
+}
+
+func TestGmailLabelScopeKey(t *testing.T) {
+	var cfg File
+
+	if err := SetValue(&cfg, KeyGmailLabelScope, " Thread "); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	if got := GetValue(cfg, KeyGmailLabelScope); got != GmailLabelScopeThread {
+		t.Fatalf("gmail label scope = %q", got)
+	}
+
+	if err := SetValue(&cfg, KeyGmailLabelScope, "conversation"); !errors.Is(err, errInvalidConfigValue) {
+		t.Fatalf("expected invalid value error, got %v", err)
+	}
+}
+
+func TestBulkConfirmThresholdKey(t *testing.T) {
+	var cfg File
+
+	if err := SetValue(&cfg, KeyBulkConfirmThreshold, " 200 "); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	if got := GetValue(cfg, KeyBulkConfirmThreshold); got != "200" {
+		t.Fatalf("bulk confirm threshold = %q", got)
+	}
+
+	if err := SetValue(&cfg, KeyBulkConfirmThreshold, "0"); !errors.Is(err, errInvalidConfigValue) {
+		t.Fatalf("expected invalid value error, got %v", err)
+	}
+}
+
+func TestBulkMaxPerSecondKey(t *testing.T) {
+	var cfg File
+
+	if err := SetValue(&cfg, KeyBulkMaxPerSecond, "5"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	if got := GetValue(cfg, KeyBulkMaxPerSecond); got != "5" {
+		t.Fatalf("bulk max per second = %q", got)
+	}
+
+	if err := SetValue(&cfg, KeyBulkMaxPerSecond, "-1"); !errors.Is(err, errInvalidConfigValue) {
+		t.Fatalf("expected invalid value error, got %v", err)
+	}
+}
@@ -139,6 +139,28 @@ func GmailWatchDir() (string, error) {
 	return filepath.Join(dir, "state", "gmail-watch"), nil
 }
 
+// BulkJournalPath is the append-only log of bulk trash and delete runs that
+// `gog journal` lists and undoes.
+func BulkJournalPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "state", "bulk-journal.jsonl"), nil
+}
+
+// IDCacheDir holds the lazily refreshed name→ID lookup cache (labels,
+// calendars, sheet tabs, ...). Everything in it is safe to delete.
+func IDCacheDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "cache", "ids"), nil
+}
+
 func KeepServiceAccountPath(email string) (string, error) {
 	dir, err := Dir()
 	if err != nil {
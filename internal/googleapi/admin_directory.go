@@ -20,3 +20,20 @@ func NewAdminDirectory(ctx context.Context, email string) (*admin.Service, error
 		return svc, nil
 	}
 }
+
+// ScopeAdminDirectoryResourceCalendarRO is the only scope room and building
+// lookups use.
+const ScopeAdminDirectoryResourceCalendarRO = "https://www.googleapis.com/auth/admin.directory.resource.calendar.readonly"
+
+// NewAdminDirectoryResources creates an Admin SDK Directory service for
+// reading calendar resources (rooms, buildings). It asks only for the
+// read-only resource scope, so existing user/group delegation is unaffected.
+func NewAdminDirectoryResources(ctx context.Context, email string) (*admin.Service, error) {
+	if opts, err := optionsForAccountScopes(ctx, string(googleauth.ServiceAdmin), email, []string{ScopeAdminDirectoryResourceCalendarRO}); err != nil {
+		return nil, fmt.Errorf("admin directory resources options: %w", err)
+	} else if svc, err := admin.NewService(ctx, opts...); err != nil {
+		return nil, fmt.Errorf("create admin directory resources service: %w", err)
+	} else {
+		return svc, nil
+	}
+}
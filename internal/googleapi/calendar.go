@@ -3,8 +3,10 @@ package googleapi
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/option"
 
 	"github.com/steipete/gogcli/internal/googleauth"
 )
@@ -18,3 +20,29 @@ func NewCalendar(ctx context.Context, email string) (*calendar.Service, error) {
 		return svc, nil
 	}
 }
+
+// NewCalendarWithServiceAccount builds a Calendar client that acts as
+// impersonateEmail through the service account's domain-wide delegation.
+func NewCalendarWithServiceAccount(ctx context.Context, serviceAccountPath, impersonateEmail string) (*calendar.Service, error) {
+	data, err := os.ReadFile(serviceAccountPath) //nolint:gosec // stored config file
+	if err != nil {
+		return nil, fmt.Errorf("read service account file: %w", err)
+	}
+
+	scopes, err := googleauth.Scopes(googleauth.ServiceCalendar)
+	if err != nil {
+		return nil, fmt.Errorf("calendar scopes: %w", err)
+	}
+
+	ts, err := newServiceAccountTokenSource(ctx, data, impersonateEmail, scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := calendar.NewService(ctx, option.WithTokenSource(ts))
+	if err != nil {
+		return nil, fmt.Errorf("create calendar service: %w", err)
+	}
+
+	return svc, nil
+}
@@ -7,12 +7,14 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 
+	"github.com/steipete/gogcli/internal/config"
 	"github.com/steipete/gogcli/internal/googleauth"
 )
 
@@ -28,9 +30,39 @@ const (
 	// tokenExchangeTimeout is applied to the short-lived HTTP client used
 	// for OAuth2 token refresh exchanges, which should always be fast.
 	tokenExchangeTimeout = 30 * time.Second
+
+	// defaultMaxIdleConnsPerHost raises net/http's default of 2 so that bulk
+	// operations fanning out against *.googleapis.com keep their warm
+	// connections instead of repeating TLS handshakes.
+	defaultMaxIdleConnsPerHost = 16
+)
+
+var (
+	newADCTokenSource   = google.DefaultTokenSource
+	readTransportConfig = config.ReadConfig
+
+	sharedTransportOnce sync.Once
+	sharedTransport     *http.Transport
 )
 
-var newADCTokenSource = google.DefaultTokenSource
+type transportWrapperKey struct{}
+
+// WithTransportWrapper makes every API client built from ctx route its
+// requests through wrap, outside the retry layer so each final response is
+// seen once.
+func WithTransportWrapper(ctx context.Context, wrap func(http.RoundTripper) http.RoundTripper) context.Context {
+	if wrap == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, transportWrapperKey{}, wrap)
+}
+
+func wrapTransport(ctx context.Context, rt http.RoundTripper) http.RoundTripper {
+	if wrap, ok := ctx.Value(transportWrapperKey{}).(func(http.RoundTripper) http.RoundTripper); ok {
+		return wrap(rt)
+	}
+	return rt
+}
 
 func optionsForAccount(ctx context.Context, service googleauth.Service, email string) ([]option.ClientOption, error) {
 	scopes, err := googleauth.Scopes(service)
@@ -53,6 +85,19 @@ func IsADCMode() bool {
 func optionsForAccountScopes(ctx context.Context, serviceLabel string, email string, scopes []string) ([]option.ClientOption, error) {
 	slog.Debug("creating client options with custom scopes", "serviceLabel", serviceLabel, "email", email)
 
+	c, err := httpClientForAccountScopes(ctx, serviceLabel, email, scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("client options with custom scopes created successfully", "serviceLabel", serviceLabel, "email", email)
+
+	return []option.ClientOption{option.WithHTTPClient(c)}, nil
+}
+
+// httpClientForAccountScopes returns the authenticated, retrying HTTP client
+// that backs every generated service for the given account and scopes.
+func httpClientForAccountScopes(ctx context.Context, serviceLabel string, email string, scopes []string) (*http.Client, error) {
 	var ts oauth2.TokenSource
 
 	if IsADCMode() {
@@ -73,21 +118,17 @@ func optionsForAccountScopes(ctx context.Context, serviceLabel string, email str
 		}
 	}
 
-	baseTransport := newBaseTransport()
+	baseTransport := sharedBaseTransport()
 	retryTransport := NewRetryTransport(&oauth2.Transport{
 		Source: ts,
 		Base:   baseTransport,
 	})
-	c := &http.Client{
-		Transport: retryTransport,
+	return &http.Client{
+		Transport: wrapTransport(ctx, retryTransport),
 		// No Timeout set: large file downloads (Drive videos, etc.) must not
 		// be cut short. Server responsiveness is guarded by the transport's
 		// ResponseHeaderTimeout instead.
-	}
-
-	slog.Debug("client options with custom scopes created successfully", "serviceLabel", serviceLabel, "email", email)
-
-	return []option.ClientOption{option.WithHTTPClient(c)}, nil
+	}, nil
 }
 
 func newBaseTransport() *http.Transport {
@@ -105,6 +146,7 @@ func newBaseTransport() *http.Transport {
 	// Clone() deep-copies TLSClientConfig, so no additional clone needed.
 	transport := defaultTransport.Clone()
 	transport.ResponseHeaderTimeout = responseHeaderTimeout
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
 
 	if transport.TLSClientConfig == nil {
 		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
@@ -117,3 +159,61 @@ func newBaseTransport() *http.Transport {
 
 	return transport
 }
+
+// sharedBaseTransport returns the process-wide base transport. Every service
+// client created during one invocation (or one long-running watch daemon)
+// shares it, so idle connections and HTTP/2 sessions are pooled across
+// Gmail/Drive/Docs/... calls instead of being re-established per service.
+func sharedBaseTransport() *http.Transport {
+	sharedTransportOnce.Do(func() {
+		transport := newBaseTransport()
+
+		cfg, err := readTransportConfig()
+		if err != nil {
+			slog.Debug("transport tuning: read config failed; using defaults", "err", err)
+		} else {
+			applyTransportTuning(transport, cfg)
+		}
+
+		sharedTransport = transport
+	})
+
+	return sharedTransport
+}
+
+// applyTransportTuning applies the http_* config keys to transport. Invalid
+// values are ignored (config set validates them up front).
+func applyTransportTuning(transport *http.Transport, cfg config.File) {
+	if cfg.HTTPMaxIdleConns > 0 {
+		transport.MaxIdleConns = cfg.HTTPMaxIdleConns
+		transport.MaxIdleConnsPerHost = cfg.HTTPMaxIdleConns
+	}
+
+	if d, ok := parseTuningDuration(cfg.HTTPIdleTimeout); ok {
+		transport.IdleConnTimeout = d
+	}
+
+	if d, ok := parseTuningDuration(cfg.HTTPPingInterval); ok {
+		if transport.HTTP2 == nil {
+			transport.HTTP2 = &http.HTTP2Config{}
+		}
+		// Send a PING after d without frames, and drop the connection if the
+		// PING is not answered within the same window.
+		transport.HTTP2.SendPingTimeout = d
+		transport.HTTP2.PingTimeout = d
+	}
+}
+
+func parseTuningDuration(raw string) (time.Duration, bool) {
+	if raw == "" {
+		return 0, false
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		slog.Debug("transport tuning: ignoring invalid duration", "value", raw)
+		return 0, false
+	}
+
+	return d, true
+}
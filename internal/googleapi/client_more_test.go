@@ -549,3 +549,55 @@ func TestOptionsForAccountScopes_NoClientTimeout(t *testing.T) {
 		t.Fatalf("expected ResponseHeaderTimeout to be set on transport")
 	}
 }
+
+func TestNewBaseTransport_RaisesIdleConnsPerHost(t *testing.T) {
+	transport := newBaseTransport()
+	if transport.MaxIdleConnsPerHost != defaultMaxIdleConnsPerHost {
+		t.Fatalf("expected MaxIdleConnsPerHost=%d, got %d", defaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	}
+}
+
+func TestApplyTransportTuning(t *testing.T) {
+	transport := newBaseTransport()
+	applyTransportTuning(transport, config.File{
+		HTTPMaxIdleConns: 64,
+		HTTPIdleTimeout:  "2m",
+		HTTPPingInterval: "15s",
+	})
+
+	if transport.MaxIdleConns != 64 || transport.MaxIdleConnsPerHost != 64 {
+		t.Fatalf("expected idle conns 64/64, got %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+
+	if transport.IdleConnTimeout != 2*time.Minute {
+		t.Fatalf("expected idle timeout 2m, got %v", transport.IdleConnTimeout)
+	}
+
+	if transport.HTTP2 == nil || transport.HTTP2.SendPingTimeout != 15*time.Second || transport.HTTP2.PingTimeout != 15*time.Second {
+		t.Fatalf("expected HTTP/2 ping tuning, got %#v", transport.HTTP2)
+	}
+}
+
+func TestApplyTransportTuning_IgnoresInvalid(t *testing.T) {
+	transport := newBaseTransport()
+	wantIdle := transport.IdleConnTimeout
+
+	applyTransportTuning(transport, config.File{HTTPIdleTimeout: "soon", HTTPPingInterval: "-1s"})
+
+	if transport.IdleConnTimeout != wantIdle {
+		t.Fatalf("expected idle timeout unchanged, got %v", transport.IdleConnTimeout)
+	}
+
+	if transport.HTTP2 != nil && transport.HTTP2.SendPingTimeout != 0 {
+		t.Fatalf("expected no HTTP/2 ping tuning, got %v", transport.HTTP2.SendPingTimeout)
+	}
+}
+
+func TestSharedBaseTransport_Reused(t *testing.T) {
+	first := sharedBaseTransport()
+	second := sharedBaseTransport()
+
+	if first == nil || first != second {
+		t.Fatalf("expected one shared transport, got %p and %p", first, second)
+	}
+}
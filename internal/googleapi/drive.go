@@ -3,8 +3,10 @@ package googleapi
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/drivelabels/v2"
 
 	"github.com/steipete/gogcli/internal/googleauth"
 )
@@ -18,3 +20,35 @@ func NewDrive(ctx context.Context, email string) (*drive.Service, error) {
 		return svc, nil
 	}
 }
+
+// ScopeDriveLabelsRO is requested only by the Drive Labels client, so regular
+// Drive clients (and domain-wide delegation grants) do not need it.
+const ScopeDriveLabelsRO = "https://www.googleapis.com/auth/drive.labels.readonly"
+
+// NewDriveLabels creates a Drive Labels service for reading the label
+// taxonomy; applying labels to files goes through the Drive API.
+func NewDriveLabels(ctx context.Context, email string) (*drivelabels.Service, error) {
+	if opts, err := optionsForAccountScopes(ctx, string(googleauth.ServiceDrive), email, []string{ScopeDriveLabelsRO}); err != nil {
+		return nil, fmt.Errorf("drive labels options: %w", err)
+	} else if svc, err := drivelabels.NewService(ctx, opts...); err != nil {
+		return nil, fmt.Errorf("create drive labels service: %w", err)
+	} else {
+		return svc, nil
+	}
+}
+
+// NewDriveHTTPClient returns an authenticated client with Drive scopes for
+// endpoints the generated service cannot reach, such as revision export links.
+func NewDriveHTTPClient(ctx context.Context, email string) (*http.Client, error) {
+	scopes, err := googleauth.Scopes(googleauth.ServiceDrive)
+	if err != nil {
+		return nil, fmt.Errorf("resolve scopes: %w", err)
+	}
+
+	c, err := httpClientForAccountScopes(ctx, string(googleauth.ServiceDrive), email, scopes)
+	if err != nil {
+		return nil, fmt.Errorf("drive http client: %w", err)
+	}
+
+	return c, nil
+}
@@ -10,7 +10,8 @@ import (
 const (
 	scopeContactsWrite   = "https://www.googleapis.com/auth/contacts"
 	scopeContactsOtherRO = "https://www.googleapis.com/auth/contacts.other.readonly"
-	scopeDirectoryRO     = "https://www.googleapis.com/auth/directory.readonly"
+	// ScopeDirectoryRO is the only scope the workspace directory lookups use.
+	ScopeDirectoryRO = "https://www.googleapis.com/auth/directory.readonly"
 )
 
 func NewPeopleContacts(ctx context.Context, email string) (*people.Service, error) {
@@ -34,7 +35,7 @@ func NewPeopleOtherContacts(ctx context.Context, email string) (*people.Service,
 }
 
 func NewPeopleDirectory(ctx context.Context, email string) (*people.Service, error) {
-	if opts, err := optionsForAccountScopes(ctx, "contacts", email, []string{scopeDirectoryRO}); err != nil {
+	if opts, err := optionsForAccountScopes(ctx, "contacts", email, []string{ScopeDirectoryRO}); err != nil {
 		return nil, fmt.Errorf("contacts options: %w", err)
 	} else if svc, err := people.NewService(ctx, opts...); err != nil {
 		return nil, fmt.Errorf("create contacts service: %w", err)
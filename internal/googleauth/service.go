@@ -130,9 +130,11 @@ var serviceInfoByService = map[Service]serviceInfo{
 		apis: []string{"Classroom API"},
 	},
 	ServiceDrive: {
+		// `drive labels` also calls the Drive Labels API, but requests its
+		// scope on its own client (see googleapi.NewDriveLabels).
 		scopes: []string{"https://www.googleapis.com/auth/drive"},
 		user:   true,
-		apis:   []string{"Drive API"},
+		apis:   []string{"Drive API", "Drive Labels API"},
 	},
 	ServiceDocs: {
 		// Docs commands are implemented via Drive APIs (export/copy/create),
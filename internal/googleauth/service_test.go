@@ -349,6 +349,19 @@ func TestScopesForManageWithOptions_DriveScopeFile(t *testing.T) {
 	}
 }
 
+func TestScopesForManageWithOptions_DriveOmitsLabelsReadonly(t *testing.T) {
+	for _, opts := range []ScopeOptions{{}, {Readonly: true}, {DriveScope: DriveScopeFile}} {
+		scopes, err := ScopesForManageWithOptions([]Service{ServiceDrive}, opts)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+
+		if containsScope(scopes, "https://www.googleapis.com/auth/drive.labels.readonly") {
+			t.Fatalf("unexpected drive.labels.readonly in %v (opts %+v)", scopes, opts)
+		}
+	}
+}
+
 func TestScopesForManageWithOptions_InvalidDriveScope(t *testing.T) {
 	if _, err := ScopesForManageWithOptions([]Service{ServiceDrive}, ScopeOptions{DriveScope: DriveScopeMode("nope")}); err == nil {
 		t.Fatalf("expected error")
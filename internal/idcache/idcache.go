@@ -0,0 +1,170 @@
+// Package idcache is a small on-disk cache of name→ID lookups (Gmail labels,
+// calendars, contact groups, sheet tabs and headers, Drive folders) so
+// commands that accept natural names do not pay for an extra list call on
+// every invocation.
+//
+// Entries are grouped per account, kind, and scope (e.g. a spreadsheet ID for
+// sheet tabs) and expire after a TTL. Callers are expected to treat a missing
+// name as a cache miss and refresh, so stale entries only matter until the
+// next refresh.
+package idcache
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+// DefaultTTL is used when GOG_ID_CACHE_TTL is unset.
+const DefaultTTL = 15 * time.Minute
+
+// Kind names one family of name→ID mappings.
+type Kind string
+
+const (
+	KindGmailLabels Kind = "gmail-labels"
+	KindCalendars   Kind = "calendars"
+	// KindContactGroups maps contact group names to contactGroups/...
+	// resource names.
+	KindContactGroups Kind = "contact-groups"
+	KindSheetTabs     Kind = "sheet-tabs"
+	KindSheetHeaders  Kind = "sheet-headers"
+	// KindDriveFolders maps folder paths ("/My Drive/Projects") to folder
+	// IDs for Drive path resolution; files themselves are never cached.
+	KindDriveFolders Kind = "drive-folders"
+)
+
+// ErrDisabled is returned by Default when caching is turned off.
+var ErrDisabled = errors.New("id cache disabled")
+
+type Cache struct {
+	Dir string
+	TTL time.Duration
+	Now func() time.Time
+}
+
+type entry struct {
+	FetchedAt time.Time         `json:"fetched_at"`
+	Names     map[string]string `json:"names"`
+}
+
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{Dir: dir, TTL: ttl, Now: time.Now}
+}
+
+// Default opens the cache under the config dir. GOG_ID_CACHE_TTL overrides
+// the TTL (Go duration); "0" or "off" disables the cache.
+func Default() (*Cache, error) {
+	ttl, err := ttlFromEnv(os.Getenv("GOG_ID_CACHE_TTL"))
+	if err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		return nil, ErrDisabled
+	}
+
+	dir, err := config.IDCacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(dir, ttl), nil
+}
+
+func ttlFromEnv(raw string) (time.Duration, error) {
+	raw = strings.TrimSpace(strings.ToLower(raw))
+	switch raw {
+	case "":
+		return DefaultTTL, nil
+	case "0", "off", "false", "no":
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid GOG_ID_CACHE_TTL %q: %w", raw, err)
+	}
+
+	return d, nil
+}
+
+// Get returns the cached mapping if present and not expired.
+func (c *Cache) Get(account string, kind Kind, scope string) (map[string]string, bool) {
+	path := c.path(account, kind, scope)
+
+	b, err := os.ReadFile(path) //nolint:gosec // path is derived inside the cache dir
+	if err != nil {
+		return nil, false
+	}
+
+	var e entry
+	if err := json.Unmarshal(b, &e); err != nil || e.Names == nil {
+		return nil, false
+	}
+
+	if c.now().Sub(e.FetchedAt) > c.TTL {
+		return nil, false
+	}
+
+	return e.Names, true
+}
+
+// Put replaces the mapping for (account, kind, scope).
+func (c *Cache) Put(account string, kind Kind, scope string, names map[string]string) error {
+	path := c.path(account, kind, scope)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("ensure id cache dir: %w", err)
+	}
+
+	b, err := json.Marshal(entry{FetchedAt: c.now(), Names: names})
+	if err != nil {
+		return fmt.Errorf("encode id cache: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("write id cache: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("commit id cache: %w", err)
+	}
+
+	return nil
+}
+
+// Invalidate drops the mapping for (account, kind, scope), if any.
+func (c *Cache) Invalidate(account string, kind Kind, scope string) error {
+	if err := os.Remove(c.path(account, kind, scope)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("invalidate id cache: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Cache) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+
+	return time.Now()
+}
+
+func (c *Cache) path(account string, kind Kind, scope string) string {
+	accountDir := base64.RawURLEncoding.EncodeToString([]byte(strings.ToLower(strings.TrimSpace(account))))
+
+	name := string(kind)
+	if scope != "" {
+		name += "-" + base64.RawURLEncoding.EncodeToString([]byte(scope))
+	}
+
+	return filepath.Join(c.Dir, accountDir, name+".json")
+}
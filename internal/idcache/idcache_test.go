@@ -0,0 +1,84 @@
+package idcache
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCache_PutGetExpire(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := New(t.TempDir(), time.Minute)
+	c.Now = func() time.Time { return now }
+
+	if _, ok := c.Get("a@b.com", KindGmailLabels, ""); ok {
+		t.Fatalf("expected miss on empty cache")
+	}
+
+	if err := c.Put("a@b.com", KindGmailLabels, "", map[string]string{"work": "Label_1"}); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	got, ok := c.Get("A@B.com", KindGmailLabels, "")
+	if !ok || got["work"] != "Label_1" {
+		t.Fatalf("expected hit, got %v %v", got, ok)
+	}
+
+	if _, ok := c.Get("other@b.com", KindGmailLabels, ""); ok {
+		t.Fatalf("expected accounts to be isolated")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.Get("a@b.com", KindGmailLabels, ""); ok {
+		t.Fatalf("expected expired entry to miss")
+	}
+}
+
+func TestCache_ScopesAndInvalidate(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+
+	if err := c.Put("a@b.com", KindSheetTabs, "sheet/1", map[string]string{"Data": "0"}); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	if _, ok := c.Get("a@b.com", KindSheetTabs, "sheet/2"); ok {
+		t.Fatalf("expected scopes to be isolated")
+	}
+
+	if err := c.Invalidate("a@b.com", KindSheetTabs, "sheet/1"); err != nil {
+		t.Fatalf("invalidate: %v", err)
+	}
+
+	if _, ok := c.Get("a@b.com", KindSheetTabs, "sheet/1"); ok {
+		t.Fatalf("expected miss after invalidate")
+	}
+
+	if err := c.Invalidate("a@b.com", KindSheetTabs, "sheet/1"); err != nil {
+		t.Fatalf("second invalidate should be a no-op: %v", err)
+	}
+}
+
+func TestDefault_TTLFromEnv(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+
+	t.Setenv("GOG_ID_CACHE_TTL", "off")
+	if _, err := Default(); !errors.Is(err, ErrDisabled) {
+		t.Fatalf("expected ErrDisabled, got %v", err)
+	}
+
+	t.Setenv("GOG_ID_CACHE_TTL", "5m")
+	c, err := Default()
+	if err != nil {
+		t.Fatalf("default: %v", err)
+	}
+	if c.TTL != 5*time.Minute {
+		t.Fatalf("expected 5m TTL, got %v", c.TTL)
+	}
+
+	t.Setenv("GOG_ID_CACHE_TTL", "soon")
+	if _, err := Default(); err == nil {
+		t.Fatalf("expected invalid TTL error")
+	}
+}
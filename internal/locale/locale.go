@@ -0,0 +1,193 @@
+// Package locale renders dates, times, and numbers for human-readable
+// output in the user's language (--lang / GOG_LANG). A nil *Locale is the
+// canonical, locale-independent style (ISO dates, 24h times, plain
+// numbers), so callers can pass whatever FromContext returns; JSON and
+// plain output never carry a Locale.
+package locale
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+const (
+	isoDate  = "2006-01-02"
+	time24h  = "15:04"
+	time12h  = "3:04 PM"
+	dmySlash = "02/01/2006"
+	dmyDot   = "02.01.2006"
+)
+
+// Locale holds the date patterns and number printer for one language tag.
+type Locale struct {
+	tag        language.Tag
+	printer    *message.Printer
+	dateLayout string
+	timeLayout string
+	weekdays   [7]string
+}
+
+// Parse accepts BCP 47 tags (de, pt-BR) and POSIX locale names
+// (de_DE.UTF-8). Empty, "C", and "POSIX" return nil: the canonical style.
+func Parse(value string) (*Locale, error) {
+	value = strings.TrimSpace(value)
+	if i := strings.IndexAny(value, ".@"); i >= 0 {
+		value = value[:i]
+	}
+	switch strings.ToUpper(value) {
+	case "", "C", "POSIX":
+		return nil, nil
+	}
+	tag, err := language.Parse(strings.ReplaceAll(value, "_", "-"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid language %q (use a tag like en-US, de, or pt-BR)", value)
+	}
+	dateLayout, timeLayout := layoutsFor(tag)
+	return &Locale{
+		tag:        tag,
+		printer:    message.NewPrinter(tag),
+		dateLayout: dateLayout,
+		timeLayout: timeLayout,
+		weekdays:   weekdaysFor(tag),
+	}, nil
+}
+
+// layoutsFor picks the common short date and time patterns for a language
+// and (possibly inferred) region.
+func layoutsFor(tag language.Tag) (date, clock string) {
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	switch base.String() {
+	case "en":
+		switch region.String() {
+		case "US", "PH":
+			return "01/02/2006", time12h
+		case "CA":
+			return isoDate, time12h
+		default:
+			return dmySlash, time24h
+		}
+	case "de", "ru", "pl", "fi", "nb", "no", "nn", "da", "cs", "sk", "tr", "uk", "ro", "hr", "sl":
+		return dmyDot, time24h
+	case "fr":
+		if region.String() == "CA" {
+			return isoDate, time24h
+		}
+		return dmySlash, time24h
+	case "es", "it", "pt", "el", "ca", "vi", "id":
+		return dmySlash, time24h
+	case "nl":
+		return "02-01-2006", time24h
+	case "ja", "zh":
+		return "2006/01/02", time24h
+	case "ko":
+		return "2006. 01. 02.", time24h
+	case "hu":
+		return "2006. 01. 02.", time24h
+	default:
+		return isoDate, time24h
+	}
+}
+
+var weekdayNames = map[string][7]string{
+	"de": {"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	"es": {"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	"fr": {"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	"it": {"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+	"ja": {"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+	"nl": {"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+	"pl": {"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+	"pt": {"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+	"sv": {"söndag", "måndag", "tisdag", "onsdag", "torsdag", "fredag", "lördag"},
+	"zh": {"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+}
+
+// weekdaysFor returns translated weekday names, falling back to English
+// for languages without a table.
+func weekdaysFor(tag language.Tag) [7]string {
+	base, _ := tag.Base()
+	if names, ok := weekdayNames[base.String()]; ok {
+		return names
+	}
+	var names [7]string
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		names[d] = d.String()
+	}
+	return names
+}
+
+// Tag returns the language tag; nil reports language.Und.
+func (l *Locale) Tag() language.Tag {
+	if l == nil {
+		return language.Und
+	}
+	return l.tag
+}
+
+// Date formats the calendar date of t.
+func (l *Locale) Date(t time.Time) string {
+	if l == nil {
+		return t.Format(isoDate)
+	}
+	return t.Format(l.dateLayout)
+}
+
+// Time formats the wall-clock time of t (minute precision).
+func (l *Locale) Time(t time.Time) string {
+	if l == nil {
+		return t.Format(time24h)
+	}
+	return t.Format(l.timeLayout)
+}
+
+// DateTime formats t as date and time (minute precision).
+func (l *Locale) DateTime(t time.Time) string {
+	return l.Date(t) + " " + l.Time(t)
+}
+
+// Weekday returns the name of d.
+func (l *Locale) Weekday(d time.Weekday) string {
+	if l == nil || d < time.Sunday || d > time.Saturday {
+		return d.String()
+	}
+	return l.weekdays[d]
+}
+
+// Decimal formats f with prec fractional digits and the locale's
+// separators.
+func (l *Locale) Decimal(f float64, prec int) string {
+	if l == nil {
+		return strconv.FormatFloat(f, 'f', prec, 64)
+	}
+	return l.printer.Sprintf("%.*f", prec, f)
+}
+
+// Int formats n with the locale's digit grouping.
+func (l *Locale) Int(n int64) string {
+	if l == nil {
+		return strconv.FormatInt(n, 10)
+	}
+	return l.printer.Sprintf("%d", n)
+}
+
+type ctxKey struct{}
+
+// WithLocale attaches l to ctx. Attach it only for human output.
+func WithLocale(ctx context.Context, l *Locale) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext returns the attached Locale, or nil for the canonical style.
+func FromContext(ctx context.Context) *Locale {
+	if ctx == nil {
+		return nil
+	}
+	l, _ := ctx.Value(ctxKey{}).(*Locale)
+	return l
+}
@@ -0,0 +1,69 @@
+package locale
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	for _, in := range []string{"", "C", "POSIX", "C.UTF-8"} {
+		l, err := Parse(in)
+		if err != nil || l != nil {
+			t.Fatalf("Parse(%q) = %v, %v; want canonical nil", in, l, err)
+		}
+	}
+	l, err := Parse("de_DE.UTF-8")
+	if err != nil || l.Tag().String() != "de-DE" {
+		t.Fatalf("Parse(de_DE.UTF-8) = %v, %v", l, err)
+	}
+	if _, err := Parse("not a language"); err == nil {
+		t.Fatal("expected error for invalid language")
+	}
+}
+
+func TestLocaleFormats(t *testing.T) {
+	ts := time.Date(2025, 3, 7, 14, 5, 0, 0, time.UTC)
+	cases := []struct {
+		lang     string
+		dateTime string
+		weekday  string
+		decimal  string
+		integer  string
+	}{
+		{"", "2025-03-07 14:05", "Friday", "1234.5", "1234567"},
+		{"en", "03/07/2025 2:05 PM", "Friday", "1,234.5", "1,234,567"},
+		{"en-GB", "07/03/2025 14:05", "Friday", "1,234.5", "1,234,567"},
+		{"de", "07.03.2025 14:05", "Freitag", "1.234,5", "1.234.567"},
+		{"fr-CA", "2025-03-07 14:05", "vendredi", "1 234,5", "1 234 567"},
+		{"ja", "2025/03/07 14:05", "金曜日", "1,234.5", "1,234,567"},
+	}
+	for _, tc := range cases {
+		l, err := Parse(tc.lang)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tc.lang, err)
+		}
+		if got := l.DateTime(ts); got != tc.dateTime {
+			t.Errorf("%q DateTime = %q, want %q", tc.lang, got, tc.dateTime)
+		}
+		if got := l.Weekday(ts.Weekday()); got != tc.weekday {
+			t.Errorf("%q Weekday = %q, want %q", tc.lang, got, tc.weekday)
+		}
+		if got := l.Decimal(1234.5, 1); got != tc.decimal {
+			t.Errorf("%q Decimal = %q, want %q", tc.lang, got, tc.decimal)
+		}
+		if got := l.Int(1234567); got != tc.integer {
+			t.Errorf("%q Int = %q, want %q", tc.lang, got, tc.integer)
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+	if FromContext(context.Background()) != nil {
+		t.Fatal("expected nil locale by default")
+	}
+	l, _ := Parse("nl")
+	if FromContext(WithLocale(context.Background(), l)) != l {
+		t.Fatal("expected attached locale")
+	}
+}
@@ -0,0 +1,323 @@
+// Package redact replaces email addresses and personal names in command
+// output with stable placeholders, so output can be shared publicly while
+// staying readable: the same address always maps to the same placeholder
+// within one run. Opaque IDs are left untouched.
+//
+// Names cannot be spotted in free text, so the Redactor learns them from the
+// API responses a command reads (see Transport) and from JSON name keys, and
+// then masks every later occurrence in human output too.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+const emailPattern = `[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`
+
+var (
+	emailRe = regexp.MustCompile(emailPattern)
+	// namedAddrRe matches RFC 5322 style `Name <addr>` and `"Name" <addr>`.
+	namedAddrRe = regexp.MustCompile(`("[^"\n]*"|\pL[\pL\pM'.\- ]*?)[ ]*<(` + emailPattern + `)>`)
+)
+
+// nameKeys are JSON object keys whose string values are personal names.
+var nameKeys = map[string]struct{}{
+	"displayName":      {},
+	"givenName":        {},
+	"familyName":       {},
+	"middleName":       {},
+	"fullName":         {},
+	"formattedName":    {},
+	"unstructuredName": {},
+}
+
+// Addresses under these domains are system identifiers (calendar and
+// message IDs), not people.
+var keepDomains = []string{
+	"calendar.google.com",
+	"mail.gmail.com",
+}
+
+// Redactor holds the placeholder assignments for one run. It is safe for
+// concurrent use.
+type Redactor struct {
+	mu     sync.Mutex
+	emails map[string]string
+	names  map[string]string
+	// namesRe matches every learned name; rebuilt lazily after names change.
+	namesRe *regexp.Regexp
+}
+
+func New() *Redactor {
+	return &Redactor{emails: map[string]string{}, names: map[string]string{}}
+}
+
+// String masks email addresses, `Name <addr>` pairs, and every name learned
+// so far in s.
+func (r *Redactor) String(s string) string {
+	if strings.Contains(s, "@") {
+		s = namedAddrRe.ReplaceAllStringFunc(s, func(m string) string {
+			sub := namedAddrRe.FindStringSubmatch(m)
+			return r.Name(strings.Trim(sub[1], `"`)) + " <" + r.Email(sub[2]) + ">"
+		})
+		s = emailRe.ReplaceAllStringFunc(s, r.Email)
+	}
+	return r.maskKnownNames(s)
+}
+
+// Email returns the placeholder for an address.
+func (r *Redactor) Email(addr string) string {
+	if isPlaceholderEmail(addr) || keepDomain(addr) {
+		return addr
+	}
+	key := strings.ToLower(addr)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if v, ok := r.emails[key]; ok {
+		return v
+	}
+	v := fmt.Sprintf("user%d@example.com", len(r.emails)+1)
+	r.emails[key] = v
+	return v
+}
+
+// Name returns the placeholder for a personal name.
+func (r *Redactor) Name(name string) string {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if utf8.RuneCountInString(key) < 2 || key == "person" || strings.HasPrefix(key, "person ") {
+		return name
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if v, ok := r.names[key]; ok {
+		return v
+	}
+	v := fmt.Sprintf("Person %d", len(r.names)+1)
+	r.names[key] = v
+	r.namesRe = nil
+	return v
+}
+
+// maskKnownNames replaces whole-word, case-insensitive occurrences of the
+// learned names in s.
+func (r *Redactor) maskKnownNames(s string) string {
+	re := r.knownNames()
+	if re == nil {
+		return s
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringIndex(s, -1) {
+		if !wordBoundary(s, m[0], m[1]) {
+			continue
+		}
+		b.WriteString(s[last:m[0]])
+		b.WriteString(r.Name(s[m[0]:m[1]]))
+		last = m[1]
+	}
+	if last == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// knownNames returns a case-insensitive pattern for the learned names,
+// longest first so "Alice Smith" wins over "Alice".
+func (r *Redactor) knownNames() *regexp.Regexp {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.namesRe != nil || len(r.names) == 0 {
+		return r.namesRe
+	}
+	keys := make([]string, 0, len(r.names))
+	for k := range r.names {
+		keys = append(keys, regexp.QuoteMeta(k))
+	}
+	sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+	r.namesRe = regexp.MustCompile(`(?i)(?:` + strings.Join(keys, "|") + `)`)
+	return r.namesRe
+}
+
+// wordBoundary reports whether s[start:end] is not glued to letters or
+// digits on either side, so "Al" does not match inside "Also".
+func wordBoundary(s string, start, end int) bool {
+	if start > 0 {
+		if c, _ := utf8.DecodeLastRuneInString(s[:start]); isWordRune(c) {
+			return false
+		}
+	}
+	if end < len(s) {
+		if c, _ := utf8.DecodeRuneInString(s[end:]); isWordRune(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRune(c rune) bool {
+	return c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+// Learn records the personal names in a decoded JSON value (values under name
+// keys and the names in `Name <addr>` strings) without changing it, so later
+// text output can mask them.
+func (r *Redactor) Learn(v any) {
+	switch vv := v.(type) {
+	case map[string]any:
+		for k, item := range vv {
+			if s, ok := item.(string); ok {
+				if _, isName := nameKeys[k]; isName && !strings.Contains(s, "@") {
+					r.Name(s)
+					continue
+				}
+			}
+			r.Learn(item)
+		}
+	case []any:
+		for _, item := range vv {
+			r.Learn(item)
+		}
+	case string:
+		if strings.Contains(vv, "<") {
+			for _, sub := range namedAddrRe.FindAllStringSubmatch(vv, -1) {
+				r.Name(strings.Trim(sub[1], `"`))
+			}
+		}
+	}
+}
+
+// maxLearnBody caps how much of a JSON response Transport buffers to learn
+// names from; larger bodies pass through untouched.
+const maxLearnBody = 8 << 20
+
+// Transport wraps base so every JSON API response is scanned with Learn
+// before the caller decodes it. Media downloads are passed through.
+func (r *Redactor) Transport(base http.RoundTripper) http.RoundTripper {
+	return learnTransport{r: r, base: base}
+}
+
+type learnTransport struct {
+	r    *Redactor
+	base http.RoundTripper
+}
+
+func (t learnTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		return resp, err
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "application/json" || resp.ContentLength > maxLearnBody {
+		return resp, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxLearnBody+1))
+	rest := resp.Body
+	if err != nil || len(body) > maxLearnBody {
+		resp.Body = readCloser{io.MultiReader(bytes.NewReader(body), rest), rest}
+		return resp, nil
+	}
+	_ = rest.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	var v any
+	if json.Unmarshal(body, &v) == nil {
+		t.r.Learn(v)
+	}
+	return resp, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// Value masks a decoded JSON value (maps, slices, and scalars as produced by
+// encoding/json). Strings under name keys are replaced as names; all other
+// strings have their email addresses masked.
+func (r *Redactor) Value(v any) any {
+	switch vv := v.(type) {
+	case map[string]any:
+		for k, item := range vv {
+			if s, ok := item.(string); ok {
+				if _, isName := nameKeys[k]; isName && !strings.Contains(s, "@") {
+					vv[k] = r.Name(s)
+					continue
+				}
+			}
+			vv[k] = r.Value(item)
+		}
+		return vv
+	case []any:
+		for i, item := range vv {
+			vv[i] = r.Value(item)
+		}
+		return vv
+	case string:
+		return r.String(vv)
+	default:
+		return v
+	}
+}
+
+// Copy streams src to dst, masking each chunk as it arrives. Chunks are
+// written as soon as they are read so prompts without a trailing newline
+// still show up; only a read that fills the buffer holds back its last
+// partial line, so an address is never split across two reads.
+func (r *Redactor) Copy(dst io.Writer, src io.Reader) error {
+	buf := make([]byte, 64*1024)
+	var pending []byte
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			chunk := append(pending, buf[:n]...)
+			pending = nil
+			if n == len(buf) {
+				if i := bytes.LastIndexByte(chunk, '\n'); i >= 0 && i < len(chunk)-1 {
+					pending = append([]byte(nil), chunk[i+1:]...)
+					chunk = chunk[:i+1]
+				}
+			}
+			if _, werr := io.WriteString(dst, r.String(string(chunk))); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			if len(pending) > 0 {
+				_, werr := io.WriteString(dst, r.String(string(pending)))
+				return werr
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func isPlaceholderEmail(addr string) bool {
+	return strings.HasPrefix(addr, "user") && strings.HasSuffix(addr, "@example.com")
+}
+
+func keepDomain(addr string) bool {
+	at := strings.LastIndexByte(addr, '@')
+	domain := strings.ToLower(addr[at+1:])
+	for _, d := range keepDomains {
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	r := New()
+	got := r.String(`From: "Alice Smith" <alice@corp.com>, bob@corp.com; cc alice@CORP.com`)
+	want := "From: Person 1 <user1@example.com>, user2@example.com; cc user1@example.com"
+	if got != want {
+		t.Fatalf("got %q\nwant %q", got, want)
+	}
+
+	// Placeholders are stable and re-redacting is a no-op.
+	if again := r.String(got); again != want {
+		t.Fatalf("re-redact changed output: %q", again)
+	}
+
+	for _, id := range []string{
+		"c_abc123@group.calendar.google.com",
+		"<CAF=abc@mail.gmail.com>",
+		"1AbCdEfGh_ij",
+	} {
+		if got := r.String(id); got != id {
+			t.Fatalf("expected ID %q to be kept, got %q", id, got)
+		}
+	}
+}
+
+func TestValue(t *testing.T) {
+	var v any
+	if err := json.Unmarshal([]byte(`{
+		"id": "evt1",
+		"organizer": {"email": "alice@corp.com", "displayName": "Alice Smith"},
+		"attendees": [{"email": "bob@corp.com", "displayName": "Bob"}],
+		"summary": "Sync with alice@corp.com",
+		"count": 2
+	}`), &v); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	out, err := json.Marshal(New().Value(v))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	s := string(out)
+	for _, leaked := range []string{"alice", "Alice", "bob", "Bob"} {
+		if strings.Contains(s, leaked) {
+			t.Fatalf("output still contains %q: %s", leaked, s)
+		}
+	}
+	for _, kept := range []string{`"id":"evt1"`, `"count":2`, `"Sync with user1@example.com"`} {
+		if !strings.Contains(s, kept) {
+			t.Fatalf("output missing %s: %s", kept, s)
+		}
+	}
+}
+
+func TestCopy(t *testing.T) {
+	var out bytes.Buffer
+	if err := New().Copy(&out, strings.NewReader("owner\talice@corp.com\nOK? ")); err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+	if got := out.String(); got != "owner\tuser1@example.com\nOK? " {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestLearnedNamesMaskedInText(t *testing.T) {
+	r := New()
+	r.Learn(map[string]any{
+		"names": []any{map[string]any{"displayName": "Alice Smith", "givenName": "Alice"}},
+		"from":  "Bob Jones <bob@corp.com>",
+	})
+
+	got := r.String("ALICE SMITH\t1AbC\nAlice met Bob Jones; Alicent and Also stay\n")
+	for _, leaked := range []string{"ALICE", "Alice ", "Bob Jones"} {
+		if strings.Contains(got, leaked) {
+			t.Fatalf("output still contains %q: %q", leaked, got)
+		}
+	}
+	for _, kept := range []string{"1AbC", "Alicent", "Also"} {
+		if !strings.Contains(got, kept) {
+			t.Fatalf("output missing %q: %q", kept, got)
+		}
+	}
+	if again := r.String(got); again != got {
+		t.Fatalf("re-redact changed output: %q", again)
+	}
+}
+
+func TestTransportLearnsFromJSONResponses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/media" {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = io.WriteString(w, `{"displayName":"Carol"}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		_, _ = io.WriteString(w, `{"owners":[{"displayName":"Dana Scully"}]}`)
+	}))
+	defer srv.Close()
+
+	r := New()
+	client := &http.Client{Transport: r.Transport(http.DefaultTransport)}
+	for _, path := range []string{"/files", "/media"} {
+		resp, err := client.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("get %s: %v", path, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if !strings.Contains(string(body), "displayName") {
+			t.Fatalf("%s: body not passed through: %q", path, body)
+		}
+	}
+
+	if got := r.String("owner Dana Scully, editor Carol"); got != "owner Person 1, editor Carol" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
@@ -0,0 +1,237 @@
+// Package textdiff computes line-based diffs (Myers' algorithm) and renders
+// them as unified diffs or as a list of changed line ranges.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OpKind classifies one line of an edit script.
+type OpKind int
+
+const (
+	Equal OpKind = iota
+	Delete
+	Insert
+)
+
+// Op is one line of the edit script. A and B are zero-based line indexes in
+// the old and new input; the index for the side a line is absent from holds
+// the position it would be inserted at.
+type Op struct {
+	Kind OpKind
+	A    int
+	B    int
+	Text string
+}
+
+// Change is a contiguous run of deleted and/or inserted lines. Line numbers
+// are one-based; a zero count means the range is empty on that side.
+type Change struct {
+	OldStart int      `json:"oldStart"`
+	OldLines int      `json:"oldLines"`
+	NewStart int      `json:"newStart"`
+	NewLines int      `json:"newLines"`
+	Removed  []string `json:"removed,omitempty"`
+	Added    []string `json:"added,omitempty"`
+}
+
+// Lines splits s into lines without their terminators. A trailing newline
+// does not produce an empty final line.
+func Lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.TrimSuffix(s, "\n")
+	return strings.Split(s, "\n")
+}
+
+// Diff returns the shortest edit script turning a into b.
+func Diff(a, b []string) []Op {
+	n, m := len(a), len(b)
+	maxD := n + m
+	if maxD == 0 {
+		return nil
+	}
+
+	// v[k] is the furthest x reached on diagonal k. Each trace entry keeps
+	// only the window of diagonals the backtrack for that depth can read.
+	off := maxD + 1
+	v := make([]int, 2*maxD+3)
+	type snapshot struct {
+		lo     int
+		values []int
+	}
+	var trace []snapshot
+
+search:
+	for d := 0; d <= maxD; d++ {
+		lo := max(-d-1, -maxD-1)
+		hi := min(d+1, maxD+1)
+		trace = append(trace, snapshot{lo: lo, values: append([]int(nil), v[off+lo:off+hi+1]...)})
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+				x = v[off+k+1]
+			} else {
+				x = v[off+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	var ops []Op
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		snap := trace[d]
+		at := func(k int) int { return snap.values[k-snap.lo] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, Op{Kind: Equal, A: x, B: y, Text: a[x]})
+		}
+		if d > 0 {
+			if x == prevX {
+				ops = append(ops, Op{Kind: Insert, A: x, B: prevY, Text: b[prevY]})
+			} else {
+				ops = append(ops, Op{Kind: Delete, A: prevX, B: y, Text: a[prevX]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// Changes groups an edit script into contiguous changed ranges.
+func Changes(ops []Op) []Change {
+	var out []Change
+	for i := 0; i < len(ops); {
+		if ops[i].Kind == Equal {
+			i++
+			continue
+		}
+		c := Change{OldStart: ops[i].A + 1, NewStart: ops[i].B + 1}
+		for ; i < len(ops) && ops[i].Kind != Equal; i++ {
+			if ops[i].Kind == Delete {
+				c.Removed = append(c.Removed, ops[i].Text)
+				c.OldLines++
+			} else {
+				c.Added = append(c.Added, ops[i].Text)
+				c.NewLines++
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// Unified renders ops as a unified diff with the given number of context
+// lines. It returns "" when the inputs are identical.
+func Unified(oldLabel, newLabel string, ops []Op, context int) string {
+	if context < 0 {
+		context = 0
+	}
+
+	var b strings.Builder
+	for start := 0; start < len(ops); {
+		// Find the next change, then extend the hunk while the gap between
+		// changes is small enough to share context.
+		first := start
+		for first < len(ops) && ops[first].Kind == Equal {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		end := first
+		for end < len(ops) {
+			if ops[end].Kind != Equal {
+				end++
+				continue
+			}
+			gap := end
+			for gap < len(ops) && ops[gap].Kind == Equal {
+				gap++
+			}
+			if gap == len(ops) || gap-end > 2*context {
+				break
+			}
+			end = gap
+		}
+
+		lo := max(first-context, start)
+		hi := min(end+context, len(ops))
+		if b.Len() == 0 {
+			fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldLabel, newLabel)
+		}
+		writeHunk(&b, ops[lo:hi])
+		start = hi
+	}
+	return b.String()
+}
+
+func writeHunk(b *strings.Builder, ops []Op) {
+	oldStart, newStart := ops[0].A+1, ops[0].B+1
+	oldLines, newLines := 0, 0
+	for _, op := range ops {
+		if op.Kind != Insert {
+			oldLines++
+		}
+		if op.Kind != Delete {
+			newLines++
+		}
+	}
+	// Unified diff convention: an empty range starts at the line before it.
+	if oldLines == 0 {
+		oldStart--
+	}
+	if newLines == 0 {
+		newStart--
+	}
+	fmt.Fprintf(b, "@@ -%s +%s @@\n", hunkRange(oldStart, oldLines), hunkRange(newStart, newLines))
+	for _, op := range ops {
+		switch op.Kind {
+		case Equal:
+			b.WriteString(" ")
+		case Delete:
+			b.WriteString("-")
+		case Insert:
+			b.WriteString("+")
+		}
+		b.WriteString(op.Text)
+		b.WriteString("\n")
+	}
+}
+
+func hunkRange(start, lines int) string {
+	if lines == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, lines)
+}
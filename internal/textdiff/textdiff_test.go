@@ -0,0 +1,73 @@
+package textdiff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func apply(ops []Op) []string {
+	var out []string
+	for _, op := range ops {
+		if op.Kind != Delete {
+			out = append(out, op.Text)
+		}
+	}
+	return out
+}
+
+func TestDiff_RoundTrip(t *testing.T) {
+	cases := [][2]string{
+		{"", ""},
+		{"", "a\nb\n"},
+		{"a\nb\n", ""},
+		{"a\nb\nc\n", "a\nc\n"},
+		{"a\nb\nc\nd\ne\n", "x\nb\nc\ny\ne\nz\n"},
+		{"same\n", "same\n"},
+	}
+	for _, tc := range cases {
+		a, b := Lines(tc[0]), Lines(tc[1])
+		ops := Diff(a, b)
+		if got := apply(ops); !reflect.DeepEqual(got, b) && (len(got) != 0 || len(b) != 0) {
+			t.Fatalf("diff(%q,%q) rebuilt %q", tc[0], tc[1], got)
+		}
+	}
+}
+
+func TestChanges(t *testing.T) {
+	ops := Diff(Lines("a\nb\nc\nd\n"), Lines("a\nB\nc\nd\ne\n"))
+	got := Changes(ops)
+	want := []Change{
+		{OldStart: 2, OldLines: 1, NewStart: 2, NewLines: 1, Removed: []string{"b"}, Added: []string{"B"}},
+		{OldStart: 5, OldLines: 0, NewStart: 5, NewLines: 1, Added: []string{"e"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected changes:\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	old := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	updated := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n"
+	got := Unified("a/doc", "b/doc", Diff(Lines(old), Lines(updated)), 1)
+	want := strings.Join([]string{
+		"--- a/doc",
+		"+++ b/doc",
+		"@@ -2,3 +2,3 @@",
+		" 2",
+		"-3",
+		"+three",
+		" 4",
+		"@@ -10 +10,2 @@",
+		" 10",
+		"+11",
+		"",
+	}, "\n")
+	if got != want {
+		t.Fatalf("unexpected unified diff:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := Unified("a", "b", Diff(Lines("x\n"), Lines("x\n")), 3); got != "" {
+		t.Fatalf("expected empty diff for identical input, got %q", got)
+	}
+}
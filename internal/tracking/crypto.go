@@ -18,6 +18,8 @@ type PixelPayload struct {
 	Recipient   string `json:"r"`
 	SubjectHash string `json:"s"`
 	SentAt      int64  `json:"t"`
+	// URL is the click-through target for link redirects (empty for pixels).
+	URL string `json:"u,omitempty"`
 }
 
 // Encrypt encrypts a PixelPayload into a URL-safe base64 blob using AES-GCM
@@ -0,0 +1,114 @@
+package tracking
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+const (
+	EventOpen  = "open"
+	EventClick = "click"
+)
+
+// Event is one open or click recorded by the self-hosted endpoint.
+type Event struct {
+	Kind        string `json:"kind"`
+	At          string `json:"at"`
+	TrackingID  string `json:"tracking_id,omitempty"`
+	Recipient   string `json:"recipient"`
+	SubjectHash string `json:"subject_hash"`
+	SentAt      int64  `json:"sent_at"`
+	URL         string `json:"url,omitempty"`
+	UserAgent   string `json:"user_agent,omitempty"`
+}
+
+// EventLogPath is the NDJSON file events for account are appended to.
+func EventLogPath(account string) (string, error) {
+	account = normalizeAccount(account)
+	if account == "" {
+		return "", errMissingAccount
+	}
+
+	dir, err := config.Dir()
+	if err != nil {
+		return "", fmt.Errorf("config dir: %w", err)
+	}
+
+	name := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(account)
+
+	return filepath.Join(dir, "state", "tracking", name+".ndjson"), nil
+}
+
+// EventLog appends events to an NDJSON file; safe for concurrent use.
+type EventLog struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func (l *EventLog) Append(ev Event) error {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0o700); err != nil {
+		return fmt.Errorf("ensure event log dir: %w", err)
+	}
+
+	f, err := os.OpenFile(l.Path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open event log: %w", err)
+	}
+
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write event log: %w", err)
+	}
+
+	return f.Close()
+}
+
+// ReadEvents loads every event from path; a missing file means no events.
+// Malformed lines (e.g. a torn final write) are skipped.
+func ReadEvents(path string) ([]Event, error) {
+	f, err := os.Open(path) //nolint:gosec // path derived from config dir
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("open event log: %w", err)
+	}
+	defer f.Close()
+
+	var events []Event
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for sc.Scan() {
+		var ev Event
+		if json.Unmarshal(sc.Bytes(), &ev) != nil || ev.Kind == "" {
+			continue
+		}
+
+		events = append(events, ev)
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read event log: %w", err)
+	}
+
+	return events, nil
+}
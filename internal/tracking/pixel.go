@@ -10,6 +10,12 @@ import (
 
 // GeneratePixelURL creates a tracking pixel URL for an email
 func GeneratePixelURL(cfg *Config, recipient, subject string) (string, string, error) {
+	return GeneratePixelURLAt(cfg, recipient, subject, time.Now().Unix())
+}
+
+// GeneratePixelURLAt is GeneratePixelURL with an explicit send time, so the
+// pixel and click links of one message share it.
+func GeneratePixelURLAt(cfg *Config, recipient, subject string, sentAt int64) (string, string, error) {
 	if !cfg.IsConfigured() {
 		return "", "", errTrackingNotConfigured
 	}
@@ -20,7 +26,7 @@ func GeneratePixelURL(cfg *Config, recipient, subject string) (string, string, e
 	payload := &PixelPayload{
 		Recipient:   recipient,
 		SubjectHash: subjectHash,
-		SentAt:      time.Now().Unix(),
+		SentAt:      sentAt,
 	}
 
 	blob, err := Encrypt(payload, cfg.TrackingKey)
@@ -33,6 +39,27 @@ func GeneratePixelURL(cfg *Config, recipient, subject string) (string, string, e
 	return pixelURL, blob, nil
 }
 
+// GenerateClickURL wraps target in a redirect through the tracking endpoint.
+// The target travels inside the encrypted blob, so the endpoint cannot be
+// used as an open redirect.
+func GenerateClickURL(cfg *Config, recipient, subject string, sentAt int64, target string) (string, error) {
+	if !cfg.IsConfigured() {
+		return "", errTrackingNotConfigured
+	}
+
+	blob, err := Encrypt(&PixelPayload{
+		Recipient:   recipient,
+		SubjectHash: hashSubject(subject),
+		SentAt:      sentAt,
+		URL:         target,
+	}, cfg.TrackingKey)
+	if err != nil {
+		return "", fmt.Errorf("encrypt payload: %w", err)
+	}
+
+	return fmt.Sprintf("%s/c/%s", cfg.WorkerURL, blob), nil
+}
+
 // GeneratePixelHTML returns HTML img tag for the tracking pixel
 func GeneratePixelHTML(pixelURL string) string {
 	return fmt.Sprintf(
@@ -0,0 +1,76 @@
+package tracking
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// transparentGIF is a 1x1 transparent GIF.
+var transparentGIF = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// Handler serves the self-hosted tracking endpoints: /p/<blob>.gif records
+// an open and returns a pixel, /c/<blob> records a click and redirects to
+// the URL sealed in the blob. Blobs that do not decrypt with trackingKey get
+// a pixel (or 404 for clicks) and are not recorded.
+func Handler(trackingKey string, record func(Event) error, logf func(string, ...any)) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("/p/", func(w http.ResponseWriter, r *http.Request) {
+		blob := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/p/"), ".gif")
+		if payload, err := Decrypt(blob, trackingKey); err == nil {
+			ev := newEvent(EventOpen, payload, r, time.Now())
+			ev.TrackingID = blob
+			recordEvent(record, logf, ev)
+		}
+
+		w.Header().Set("Content-Type", "image/gif")
+		w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate, private")
+		_, _ = w.Write(transparentGIF)
+	})
+	mux.HandleFunc("/c/", func(w http.ResponseWriter, r *http.Request) {
+		payload, err := Decrypt(strings.TrimPrefix(r.URL.Path, "/c/"), trackingKey)
+		if err != nil || !isHTTPURL(payload.URL) {
+			http.NotFound(w, r)
+			return
+		}
+
+		recordEvent(record, logf, newEvent(EventClick, payload, r, time.Now()))
+
+		w.Header().Set("Cache-Control", "no-store")
+		http.Redirect(w, r, payload.URL, http.StatusFound)
+	})
+
+	return mux
+}
+
+func newEvent(kind string, payload *PixelPayload, r *http.Request, at time.Time) Event {
+	return Event{
+		Kind:        kind,
+		At:          at.UTC().Format(time.RFC3339),
+		Recipient:   payload.Recipient,
+		SubjectHash: payload.SubjectHash,
+		SentAt:      payload.SentAt,
+		URL:         payload.URL,
+		UserAgent:   r.UserAgent(),
+	}
+}
+
+func recordEvent(record func(Event) error, logf func(string, ...any), ev Event) {
+	if err := record(ev); err != nil && logf != nil {
+		logf("tracking: record %s: %v", ev.Kind, err)
+	}
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
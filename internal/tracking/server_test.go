@@ -0,0 +1,63 @@
+package tracking
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHandlerRecordsOpensAndClicks(t *testing.T) {
+	key, _ := GenerateKey()
+	cfg := &Config{Enabled: true, WorkerURL: "https://track.example.com", TrackingKey: key}
+	log := &EventLog{Path: filepath.Join(t.TempDir(), "events.ndjson")}
+	srv := httptest.NewServer(Handler(key, log.Append, nil))
+	defer srv.Close()
+
+	pixelURL, blob, err := GeneratePixelURLAt(cfg, "a@b.com", "Hello", 1700000000)
+	if err != nil {
+		t.Fatalf("pixel: %v", err)
+	}
+	clickURL, err := GenerateClickURL(cfg, "a@b.com", "Hello", 1700000000, "https://example.org/x?y=1")
+	if err != nil {
+		t.Fatalf("click: %v", err)
+	}
+
+	resp, err := http.Get(srv.URL + strings.TrimPrefix(pixelURL, cfg.WorkerURL))
+	if err != nil {
+		t.Fatalf("get pixel: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.Header.Get("Content-Type") != "image/gif" {
+		t.Fatalf("unexpected content type %q", resp.Header.Get("Content-Type"))
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err = client.Get(srv.URL + strings.TrimPrefix(clickURL, cfg.WorkerURL))
+	if err != nil {
+		t.Fatalf("get click: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusFound || resp.Header.Get("Location") != "https://example.org/x?y=1" {
+		t.Fatalf("unexpected redirect %d %q", resp.StatusCode, resp.Header.Get("Location"))
+	}
+
+	// Foreign blobs are neither recorded nor redirected.
+	resp, err = client.Get(srv.URL + "/c/not-a-blob")
+	if err != nil {
+		t.Fatalf("get bad click: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown blob, got %d", resp.StatusCode)
+	}
+
+	events, err := ReadEvents(log.Path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(events) != 2 || events[0].Kind != EventOpen || events[0].TrackingID != blob || events[1].Kind != EventClick || events[1].SentAt != 1700000000 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+}
@@ -32,3 +32,19 @@ CREATE INDEX IF NOT EXISTS idx_opens_recipient ON opens(recipient);
 CREATE INDEX IF NOT EXISTS idx_opens_sent_at ON opens(sent_at);
 CREATE INDEX IF NOT EXISTS idx_opens_opened_at ON opens(opened_at);
 CREATE INDEX IF NOT EXISTS idx_opens_recipient_subject ON opens(recipient, subject_hash, sent_at);
+
+-- Link clicks (links rewritten by `gog gmail send --track-clicks`)
+CREATE TABLE IF NOT EXISTS clicks (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  tracking_id TEXT NOT NULL,
+  recipient TEXT NOT NULL,
+  subject_hash TEXT NOT NULL,
+  sent_at TEXT NOT NULL,
+  clicked_at TEXT NOT NULL DEFAULT (datetime('now')),
+  url TEXT NOT NULL,
+  ip TEXT,
+  user_agent TEXT,
+  country TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_clicks_recipient ON clicks(recipient);
@@ -0,0 +1,61 @@
+import { describe, it, expect } from 'vitest';
+import { importKey, encrypt } from './crypto';
+import { clickTarget } from './click';
+import worker from './index';
+import type { Env } from './types';
+
+const testKey = 'MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDE='; // 32 bytes base64
+
+function mockEnv(inserts: unknown[][]): Env {
+  const db = {
+    prepare: (_sql: string) => ({
+      bind: (...args: unknown[]) => ({
+        run: async () => {
+          inserts.push(args);
+          return {};
+        },
+      }),
+    }),
+  };
+  return { DB: db as unknown as D1Database, TRACKING_KEY: testKey, ADMIN_KEY: 'admin' };
+}
+
+describe('clickTarget', () => {
+  it('accepts http(s) targets', () => {
+    expect(clickTarget({ r: 'a@b.com', s: 'abc123', t: 1, u: 'https://example.com/x?y=1' })).toBe('https://example.com/x?y=1');
+  });
+
+  it('rejects missing and non-http targets', () => {
+    expect(clickTarget({ r: 'a@b.com', s: 'abc123', t: 1 })).toBeNull();
+    expect(clickTarget({ r: 'a@b.com', s: 'abc123', t: 1, u: 'javascript:alert(1)' })).toBeNull();
+    expect(clickTarget({ r: 'a@b.com', s: 'abc123', t: 1, u: 'not a url' })).toBeNull();
+  });
+});
+
+describe('click endpoint', () => {
+  it('records the click and redirects to the sealed URL', async () => {
+    const key = await importKey(testKey);
+    const blob = await encrypt({ r: 'a@b.com', s: 'abc123', t: 1704067200, u: 'https://example.com/page' }, key);
+    const inserts: unknown[][] = [];
+
+    const res = await worker.fetch(new Request(`https://t.example/c/${blob}`), mockEnv(inserts));
+
+    expect(res.status).toBe(302);
+    expect(res.headers.get('Location')).toBe('https://example.com/page');
+    expect(inserts).toHaveLength(1);
+    expect(inserts[0][1]).toBe('a@b.com');
+    expect(inserts[0][5]).toBe('https://example.com/page');
+  });
+
+  it('returns 404 for undecryptable blobs and pixel blobs', async () => {
+    const key = await importKey(testKey);
+    const pixelBlob = await encrypt({ r: 'a@b.com', s: 'abc123', t: 1704067200 }, key);
+    const inserts: unknown[][] = [];
+
+    for (const blob of ['invalid', pixelBlob]) {
+      const res = await worker.fetch(new Request(`https://t.example/c/${blob}`), mockEnv(inserts));
+      expect(res.status).toBe(404);
+    }
+    expect(inserts).toHaveLength(0);
+  });
+});
@@ -0,0 +1,32 @@
+import type { PixelPayload } from './types';
+
+// clickTarget returns the http(s) URL sealed in a click payload, or null when
+// the payload carries none (e.g. a pixel blob replayed on /c/).
+export function clickTarget(payload: PixelPayload): string | null {
+  if (!payload.u) {
+    return null;
+  }
+
+  let url: URL;
+  try {
+    url = new URL(payload.u);
+  } catch {
+    return null;
+  }
+
+  if ((url.protocol !== 'http:' && url.protocol !== 'https:') || url.host === '') {
+    return null;
+  }
+
+  return payload.u;
+}
+
+export function redirectResponse(target: string): Response {
+  return new Response(null, {
+    status: 302,
+    headers: {
+      'Location': target,
+      'Cache-Control': 'no-store',
+    },
+  });
+}
@@ -2,6 +2,7 @@ import type { Env, PixelPayload } from './types';
 import { importKey, decrypt } from './crypto';
 import { detectBot } from './bot';
 import { pixelResponse } from './pixel';
+import { clickTarget, redirectResponse } from './click';
 
 export default {
   async fetch(request: Request, env: Env): Promise<Response> {
@@ -14,6 +15,11 @@ export default {
         return await handlePixel(request, env, path);
       }
 
+      // Click endpoint: GET /c/:blob
+      if (path.startsWith('/c/')) {
+        return await handleClick(request, env, path);
+      }
+
       // Query endpoint: GET /q/:blob
       if (path.startsWith('/q/')) {
         return await handleQuery(request, env, path);
@@ -96,6 +102,53 @@ async function handlePixel(request: Request, env: Env, path: string): Promise<Re
   return pixelResponse();
 }
 
+async function handleClick(request: Request, env: Env, path: string): Promise<Response> {
+  // Extract blob from /c/:blob
+  const blob = path.slice(3);
+
+  const key = await importKey(env.TRACKING_KEY);
+  let payload: PixelPayload;
+
+  try {
+    payload = await decrypt(blob, key);
+  } catch {
+    return new Response('Not Found', { status: 404 });
+  }
+
+  // Only redirect to the URL sealed in the blob, so this is not an open redirect.
+  const target = clickTarget(payload);
+  if (!target) {
+    return new Response('Not Found', { status: 404 });
+  }
+
+  const userAgent = request.headers.get('User-Agent') || 'unknown';
+  const cf = (request as any).cf || {};
+
+  try {
+    await env.DB.prepare(`
+      INSERT INTO clicks (
+        tracking_id, recipient, subject_hash, sent_at, clicked_at,
+        url, ip, user_agent, country
+      ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `).bind(
+      blob,
+      payload.r,
+      payload.s,
+      new Date(payload.t * 1000).toISOString(),
+      new Date().toISOString(),
+      target,
+      request.headers.get('CF-Connecting-IP') || 'unknown',
+      userAgent,
+      cf.country || null
+    ).run();
+  } catch (error) {
+    // Never break the link because logging failed.
+    console.error('Failed to record click:', error);
+  }
+
+  return redirectResponse(target);
+}
+
 async function handleQuery(request: Request, env: Env, path: string): Promise<Response> {
   const blob = path.slice(3); // Remove '/q/'
 
@@ -8,6 +8,7 @@ export interface PixelPayload {
   r: string; // recipient
   s: string; // subject hash (first 6 chars)
   t: number; // sent timestamp (unix)
+  u?: string; // click-through target (links only)
 }
 
 export interface OpenRecord {
@@ -1,4 +1,4 @@
-package cmd
+package docsmd
 
 import (
 	"fmt"
@@ -50,7 +50,7 @@ func MarkdownToDocsRequests(elements []MarkdownElement, baseIndex int64) ([]*doc
 			// Add stripped heading text with newline
 			plainText.WriteString(strippedContent)
 			plainText.WriteString("\n")
-			charOffset += utf16Len(strippedContent + "\n")
+			charOffset += UTF16Len(strippedContent + "\n")
 
 			// Apply heading style
 			headingStyle := getHeadingStyle(el.Type)
@@ -84,7 +84,7 @@ func MarkdownToDocsRequests(elements []MarkdownElement, baseIndex int64) ([]*doc
 			// Add code block text (no inline formatting in code blocks)
 			codeContent := el.Content + "\n"
 			plainText.WriteString(codeContent)
-			charOffset += utf16Len(codeContent)
+			charOffset += UTF16Len(codeContent)
 
 			// Apply monospace font to entire code block
 			requests = append(requests, &docs.Request{
@@ -123,24 +123,10 @@ func MarkdownToDocsRequests(elements []MarkdownElement, baseIndex int64) ([]*doc
 			// Add stripped blockquote text
 			plainText.WriteString(strippedContent)
 			plainText.WriteString("\n")
-			charOffset += utf16Len(strippedContent + "\n")
+			charOffset += UTF16Len(strippedContent + "\n")
 
-			// Apply blockquote style (indent)
-			requests = append(requests, &docs.Request{
-				UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
-					Range: &docs.Range{
-						StartIndex: startOffset,
-						EndIndex:   charOffset,
-					},
-					ParagraphStyle: &docs.ParagraphStyle{
-						IndentStart: &docs.Dimension{
-							Magnitude: 36,
-							Unit:      "PT",
-						},
-					},
-					Fields: "indentStart",
-				},
-			})
+			// Apply blockquote style (indent + left border), same as docs sed
+			requests = append(requests, BlockquoteStyleRequest(startOffset, charOffset))
 
 			// Apply inline text styles
 			for _, style := range styles {
@@ -169,11 +155,11 @@ func MarkdownToDocsRequests(elements []MarkdownElement, baseIndex int64) ([]*doc
 			if el.Type == MDNumberedList {
 				prefix = "1. "
 			}
-			prefixLen := utf16Len(prefix)
+			prefixLen := UTF16Len(prefix)
 			plainText.WriteString(prefix)
 			plainText.WriteString(strippedContent)
 			plainText.WriteString("\n")
-			charOffset += prefixLen + utf16Len(strippedContent+"\n")
+			charOffset += prefixLen + UTF16Len(strippedContent+"\n")
 
 			// Apply inline text styles (offset by prefix length)
 			for _, style := range styles {
@@ -188,7 +174,7 @@ func MarkdownToDocsRequests(elements []MarkdownElement, baseIndex int64) ([]*doc
 			separator := strings.Repeat("-", 40)
 			plainText.WriteString(separator)
 			plainText.WriteString("\n")
-			charOffset += utf16Len(separator + "\n")
+			charOffset += UTF16Len(separator + "\n")
 
 		case MDParagraph:
 			// Parse inline formatting for paragraph content
@@ -204,7 +190,7 @@ func MarkdownToDocsRequests(elements []MarkdownElement, baseIndex int64) ([]*doc
 			// Add stripped paragraph text
 			plainText.WriteString(strippedContent)
 			plainText.WriteString("\n")
-			charOffset += utf16Len(strippedContent + "\n")
+			charOffset += UTF16Len(strippedContent + "\n")
 
 			if debugMarkdown {
 				fmt.Printf("  charOffset after: %d, plainText.Len: %d\n", charOffset, plainText.Len())
@@ -226,7 +212,7 @@ func MarkdownToDocsRequests(elements []MarkdownElement, baseIndex int64) ([]*doc
 		case MDEmptyLine:
 			// Add empty line
 			plainText.WriteString("\n")
-			charOffset += utf16Len("\n")
+			charOffset += UTF16Len("\n")
 
 		case MDTable:
 			// Handle markdown table - save for native insertion
@@ -252,7 +238,7 @@ func MarkdownToDocsRequests(elements []MarkdownElement, baseIndex int64) ([]*doc
 
 			// Add a placeholder newline (table will be inserted here)
 			plainText.WriteString("\n")
-			charOffset += utf16Len("\n")
+			charOffset += UTF16Len("\n")
 		}
 	}
 
@@ -285,6 +271,14 @@ func buildTextStyleRequest(style TextStyle, baseOffset int64) *docs.Request {
 		textStyle.Italic = true
 		fields = append(fields, "italic")
 	}
+	if style.Strikethrough {
+		textStyle.Strikethrough = true
+		fields = append(fields, "strikethrough")
+	}
+	if style.Underline {
+		textStyle.Underline = true
+		fields = append(fields, "underline")
+	}
 	if style.Code {
 		textStyle.WeightedFontFamily = &docs.WeightedFontFamily{
 			FontFamily: "Courier New",
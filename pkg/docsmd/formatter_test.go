@@ -1,4 +1,4 @@
-package cmd
+package docsmd
 
 import "testing"
 
@@ -39,3 +39,26 @@ func TestMarkdownToDocsRequests_TableStartIndexUsesBase(t *testing.T) {
 		t.Fatalf("unexpected table start index: %d", tables[0].StartIndex)
 	}
 }
+
+func TestMarkdownToDocsRequests_BlockquoteBorder(t *testing.T) {
+	elements := ParseMarkdown("> quoted *text*\n>\n> more")
+	requests, text, _ := MarkdownToDocsRequests(elements, 1)
+
+	if text != "quoted text\n\nmore\n" {
+		t.Fatalf("unexpected text: %q", text)
+	}
+	var quotes int
+	for _, req := range requests {
+		ps := req.UpdateParagraphStyle
+		if ps == nil {
+			continue
+		}
+		quotes++
+		if ps.Fields != "indentStart,borderLeft" || ps.ParagraphStyle.BorderLeft == nil || ps.ParagraphStyle.IndentStart == nil {
+			t.Fatalf("expected indent and left border, got %#v", ps)
+		}
+	}
+	if quotes != 3 {
+		t.Fatalf("expected 3 blockquote paragraphs, got %d", quotes)
+	}
+}
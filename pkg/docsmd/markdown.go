@@ -1,4 +1,7 @@
-package cmd
+// Package docsmd parses Markdown and turns it into Google Docs batchUpdate
+// requests: the text to insert, the styles to apply, and the tables to build
+// natively. Indices are UTF-16 code units, as the Docs API expects.
+package docsmd
 
 import (
 	"fmt"
@@ -39,6 +42,37 @@ const (
 	MDTable
 )
 
+var markdownElementTypeNames = [...]string{
+	MDText:           "text",
+	MDHeading1:       "heading1",
+	MDHeading2:       "heading2",
+	MDHeading3:       "heading3",
+	MDHeading4:       "heading4",
+	MDHeading5:       "heading5",
+	MDHeading6:       "heading6",
+	MDBold:           "bold",
+	MDItalic:         "italic",
+	MDBoldItalic:     "bolditalic",
+	MDCode:           "code",
+	MDCodeBlock:      "codeblock",
+	MDLink:           "link",
+	MDImage:          "image",
+	MDListItem:       "bullet",
+	MDNumberedList:   "numbered",
+	MDBlockquote:     "blockquote",
+	MDHorizontalRule: "hrule",
+	MDParagraph:      "paragraph",
+	MDEmptyLine:      "empty",
+	MDTable:          "table",
+}
+
+func (t MarkdownElementType) String() string {
+	if t >= 0 && int(t) < len(markdownElementTypeNames) {
+		return markdownElementTypeNames[t]
+	}
+	return fmt.Sprintf("MarkdownElementType(%d)", int(t))
+}
+
 // MarkdownElement represents a parsed markdown element
 type MarkdownElement struct {
 	Type       MarkdownElementType
@@ -51,12 +85,14 @@ type MarkdownElement struct {
 
 // TextStyle represents text formatting
 type TextStyle struct {
-	Bold   bool
-	Italic bool
-	Code   bool
-	Link   string
-	Start  int64
-	End    int64
+	Bold          bool
+	Italic        bool
+	Code          bool
+	Strikethrough bool
+	Underline     bool
+	Link          string
+	Start         int64
+	End           int64
 }
 
 // ParagraphStyle represents paragraph-level formatting
@@ -66,8 +102,8 @@ type ParagraphStyle struct {
 	End   int64
 }
 
-// utf16Len returns the number of UTF-16 code units in a string
-func utf16Len(s string) int64 {
+// UTF16Len returns the number of UTF-16 code units in a string
+func UTF16Len(s string) int64 {
 	return int64(len(utf16.Encode([]rune(s))))
 }
 
@@ -144,9 +180,10 @@ func ParseMarkdown(text string) []MarkdownElement {
 			continue
 		}
 
-		// Blockquote
-		if strings.HasPrefix(line, "> ") {
-			content := strings.TrimPrefix(line, "> ")
+		// Blockquote; a bare ">" is an empty line inside the quote
+		if strings.HasPrefix(line, "> ") || strings.TrimSpace(line) == ">" {
+			content := strings.TrimPrefix(strings.TrimSpace(line), ">")
+			content = strings.TrimPrefix(content, " ")
 			if debugMarkdown {
 				fmt.Printf("[PARSE] Blockquote detected: %q -> %q\n", line, content)
 			}
@@ -327,6 +364,33 @@ func ParseInlineFormatting(text string) ([]TextStyle, string) {
 		})
 	}
 
+	// Find strikethrough ~~text~~ and underline __text__ (outside code spans)
+	for _, p := range []struct {
+		re  *regexp.Regexp
+		typ string
+	}{
+		{regexp.MustCompile(`~~([^~]+)~~`), "strike"},
+		{regexp.MustCompile(`__([^_]+)__`), "underline"},
+	} {
+		for _, idx := range p.re.FindAllStringSubmatchIndex(text, -1) {
+			overlaps := false
+			for _, m := range matches {
+				if idx[0] < m.End && idx[1] > m.Start {
+					overlaps = true
+					break
+				}
+			}
+			if !overlaps {
+				matches = append(matches, InlineMatch{
+					Start:   idx[0],
+					End:     idx[1],
+					Content: text[idx[2]:idx[3]],
+					Type:    p.typ,
+				})
+			}
+		}
+	}
+
 	// Find bold-italic ***text***
 	biRegex := regexp.MustCompile(`\*\*\*([^*]+)\*\*\*`)
 	for _, idx := range biRegex.FindAllStringSubmatchIndex(text, -1) {
@@ -420,7 +484,7 @@ func ParseInlineFormatting(text string) ([]TextStyle, string) {
 			if m.Start == currentByte {
 				positionMap[currentByte] = strippedUTF16Len
 				stripped.WriteString(m.Content)
-				strippedUTF16Len += utf16Len(m.Content)
+				strippedUTF16Len += UTF16Len(m.Content)
 				currentByte = m.End
 				matchFound = true
 				break
@@ -431,7 +495,7 @@ func ParseInlineFormatting(text string) ([]TextStyle, string) {
 			positionMap[currentByte] = strippedUTF16Len
 			char, size := nextRune(text[currentByte:])
 			stripped.WriteString(char)
-			strippedUTF16Len += utf16Len(char)
+			strippedUTF16Len += UTF16Len(char)
 			currentByte += size
 		}
 	}
@@ -443,12 +507,14 @@ func ParseInlineFormatting(text string) ([]TextStyle, string) {
 	styles := make([]TextStyle, 0, len(matches))
 	for _, m := range matches {
 		styles = append(styles, TextStyle{
-			Start:  positionMap[m.Start],
-			End:    positionMap[m.End],
-			Bold:   m.Type == fmtBold || m.Type == fmtBoldItalic,
-			Italic: m.Type == "italic" || m.Type == fmtBoldItalic,
-			Code:   m.Type == inlineTypeCode,
-			Link:   m.URL,
+			Start:         positionMap[m.Start],
+			End:           positionMap[m.End],
+			Bold:          m.Type == fmtBold || m.Type == fmtBoldItalic,
+			Italic:        m.Type == "italic" || m.Type == fmtBoldItalic,
+			Code:          m.Type == inlineTypeCode,
+			Strikethrough: m.Type == "strike",
+			Underline:     m.Type == "underline",
+			Link:          m.URL,
 		})
 	}
 
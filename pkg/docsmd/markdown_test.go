@@ -1,4 +1,4 @@
-package cmd
+package docsmd
 
 import (
 	"testing"
@@ -45,6 +45,11 @@ func TestParseMarkdown(t *testing.T) {
 			input:    "> This is a quote",
 			expected: []MarkdownElementType{MDBlockquote},
 		},
+		{
+			name:     "multi-line blockquote",
+			input:    "> first\n>\n> second",
+			expected: []MarkdownElementType{MDBlockquote, MDBlockquote, MDBlockquote},
+		},
 		{
 			name:     "mixed content",
 			input:    "# Title\n\nParagraph here\n\n- List item",
@@ -99,6 +104,24 @@ func TestParseInlineFormatting(t *testing.T) {
 			expectedText:  "Check this link",
 			expectedCount: 1,
 		},
+		{
+			name:          "strikethrough",
+			input:         "This is ~~gone~~ text",
+			expectedText:  "This is gone text",
+			expectedCount: 1,
+		},
+		{
+			name:          "underline",
+			input:         "This is __key__ text",
+			expectedText:  "This is key text",
+			expectedCount: 1,
+		},
+		{
+			name:          "markers inside code stay literal",
+			input:         "Run `a __b__ ~~c~~`",
+			expectedText:  "Run a __b__ ~~c~~",
+			expectedCount: 1,
+		},
 		{
 			name:          "no formatting",
 			input:         "Just plain text",
@@ -120,6 +143,38 @@ func TestParseInlineFormatting(t *testing.T) {
 	}
 }
 
+func TestParseInlineFormatting_StrikeUnderlineCode(t *testing.T) {
+	styles, text := ParseInlineFormatting("a ~~b~~ __c__ `d`")
+	if text != "a b c d" {
+		t.Fatalf("unexpected text %q", text)
+	}
+	if len(styles) != 3 {
+		t.Fatalf("expected 3 styles, got %#v", styles)
+	}
+	if s := styles[0]; !s.Strikethrough || s.Start != 2 || s.End != 3 {
+		t.Fatalf("unexpected strike style: %#v", s)
+	}
+	if s := styles[1]; !s.Underline || s.Start != 4 || s.End != 5 {
+		t.Fatalf("unexpected underline style: %#v", s)
+	}
+	if s := styles[2]; !s.Code || s.Start != 6 || s.End != 7 {
+		t.Fatalf("unexpected code style: %#v", s)
+	}
+
+	req := buildTextStyleRequest(styles[0], 10)
+	if req == nil || req.UpdateTextStyle.Fields != "strikethrough" || !req.UpdateTextStyle.TextStyle.Strikethrough || req.UpdateTextStyle.Range.StartIndex != 12 {
+		t.Fatalf("unexpected strike request: %#v", req)
+	}
+	req = buildTextStyleRequest(styles[1], 0)
+	if req == nil || req.UpdateTextStyle.Fields != "underline" || !req.UpdateTextStyle.TextStyle.Underline {
+		t.Fatalf("unexpected underline request: %#v", req)
+	}
+	req = buildTextStyleRequest(styles[2], 0)
+	if req == nil || req.UpdateTextStyle.Fields != "weightedFontFamily" || req.UpdateTextStyle.TextStyle.WeightedFontFamily.FontFamily != "Courier New" {
+		t.Fatalf("unexpected code request: %#v", req)
+	}
+}
+
 func TestParseHeading(t *testing.T) {
 	tests := []struct {
 		line            string
@@ -0,0 +1,40 @@
+package docsmd
+
+import "google.golang.org/api/docs/v1"
+
+// BorderGrey is the grey intensity for borders (blockquotes, horizontal rules).
+const BorderGrey = 0.8
+
+// blockquoteBorderWidthPt is the border width in points for blockquotes.
+const blockquoteBorderWidthPt = 3.0
+
+// blockquoteIndentPt is the left indent in points for blockquotes.
+const blockquoteIndentPt = 36.0
+
+// blockquotePaddingPt is the left padding in points for blockquotes.
+const blockquotePaddingPt = 12.0
+
+// BlockquoteStyleRequest returns an UpdateParagraphStyle request that
+// indents the paragraphs in [start, end) and draws a grey left border.
+func BlockquoteStyleRequest(start, end int64) *docs.Request {
+	return &docs.Request{
+		UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
+			Range: &docs.Range{StartIndex: start, EndIndex: end},
+			ParagraphStyle: &docs.ParagraphStyle{
+				IndentStart: &docs.Dimension{Magnitude: blockquoteIndentPt, Unit: "PT"},
+				BorderLeft: &docs.ParagraphBorder{
+					Color:     GreyColor(BorderGrey),
+					Width:     &docs.Dimension{Magnitude: blockquoteBorderWidthPt, Unit: "PT"},
+					DashStyle: "SOLID",
+					Padding:   &docs.Dimension{Magnitude: blockquotePaddingPt, Unit: "PT"},
+				},
+			},
+			Fields: "indentStart,borderLeft",
+		},
+	}
+}
+
+// GreyColor returns an OptionalColor with the given greyscale intensity (0.0=black, 1.0=white).
+func GreyColor(intensity float64) *docs.OptionalColor {
+	return &docs.OptionalColor{Color: &docs.Color{RgbColor: &docs.RgbColor{Red: intensity, Green: intensity, Blue: intensity}}}
+}
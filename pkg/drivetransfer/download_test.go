@@ -0,0 +1,94 @@
+package drivetransfer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+)
+
+func TestDownloadResumesWithFetchHook(t *testing.T) {
+	body := "hello, resumable world"
+	sum := sha256.Sum256([]byte(body))
+	meta := &drive.File{Id: "id1", Name: "f.bin", Size: int64(len(body)), Sha256Checksum: hex.EncodeToString(sum[:])}
+	dest := filepath.Join(t.TempDir(), "sub", "f.bin")
+	if err := os.MkdirAll(filepath.Dir(dest), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dest+PartialSuffix, []byte(body[:5]), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var offsets []int64
+	var resumedAt int64
+	path, n, err := Download(context.Background(), nil, meta, dest, Options{
+		Fetch: func(_ context.Context, _ *drive.Service, fileID string, offset int64) (*http.Response, error) {
+			offsets = append(offsets, offset)
+			return &http.Response{StatusCode: http.StatusPartialContent, Body: io.NopCloser(strings.NewReader(body[offset:]))}, nil
+		},
+		OnResume: func(offset int64) { resumedAt = offset },
+	})
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if path != dest || n != int64(len(body)) || resumedAt != 5 || len(offsets) != 1 || offsets[0] != 5 {
+		t.Fatalf("path=%q n=%d resumedAt=%d offsets=%v", path, n, resumedAt, offsets)
+	}
+	if got, _ := os.ReadFile(dest); string(got) != body {
+		t.Fatalf("content %q", got)
+	}
+}
+
+func TestDownloadRestartsWhenRangeRejected(t *testing.T) {
+	body := "fresh"
+	meta := &drive.File{Id: "id1", Name: "f.bin"}
+	dest := filepath.Join(t.TempDir(), "f.bin")
+	if err := os.WriteFile(dest+PartialSuffix, []byte("stale partial"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var offsets []int64
+	_, _, err := Download(context.Background(), nil, meta, dest, Options{
+		Fetch: func(_ context.Context, _ *drive.Service, _ string, offset int64) (*http.Response, error) {
+			offsets = append(offsets, offset)
+			if offset > 0 {
+				return nil, &gapi.Error{Code: http.StatusRequestedRangeNotSatisfiable}
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if len(offsets) != 2 || offsets[1] != 0 {
+		t.Fatalf("unexpected fetches %v", offsets)
+	}
+	if got, _ := os.ReadFile(dest); string(got) != body {
+		t.Fatalf("content %q", got)
+	}
+}
+
+func TestVerifyMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "f")
+	if err := os.WriteFile(path, []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err := Verify(path, &drive.File{Name: "f", Md5Checksum: "00"})
+	if err == nil || !strings.Contains(err.Error(), "md5 checksum mismatch") {
+		t.Fatalf("expected mismatch, got %v", err)
+	}
+	if err := Verify(path, &drive.File{Name: "f"}); err != nil {
+		t.Fatalf("no checksum should pass: %v", err)
+	}
+	if h, _, _ := ChecksumHash(&drive.File{}); h != nil {
+		t.Fatal("expected nil hash without checksums")
+	}
+}
@@ -0,0 +1,113 @@
+package drivetransfer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+)
+
+// DefaultUploadFields are the file fields Upload and Replace return when
+// UploadOptions.Fields is empty.
+const DefaultUploadFields = "id, name, mimeType, size, webViewLink"
+
+// UploadOptions customizes Upload and Replace. The zero value uploads
+// resumably in googleapi.DefaultUploadChunkSize chunks.
+type UploadOptions struct {
+	// MimeType of the content; empty lets the client detect it.
+	MimeType string
+	// ChunkSize is the resumable chunk size in bytes. 0 uses the default;
+	// a negative value sends the content in a single request.
+	ChunkSize int
+	// ChunkRetry bounds how long a failed chunk is retried; 0 keeps the
+	// client default.
+	ChunkRetry time.Duration
+	// KeepRevisionForever pins the uploaded revision.
+	KeepRevisionForever bool
+	// Fields selects the returned file fields.
+	Fields string
+	// OnProgress is called after each uploaded chunk with the bytes sent so
+	// far and the total (0 when unknown).
+	OnProgress func(current, total int64)
+}
+
+// MediaOptions returns the media options for a Files.Create or
+// Files.Update call. Content that fits in one chunk is still sent in a
+// single request.
+func (o UploadOptions) MediaOptions() []gapi.MediaOption {
+	var media []gapi.MediaOption
+	if o.MimeType != "" {
+		media = append(media, gapi.ContentType(o.MimeType))
+	}
+	switch {
+	case o.ChunkSize < 0:
+		media = append(media, gapi.ChunkSize(0))
+	case o.ChunkSize > 0:
+		media = append(media, gapi.ChunkSize(o.ChunkSize))
+	}
+	if o.ChunkRetry > 0 {
+		media = append(media, gapi.ChunkRetryDeadline(o.ChunkRetry))
+	}
+	return media
+}
+
+func (o UploadOptions) fields() gapi.Field {
+	if o.Fields == "" {
+		return DefaultUploadFields
+	}
+	return gapi.Field(o.Fields)
+}
+
+// Upload creates a Drive file described by meta (name, parents, target
+// mimeType for conversions) with content.
+func Upload(ctx context.Context, svc *drive.Service, meta *drive.File, content io.Reader, opts UploadOptions) (*drive.File, error) {
+	call := svc.Files.Create(meta).
+		SupportsAllDrives(true).
+		Media(content, opts.MediaOptions()...).
+		Fields(opts.fields()).
+		Context(ctx)
+	if opts.KeepRevisionForever {
+		call = call.KeepRevisionForever(true)
+	}
+	if opts.OnProgress != nil {
+		call = call.ProgressUpdater(opts.OnProgress)
+	}
+	return call.Do()
+}
+
+// Replace uploads content as a new revision of fileID, keeping its ID and
+// sharing; meta may rename the file. Google Workspace files (Docs, Sheets,
+// ...) have no binary content to replace and are rejected.
+func Replace(ctx context.Context, svc *drive.Service, fileID string, meta *drive.File, content io.Reader, opts UploadOptions) (*drive.File, error) {
+	existing, err := svc.Files.Get(fileID).
+		SupportsAllDrives(true).
+		Fields("id, mimeType").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(existing.MimeType, "application/vnd.google-apps.") {
+		return nil, fmt.Errorf("cannot replace content for Google Workspace files (mimeType=%s)", existing.MimeType)
+	}
+	if meta == nil {
+		meta = &drive.File{}
+	}
+
+	call := svc.Files.Update(fileID, meta).
+		SupportsAllDrives(true).
+		Media(content, opts.MediaOptions()...).
+		Fields(opts.fields()).
+		Context(ctx)
+	if opts.KeepRevisionForever {
+		call = call.KeepRevisionForever(true)
+	}
+	if opts.OnProgress != nil {
+		call = call.ProgressUpdater(opts.OnProgress)
+	}
+	return call.Do()
+}
@@ -0,0 +1,84 @@
+package drivetransfer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+func newTestDriveService(t *testing.T, h http.HandlerFunc) *drive.Service {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	svc, err := drive.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	return svc
+}
+
+func TestUploadSingleRequestAndProgress(t *testing.T) {
+	var uploadType, body string
+	svc := newTestDriveService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/upload/drive/v3/files" {
+			http.NotFound(w, r)
+			return
+		}
+		uploadType = r.URL.Query().Get("uploadType")
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "f1", "name": "a.txt"})
+	})
+
+	var progress bool
+	created, err := Upload(context.Background(), svc, &drive.File{Name: "a.txt"}, strings.NewReader("hello"), UploadOptions{
+		MimeType:   "text/plain",
+		ChunkSize:  -1,
+		OnProgress: func(int64, int64) { progress = true },
+	})
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	if created.Id != "f1" || uploadType != "multipart" || !strings.Contains(body, "hello") {
+		t.Fatalf("created=%#v uploadType=%q body=%q", created, uploadType, body)
+	}
+	if progress {
+		t.Fatal("single-request uploads should not report chunk progress")
+	}
+}
+
+func TestReplaceRejectsWorkspaceFiles(t *testing.T) {
+	updated := false
+	svc := newTestDriveService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files/doc1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "doc1", "mimeType": "application/vnd.google-apps.document"})
+		case r.Method == http.MethodPatch:
+			updated = true
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "doc1"})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	_, err := Replace(context.Background(), svc, "doc1", nil, strings.NewReader("x"), UploadOptions{})
+	if err == nil || !strings.Contains(err.Error(), "Google Workspace") {
+		t.Fatalf("expected Workspace error, got %v", err)
+	}
+	if updated {
+		t.Fatal("Workspace file must not be updated")
+	}
+}
@@ -1,4 +1,7 @@
-package cmd
+// Package gmailmime builds RFC 822 messages for the Gmail API: address and
+// subject encoding, plain/HTML alternatives, and base64 attachments. The
+// output is ready to base64url-encode into gmail.Message.Raw.
+package gmailmime
 
 import (
 	"bytes"
@@ -15,18 +18,23 @@ import (
 	"time"
 )
 
-type mailAttachment struct {
+// Attachment is a file attached to a Message. Data wins over Path; Filename
+// defaults to the base of Path and MIMEType to a guess from the extension.
+type Attachment struct {
 	Path     string
 	Filename string
 	MIMEType string
 	Data     []byte
 }
 
-type rfc822Config struct {
-	allowMissingTo bool
+// BuildOptions relaxes Build's validation. AllowMissingTo is for drafts.
+type BuildOptions struct {
+	AllowMissingTo bool
 }
 
-type mailOptions struct {
+// Message describes the headers, bodies, and attachments of one email.
+// Body and BodyHTML together produce a multipart/alternative body.
+type Message struct {
 	From              string
 	To                []string
 	Cc                []string
@@ -38,11 +46,13 @@ type mailOptions struct {
 	InReplyTo         string
 	References        string
 	AdditionalHeaders map[string]string
-	Attachments       []mailAttachment
+	Attachments       []Attachment
 }
 
-func buildRFC822(opts mailOptions, cfg *rfc822Config) ([]byte, error) {
-	allowMissingTo := cfg != nil && cfg.allowMissingTo
+// Build renders opts with CRLF line endings, adding Date, Message-ID, and
+// MIME-Version headers. cfg may be nil.
+func Build(opts Message, cfg *BuildOptions) ([]byte, error) {
+	allowMissingTo := cfg != nil && cfg.AllowMissingTo
 
 	if strings.TrimSpace(opts.From) == "" {
 		return nil, errors.New("missing From")
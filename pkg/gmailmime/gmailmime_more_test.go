@@ -1,4 +1,4 @@
-package cmd
+package gmailmime
 
 import (
 	"os"
@@ -8,25 +8,25 @@ import (
 )
 
 func TestBuildRFC822_MissingFields(t *testing.T) {
-	if _, err := buildRFC822(mailOptions{To: []string{"c@d.com"}, Subject: "Hi"}, nil); err == nil {
+	if _, err := Build(Message{To: []string{"c@d.com"}, Subject: "Hi"}, nil); err == nil {
 		t.Fatalf("expected missing From error")
 	}
-	if _, err := buildRFC822(mailOptions{From: "a@b.com", Subject: "Hi"}, nil); err == nil {
+	if _, err := Build(Message{From: "a@b.com", Subject: "Hi"}, nil); err == nil {
 		t.Fatalf("expected missing To error")
 	}
-	if _, err := buildRFC822(mailOptions{From: "a@b.com", To: []string{"c@d.com"}}, nil); err == nil {
+	if _, err := Build(Message{From: "a@b.com", To: []string{"c@d.com"}}, nil); err == nil {
 		t.Fatalf("expected missing Subject error")
 	}
 }
 
 func TestBuildRFC822_AllowMissingTo(t *testing.T) {
-	raw, err := buildRFC822(mailOptions{
+	raw, err := Build(Message{
 		From:    "a@b.com",
 		Subject: "Hi",
 		Body:    "Hello",
-	}, &rfc822Config{allowMissingTo: true})
+	}, &BuildOptions{AllowMissingTo: true})
 	if err != nil {
-		t.Fatalf("buildRFC822: %v", err)
+		t.Fatalf("Build: %v", err)
 	}
 	if strings.Contains(string(raw), "\r\nTo:") {
 		t.Fatalf("expected no To header")
@@ -34,21 +34,21 @@ func TestBuildRFC822_AllowMissingTo(t *testing.T) {
 }
 
 func TestBuildRFC822_InvalidHeaders(t *testing.T) {
-	if _, err := buildRFC822(mailOptions{
+	if _, err := Build(Message{
 		From:    "a@b.com\r\nBcc: evil@evil.com",
 		To:      []string{"c@d.com"},
 		Subject: "Hi",
 	}, nil); err == nil {
 		t.Fatalf("expected invalid From error")
 	}
-	if _, err := buildRFC822(mailOptions{
+	if _, err := Build(Message{
 		From:    "a@b.com",
 		To:      []string{"c@d.com\r\n"},
 		Subject: "Hi",
 	}, nil); err == nil {
 		t.Fatalf("expected invalid address error")
 	}
-	if _, err := buildRFC822(mailOptions{
+	if _, err := Build(Message{
 		From:      "a@b.com",
 		To:        []string{"c@d.com"},
 		Subject:   "Hi",
@@ -58,7 +58,7 @@ func TestBuildRFC822_InvalidHeaders(t *testing.T) {
 	}, nil); err == nil {
 		t.Fatalf("expected invalid Reply-To error")
 	}
-	if _, err := buildRFC822(mailOptions{
+	if _, err := Build(Message{
 		From:       "a@b.com",
 		To:         []string{"c@d.com"},
 		Subject:    "Hi",
@@ -67,7 +67,7 @@ func TestBuildRFC822_InvalidHeaders(t *testing.T) {
 	}, nil); err == nil {
 		t.Fatalf("expected invalid References error")
 	}
-	if _, err := buildRFC822(mailOptions{
+	if _, err := Build(Message{
 		From:    "a@b.com",
 		To:      []string{"c@d.com"},
 		Subject: "Hi\r\n",
@@ -75,7 +75,7 @@ func TestBuildRFC822_InvalidHeaders(t *testing.T) {
 	}, nil); err == nil {
 		t.Fatalf("expected invalid Subject error")
 	}
-	if _, err := buildRFC822(mailOptions{
+	if _, err := Build(Message{
 		From:    "a@b.com",
 		To:      []string{"c@d.com"},
 		Subject: "Hi",
@@ -93,17 +93,17 @@ func TestBuildRFC822_AttachmentFromPath_DefaultMime(t *testing.T) {
 	if err := os.WriteFile(path, []byte("data"), 0o600); err != nil {
 		t.Fatalf("WriteFile: %v", err)
 	}
-	raw, err := buildRFC822(mailOptions{
+	raw, err := Build(Message{
 		From:    "a@b.com",
 		To:      []string{"c@d.com"},
 		Subject: "Hi",
 		Body:    "Hello",
-		Attachments: []mailAttachment{
+		Attachments: []Attachment{
 			{Path: path},
 		},
 	}, nil)
 	if err != nil {
-		t.Fatalf("buildRFC822: %v", err)
+		t.Fatalf("Build: %v", err)
 	}
 	s := string(raw)
 	if !strings.Contains(s, "application/octet-stream") {
@@ -1,4 +1,4 @@
-package cmd
+package gmailmime
 
 import (
 	"regexp"
@@ -7,7 +7,7 @@ import (
 )
 
 func TestBuildRFC822Plain(t *testing.T) {
-	raw, err := buildRFC822(mailOptions{
+	raw, err := Build(Message{
 		From:    "a@b.com",
 		To:      []string{"c@d.com"},
 		Subject: "Hi",
@@ -29,7 +29,7 @@ func TestBuildRFC822Plain(t *testing.T) {
 }
 
 func TestBuildRFC822HTMLOnly(t *testing.T) {
-	raw, err := buildRFC822(mailOptions{
+	raw, err := Build(Message{
 		From:     "a@b.com",
 		To:       []string{"c@d.com"},
 		Subject:  "Hi",
@@ -51,7 +51,7 @@ func TestBuildRFC822HTMLOnly(t *testing.T) {
 }
 
 func TestBuildRFC822PlainAndHTMLAlternative(t *testing.T) {
-	raw, err := buildRFC822(mailOptions{
+	raw, err := Build(Message{
 		From:     "a@b.com",
 		To:       []string{"c@d.com"},
 		Subject:  "Hi",
@@ -74,12 +74,12 @@ func TestBuildRFC822PlainAndHTMLAlternative(t *testing.T) {
 }
 
 func TestBuildRFC822WithAttachment(t *testing.T) {
-	raw, err := buildRFC822(mailOptions{
+	raw, err := Build(Message{
 		From:    "a@b.com",
 		To:      []string{"c@d.com"},
 		Subject: "Hi",
 		Body:    "Hello",
-		Attachments: []mailAttachment{
+		Attachments: []Attachment{
 			{Filename: "x.txt", MIMEType: "text/plain", Data: []byte("abc")},
 		},
 	}, nil)
@@ -96,13 +96,13 @@ func TestBuildRFC822WithAttachment(t *testing.T) {
 }
 
 func TestBuildRFC822AlternativeWithAttachment(t *testing.T) {
-	raw, err := buildRFC822(mailOptions{
+	raw, err := Build(Message{
 		From:     "a@b.com",
 		To:       []string{"c@d.com"},
 		Subject:  "Hi",
 		Body:     "Plain",
 		BodyHTML: "<p>HTML</p>",
-		Attachments: []mailAttachment{
+		Attachments: []Attachment{
 			{Filename: "x.txt", MIMEType: "text/plain", Data: []byte("abc")},
 		},
 	}, nil)
@@ -125,7 +125,7 @@ func TestBuildRFC822AlternativeWithAttachment(t *testing.T) {
 }
 
 func TestBuildRFC822UTF8Subject(t *testing.T) {
-	raw, err := buildRFC822(mailOptions{
+	raw, err := Build(Message{
 		From:    "a@b.com",
 		To:      []string{"c@d.com"},
 		Subject: "Grüße",
@@ -141,7 +141,7 @@ func TestBuildRFC822UTF8Subject(t *testing.T) {
 }
 
 func TestBuildRFC822UTF8FromDisplayName(t *testing.T) {
-	raw, err := buildRFC822(mailOptions{
+	raw, err := Build(Message{
 		From:    "Sérgio Bastos • Importrust <alias@domain.com>",
 		To:      []string{"c@d.com"},
 		Subject: "Hi",
@@ -163,7 +163,7 @@ func TestBuildRFC822UTF8FromDisplayName(t *testing.T) {
 }
 
 func TestBuildRFC822PlainFromAddressStaysUnwrapped(t *testing.T) {
-	raw, err := buildRFC822(mailOptions{
+	raw, err := Build(Message{
 		From:    "a@b.com",
 		To:      []string{"c@d.com"},
 		Subject: "Hi",
@@ -182,7 +182,7 @@ func TestBuildRFC822PlainFromAddressStaysUnwrapped(t *testing.T) {
 }
 
 func TestBuildRFC822ReplyToHeader(t *testing.T) {
-	raw, err := buildRFC822(mailOptions{
+	raw, err := Build(Message{
 		From:    "a@b.com",
 		To:      []string{"c@d.com"},
 		ReplyTo: "reply@example.com",
@@ -199,7 +199,7 @@ func TestBuildRFC822ReplyToHeader(t *testing.T) {
 }
 
 func TestBuildRFC822AdditionalHeadersMessageIDIsNotDuplicated(t *testing.T) {
-	raw, err := buildRFC822(mailOptions{
+	raw, err := Build(Message{
 		From:    "a@b.com",
 		To:      []string{"c@d.com"},
 		Subject: "Hi",
@@ -221,7 +221,7 @@ func TestBuildRFC822AdditionalHeadersMessageIDIsNotDuplicated(t *testing.T) {
 }
 
 func TestBuildRFC822ReplyToRejectsNewlines(t *testing.T) {
-	_, err := buildRFC822(mailOptions{
+	_, err := Build(Message{
 		From:    "a@b.com",
 		To:      []string{"c@d.com"},
 		ReplyTo: "a@b.com\r\nBcc: evil@evil.com",
@@ -1,3 +1,6 @@
+// Package outfmt carries gog's output mode (--json / --plain) through a
+// context and writes JSON the way the CLI does, including --results-only and
+// --select projections.
 package outfmt
 
 import (
@@ -60,6 +63,9 @@ type JSONTransform struct {
 	// Select projects objects to only the requested fields (comma-separated; supports dot paths).
 	// When applied to a list, it projects each element.
 	Select []string
+	// Redact, when set, rewrites the decoded JSON value (maps, slices, and
+	// scalars as produced by encoding/json) last, e.g. to mask personal data.
+	Redact func(any) any
 }
 
 type jsonTransformKey struct{}
@@ -80,7 +86,7 @@ func JSONTransformFromContext(ctx context.Context) (JSONTransform, bool) {
 }
 
 func WriteJSON(ctx context.Context, w io.Writer, v any) error {
-	if t, ok := JSONTransformFromContext(ctx); ok && (t.ResultsOnly || len(t.Select) > 0) {
+	if t, ok := JSONTransformFromContext(ctx); ok && (t.ResultsOnly || len(t.Select) > 0 || t.Redact != nil) {
 		transformed, err := applyJSONTransform(v, t)
 		if err != nil {
 			return fmt.Errorf("transform json: %w", err)
@@ -119,6 +125,10 @@ func applyJSONTransform(v any, t JSONTransform) (any, error) {
 		anyV = selectFields(anyV, t.Select)
 	}
 
+	if t.Redact != nil {
+		anyV = t.Redact(anyV)
+	}
+
 	return anyV, nil
 }
 
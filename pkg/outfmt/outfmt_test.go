@@ -81,6 +81,36 @@ func TestWriteJSON_ResultsOnlyAndSelect(t *testing.T) {
 	}
 }
 
+func TestWriteJSON_Redact(t *testing.T) {
+	ctx := WithJSONTransform(context.Background(), JSONTransform{Redact: func(v any) any {
+		m, ok := v.(map[string]any)
+		if !ok {
+			t.Fatalf("expected generic JSON object, got %T", v)
+		}
+		m["owner"] = "redacted"
+		return m
+	}})
+
+	type owner struct {
+		Email string `json:"emailAddress"`
+	}
+	var buf bytes.Buffer
+	if err := WriteJSON(ctx, &buf, struct {
+		ID    string `json:"id"`
+		Owner owner  `json:"owner"`
+	}{ID: "abc", Owner: owner{Email: "alice@corp.com"}}); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v (out=%q)", err, buf.String())
+	}
+	if got["id"] != "abc" || got["owner"] != "redacted" {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+}
+
 func TestFromEnvAndParseError(t *testing.T) {
 	t.Setenv("GOG_JSON", "yes")
 	t.Setenv("GOG_PLAIN", "0")